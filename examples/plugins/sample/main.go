@@ -0,0 +1,95 @@
+// Command sample is an example pvetui plugin. It adds a guest menu action,
+// a details section and a guest list column without talking to the API.
+//
+// Build it and add it to the config:
+//
+//	go build -o ~/.local/bin/pvetui-plugin-sample ./examples/plugins/sample
+//
+//	plugins:
+//	  - path: ~/.local/bin/pvetui-plugin-sample
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/plugin"
+)
+
+type sample struct{}
+
+func (sample) Describe() plugin.Manifest {
+	return plugin.Manifest{
+		Name:     "sample",
+		Actions:  []plugin.Action{{ID: "hello", Label: "Say hello (sample plugin)"}},
+		Sections: []plugin.Section{{ID: "info", Title: "Sample Plugin"}},
+		Columns:  []plugin.Column{{ID: "up", Title: "up"}},
+	}
+}
+
+func (sample) Action(id string, guest plugin.Guest) (string, error) {
+	if id != "hello" {
+		return "", fmt.Errorf("unknown action %q", id)
+	}
+
+	return fmt.Sprintf("Hello from %s, %s is %s on %s", hostname(), guest.Name, guest.Status, guest.Node), nil
+}
+
+func (sample) Section(id string, guest plugin.Guest) ([]plugin.Row, error) {
+	if id != "info" {
+		return nil, fmt.Errorf("unknown section %q", id)
+	}
+
+	tags := "none"
+	if guest.Tags != "" {
+		tags = strings.ReplaceAll(guest.Tags, ";", ", ")
+	}
+
+	return []plugin.Row{
+		{Label: "Guest", Value: fmt.Sprintf("%s %d on %s", strings.ToUpper(guest.Type), guest.ID, guest.Node)},
+		{Label: "Tags", Value: tags},
+		{Label: "Uptime", Value: formatUptime(guest.Uptime)},
+		{Label: "Answered by", Value: fmt.Sprintf("pid %d on %s", os.Getpid(), hostname())},
+	}, nil
+}
+
+func (sample) Columns(guests []plugin.Guest) (map[string]map[string]string, error) {
+	columns := make(map[string]map[string]string, len(guests))
+
+	for _, guest := range guests {
+		if guest.Uptime > 0 {
+			columns[guest.Key()] = map[string]string{"up": formatUptime(guest.Uptime)}
+		}
+	}
+
+	return columns, nil
+}
+
+// formatUptime formats seconds as days or hours.
+func formatUptime(seconds int64) string {
+	uptime := time.Duration(seconds) * time.Second
+
+	switch {
+	case uptime <= 0:
+		return "-"
+	case uptime >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(uptime.Hours()/24))
+	default:
+		return fmt.Sprintf("%dh", int(uptime.Hours()))
+	}
+}
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "plugin"
+	}
+
+	return name
+}
+
+func main() {
+	plugin.Serve(sample{})
+}
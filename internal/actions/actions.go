@@ -0,0 +1,106 @@
+// Package actions runs user-defined commands configured for guests.
+//
+// Command templates may reference the selected guest with the placeholders
+// {ip}, {vmid}, {name}, {node} and {type}; the values are inserted
+// shell-quoted. The expanded command is run through the system shell,
+// either in the foreground while the UI is suspended or detached in the
+// background.
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Expand replaces the placeholders in the command template with the values of the guest.
+//
+// Guest names and IP addresses are reported by the guest itself, so every
+// substituted value is validated and inserted shell-quoted; templates should
+// not add their own quotes around placeholders. It returns an error if the
+// template references {ip} but the guest has no known IP address, or if a
+// referenced value is not safe to pass to the shell.
+func Expand(template string, vm *api.VM) (string, error) {
+	if vm == nil {
+		return "", fmt.Errorf("no guest selected")
+	}
+
+	if strings.Contains(template, "{ip}") {
+		if vm.IP == "" {
+			return "", fmt.Errorf("IP address of %s is not available", vm.Name)
+		}
+
+		if net.ParseIP(vm.IP) == nil {
+			return "", fmt.Errorf("invalid IP address reported for %s: %q", vm.Name, vm.IP)
+		}
+	}
+
+	for placeholder, value := range map[string]string{"{name}": vm.Name, "{node}": vm.Node, "{type}": vm.Type} {
+		if strings.Contains(template, placeholder) && !isHostname(value) {
+			return "", fmt.Errorf("%s value %q contains characters that are not allowed in commands", placeholder, value)
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"{ip}", shellQuote(vm.IP),
+		"{vmid}", strconv.Itoa(vm.ID),
+		"{name}", shellQuote(vm.Name),
+		"{node}", shellQuote(vm.Node),
+		"{type}", shellQuote(vm.Type),
+	)
+
+	return replacer.Replace(template), nil
+}
+
+// isHostname reports whether s only contains characters valid in host names.
+func isHostname(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '.' || c == '_') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shellQuote quotes a value as a single argument for the shell used by Command.
+func shellQuote(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Command builds the shell command that runs the expanded command line.
+func Command(ctx context.Context, commandLine string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", commandLine)
+	}
+
+	return exec.CommandContext(ctx, "sh", "-c", commandLine)
+}
+
+// IsBackground reports whether the action runs detached from the terminal.
+func IsBackground(action config.CustomAction) bool {
+	return action.Mode == config.CustomActionModeBackground
+}
+
+// LastLine returns the last non-empty line of command output, used to
+// summarize background results.
+func LastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	return strings.TrimSpace(lines[len(lines)-1])
+}
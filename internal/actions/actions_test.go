@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestExpand(t *testing.T) {
+	vm := &api.VM{ID: 104, Name: "web01", Node: "pve1", Type: api.VMTypeQemu, IP: "10.0.0.4"}
+
+	got, err := Expand("ssh admin@{ip} # {name} ({vmid}) on {node} [{type}]", vm)
+	require.NoError(t, err)
+	assert.Equal(t, "ssh admin@'10.0.0.4' # 'web01' (104) on 'pve1' ['qemu']", got)
+}
+
+func TestExpand_RejectsUnsafeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		vm       *api.VM
+	}{
+		{
+			name:     "command separator in IP",
+			template: "ssh admin@{ip}",
+			vm:       &api.VM{ID: 104, Name: "web01", Node: "pve1", IP: "10.0.0.4; rm -rf ~"},
+		},
+		{
+			name:     "command substitution in name",
+			template: "ansible-playbook -l {name} site.yml",
+			vm:       &api.VM{ID: 104, Name: "web$(touch /tmp/pwned)", Node: "pve1"},
+		},
+		{
+			name:     "quote in name",
+			template: "echo {name}",
+			vm:       &api.VM{ID: 104, Name: "web'01", Node: "pve1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Expand(tt.template, tt.vm)
+			assert.Error(t, err)
+		})
+	}
+
+	// Unsafe values are fine as long as the template does not use them
+	got, err := Expand("echo {vmid}", &api.VM{ID: 104, Name: "web; reboot", Node: "pve1"})
+	require.NoError(t, err)
+	assert.Equal(t, "echo 104", got)
+}
+
+func TestShellQuote(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	for _, value := range []string{"plain", "semi;colon", "$(touch /tmp/pwned)", "it's", "`id`"} {
+		out, err := Command(context.Background(), "printf %s "+shellQuote(value)).Output()
+		require.NoError(t, err)
+		assert.Equal(t, value, string(out))
+	}
+}
+
+func TestExpand_MissingIP(t *testing.T) {
+	vm := &api.VM{ID: 104, Name: "web01", Node: "pve1"}
+
+	_, err := Expand("ping {ip}", vm)
+	assert.ErrorContains(t, err, "IP address")
+
+	// Templates that do not need the IP still work
+	got, err := Expand("echo {vmid}", vm)
+	require.NoError(t, err)
+	assert.Equal(t, "echo 104", got)
+}
+
+func TestIsBackground(t *testing.T) {
+	assert.True(t, IsBackground(config.CustomAction{Mode: config.CustomActionModeBackground}))
+	assert.False(t, IsBackground(config.CustomAction{Mode: config.CustomActionModeSuspend}))
+	assert.False(t, IsBackground(config.CustomAction{}))
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	out, err := Command(context.Background(), "echo one; echo two").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "two", LastLine(out))
+}
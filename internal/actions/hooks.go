@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Hooks returns the hooks of an action run at the given phase, in config
+// order.
+func Hooks(hooks []config.Hook, action, when string) []config.Hook {
+	var matched []config.Hook
+
+	for _, hook := range hooks {
+		if hook.Action == action && hook.When == when {
+			matched = append(matched, hook)
+		}
+	}
+
+	return matched
+}
+
+// HookPrompt returns the first prompt of the hooks, or "" if none asks for
+// input. The entered value is shared by all hooks of the action.
+func HookPrompt(hooks ...[]config.Hook) string {
+	for _, list := range hooks {
+		for _, hook := range list {
+			if hook.Prompt != "" {
+				return hook.Prompt
+			}
+		}
+	}
+
+	return ""
+}
+
+// hookOutputWait is how long a timed out hook's output is still read after
+// it was killed, for commands it started that keep the output open.
+const hookOutputWait = time.Second
+
+// RunHook runs a hook for a guest and returns its combined output. The
+// command is expanded like a custom action, {input} is replaced with the
+// entered value, and the action and input are also available to the command
+// as PVETUI_HOOK_ACTION and PVETUI_HOOK_INPUT. A command that runs longer
+// than the hook's timeout is killed and fails.
+func RunHook(ctx context.Context, hook config.Hook, vm *api.VM, input string) ([]byte, error) {
+	commandLine, err := Expand(hook.Command, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guest values can't contain braces, so {input} is only found in the template
+	commandLine = strings.ReplaceAll(commandLine, "{input}", shellQuote(input))
+
+	ctx, cancel := context.WithTimeout(ctx, hook.CommandTimeout())
+	defer cancel()
+
+	cmd := Command(ctx, commandLine)
+	cmd.Env = append(os.Environ(),
+		"PVETUI_HOOK_ACTION="+hook.Action,
+		"PVETUI_HOOK_WHEN="+hook.When,
+		"PVETUI_HOOK_INPUT="+input,
+	)
+	cmd.WaitDelay = hookOutputWait
+
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("timed out after %s", hook.CommandTimeout())
+	}
+
+	if err != nil {
+		if last := LastLine(output); last != "" {
+			return output, fmt.Errorf("%w: %s", err, last)
+		}
+
+		return output, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestHooks(t *testing.T) {
+	hooks := []config.Hook{
+		{Action: config.HookActionVMStop, When: config.HookPost, Command: "notify"},
+		{Action: config.HookActionVMStop, When: config.HookPre, Command: "check", Prompt: "Ticket ID"},
+		{Action: config.HookActionVMStart, When: config.HookPre, Command: "other"},
+	}
+
+	pre := Hooks(hooks, config.HookActionVMStop, config.HookPre)
+	require.Len(t, pre, 1)
+	assert.Equal(t, "check", pre[0].Command)
+
+	post := Hooks(hooks, config.HookActionVMStop, config.HookPost)
+	assert.Equal(t, "Ticket ID", HookPrompt(pre, post))
+	assert.Empty(t, HookPrompt(post))
+	assert.Empty(t, Hooks(hooks, config.HookActionVMDelete, config.HookPre))
+}
+
+func TestRunHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	vm := &api.VM{ID: 104, Name: "web01", Node: "pve1"}
+	hook := config.Hook{
+		Action:  config.HookActionVMStop,
+		When:    config.HookPre,
+		Command: `echo {vmid} {input} "$PVETUI_HOOK_ACTION"`,
+	}
+
+	out, err := RunHook(context.Background(), hook, vm, "CHG-1; reboot")
+	require.NoError(t, err)
+	assert.Equal(t, "104 CHG-1; reboot vm.stop", LastLine(out))
+
+	hook.Command = `echo "no ticket" >&2; exit 3`
+	_, err = RunHook(context.Background(), hook, vm, "")
+	assert.ErrorContains(t, err, "no ticket")
+}
+
+func TestRunHook_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	// The shell forks sleep, which keeps the output open after the shell was killed
+	hook := config.Hook{Action: config.HookActionVMStop, When: config.HookPre, Command: "sleep 30; echo done", Timeout: 1}
+
+	start := time.Now()
+	_, err := RunHook(context.Background(), hook, &api.VM{ID: 104, Node: "pve1"}, "")
+
+	assert.EqualError(t, err, "timed out after 1s")
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
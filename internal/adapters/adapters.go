@@ -19,6 +19,8 @@
 //   - ConfigAdapter: Wraps internal.Config to implement interfaces.Config
 //   - LoggerAdapter: Wraps internal.Logger to implement interfaces.Logger
 //   - CacheAdapter: Wraps internal.Cache to implement interfaces.Cache
+//   - NewTerminalTFAPrompt: Asks for TOTP codes of two-factor logins on the terminal
+//   - NewBrowserOpenIDAuthorizer: Completes OpenID Connect logins in the browser
 //
 // Example usage:
 //
@@ -30,7 +32,7 @@
 //	// Wrap with adapters for API client
 //	configAdapter := adapters.NewConfigAdapter(config)
 //	loggerAdapter := adapters.NewLoggerAdapter(logger)
-//	cacheAdapter := adapters.NewCacheAdapter(cache)
+//	cacheAdapter := adapters.NewCacheAdapterFor(cache)
 //
 //	// Use with API client
 //	client, err := api.NewClient(configAdapter,
@@ -86,9 +88,9 @@ func NewLoggerAdapter(cfg *config.Config) interfaces.Logger {
 
 	// Initialize global logger with validation
 	if err := logger.InitGlobalLoggerWithValidation(level, cfg.CacheDir); err != nil {
-		// If initialization fails, create a simple logger as fallback
+		// If initialization fails, keep entries in memory for the log viewer
 		return &LoggerAdapter{
-			logger: logger.NewSimpleLogger(level),
+			logger: logger.NewMemoryLogger(level),
 		}
 	}
 
@@ -100,9 +102,9 @@ func NewLoggerAdapter(cfg *config.Config) interfaces.Logger {
 		return &LoggerAdapter{logger: concreteLogger}
 	}
 
-	// Fallback to simple logger if global logger is not the expected type
+	// Fallback to the in-memory history if global logger is not the expected type
 	return &LoggerAdapter{
-		logger: logger.NewSimpleLogger(level),
+		logger: logger.NewMemoryLogger(level),
 	}
 }
 
@@ -147,6 +149,12 @@ func NewCacheAdapter() interfaces.Cache {
 	}
 }
 
+// NewCacheAdapterFor creates a cache adapter for c, e.g. the namespace of a
+// profile within the global cache (see cache.ForProfile).
+func NewCacheAdapterFor(c cache.Cache) interfaces.Cache {
+	return &CacheAdapter{cache: c}
+}
+
 func (c *CacheAdapter) Get(key string, dest interface{}) (bool, error) {
 	return c.cache.Get(key, dest)
 }
@@ -1,6 +1,11 @@
 package adapters
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -8,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
 func TestConfigAdapter(t *testing.T) {
@@ -291,3 +297,52 @@ func TestCacheAdapter_TTL(t *testing.T) {
 	assert.NoError(t, err)
 	// We don't assert on found here because it depends on the cache implementation
 }
+
+func TestTerminalTFAPrompt(t *testing.T) {
+	var out strings.Builder
+
+	prompt := NewTerminalTFAPrompt(strings.NewReader(" 123456 \n"), &out, "root@pam")
+
+	code, err := prompt(api.TFAChallenge{TOTP: true})
+	require.NoError(t, err)
+	assert.Equal(t, "123456", code)
+	assert.Contains(t, out.String(), "root@pam")
+
+	// Input ends without a code
+	_, err = prompt(api.TFAChallenge{TOTP: true})
+	assert.Error(t, err)
+}
+
+func TestBrowserOpenIDAuthorizer(t *testing.T) {
+	// Find a free port for the redirect listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	redirectURL := fmt.Sprintf("http://localhost:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, listener.Close())
+
+	original := openBrowser
+	defer func() { openBrowser = original }()
+
+	var notified string
+
+	openBrowser = func(authURL string) error {
+		// Play the identity provider redirecting back
+		go func() {
+			resp, err := http.Get(strings.Replace(redirectURL, "localhost", "127.0.0.1", 1) + "?code=abc&state=xyz")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+
+		return nil
+	}
+
+	authorize := NewBrowserOpenIDAuthorizer(redirectURL, func(authURL string) { notified = authURL })
+
+	query, err := authorize(context.Background(), "https://idp.example.com/auth")
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/auth", notified)
+	assert.Equal(t, "abc", query.Get("code"))
+	assert.Equal(t, "xyz", query.Get("state"))
+}
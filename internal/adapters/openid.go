@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/vnc"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// openIDLoginTimeout bounds how long an OpenID login waits for the browser.
+const openIDLoginTimeout = 5 * time.Minute
+
+// openBrowser opens the identity provider login page; replaced in tests.
+var openBrowser = vnc.OpenBrowser
+
+// NewBrowserOpenIDAuthorizer returns an OpenID authorizer that opens the
+// login page in the browser and waits for the redirect on redirectURL, which
+// has to be a local http:// address. notify receives the login URL first, so
+// it can be shown in case no browser can be opened.
+func NewBrowserOpenIDAuthorizer(redirectURL string, notify func(authURL string)) api.OpenIDAuthorizer {
+	return func(ctx context.Context, authURL string) (url.Values, error) {
+		redirect, err := url.Parse(redirectURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect URL: %w", err)
+		}
+
+		address := redirect.Host
+		if redirect.Hostname() == "localhost" {
+			address = net.JoinHostPort("127.0.0.1", redirect.Port())
+		}
+
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("listen for the login redirect: %w", err)
+		}
+
+		path := redirect.Path
+		if path == "" {
+			path = "/"
+		}
+
+		result := make(chan url.Values, 1)
+
+		server := &http.Server{
+			ReadHeaderTimeout: 10 * time.Second,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				if r.URL.Path != path || (query.Get("code") == "" && query.Get("error") == "") {
+					http.NotFound(w, r)
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				fmt.Fprint(w, "<html><body><p>Login complete. You can close this tab and return to pvetui.</p></body></html>")
+
+				select {
+				case result <- query:
+				default:
+				}
+			}),
+		}
+
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		defer func() {
+			_ = server.Close()
+		}()
+
+		if notify != nil {
+			notify(authURL)
+		}
+
+		// The URL was shown, so the login can still be opened by hand
+		_ = openBrowser(authURL)
+
+		ctx, cancel := context.WithTimeout(ctx, openIDLoginTimeout)
+		defer cancel()
+
+		select {
+		case query := <-result:
+			return query, nil
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no login completed within %s", openIDLoginTimeout)
+		}
+	}
+}
+
+// NewTerminalOpenIDAuthorizer returns a browser OpenID authorizer that prints
+// the login URL on the terminal, before the interface starts.
+func NewTerminalOpenIDAuthorizer(redirectURL, realm string, out io.Writer) api.OpenIDAuthorizer {
+	return NewBrowserOpenIDAuthorizer(redirectURL, func(authURL string) {
+		fmt.Fprintf(out, "🌐 Log in to %s in your browser. If it does not open, visit:\n   %s\n", realm, authURL)
+	})
+}
@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// NewTerminalTFAPrompt returns a TFA prompt that asks for the TOTP code on
+// the terminal, before the interface starts.
+func NewTerminalTFAPrompt(in io.Reader, out io.Writer, user string) api.TFAPrompt {
+	reader := bufio.NewReader(in)
+
+	return func(api.TFAChallenge) (string, error) {
+		fmt.Fprintf(out, "🔐 TOTP code for %s: ", user)
+
+		input, err := reader.ReadString('\n')
+		code := strings.TrimSpace(input)
+
+		if code == "" {
+			if err != nil {
+				return "", fmt.Errorf("read two-factor code: %w", err)
+			}
+
+			return "", fmt.Errorf("no two-factor code entered")
+		}
+
+		return code, nil
+	}
+}
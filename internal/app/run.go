@@ -2,15 +2,21 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/devnullvoid/pvetui/internal/adapters"
 	"github.com/devnullvoid/pvetui/internal/cache"
 	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/logger"
+	"github.com/devnullvoid/pvetui/internal/startup"
 	"github.com/devnullvoid/pvetui/internal/ui"
+	"github.com/devnullvoid/pvetui/internal/ui/components"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -18,10 +24,26 @@ import (
 // Options configures the Run function.
 type Options struct {
 	NoCache bool
+	// StartupTrace records the durations of the startup phases, shown in
+	// the global menu and printed on exit.
+	StartupTrace bool
+	// DeepLink is the node or guest opened once loaded and the action run
+	// on it, if any.
+	DeepLink components.DeepLink
 }
 
 // RunWithStartupVerification constructs the API client, performs connectivity verification with user feedback, and starts the TUI.
 func RunWithStartupVerification(cfg *config.Config, configPath string, opts Options) error {
+	// Panics write a crash report instead of leaving the terminal corrupted
+	crash.SetConfig(cfg)
+
+	defer crash.Recover()
+
+	var trace *startup.Trace
+	if opts.StartupTrace {
+		trace = startup.NewTrace(time.Now())
+	}
+
 	// Initialize logger first (but don't output startup messages in debug mode)
 	level := logger.LevelInfo
 	if cfg.Debug {
@@ -30,9 +52,11 @@ func RunWithStartupVerification(cfg *config.Config, configPath string, opts Opti
 
 	mainLogger, err := logger.NewInternalLogger(level, cfg.CacheDir)
 	if err != nil {
-		mainLogger = logger.NewSimpleLogger(level)
+		mainLogger = logger.NewMemoryLogger(level)
 	}
 
+	mainLogger = mainLogger.WithComponent("main")
+
 	loggerAdapter := adapters.NewLoggerAdapter(cfg)
 	models.SetUILogger(loggerAdapter)
 
@@ -43,10 +67,26 @@ func RunWithStartupVerification(cfg *config.Config, configPath string, opts Opti
 		}
 	}
 
-	// Initialize cache
+	// Initialize cache. Without the encryption key, nothing is written to
+	// disk rather than writing plaintext.
 	if !opts.NoCache {
-		if cacheErr := cache.InitGlobalCache(cfg.CacheDir); cacheErr != nil {
+		if key, keyErr := cacheEncryptionKey(cfg); keyErr != nil {
+			mainLogger.Error("failed to get the cache encryption key: %v", keyErr)
+			printProgress(cfg, fmt.Sprintf("⚠️  Cache encryption unavailable, caching in memory only: %v", keyErr))
+		} else if cacheErr := cache.InitGlobalCache(cfg.CacheDir, key); cacheErr != nil {
 			mainLogger.Error("failed to initialize cache: %v", cacheErr)
+		} else if cfg.Cache.PurgeOnExit {
+			// Failed and aborted startups and crashes purge what was cached
+			// as well
+			purgeCache := sync.OnceFunc(func() {
+				if purgeErr := cache.PurgeGlobalCache(); purgeErr != nil {
+					mainLogger.Error("failed to purge the cache: %v", purgeErr)
+				}
+			})
+
+			defer purgeCache()
+
+			crash.SetCleanup(purgeCache)
 		}
 	}
 
@@ -60,52 +100,137 @@ func RunWithStartupVerification(cfg *config.Config, configPath string, opts Opti
 
 	// Create adapters
 	configAdapter := adapters.NewConfigAdapter(cfg)
-	cacheAdapter := adapters.NewCacheAdapter()
+	// Each profile and cluster gets its own part of the cache
+	profileCache := cache.ForProfile(cfg)
+	cacheAdapter := adapters.NewCacheAdapterFor(profileCache)
 
 	// Initialize API client (this just sets up the client, doesn't test connectivity)
-	fmt.Println("🔧 Initializing API client...")
+	printProgress(cfg, "🔧 Initializing API client...")
 
-	client, err := api.NewClient(
-		configAdapter,
+	clientOptions := []api.ClientOption{
 		api.WithLogger(loggerAdapter),
 		api.WithCache(cacheAdapter),
-	)
+		api.WithCacheTTLs(api.CacheTTLs{Status: cfg.Cache.StatusTTL(), Config: cfg.Cache.ConfigTTL(), Agent: cfg.Cache.AgentTTL()}),
+		api.WithLazyEnrichment(cfg.Enrichment.IsLazy()),
+		api.WithTFAPrompt(adapters.NewTerminalTFAPrompt(os.Stdin, os.Stdout,
+			fmt.Sprintf("%s@%s", configAdapter.GetUser(), configAdapter.GetRealm()))),
+	}
+	if trace != nil {
+		clientOptions = append(clientOptions, api.WithPhaseObserver(trace.Record))
+	}
+
+	if cfg.IsUsingOpenID() {
+		clientOptions = append(clientOptions, api.WithOpenID(cfg.GetOpenIDRedirectURL(),
+			adapters.NewTerminalOpenIDAuthorizer(cfg.GetOpenIDRedirectURL(), cfg.GetRealm(), os.Stdout)))
+	}
+
+	start := time.Now()
+
+	client, err := api.NewClient(configAdapter, clientOptions...)
 	if err != nil {
-		// Provide more specific error messages
-		if strings.Contains(err.Error(), "authentication failed") {
-			return fmt.Errorf("authentication failed: %w", err)
-		} else if strings.Contains(err.Error(), "missing port") {
-			return fmt.Errorf("invalid address format (missing port): %w", err)
+		// Authentication and connection errors speak for themselves
+		if errors.Is(err, api.ErrAuthFailed) || errors.Is(err, api.ErrTFARequired) || errors.Is(err, api.ErrConnection) {
+			return err
 		}
 
 		return fmt.Errorf("failed to initialize API client: %w", err)
 	}
 
-	fmt.Println("✅ API client initialized")
+	if trace != nil {
+		trace.Record("client setup", start)
+	}
 
-	// Now test actual connectivity and authentication
-	fmt.Printf("🔗 Testing connection to %s...\n", strings.TrimSuffix(cfg.Addr, "/api2/json"))
+	printProgress(cfg, "✅ API client initialized")
 
-	// Try a simple API call to verify connectivity and authentication
-	var result map[string]interface{}
-	if testErr := client.GetNoRetry("/version", &result); testErr != nil {
-		if strings.Contains(testErr.Error(), "authentication failed") || strings.Contains(testErr.Error(), "Unauthorized") {
-			return fmt.Errorf("authentication failed: invalid credentials")
-		} else if strings.Contains(testErr.Error(), "connection") || strings.Contains(testErr.Error(), "timeout") || strings.Contains(testErr.Error(), "dial") || strings.Contains(testErr.Error(), "name resolution") {
-			return fmt.Errorf("connection failed: %w", testErr)
+	if moved, migrateErr := profileCache.MigrateLegacy(client.GetBaseURL()); migrateErr != nil {
+		mainLogger.Error("failed to migrate the cache to namespace %s: %v", profileCache.Name(), migrateErr)
+	} else if moved > 0 {
+		mainLogger.Info("Migrated %d cached items to namespace %s", moved, profileCache.Name())
+	}
+
+	// Now test actual connectivity and authentication, unless the caller
+	// prefers to find out in the interface
+	if !cfg.SkipVerification {
+		proceed, verifyErr := verifyConnection(cfg, client, trace)
+		if verifyErr != nil {
+			return verifyErr
 		}
 
-		return fmt.Errorf("API test failed: %w", testErr)
+		if !proceed {
+			printProgress(cfg, "🚪 Startup aborted.")
+
+			return nil
+		}
 	}
 
-	fmt.Println("✅ Connected successfully")
-	fmt.Println("✅ Authentication successful")
-	fmt.Println("🖥️  Loading interface...")
-	fmt.Println()
+	printProgress(cfg, "🖥️  Loading interface...")
+	printProgress(cfg, "")
 
 	// Start the UI
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	return ui.RunApp(ctx, client, cfg, configPath)
+	runErr := ui.RunApp(ctx, client, cfg, configPath, trace, opts.DeepLink)
+
+	if trace != nil {
+		mainLogger.Info("%s", trace.Format())
+		fmt.Print(trace.Format())
+	}
+
+	return runErr
+}
+
+// verifyConnection checks connectivity, authentication, the cluster and
+// its nodes before the interface starts, on a checklist unless quiet startup
+// is configured. It reports whether to start the interface: the user may
+// continue with partial functionality when optional checks fail, or abort.
+func verifyConnection(cfg *config.Config, client *api.Client, trace *startup.Trace) (bool, error) {
+	checks := verificationChecks(cfg, client, trace)
+	start := time.Now()
+
+	if cfg.Quiet {
+		verification := startup.RunChecks(context.Background(), checks, nil)
+
+		return verification.Err == nil, verification.Err
+	}
+
+	title := "Connecting to " + strings.TrimSuffix(cfg.Addr, "/api2/json")
+
+	verification, proceed := components.RunStartupChecklist(cfg, title, checks)
+	if verification.Err != nil {
+		return false, verification.Err
+	}
+
+	if trace != nil {
+		trace.Record("startup verification", start)
+	}
+
+	if proceed && !verification.Complete() {
+		printProgress(cfg, "⚠️  Some startup checks failed, continuing with partial functionality")
+	} else if proceed {
+		printProgress(cfg, "✅ Connected successfully")
+	}
+
+	return proceed, nil
+}
+
+// cacheEncryptionKey returns the key the cache is encrypted with, or nil
+// when encryption is off.
+func cacheEncryptionKey(cfg *config.Config) ([]byte, error) {
+	switch cfg.Cache.Encryption {
+	case config.CacheEncryptionKeyring:
+		return cache.KeyringKey()
+	case config.CacheEncryptionPassphrase:
+		return cache.PassphraseKey(cfg.Cache.Passphrase, cfg.CacheDir)
+	}
+
+	return nil, nil
+}
+
+// printProgress prints a startup progress message unless quiet startup is
+// configured.
+func printProgress(cfg *config.Config, msg string) {
+	if !cfg.Quiet {
+		fmt.Println(msg)
+	}
 }
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/startup"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// nodeCheckTimeout bounds the checks of a single node, so an unreachable
+// node doesn't hold up the startup.
+const nodeCheckTimeout = 10 * time.Second
+
+// sshDialTimeout bounds connecting to the SSH port of a node.
+const sshDialTimeout = 3 * time.Second
+
+// verificationChecks returns the checks run before the interface starts:
+// authentication, which must pass, then the cluster status, the nodes and
+// their SSH ports, without which parts of the interface don't work.
+func verificationChecks(cfg *config.Config, client *api.Client, trace *startup.Trace) []startup.Check {
+	// The node list of the cluster status check is used by the checks of
+	// the nodes
+	var cluster *api.Cluster
+
+	return []startup.Check{
+		{
+			Name:     "Authentication",
+			Required: true,
+			Run: func(ctx context.Context, _ func(string)) startup.CheckResult {
+				start := time.Now()
+
+				var result map[string]interface{}
+				if err := client.WithContext(ctx).GetNoRetry("/version", &result); err != nil {
+					return startup.CheckResult{Status: startup.CheckFailed, Err: verificationError(err)}
+				}
+
+				if trace != nil {
+					trace.Record("connect and authenticate", start)
+				}
+
+				detail := "connected to " + strings.TrimSuffix(cfg.Addr, "/api2/json")
+				if data, ok := result["data"].(map[string]interface{}); ok {
+					if version, ok := data["version"].(string); ok {
+						detail = "Proxmox VE " + version
+					}
+				}
+
+				return startup.CheckResult{Status: startup.CheckPassed, Detail: detail}
+			},
+		},
+		{
+			Name: "Cluster status",
+			Run: func(ctx context.Context, _ func(string)) startup.CheckResult {
+				status, err := client.WithContext(ctx).GetClusterNodes()
+				if err != nil {
+					return failedCheck(err)
+				}
+
+				cluster = status
+
+				return clusterCheckResult(status)
+			},
+		},
+		{
+			Name: "Nodes reachable",
+			Run: func(ctx context.Context, progress func(string)) startup.CheckResult {
+				if cluster == nil || len(cluster.Nodes) == 0 {
+					return startup.CheckResult{Status: startup.CheckSkipped, Detail: "no nodes listed"}
+				}
+
+				return checkNodes(ctx, cluster.Nodes, progress, func(ctx context.Context, node *api.Node) error {
+					var result map[string]interface{}
+
+					return client.WithContext(ctx).GetNoRetry(fmt.Sprintf("/nodes/%s/status", node.Name), &result)
+				})
+			},
+		},
+		{
+			Name: "SSH",
+			Run: func(ctx context.Context, progress func(string)) startup.CheckResult {
+				if cfg.SSHUser == "" {
+					return startup.CheckResult{Status: startup.CheckSkipped, Detail: "ssh_user not set"}
+				}
+
+				if cluster == nil || len(cluster.Nodes) == 0 {
+					return startup.CheckResult{Status: startup.CheckSkipped, Detail: "no nodes listed"}
+				}
+
+				port := cfg.SSHPort
+				if port == 0 {
+					port = 22
+				}
+
+				result := checkNodes(ctx, cluster.Nodes, progress, func(ctx context.Context, node *api.Node) error {
+					addr := cfg.NodeAddress(node.Name, node.IP)
+					if addr == "" {
+						return errors.New("no address")
+					}
+
+					conn, err := (&net.Dialer{Timeout: sshDialTimeout}).DialContext(ctx, "tcp", net.JoinHostPort(addr, strconv.Itoa(port)))
+					if err != nil {
+						return err
+					}
+
+					return conn.Close()
+				})
+				result.Detail += fmt.Sprintf(", port %d", port)
+
+				return result
+			},
+		},
+	}
+}
+
+// verificationError turns the error of the authentication check into the
+// error startup fails with.
+func verificationError(err error) error {
+	switch {
+	case errors.Is(err, api.ErrTFARequired):
+		return fmt.Errorf("authentication failed: %w", err)
+	case errors.Is(err, api.ErrAuthFailed):
+		return fmt.Errorf("%w: invalid credentials", api.ErrAuthFailed)
+	case errors.Is(err, api.ErrConnection):
+		return err
+	}
+
+	return fmt.Errorf("API test failed: %w", err)
+}
+
+// failedCheck is the result of a check whose request failed.
+func failedCheck(err error) startup.CheckResult {
+	detail := err.Error()
+	if errors.Is(err, api.ErrPermission) {
+		detail = "permission denied"
+	}
+
+	return startup.CheckResult{Status: startup.CheckFailed, Detail: detail, Err: err}
+}
+
+// clusterCheckResult describes the cluster status found: the cluster name
+// and quorum, or the single node of a standalone installation.
+func clusterCheckResult(cluster *api.Cluster) startup.CheckResult {
+	switch {
+	case cluster.StatusForbidden:
+		return startup.CheckResult{Status: startup.CheckPartial, Detail: fmt.Sprintf("%d nodes; no permission to read the cluster status", len(cluster.Nodes))}
+	case cluster.Name == "" && len(cluster.Nodes) == 1:
+		return startup.CheckResult{Status: startup.CheckPassed, Detail: "standalone node " + cluster.Nodes[0].Name}
+	case !cluster.Quorate:
+		return startup.CheckResult{Status: startup.CheckPartial, Detail: fmt.Sprintf("cluster %s, %d nodes, not quorate", cluster.Name, len(cluster.Nodes))}
+	}
+
+	return startup.CheckResult{Status: startup.CheckPassed, Detail: fmt.Sprintf("cluster %s, %d nodes, quorate", cluster.Name, len(cluster.Nodes))}
+}
+
+// checkNodes runs check on the online nodes at once and counts those that
+// pass, reporting the count as it grows. Offline nodes count as failed.
+func checkNodes(ctx context.Context, nodes []*api.Node, progress func(string), check func(context.Context, *api.Node) error) startup.CheckResult {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		passed int
+		failed []string
+	)
+
+	for _, node := range nodes {
+		if !node.Online {
+			mu.Lock()
+			failed = append(failed, node.Name)
+			mu.Unlock()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(node *api.Node) {
+			defer crash.Recover()
+			defer wg.Done()
+
+			nodeCtx, cancel := context.WithTimeout(ctx, nodeCheckTimeout)
+			defer cancel()
+
+			err := check(nodeCtx, node)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failed = append(failed, node.Name)
+			} else {
+				passed++
+			}
+
+			progress(fmt.Sprintf("%d/%d", passed, len(nodes)))
+		}(node)
+	}
+
+	wg.Wait()
+
+	return nodeCheckResult(passed, len(nodes), failed)
+}
+
+// nodeCheckResult describes how many of total nodes passed a check and
+// names the others.
+func nodeCheckResult(passed, total int, failed []string) startup.CheckResult {
+	detail := fmt.Sprintf("%d/%d", passed, total)
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		detail += " (" + strings.Join(failed, ", ") + " failed)"
+	}
+
+	switch {
+	case passed == total:
+		return startup.CheckResult{Status: startup.CheckPassed, Detail: detail}
+	case passed == 0:
+		return startup.CheckResult{Status: startup.CheckFailed, Detail: detail}
+	}
+
+	return startup.CheckResult{Status: startup.CheckPartial, Detail: detail}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/startup"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCheckNodes(t *testing.T) {
+	nodes := []*api.Node{
+		{Name: "pve3", Online: true},
+		{Name: "pve1", Online: true},
+		{Name: "pve2", Online: true},
+		{Name: "pve4"},
+	}
+
+	var updates []string
+
+	result := checkNodes(context.Background(), nodes, func(detail string) {
+		updates = append(updates, detail)
+	}, func(_ context.Context, node *api.Node) error {
+		if node.Name == "pve3" {
+			return errors.New("no route to host")
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, startup.CheckPartial, result.Status)
+	assert.Equal(t, "2/4 (pve3, pve4 failed)", result.Detail)
+	assert.Len(t, updates, 3)
+
+	result = checkNodes(context.Background(), nodes[:3], func(string) {}, func(context.Context, *api.Node) error {
+		return nil
+	})
+	assert.Equal(t, startup.CheckResult{Status: startup.CheckPassed, Detail: "3/3"}, result)
+
+	result = checkNodes(context.Background(), nodes[3:], func(string) {}, nil)
+	assert.Equal(t, startup.CheckFailed, result.Status)
+}
+
+func TestClusterCheckResult(t *testing.T) {
+	nodes := []*api.Node{{Name: "pve1"}, {Name: "pve2"}}
+
+	tests := []struct {
+		name    string
+		cluster *api.Cluster
+		want    startup.CheckResult
+	}{
+		{"quorate", &api.Cluster{Name: "lab", Quorate: true, Nodes: nodes},
+			startup.CheckResult{Status: startup.CheckPassed, Detail: "cluster lab, 2 nodes, quorate"}},
+		{"not quorate", &api.Cluster{Name: "lab", Nodes: nodes},
+			startup.CheckResult{Status: startup.CheckPartial, Detail: "cluster lab, 2 nodes, not quorate"}},
+		{"standalone", &api.Cluster{Nodes: nodes[:1]},
+			startup.CheckResult{Status: startup.CheckPassed, Detail: "standalone node pve1"}},
+		{"forbidden", &api.Cluster{StatusForbidden: true, Nodes: nodes},
+			startup.CheckResult{Status: startup.CheckPartial, Detail: "2 nodes; no permission to read the cluster status"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterCheckResult(tt.cluster))
+		})
+	}
+}
+
+func TestVerificationError(t *testing.T) {
+	connErr := fmt.Errorf("%w: dial tcp: connection refused", api.ErrConnection)
+	assert.Equal(t, connErr, verificationError(connErr))
+
+	err := verificationError(fmt.Errorf("%w: 401 Unauthorized", api.ErrAuthFailed))
+	assert.ErrorIs(t, err, api.ErrAuthFailed)
+	assert.EqualError(t, err, "authentication failed: invalid credentials")
+
+	assert.ErrorIs(t, verificationError(api.ErrTFARequired), api.ErrTFARequired)
+}
@@ -6,9 +6,9 @@
 package bootstrap
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"strings"
 
 	"github.com/devnullvoid/pvetui/internal/app"
 	"github.com/devnullvoid/pvetui/internal/config"
@@ -18,6 +18,7 @@ import (
 	"github.com/devnullvoid/pvetui/internal/ui/components"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/internal/version"
+	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
 // BootstrapOptions contains all the options for bootstrapping the application.
@@ -27,6 +28,11 @@ type BootstrapOptions struct {
 	NoCache      bool
 	Version      bool
 	ConfigWizard bool
+	CheckConfig  bool
+	StartupTrace bool
+	// DeepLink is the node or guest opened on startup and the action run
+	// on it, if any.
+	DeepLink components.DeepLink
 	// Flag values for config overrides
 	FlagAddr        string
 	FlagUser        string
@@ -39,20 +45,27 @@ type BootstrapOptions struct {
 	FlagSSHUser     string
 	FlagDebug       bool
 	FlagCacheDir    string
+	FlagQuiet       bool
+	FlagQuietExit   bool
+	FlagSkipVerify  bool
 }
 
 // BootstrapResult contains the result of the bootstrap process.
 type BootstrapResult struct {
-	Config     *config.Config
-	ConfigPath string
-	Profile    string
-	NoCache    bool
+	Config       *config.Config
+	ConfigPath   string
+	Profile      string
+	NoCache      bool
+	StartupTrace bool
+	DeepLink     components.DeepLink
 }
 
 // ParseFlags parses command line flags and returns bootstrap options.
 func ParseFlags() BootstrapOptions {
 	var configPath, profile string
-	var noCache, version, configWizard bool
+	var noCache, version, configWizard, checkConfig, startupTrace bool
+	var selectVMID, vmid int
+	var node, action string
 
 	// Bootstrap flags
 	flag.StringVar(&configPath, "config", "", "Path to YAML config file")
@@ -65,10 +78,16 @@ func ParseFlags() BootstrapOptions {
 	flag.BoolVar(&version, "v", false, "Short for --version")
 	flag.BoolVar(&configWizard, "config-wizard", false, "Launch interactive config wizard and exit")
 	flag.BoolVar(&configWizard, "w", false, "Short for --config-wizard")
+	flag.BoolVar(&checkConfig, "check-config", false, "Validate the config file and exit")
+	flag.BoolVar(&startupTrace, "startup-trace", false, "Record how long each startup phase takes and show the breakdown")
+	flag.IntVar(&selectVMID, "select", 0, "Open the Guests page with the guest of this VMID selected")
+	flag.IntVar(&vmid, "vm", 0, "Guest (VMID) to open on startup, with --action to run an action on it")
+	flag.StringVar(&node, "node", "", "Node to open on startup, with --action to run an action on it")
+	flag.StringVar(&action, "action", "", "Action to run on the --vm or --node once loaded: shell, vnc, serial (guests) or web")
 
 	// Config flags (these will be applied to the config object later)
 	var flagAddr, flagUser, flagPassword, flagTokenID, flagTokenSecret, flagRealm, flagApiPath, flagSSHUser, flagCacheDir string
-	var flagInsecure, flagDebug bool
+	var flagInsecure, flagDebug, flagQuiet, flagQuietExit, flagSkipVerify bool
 
 	flag.StringVar(&flagAddr, "addr", "", "Proxmox API URL (env PVETUI_ADDR)")
 	flag.StringVar(&flagAddr, "a", "", "Short for --addr")
@@ -92,15 +111,27 @@ func ParseFlags() BootstrapOptions {
 	flag.BoolVar(&flagDebug, "d", false, "Short for --debug")
 	flag.StringVar(&flagCacheDir, "cache-dir", "", "Cache directory path (env PVETUI_CACHE_DIR)")
 	flag.StringVar(&flagCacheDir, "cd", "", "Short for --cache-dir")
+	flag.BoolVar(&flagQuiet, "quiet", false, "Don't print progress messages before the interface starts (env PVETUI_QUIET)")
+	flag.BoolVar(&flagQuiet, "q", false, "Short for --quiet")
+	flag.BoolVar(&flagQuietExit, "quiet-exit", false, "Don't print messages on exit (env PVETUI_QUIET_EXIT)")
+	flag.BoolVar(&flagSkipVerify, "skip-verify", false, "Start without checking the connection first (env PVETUI_SKIP_VERIFY)")
 
 	flag.Parse()
 
+	// --select is --vm without an action
+	if vmid == 0 {
+		vmid = selectVMID
+	}
+
 	return BootstrapOptions{
 		ConfigPath:   configPath,
 		Profile:      profile,
 		NoCache:      noCache,
 		Version:      version,
 		ConfigWizard: configWizard,
+		CheckConfig:  checkConfig,
+		StartupTrace: startupTrace,
+		DeepLink:     components.DeepLink{VMID: vmid, Node: node, Action: action},
 		// Store flag values for later use
 		FlagAddr:        flagAddr,
 		FlagUser:        flagUser,
@@ -113,6 +144,9 @@ func ParseFlags() BootstrapOptions {
 		FlagSSHUser:     flagSSHUser,
 		FlagDebug:       flagDebug,
 		FlagCacheDir:    flagCacheDir,
+		FlagQuiet:       flagQuiet,
+		FlagQuietExit:   flagQuietExit,
+		FlagSkipVerify:  flagSkipVerify,
 	}
 }
 
@@ -124,17 +158,20 @@ func Bootstrap(opts BootstrapOptions) (*BootstrapResult, error) {
 		return nil, nil
 	}
 
-	fmt.Println("🚀 Starting pvetui...")
-
-	// Initialize configuration
-	cfg := config.NewConfig()
+	if opts.CheckConfig {
+		return nil, CheckConfig(ResolveConfigPath(opts.ConfigPath), opts.Profile)
+	}
 
-	// Resolve configuration path
-	configPath := ResolveConfigPath(opts.ConfigPath)
+	if err := opts.DeepLink.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Handle config wizard BEFORE config loading and profile resolution
 	// This allows the wizard to work even when no config file exists
 	if opts.ConfigWizard {
+		cfg := config.NewConfig()
+		configPath := ResolveConfigPath(opts.ConfigPath)
+
 		// Try to load existing config if it exists, but don't fail if it doesn't
 		if configPath != "" {
 			_ = cfg.MergeWithFile(configPath) // Ignore errors for config wizard
@@ -164,22 +201,64 @@ func Bootstrap(opts BootstrapOptions) (*BootstrapResult, error) {
 	}
 
 	// Regular application flow: load config and resolve profiles
+	cfg, configPath, selectedProfile, err := LoadConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.Quiet {
+		fmt.Println("🚀 Starting pvetui...")
+	}
+
+	config.DebugEnabled = cfg.Debug
+	logger.SetDebugEnabled(cfg.Debug)
+	logger.SetFormat(logger.Format(cfg.LogFormat))
+	logger.SetRawLogging(cfg.LogRaw)
+
+	// Validate, handling errors with onboarding
+	if err := cfg.Validate(); err != nil {
+		if err := onboarding.HandleValidationError(cfg, configPath, opts.NoCache, selectedProfile); err != nil {
+			return nil, fmt.Errorf("onboarding failed: %w", err)
+		}
+		return nil, nil
+	}
+
+	onboarding.CheckConfigPermissions(cfg, configPath)
+
+	return &BootstrapResult{
+		Config:     cfg,
+		ConfigPath: configPath,
+		Profile:    selectedProfile,
+		NoCache:    opts.NoCache,
+		// Startup tracing only applies when the application starts
+		StartupTrace: opts.StartupTrace,
+		DeepLink:     opts.DeepLink,
+	}, nil
+}
+
+// LoadConfig loads the config file, selects the profile and applies the
+// command line flags the way the application does at startup. Defaults are
+// set but the result is not validated.
+func LoadConfig(opts BootstrapOptions) (*config.Config, string, string, error) {
+	cfg := config.NewConfig()
+	configPath := ResolveConfigPath(opts.ConfigPath)
+
 	if configPath != "" {
 		if err := cfg.MergeWithFile(configPath); err != nil {
-			return nil, fmt.Errorf("failed to load config file: %w", err)
+			return nil, "", "", fmt.Errorf("failed to load config file: %w", err)
 		}
 	}
 
 	// Handle profile selection
 	selectedProfile, err := profile.ResolveProfile(opts.Profile, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("profile resolution failed: %w", err)
+		return nil, "", "", fmt.Errorf("profile resolution failed: %w", err)
 	}
 
 	// Apply selected profile
 	if selectedProfile != "" {
 		if err := cfg.ApplyProfile(selectedProfile); err != nil {
-			return nil, fmt.Errorf("could not select profile '%s': %w", selectedProfile, err)
+			return nil, "", "", fmt.Errorf("could not select profile '%s': %w", selectedProfile, err)
 		}
 	}
 
@@ -220,25 +299,9 @@ func Bootstrap(opts BootstrapOptions) (*BootstrapResult, error) {
 		}
 	}
 
-	// Set defaults and validate
 	cfg.SetDefaults()
-	config.DebugEnabled = cfg.Debug
-	logger.SetDebugEnabled(cfg.Debug)
 
-	// Handle validation errors with onboarding
-	if err := cfg.Validate(); err != nil {
-		if err := onboarding.HandleValidationError(cfg, configPath, opts.NoCache, selectedProfile); err != nil {
-			return nil, fmt.Errorf("onboarding failed: %w", err)
-		}
-		return nil, nil
-	}
-
-	return &BootstrapResult{
-		Config:     cfg,
-		ConfigPath: configPath,
-		Profile:    selectedProfile,
-		NoCache:    opts.NoCache,
-	}, nil
+	return cfg, configPath, selectedProfile, nil
 }
 
 // applyFlagsToConfig applies command line flags to the config object
@@ -277,6 +340,15 @@ func applyFlagsToConfig(cfg *config.Config, opts BootstrapOptions) {
 	if opts.FlagCacheDir != "" {
 		cfg.CacheDir = opts.FlagCacheDir
 	}
+	if opts.FlagQuiet {
+		cfg.Quiet = true
+	}
+	if opts.FlagQuietExit {
+		cfg.QuietExit = true
+	}
+	if opts.FlagSkipVerify {
+		cfg.SkipVerification = true
+	}
 }
 
 // StartApplication starts the main application with the given configuration.
@@ -285,22 +357,27 @@ func StartApplication(result *BootstrapResult) error {
 		return fmt.Errorf("bootstrap result is nil")
 	}
 
-	if result.ConfigPath != "" {
+	switch {
+	case result.Config.Quiet:
+	case result.ConfigPath != "":
 		fmt.Printf("✅ Configuration loaded from %s\n", result.ConfigPath)
-	} else {
+	default:
 		fmt.Println("✅ Configuration loaded from environment variables")
 	}
 
 	// Apply theme configuration
+	theme.SetAccessible(result.Config.Accessible)
 	theme.ApplyCustomTheme(&result.Config.Theme)
 	theme.ApplyToTview()
 
-	appOpts := app.Options{NoCache: result.NoCache}
+	appOpts := app.Options{NoCache: result.NoCache, StartupTrace: result.StartupTrace, DeepLink: result.DeepLink}
 	if err := app.RunWithStartupVerification(result.Config, result.ConfigPath, appOpts); err != nil {
 		return handleStartupError(err, result.Config)
 	}
 
-	fmt.Println("🚪 Exiting.")
+	if !result.Config.QuietExit {
+		fmt.Println("🚪 Exiting.")
+	}
 	return nil
 }
 
@@ -317,6 +394,40 @@ func ResolveConfigPath(flagPath string) string {
 	return ""
 }
 
+// CheckConfig loads and validates the config file without starting the
+// application, printing each problem with its line and column.
+func CheckConfig(configPath, profileName string) error {
+	if configPath == "" {
+		return fmt.Errorf("no config file found: specify one with --config")
+	}
+
+	cfg := config.NewConfig()
+	if err := cfg.MergeWithFile(configPath); err != nil {
+		return fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	selectedProfile, err := profile.ResolveProfile(profileName, cfg)
+	if err != nil {
+		return fmt.Errorf("profile resolution failed: %w", err)
+	}
+
+	if selectedProfile != "" {
+		if err := cfg.ApplyProfile(selectedProfile); err != nil {
+			return fmt.Errorf("could not select profile '%s': %w", selectedProfile, err)
+		}
+	}
+
+	cfg.SetDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	fmt.Printf("✅ Configuration is valid: %s\n", configPath)
+
+	return nil
+}
+
 // HandleConfigWizard launches the configuration wizard.
 func HandleConfigWizard(cfg *config.Config, configPath string, activeProfile string) error {
 	res := components.LaunchConfigWizard(cfg, configPath, activeProfile)
@@ -338,10 +449,10 @@ func handleStartupError(err error, cfg *config.Config) error {
 	fmt.Printf("❌ %v\n", err)
 	fmt.Println()
 
-	if strings.Contains(err.Error(), "authentication failed") {
+	if errors.Is(err, api.ErrAuthFailed) || errors.Is(err, api.ErrTFARequired) {
 		fmt.Println("💡 Please check your credentials in the config file:")
 		fmt.Printf("   %s\n", config.GetDefaultConfigPath())
-	} else if strings.Contains(err.Error(), "connection") || strings.Contains(err.Error(), "timeout") {
+	} else if errors.Is(err, api.ErrConnection) {
 		fmt.Println("💡 Please check your Proxmox server address and network connectivity:")
 		fmt.Printf("   Current address: %s\n", cfg.Addr)
 	}
@@ -2,8 +2,10 @@ package cache
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -12,11 +14,20 @@ import (
 
 // BadgerCache implements the Cache interface using Badger DB.
 type BadgerCache struct {
-	db *badger.DB
+	db  *badger.DB
+	dir string
 }
 
 // NewBadgerCache creates a new Badger-based cache.
 func NewBadgerCache(dir string) (*BadgerCache, error) {
+	return NewEncryptedBadgerCache(dir, nil)
+}
+
+// NewEncryptedBadgerCache creates a Badger-based cache whose data is
+// encrypted at rest with AES, using a key of 16, 24 or 32 bytes. A cache
+// written with another key, or without encryption, is discarded. A nil key
+// leaves the data unencrypted.
+func NewEncryptedBadgerCache(dir string, encryptionKey []byte) (*BadgerCache, error) {
 	// Ensure the directory exists
 	if err := os.MkdirAll(dir, 0o750); err != nil {
 		return nil, fmt.Errorf("failed to create badger directory: %w", err)
@@ -47,7 +58,22 @@ func NewBadgerCache(dir string) (*BadgerCache, error) {
 	// Note: In badger v4, these options may have been renamed or removed
 	// opts.ValueLogLoadingMode = badger.MemoryMap
 
+	if encryptionKey != nil {
+		// Encrypted tables need a block and index cache to be read quickly
+		opts = opts.WithEncryptionKey(encryptionKey).WithIndexCacheSize(8 << 20)
+	}
+
 	db, err := badger.Open(opts)
+	if errors.Is(err, badger.ErrEncryptionKeyMismatch) {
+		// Encryption was turned on or off or the key changed; the cached
+		// data can't be read anymore, so start over
+		getCacheLogger().Info("Cache encryption key changed, discarding the cache at %s", dir)
+
+		if err = removeBadgerFiles(dir); err == nil {
+			db, err = badger.Open(opts)
+		}
+	}
+
 	if err != nil {
 		// Check if the error is due to resource temporarily unavailable
 		if os.IsExist(err) || isErrorTemporarilyUnavailable(err) {
@@ -71,10 +97,28 @@ func NewBadgerCache(dir string) (*BadgerCache, error) {
 	}()
 
 	return &BadgerCache{
-		db: db,
+		db:  db,
+		dir: dir,
 	}, nil
 }
 
+// removeBadgerFiles deletes the database files in dir, keeping the
+// directory.
+func removeBadgerFiles(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read badger directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("remove badger files: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // isLockFileStale checks if the lock file exists but no process is using it.
 func isLockFileStale(lockFilePath string) (bool, error) {
 	// This is a simple implementation and might not be completely reliable
@@ -208,6 +252,80 @@ func (c *BadgerCache) Clear() error {
 	return c.db.DropAll()
 }
 
+// Keys returns the keys of the items starting with prefix.
+func (c *BadgerCache) Keys(prefix string) ([]string, error) {
+	var keys []string
+
+	err := c.db.View(func(txn *badger.Txn) error {
+		options := badger.DefaultIteratorOptions
+		options.PrefetchValues = false
+		options.Prefix = []byte(prefix)
+
+		it := txn.NewIterator(options)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			keys = append(keys, string(it.Item().KeyCopy(nil)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("badger key listing: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Rename moves an item to another key, keeping its timestamp and TTL.
+func (c *BadgerCache) Rename(oldKey, newKey string) error {
+	err := c.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(oldKey))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+
+		if err := txn.Set([]byte(newKey), value); err != nil {
+			return err
+		}
+
+		return txn.Delete([]byte(oldKey))
+	})
+	if err != nil {
+		return fmt.Errorf("badger rename operation: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePrefix removes the items whose keys start with prefix.
+func (c *BadgerCache) DeletePrefix(prefix string) error {
+	getCacheLogger().Debug("Clearing cache items with prefix %s", prefix)
+
+	return c.db.DropPrefix([]byte(prefix))
+}
+
+// Purge closes the database and deletes its files, leaving nothing of the
+// cached data on disk.
+func (c *BadgerCache) Purge() error {
+	getCacheLogger().Debug("Purging Badger database at %s", c.dir)
+
+	if err := c.db.Close(); err != nil {
+		return fmt.Errorf("close badger database: %w", err)
+	}
+
+	return removeBadgerFiles(c.dir)
+}
+
 // Close closes the badger database.
 func (c *BadgerCache) Close() error {
 	getCacheLogger().Debug("Closing Badger database")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -247,6 +248,60 @@ func (c *FileCache) Clear() error {
 	return nil
 }
 
+// Keys returns the keys of the items starting with prefix.
+func (c *FileCache) Keys(prefix string) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var keys []string
+
+	for key := range c.inMemory {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// Rename moves an item to another key, keeping its timestamp and TTL.
+func (c *FileCache) Rename(oldKey, newKey string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, ok := c.inMemory[oldKey]
+	if !ok {
+		return nil
+	}
+
+	c.inMemory[newKey] = item
+	delete(c.inMemory, oldKey)
+
+	if c.persisted {
+		if err := os.Rename(filepath.Join(c.dir, oldKey+".json"), filepath.Join(c.dir, newKey+".json")); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rename cache file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeletePrefix removes the items whose keys start with prefix.
+func (c *FileCache) DeletePrefix(prefix string) error {
+	keys, err := c.Keys(prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := c.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Close implements the Cache.Close method for FileCache
 // This is a no-op for FileCache since it doesn't maintain any resources that need explicit closing.
 func (c *FileCache) Close() error {
@@ -280,8 +335,6 @@ func getCacheLogger() interfaces.Logger {
 			level = logger.LevelDebug
 		}
 
-		var err error
-
 		// Use the global cache directory if available, otherwise fallback to current directory
 		cacheDir := globalCacheDir
 		if cacheDir == "" {
@@ -289,18 +342,22 @@ func getCacheLogger() interfaces.Logger {
 		}
 
 		// Always use our new internal logger system with the cache directory
-		cacheLogger, err = logger.NewInternalLogger(level, cacheDir)
+		fileLogger, err := logger.NewInternalLogger(level, cacheDir)
 		if err != nil {
-			// Fallback to simple logger if file logging fails
-			cacheLogger = logger.NewSimpleLogger(level)
+			// Fallback to the in-memory history if file logging fails
+			fileLogger = logger.NewMemoryLogger(level)
 		}
+
+		cacheLogger = fileLogger.WithComponent("cache")
 	})
 
 	return cacheLogger
 }
 
-// InitGlobalCache initializes the global cache with the given directory.
-func InitGlobalCache(cacheDir string) error {
+// InitGlobalCache initializes the global cache with the given directory,
+// encrypted with encryptionKey unless it is nil (see
+// NewEncryptedBadgerCache).
+func InitGlobalCache(cacheDir string, encryptionKey []byte) error {
 	var err error
 
 	once.Do(func() {
@@ -335,7 +392,7 @@ func InitGlobalCache(cacheDir string) error {
 		// Initialize badger cache
 		getCacheLogger().Debug("Attempting to initialize BadgerDB cache at %s", badgerDir)
 
-		badgerCache, badgerErr := NewBadgerCache(badgerDir)
+		badgerCache, badgerErr := NewEncryptedBadgerCache(badgerDir, encryptionKey)
 		if badgerErr != nil {
 			// If lock file exists and we failed to initialize, it might be a lock contention
 			if lockFileExists {
@@ -343,7 +400,7 @@ func InitGlobalCache(cacheDir string) error {
 				// Wait a short time and try again once
 				time.Sleep(500 * time.Millisecond)
 
-				badgerCache, badgerErr = NewBadgerCache(badgerDir)
+				badgerCache, badgerErr = NewEncryptedBadgerCache(badgerDir, encryptionKey)
 			}
 
 			// If still failed, don't fall back to file cache, use in-memory as temporary solution
@@ -404,3 +461,15 @@ func GetBadgerCache() (*BadgerCache, bool) {
 
 	return badgerCache, ok
 }
+
+// PurgeGlobalCache deletes the data of the persistent global cache from
+// disk, e.g. on exit on shared machines. The cache must not be used
+// afterwards.
+func PurgeGlobalCache() error {
+	badgerCache, ok := GetBadgerCache()
+	if !ok {
+		return nil
+	}
+
+	return badgerCache.Purge()
+}
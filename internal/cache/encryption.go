@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptionKeySize selects AES-256 for the encrypted cache.
+const encryptionKeySize = 32
+
+// The encryption key of the cache is kept in the OS keyring under this
+// service and account.
+const (
+	keyringService = "pvetui"
+	keyringAccount = "cache-key"
+)
+
+// saltFile holds the salt the passphrase key is derived with.
+const saltFile = "cache-salt"
+
+// runKeyringCommand runs a keyring command with input on its standard input
+// and returns its output. A failed command's error wraps its *exec.ExitError,
+// which holds what it printed to standard error. Replaced in tests.
+var runKeyringCommand = func(input string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+
+	output, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return output, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+
+	return output, err
+}
+
+// errSecItemNotFound is the exit status of security(1) when the keychain has
+// no such item.
+const errSecItemNotFound = 44
+
+// keyringKeyMissing reports whether a failed lookup of the cache key means
+// there is no key yet, rather than that the keyring couldn't be read, as
+// when it is locked or its service isn't running. security exits with
+// errSecItemNotFound; secret-tool exits with 1 without printing anything.
+func keyringKeyMissing(output []byte, err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	if runtime.GOOS == "darwin" {
+		return exitErr.ExitCode() == errSecItemNotFound
+	}
+
+	return exitErr.ExitCode() == 1 && len(bytes.TrimSpace(output)) == 0 && len(exitErr.Stderr) == 0
+}
+
+// KeyringKey returns the cache encryption key kept in the OS keyring,
+// creating and storing a random key on first use. It uses the macOS
+// keychain through security(1) and the Secret Service (GNOME Keyring,
+// KWallet) through secret-tool(1) elsewhere. A keyring that can't be read
+// is an error: a new key would replace the stored one and discard the cache.
+func KeyringKey() ([]byte, error) {
+	var lookup, store []string
+
+	switch runtime.GOOS {
+	case "windows":
+		return nil, errors.New("the OS keyring is not supported on Windows; use passphrase encryption")
+	case "darwin":
+		lookup = []string{"security", "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w"}
+		store = []string{"security", "-i"}
+	default:
+		lookup = []string{"secret-tool", "lookup", "service", keyringService, "account", keyringAccount}
+		store = []string{"secret-tool", "store", "--label=pvetui cache key", "service", keyringService, "account", keyringAccount}
+	}
+
+	output, err := runKeyringCommand("", lookup[0], lookup[1:]...)
+	if err == nil {
+		if key, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(string(output))); decodeErr == nil && len(key) == encryptionKeySize {
+			return key, nil
+		}
+	} else if !keyringKeyMissing(output, err) {
+		return nil, fmt.Errorf("read the cache key from the OS keyring with %s: %w", lookup[0], err)
+	}
+
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate cache key: %w", err)
+	}
+
+	secret := base64.StdEncoding.EncodeToString(key)
+
+	// secret-tool reads the secret from standard input. security takes it as
+	// an argument, so the command is passed to its interactive mode on
+	// standard input to keep the key out of the process list; base64 needs
+	// no quoting there.
+	input := secret
+	if runtime.GOOS == "darwin" {
+		input = fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", keyringService, keyringAccount, secret)
+	}
+
+	if _, err := runKeyringCommand(input, store[0], store[1:]...); err != nil {
+		return nil, fmt.Errorf("store the cache key in the OS keyring with %s: %w", store[0], err)
+	}
+
+	return key, nil
+}
+
+// PassphraseKey derives the cache encryption key from a passphrase with
+// scrypt. The salt is created in cacheDir on first use and kept, so the same
+// passphrase opens the cache again.
+func PassphraseKey(passphrase, cacheDir string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("the cache passphrase is empty")
+	}
+
+	path := filepath.Join(cacheDir, saltFile)
+
+	salt, err := os.ReadFile(path)
+	if err != nil || len(salt) < 16 {
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("generate cache salt: %w", err)
+		}
+
+		if err := os.MkdirAll(cacheDir, 0o750); err != nil {
+			return nil, fmt.Errorf("create cache directory: %w", err)
+		}
+
+		if err := os.WriteFile(path, salt, 0o600); err != nil {
+			return nil, fmt.Errorf("write cache salt: %w", err)
+		}
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, encryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive cache key: %w", err)
+	}
+
+	return key, nil
+}
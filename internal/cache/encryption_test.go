@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPassphraseKey verifies that the same passphrase derives the same key
+// with the salt kept in the cache directory.
+func TestPassphraseKey(t *testing.T) {
+	dir := t.TempDir()
+
+	key, err := PassphraseKey("correct horse", dir)
+	if err != nil {
+		t.Fatalf("derive key: %v", err)
+	}
+
+	if len(key) != encryptionKeySize {
+		t.Fatalf("expected a %d byte key, got %d", encryptionKeySize, len(key))
+	}
+
+	again, err := PassphraseKey("correct horse", dir)
+	if err != nil || !bytes.Equal(key, again) {
+		t.Fatalf("expected the same key again, got %x (%v)", again, err)
+	}
+
+	other, err := PassphraseKey("battery staple", dir)
+	if err != nil || bytes.Equal(key, other) {
+		t.Fatalf("expected another key for another passphrase, got %x (%v)", other, err)
+	}
+
+	if _, err := PassphraseKey("", dir); err == nil {
+		t.Fatalf("expected an error for an empty passphrase")
+	}
+}
+
+// TestKeyringKey verifies that a key is created and stored in the Secret
+// Service on first use and read from it afterwards.
+func TestKeyringKey(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("uses the secret-tool commands")
+	}
+
+	stored := ""
+	original := runKeyringCommand
+	runKeyringCommand = func(input string, name string, args ...string) ([]byte, error) {
+		if name != "secret-tool" {
+			t.Fatalf("unexpected command %s", name)
+		}
+
+		if args[0] == "store" {
+			stored = input
+
+			return nil, nil
+		}
+
+		if stored == "" {
+			// secret-tool exits with 1 and no output for a missing secret
+			return exec.Command("sh", "-c", "exit 1").Output()
+		}
+
+		return []byte(stored + "\n"), nil
+	}
+
+	t.Cleanup(func() { runKeyringCommand = original })
+
+	key, err := KeyringKey()
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+
+	if stored == "" || len(key) != encryptionKeySize {
+		t.Fatalf("expected a stored %d byte key, got %d bytes, stored %q", encryptionKeySize, len(key), stored)
+	}
+
+	again, err := KeyringKey()
+	if err != nil || !bytes.Equal(key, again) {
+		t.Fatalf("expected the stored key, got %x (%v)", again, err)
+	}
+}
+
+// TestKeyringKey_Unreadable verifies that a keyring that can't be read is
+// an error and the stored key is left alone.
+func TestKeyringKey_Unreadable(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("uses the secret-tool commands")
+	}
+
+	original := runKeyringCommand
+	t.Cleanup(func() { runKeyringCommand = original })
+
+	lookups := map[string]func() ([]byte, error){
+		"locked keyring": func() ([]byte, error) {
+			return original("", "sh", "-c", "echo 'Cannot get secret of a locked object' >&2; exit 1")
+		},
+		"missing secret-tool": func() ([]byte, error) {
+			return nil, &exec.Error{Name: "secret-tool", Err: exec.ErrNotFound}
+		},
+	}
+
+	for name, lookup := range lookups {
+		t.Run(name, func(t *testing.T) {
+			runKeyringCommand = func(input string, command string, args ...string) ([]byte, error) {
+				if args[0] == "store" {
+					t.Fatalf("expected the stored key to be kept")
+				}
+
+				return lookup()
+			}
+
+			if _, err := KeyringKey(); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+// TestEncryptedBadgerCache verifies that the encrypted cache reads its
+// data with the same key, discards it with another key and leaves no data
+// after a purge.
+func TestEncryptedBadgerCache(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, encryptionKeySize)
+
+	c, err := NewEncryptedBadgerCache(dir, key)
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+
+	if err := c.Set("node", map[string]string{"ip": "10.0.0.1"}, time.Hour); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	files, _ := os.ReadDir(dir)
+	for _, file := range files {
+		data, _ := os.ReadFile(dir + "/" + file.Name())
+		if strings.Contains(string(data), "10.0.0.1") {
+			t.Fatalf("found plaintext data in %s", file.Name())
+		}
+	}
+
+	c, err = NewEncryptedBadgerCache(dir, key)
+	if err != nil {
+		t.Fatalf("reopen cache: %v", err)
+	}
+
+	var got map[string]string
+	if found, err := c.Get("node", &got); err != nil || !found || got["ip"] != "10.0.0.1" {
+		t.Fatalf("expected the cached item, got %v (found %v, %v)", got, found, err)
+	}
+
+	_ = c.Close()
+
+	c, err = NewBadgerCache(dir)
+	if err != nil {
+		t.Fatalf("open without key: %v", err)
+	}
+
+	if found, _ := c.Get("node", &got); found {
+		t.Fatalf("expected the encrypted cache to be discarded")
+	}
+
+	if err := c.Set("node", "plain", time.Hour); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if err := c.Purge(); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("expected no files after the purge, got %d", len(files))
+	}
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+// namespacePrefix starts the keys of namespaced items, keeping them apart
+// from the shared items of the cache and from the legacy API items.
+const namespacePrefix = "ns_"
+
+// legacyAPIPrefix starts the keys of the API responses cached before the
+// cache was namespaced.
+const legacyAPIPrefix = "proxmox_api_"
+
+// migratedKey marks a namespace the legacy API items were migrated to.
+const migratedKey = "legacy_migrated"
+
+// unsafeNamespaceChars are replaced in profile names, which end up in file
+// names of the file cache.
+var unsafeNamespaceChars = regexp.MustCompile(`[^A-Za-z0-9-]+`)
+
+// keyStore is implemented by caches that can list, rename and delete items
+// by key prefix, which namespaces need to clear and migrate their items.
+type keyStore interface {
+	Keys(prefix string) ([]string, error)
+	Rename(oldKey, newKey string) error
+	DeletePrefix(prefix string) error
+}
+
+// NamespacedCache is the part of a shared cache belonging to one cluster
+// connection. Its keys are prefixed with the namespace, so connections never
+// read each other's items, and Clear removes only its own items.
+type NamespacedCache struct {
+	cache     Cache
+	namespace string
+}
+
+// Namespace returns the part of c belonging to namespace.
+func Namespace(c Cache, namespace string) *NamespacedCache {
+	return &NamespacedCache{cache: c, namespace: namespace}
+}
+
+// ProfileNamespace returns the namespace of a connection: the profile name
+// and a hash of the address and identity it connects with, so a profile that
+// is pointed at another cluster or user starts with an empty cache.
+func ProfileNamespace(profile, addr, user, tokenID string) string {
+	if profile == "" {
+		profile = "default"
+	}
+
+	addr = strings.TrimSuffix(strings.TrimRight(addr, "/"), "/api2/json")
+	sum := sha256.Sum256([]byte(strings.Join([]string{addr, user, tokenID}, "\n")))
+
+	return strings.Trim(unsafeNamespaceChars.ReplaceAllString(profile, "-"), "-") + "-" + hex.EncodeToString(sum[:6])
+}
+
+// ForProfile returns the part of the global cache belonging to the active
+// profile of cfg.
+func ForProfile(cfg *config.Config) *NamespacedCache {
+	user := cfg.GetUser()
+	if realm := cfg.GetRealm(); realm != "" {
+		user += "@" + realm
+	}
+
+	return Namespace(GetGlobalCache(), ProfileNamespace(cfg.GetActiveProfile(), cfg.GetAddr(), user, cfg.GetTokenID()))
+}
+
+// Name returns the namespace.
+func (n *NamespacedCache) Name() string {
+	return n.namespace
+}
+
+func (n *NamespacedCache) key(key string) string {
+	return n.prefix() + key
+}
+
+func (n *NamespacedCache) prefix() string {
+	return namespacePrefix + n.namespace + "_"
+}
+
+// Get retrieves data from the namespace.
+func (n *NamespacedCache) Get(key string, dest interface{}) (bool, error) {
+	return n.cache.Get(n.key(key), dest)
+}
+
+// Set stores data in the namespace.
+func (n *NamespacedCache) Set(key string, data interface{}, ttl time.Duration) error {
+	return n.cache.Set(n.key(key), data, ttl)
+}
+
+// Delete removes an item from the namespace.
+func (n *NamespacedCache) Delete(key string) error {
+	return n.cache.Delete(n.key(key))
+}
+
+// Clear removes the items of the namespace, keeping those of others.
+func (n *NamespacedCache) Clear() error {
+	store, ok := n.cache.(keyStore)
+	if !ok {
+		return fmt.Errorf("cache %T can't clear a namespace", n.cache)
+	}
+
+	return store.DeletePrefix(n.prefix())
+}
+
+// Close does nothing: the shared cache is closed by its owner.
+func (n *NamespacedCache) Close() error {
+	return nil
+}
+
+// MigrateLegacy moves the API responses cached for baseURL before the cache
+// was namespaced into the namespace, once, and deletes those cached for
+// other servers, which can't be told apart by profile. It returns the number
+// of items moved.
+func (n *NamespacedCache) MigrateLegacy(baseURL string) (int, error) {
+	store, ok := n.cache.(keyStore)
+	if !ok {
+		return 0, nil
+	}
+
+	var migrated bool
+	if found, err := n.Get(migratedKey, &migrated); err == nil && found && migrated {
+		return 0, nil
+	}
+
+	keys, err := store.Keys(legacyAPIPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("list legacy cache items: %w", err)
+	}
+
+	// The client builds its keys from the base URL and path with every /
+	// replaced by _, and keeps doing so within the namespace
+	ownPrefix := strings.ReplaceAll(legacyAPIPrefix+baseURL+"_", "/", "_")
+	moved := 0
+
+	for _, key := range keys {
+		if strings.HasPrefix(key, ownPrefix) {
+			if err := store.Rename(key, n.key(key)); err != nil {
+				return moved, fmt.Errorf("migrate cache item %s: %w", key, err)
+			}
+
+			moved++
+
+			continue
+		}
+
+		if err := n.cache.Delete(key); err != nil {
+			return moved, fmt.Errorf("delete legacy cache item %s: %w", key, err)
+		}
+	}
+
+	if err := n.Set(migratedKey, true, 0); err != nil {
+		return moved, err
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNamespacedCache_Isolation verifies that namespaces of a shared cache
+// don't see or clear each other's items.
+func TestNamespacedCache_Isolation(t *testing.T) {
+	badgerCache, err := NewBadgerCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	defer badgerCache.Close()
+
+	for name, shared := range map[string]Cache{"file": NewMemoryCache(), "badger": badgerCache} {
+		t.Run(name, func(t *testing.T) {
+			first := Namespace(shared, "lab-1")
+			second := Namespace(shared, "prod-2")
+
+			if err := first.Set("nodes", "lab", time.Minute); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			if err := shared.Set("scripts", "shared", time.Minute); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			var got string
+			if found, _ := second.Get("nodes", &got); found {
+				t.Fatalf("second namespace read %q of the first", got)
+			}
+
+			if err := second.Set("nodes", "prod", time.Minute); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			if err := second.Clear(); err != nil {
+				t.Fatalf("clear: %v", err)
+			}
+
+			if found, _ := first.Get("nodes", &got); !found || got != "lab" {
+				t.Fatalf("expected the first namespace to keep its item, got %q (found %v)", got, found)
+			}
+
+			if found, _ := shared.Get("scripts", &got); !found {
+				t.Fatalf("expected the shared item to survive clearing a namespace")
+			}
+
+			if found, _ := second.Get("nodes", &got); found {
+				t.Fatalf("expected the cleared namespace to be empty")
+			}
+		})
+	}
+}
+
+// TestNamespacedCache_MigrateLegacy verifies that legacy API items of the
+// connected server move into the namespace and those of others are dropped.
+func TestNamespacedCache_MigrateLegacy(t *testing.T) {
+	shared, err := NewFileCache(t.TempDir(), true)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	own := legacyAPIKey("https://pve1:8006", "/cluster/resources")
+	other := legacyAPIKey("https://pve2:8006", "/cluster/resources")
+
+	for _, key := range []string{own, other, "scripts"} {
+		if err := shared.Set(key, key, time.Minute); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+
+	ns := Namespace(shared, "default-abc")
+
+	moved, err := ns.MigrateLegacy("https://pve1:8006")
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	if moved != 1 {
+		t.Fatalf("expected 1 item moved, got %d", moved)
+	}
+
+	var got string
+	if found, _ := ns.Get(own, &got); !found || got != own {
+		t.Fatalf("expected the own item in the namespace, got %q (found %v)", got, found)
+	}
+
+	for _, key := range []string{own, other} {
+		if found, _ := shared.Get(key, &got); found {
+			t.Fatalf("expected legacy item %s to be gone", key)
+		}
+	}
+
+	if found, _ := shared.Get("scripts", &got); !found {
+		t.Fatalf("expected items other than API responses to be kept")
+	}
+
+	// The items survive a restart and the migration runs once
+	reopened, err := NewFileCache(shared.dir, true)
+	if err != nil {
+		t.Fatalf("failed to reopen cache: %v", err)
+	}
+
+	if err := reopened.Set(own, "new legacy", time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	ns = Namespace(reopened, "default-abc")
+
+	if moved, err := ns.MigrateLegacy("https://pve1:8006"); err != nil || moved != 0 {
+		t.Fatalf("expected no second migration, moved %d: %v", moved, err)
+	}
+
+	if found, _ := ns.Get(own, &got); !found || got != own {
+		t.Fatalf("expected the migrated item after reopening, got %q (found %v)", got, found)
+	}
+}
+
+// legacyAPIKey builds a cache key the way the API client does, from its base
+// URL, which has no API path, and the request path.
+func legacyAPIKey(baseURL, path string) string {
+	return strings.ReplaceAll(fmt.Sprintf("proxmox_api_%s_%s", baseURL, path), "/", "_")
+}
+
+// TestProfileNamespace verifies that namespaces differ by profile and
+// cluster and ignore how the address is written.
+func TestProfileNamespace(t *testing.T) {
+	base := ProfileNamespace("home lab", "https://pve:8006", "root@pam", "")
+
+	if base != ProfileNamespace("home lab", "https://pve:8006/api2/json/", "root@pam", "") {
+		t.Fatalf("expected the API path to be ignored")
+	}
+
+	for _, other := range []string{
+		ProfileNamespace("work", "https://pve:8006", "root@pam", ""),
+		ProfileNamespace("home lab", "https://other:8006", "root@pam", ""),
+		ProfileNamespace("home lab", "https://pve:8006", "admin@pve", ""),
+		ProfileNamespace("home lab", "https://pve:8006", "root@pam", "tui"),
+	} {
+		if other == base {
+			t.Fatalf("expected %s to differ from %s", other, base)
+		}
+	}
+
+	if got := ProfileNamespace("", "https://pve:8006", "root@pam", ""); got[:8] != "default-" {
+		t.Fatalf("expected the default profile name, got %s", got)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devnullvoid/pvetui/internal/adapters"
+	"github.com/devnullvoid/pvetui/internal/bootstrap"
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/health"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// newCheckCmd creates the check command
+func newCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Diagnose the connection to the cluster",
+		Long: `Check the configuration and the connection to the cluster without starting
+the interface.
+
+The config is validated, a config file holding secrets is checked to be
+readable by its owner only, the API login is tested, every node is asked for
+its status, and when ssh_user is set an SSH login to every online node is
+tried. The results are printed as a table.
+
+Exit status: 0 when all checks pass, 1 when a permissions, node or SSH check
+fails, and 2 when the config is invalid or the API can't be reached or logged in to.`,
+		RunE: runCheck,
+	}
+
+	return cmd
+}
+
+// runCheck executes the check command
+func runCheck(cmd *cobra.Command, args []string) error {
+	opts := getBootstrapOptions(cmd)
+
+	var report *health.Report
+
+	cfg, configPath, _, err := bootstrap.LoadConfig(opts)
+	if err != nil {
+		report = &health.Report{}
+		report.Fail("config", bootstrap.ResolveConfigPath(opts.ConfigPath), err)
+	} else {
+		checker := &health.Checker{
+			Config:     cfg,
+			ConfigPath: configPath,
+			Connect:    connectAPI,
+			Executor:   ssh.NewDefaultExecutor(),
+		}
+		report = checker.Run(context.Background())
+	}
+
+	report.Print(cmd.OutOrStdout())
+
+	if code := report.ExitCode(); code != health.ExitOK {
+		os.Exit(code)
+	}
+
+	return nil
+}
+
+// connectAPI creates an API client for the config, as the application does.
+func connectAPI(cfg *config.Config) (health.API, error) {
+	cfg.Addr = strings.TrimRight(cfg.Addr, "/") + "/" + strings.TrimPrefix(cfg.ApiPath, "/")
+
+	configAdapter := adapters.NewConfigAdapter(cfg)
+
+	// The report goes to stdout, so the code is asked for on stderr
+	tfaPrompt := adapters.NewTerminalTFAPrompt(os.Stdin, os.Stderr,
+		fmt.Sprintf("%s@%s", configAdapter.GetUser(), configAdapter.GetRealm()))
+
+	options := []api.ClientOption{api.WithLogger(adapters.NewLoggerAdapter(cfg)), api.WithTFAPrompt(tfaPrompt)}
+
+	if cfg.IsUsingOpenID() {
+		options = append(options, api.WithOpenID(cfg.GetOpenIDRedirectURL(),
+			adapters.NewTerminalOpenIDAuthorizer(cfg.GetOpenIDRedirectURL(), cfg.GetRealm(), os.Stderr)))
+	}
+
+	return api.NewClient(configAdapter, options...)
+}
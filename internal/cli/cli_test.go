@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -53,6 +59,12 @@ func TestPersistentFlags(t *testing.T) {
 		"no-cache",
 		"version",
 		"config-wizard",
+		"check-config",
+		"startup-trace",
+		"select",
+		"vm",
+		"node",
+		"action",
 		"addr",
 		"user",
 		"password",
@@ -64,6 +76,9 @@ func TestPersistentFlags(t *testing.T) {
 		"ssh-user",
 		"debug",
 		"cache-dir",
+		"quiet",
+		"quiet-exit",
+		"skip-verify",
 	}
 
 	for _, flagName := range expectedFlags {
@@ -72,3 +87,136 @@ func TestPersistentFlags(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvCommand(t *testing.T) {
+	var envCmd *cobra.Command
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Use == "env" {
+			envCmd = cmd
+			break
+		}
+	}
+
+	if envCmd == nil {
+		t.Fatal("Expected env command to be added to root command")
+	}
+
+	if envCmd.Flags().Lookup("list") == nil {
+		t.Error("Expected env command to have a --list flag")
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	var checkCmd *cobra.Command
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Use == "check" {
+			checkCmd = cmd
+			break
+		}
+	}
+
+	if checkCmd == nil {
+		t.Fatal("Expected check command to be added to root command")
+	}
+
+	if checkCmd.Long == "" {
+		t.Error("Expected check command to have a long description")
+	}
+}
+
+func TestRealmsCommand(t *testing.T) {
+	var realmsCmd *cobra.Command
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Use == "realms" {
+			realmsCmd = cmd
+			break
+		}
+	}
+
+	if realmsCmd == nil {
+		t.Fatal("Expected realms command to be added to root command")
+	}
+
+	var out strings.Builder
+	printRealms(&out, []api.Realm{
+		{Realm: "pam", Type: "pam", Default: true},
+		{Realm: "company-sso", Type: "openid", Comment: "Company SSO", TFA: "type=oath"},
+	})
+
+	for _, want := range []string{"pam (default)", "company-sso", "browser (openid: true) + 2FA", "Company SSO"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected realms output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSelfUpdateCommand(t *testing.T) {
+	var selfUpdateCmd *cobra.Command
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Use == "self-update" {
+			selfUpdateCmd = cmd
+			break
+		}
+	}
+
+	if selfUpdateCmd == nil {
+		t.Fatal("Expected self-update command to be added to root command")
+	}
+
+	if selfUpdateCmd.Flags().Lookup("check") == nil {
+		t.Error("Expected self-update command to have a --check flag")
+	}
+}
+
+func TestResolveEnvSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `profiles:
+  default:
+    addr: "https://file.example.com:8006"
+    user: "fileuser"
+    password: "filepass"
+default_profile: default
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, envVar := range config.EnvVars {
+		t.Setenv(envVar.Name, "")
+	}
+
+	t.Setenv("PVETUI_USER", "envuser")
+
+	cmd := &cobra.Command{Use: "test"}
+	addPersistentFlags(cmd)
+
+	if err := cmd.ParseFlags([]string{"--realm", "pve"}); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := resolveEnvSettings(cmd, configPath, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string][2]string{
+		"PVETUI_PROFILE":  {"default", "config file"},
+		"PVETUI_ADDR":     {"https://file.example.com:8006", "config file"},
+		"PVETUI_USER":     {"envuser", "env"},
+		"PVETUI_PASSWORD": {"********", "config file"},
+		"PVETUI_REALM":    {"pve", "flag --realm"},
+		"PVETUI_API_PATH": {"/api2/json", "default"},
+		"PVETUI_TOKEN_ID": {"", "unset"},
+	}
+
+	for _, setting := range settings {
+		want, ok := expected[setting.Var.Name]
+		if !ok {
+			continue
+		}
+
+		if setting.Value != want[0] || setting.Source != want[1] {
+			t.Errorf("%s: got value %q from %q, want %q from %q", setting.Var.Name, setting.Value, setting.Source, want[0], want[1])
+		}
+	}
+}
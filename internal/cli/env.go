@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devnullvoid/pvetui/internal/bootstrap"
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/profile"
+)
+
+// envFile and envFileVars record the dotenv file loaded at startup and the
+// variables it set.
+var (
+	envFile     string
+	envFileVars []string
+)
+
+// loadEnvFile loads the .env file next to the config file before any command
+// reads its settings from the environment.
+func loadEnvFile(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+
+	path := config.EnvFilePath(bootstrap.ResolveConfigPath(configPath))
+	if path == "" {
+		return nil
+	}
+
+	loaded, ignored, err := config.LoadEnvFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load env file: %w", err)
+	}
+
+	if len(ignored) > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  %s: ignoring %s (only %s* variables are loaded)\n",
+			path, strings.Join(ignored, ", "), config.EnvPrefix)
+	}
+
+	envFile, envFileVars = path, loaded
+
+	return nil
+}
+
+// newEnvCmd creates the env command
+func newEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Show supported environment variables",
+		Long: `Show the environment variables supported by pvetui.
+
+With --list, every variable is printed with the value pvetui would use and
+where it comes from. Settings are resolved in this order: command line flag,
+environment (including the .env file next to the config file), config file,
+built-in default.`,
+		RunE: runEnv,
+	}
+
+	cmd.Flags().BoolP("list", "l", false, "List variables with their current value and source")
+
+	return cmd
+}
+
+// runEnv executes the env command
+func runEnv(cmd *cobra.Command, args []string) error {
+	list, _ := cmd.Flags().GetBool("list")
+	if !list {
+		return cmd.Help()
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	profileFlag, _ := cmd.Flags().GetString("profile")
+	resolvedPath := bootstrap.ResolveConfigPath(configPath)
+
+	settings, err := resolveEnvSettings(cmd, resolvedPath, profileFlag)
+	if err != nil {
+		return err
+	}
+
+	printEnvSettings(cmd.OutOrStdout(), resolvedPath, settings)
+
+	return nil
+}
+
+// envSetting is the resolved value of an environment variable's setting.
+type envSetting struct {
+	Var    config.EnvVar
+	Value  string
+	Source string
+}
+
+// resolveEnvSettings determines the effective value and its source for each
+// supported environment variable.
+func resolveEnvSettings(cmd *cobra.Command, configPath, profileFlag string) ([]envSetting, error) {
+	fileCfg := &config.Config{}
+
+	if configPath != "" {
+		if err := fileCfg.MergeWithFile(configPath); err != nil {
+			var schemaErrs config.SchemaErrors
+			if !errors.As(err, &schemaErrs) {
+				return nil, fmt.Errorf("failed to load config file: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  %s: %v\n\n", configPath, err)
+		}
+	}
+
+	selectedProfile, err := profile.ResolveProfile(profileFlag, fileCfg)
+	if err != nil {
+		return nil, fmt.Errorf("profile resolution failed: %w", err)
+	}
+
+	if selectedProfile != "" && len(fileCfg.Profiles) > 0 {
+		if err := fileCfg.ApplyProfile(selectedProfile); err != nil {
+			return nil, fmt.Errorf("could not select profile '%s': %w", selectedProfile, err)
+		}
+	}
+
+	defaults := &config.Config{}
+	defaults.SetDefaults()
+
+	settings := make([]envSetting, 0, len(config.EnvVars))
+
+	for _, envVar := range config.EnvVars {
+		setting := envSetting{Var: envVar}
+
+		if flag := cmd.Flags().Lookup(envVar.Flag); flag != nil && flag.Changed {
+			setting.Value, setting.Source = flag.Value.String(), "flag --"+envVar.Flag
+		} else if value, ok := os.LookupEnv(envVar.Name); ok && value != "" {
+			setting.Value, setting.Source = value, "env"
+			if slices.Contains(envFileVars, envVar.Name) {
+				setting.Source = "env file"
+			}
+		} else if value := envVar.Value(fileCfg); value != "" {
+			setting.Value, setting.Source = value, "config file"
+		} else if value := envVar.Value(defaults); value != "" {
+			setting.Value, setting.Source = value, "default"
+		} else {
+			setting.Source = "unset"
+		}
+
+		if envVar.Secret && setting.Value != "" {
+			setting.Value = "********"
+		}
+
+		settings = append(settings, setting)
+	}
+
+	return settings, nil
+}
+
+// printEnvSettings writes the resolved settings as a table.
+func printEnvSettings(w io.Writer, configPath string, settings []envSetting) {
+	if configPath == "" {
+		configPath = "none"
+	}
+
+	fmt.Fprintf(w, "Config file: %s\n", configPath)
+
+	switch {
+	case envFile == "":
+		fmt.Fprintf(w, "Env file:    none\n")
+	case len(envFileVars) == 0:
+		fmt.Fprintf(w, "Env file:    %s (nothing loaded)\n", envFile)
+	default:
+		fmt.Fprintf(w, "Env file:    %s (%d variable(s) loaded)\n", envFile, len(envFileVars))
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VARIABLE\tSOURCE\tVALUE\tDESCRIPTION")
+
+	for _, setting := range settings {
+		value := setting.Value
+		if value == "" {
+			value = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", setting.Var.Name, setting.Source, value, setting.Var.Description)
+	}
+
+	_ = tw.Flush()
+}
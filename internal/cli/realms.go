@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devnullvoid/pvetui/internal/adapters"
+	"github.com/devnullvoid/pvetui/internal/bootstrap"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// newRealmsCmd creates the realms command
+func newRealmsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "realms",
+		Short: "List the login realms of the cluster",
+		Long: `List the authentication realms users can log in with, as the login form of
+the web interface does. Only the address of the profile is needed, so this
+works before credentials are set up.
+
+OpenID Connect realms are logged in to in the browser: set "openid: true"
+and the realm name in the profile instead of a password or API token.`,
+		RunE: runRealms,
+	}
+
+	return cmd
+}
+
+// runRealms executes the realms command
+func runRealms(cmd *cobra.Command, args []string) error {
+	cfg, _, _, err := bootstrap.LoadConfig(getBootstrapOptions(cmd))
+	if err != nil {
+		return err
+	}
+
+	cfg.Addr = strings.TrimRight(cfg.Addr, "/") + "/" + strings.TrimPrefix(cfg.ApiPath, "/")
+
+	realms, err := api.ListRealms(adapters.NewConfigAdapter(cfg), api.WithLogger(adapters.NewLoggerAdapter(cfg)))
+	if err != nil {
+		return err
+	}
+
+	printRealms(cmd.OutOrStdout(), realms)
+
+	return nil
+}
+
+// printRealms writes the realms as a table.
+func printRealms(w io.Writer, realms []api.Realm) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REALM\tTYPE\tLOGIN\tCOMMENT")
+
+	for _, realm := range realms {
+		name := realm.Realm
+		if realm.Default {
+			name += " (default)"
+		}
+
+		login := "password"
+		if realm.IsOpenID() {
+			login = "browser (openid: true)"
+		}
+
+		// The realm enforces two-factor authentication for all its users
+		if realm.TFA != "" {
+			login += " + 2FA"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, realm.Type, login, realm.Comment)
+	}
+
+	_ = tw.Flush()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/devnullvoid/pvetui/internal/bootstrap"
+	"github.com/devnullvoid/pvetui/internal/ui/components"
 	"github.com/devnullvoid/pvetui/internal/version"
 )
 
@@ -19,8 +20,9 @@ var RootCmd = &cobra.Command{
 
 It provides an interactive interface for managing virtual machines, containers,
 nodes, and other Proxmox resources directly from the terminal.`,
-	Version: version.GetVersionString(),
-	RunE:    runMainApplication,
+	Version:           version.GetVersionString(),
+	PersistentPreRunE: loadEnvFile,
+	RunE:              runMainApplication,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -41,6 +43,10 @@ func init() {
 
 	// Add commands
 	RootCmd.AddCommand(newConfigWizardCmd())
+	RootCmd.AddCommand(newEnvCmd())
+	RootCmd.AddCommand(newCheckCmd())
+	RootCmd.AddCommand(newRealmsCmd())
+	RootCmd.AddCommand(newSelfUpdateCmd())
 }
 
 // runMainApplication runs the main application
@@ -75,6 +81,17 @@ func getBootstrapOptions(cmd *cobra.Command) bootstrap.BootstrapOptions {
 	noCache, _ := cmd.Flags().GetBool("no-cache")
 	version, _ := cmd.Flags().GetBool("version")
 	configWizard, _ := cmd.Flags().GetBool("config-wizard")
+	checkConfig, _ := cmd.Flags().GetBool("check-config")
+	startupTrace, _ := cmd.Flags().GetBool("startup-trace")
+	selectVMID, _ := cmd.Flags().GetInt("select")
+	vmid, _ := cmd.Flags().GetInt("vm")
+	node, _ := cmd.Flags().GetString("node")
+	action, _ := cmd.Flags().GetString("action")
+
+	// --select is --vm without an action
+	if vmid == 0 {
+		vmid = selectVMID
+	}
 
 	// Get config values from viper (which handles env vars)
 	addr := viper.GetString("addr")
@@ -88,6 +105,9 @@ func getBootstrapOptions(cmd *cobra.Command) bootstrap.BootstrapOptions {
 	sshUser := viper.GetString("ssh_user")
 	debug := viper.GetBool("debug")
 	cacheDir := viper.GetString("cache_dir")
+	quiet := viper.GetBool("quiet")
+	quietExit := viper.GetBool("quiet_exit")
+	skipVerify := viper.GetBool("skip_verify")
 
 	return bootstrap.BootstrapOptions{
 		ConfigPath:      configPath,
@@ -95,6 +115,9 @@ func getBootstrapOptions(cmd *cobra.Command) bootstrap.BootstrapOptions {
 		NoCache:         noCache,
 		Version:         version,
 		ConfigWizard:    configWizard,
+		CheckConfig:     checkConfig,
+		StartupTrace:    startupTrace,
+		DeepLink:        components.DeepLink{VMID: vmid, Node: node, Action: action},
 		FlagAddr:        addr,
 		FlagUser:        user,
 		FlagPassword:    password,
@@ -106,6 +129,9 @@ func getBootstrapOptions(cmd *cobra.Command) bootstrap.BootstrapOptions {
 		FlagSSHUser:     sshUser,
 		FlagDebug:       debug,
 		FlagCacheDir:    cacheDir,
+		FlagQuiet:       quiet,
+		FlagQuietExit:   quietExit,
+		FlagSkipVerify:  skipVerify,
 	}
 }
 
@@ -117,6 +143,12 @@ func addPersistentFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolP("no-cache", "n", false, "Disable caching")
 	cmd.PersistentFlags().BoolP("version", "v", false, "Show version information")
 	cmd.PersistentFlags().BoolP("config-wizard", "w", false, "Launch interactive config wizard and exit")
+	cmd.PersistentFlags().Bool("check-config", false, "Validate the config file and exit")
+	cmd.PersistentFlags().Bool("startup-trace", false, "Record how long each startup phase takes and show the breakdown")
+	cmd.PersistentFlags().Int("select", 0, "Open the Guests page with the guest of this VMID selected")
+	cmd.PersistentFlags().Int("vm", 0, "Guest (VMID) to open on startup, with --action to run an action on it")
+	cmd.PersistentFlags().String("node", "", "Node to open on startup, with --action to run an action on it")
+	cmd.PersistentFlags().String("action", "", "Action to run on the --vm or --node once loaded: shell, vnc, serial (guests) or web")
 
 	// Config flags
 	cmd.PersistentFlags().String("addr", "", "Proxmox API URL")
@@ -130,6 +162,9 @@ func addPersistentFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("ssh-user", "", "SSH username")
 	cmd.PersistentFlags().Bool("debug", false, "Enable debug logging")
 	cmd.PersistentFlags().String("cache-dir", "", "Cache directory path")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Don't print progress messages before the interface starts")
+	cmd.PersistentFlags().Bool("quiet-exit", false, "Don't print messages on exit")
+	cmd.PersistentFlags().Bool("skip-verify", false, "Start without checking the connection first")
 
 	// Bind flags to environment variables
 	viper.SetEnvPrefix("PVETUI")
@@ -169,4 +204,13 @@ func addPersistentFlags(cmd *cobra.Command) {
 	if err := viper.BindPFlag("cache_dir", cmd.PersistentFlags().Lookup("cache-dir")); err != nil {
 		panic(fmt.Sprintf("failed to bind cache_dir flag: %v", err))
 	}
+	if err := viper.BindPFlag("quiet", cmd.PersistentFlags().Lookup("quiet")); err != nil {
+		panic(fmt.Sprintf("failed to bind quiet flag: %v", err))
+	}
+	if err := viper.BindPFlag("quiet_exit", cmd.PersistentFlags().Lookup("quiet-exit")); err != nil {
+		panic(fmt.Sprintf("failed to bind quiet_exit flag: %v", err))
+	}
+	if err := viper.BindPFlag("skip_verify", cmd.PersistentFlags().Lookup("skip-verify")); err != nil {
+		panic(fmt.Sprintf("failed to bind skip_verify flag: %v", err))
+	}
 }
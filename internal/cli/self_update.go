@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devnullvoid/pvetui/internal/update"
+	"github.com/devnullvoid/pvetui/internal/version"
+)
+
+// newSelfUpdateCmd creates the self-update command
+func newSelfUpdateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update pvetui to the latest release",
+		Long: `Download the latest release from GitHub and replace the running binary.
+
+The release archive for this platform is verified against the checksums
+published with the release before the binary is replaced. The directory of
+the binary must be writable; installs managed by a package manager should
+be updated with it instead.
+
+Use --check to only print whether a newer release is available.`,
+		RunE: runSelfUpdate,
+	}
+
+	cmd.Flags().Bool("check", false, "Only check for a newer release")
+
+	return cmd
+}
+
+// runSelfUpdate executes the self-update command
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	checkOnly, _ := cmd.Flags().GetBool("check")
+	out := cmd.OutOrStdout()
+
+	if version.IsDevBuild() {
+		return update.ErrDevBuild
+	}
+
+	ctx := context.Background()
+	client := update.NewClient()
+
+	release, err := client.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := version.GetBuildInfo().Version
+	if !update.IsNewer(current, release.Tag) {
+		fmt.Fprintf(out, "Already up to date (%s)\n", current)
+
+		return nil
+	}
+
+	if checkOnly {
+		fmt.Fprintf(out, "Update available: %s (current %s)\n%s\n", release.Tag, current, release.URL)
+
+		return nil
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	fmt.Fprintf(out, "Downloading %s for %s/%s...\n", release.Tag, runtime.GOOS, runtime.GOARCH)
+
+	binary, err := client.Download(ctx, release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	if err := update.Replace(exePath, binary); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Updated %s to %s\n", exePath, release.Tag)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// announcementsSeenFileName lists the announcements already shown on connect.
+const announcementsSeenFileName = ".announcements-seen"
+
+// maxSeenAnnouncements bounds the remembered announcements, so clusters of
+// several profiles are remembered without the file growing forever.
+const maxSeenAnnouncements = 20
+
+// announcementsSeenPath returns the path of the seen announcements file.
+func announcementsSeenPath() string {
+	return filepath.Join(getConfigDir(), announcementsSeenFileName)
+}
+
+// seenAnnouncements returns the remembered announcement hashes, oldest first.
+func seenAnnouncements() []string {
+	data, err := os.ReadFile(announcementsSeenPath())
+	if err != nil {
+		return nil
+	}
+
+	return strings.Fields(string(data))
+}
+
+// IsAnnouncementSeen reports whether the announcements with the given hash
+// were already shown.
+func IsAnnouncementSeen(hash string) bool {
+	return slices.Contains(seenAnnouncements(), hash)
+}
+
+// MarkAnnouncementSeen records that the announcements with the given hash
+// were shown, so they aren't shown again on the next connect.
+func MarkAnnouncementSeen(hash string) error {
+	seen := seenAnnouncements()
+	if slices.Contains(seen, hash) {
+		return nil
+	}
+
+	seen = append(seen, hash)
+	if len(seen) > maxSeenAnnouncements {
+		seen = seen[len(seen)-maxSeenAnnouncements:]
+	}
+
+	if err := os.MkdirAll(getConfigDir(), 0o750); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(announcementsSeenPath(), []byte(strings.Join(seen, "\n")+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write seen announcements: %w", err)
+	}
+
+	return nil
+}
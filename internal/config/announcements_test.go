@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementSeen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("config directory is not derived from XDG_CONFIG_HOME")
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	assert.False(t, IsAnnouncementSeen("abc"))
+
+	require.NoError(t, MarkAnnouncementSeen("abc"))
+	require.NoError(t, MarkAnnouncementSeen("abc"))
+	assert.True(t, IsAnnouncementSeen("abc"))
+	assert.Len(t, seenAnnouncements(), 1)
+
+	// The oldest hashes are forgotten
+	for i := 0; i < maxSeenAnnouncements; i++ {
+		require.NoError(t, MarkAnnouncementSeen(fmt.Sprintf("h%d", i)))
+	}
+
+	assert.False(t, IsAnnouncementSeen("abc"))
+	assert.True(t, IsAnnouncementSeen("h0"))
+}
@@ -47,6 +47,7 @@
 // The package supports both password and API token authentication:
 //   - Password: Requires user + password + realm
 //   - API Token: Requires user + token_id + token_secret + realm
+//   - OpenID (profiles only): Requires openid: true + the realm of an OpenID realm
 //
 // Example usage:
 //
@@ -73,8 +74,15 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/devnullvoid/pvetui/internal/keys"
 	"github.com/getsops/sops/v3/decrypt"
@@ -84,8 +92,180 @@ import (
 const (
 	defaultRealm   = "pam"
 	defaultApiPath = "/api2/json"
+
+	// DefaultCompactWidth is the terminal width (in columns) below which list
+	// and details panels are stacked vertically instead of side by side.
+	DefaultCompactWidth = 100
+
+	// DefaultGuestLimit is the number of guests listed before a "load more"
+	// entry, keeping the guest list responsive on very large clusters.
+	DefaultGuestLimit = 500
+
+	// DefaultMetricsHistory is the number of metric samples kept per node
+	// and guest, 15 minutes at the auto-refresh interval of 10 seconds.
+	DefaultMetricsHistory = 90
+
+	// DefaultSensorsWarning and DefaultSensorsCritical are the node
+	// temperatures (°C) at which readings are highlighted.
+	DefaultSensorsWarning  = 70
+	DefaultSensorsCritical = 85
+
+	// DefaultClockMaxSkew is the clock difference in seconds at which nodes
+	// are flagged as out of sync. The API reports whole seconds, so smaller
+	// differences can't be told apart reliably.
+	DefaultClockMaxSkew = 2
+
+	// DefaultRecentGuests is the number of recently viewed guests kept
+	// enriched across refreshes in lazy enrichment mode.
+	DefaultRecentGuests = 10
+
+	// DefaultMetadataPattern extracts "key: value" and "key=value" pairs
+	// (optionally as list items) from guest tags and notes lines.
+	DefaultMetadataPattern = `^(?:[-*+]\s+)?([A-Za-z][\w-]*)\s*[:=]\s*([^\s/].*)$`
+
+	// DefaultOpenIDRedirectURL is where pvetui waits for the browser after
+	// an OpenID login. The identity provider has to allow it as a redirect
+	// URI.
+	DefaultOpenIDRedirectURL = "http://localhost:8765/"
+)
+
+// Summary panel modes select what the panel above the main view shows.
+const (
+	SummaryModeCluster = "cluster" // Cluster name, quorum and resource totals
+	SummaryModeNode    = "node"    // Stats of the selected node
+	SummaryModeTasks   = "tasks"   // Running and recent tasks
+	SummaryModeNone    = "none"    // Hide the panel
+)
+
+// SummaryModes lists the valid summary panel modes in cycling order.
+var SummaryModes = []string{SummaryModeCluster, SummaryModeNode, SummaryModeTasks, SummaryModeNone}
+
+// Start pages select the page shown on startup.
+const (
+	StartPageNodes  = "nodes"
+	StartPageGuests = "guests"
+	StartPageTasks  = "tasks"
+)
+
+// StartPages lists the valid start pages.
+var StartPages = []string{StartPageNodes, StartPageGuests, StartPageTasks}
+
+// Focus targets select the panel of the start page that has the focus.
+const (
+	FocusList    = "list"    // The node, guest or task list
+	FocusDetails = "details" // The details of the selected node or guest
+)
+
+// FocusTargets lists the valid default focus targets.
+var FocusTargets = []string{FocusList, FocusDetails}
+
+// Placement strategies rank the nodes suggested for new and migrated guests.
+const (
+	PlacementStrategyBalanced = "balanced" // Weigh free memory, CPU and storage
+	PlacementStrategyMemory   = "memory"   // Most free memory first
+	PlacementStrategyCPU      = "cpu"      // Least busy CPU first
+)
+
+// PlacementStrategies lists the valid placement strategies.
+var PlacementStrategies = []string{PlacementStrategyBalanced, PlacementStrategyMemory, PlacementStrategyCPU}
+
+// Enrichment modes select which guests get detailed status and guest agent data.
+const (
+	EnrichmentModeEager = "eager" // All running guests after every load
+	EnrichmentModeLazy  = "lazy"  // The selected and recently viewed guests only
+)
+
+// EnrichmentModes lists the valid enrichment modes.
+var EnrichmentModes = []string{EnrichmentModeEager, EnrichmentModeLazy}
+
+// Log formats select how entries are written to the log file.
+const (
+	LogFormatConsole = "console" // [time] [LEVEL] [component] message
+	LogFormatJSON    = "json"    // One JSON object per line
+)
+
+// LogFormats lists the valid log formats.
+var LogFormats = []string{LogFormatConsole, LogFormatJSON}
+
+// Custom action run modes.
+const (
+	CustomActionModeSuspend    = "suspend"    // Suspend the UI and run in the terminal
+	CustomActionModeBackground = "background" // Run detached and report the result in the header
+)
+
+// Hook phases select whether a hook runs before or after its action.
+const (
+	HookPre  = "pre"  // Before the action; a failure aborts it
+	HookPost = "post" // After the action succeeded
+)
+
+// Guest actions that hooks can be attached to.
+const (
+	HookActionVMStart    = "vm.start"
+	HookActionVMShutdown = "vm.shutdown"
+	HookActionVMStop     = "vm.stop"
+	HookActionVMRestart  = "vm.restart"
+	HookActionVMReset    = "vm.reset"
+	HookActionVMDelete   = "vm.delete"
+	HookActionVMMigrate  = "vm.migrate"
+)
+
+// HookActions lists the actions that hooks can be attached to.
+var HookActions = []string{
+	HookActionVMStart, HookActionVMShutdown, HookActionVMStop, HookActionVMRestart,
+	HookActionVMReset, HookActionVMDelete, HookActionVMMigrate,
+}
+
+// Notification backends deliver alerts outside the terminal.
+const (
+	NotificationWebhook = "webhook" // JSON POST to any URL
+	NotificationGotify  = "gotify"  // Gotify server message API
+	NotificationNtfy    = "ntfy"    // ntfy topic
+	NotificationSlack   = "slack"   // Slack incoming webhook
+)
+
+// NotificationTypes lists the valid notification backends.
+var NotificationTypes = []string{NotificationWebhook, NotificationGotify, NotificationNtfy, NotificationSlack}
+
+// Notification events select what a backend is notified about.
+const (
+	NotificationEventAlerts      = "alerts"       // New alerts: offline nodes, full storages, guest stops and restarts
+	NotificationEventTasks       = "tasks"        // Tasks that finished with an error
+	NotificationEventSchemaDrift = "schema_drift" // API responses missing fields or with unexpected types
+)
+
+// NotificationEvents lists the valid notification events.
+var NotificationEvents = []string{NotificationEventAlerts, NotificationEventTasks, NotificationEventSchemaDrift}
+
+// DefaultNotificationEvents are sent to backends that don't list events.
+var DefaultNotificationEvents = []string{NotificationEventAlerts, NotificationEventTasks}
+
+// Affinity rule types select whether the guests of a group belong together.
+const (
+	AffinityTogether = "affinity"      // Keep the guests on the same node
+	AffinitySeparate = "anti-affinity" // Keep the guests on different nodes
+)
+
+// AffinityTypes lists the valid affinity rule types.
+var AffinityTypes = []string{AffinityTogether, AffinitySeparate}
+
+// Guest group waits select when the next guest of a group is started.
+const (
+	GroupWaitRunning = "running" // The guest is reported as running
+	GroupWaitAgent   = "agent"   // The QEMU guest agent answers a ping
+	GroupWaitTCP     = "tcp"     // A TCP port of the guest accepts connections
 )
 
+// GroupWaits lists the valid guest group waits.
+var GroupWaits = []string{GroupWaitRunning, GroupWaitAgent, GroupWaitTCP}
+
+// DefaultGroupTimeout is how long a guest group waits for each guest, in
+// seconds.
+const DefaultGroupTimeout = 120
+
+// DefaultHookTimeout is how long a hook command may run, in seconds.
+const DefaultHookTimeout = 30
+
 // DebugEnabled is a global flag to enable debug logging throughout the application.
 //
 // This variable is set during configuration parsing and used by various
@@ -100,10 +280,89 @@ type Config struct {
 	// It is not persisted to disk and is used to resolve getters when set.
 	ActiveProfile string `yaml:"-"`
 	// The following fields are global settings, not per-profile
-	Debug       bool        `yaml:"debug"`
-	CacheDir    string      `yaml:"cache_dir"`
-	KeyBindings KeyBindings `yaml:"key_bindings"`
-	Theme       ThemeConfig `yaml:"theme"`
+	Debug    bool   `yaml:"debug"`
+	CacheDir string `yaml:"cache_dir"`
+	// LogFormat is LogFormatConsole (default) or LogFormatJSON.
+	LogFormat string `yaml:"log_format"`
+	// LogRaw turns off the redaction of tickets, tokens and passwords in
+	// logs, for debugging locally.
+	LogRaw bool `yaml:"log_raw"`
+	// CompactWidth is the terminal width below which the layout switches to
+	// stacked panels. Zero disables the compact layout.
+	CompactWidth int `yaml:"compact_width"`
+	// GuestLimit is the number of guests listed at once; more are listed
+	// on demand. Zero lists all guests.
+	GuestLimit int `yaml:"guest_limit"`
+	// MetricsHistory is the number of CPU, memory, network and disk samples
+	// kept in memory for every node and guest, one per refresh. Zero keeps
+	// none.
+	MetricsHistory int `yaml:"metrics_history"`
+	// Sparklines shows the CPU and memory trend of running guests next to
+	// their names in the guest list; the sparklines key toggles it.
+	Sparklines bool `yaml:"sparklines"`
+	// StartPage is the page shown on startup, one of StartPages.
+	StartPage string `yaml:"start_page"`
+	// DefaultFocus is the panel of the start page focused on startup, one
+	// of FocusTargets. The Tasks page only has a list.
+	DefaultFocus string `yaml:"default_focus"`
+	// Accessible replaces emoji with ASCII labels and defaults to the
+	// high-contrast theme.
+	Accessible bool `yaml:"accessible"`
+	// UpdateCheck looks for a newer release on startup.
+	UpdateCheck bool `yaml:"update_check"`
+	// Quiet suppresses the progress messages printed before the interface
+	// starts. Errors are still printed.
+	Quiet bool `yaml:"quiet"`
+	// QuietExit suppresses the messages printed when the application exits.
+	QuietExit bool `yaml:"quiet_exit"`
+	// SkipVerification starts the interface without first checking the
+	// connection with a request to /version.
+	SkipVerification bool `yaml:"skip_verification"`
+	// SSHMultiplex shares one SSH connection per node between shells,
+	// script installs and commands (OpenSSH ControlMaster).
+	SSHMultiplex bool          `yaml:"ssh_multiplex"`
+	KeyBindings  KeyBindings   `yaml:"key_bindings"`
+	Theme        ThemeConfig   `yaml:"theme"`
+	Summary      SummaryConfig `yaml:"summary"`
+	Sensors      SensorsConfig `yaml:"sensors"`
+	// Enrichment selects which guests get guest agent data.
+	Enrichment EnrichmentConfig `yaml:"enrichment"`
+	// Metadata configures guest metadata parsed from tags and notes.
+	Metadata MetadataConfig `yaml:"metadata"`
+	// Backups configures the last-backup indicator and overdue backup alerts.
+	Backups BackupsConfig `yaml:"backups"`
+	// Snapshots configures the detection of stale snapshots.
+	Snapshots SnapshotsConfig `yaml:"snapshots"`
+	// Clock configures when node clocks are flagged as out of sync.
+	Clock ClockConfig `yaml:"clock"`
+	// Costs sets the unit costs of the cost report.
+	Costs CostsConfig `yaml:"costs"`
+	// Placement selects how target nodes are suggested for new and migrated guests.
+	Placement PlacementConfig `yaml:"placement"`
+	// Lock configures the session lock screen.
+	Lock LockConfig `yaml:"lock"`
+	// Cache configures how the persistent cache is kept on disk.
+	Cache CacheConfig `yaml:"cache"`
+	// CustomActions are user-defined commands shown in the guest context menu.
+	CustomActions []CustomAction `yaml:"custom_actions"`
+	// GuestServices are commands run inside matching guests from their
+	// Services menu.
+	GuestServices []GuestService `yaml:"guest_services"`
+	// GuestSSH overrides the SSH user and key of shells to matching QEMU
+	// guests; the first matching rule applies.
+	GuestSSH []GuestSSHRule `yaml:"guest_ssh"`
+	// ScriptSources are additional script repositories shown in the script selector.
+	ScriptSources []ScriptSource `yaml:"script_sources"`
+	// Plugins are external executables extending the interface.
+	Plugins []Plugin `yaml:"plugins"`
+	// Hooks are commands run before or after guest actions.
+	Hooks []Hook `yaml:"hooks"`
+	// Notifications are backends alerted about problems found while running.
+	Notifications []Notification `yaml:"notifications"`
+	// AffinityRules keep groups of guests on one node or spread over nodes.
+	AffinityRules []AffinityRule `yaml:"affinity_rules"`
+	// GuestGroups are stacks of guests started and stopped in order.
+	GuestGroups []GuestGroup `yaml:"guest_groups"`
 	// Deprecated: legacy single-profile fields for migration
 	Addr        string `yaml:"addr"`
 	User        string `yaml:"user"`
@@ -114,6 +373,11 @@ type Config struct {
 	ApiPath     string `yaml:"api_path"`
 	Insecure    bool   `yaml:"insecure"`
 	SSHUser     string `yaml:"ssh_user"`
+	SSHPort     int    `yaml:"ssh_port,omitempty"`
+	SSHKeyFile  string `yaml:"ssh_key_file,omitempty"`
+	// NodeAddresses overrides the addresses SSH and other direct
+	// connections to nodes use, by node name (see NodeAddress).
+	NodeAddresses map[string]string `yaml:"node_addresses,omitempty"`
 }
 
 // KeyBindings defines customizable key mappings for common actions.
@@ -122,18 +386,23 @@ type Config struct {
 type KeyBindings struct {
 	SwitchView        string `yaml:"switch_view"` // Switch between pages
 	SwitchViewReverse string `yaml:"switch_view_reverse"`
-	NodesPage         string `yaml:"nodes_page"`   // Jump to Nodes page
-	GuestsPage        string `yaml:"guests_page"`  // Jump to Guests page
-	TasksPage         string `yaml:"tasks_page"`   // Jump to Tasks page
-	Menu              string `yaml:"menu"`         // Open context menu
-	GlobalMenu        string `yaml:"global_menu"`  // Open global context menu
-	Shell             string `yaml:"shell"`        // Open shell session
-	VNC               string `yaml:"vnc"`          // Open VNC console
-	Refresh           string `yaml:"refresh"`      // Manual refresh
-	AutoRefresh       string `yaml:"auto_refresh"` // Toggle auto-refresh
-	Search            string `yaml:"search"`       // Activate search
-	Help              string `yaml:"help"`         // Toggle help modal
-	Quit              string `yaml:"quit"`         // Quit application
+	NodesPage         string `yaml:"nodes_page"`    // Jump to Nodes page
+	GuestsPage        string `yaml:"guests_page"`   // Jump to Guests page
+	TasksPage         string `yaml:"tasks_page"`    // Jump to Tasks page
+	Menu              string `yaml:"menu"`          // Open context menu
+	GlobalMenu        string `yaml:"global_menu"`   // Open global context menu
+	Shell             string `yaml:"shell"`         // Open shell session
+	VNC               string `yaml:"vnc"`           // Open VNC console
+	Refresh           string `yaml:"refresh"`       // Manual refresh
+	AutoRefresh       string `yaml:"auto_refresh"`  // Toggle auto-refresh
+	Search            string `yaml:"search"`        // Activate search
+	GlobalSearch      string `yaml:"global_search"` // Search across all entity types
+	ToggleZoom        string `yaml:"toggle_zoom"`   // Show the focused panel full-screen
+	Yank              string `yaml:"yank"`          // Copy the selected value to the clipboard
+	NodeGuests        string `yaml:"node_guests"`   // List the guests of the selected node and back
+	Sparklines        string `yaml:"sparklines"`    // Toggle the trend sparklines of the guest list
+	Help              string `yaml:"help"`          // Toggle help modal
+	Quit              string `yaml:"quit"`          // Quit application
 }
 
 // ThemeConfig defines theme-related configuration options.
@@ -146,6 +415,460 @@ type ThemeConfig struct {
 	Colors map[string]string `yaml:"colors"`
 }
 
+// SummaryConfig defines the content and size of the summary panel.
+type SummaryConfig struct {
+	// Mode is one of "cluster", "node", "tasks" or "none".
+	// If empty, defaults to "cluster".
+	Mode string `yaml:"mode"`
+	// Compact renders the summary as a single line without a border.
+	Compact bool `yaml:"compact"`
+}
+
+// SensorsConfig defines how node temperatures are read and highlighted.
+type SensorsConfig struct {
+	// SSH reads temperatures with `sensors -j` (lm-sensors) over SSH when the
+	// node status does not include them.
+	SSH bool `yaml:"ssh"`
+	// Warning and Critical are the temperatures (°C) at which readings are
+	// shown in the warning and error colors.
+	Warning  float64 `yaml:"warning"`
+	Critical float64 `yaml:"critical"`
+}
+
+// EnrichmentConfig defines which guests are enriched with detailed status
+// and guest agent data (network interfaces, filesystems).
+type EnrichmentConfig struct {
+	// Mode is EnrichmentModeEager (default) or EnrichmentModeLazy.
+	Mode string `yaml:"mode"`
+	// WarmUp enriches all running guests once in the background after
+	// startup in lazy mode.
+	WarmUp bool `yaml:"warm_up"`
+	// Recent is the number of recently viewed guests kept enriched across
+	// refreshes in lazy mode.
+	Recent int `yaml:"recent"`
+	// LXCSSH reads the load, process count and resource pressure of the
+	// selected running container over SSH, since containers have no guest
+	// agent.
+	LXCSSH bool `yaml:"lxc_ssh"`
+	// ARPFallback infers the IP of running guests that neither the guest
+	// agent nor the config report one for from the neighbor (ARP) table of
+	// their node, read over SSH.
+	ARPFallback bool `yaml:"arp_fallback"`
+}
+
+// IsLazy reports whether only the selected and recently viewed guests are enriched.
+func (e EnrichmentConfig) IsLazy() bool {
+	return e.Mode == EnrichmentModeLazy
+}
+
+// MetadataConfig defines how guest metadata such as owner or environment is
+// parsed from tags and notes.
+type MetadataConfig struct {
+	// Patterns are regular expressions matched against each tag and each
+	// notes line. The first capture group is the key, the second the value.
+	// If empty, DefaultMetadataPattern is used.
+	Patterns []string `yaml:"patterns"`
+	// Columns are metadata keys shown next to guests in the guest list.
+	Columns []string `yaml:"columns"`
+}
+
+// BackupsConfig defines when guests are flagged for missing backups.
+type BackupsConfig struct {
+	// MaxAgeDays raises an alert for guests whose last backup is older than
+	// this many days or that have never been backed up. 0 disables the alert.
+	MaxAgeDays int `yaml:"max_age_days"`
+}
+
+// MaxAge returns the backup age that raises an alert, 0 if disabled.
+func (b BackupsConfig) MaxAge() time.Duration {
+	return time.Duration(b.MaxAgeDays) * 24 * time.Hour
+}
+
+// SnapshotsConfig defines when snapshots are reported as stale.
+type SnapshotsConfig struct {
+	// MaxAgeDays reports snapshots older than this many days. 0 disables
+	// the age check.
+	MaxAgeDays int `yaml:"max_age_days"`
+	// Patterns are regular expressions; snapshots whose name matches one
+	// are reported regardless of their age.
+	Patterns []string `yaml:"patterns"`
+}
+
+// MaxAge returns the snapshot age that marks a snapshot as stale, 0 if
+// disabled.
+func (s SnapshotsConfig) MaxAge() time.Duration {
+	return time.Duration(s.MaxAgeDays) * 24 * time.Hour
+}
+
+// Enabled reports whether stale snapshots are looked for at all.
+func (s SnapshotsConfig) Enabled() bool {
+	return s.MaxAgeDays > 0 || len(s.Patterns) > 0
+}
+
+// ClockConfig defines when node clocks are flagged as out of sync.
+type ClockConfig struct {
+	// MaxSkewSeconds flags nodes whose clock differs from the rest of the
+	// cluster by more than this many seconds. 0 disables the check.
+	MaxSkewSeconds int `yaml:"max_skew_seconds"`
+}
+
+// MaxSkew returns the clock difference that flags a node, 0 if disabled.
+func (c ClockConfig) MaxSkew() time.Duration {
+	return time.Duration(c.MaxSkewSeconds) * time.Second
+}
+
+// CostsConfig defines the unit costs the cost report charges for the
+// resources configured for guests, per billing period.
+type CostsConfig struct {
+	// Currency is shown with the costs, e.g. "EUR" or "$".
+	Currency string `yaml:"currency"`
+	// VCPU is the cost of one virtual CPU.
+	VCPU float64 `yaml:"vcpu"`
+	// MemoryGB is the cost of one GiB of memory.
+	MemoryGB float64 `yaml:"memory_gb"`
+	// StorageGB is the cost of one GiB of disk.
+	StorageGB float64 `yaml:"storage_gb"`
+}
+
+// Enabled reports whether any unit cost is set.
+func (c CostsConfig) Enabled() bool {
+	return c.VCPU > 0 || c.MemoryGB > 0 || c.StorageGB > 0
+}
+
+// LockConfig defines the session lock, which hides the interface behind a
+// passphrase prompt while staying connected.
+type LockConfig struct {
+	// IdleMinutes locks the session after this many minutes without key
+	// presses. 0 disables the idle lock.
+	IdleMinutes int `yaml:"idle_minutes"`
+	// Passphrase unlocks the session. If empty, the password of the active
+	// profile is used.
+	Passphrase string `yaml:"passphrase"`
+}
+
+// IdleTimeout returns the idle time after which the session locks, 0 if
+// disabled.
+func (l LockConfig) IdleTimeout() time.Duration {
+	return time.Duration(l.IdleMinutes) * time.Minute
+}
+
+// Cache encryption modes select where the key of the encrypted cache
+// comes from.
+const (
+	CacheEncryptionOff        = "off"        // Plaintext cache
+	CacheEncryptionKeyring    = "keyring"    // Random key kept in the OS keyring
+	CacheEncryptionPassphrase = "passphrase" // Key derived from a passphrase
+)
+
+// CacheEncryptionModes lists the valid cache encryption modes.
+var CacheEncryptionModes = []string{CacheEncryptionOff, CacheEncryptionKeyring, CacheEncryptionPassphrase}
+
+// CacheConfig defines how the persistent cache of API responses is kept
+// on disk. Changes take effect on restart.
+type CacheConfig struct {
+	// Encryption is "off" (default), "keyring" or "passphrase".
+	Encryption string `yaml:"encryption"`
+	// Passphrase derives the key of the passphrase encryption.
+	// PVETUI_CACHE_PASSPHRASE overrides it.
+	Passphrase string `yaml:"passphrase"`
+	// PurgeOnExit deletes the cache when pvetui exits, for shared machines.
+	PurgeOnExit bool `yaml:"purge_on_exit"`
+	// StatusTTLSeconds is how long the status and usage of guests and nodes
+	// are cached. 0 keeps the default of an hour.
+	StatusTTLSeconds int `yaml:"status_ttl_seconds"`
+	// ConfigTTLSeconds is how long configurations and versions are cached.
+	// 0 keeps the default of an hour.
+	ConfigTTLSeconds int `yaml:"config_ttl_seconds"`
+	// AgentTTLSeconds is how long guest agent data, such as filesystems,
+	// and container interfaces are cached. 0 keeps the default of an hour.
+	AgentTTLSeconds int `yaml:"agent_ttl_seconds"`
+}
+
+// Encrypted reports whether the cache is encrypted.
+func (c CacheConfig) Encrypted() bool {
+	return c.Encryption != "" && c.Encryption != CacheEncryptionOff
+}
+
+// StatusTTL returns the cache TTL of status data, 0 for the default.
+func (c CacheConfig) StatusTTL() time.Duration {
+	return time.Duration(c.StatusTTLSeconds) * time.Second
+}
+
+// ConfigTTL returns the cache TTL of configurations, 0 for the default.
+func (c CacheConfig) ConfigTTL() time.Duration {
+	return time.Duration(c.ConfigTTLSeconds) * time.Second
+}
+
+// AgentTTL returns the cache TTL of guest agent data, 0 for the default.
+func (c CacheConfig) AgentTTL() time.Duration {
+	return time.Duration(c.AgentTTLSeconds) * time.Second
+}
+
+// PlacementConfig defines how target nodes are ranked.
+type PlacementConfig struct {
+	// Strategy is one of "balanced", "memory" or "cpu".
+	// If empty, defaults to "balanced".
+	Strategy string `yaml:"strategy"`
+}
+
+// EffectivePatterns returns the configured patterns or the default pattern.
+func (m MetadataConfig) EffectivePatterns() []string {
+	if len(m.Patterns) == 0 {
+		return []string{DefaultMetadataPattern}
+	}
+
+	return m.Patterns
+}
+
+// CustomAction defines a user command that can be run for a guest.
+//
+// The command is run through the system shell after replacing the
+// placeholders {ip}, {vmid}, {name}, {node} and {type} with the values of
+// the selected guest.
+type CustomAction struct {
+	// Name is the label shown in the guest context menu.
+	Name string `yaml:"name"`
+	// Command is the shell command template.
+	Command string `yaml:"command"`
+	// Mode is "suspend" (default) to run in the terminal while the UI is
+	// suspended, or "background" to run detached.
+	Mode string `yaml:"mode"`
+}
+
+// GuestService is a command run inside a guest, e.g. to restart a service.
+//
+// QEMU VMs run it with the guest agent and containers with `pct exec` over
+// SSH to their node, both through `sh -c`.
+type GuestService struct {
+	// Name is the label shown in the Services menu.
+	Name string `yaml:"name"`
+	// Command is the shell command run inside the guest.
+	Command string `yaml:"command"`
+	// Guests selects the guests offering the command by ID or by name, where
+	// names may use shell patterns like "web-*". Empty selects all guests.
+	Guests []string `yaml:"guests"`
+}
+
+// Matches reports whether the service is offered for the guest with the
+// given ID and name.
+func (s GuestService) Matches(id int, name string) bool {
+	return len(s.Guests) == 0 || matchGuest(s.Guests, id, name)
+}
+
+// matchGuest reports whether a guest is one of guests, given by ID or by a
+// name pattern.
+func matchGuest(guests []string, id int, name string) bool {
+	for _, guest := range guests {
+		if guest == strconv.Itoa(id) {
+			return true
+		}
+
+		if matched, _ := path.Match(guest, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GuestSSHRule sets the user and identity file of direct SSH sessions to
+// matching QEMU guests, which often differ from those of the nodes.
+type GuestSSHRule struct {
+	// Guests selects guests by ID or by name, where names may use shell
+	// patterns like "web-*".
+	Guests []string `yaml:"guests"`
+	// Tags selects guests with any of these Proxmox tags.
+	Tags []string `yaml:"tags"`
+	// User is the SSH user; empty keeps ssh_user.
+	User string `yaml:"user"`
+	// KeyFile is the identity file; a leading "~" is expanded. Empty leaves
+	// the choice to the SSH client configuration.
+	KeyFile string `yaml:"key_file"`
+}
+
+// Matches reports whether the rule applies to the guest with the given ID,
+// name and Proxmox tags. A rule without guests and tags applies to all.
+func (r GuestSSHRule) Matches(id int, name, tags string) bool {
+	if len(r.Guests) == 0 && len(r.Tags) == 0 {
+		return true
+	}
+
+	if matchGuest(r.Guests, id, name) {
+		return true
+	}
+
+	for _, tag := range strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	}) {
+		if slices.Contains(r.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GuestSSHLogin returns the SSH user and identity file for a guest from the
+// first matching guest_ssh rule, falling back to ssh_user and no identity
+// file.
+func (c *Config) GuestSSHLogin(id int, name, tags string) (user, keyFile string) {
+	user = c.SSHUser
+
+	for _, rule := range c.GuestSSH {
+		if rule.Matches(id, name, tags) {
+			if rule.User != "" {
+				user = rule.User
+			}
+
+			return user, rule.KeyFile
+		}
+	}
+
+	return user, ""
+}
+
+// NodeAddress returns the address SSH and other direct connections to a
+// node use: its entry in node_addresses, e.g. a VPN address, or else ip, the
+// address the cluster reports, which is often not routable from the
+// workstation.
+func (c *Config) NodeAddress(node, ip string) string {
+	if address := c.NodeAddresses[node]; address != "" {
+		return address
+	}
+
+	return ip
+}
+
+// ScriptSource is an additional script repository described by a manifest.
+//
+// Exactly one of Path (a local directory) or URL (the raw base URL of a git
+// repository) must be set. The manifest is read from manifest.yaml at its root.
+type ScriptSource struct {
+	// Name is the category name shown in the script selector.
+	Name string `yaml:"name"`
+	// Path is a local directory containing the manifest and scripts.
+	Path string `yaml:"path"`
+	// URL is the raw base URL serving the manifest and scripts.
+	URL string `yaml:"url"`
+}
+
+// Plugin is an external executable speaking the plugin protocol of
+// pkg/plugin. It can add guest menu actions, details sections and guest
+// list columns.
+type Plugin struct {
+	// Path is the plugin executable; a leading "~" is expanded.
+	Path string `yaml:"path"`
+	// Args are passed to the executable on every request.
+	Args []string `yaml:"args"`
+	// Timeout bounds a single request in seconds (default 10).
+	Timeout int `yaml:"timeout"`
+}
+
+// Hook is a command run before or after a guest action.
+//
+// The command is expanded like a custom action and may also reference the
+// text entered for Prompt as {input}. A pre hook that exits non-zero or
+// times out aborts the action.
+type Hook struct {
+	// Action is the guest action, e.g. "vm.stop".
+	Action string `yaml:"action"`
+	// When is "pre" or "post".
+	When string `yaml:"when"`
+	// Command is the shell command template.
+	Command string `yaml:"command"`
+	// Prompt asks for a value before the action, such as a ticket ID.
+	Prompt string `yaml:"prompt"`
+	// Timeout is how long the command may run before it is killed, in
+	// seconds (default DefaultHookTimeout).
+	Timeout int `yaml:"timeout"`
+}
+
+// CommandTimeout returns how long the hook command may run.
+func (h Hook) CommandTimeout() time.Duration {
+	if h.Timeout <= 0 {
+		return DefaultHookTimeout * time.Second
+	}
+
+	return time.Duration(h.Timeout) * time.Second
+}
+
+// Notification is an outbound backend that is sent new alerts and failed
+// tasks detected by the interface.
+type Notification struct {
+	// Type is "webhook", "gotify", "ntfy" or "slack".
+	Type string `yaml:"type"`
+	// URL is the webhook URL, the Gotify server, or the ntfy topic URL.
+	URL string `yaml:"url"`
+	// Token is the Gotify application token or an ntfy access token.
+	Token string `yaml:"token"`
+	// Events selects the notifications from "alerts", "tasks" and
+	// "schema_drift" (default alerts and tasks).
+	Events []string `yaml:"events"`
+}
+
+// Notifies reports whether the backend is sent the given event.
+func (n Notification) Notifies(event string) bool {
+	if len(n.Events) == 0 {
+		return slices.Contains(DefaultNotificationEvents, event)
+	}
+
+	return slices.Contains(n.Events, event)
+}
+
+// AffinityRule groups guests that should share a node (affinity) or run on
+// different nodes (anti-affinity). Guests can also join a group with a tag
+// named after the type and group, e.g. "anti-affinity-db".
+type AffinityRule struct {
+	// Group names the group, e.g. "db".
+	Group string `yaml:"group"`
+	// Type is "affinity" or "anti-affinity".
+	Type string `yaml:"type"`
+	// Guests are the VMIDs in the group, in addition to tagged guests.
+	Guests []int `yaml:"guests"`
+}
+
+// GuestGroup is a stack of guests that depend on each other, e.g. a
+// database, the application using it and a proxy in front. The guests are
+// started in order, each once the previous one passed its wait, and shut
+// down in reverse order.
+type GuestGroup struct {
+	// Name is the label shown in the menus.
+	Name string `yaml:"name"`
+	// Guests are the guests of the group in start order.
+	Guests []GroupGuest `yaml:"guests"`
+	// Timeout is how long to wait for each guest to start or shut down, in
+	// seconds (default DefaultGroupTimeout).
+	Timeout int `yaml:"timeout"`
+}
+
+// GroupGuest is a guest of a GuestGroup and the check it must pass before
+// the next guest starts.
+type GroupGuest struct {
+	// ID is the VMID of the guest.
+	ID int `yaml:"id"`
+	// Wait is one of GroupWaits (default "running").
+	Wait string `yaml:"wait"`
+	// Port is the TCP port checked with the "tcp" wait.
+	Port int `yaml:"port"`
+	// Host is the address checked with the "tcp" wait instead of the IP
+	// reported for the guest.
+	Host string `yaml:"host"`
+}
+
+// WaitTimeout returns how long the group waits for each guest.
+func (g GuestGroup) WaitTimeout() time.Duration {
+	if g.Timeout <= 0 {
+		return DefaultGroupTimeout * time.Second
+	}
+
+	return time.Duration(g.Timeout) * time.Second
+}
+
+// Contains reports whether the guest with the given VMID is in the group.
+func (g GuestGroup) Contains(id int) bool {
+	return slices.ContainsFunc(g.Guests, func(guest GroupGuest) bool { return guest.ID == id })
+}
+
 // DefaultKeyBindings returns a KeyBindings struct with the default key mappings.
 func DefaultKeyBindings() KeyBindings {
 	return KeyBindings{
@@ -161,6 +884,11 @@ func DefaultKeyBindings() KeyBindings {
 		Refresh:           "Ctrl+r",
 		AutoRefresh:       "a",
 		Search:            "/",
+		GlobalSearch:      "Ctrl+/",
+		ToggleZoom:        "z",
+		Yank:              "y",
+		NodeGuests:        "f",
+		Sparklines:        "t",
 		Help:              "?",
 		Quit:              "q",
 	}
@@ -181,6 +909,11 @@ func keyBindingsToMap(kb KeyBindings) map[string]string {
 		"refresh":             kb.Refresh,
 		"auto_refresh":        kb.AutoRefresh,
 		"search":              kb.Search,
+		"global_search":       kb.GlobalSearch,
+		"toggle_zoom":         kb.ToggleZoom,
+		"yank":                kb.Yank,
+		"node_guests":         kb.NodeGuests,
+		"sparklines":          kb.Sparklines,
 		"help":                kb.Help,
 		"quit":                kb.Quit,
 	}
@@ -255,18 +988,30 @@ func NewConfig() *Config {
 		Profiles:       make(map[string]ProfileConfig),
 		DefaultProfile: "default",
 		// Read environment variables for legacy fields
-		Addr:        os.Getenv("PVETUI_ADDR"),
-		User:        os.Getenv("PVETUI_USER"),
-		Password:    os.Getenv("PVETUI_PASSWORD"),
-		TokenID:     os.Getenv("PVETUI_TOKEN_ID"),
-		TokenSecret: os.Getenv("PVETUI_TOKEN_SECRET"),
-		Realm:       os.Getenv("PVETUI_REALM"),
-		ApiPath:     os.Getenv("PVETUI_API_PATH"),
-		Insecure:    strings.ToLower(os.Getenv("PVETUI_INSECURE")) == "true",
-		SSHUser:     os.Getenv("PVETUI_SSH_USER"),
-		Debug:       strings.ToLower(os.Getenv("PVETUI_DEBUG")) == "true",
-		CacheDir:    os.Getenv("PVETUI_CACHE_DIR"),
-		KeyBindings: DefaultKeyBindings(),
+		Addr:             os.Getenv("PVETUI_ADDR"),
+		User:             os.Getenv("PVETUI_USER"),
+		Password:         os.Getenv("PVETUI_PASSWORD"),
+		TokenID:          os.Getenv("PVETUI_TOKEN_ID"),
+		TokenSecret:      os.Getenv("PVETUI_TOKEN_SECRET"),
+		Realm:            os.Getenv("PVETUI_REALM"),
+		ApiPath:          os.Getenv("PVETUI_API_PATH"),
+		Insecure:         strings.ToLower(os.Getenv("PVETUI_INSECURE")) == "true",
+		SSHUser:          os.Getenv("PVETUI_SSH_USER"),
+		Debug:            strings.ToLower(os.Getenv("PVETUI_DEBUG")) == "true",
+		CacheDir:         os.Getenv("PVETUI_CACHE_DIR"),
+		Accessible:       strings.ToLower(os.Getenv("PVETUI_ACCESSIBLE")) == "true",
+		Quiet:            strings.ToLower(os.Getenv("PVETUI_QUIET")) == "true",
+		QuietExit:        strings.ToLower(os.Getenv("PVETUI_QUIET_EXIT")) == "true",
+		SkipVerification: strings.ToLower(os.Getenv("PVETUI_SKIP_VERIFY")) == "true",
+		CompactWidth:     DefaultCompactWidth,
+		GuestLimit:       DefaultGuestLimit,
+		MetricsHistory:   DefaultMetricsHistory,
+		Cache:            CacheConfig{Passphrase: os.Getenv("PVETUI_CACHE_PASSPHRASE")},
+		SSHMultiplex:     true,
+		KeyBindings:      DefaultKeyBindings(),
+		Sensors:          SensorsConfig{Warning: DefaultSensorsWarning, Critical: DefaultSensorsCritical},
+		Enrichment:       EnrichmentConfig{Mode: EnrichmentModeEager, Recent: DefaultRecentGuests},
+		Clock:            ClockConfig{MaxSkewSeconds: DefaultClockMaxSkew},
 	}
 
 	// Set default values for Realm and ApiPath only
@@ -289,6 +1034,129 @@ func init() {
 	configFs.StringVar(&configPath, "config", "", "Path to YAML config file")
 }
 
+// configFile is the layout of the YAML config file. It uses pointers to
+// distinguish between unset and explicitly set values.
+type configFile struct {
+	Profiles         map[string]ProfileConfig `yaml:"profiles"`
+	DefaultProfile   string                   `yaml:"default_profile"`
+	Debug            *bool                    `yaml:"debug"`
+	CacheDir         string                   `yaml:"cache_dir"`
+	LogFormat        string                   `yaml:"log_format"`
+	LogRaw           *bool                    `yaml:"log_raw"`
+	CompactWidth     *int                     `yaml:"compact_width"`
+	GuestLimit       *int                     `yaml:"guest_limit"`
+	MetricsHistory   *int                     `yaml:"metrics_history"`
+	Sparklines       *bool                    `yaml:"sparklines"`
+	StartPage        string                   `yaml:"start_page"`
+	DefaultFocus     string                   `yaml:"default_focus"`
+	Accessible       *bool                    `yaml:"accessible"`
+	Quiet            *bool                    `yaml:"quiet"`
+	QuietExit        *bool                    `yaml:"quiet_exit"`
+	SkipVerification *bool                    `yaml:"skip_verification"`
+	UpdateCheck      *bool                    `yaml:"update_check"`
+	SSHMultiplex     *bool                    `yaml:"ssh_multiplex"`
+	KeyBindings      struct {
+		SwitchView        string `yaml:"switch_view"`
+		SwitchViewReverse string `yaml:"switch_view_reverse"`
+		NodesPage         string `yaml:"nodes_page"`
+		GuestsPage        string `yaml:"guests_page"`
+		TasksPage         string `yaml:"tasks_page"`
+		Menu              string `yaml:"menu"`
+		GlobalMenu        string `yaml:"global_menu"`
+		Shell             string `yaml:"shell"`
+		VNC               string `yaml:"vnc"`
+		Scripts           string `yaml:"scripts"`
+		Refresh           string `yaml:"refresh"`
+		AutoRefresh       string `yaml:"auto_refresh"`
+		Search            string `yaml:"search"`
+		GlobalSearch      string `yaml:"global_search"`
+		ToggleZoom        string `yaml:"toggle_zoom"`
+		Yank              string `yaml:"yank"`
+		NodeGuests        string `yaml:"node_guests"`
+		Sparklines        string `yaml:"sparklines"`
+		Help              string `yaml:"help"`
+		Quit              string `yaml:"quit"`
+	} `yaml:"key_bindings"`
+	Theme struct {
+		Name   string            `yaml:"name"`
+		Colors map[string]string `yaml:"colors"`
+	} `yaml:"theme"`
+	Summary struct {
+		Mode    string `yaml:"mode"`
+		Compact *bool  `yaml:"compact"`
+	} `yaml:"summary"`
+	Sensors struct {
+		SSH      *bool    `yaml:"ssh"`
+		Warning  *float64 `yaml:"warning"`
+		Critical *float64 `yaml:"critical"`
+	} `yaml:"sensors"`
+	Enrichment struct {
+		Mode        string `yaml:"mode"`
+		WarmUp      *bool  `yaml:"warm_up"`
+		Recent      *int   `yaml:"recent"`
+		LXCSSH      *bool  `yaml:"lxc_ssh"`
+		ARPFallback *bool  `yaml:"arp_fallback"`
+	} `yaml:"enrichment"`
+	Metadata struct {
+		Patterns []string `yaml:"patterns"`
+		Columns  []string `yaml:"columns"`
+	} `yaml:"metadata"`
+	Backups struct {
+		MaxAgeDays *int `yaml:"max_age_days"`
+	} `yaml:"backups"`
+	Snapshots struct {
+		MaxAgeDays *int     `yaml:"max_age_days"`
+		Patterns   []string `yaml:"patterns"`
+	} `yaml:"snapshots"`
+	Clock struct {
+		MaxSkewSeconds *int `yaml:"max_skew_seconds"`
+	} `yaml:"clock"`
+	Costs struct {
+		Currency  string   `yaml:"currency"`
+		VCPU      *float64 `yaml:"vcpu"`
+		MemoryGB  *float64 `yaml:"memory_gb"`
+		StorageGB *float64 `yaml:"storage_gb"`
+	} `yaml:"costs"`
+	Placement struct {
+		Strategy string `yaml:"strategy"`
+	} `yaml:"placement"`
+	Lock struct {
+		IdleMinutes *int   `yaml:"idle_minutes"`
+		Passphrase  string `yaml:"passphrase"`
+	} `yaml:"lock"`
+	Cache struct {
+		Encryption       string `yaml:"encryption"`
+		Passphrase       string `yaml:"passphrase"`
+		PurgeOnExit      *bool  `yaml:"purge_on_exit"`
+		StatusTTLSeconds *int   `yaml:"status_ttl_seconds"`
+		ConfigTTLSeconds *int   `yaml:"config_ttl_seconds"`
+		AgentTTLSeconds  *int   `yaml:"agent_ttl_seconds"`
+	} `yaml:"cache"`
+	CustomActions []CustomAction `yaml:"custom_actions"`
+	GuestServices []GuestService `yaml:"guest_services"`
+	GuestSSH      []GuestSSHRule `yaml:"guest_ssh"`
+	ScriptSources []ScriptSource `yaml:"script_sources"`
+	Plugins       []Plugin       `yaml:"plugins"`
+	Hooks         []Hook         `yaml:"hooks"`
+	Notifications []Notification `yaml:"notifications"`
+	AffinityRules []AffinityRule `yaml:"affinity_rules"`
+	GuestGroups   []GuestGroup   `yaml:"guest_groups"`
+	// Legacy fields for migration
+	Addr        string `yaml:"addr"`
+	User        string `yaml:"user"`
+	Password    string `yaml:"password"`
+	TokenID     string `yaml:"token_id"`
+	TokenSecret string `yaml:"token_secret"`
+	Realm       string `yaml:"realm"`
+	ApiPath     string `yaml:"api_path"`
+	Insecure    *bool  `yaml:"insecure"`
+	SSHUser     string `yaml:"ssh_user"`
+	SSHPort     int    `yaml:"ssh_port"`
+	SSHKeyFile  string `yaml:"ssh_key_file"`
+
+	NodeAddresses map[string]string `yaml:"node_addresses"`
+}
+
 func ParseConfigFlags() {
 	_ = configFs.Parse(os.Args[1:]) // Parse just the --config flag first, ignore errors
 }
@@ -314,46 +1182,17 @@ func (c *Config) MergeWithFile(path string) error {
 		fmt.Printf("🔐 Decrypted config file: %s\n", path)
 	}
 
-	// Use a struct with pointers to distinguish between unset and explicitly set values
-	var fileConfig struct {
-		Profiles       map[string]ProfileConfig `yaml:"profiles"`
-		DefaultProfile string                   `yaml:"default_profile"`
-		Debug          *bool                    `yaml:"debug"`
-		CacheDir       string                   `yaml:"cache_dir"`
-		KeyBindings    struct {
-			SwitchView        string `yaml:"switch_view"`
-			SwitchViewReverse string `yaml:"switch_view_reverse"`
-			NodesPage         string `yaml:"nodes_page"`
-			GuestsPage        string `yaml:"guests_page"`
-			TasksPage         string `yaml:"tasks_page"`
-			Menu              string `yaml:"menu"`
-			GlobalMenu        string `yaml:"global_menu"`
-			Shell             string `yaml:"shell"`
-			VNC               string `yaml:"vnc"`
-			Scripts           string `yaml:"scripts"`
-			Refresh           string `yaml:"refresh"`
-			AutoRefresh       string `yaml:"auto_refresh"`
-			Search            string `yaml:"search"`
-			Help              string `yaml:"help"`
-			Quit              string `yaml:"quit"`
-		} `yaml:"key_bindings"`
-		Theme struct {
-			Name   string            `yaml:"name"`
-			Colors map[string]string `yaml:"colors"`
-		} `yaml:"theme"`
-		// Legacy fields for migration
-		Addr        string `yaml:"addr"`
-		User        string `yaml:"user"`
-		Password    string `yaml:"password"`
-		TokenID     string `yaml:"token_id"`
-		TokenSecret string `yaml:"token_secret"`
-		Realm       string `yaml:"realm"`
-		ApiPath     string `yaml:"api_path"`
-		Insecure    *bool  `yaml:"insecure"`
-		SSHUser     string `yaml:"ssh_user"`
-	}
+	// Schema problems are returned after merging, so callers that tolerate
+	// them (such as the config wizard) still get the rest of the file.
+	schemaErr := CheckSchema(data)
+
+	var fileConfig configFile
 
 	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+		if schemaErr != nil {
+			return schemaErr
+		}
+
 		return err
 	}
 
@@ -400,6 +1239,21 @@ func (c *Config) MergeWithFile(path string) error {
 				if fileProfile.SSHUser != "" {
 					existingProfile.SSHUser = fileProfile.SSHUser
 				}
+				if fileProfile.SSHPort != 0 {
+					existingProfile.SSHPort = fileProfile.SSHPort
+				}
+				if fileProfile.SSHKeyFile != "" {
+					existingProfile.SSHKeyFile = fileProfile.SSHKeyFile
+				}
+				if fileProfile.NodeAddresses != nil {
+					existingProfile.NodeAddresses = fileProfile.NodeAddresses
+				}
+				if fileProfile.OpenID {
+					existingProfile.OpenID = fileProfile.OpenID
+				}
+				if fileProfile.OpenIDRedirectURL != "" {
+					existingProfile.OpenIDRedirectURL = fileProfile.OpenIDRedirectURL
+				}
 
 				c.Profiles[name] = existingProfile
 			}
@@ -441,6 +1295,18 @@ func (c *Config) MergeWithFile(path string) error {
 		if fileConfig.SSHUser != "" {
 			c.SSHUser = fileConfig.SSHUser
 		}
+
+		if fileConfig.SSHPort != 0 {
+			c.SSHPort = fileConfig.SSHPort
+		}
+
+		if fileConfig.SSHKeyFile != "" {
+			c.SSHKeyFile = fileConfig.SSHKeyFile
+		}
+
+		if fileConfig.NodeAddresses != nil {
+			c.NodeAddresses = fileConfig.NodeAddresses
+		}
 	}
 
 	// Merge global settings
@@ -452,6 +1318,62 @@ func (c *Config) MergeWithFile(path string) error {
 		c.CacheDir = fileConfig.CacheDir
 	}
 
+	if fileConfig.LogFormat != "" {
+		c.LogFormat = fileConfig.LogFormat
+	}
+
+	if fileConfig.LogRaw != nil {
+		c.LogRaw = *fileConfig.LogRaw
+	}
+
+	if fileConfig.CompactWidth != nil {
+		c.CompactWidth = *fileConfig.CompactWidth
+	}
+
+	if fileConfig.GuestLimit != nil {
+		c.GuestLimit = *fileConfig.GuestLimit
+	}
+
+	if fileConfig.MetricsHistory != nil {
+		c.MetricsHistory = *fileConfig.MetricsHistory
+	}
+
+	if fileConfig.Sparklines != nil {
+		c.Sparklines = *fileConfig.Sparklines
+	}
+
+	if fileConfig.StartPage != "" {
+		c.StartPage = fileConfig.StartPage
+	}
+
+	if fileConfig.DefaultFocus != "" {
+		c.DefaultFocus = fileConfig.DefaultFocus
+	}
+
+	if fileConfig.Accessible != nil {
+		c.Accessible = *fileConfig.Accessible
+	}
+
+	if fileConfig.Quiet != nil {
+		c.Quiet = *fileConfig.Quiet
+	}
+
+	if fileConfig.QuietExit != nil {
+		c.QuietExit = *fileConfig.QuietExit
+	}
+
+	if fileConfig.SkipVerification != nil {
+		c.SkipVerification = *fileConfig.SkipVerification
+	}
+
+	if fileConfig.UpdateCheck != nil {
+		c.UpdateCheck = *fileConfig.UpdateCheck
+	}
+
+	if fileConfig.SSHMultiplex != nil {
+		c.SSHMultiplex = *fileConfig.SSHMultiplex
+	}
+
 	// Migrate legacy configuration to profile-based if needed
 	if migrated := c.MigrateLegacyToProfiles(); migrated {
 		fmt.Printf("🔄 Migrated legacy configuration to profile-based format\n")
@@ -472,6 +1394,11 @@ func (c *Config) MergeWithFile(path string) error {
 		Refresh           string `yaml:"refresh"`
 		AutoRefresh       string `yaml:"auto_refresh"`
 		Search            string `yaml:"search"`
+		GlobalSearch      string `yaml:"global_search"`
+		ToggleZoom        string `yaml:"toggle_zoom"`
+		Yank              string `yaml:"yank"`
+		NodeGuests        string `yaml:"node_guests"`
+		Sparklines        string `yaml:"sparklines"`
 		Help              string `yaml:"help"`
 		Quit              string `yaml:"quit"`
 	}{} {
@@ -523,8 +1450,28 @@ func (c *Config) MergeWithFile(path string) error {
 			c.KeyBindings.Search = kb.Search
 		}
 
-		if kb.Help != "" {
-			c.KeyBindings.Help = kb.Help
+		if kb.GlobalSearch != "" {
+			c.KeyBindings.GlobalSearch = kb.GlobalSearch
+		}
+
+		if kb.ToggleZoom != "" {
+			c.KeyBindings.ToggleZoom = kb.ToggleZoom
+		}
+
+		if kb.Yank != "" {
+			c.KeyBindings.Yank = kb.Yank
+		}
+
+		if kb.NodeGuests != "" {
+			c.KeyBindings.NodeGuests = kb.NodeGuests
+		}
+
+		if kb.Sparklines != "" {
+			c.KeyBindings.Sparklines = kb.Sparklines
+		}
+
+		if kb.Help != "" {
+			c.KeyBindings.Help = kb.Help
 		}
 
 		if kb.Quit != "" {
@@ -540,7 +1487,164 @@ func (c *Config) MergeWithFile(path string) error {
 		c.Theme.Colors[k] = v
 	}
 
-	return nil
+	// Merge summary panel configuration if provided
+	if fileConfig.Summary.Mode != "" {
+		c.Summary.Mode = fileConfig.Summary.Mode
+	}
+
+	if fileConfig.Summary.Compact != nil {
+		c.Summary.Compact = *fileConfig.Summary.Compact
+	}
+
+	// Merge sensors configuration if provided
+	if fileConfig.Sensors.SSH != nil {
+		c.Sensors.SSH = *fileConfig.Sensors.SSH
+	}
+
+	if fileConfig.Sensors.Warning != nil {
+		c.Sensors.Warning = *fileConfig.Sensors.Warning
+	}
+
+	if fileConfig.Sensors.Critical != nil {
+		c.Sensors.Critical = *fileConfig.Sensors.Critical
+	}
+
+	// Merge enrichment configuration if provided
+	if fileConfig.Enrichment.Mode != "" {
+		c.Enrichment.Mode = fileConfig.Enrichment.Mode
+	}
+
+	if fileConfig.Enrichment.WarmUp != nil {
+		c.Enrichment.WarmUp = *fileConfig.Enrichment.WarmUp
+	}
+
+	if fileConfig.Enrichment.Recent != nil {
+		c.Enrichment.Recent = *fileConfig.Enrichment.Recent
+	}
+
+	if fileConfig.Enrichment.LXCSSH != nil {
+		c.Enrichment.LXCSSH = *fileConfig.Enrichment.LXCSSH
+	}
+
+	if fileConfig.Enrichment.ARPFallback != nil {
+		c.Enrichment.ARPFallback = *fileConfig.Enrichment.ARPFallback
+	}
+
+	// Merge metadata configuration if provided
+	if len(fileConfig.Metadata.Patterns) > 0 {
+		c.Metadata.Patterns = fileConfig.Metadata.Patterns
+	}
+
+	if len(fileConfig.Metadata.Columns) > 0 {
+		c.Metadata.Columns = fileConfig.Metadata.Columns
+	}
+
+	if fileConfig.Backups.MaxAgeDays != nil {
+		c.Backups.MaxAgeDays = *fileConfig.Backups.MaxAgeDays
+	}
+
+	if fileConfig.Snapshots.MaxAgeDays != nil {
+		c.Snapshots.MaxAgeDays = *fileConfig.Snapshots.MaxAgeDays
+	}
+
+	if len(fileConfig.Snapshots.Patterns) > 0 {
+		c.Snapshots.Patterns = fileConfig.Snapshots.Patterns
+	}
+
+	if fileConfig.Clock.MaxSkewSeconds != nil {
+		c.Clock.MaxSkewSeconds = *fileConfig.Clock.MaxSkewSeconds
+	}
+
+	if fileConfig.Costs.Currency != "" {
+		c.Costs.Currency = fileConfig.Costs.Currency
+	}
+
+	if fileConfig.Costs.VCPU != nil {
+		c.Costs.VCPU = *fileConfig.Costs.VCPU
+	}
+
+	if fileConfig.Costs.MemoryGB != nil {
+		c.Costs.MemoryGB = *fileConfig.Costs.MemoryGB
+	}
+
+	if fileConfig.Costs.StorageGB != nil {
+		c.Costs.StorageGB = *fileConfig.Costs.StorageGB
+	}
+
+	if fileConfig.Placement.Strategy != "" {
+		c.Placement.Strategy = fileConfig.Placement.Strategy
+	}
+
+	if fileConfig.Lock.IdleMinutes != nil {
+		c.Lock.IdleMinutes = *fileConfig.Lock.IdleMinutes
+	}
+
+	if fileConfig.Lock.Passphrase != "" {
+		c.Lock.Passphrase = fileConfig.Lock.Passphrase
+	}
+
+	if fileConfig.Cache.Encryption != "" {
+		c.Cache.Encryption = fileConfig.Cache.Encryption
+	}
+
+	// The environment takes precedence over the passphrase in the file
+	if fileConfig.Cache.Passphrase != "" && os.Getenv("PVETUI_CACHE_PASSPHRASE") == "" {
+		c.Cache.Passphrase = fileConfig.Cache.Passphrase
+	}
+
+	if fileConfig.Cache.PurgeOnExit != nil {
+		c.Cache.PurgeOnExit = *fileConfig.Cache.PurgeOnExit
+	}
+
+	if fileConfig.Cache.StatusTTLSeconds != nil {
+		c.Cache.StatusTTLSeconds = *fileConfig.Cache.StatusTTLSeconds
+	}
+
+	if fileConfig.Cache.ConfigTTLSeconds != nil {
+		c.Cache.ConfigTTLSeconds = *fileConfig.Cache.ConfigTTLSeconds
+	}
+
+	if fileConfig.Cache.AgentTTLSeconds != nil {
+		c.Cache.AgentTTLSeconds = *fileConfig.Cache.AgentTTLSeconds
+	}
+
+	if len(fileConfig.CustomActions) > 0 {
+		c.CustomActions = fileConfig.CustomActions
+	}
+
+	if len(fileConfig.GuestSSH) > 0 {
+		c.GuestSSH = fileConfig.GuestSSH
+	}
+
+	if len(fileConfig.GuestServices) > 0 {
+		c.GuestServices = fileConfig.GuestServices
+	}
+
+	if len(fileConfig.ScriptSources) > 0 {
+		c.ScriptSources = fileConfig.ScriptSources
+	}
+
+	if len(fileConfig.Plugins) > 0 {
+		c.Plugins = fileConfig.Plugins
+	}
+
+	if len(fileConfig.Hooks) > 0 {
+		c.Hooks = fileConfig.Hooks
+	}
+
+	if len(fileConfig.Notifications) > 0 {
+		c.Notifications = fileConfig.Notifications
+	}
+
+	if len(fileConfig.AffinityRules) > 0 {
+		c.AffinityRules = fileConfig.AffinityRules
+	}
+
+	if len(fileConfig.GuestGroups) > 0 {
+		c.GuestGroups = fileConfig.GuestGroups
+	}
+
+	return schemaErr
 }
 
 func (c *Config) Validate() error {
@@ -565,7 +1669,8 @@ func (c *Config) Validate() error {
 				return errors.New("proxmox address required in " + label)
 			}
 
-			if selectedProfile.User == "" {
+			// OpenID users are named by the identity provider
+			if selectedProfile.User == "" && !selectedProfile.OpenID {
 				return errors.New("proxmox username required in " + label)
 			}
 
@@ -573,13 +1678,23 @@ func (c *Config) Validate() error {
 			hasPassword := selectedProfile.Password != ""
 			hasToken := selectedProfile.TokenID != "" && selectedProfile.TokenSecret != ""
 
-			if !hasPassword && !hasToken {
-				return errors.New("authentication required in " + label + ": provide either password or API token")
+			if !hasPassword && !hasToken && !selectedProfile.OpenID {
+				return errors.New("authentication required in " + label + ": provide either password, API token, or openid: true")
 			}
 
 			if hasPassword && hasToken {
 				return errors.New("conflicting authentication methods in " + label + ": provide either password or API token, not both")
 			}
+
+			if selectedProfile.OpenID && (hasPassword || hasToken) {
+				return errors.New("conflicting authentication methods in " + label + ": openid cannot be combined with password or API token")
+			}
+
+			if selectedProfile.OpenIDRedirectURL != "" {
+				if err := validateOpenIDRedirectURL(selectedProfile.OpenIDRedirectURL); err != nil {
+					return fmt.Errorf("openid_redirect_url in %s: %w", label, err)
+				}
+			}
 		}
 	} else {
 		// Validate legacy configuration
@@ -604,6 +1719,146 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.CompactWidth < 0 {
+		return errors.New("compact_width must not be negative")
+	}
+
+	if c.GuestLimit < 0 {
+		return errors.New("guest_limit must not be negative")
+	}
+
+	if c.MetricsHistory < 0 {
+		return errors.New("metrics_history must not be negative")
+	}
+
+	if c.StartPage != "" && !slices.Contains(StartPages, c.StartPage) {
+		return fmt.Errorf("invalid start_page '%s': must be one of %s", c.StartPage, strings.Join(StartPages, ", "))
+	}
+
+	if c.DefaultFocus != "" && !slices.Contains(FocusTargets, c.DefaultFocus) {
+		return fmt.Errorf("invalid default_focus '%s': must be one of %s", c.DefaultFocus, strings.Join(FocusTargets, ", "))
+	}
+
+	if c.SSHPort < 0 || c.SSHPort > 65535 {
+		return fmt.Errorf("invalid ssh_port %d: must be between 1 and 65535", c.SSHPort)
+	}
+
+	if err := validateNodeAddresses(c.NodeAddresses); err != nil {
+		return err
+	}
+
+	for name, profile := range c.Profiles {
+		if err := validateNodeAddresses(profile.NodeAddresses); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+	}
+
+	if c.LogFormat != "" && !slices.Contains(LogFormats, c.LogFormat) {
+		return fmt.Errorf("invalid log_format '%s': must be one of %s", c.LogFormat, strings.Join(LogFormats, ", "))
+	}
+
+	if c.Enrichment.Mode != "" && !slices.Contains(EnrichmentModes, c.Enrichment.Mode) {
+		return fmt.Errorf("invalid enrichment mode '%s': must be one of %s", c.Enrichment.Mode, strings.Join(EnrichmentModes, ", "))
+	}
+
+	if c.Enrichment.Recent < 0 {
+		return errors.New("enrichment recent must not be negative")
+	}
+
+	if c.Backups.MaxAgeDays < 0 {
+		return errors.New("backups max_age_days must not be negative")
+	}
+
+	if c.Snapshots.MaxAgeDays < 0 {
+		return errors.New("snapshots max_age_days must not be negative")
+	}
+
+	for _, pattern := range c.Snapshots.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid snapshot pattern '%s': %w", pattern, err)
+		}
+	}
+
+	if c.Clock.MaxSkewSeconds < 0 {
+		return errors.New("clock max_skew_seconds must not be negative")
+	}
+
+	if c.Costs.VCPU < 0 || c.Costs.MemoryGB < 0 || c.Costs.StorageGB < 0 {
+		return errors.New("costs must not be negative")
+	}
+
+	if c.Lock.IdleMinutes < 0 {
+		return errors.New("lock idle_minutes must not be negative")
+	}
+
+	if c.Cache.Encryption != "" && !slices.Contains(CacheEncryptionModes, c.Cache.Encryption) {
+		return fmt.Errorf("invalid cache encryption '%s': must be one of %s", c.Cache.Encryption, strings.Join(CacheEncryptionModes, ", "))
+	}
+
+	if c.Cache.Encryption == CacheEncryptionPassphrase && c.Cache.Passphrase == "" {
+		return errors.New("cache encryption 'passphrase' requires cache.passphrase or PVETUI_CACHE_PASSPHRASE")
+	}
+
+	if c.Cache.StatusTTLSeconds < 0 || c.Cache.ConfigTTLSeconds < 0 || c.Cache.AgentTTLSeconds < 0 {
+		return errors.New("cache TTLs must not be negative")
+	}
+
+	if c.Placement.Strategy != "" && !slices.Contains(PlacementStrategies, c.Placement.Strategy) {
+		return fmt.Errorf("invalid placement strategy '%s': must be one of %s", c.Placement.Strategy, strings.Join(PlacementStrategies, ", "))
+	}
+
+	if c.Summary.Mode != "" && !slices.Contains(SummaryModes, c.Summary.Mode) {
+		return fmt.Errorf("invalid summary mode '%s': must be one of %s", c.Summary.Mode, strings.Join(SummaryModes, ", "))
+	}
+
+	if c.Sensors.Warning < 0 || c.Sensors.Critical < 0 {
+		return errors.New("sensors warning and critical temperatures must not be negative")
+	}
+
+	if c.Sensors.Warning > 0 && c.Sensors.Critical > 0 && c.Sensors.Warning >= c.Sensors.Critical {
+		return fmt.Errorf("sensors warning temperature (%g) must be below the critical temperature (%g)", c.Sensors.Warning, c.Sensors.Critical)
+	}
+
+	if err := ValidateMetadata(c.Metadata); err != nil {
+		return err
+	}
+
+	if err := ValidateCustomActions(c.CustomActions); err != nil {
+		return err
+	}
+
+	if err := ValidateGuestSSH(c.GuestSSH); err != nil {
+		return err
+	}
+
+	if err := ValidateGuestServices(c.GuestServices); err != nil {
+		return err
+	}
+
+	if err := ValidateScriptSources(c.ScriptSources); err != nil {
+		return err
+	}
+
+	if err := ValidatePlugins(c.Plugins); err != nil {
+		return err
+	}
+
+	if err := ValidateHooks(c.Hooks); err != nil {
+		return err
+	}
+
+	if err := ValidateNotifications(c.Notifications); err != nil {
+		return err
+	}
+
+	if err := ValidateAffinityRules(c.AffinityRules); err != nil {
+		return err
+	}
+
+	if err := ValidateGuestGroups(c.GuestGroups); err != nil {
+		return err
+	}
+
 	if err := ValidateKeyBindings(c.KeyBindings); err != nil {
 		return err
 	}
@@ -611,11 +1866,346 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ValidateMetadata checks that every metadata pattern compiles and has
+// capture groups for the key and the value, and that columns are named.
+func ValidateMetadata(metadata MetadataConfig) error {
+	for _, pattern := range metadata.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid metadata pattern '%s': %w", pattern, err)
+		}
+
+		if re.NumSubexp() < 2 {
+			return fmt.Errorf("metadata pattern '%s' needs two capture groups (key and value)", pattern)
+		}
+	}
+
+	for i, column := range metadata.Columns {
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("metadata column #%d: key is required", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCustomActions checks that every custom action has a unique name,
+// a command, and a known run mode.
+func ValidateCustomActions(actions []CustomAction) error {
+	seen := make(map[string]bool)
+
+	for i, action := range actions {
+		if strings.TrimSpace(action.Name) == "" {
+			return fmt.Errorf("custom action #%d: name is required", i+1)
+		}
+
+		if seen[action.Name] {
+			return fmt.Errorf("custom action '%s': duplicate name", action.Name)
+		}
+
+		seen[action.Name] = true
+
+		if strings.TrimSpace(action.Command) == "" {
+			return fmt.Errorf("custom action '%s': command is required", action.Name)
+		}
+
+		switch action.Mode {
+		case "", CustomActionModeSuspend, CustomActionModeBackground:
+		default:
+			return fmt.Errorf("custom action '%s': invalid mode '%s': must be %s or %s",
+				action.Name, action.Mode, CustomActionModeSuspend, CustomActionModeBackground)
+		}
+	}
+
+	return nil
+}
+
+// ValidateGuestServices checks that every guest service has a unique name,
+// a command, and valid guest patterns.
+func ValidateGuestServices(services []GuestService) error {
+	seen := make(map[string]bool)
+
+	for i, service := range services {
+		if strings.TrimSpace(service.Name) == "" {
+			return fmt.Errorf("guest service #%d: name is required", i+1)
+		}
+
+		if seen[service.Name] {
+			return fmt.Errorf("guest service '%s': duplicate name", service.Name)
+		}
+
+		seen[service.Name] = true
+
+		if strings.TrimSpace(service.Command) == "" {
+			return fmt.Errorf("guest service '%s': command is required", service.Name)
+		}
+
+		for _, guest := range service.Guests {
+			if _, err := path.Match(guest, ""); err != nil || strings.TrimSpace(guest) == "" {
+				return fmt.Errorf("guest service '%s': invalid guest pattern '%s'", service.Name, guest)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateGuestSSH checks that every guest SSH rule sets a user or identity
+// file and has valid guest patterns.
+func ValidateGuestSSH(rules []GuestSSHRule) error {
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.User) == "" && strings.TrimSpace(rule.KeyFile) == "" {
+			return fmt.Errorf("guest_ssh rule #%d: user or key_file is required", i+1)
+		}
+
+		for _, guest := range rule.Guests {
+			if _, err := path.Match(guest, ""); err != nil || strings.TrimSpace(guest) == "" {
+				return fmt.Errorf("guest_ssh rule #%d: invalid guest pattern '%s'", i+1, guest)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateScriptSources checks that every script source has a unique name
+// and exactly one of a local path or an http(s) URL.
+func ValidateScriptSources(sources []ScriptSource) error {
+	seen := make(map[string]bool)
+
+	for i, source := range sources {
+		if strings.TrimSpace(source.Name) == "" {
+			return fmt.Errorf("script source #%d: name is required", i+1)
+		}
+
+		if seen[source.Name] {
+			return fmt.Errorf("script source '%s': duplicate name", source.Name)
+		}
+
+		seen[source.Name] = true
+
+		if (source.Path == "") == (source.URL == "") {
+			return fmt.Errorf("script source '%s': set either path or url", source.Name)
+		}
+
+		if source.URL != "" && !strings.HasPrefix(source.URL, "https://") && !strings.HasPrefix(source.URL, "http://") {
+			return fmt.Errorf("script source '%s': url must start with http:// or https://", source.Name)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePlugins checks that every plugin has a path and a non-negative
+// timeout.
+func ValidatePlugins(plugins []Plugin) error {
+	for i, plugin := range plugins {
+		if strings.TrimSpace(plugin.Path) == "" {
+			return fmt.Errorf("plugin #%d: path is required", i+1)
+		}
+
+		if plugin.Timeout < 0 {
+			return fmt.Errorf("plugin '%s': timeout must not be negative", plugin.Path)
+		}
+	}
+
+	return nil
+}
+
+// ValidateHooks checks that every hook has a known action and phase, a
+// command and no negative timeout.
+func ValidateHooks(hooks []Hook) error {
+	for i, hook := range hooks {
+		if !slices.Contains(HookActions, hook.Action) {
+			return fmt.Errorf("hook #%d: invalid action '%s': must be one of %s", i+1, hook.Action, strings.Join(HookActions, ", "))
+		}
+
+		if hook.When != HookPre && hook.When != HookPost {
+			return fmt.Errorf("hook #%d (%s): invalid when '%s': must be %s or %s", i+1, hook.Action, hook.When, HookPre, HookPost)
+		}
+
+		if strings.TrimSpace(hook.Command) == "" {
+			return fmt.Errorf("hook #%d (%s): command is required", i+1, hook.Action)
+		}
+
+		if hook.Timeout < 0 {
+			return fmt.Errorf("hook #%d (%s): timeout must not be negative", i+1, hook.Action)
+		}
+	}
+
+	return nil
+}
+
+// ValidateNotifications checks that every notification backend has a known
+// type, an http(s) URL and known events.
+func ValidateNotifications(notifications []Notification) error {
+	for i, notification := range notifications {
+		if !slices.Contains(NotificationTypes, notification.Type) {
+			return fmt.Errorf("notification #%d: invalid type '%s': must be one of %s", i+1, notification.Type, strings.Join(NotificationTypes, ", "))
+		}
+
+		if !strings.HasPrefix(notification.URL, "https://") && !strings.HasPrefix(notification.URL, "http://") {
+			return fmt.Errorf("notification #%d (%s): url must start with http:// or https://", i+1, notification.Type)
+		}
+
+		for _, event := range notification.Events {
+			if !slices.Contains(NotificationEvents, event) {
+				return fmt.Errorf("notification #%d (%s): invalid event '%s': must be one of %s", i+1, notification.Type, event, strings.Join(NotificationEvents, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAffinityRules checks that every affinity rule has a group name and
+// a known type.
+func ValidateAffinityRules(rules []AffinityRule) error {
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Group) == "" {
+			return fmt.Errorf("affinity rule #%d: group is required", i+1)
+		}
+
+		if !slices.Contains(AffinityTypes, rule.Type) {
+			return fmt.Errorf("affinity rule #%d (%s): invalid type '%s': must be one of %s", i+1, rule.Group, rule.Type, strings.Join(AffinityTypes, ", "))
+		}
+
+		for _, vmid := range rule.Guests {
+			if vmid <= 0 {
+				return fmt.Errorf("affinity rule #%d (%s): invalid guest ID %d", i+1, rule.Group, vmid)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateGuestGroups checks that every guest group has a unique name and
+// guests with valid waits.
+func ValidateGuestGroups(groups []GuestGroup) error {
+	names := make(map[string]bool)
+
+	for i, group := range groups {
+		if strings.TrimSpace(group.Name) == "" {
+			return fmt.Errorf("guest group #%d: name is required", i+1)
+		}
+
+		if names[group.Name] {
+			return fmt.Errorf("guest group #%d (%s): duplicate name", i+1, group.Name)
+		}
+
+		names[group.Name] = true
+
+		if len(group.Guests) == 0 {
+			return fmt.Errorf("guest group #%d (%s): guests are required", i+1, group.Name)
+		}
+
+		if group.Timeout < 0 {
+			return fmt.Errorf("guest group #%d (%s): timeout must not be negative", i+1, group.Name)
+		}
+
+		seen := make(map[int]bool)
+
+		for _, guest := range group.Guests {
+			if guest.ID <= 0 {
+				return fmt.Errorf("guest group #%d (%s): invalid guest ID %d", i+1, group.Name, guest.ID)
+			}
+
+			if seen[guest.ID] {
+				return fmt.Errorf("guest group #%d (%s): guest %d is listed twice", i+1, group.Name, guest.ID)
+			}
+
+			seen[guest.ID] = true
+
+			if guest.Wait != "" && !slices.Contains(GroupWaits, guest.Wait) {
+				return fmt.Errorf("guest group #%d (%s): guest %d: invalid wait '%s': must be one of %s", i+1, group.Name, guest.ID, guest.Wait, strings.Join(GroupWaits, ", "))
+			}
+
+			if guest.Wait == GroupWaitTCP && (guest.Port < 1 || guest.Port > 65535) {
+				return fmt.Errorf("guest group #%d (%s): guest %d: the tcp wait needs a port between 1 and 65535", i+1, group.Name, guest.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
 // IsUsingTokenAuth returns true if the configuration is set up for API token authentication.
 func (c *Config) IsUsingTokenAuth() bool {
 	return c.TokenID != "" && c.TokenSecret != ""
 }
 
+// IsUsingOpenID returns true if the active profile logs in through an OpenID
+// Connect realm.
+func (c *Config) IsUsingOpenID() bool {
+	profile, ok := c.currentProfile()
+
+	return ok && profile.OpenID
+}
+
+// GetOpenIDRedirectURL returns the URL the identity provider redirects to
+// after an OpenID login.
+func (c *Config) GetOpenIDRedirectURL() string {
+	if profile, ok := c.currentProfile(); ok && profile.OpenIDRedirectURL != "" {
+		return profile.OpenIDRedirectURL
+	}
+
+	return DefaultOpenIDRedirectURL
+}
+
+// validateNodeAddresses checks that node address overrides are host names
+// or IP addresses, without a scheme, port or path.
+func validateNodeAddresses(addresses map[string]string) error {
+	for node, address := range addresses {
+		if node == "" {
+			return errors.New("node_addresses: node name must not be empty")
+		}
+
+		if address == "" || (strings.ContainsAny(address, "/: \t") && net.ParseIP(address) == nil) {
+			return fmt.Errorf("node_addresses: invalid address '%s' for node %s: must be a host name or IP address", address, node)
+		}
+	}
+
+	return nil
+}
+
+// validateOpenIDRedirectURL checks that pvetui can listen at an OpenID
+// redirect URL: plain HTTP on the local machine with an explicit port.
+func validateOpenIDRedirectURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" || u.Port() == "" {
+		return errors.New("must be an http:// URL with a port, e.g. " + DefaultOpenIDRedirectURL)
+	}
+
+	if host := u.Hostname(); host != "localhost" && !isLoopbackIP(host) {
+		return errors.New("must point to this machine (localhost or 127.0.0.1)")
+	}
+
+	return nil
+}
+
+// isLoopbackIP reports whether host is a loopback IP address.
+func isLoopbackIP(host string) bool {
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// currentProfile returns the active profile, or the default profile.
+func (c *Config) currentProfile() (ProfileConfig, bool) {
+	for _, name := range []string{c.ActiveProfile, c.DefaultProfile} {
+		if profile, exists := c.Profiles[name]; exists && name != "" {
+			return profile, true
+		}
+	}
+
+	return ProfileConfig{}, false
+}
+
 // GetAPIToken returns the full API token string in the format required by Proxmox
 // Format: PVEAPIToken=USER@REALM!TOKENID=SECRET.
 func (c *Config) GetAPIToken() string {
@@ -761,6 +2351,38 @@ func (c *Config) SetDefaults() {
 		c.CacheDir = getCacheDir()
 	}
 
+	if c.Summary.Mode == "" {
+		c.Summary.Mode = SummaryModeCluster
+	}
+
+	if c.StartPage == "" {
+		c.StartPage = StartPageNodes
+	}
+
+	if c.DefaultFocus == "" {
+		c.DefaultFocus = FocusList
+	}
+
+	if c.Enrichment.Mode == "" {
+		c.Enrichment.Mode = EnrichmentModeEager
+	}
+
+	if c.Placement.Strategy == "" {
+		c.Placement.Strategy = PlacementStrategyBalanced
+	}
+
+	if c.LogFormat == "" {
+		c.LogFormat = LogFormatConsole
+	}
+
+	if c.Sensors.Warning == 0 {
+		c.Sensors.Warning = DefaultSensorsWarning
+	}
+
+	if c.Sensors.Critical == 0 {
+		c.Sensors.Critical = DefaultSensorsCritical
+	}
+
 	// Apply default key bindings if not set
 	defaults := DefaultKeyBindings()
 	if c.KeyBindings.SwitchView == "" {
@@ -811,6 +2433,26 @@ func (c *Config) SetDefaults() {
 		c.KeyBindings.Search = defaults.Search
 	}
 
+	if c.KeyBindings.GlobalSearch == "" {
+		c.KeyBindings.GlobalSearch = defaults.GlobalSearch
+	}
+
+	if c.KeyBindings.ToggleZoom == "" {
+		c.KeyBindings.ToggleZoom = defaults.ToggleZoom
+	}
+
+	if c.KeyBindings.Yank == "" {
+		c.KeyBindings.Yank = defaults.Yank
+	}
+
+	if c.KeyBindings.NodeGuests == "" {
+		c.KeyBindings.NodeGuests = defaults.NodeGuests
+	}
+
+	if c.KeyBindings.Sparklines == "" {
+		c.KeyBindings.Sparklines = defaults.Sparklines
+	}
+
 	if c.KeyBindings.Help == "" {
 		c.KeyBindings.Help = defaults.Help
 	}
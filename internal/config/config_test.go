@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"filippo.io/age"
 	"github.com/stretchr/testify/assert"
@@ -372,6 +373,482 @@ invalid: yaml: content:
 	}
 }
 
+func TestConfig_MergeWithFile_CompactWidth(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, DefaultCompactWidth, cfg.CompactWidth)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+compact_width: 0
+`), 0o600))
+
+	// An explicit zero disables the compact layout rather than keeping the default
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 0, cfg.CompactWidth)
+	require.NoError(t, cfg.Validate())
+
+	cfg.CompactWidth = -1
+	assert.ErrorContains(t, cfg.Validate(), "compact_width")
+}
+
+func TestConfig_MergeWithFile_GuestLimit(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, DefaultGuestLimit, cfg.GuestLimit)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+guest_limit: 0
+`), 0o600))
+
+	// An explicit zero lists all guests rather than keeping the default
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 0, cfg.GuestLimit)
+	require.NoError(t, cfg.Validate())
+
+	cfg.GuestLimit = -1
+	assert.ErrorContains(t, cfg.Validate(), "guest_limit")
+}
+
+func TestConfig_MergeWithFile_MetricsHistory(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, DefaultMetricsHistory, cfg.MetricsHistory)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+metrics_history: 360
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 360, cfg.MetricsHistory)
+	require.NoError(t, cfg.Validate())
+
+	cfg.MetricsHistory = -1
+	assert.ErrorContains(t, cfg.Validate(), "metrics_history")
+}
+
+func TestConfig_MergeWithFile_Enrichment(t *testing.T) {
+	cfg := NewConfig()
+	assert.False(t, cfg.Enrichment.IsLazy())
+	assert.Equal(t, DefaultRecentGuests, cfg.Enrichment.Recent)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+enrichment:
+  mode: lazy
+  warm_up: true
+  recent: 0
+  lxc_ssh: true
+  arp_fallback: true
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.True(t, cfg.Enrichment.IsLazy())
+	assert.True(t, cfg.Enrichment.WarmUp)
+	assert.True(t, cfg.Enrichment.LXCSSH)
+	assert.True(t, cfg.Enrichment.ARPFallback)
+	assert.Equal(t, 0, cfg.Enrichment.Recent)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enrichment.Mode = "sometimes"
+	assert.ErrorContains(t, cfg.Validate(), "enrichment mode")
+
+	cfg.Enrichment.Mode = EnrichmentModeLazy
+	cfg.Enrichment.Recent = -1
+	assert.ErrorContains(t, cfg.Validate(), "enrichment recent")
+}
+
+func TestConfig_MergeWithFile_Backups(t *testing.T) {
+	cfg := NewConfig()
+	assert.Zero(t, cfg.Backups.MaxAge())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+backups:
+  max_age_days: 2
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 48*time.Hour, cfg.Backups.MaxAge())
+	require.NoError(t, cfg.Validate())
+
+	cfg.Backups.MaxAgeDays = -1
+	assert.ErrorContains(t, cfg.Validate(), "max_age_days")
+}
+
+func TestConfig_MergeWithFile_CacheTTLs(t *testing.T) {
+	cfg := NewConfig()
+	assert.Zero(t, cfg.Cache.StatusTTL())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+cache:
+  status_ttl_seconds: 5
+  agent_ttl_seconds: 600
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 5*time.Second, cfg.Cache.StatusTTL())
+	assert.Zero(t, cfg.Cache.ConfigTTL())
+	assert.Equal(t, 10*time.Minute, cfg.Cache.AgentTTL())
+	require.NoError(t, cfg.Validate())
+
+	cfg.Cache.ConfigTTLSeconds = -1
+	assert.ErrorContains(t, cfg.Validate(), "cache TTLs")
+}
+
+func TestConfig_MergeWithFile_Clock(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, DefaultClockMaxSkew*time.Second, cfg.Clock.MaxSkew())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+clock:
+  max_skew_seconds: 0
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Zero(t, cfg.Clock.MaxSkew())
+	require.NoError(t, cfg.Validate())
+
+	cfg.Clock.MaxSkewSeconds = -1
+	assert.ErrorContains(t, cfg.Validate(), "max_skew_seconds")
+}
+
+func TestConfig_MergeWithFile_Costs(t *testing.T) {
+	cfg := NewConfig()
+	assert.False(t, cfg.Costs.Enabled())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+costs:
+  currency: EUR
+  vcpu: 4.5
+  memory_gb: 1.25
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.True(t, cfg.Costs.Enabled())
+	assert.Equal(t, "EUR", cfg.Costs.Currency)
+	assert.InDelta(t, 4.5, cfg.Costs.VCPU, 0.001)
+	assert.InDelta(t, 1.25, cfg.Costs.MemoryGB, 0.001)
+	assert.Zero(t, cfg.Costs.StorageGB)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Costs.StorageGB = -0.1
+	assert.ErrorContains(t, cfg.Validate(), "costs")
+}
+
+func TestConfig_MergeWithFile_Snapshots(t *testing.T) {
+	cfg := NewConfig()
+	assert.False(t, cfg.Snapshots.Enabled())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+snapshots:
+  max_age_days: 30
+  patterns:
+    - '^before-upgrade'
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.True(t, cfg.Snapshots.Enabled())
+	assert.Equal(t, 30*24*time.Hour, cfg.Snapshots.MaxAge())
+	assert.Equal(t, []string{"^before-upgrade"}, cfg.Snapshots.Patterns)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Snapshots.Patterns = []string{"(before"}
+	assert.ErrorContains(t, cfg.Validate(), "invalid snapshot pattern")
+
+	cfg.Snapshots.Patterns = nil
+	cfg.Snapshots.MaxAgeDays = -1
+	assert.ErrorContains(t, cfg.Validate(), "snapshots max_age_days")
+}
+
+func TestConfig_MergeWithFile_Lock(t *testing.T) {
+	cfg := NewConfig()
+	assert.Zero(t, cfg.Lock.IdleTimeout())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+lock:
+  idle_minutes: 15
+  passphrase: "change-me"
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, 15*time.Minute, cfg.Lock.IdleTimeout())
+	assert.Equal(t, "change-me", cfg.Lock.Passphrase)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Lock.IdleMinutes = -1
+	assert.ErrorContains(t, cfg.Validate(), "idle_minutes")
+}
+
+func TestConfig_MergeWithFile_LogFormat(t *testing.T) {
+	cfg := NewConfig()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+log_format: json
+log_raw: true
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, LogFormatJSON, cfg.LogFormat)
+	assert.True(t, cfg.LogRaw)
+	require.NoError(t, cfg.Validate())
+
+	cfg.LogFormat = "xml"
+	assert.ErrorContains(t, cfg.Validate(), "log_format")
+}
+
+func TestConfig_MergeWithFile_StartPage(t *testing.T) {
+	cfg := NewConfig()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+start_page: guests
+default_focus: details
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, StartPageGuests, cfg.StartPage)
+	assert.Equal(t, FocusDetails, cfg.DefaultFocus)
+	require.NoError(t, cfg.Validate())
+
+	cfg.StartPage = "storage"
+	assert.ErrorContains(t, cfg.Validate(), "start_page")
+
+	cfg.StartPage = ""
+	cfg.DefaultFocus = "search"
+	assert.ErrorContains(t, cfg.Validate(), "default_focus")
+
+	cfg = NewConfig()
+	cfg.SetDefaults()
+	assert.Equal(t, StartPageNodes, cfg.StartPage)
+	assert.Equal(t, FocusList, cfg.DefaultFocus)
+}
+
+func TestConfig_MergeWithFile_Accessible(t *testing.T) {
+	t.Setenv("PVETUI_ACCESSIBLE", "")
+
+	cfg := NewConfig()
+	assert.False(t, cfg.Accessible)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("accessible: true\n"), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.True(t, cfg.Accessible)
+
+	t.Setenv("PVETUI_ACCESSIBLE", "true")
+	assert.True(t, NewConfig().Accessible)
+}
+
+func TestConfig_MergeWithFile_QuietStartup(t *testing.T) {
+	t.Setenv("PVETUI_QUIET", "")
+	t.Setenv("PVETUI_QUIET_EXIT", "")
+	t.Setenv("PVETUI_SKIP_VERIFY", "")
+
+	cfg := NewConfig()
+	assert.False(t, cfg.Quiet)
+	assert.False(t, cfg.QuietExit)
+	assert.False(t, cfg.SkipVerification)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("quiet: true\nquiet_exit: true\nskip_verification: true\n"), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.True(t, cfg.Quiet)
+	assert.True(t, cfg.QuietExit)
+	assert.True(t, cfg.SkipVerification)
+
+	t.Setenv("PVETUI_SKIP_VERIFY", "true")
+	assert.True(t, NewConfig().SkipVerification)
+}
+
+func TestConfig_MergeWithFile_SSHMultiplex(t *testing.T) {
+	cfg := NewConfig()
+	assert.True(t, cfg.SSHMultiplex)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("ssh_multiplex: false\n"), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.False(t, cfg.SSHMultiplex)
+}
+
+func TestConfig_MergeWithFile_Sensors(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, SensorsConfig{Warning: DefaultSensorsWarning, Critical: DefaultSensorsCritical}, cfg.Sensors)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+sensors:
+  ssh: true
+  warning: 60
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, SensorsConfig{SSH: true, Warning: 60, Critical: DefaultSensorsCritical}, cfg.Sensors)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Sensors.Warning = 90
+	assert.ErrorContains(t, cfg.Validate(), "must be below the critical temperature")
+}
+
+func TestConfig_MergeWithFile_Metadata(t *testing.T) {
+	cfg := NewConfig()
+	assert.Equal(t, []string{DefaultMetadataPattern}, cfg.Metadata.EffectivePatterns())
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    password: secret
+default_profile: default
+metadata:
+  patterns:
+    - '^(owner|env)-(.+)$'
+  columns: [owner, env]
+`), 0o600))
+
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, []string{"^(owner|env)-(.+)$"}, cfg.Metadata.EffectivePatterns())
+	assert.Equal(t, []string{"owner", "env"}, cfg.Metadata.Columns)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Metadata.Patterns = []string{"^owner-.+$"}
+	assert.ErrorContains(t, cfg.Validate(), "needs two capture groups")
+
+	cfg.Metadata.Patterns = []string{"(owner"}
+	assert.ErrorContains(t, cfg.Validate(), "invalid metadata pattern")
+
+	cfg.Metadata.Patterns = nil
+	cfg.Metadata.Columns = []string{" "}
+	assert.ErrorContains(t, cfg.Validate(), "metadata column #1")
+}
+
+func TestConfig_SummaryConfig(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Addr = "https://pve.example.com:8006"
+	cfg.User = "root"
+	cfg.Password = "secret"
+
+	cfg.SetDefaults()
+	assert.Equal(t, SummaryModeCluster, cfg.Summary.Mode)
+	assert.False(t, cfg.Summary.Compact)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("summary:\n  mode: tasks\n  compact: true\n"), 0o600))
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, SummaryModeTasks, cfg.Summary.Mode)
+	assert.True(t, cfg.Summary.Compact)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Summary.Mode = "everything"
+	assert.ErrorContains(t, cfg.Validate(), "invalid summary mode")
+}
+
+func TestConfig_PlacementConfig(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Addr = "https://pve.example.com:8006"
+	cfg.User = "root"
+	cfg.Password = "secret"
+
+	cfg.SetDefaults()
+	assert.Equal(t, PlacementStrategyBalanced, cfg.Placement.Strategy)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("placement:\n  strategy: memory\n"), 0o600))
+	require.NoError(t, cfg.MergeWithFile(path))
+	assert.Equal(t, PlacementStrategyMemory, cfg.Placement.Strategy)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Placement.Strategy = "random"
+	assert.ErrorContains(t, cfg.Validate(), "invalid placement strategy")
+}
+
 func TestConfig_MergeWithEncryptedFile(t *testing.T) {
 	if _, err := exec.LookPath("sops"); err != nil {
 		t.Skip("sops binary not available")
@@ -503,6 +980,286 @@ func TestValidateKeyBindings(t *testing.T) {
 	})
 }
 
+func TestValidateCustomActions(t *testing.T) {
+	valid := []CustomAction{
+		{Name: "Ping", Command: "ping -c 3 {ip}"},
+		{Name: "Playbook", Command: "ansible-playbook -l {name} site.yml", Mode: CustomActionModeBackground},
+	}
+	assert.NoError(t, ValidateCustomActions(valid))
+
+	t.Run("missing name", func(t *testing.T) {
+		err := ValidateCustomActions([]CustomAction{{Command: "true"}})
+		assert.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		err := ValidateCustomActions([]CustomAction{{Name: "Ping"}})
+		assert.ErrorContains(t, err, "command is required")
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := ValidateCustomActions([]CustomAction{{Name: "Ping", Command: "a"}, {Name: "Ping", Command: "b"}})
+		assert.ErrorContains(t, err, "duplicate name")
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		err := ValidateCustomActions([]CustomAction{{Name: "Ping", Command: "a", Mode: "detached"}})
+		assert.ErrorContains(t, err, "invalid mode")
+	})
+}
+
+func TestValidateGuestServices(t *testing.T) {
+	valid := []GuestService{
+		{Name: "Restart nginx", Command: "systemctl restart nginx", Guests: []string{"100", "web-*"}},
+		{Name: "Disk usage", Command: "df -h"},
+	}
+	assert.NoError(t, ValidateGuestServices(valid))
+
+	t.Run("missing command", func(t *testing.T) {
+		err := ValidateGuestServices([]GuestService{{Name: "Restart nginx"}})
+		assert.ErrorContains(t, err, "command is required")
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := ValidateGuestServices([]GuestService{{Name: "df", Command: "df"}, {Name: "df", Command: "df -h"}})
+		assert.ErrorContains(t, err, "duplicate name")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		err := ValidateGuestServices([]GuestService{{Name: "df", Command: "df", Guests: []string{"web-["}}})
+		assert.ErrorContains(t, err, "invalid guest pattern")
+	})
+}
+
+func TestGuestService_Matches(t *testing.T) {
+	service := GuestService{Name: "Restart nginx", Command: "systemctl restart nginx", Guests: []string{"100", "web-*"}}
+
+	assert.True(t, service.Matches(100, "proxy"))
+	assert.True(t, service.Matches(205, "web-02"))
+	assert.False(t, service.Matches(1000, "db-01"))
+	assert.True(t, GuestService{Name: "df", Command: "df"}.Matches(1000, "db-01"))
+}
+
+func TestValidateGuestSSH(t *testing.T) {
+	assert.NoError(t, ValidateGuestSSH([]GuestSSHRule{{Guests: []string{"win-*"}, User: "Administrator"}, {KeyFile: "~/.ssh/guests"}}))
+
+	t.Run("missing user and key", func(t *testing.T) {
+		err := ValidateGuestSSH([]GuestSSHRule{{Tags: []string{"ubuntu"}}})
+		assert.ErrorContains(t, err, "user or key_file is required")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		err := ValidateGuestSSH([]GuestSSHRule{{Guests: []string{"web-["}, User: "deploy"}})
+		assert.ErrorContains(t, err, "invalid guest pattern")
+	})
+}
+
+func TestConfig_GuestSSHLogin(t *testing.T) {
+	cfg := &Config{
+		SSHUser: "root",
+		GuestSSH: []GuestSSHRule{
+			{Guests: []string{"100", "web-*"}, User: "deploy", KeyFile: "~/.ssh/deploy"},
+			{Tags: []string{"ubuntu"}, User: "ubuntu"},
+			{Tags: []string{"debian"}, KeyFile: "~/.ssh/debian"},
+		},
+	}
+
+	login := func(id int, name, tags string) []string {
+		user, keyFile := cfg.GuestSSHLogin(id, name, tags)
+
+		return []string{user, keyFile}
+	}
+
+	assert.Equal(t, []string{"deploy", "~/.ssh/deploy"}, login(100, "proxy", ""))
+	assert.Equal(t, []string{"deploy", "~/.ssh/deploy"}, login(205, "web-02", "ubuntu"))
+	assert.Equal(t, []string{"ubuntu", ""}, login(300, "ci", "prod;ubuntu"))
+	assert.Equal(t, []string{"root", "~/.ssh/debian"}, login(301, "mail", "debian"))
+	assert.Equal(t, []string{"root", ""}, login(302, "db-01", "postgres"))
+}
+
+func TestConfig_NodeAddress(t *testing.T) {
+	cfg := &Config{NodeAddresses: map[string]string{"pve1": "10.8.0.11"}}
+
+	assert.Equal(t, "10.8.0.11", cfg.NodeAddress("pve1", "192.168.1.11"))
+	assert.Equal(t, "192.168.1.12", cfg.NodeAddress("pve2", "192.168.1.12"))
+	assert.Equal(t, "192.168.1.12", (&Config{}).NodeAddress("pve2", "192.168.1.12"))
+}
+
+func TestValidateNodeAddresses(t *testing.T) {
+	assert.NoError(t, validateNodeAddresses(map[string]string{
+		"pve1": "10.8.0.11",
+		"pve2": "pve2.vpn.example.com",
+		"pve3": "fd00::13",
+	}))
+
+	for _, address := range []string{"", "pve1:22", "ssh://pve1", "pve 1"} {
+		assert.ErrorContains(t, validateNodeAddresses(map[string]string{"pve1": address}), "invalid address", address)
+	}
+
+	assert.ErrorContains(t, validateNodeAddresses(map[string]string{"": "10.8.0.11"}), "node name must not be empty")
+}
+
+func TestValidatePlugins(t *testing.T) {
+	assert.NoError(t, ValidatePlugins([]Plugin{{Path: "~/bin/pvetui-plugin-sample", Timeout: 5}}))
+
+	t.Run("missing path", func(t *testing.T) {
+		err := ValidatePlugins([]Plugin{{Args: []string{"--verbose"}}})
+		assert.ErrorContains(t, err, "path is required")
+	})
+
+	t.Run("negative timeout", func(t *testing.T) {
+		err := ValidatePlugins([]Plugin{{Path: "plugin", Timeout: -1}})
+		assert.ErrorContains(t, err, "must not be negative")
+	})
+}
+
+func TestValidateHooks(t *testing.T) {
+	valid := []Hook{
+		{Action: HookActionVMStop, When: HookPost, Command: "curl -X POST https://hooks.example.com/{vmid}"},
+		{Action: HookActionVMDelete, When: HookPre, Command: "check-ticket {input}", Prompt: "Ticket ID"},
+	}
+	assert.NoError(t, ValidateHooks(valid))
+
+	t.Run("unknown action", func(t *testing.T) {
+		err := ValidateHooks([]Hook{{Action: "node.reboot", When: HookPre, Command: "true"}})
+		assert.ErrorContains(t, err, "invalid action")
+	})
+
+	t.Run("invalid phase", func(t *testing.T) {
+		err := ValidateHooks([]Hook{{Action: HookActionVMStop, When: "after", Command: "true"}})
+		assert.ErrorContains(t, err, "invalid when")
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		err := ValidateHooks([]Hook{{Action: HookActionVMStop, When: HookPre}})
+		assert.ErrorContains(t, err, "command is required")
+	})
+
+	t.Run("negative timeout", func(t *testing.T) {
+		err := ValidateHooks([]Hook{{Action: HookActionVMStop, When: HookPre, Command: "true", Timeout: -1}})
+		assert.ErrorContains(t, err, "timeout must not be negative")
+	})
+
+	assert.Equal(t, DefaultHookTimeout*time.Second, valid[0].CommandTimeout())
+	assert.Equal(t, 5*time.Second, Hook{Timeout: 5}.CommandTimeout())
+}
+
+func TestValidateNotifications(t *testing.T) {
+	valid := []Notification{
+		{Type: NotificationNtfy, URL: "https://ntfy.sh/pvetui-alerts"},
+		{Type: NotificationGotify, URL: "http://gotify.lan", Token: "secret", Events: []string{NotificationEventTasks}},
+	}
+	assert.NoError(t, ValidateNotifications(valid))
+	assert.True(t, valid[0].Notifies(NotificationEventAlerts))
+	assert.False(t, valid[1].Notifies(NotificationEventAlerts))
+	assert.False(t, valid[0].Notifies(NotificationEventSchemaDrift))
+
+	t.Run("unknown type", func(t *testing.T) {
+		err := ValidateNotifications([]Notification{{Type: "email", URL: "https://example.com"}})
+		assert.ErrorContains(t, err, "invalid type")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		err := ValidateNotifications([]Notification{{Type: NotificationWebhook, URL: "hooks.example.com"}})
+		assert.ErrorContains(t, err, "url must start with")
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		err := ValidateNotifications([]Notification{{Type: NotificationSlack, URL: "https://hooks.slack.com/x", Events: []string{"backups"}}})
+		assert.ErrorContains(t, err, "invalid event")
+	})
+}
+
+func TestValidateAffinityRules(t *testing.T) {
+	assert.NoError(t, ValidateAffinityRules([]AffinityRule{
+		{Group: "db", Type: AffinitySeparate, Guests: []int{101, 102}},
+		{Group: "web", Type: AffinityTogether},
+	}))
+
+	t.Run("missing group", func(t *testing.T) {
+		err := ValidateAffinityRules([]AffinityRule{{Type: AffinitySeparate}})
+		assert.ErrorContains(t, err, "group is required")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		err := ValidateAffinityRules([]AffinityRule{{Group: "db", Type: "spread"}})
+		assert.ErrorContains(t, err, "invalid type")
+	})
+
+	t.Run("invalid guest", func(t *testing.T) {
+		err := ValidateAffinityRules([]AffinityRule{{Group: "db", Type: AffinitySeparate, Guests: []int{0}}})
+		assert.ErrorContains(t, err, "invalid guest ID")
+	})
+}
+
+func TestValidateGuestGroups(t *testing.T) {
+	stack := GuestGroup{Name: "lab", Guests: []GroupGuest{
+		{ID: 101, Wait: GroupWaitTCP, Port: 5432},
+		{ID: 102, Wait: GroupWaitAgent},
+		{ID: 103},
+	}}
+	assert.NoError(t, ValidateGuestGroups([]GuestGroup{stack}))
+	assert.True(t, stack.Contains(102))
+	assert.False(t, stack.Contains(104))
+	assert.Equal(t, DefaultGroupTimeout*time.Second, stack.WaitTimeout())
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := ValidateGuestGroups([]GuestGroup{stack, stack})
+		assert.ErrorContains(t, err, "duplicate name")
+	})
+
+	t.Run("no guests", func(t *testing.T) {
+		err := ValidateGuestGroups([]GuestGroup{{Name: "empty"}})
+		assert.ErrorContains(t, err, "guests are required")
+	})
+
+	t.Run("guest listed twice", func(t *testing.T) {
+		err := ValidateGuestGroups([]GuestGroup{{Name: "lab", Guests: []GroupGuest{{ID: 101}, {ID: 101}}}})
+		assert.ErrorContains(t, err, "listed twice")
+	})
+
+	t.Run("unknown wait", func(t *testing.T) {
+		err := ValidateGuestGroups([]GuestGroup{{Name: "lab", Guests: []GroupGuest{{ID: 101, Wait: "http"}}}})
+		assert.ErrorContains(t, err, "invalid wait")
+	})
+
+	t.Run("tcp without port", func(t *testing.T) {
+		err := ValidateGuestGroups([]GuestGroup{{Name: "lab", Guests: []GroupGuest{{ID: 101, Wait: GroupWaitTCP}}}})
+		assert.ErrorContains(t, err, "needs a port")
+	})
+}
+
+func TestValidateScriptSources(t *testing.T) {
+	valid := []ScriptSource{
+		{Name: "Team", URL: "https://raw.githubusercontent.com/example/scripts/main"},
+		{Name: "Local", Path: "/opt/pvetui-scripts"},
+	}
+	assert.NoError(t, ValidateScriptSources(valid))
+
+	t.Run("missing name", func(t *testing.T) {
+		err := ValidateScriptSources([]ScriptSource{{Path: "/tmp"}})
+		assert.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := ValidateScriptSources([]ScriptSource{{Name: "A", Path: "/a"}, {Name: "A", Path: "/b"}})
+		assert.ErrorContains(t, err, "duplicate name")
+	})
+
+	t.Run("path and url", func(t *testing.T) {
+		err := ValidateScriptSources([]ScriptSource{{Name: "A", Path: "/a", URL: "https://example.com"}})
+		assert.ErrorContains(t, err, "either path or url")
+
+		err = ValidateScriptSources([]ScriptSource{{Name: "A"}})
+		assert.ErrorContains(t, err, "either path or url")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		err := ValidateScriptSources([]ScriptSource{{Name: "A", URL: "git@github.com:example/scripts.git"}})
+		assert.ErrorContains(t, err, "http:// or https://")
+	})
+}
+
 func TestConfig_ProfileBasedConfiguration(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -539,6 +1296,52 @@ func TestConfig_ProfileBasedConfiguration(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid profile-based config with OpenID auth",
+			config: &Config{
+				Profiles: map[string]ProfileConfig{
+					"default": {
+						Addr:   "https://proxmox.example.com:8006",
+						Realm:  "company-sso",
+						OpenID: true,
+					},
+				},
+				DefaultProfile: "default",
+			},
+			expectError: false,
+		},
+		{
+			name: "OpenID combined with password",
+			config: &Config{
+				Profiles: map[string]ProfileConfig{
+					"default": {
+						Addr:     "https://proxmox.example.com:8006",
+						User:     "testuser",
+						Password: "testpass",
+						OpenID:   true,
+					},
+				},
+				DefaultProfile: "default",
+			},
+			expectError: true,
+			errorMsg:    "openid cannot be combined",
+		},
+		{
+			name: "OpenID redirect URL not on this machine",
+			config: &Config{
+				Profiles: map[string]ProfileConfig{
+					"default": {
+						Addr:              "https://proxmox.example.com:8006",
+						Realm:             "company-sso",
+						OpenID:            true,
+						OpenIDRedirectURL: "https://pvetui.example.com/",
+					},
+				},
+				DefaultProfile: "default",
+			},
+			expectError: true,
+			errorMsg:    "openid_redirect_url",
+		},
 		{
 			name: "missing default profile",
 			config: &Config{
@@ -579,6 +1382,23 @@ func TestConfig_ProfileBasedConfiguration(t *testing.T) {
 	}
 }
 
+func TestConfig_OpenID(t *testing.T) {
+	cfg := &Config{
+		Profiles: map[string]ProfileConfig{
+			"default": {Addr: "https://pve:8006", User: "root", Password: "secret"},
+			"sso":     {Addr: "https://pve:8006", Realm: "company-sso", OpenID: true, OpenIDRedirectURL: "http://127.0.0.1:9000/callback"},
+		},
+		DefaultProfile: "default",
+	}
+
+	assert.False(t, cfg.IsUsingOpenID())
+	assert.Equal(t, DefaultOpenIDRedirectURL, cfg.GetOpenIDRedirectURL())
+
+	cfg.ActiveProfile = "sso"
+	assert.True(t, cfg.IsUsingOpenID())
+	assert.Equal(t, "http://127.0.0.1:9000/callback", cfg.GetOpenIDRedirectURL())
+}
+
 func TestConfig_MergeWithFile_ProfileBased(t *testing.T) {
 	// Create a temporary directory for test files
 	tempDir := t.TempDir()
@@ -603,6 +1423,8 @@ profiles:
     addr: "https://secondary.example.com:8006"
     user: "secondaryuser"
     password: "secondarypass"
+    node_addresses:
+      pve1: "10.8.0.11"
 default_profile: "default"
 debug: true
 `
@@ -636,6 +1458,7 @@ debug: true
 	assert.Equal(t, "https://secondary.example.com:8006", secondaryProfile.Addr)
 	assert.Equal(t, "secondaryuser", secondaryProfile.User)
 	assert.Equal(t, "secondarypass", secondaryProfile.Password)
+	assert.Equal(t, map[string]string{"pve1": "10.8.0.11"}, secondaryProfile.NodeAddresses)
 
 	// Check global settings
 	assert.True(t, initialConfig.Debug)
@@ -699,6 +1522,25 @@ func TestConfig_MigrateLegacyToProfiles_NoLegacyFields(t *testing.T) {
 	assert.Equal(t, 0, len(cfg.Profiles))
 }
 
+func TestConfig_SetProfileToken(t *testing.T) {
+	cfg := &Config{
+		Profiles: map[string]ProfileConfig{
+			"default": {Addr: "https://pve.example.com:8006", User: "pvetui", Realm: "pve", TokenID: "tui", TokenSecret: "old"},
+			"lab":     {Addr: "https://lab.example.com:8006", User: "root", Realm: "pam", Password: "secret"},
+		},
+		DefaultProfile: "default",
+	}
+	require.NoError(t, cfg.ApplyProfile("default"))
+
+	require.NoError(t, cfg.SetProfileToken("default", "tui-20261016-1200", "new"))
+	assert.Equal(t, "tui-20261016-1200", cfg.Profiles["default"].TokenID)
+	assert.Equal(t, "new", cfg.Profiles["default"].TokenSecret)
+	assert.Equal(t, "PVEAPIToken=pvetui@pve!tui-20261016-1200=new", cfg.GetAPIToken())
+
+	assert.ErrorContains(t, cfg.SetProfileToken("lab", "tui", "new"), "does not use an API token")
+	assert.ErrorContains(t, cfg.SetProfileToken("missing", "tui", "new"), "not found")
+}
+
 func TestConfig_MigrateLegacyToProfiles_AlreadyHasProfiles(t *testing.T) {
 	// Test that migration doesn't happen when profiles already exist
 	cfg := &Config{
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EnvFileName is the dotenv file loaded from the config file's directory.
+const EnvFileName = ".env"
+
+// EnvVar describes an environment variable that overrides a config setting.
+type EnvVar struct {
+	// Name is the environment variable, e.g. "PVETUI_ADDR".
+	Name string
+	// Flag is the equivalent command line flag without dashes.
+	Flag string
+	// Description is a short explanation of the setting.
+	Description string
+	// Secret marks values that must not be printed.
+	Secret bool
+	// Value returns the setting from a loaded config.
+	Value func(c *Config) string
+}
+
+// EnvVars lists all supported environment variables in display order.
+var EnvVars = []EnvVar{
+	{Name: "PVETUI_PROFILE", Flag: "profile", Description: "Connection profile to use", Value: func(c *Config) string { return c.ActiveProfile }},
+	{Name: "PVETUI_ADDR", Flag: "addr", Description: "Proxmox API URL", Value: func(c *Config) string { return c.Addr }},
+	{Name: "PVETUI_USER", Flag: "user", Description: "Proxmox username", Value: func(c *Config) string { return c.User }},
+	{Name: "PVETUI_PASSWORD", Flag: "password", Description: "Proxmox password", Secret: true, Value: func(c *Config) string { return c.Password }},
+	{Name: "PVETUI_TOKEN_ID", Flag: "token-id", Description: "Proxmox API token ID", Value: func(c *Config) string { return c.TokenID }},
+	{Name: "PVETUI_TOKEN_SECRET", Flag: "token-secret", Description: "Proxmox API token secret", Secret: true, Value: func(c *Config) string { return c.TokenSecret }},
+	{Name: "PVETUI_REALM", Flag: "realm", Description: "Proxmox realm", Value: func(c *Config) string { return c.Realm }},
+	{Name: "PVETUI_API_PATH", Flag: "api-path", Description: "Proxmox API path", Value: func(c *Config) string { return c.ApiPath }},
+	{Name: "PVETUI_INSECURE", Flag: "insecure", Description: "Skip TLS verification", Value: func(c *Config) string { return boolSetting(c.Insecure) }},
+	{Name: "PVETUI_SSH_USER", Flag: "ssh-user", Description: "SSH username", Value: func(c *Config) string { return c.SSHUser }},
+	{Name: "PVETUI_DEBUG", Flag: "debug", Description: "Enable debug logging", Value: func(c *Config) string { return boolSetting(c.Debug) }},
+	{Name: "PVETUI_ACCESSIBLE", Description: "Use ASCII labels and high-contrast colors", Value: func(c *Config) string { return boolSetting(c.Accessible) }},
+	{Name: "PVETUI_QUIET", Flag: "quiet", Description: "Don't print startup progress messages", Value: func(c *Config) string { return boolSetting(c.Quiet) }},
+	{Name: "PVETUI_QUIET_EXIT", Flag: "quiet-exit", Description: "Don't print messages on exit", Value: func(c *Config) string { return boolSetting(c.QuietExit) }},
+	{Name: "PVETUI_SKIP_VERIFY", Flag: "skip-verify", Description: "Start without checking the connection first", Value: func(c *Config) string { return boolSetting(c.SkipVerification) }},
+	{Name: "PVETUI_CACHE_DIR", Flag: "cache-dir", Description: "Cache directory path", Value: func(c *Config) string { return c.CacheDir }},
+	{Name: "PVETUI_CACHE_PASSPHRASE", Description: "Passphrase of the encrypted cache", Secret: true, Value: func(c *Config) string { return c.Cache.Passphrase }},
+}
+
+// boolSetting formats a boolean setting, leaving false empty so it reads as unset.
+func boolSetting(b bool) string {
+	if !b {
+		return ""
+	}
+
+	return strconv.FormatBool(b)
+}
+
+// EnvFilePath returns the path of the dotenv file next to the config file,
+// or an empty string when no config file is used.
+func EnvFilePath(configPath string) string {
+	if configPath == "" {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(configPath), EnvFileName)
+}
+
+// EnvPrefix is the prefix of the environment variables read by pvetui.
+const EnvPrefix = "PVETUI_"
+
+// LoadEnvFile sets the pvetui variables defined in a dotenv file. Only names
+// starting with EnvPrefix are set, so the file cannot change variables such
+// as PATH or LD_PRELOAD that ssh and other child processes inherit; other
+// names are returned as ignored. Variables that are already set in the
+// environment are left unchanged, so the real environment takes precedence
+// over the file. A missing file is not an error.
+//
+// Lines have the form KEY=VALUE, optionally prefixed with "export".
+// Values may be wrapped in single or double quotes; lines starting
+// with # are ignored.
+func LoadEnvFile(path string) (loaded, ignored []string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return loaded, ignored, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+
+		if !strings.HasPrefix(name, EnvPrefix) {
+			ignored = append(ignored, name)
+
+			continue
+		}
+
+		if _, exists := os.LookupEnv(name); exists {
+			continue
+		}
+
+		if err := os.Setenv(name, unquoteEnvValue(strings.TrimSpace(value))); err != nil {
+			return loaded, ignored, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+
+		loaded = append(loaded, name)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return loaded, ignored, err
+	}
+
+	return loaded, ignored, nil
+}
+
+// unquoteEnvValue strips matching quotes from a dotenv value. Unquoted
+// values lose trailing " #" comments.
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		switch quote := value[0]; {
+		case quote == '"' && value[len(value)-1] == '"':
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+
+			return value[1 : len(value)-1]
+		case quote == '\'' && value[len(value)-1] == '\'':
+			return value[1 : len(value)-1]
+		}
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value
+}
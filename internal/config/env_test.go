@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EnvFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`# Proxmox connection
+PVETUI_TEST_ADDR=https://pve.example.com:8006
+export PVETUI_TEST_USER=root@pam
+PVETUI_TEST_PASSWORD="se cret"
+PVETUI_TEST_REALM='pve'
+PVETUI_TEST_DEBUG=true # enable logging
+PVETUI_TEST_PRESET=from-file
+LD_PRELOAD=/tmp/evil.so
+`), 0o600))
+
+	t.Setenv("PVETUI_TEST_PRESET", "from-env")
+
+	for _, name := range []string{"PVETUI_TEST_ADDR", "PVETUI_TEST_USER", "PVETUI_TEST_PASSWORD", "PVETUI_TEST_REALM", "PVETUI_TEST_DEBUG"} {
+		t.Setenv(name, "")
+		require.NoError(t, os.Unsetenv(name))
+	}
+
+	t.Setenv("LD_PRELOAD", "")
+	require.NoError(t, os.Unsetenv("LD_PRELOAD"))
+
+	loaded, ignored, err := LoadEnvFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"LD_PRELOAD"}, ignored)
+	_, set := os.LookupEnv("LD_PRELOAD")
+	assert.False(t, set, "only pvetui variables are loaded")
+
+	assert.Equal(t, []string{"PVETUI_TEST_ADDR", "PVETUI_TEST_USER", "PVETUI_TEST_PASSWORD", "PVETUI_TEST_REALM", "PVETUI_TEST_DEBUG"}, loaded)
+	assert.Equal(t, "https://pve.example.com:8006", os.Getenv("PVETUI_TEST_ADDR"))
+	assert.Equal(t, "root@pam", os.Getenv("PVETUI_TEST_USER"))
+	assert.Equal(t, "se cret", os.Getenv("PVETUI_TEST_PASSWORD"))
+	assert.Equal(t, "pve", os.Getenv("PVETUI_TEST_REALM"))
+	assert.Equal(t, "true", os.Getenv("PVETUI_TEST_DEBUG"))
+	assert.Equal(t, "from-env", os.Getenv("PVETUI_TEST_PRESET"), "environment takes precedence over the file")
+}
+
+func TestLoadEnvFile_Missing(t *testing.T) {
+	loaded, ignored, err := LoadEnvFile(filepath.Join(t.TempDir(), EnvFileName))
+	assert.NoError(t, err)
+	assert.Empty(t, loaded)
+	assert.Empty(t, ignored)
+}
+
+func TestLoadEnvFile_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), EnvFileName)
+	require.NoError(t, os.WriteFile(path, []byte("PVETUI_ADDR\n"), 0o600))
+
+	_, _, err := LoadEnvFile(path)
+	assert.ErrorContains(t, err, ":1: expected KEY=VALUE")
+}
+
+func TestEnvFilePath(t *testing.T) {
+	assert.Equal(t, "", EnvFilePath(""))
+	assert.Equal(t, filepath.Join("/etc/pvetui", EnvFileName), EnvFilePath("/etc/pvetui/config.yml"))
+}
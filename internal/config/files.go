@@ -42,7 +42,7 @@ func CreateDefaultConfigFile() (string, error) {
 	}
 
 	// Write template to config file
-	if err := os.WriteFile(configPath, templateData, 0o600); err != nil {
+	if err := os.WriteFile(configPath, templateData, FileMode); err != nil {
 		return "", fmt.Errorf("write config file: %w", err)
 	}
 
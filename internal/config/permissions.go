@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// FileMode is the mode config files are written with: readable and
+// writable by their owner only.
+const FileMode os.FileMode = 0o600
+
+// ExposedPermissions reports whether the file at path can be read or
+// written by other users than its owner, and returns its permission bits.
+// File modes don't restrict access on Windows, so files are never reported
+// as exposed there.
+func ExposedPermissions(path string) (os.FileMode, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false, err
+	}
+
+	mode := info.Mode().Perm()
+
+	return mode, runtime.GOOS != "windows" && mode&0o077 != 0, nil
+}
+
+// RestrictPermissions makes the file at path accessible to its owner only.
+func RestrictPermissions(path string) error {
+	if err := os.Chmod(path, FileMode); err != nil {
+		return fmt.Errorf("restrict permissions of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// HasSecrets reports whether the config holds credentials: profile
+// passwords or token secrets, the lock or cache passphrase, or notification
+// tokens.
+func (c *Config) HasSecrets() bool {
+	// The legacy fields are skipped, as they may come from the environment
+	if c.Lock.Passphrase != "" || (c.Cache.Passphrase != "" && os.Getenv("PVETUI_CACHE_PASSPHRASE") == "") {
+		return true
+	}
+
+	for _, profile := range c.Profiles {
+		if profile.Password != "" || profile.TokenSecret != "" {
+			return true
+		}
+	}
+
+	for _, notification := range c.Notifications {
+		if notification.Token != "" {
+			return true
+		}
+	}
+
+	return false
+}
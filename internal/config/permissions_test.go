@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposedPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not checked on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, nil, FileMode))
+	require.NoError(t, os.Chmod(path, 0o640))
+
+	mode, exposed, err := ExposedPermissions(path)
+	require.NoError(t, err)
+	assert.True(t, exposed)
+	assert.Equal(t, os.FileMode(0o640), mode)
+
+	require.NoError(t, RestrictPermissions(path))
+
+	mode, exposed, err = ExposedPermissions(path)
+	require.NoError(t, err)
+	assert.False(t, exposed)
+	assert.Equal(t, FileMode, mode)
+
+	_, _, err = ExposedPermissions(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
+
+func TestConfig_HasSecrets(t *testing.T) {
+	cfg := &Config{Profiles: map[string]ProfileConfig{"default": {Addr: "https://pve:8006", User: "root"}}}
+	assert.False(t, cfg.HasSecrets())
+
+	cfg.Profiles["work"] = ProfileConfig{TokenID: "pvetui", TokenSecret: "s3cr3t"}
+	assert.True(t, cfg.HasSecrets())
+
+	cfg = &Config{Notifications: []Notification{{Type: NotificationGotify, Token: "app-token"}}}
+	assert.True(t, cfg.HasSecrets())
+
+	cfg = &Config{Lock: LockConfig{Passphrase: "change-me"}}
+	assert.True(t, cfg.HasSecrets())
+}
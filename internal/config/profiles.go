@@ -20,6 +20,18 @@ type ProfileConfig struct {
 	ApiPath     string `yaml:"api_path"`
 	Insecure    bool   `yaml:"insecure"`
 	SSHUser     string `yaml:"ssh_user"`
+	SSHPort     int    `yaml:"ssh_port,omitempty"`
+	SSHKeyFile  string `yaml:"ssh_key_file,omitempty"`
+	// NodeAddresses overrides the addresses SSH and other direct
+	// connections to nodes use, by node name.
+	NodeAddresses map[string]string `yaml:"node_addresses,omitempty"`
+	// OpenID logs in through the OpenID Connect realm in Realm, in a
+	// browser, instead of with a password or token.
+	OpenID bool `yaml:"openid,omitempty"`
+	// OpenIDRedirectURL is where the identity provider sends the browser
+	// after the login; pvetui listens there. Defaults to
+	// DefaultOpenIDRedirectURL.
+	OpenIDRedirectURL string `yaml:"openid_redirect_url,omitempty"`
 }
 
 // ApplyProfile applies the settings from a named profile to the main config.
@@ -43,6 +55,9 @@ func (c *Config) ApplyProfile(profileName string) error {
 	c.ApiPath = profile.ApiPath
 	c.Insecure = profile.Insecure
 	c.SSHUser = profile.SSHUser
+	c.SSHPort = profile.SSHPort
+	c.SSHKeyFile = profile.SSHKeyFile
+	c.NodeAddresses = profile.NodeAddresses
 
 	// Mark runtime active profile so getters resolve to this profile without changing persisted default
 	c.ActiveProfile = profileName
@@ -55,7 +70,8 @@ func (c *Config) MigrateLegacyToProfiles() bool {
 	// Check if we have legacy fields but no profiles
 	hasLegacyFields := c.Addr != "" || c.User != "" || c.Password != "" ||
 		c.TokenID != "" || c.TokenSecret != "" || c.Realm != "" ||
-		c.ApiPath != "" || c.SSHUser != ""
+		c.ApiPath != "" || c.SSHUser != "" || c.SSHPort != 0 || c.SSHKeyFile != "" ||
+		len(c.NodeAddresses) > 0
 
 	if !hasLegacyFields || len(c.Profiles) > 0 {
 		return false
@@ -77,6 +93,10 @@ func (c *Config) MigrateLegacyToProfiles() bool {
 		ApiPath:     c.ApiPath,
 		Insecure:    c.Insecure,
 		SSHUser:     c.SSHUser,
+		SSHPort:     c.SSHPort,
+		SSHKeyFile:  c.SSHKeyFile,
+
+		NodeAddresses: c.NodeAddresses,
 	}
 
 	// Set default profile
@@ -92,6 +112,9 @@ func (c *Config) MigrateLegacyToProfiles() bool {
 	c.ApiPath = ""
 	c.Insecure = false
 	c.SSHUser = ""
+	c.SSHPort = 0
+	c.SSHKeyFile = ""
+	c.NodeAddresses = nil
 
 	return true
 }
@@ -105,7 +128,8 @@ func (p *ProfileConfig) Validate() error {
 	// Normalize address
 	p.Addr = strings.TrimRight(p.Addr, "/")
 
-	if p.User == "" {
+	// OpenID users are named by the identity provider
+	if p.User == "" && !p.OpenID {
 		return fmt.Errorf("profile username is required")
 	}
 
@@ -113,14 +137,22 @@ func (p *ProfileConfig) Validate() error {
 	hasPassword := p.Password != ""
 	hasToken := p.TokenID != "" && p.TokenSecret != ""
 
-	if !hasPassword && !hasToken {
-		return fmt.Errorf("profile must have either password or token authentication")
+	if !hasPassword && !hasToken && !p.OpenID {
+		return fmt.Errorf("profile must have either password, token or OpenID authentication")
 	}
 
 	if hasPassword && hasToken {
 		return fmt.Errorf("profile cannot have both password and token authentication")
 	}
 
+	if p.OpenID && (hasPassword || hasToken) {
+		return fmt.Errorf("profile cannot combine OpenID with password or token authentication")
+	}
+
+	if p.SSHPort < 0 || p.SSHPort > 65535 {
+		return fmt.Errorf("profile ssh_port must be between 1 and 65535")
+	}
+
 	if p.Realm == "" {
 		p.Realm = "pam" // Default realm
 	}
@@ -128,6 +160,30 @@ func (p *ProfileConfig) Validate() error {
 	return nil
 }
 
+// SetProfileToken replaces the API token of a profile, e.g. after the token
+// was rotated. The settings applied from the active profile are updated too.
+func (c *Config) SetProfileToken(profileName, tokenID, tokenSecret string) error {
+	profile, exists := c.Profiles[profileName]
+	if !exists {
+		return fmt.Errorf("profile '%s' not found", profileName)
+	}
+
+	if profile.TokenID == "" {
+		return fmt.Errorf("profile '%s' does not use an API token", profileName)
+	}
+
+	profile.TokenID = tokenID
+	profile.TokenSecret = tokenSecret
+	c.Profiles[profileName] = profile
+
+	if c.GetActiveProfile() == profileName {
+		c.TokenID = tokenID
+		c.TokenSecret = tokenSecret
+	}
+
+	return nil
+}
+
 // GetProfileNames returns a list of available profile names.
 func (c *Config) GetProfileNames() []string {
 	if c.Profiles == nil {
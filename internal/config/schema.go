@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaError describes a problem at a specific position of the config file.
+type SchemaError struct {
+	Line   int
+	Column int
+	// Path is the dotted key path of the offending value, e.g. "profiles.default.password".
+	Path    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// SchemaErrors is the list of problems found by CheckSchema.
+type SchemaErrors []SchemaError
+
+// Error implements the error interface, listing one problem per line.
+func (e SchemaErrors) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("config file has %d problem(s):", len(e)))
+
+	for _, err := range e {
+		lines = append(lines, "  "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CheckSchema validates raw YAML config data against the config file layout.
+// It reports unknown keys (with a suggestion for likely typos), values of the
+// wrong type, and profiles that set both password and API token
+// authentication. It returns SchemaErrors or a YAML syntax error.
+func CheckSchema(data []byte) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	if len(root.Content) == 0 {
+		return nil // Empty file
+	}
+
+	var errs SchemaErrors
+
+	doc := root.Content[0]
+	checkNode(doc, reflect.TypeOf(configFile{}), "", &errs)
+
+	if doc.Kind == yaml.MappingNode {
+		checkAuthExclusive(doc, "", &errs)
+
+		if profiles := mappingValue(doc, "profiles"); profiles != nil && profiles.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(profiles.Content); i += 2 {
+				checkAuthExclusive(profiles.Content[i+1], "profiles."+profiles.Content[i].Value, &errs)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+
+		return errs[i].Column < errs[j].Column
+	})
+
+	return errs
+}
+
+// checkNode compares a YAML node with the Go type it is decoded into.
+func checkNode(node *yaml.Node, t reflect.Type, path string, errs *SchemaErrors) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return // Explicitly empty values are allowed everywhere
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, SchemaError{Line: node.Line, Column: node.Column, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			fail("expected a mapping of keys, got %s", describeNode(node))
+
+			return
+		}
+
+		fields := structFields(t)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			field, ok := fields[key.Value]
+			if !ok {
+				message := fmt.Sprintf("unknown key %q", key.Value)
+				if suggestion := suggestKey(key.Value, fields); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+
+				*errs = append(*errs, SchemaError{Line: key.Line, Column: key.Column, Path: path, Message: message})
+
+				continue
+			}
+
+			checkNode(value, field, joinPath(path, key.Value), errs)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			fail("expected a mapping of keys, got %s", describeNode(node))
+
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkNode(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value), errs)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			fail("expected a list, got %s", describeNode(node))
+
+			return
+		}
+
+		for i, item := range node.Content {
+			checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			fail("expected a string, got %s", describeNode(node))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			fail("expected true or false, got %s", describeNode(node))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			fail("expected a whole number, got %s", describeNode(node))
+		}
+	case reflect.Float32, reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			fail("expected a number, got %s", describeNode(node))
+		}
+	}
+}
+
+// checkAuthExclusive reports a profile (or the legacy top level) that sets
+// both a password and an API token.
+func checkAuthExclusive(node *yaml.Node, path string, errs *SchemaErrors) {
+	if node.Kind != yaml.MappingNode || !hasValue(mappingValue(node, "password")) {
+		return
+	}
+
+	for _, name := range []string{"token_id", "token_secret"} {
+		if key := mappingKey(node, name); key != nil && hasValue(mappingValue(node, name)) {
+			*errs = append(*errs, SchemaError{
+				Line:    key.Line,
+				Column:  key.Column,
+				Path:    joinPath(path, name),
+				Message: "password and API token authentication are mutually exclusive; remove one of them",
+			})
+
+			return
+		}
+	}
+}
+
+// structFields maps the YAML keys of a struct type to their field types.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// suggestKey returns the known key closest to an unknown one, if it is close
+// enough to likely be a typo.
+func suggestKey(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 3 // Only suggest within two edits
+
+	for name := range fields {
+		if d := editDistance(key, name); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Damerau-Levenshtein (optimal string alignment)
+// distance between a and b, so swapped letters count as one edit.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func hasValue(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag != "!!null" && node.Value != ""
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// describeNode returns a short description of a YAML value for error messages.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []SchemaError
+	}{
+		{
+			name: "valid profile config",
+			content: `
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    user: root
+    token_id: tui
+    token_secret: secret
+    insecure: true
+    ssh_port: 2222
+default_profile: default
+compact_width: 80
+sensors:
+  ssh: true
+  warning: 65.5
+  critical: 90
+theme:
+  colors:
+    primary: "#ff0000"
+custom_actions:
+  - name: Ping
+    command: "ping -c 1 {ip}"
+`,
+		},
+		{
+			name:    "empty file",
+			content: "",
+		},
+		{
+			name: "unknown key with suggestion",
+			content: `
+profiles:
+  default:
+    passwrod: secret
+`,
+			expected: []SchemaError{
+				{Line: 4, Column: 5, Path: "profiles.default", Message: `unknown key "passwrod" (did you mean "password"?)`},
+			},
+		},
+		{
+			name: "unknown key without suggestion",
+			content: `
+colour_scheme: dark
+`,
+			expected: []SchemaError{
+				{Line: 2, Column: 1, Message: `unknown key "colour_scheme"`},
+			},
+		},
+		{
+			name: "wrong types",
+			content: `
+debug: yes
+compact_width: wide
+summary: cluster
+custom_actions:
+  name: Ping
+sensors:
+  warning: hot
+`,
+			expected: []SchemaError{
+				{Line: 2, Column: 8, Path: "debug", Message: `expected true or false, got "yes"`},
+				{Line: 3, Column: 16, Path: "compact_width", Message: `expected a whole number, got "wide"`},
+				{Line: 4, Column: 10, Path: "summary", Message: `expected a mapping of keys, got "cluster"`},
+				{Line: 6, Column: 3, Path: "custom_actions", Message: "expected a list, got a mapping"},
+				{Line: 8, Column: 12, Path: "sensors.warning", Message: `expected a number, got "hot"`},
+			},
+		},
+		{
+			name: "password and token in profile",
+			content: `
+profiles:
+  work:
+    password: secret
+    token_id: tui
+    token_secret: secret
+`,
+			expected: []SchemaError{
+				{Line: 5, Column: 5, Path: "profiles.work.token_id", Message: "password and API token authentication are mutually exclusive; remove one of them"},
+			},
+		},
+		{
+			name: "password and token in legacy config",
+			content: `
+password: secret
+token_secret: secret
+`,
+			expected: []SchemaError{
+				{Line: 3, Column: 1, Path: "token_secret", Message: "password and API token authentication are mutually exclusive; remove one of them"},
+			},
+		},
+		{
+			name: "empty token next to password",
+			content: `
+password: secret
+token_id: ""
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSchema([]byte(tt.content))
+			if tt.expected == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var schemaErrs SchemaErrors
+			require.ErrorAs(t, err, &schemaErrs)
+			assert.Equal(t, tt.expected, []SchemaError(schemaErrs))
+		})
+	}
+}
+
+func TestCheckSchema_Template(t *testing.T) {
+	data, err := templateFS.ReadFile("config.tpl.yml")
+	require.NoError(t, err)
+
+	assert.NoError(t, CheckSchema(data))
+}
+
+func TestCheckSchema_SyntaxError(t *testing.T) {
+	err := CheckSchema([]byte("profiles: [\n"))
+	require.Error(t, err)
+
+	var schemaErrs SchemaErrors
+	assert.NotErrorAs(t, err, &schemaErrs)
+}
+
+func TestMergeWithFile_SchemaErrorStillMerges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    addr: "https://pve.example.com:8006"
+    usr: root
+`), 0o600))
+
+	cfg := &Config{}
+	err := cfg.MergeWithFile(path)
+
+	var schemaErrs SchemaErrors
+	require.ErrorAs(t, err, &schemaErrs)
+	assert.Len(t, schemaErrs, 1)
+	assert.Equal(t, "https://pve.example.com:8006", cfg.Profiles["default"].Addr)
+}
+
+func TestSchemaErrors_Error(t *testing.T) {
+	errs := SchemaErrors{
+		{Line: 3, Column: 5, Path: "profiles.default", Message: `unknown key "usr"`},
+		{Line: 9, Column: 8, Path: "debug", Message: `expected true or false, got "1"`},
+	}
+
+	assert.Equal(t, "config file has 2 problem(s):\n"+
+		"  line 3, column 5: profiles.default: unknown key \"usr\"\n"+
+		"  line 9, column 8: debug: expected true or false, got \"1\"", errs.Error())
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("user", "user"))
+	assert.Equal(t, 1, editDistance("passwrod", "password"))
+	assert.Equal(t, 1, editDistance("ssh_usr", "ssh_user"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
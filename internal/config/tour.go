@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// tourPendingFileName marks that the guided tour has not been shown yet.
+const tourPendingFileName = ".tour-pending"
+
+// tourPendingPath returns the path of the guided tour marker file.
+func tourPendingPath() string {
+	return filepath.Join(getConfigDir(), tourPendingFileName)
+}
+
+// MarkTourPending records that the guided tour should be shown on the next
+// start. It is called after onboarding created a new configuration.
+func MarkTourPending() error {
+	if err := os.MkdirAll(getConfigDir(), 0o750); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(tourPendingPath(), nil, 0o600); err != nil {
+		return fmt.Errorf("write tour marker: %w", err)
+	}
+
+	return nil
+}
+
+// IsTourPending reports whether the guided tour should be shown.
+func IsTourPending() bool {
+	_, err := os.Stat(tourPendingPath())
+
+	return err == nil
+}
+
+// ClearTourPending records that the guided tour was shown or dismissed.
+func ClearTourPending() error {
+	if err := os.Remove(tourPendingPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove tour marker: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTourPending(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("config directory is not derived from XDG_CONFIG_HOME")
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	assert.False(t, IsTourPending())
+
+	require.NoError(t, MarkTourPending())
+	assert.True(t, IsTourPending())
+
+	require.NoError(t, ClearTourPending())
+	assert.False(t, IsTourPending())
+
+	// Clearing twice is not an error
+	assert.NoError(t, ClearTourPending())
+}
@@ -0,0 +1,274 @@
+// Package crash turns panics into crash reports. A recovered panic restores
+// the terminal, writes a report with the stack, version, sanitized config
+// and recent, redacted log entries to the cache directory, and prints its path.
+package crash
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/logger"
+	"github.com/devnullvoid/pvetui/internal/version"
+)
+
+// LogTail is the number of recent log entries included in a report.
+const LogTail = 200
+
+// ExitCode is the exit status after a crash.
+const ExitCode = 2
+
+// restoreTimeout bounds restoring the terminal, which may wait for the UI.
+const restoreTimeout = 2 * time.Second
+
+// secretKeys are config keys whose values are redacted in reports.
+var secretKeys = []string{"password", "passphrase", "secret", "ticket", "csrf", "token"}
+
+var (
+	mu      sync.Mutex
+	cfg     *config.Config
+	restore func()
+	cleanup func()
+	once    sync.Once
+)
+
+// SetConfig sets the configuration included, sanitized, in crash reports.
+func SetConfig(c *config.Config) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cfg = c
+}
+
+// SetRestore sets the function restoring the terminal before the crash
+// report is printed, typically stopping the UI.
+func SetRestore(fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	restore = fn
+}
+
+// SetCleanup sets a function run after the crash report is written, before
+// exiting, e.g. purging the cache. Deferred functions of other goroutines
+// don't run on a crash.
+func SetCleanup(fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cleanup = fn
+}
+
+// Recover handles a panic of the calling goroutine and exits. It must be
+// deferred directly:
+//
+//	defer crash.Recover()
+func Recover() {
+	if p := recover(); p != nil {
+		handle(p, debug.Stack())
+	}
+}
+
+// handle reports a panic once. Panics on other goroutines wait for the
+// first report to finish exiting.
+func handle(p any, stack []byte) {
+	once.Do(func() {
+		mu.Lock()
+		c, fn, clean := cfg, restore, cleanup
+		mu.Unlock()
+
+		restoreTerminal(fn)
+
+		cacheDir := os.TempDir()
+		if c != nil && c.CacheDir != "" {
+			cacheDir = c.CacheDir
+		}
+
+		report := BuildReport(p, stack, c, logger.Entries(), time.Now())
+
+		path, err := WriteReport(cacheDir, report, time.Now())
+
+		if clean != nil {
+			clean()
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\npvetui crashed: %v\nFailed to write crash report: %v\n\n%s", p, err, report)
+			os.Exit(ExitCode)
+		}
+
+		fmt.Fprintf(os.Stderr, "\npvetui crashed: %v\nA crash report was written to %s\n", p, path)
+		fmt.Fprintf(os.Stderr, "Please attach it when reporting the issue at %s/issues\n", version.GetGitHubURL())
+		os.Exit(ExitCode)
+	})
+
+	select {}
+}
+
+// restoreTerminal runs fn, ignoring its panics and giving up after
+// restoreTimeout.
+func restoreTerminal(fn func()) {
+	if fn == nil {
+		return
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() { _ = recover() }()
+
+		fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(restoreTimeout):
+	}
+}
+
+// BuildReport renders a crash report.
+func BuildReport(p any, stack []byte, c *config.Config, entries []logger.Entry, now time.Time) string {
+	var b strings.Builder
+
+	b.WriteString("pvetui crash report\n\n")
+	fmt.Fprintf(&b, "Time:    %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", version.GetFullVersionString())
+	fmt.Fprintf(&b, "Go:      %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "\nPanic: %v\n\nStack:\n%s\n", p, stack)
+
+	b.WriteString("\nConfig (secrets redacted):\n")
+	b.WriteString(sanitizedConfig(c))
+
+	if len(entries) > LogTail {
+		entries = entries[len(entries)-LogTail:]
+	}
+
+	fmt.Fprintf(&b, "\nRecent log entries (%d):\n", len(entries))
+
+	for _, entry := range entries {
+		// Entries are kept unredacted with raw logging
+		entry.Message = logger.Redact(entry.Message)
+		b.WriteString(entry.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// WriteReport writes a report to a new file in dir and returns its path.
+func WriteReport(dir, report string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp(dir, fmt.Sprintf("pvetui-crash-%s-*.txt", now.Format("20060102-150405")))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.WriteString(file, report); err != nil {
+		_ = file.Close()
+
+		return "", err
+	}
+
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(file.Name())
+}
+
+// sanitizedConfig renders c as YAML with the values of secret keys redacted.
+func sanitizedConfig(c *config.Config) string {
+	if c == nil {
+		return "(not loaded)\n"
+	}
+
+	var node yaml.Node
+	if err := node.Encode(c); err != nil {
+		return fmt.Sprintf("(unavailable: %v)\n", err)
+	}
+
+	redact(&node, "")
+
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return fmt.Sprintf("(unavailable: %v)\n", err)
+	}
+
+	return string(out)
+}
+
+// redact replaces the non-empty values of secret keys in a YAML tree, and
+// masks the URLs of notification backends, which embed webhook secrets and
+// topic names. section is the key of the top-level setting node is under.
+func redact(node *yaml.Node, section string) {
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			redact(child, section)
+		}
+
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Value != "" {
+			switch {
+			case isSecretKey(key.Value):
+				value.Value = "REDACTED"
+			case section == "notifications" && key.Value == "url":
+				value.Value = maskURL(value.Value)
+			default:
+				continue
+			}
+
+			value.Tag = "!!str"
+			value.Style = 0
+
+			continue
+		}
+
+		if section == "" {
+			redact(value, key.Value)
+		} else {
+			redact(value, section)
+		}
+	}
+}
+
+// maskURL keeps the scheme and host of a URL and redacts the rest, e.g.
+// "https://hooks.slack.com/REDACTED".
+func maskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "REDACTED"
+	}
+
+	return u.Scheme + "://" + u.Host + "/REDACTED"
+}
+
+// isSecretKey reports whether a config key holds a secret.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package crash
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/logger"
+)
+
+func TestBuildReport(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Profiles = map[string]config.ProfileConfig{
+		"default": {Addr: "https://pve.example.com:8006", User: "root", Password: "hunter2", TokenSecret: "s3cr3t"},
+	}
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	entries := make([]logger.Entry, LogTail+5)
+
+	for i := range entries {
+		entries[i] = logger.Entry{Time: now, Level: logger.LevelInfo, Component: "cache", Message: "entry"}
+	}
+
+	entries[len(entries)-2].Message = "ticket: PVE:root@pam:6543ABCD::c2ln"
+	entries[len(entries)-1].Message = "last entry"
+
+	report := BuildReport("index out of range", []byte("goroutine 1 [running]:\nmain.main()"), cfg, entries, now)
+
+	assert.Contains(t, report, "Panic: index out of range")
+	assert.Contains(t, report, "goroutine 1 [running]:")
+	assert.Contains(t, report, "https://pve.example.com:8006")
+	assert.Contains(t, report, "REDACTED")
+	assert.NotContains(t, report, "hunter2")
+	assert.NotContains(t, report, "s3cr3t")
+	assert.NotContains(t, report, "c2ln")
+	assert.Contains(t, report, "[INFO] [cache] last entry")
+	assert.Equal(t, LogTail, strings.Count(report, "[cache]"))
+}
+
+func TestBuildReport_Notifications(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Notifications = []config.Notification{
+		{Type: "slack", URL: "https://hooks.slack.com/services/T000/B000/XXXXSECRET"},
+		{Type: "gotify", URL: "https://gotify.example.com", Token: "AbCdEf123"},
+		{Type: "ntfy", URL: "https://ntfy.sh/pvetui-alerts-7f3a"},
+	}
+
+	report := BuildReport("boom", nil, cfg, nil, time.Now())
+
+	assert.Contains(t, report, "https://hooks.slack.com/REDACTED")
+	assert.Contains(t, report, "https://ntfy.sh/REDACTED")
+	assert.NotContains(t, report, "XXXXSECRET")
+	assert.NotContains(t, report, "AbCdEf123")
+	assert.NotContains(t, report, "pvetui-alerts-7f3a")
+	assert.Contains(t, report, "type: gotify")
+}
+
+func TestBuildReport_NoConfig(t *testing.T) {
+	report := BuildReport("boom", nil, nil, nil, time.Now())
+
+	assert.Contains(t, report, "(not loaded)")
+	assert.Contains(t, report, "Recent log entries (0)")
+}
+
+func TestWriteReport(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := WriteReport(dir, "report", time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Contains(t, path, "pvetui-crash-20250901-120000-")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "report", string(content))
+}
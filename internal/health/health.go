@@ -0,0 +1,365 @@
+// Package health runs the diagnostics of the check command: config
+// validation and permissions, API authentication, node reachability and
+// SSH logins.
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+)
+
+// Exit codes of the check command.
+const (
+	ExitOK          = 0 // All checks passed
+	ExitCheckFailed = 1 // A permissions, node or SSH check failed
+	ExitUnusable    = 2 // The config is invalid or the API can't be used
+)
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusSkipped
+	StatusFailed
+)
+
+// String returns the label of the status in the diagnostic table.
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusSkipped:
+		return "skip"
+	default:
+		return "FAIL"
+	}
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Check    string
+	Target   string
+	Status   Status
+	Detail   string
+	Duration time.Duration
+}
+
+// Report collects the results of a check run.
+type Report struct {
+	Results []Result
+	// unusable is set when a failure prevented the remaining checks
+	unusable bool
+}
+
+// Add appends a result.
+func (r *Report) Add(result Result) {
+	r.Results = append(r.Results, result)
+}
+
+// Fail records a failure that prevents the remaining checks.
+func (r *Report) Fail(check, target string, err error) {
+	r.Add(Result{Check: check, Target: target, Status: StatusFailed, Detail: err.Error()})
+	r.unusable = true
+}
+
+// Failed returns the number of failed checks.
+func (r *Report) Failed() int {
+	failed := 0
+
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// ExitCode returns the exit status for the report.
+func (r *Report) ExitCode() int {
+	switch {
+	case r.unusable:
+		return ExitUnusable
+	case r.Failed() > 0:
+		return ExitCheckFailed
+	default:
+		return ExitOK
+	}
+}
+
+// Print writes the results as a table followed by a summary line.
+func (r *Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tTARGET\tSTATUS\tTIME\tDETAIL")
+
+	for _, result := range r.Results {
+		elapsed := "-"
+		if result.Duration > 0 {
+			elapsed = fmt.Sprintf("%dms", result.Duration.Milliseconds())
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", result.Check, result.Target, result.Status, elapsed, result.Detail)
+	}
+
+	_ = tw.Flush()
+
+	fmt.Fprintf(w, "\n%d check(s), %d failed\n", len(r.Results), r.Failed())
+}
+
+// API is the part of the API client used by the checks.
+type API interface {
+	GetNoRetry(path string, result *map[string]interface{}) error
+}
+
+// Checker runs the checks against a loaded config.
+type Checker struct {
+	Config     *config.Config
+	ConfigPath string
+	// Connect creates an authenticated API client.
+	Connect  func(cfg *config.Config) (API, error)
+	Executor ssh.CommandExecutor
+}
+
+// node is a cluster member found by the cluster status check.
+type node struct {
+	name   string
+	ip     string
+	online bool
+}
+
+// Run validates the config, authenticates, pings every node and, when an
+// SSH user is configured, tests an SSH login to every online node.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{}
+
+	target := c.ConfigPath
+	if target == "" {
+		target = "environment"
+	}
+
+	if err := c.Config.Validate(); err != nil {
+		report.Fail("config", target, err)
+
+		return report
+	}
+
+	report.Add(Result{Check: "config", Target: target, Status: StatusOK, Detail: "valid"})
+
+	if result, ok := c.checkPermissions(); ok {
+		report.Add(result)
+	}
+
+	addr := strings.TrimSuffix(c.Config.GetAddr(), "/")
+
+	start := time.Now()
+
+	client, err := c.Connect(c.Config)
+	if err != nil {
+		report.Fail("authenticate", addr, err)
+
+		return report
+	}
+
+	var version map[string]interface{}
+	if err := client.GetNoRetry("/version", &version); err != nil {
+		report.Fail("authenticate", addr, err)
+
+		return report
+	}
+
+	report.Add(Result{
+		Check:    "authenticate",
+		Target:   addr,
+		Status:   StatusOK,
+		Detail:   fmt.Sprintf("%s as %s@%s", versionLabel(version), c.Config.GetUser(), c.Config.GetRealm()),
+		Duration: time.Since(start),
+	})
+
+	nodes, err := clusterNodes(client)
+	if err != nil {
+		report.Fail("nodes", addr, err)
+
+		return report
+	}
+
+	for _, result := range parallel(nodes, func(n node) Result { return pingNode(client, n) }) {
+		report.Add(result)
+	}
+
+	if c.Config.SSHUser == "" {
+		report.Add(Result{Check: "ssh", Target: "-", Status: StatusSkipped, Detail: "ssh_user is not configured"})
+
+		return report
+	}
+
+	opts := ssh.Options{Port: c.Config.SSHPort, IdentityFile: c.Config.SSHKeyFile}
+
+	for _, result := range parallel(nodes, func(n node) Result { return c.testSSH(ctx, n, opts) }) {
+		report.Add(result)
+	}
+
+	return report
+}
+
+// checkPermissions checks that a config file holding secrets can't be read
+// by other users. It reports false when there is nothing to check.
+func (c *Checker) checkPermissions() (Result, bool) {
+	if c.ConfigPath == "" || !c.Config.HasSecrets() {
+		return Result{}, false
+	}
+
+	mode, exposed, err := config.ExposedPermissions(c.ConfigPath)
+	if err != nil {
+		return Result{}, false
+	}
+
+	result := Result{Check: "permissions", Target: c.ConfigPath, Status: StatusOK, Detail: fmt.Sprintf("%04o", mode)}
+	if exposed {
+		result.Status = StatusFailed
+		result.Detail = fmt.Sprintf("%04o, secrets readable by other users; run chmod 600 %s", mode, c.ConfigPath)
+	}
+
+	return result, true
+}
+
+// clusterNodes lists the cluster members with their address and status.
+func clusterNodes(client API) ([]node, error) {
+	var resp map[string]interface{}
+	if err := client.GetNoRetry("/cluster/status", &resp); err != nil {
+		return nil, err
+	}
+
+	items, ok := resp["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid cluster status response format")
+	}
+
+	var nodes []node
+
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok || stringValue(entry, "type") != "node" {
+			continue
+		}
+
+		online, _ := entry["online"].(float64)
+		nodes = append(nodes, node{name: stringValue(entry, "name"), ip: stringValue(entry, "ip"), online: online == 1})
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes in cluster status")
+	}
+
+	return nodes, nil
+}
+
+// pingNode requests the status of a node through the API.
+func pingNode(client API, n node) Result {
+	result := Result{Check: "node", Target: n.name}
+
+	if !n.online {
+		result.Status, result.Detail = StatusFailed, "offline in cluster status"
+
+		return result
+	}
+
+	start := time.Now()
+
+	var resp map[string]interface{}
+
+	err := client.GetNoRetry("/nodes/"+n.name+"/status", &resp)
+	result.Duration = time.Since(start)
+
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, err.Error()
+
+		return result
+	}
+
+	result.Status, result.Detail = StatusOK, "online"
+
+	if data, ok := resp["data"].(map[string]interface{}); ok {
+		if version := stringValue(data, "pveversion"); version != "" {
+			result.Detail = "online, " + version
+		}
+	}
+
+	return result
+}
+
+// testSSH tests a non-interactive SSH login to an online node.
+func (c *Checker) testSSH(ctx context.Context, n node, opts ssh.Options) Result {
+	host := n.ip
+	if host == "" {
+		host = n.name
+	}
+
+	result := Result{Check: "ssh", Target: c.Config.SSHUser + "@" + host}
+
+	if !n.online {
+		result.Status, result.Detail = StatusSkipped, "node offline"
+
+		return result
+	}
+
+	start := time.Now()
+	err := ssh.TestConnection(ctx, c.Executor, c.Config.SSHUser, host, opts)
+	result.Duration = time.Since(start)
+
+	if err != nil {
+		result.Status, result.Detail = StatusFailed, strings.ReplaceAll(err.Error(), "\n", " ")
+
+		return result
+	}
+
+	result.Status, result.Detail = StatusOK, "login succeeded"
+
+	return result
+}
+
+// parallel runs check for every node concurrently, keeping the node order.
+func parallel(nodes []node, check func(node) Result) []Result {
+	results := make([]Result, len(nodes))
+
+	var wg sync.WaitGroup
+
+	for i, n := range nodes {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = check(n)
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// versionLabel describes the Proxmox VE version from a /version response.
+func versionLabel(resp map[string]interface{}) string {
+	data, ok := resp["data"].(map[string]interface{})
+	if !ok {
+		return "Proxmox VE"
+	}
+
+	return strings.TrimSpace("Proxmox VE " + stringValue(data, "version"))
+}
+
+// stringValue returns a string field of an API response, or "".
+func stringValue(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+
+	return value
+}
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+type fakeAPI map[string]interface{}
+
+func (f fakeAPI) GetNoRetry(path string, result *map[string]interface{}) error {
+	switch response := f[path].(type) {
+	case error:
+		return response
+	case map[string]interface{}:
+		*result = response
+
+		return nil
+	default:
+		return errors.New("unexpected path " + path)
+	}
+}
+
+// hostExecutor fails ssh logins to the hosts in failing.
+type hostExecutor struct {
+	failing []string
+}
+
+func (h hostExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	for _, host := range h.failing {
+		if slices.Contains(args, "root@"+host) {
+			return exec.CommandContext(ctx, "false")
+		}
+	}
+
+	return exec.CommandContext(ctx, "true")
+}
+
+func testConfig() *config.Config {
+	cfg := config.NewConfig()
+	cfg.Addr = "https://pve.example.com:8006"
+	cfg.User = "root"
+	cfg.Password = "secret"
+	cfg.SetDefaults()
+
+	return cfg
+}
+
+func clusterAPI() fakeAPI {
+	return fakeAPI{
+		"/version": map[string]interface{}{"data": map[string]interface{}{"version": "8.2.4"}},
+		"/cluster/status": map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"type": "cluster", "name": "lab"},
+			map[string]interface{}{"type": "node", "name": "pve1", "ip": "192.0.2.1", "online": float64(1)},
+			map[string]interface{}{"type": "node", "name": "pve2", "ip": "192.0.2.2", "online": float64(1)},
+			map[string]interface{}{"type": "node", "name": "pve3", "ip": "192.0.2.3", "online": float64(0)},
+		}},
+		"/nodes/pve1/status": map[string]interface{}{"data": map[string]interface{}{"pveversion": "pve-manager/8.2.4"}},
+		"/nodes/pve2/status": errors.New("connection refused"),
+	}
+}
+
+func TestChecker_Run(t *testing.T) {
+	cfg := testConfig()
+	cfg.SSHUser = "root"
+
+	checker := &Checker{
+		Config:   cfg,
+		Connect:  func(*config.Config) (API, error) { return clusterAPI(), nil },
+		Executor: hostExecutor{failing: []string{"192.0.2.2"}},
+	}
+
+	report := checker.Run(context.Background())
+
+	var statuses []string
+	for _, result := range report.Results {
+		statuses = append(statuses, result.Check+" "+result.Target+" "+result.Status.String())
+	}
+
+	assert.Equal(t, []string{
+		"config environment ok",
+		"authenticate https://pve.example.com:8006 ok",
+		"node pve1 ok",
+		"node pve2 FAIL",
+		"node pve3 FAIL",
+		"ssh root@192.0.2.1 ok",
+		"ssh root@192.0.2.2 FAIL",
+		"ssh root@192.0.2.3 skip",
+	}, statuses)
+
+	assert.Equal(t, "Proxmox VE 8.2.4 as root@pam", report.Results[1].Detail)
+	assert.Equal(t, "online, pve-manager/8.2.4", report.Results[2].Detail)
+	assert.Equal(t, ExitCheckFailed, report.ExitCode())
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "CHECK")
+	assert.Contains(t, out.String(), "8 check(s), 3 failed")
+}
+
+func TestChecker_Run_SSHNotConfigured(t *testing.T) {
+	api := clusterAPI()
+	api["/nodes/pve2/status"] = map[string]interface{}{"data": map[string]interface{}{}}
+	api["/cluster/status"] = map[string]interface{}{"data": []interface{}{
+		map[string]interface{}{"type": "node", "name": "pve1", "online": float64(1)},
+	}}
+
+	checker := &Checker{
+		Config:  testConfig(),
+		Connect: func(*config.Config) (API, error) { return api, nil },
+	}
+
+	report := checker.Run(context.Background())
+
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, StatusSkipped, report.Results[3].Status)
+	assert.Equal(t, ExitOK, report.ExitCode())
+}
+
+func TestChecker_Run_Unusable(t *testing.T) {
+	cfg := testConfig()
+	cfg.Addr = ""
+
+	checker := &Checker{Config: cfg, ConfigPath: "/etc/pvetui.yml"}
+	report := checker.Run(context.Background())
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "/etc/pvetui.yml", report.Results[0].Target)
+	assert.Equal(t, ExitUnusable, report.ExitCode())
+
+	checker = &Checker{
+		Config:  testConfig(),
+		Connect: func(*config.Config) (API, error) { return nil, errors.New("authentication failed") },
+	}
+	report = checker.Run(context.Background())
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "authentication failed", report.Results[1].Detail)
+	assert.Equal(t, ExitUnusable, report.ExitCode())
+}
+
+func TestChecker_Run_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not checked on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("profiles: {}\n"), 0o600))
+	require.NoError(t, os.Chmod(path, 0o644))
+
+	cfg := testConfig()
+	cfg.Lock.Passphrase = "secret"
+
+	api := clusterAPI()
+	api["/cluster/status"] = map[string]interface{}{"data": []interface{}{
+		map[string]interface{}{"type": "node", "name": "pve1", "online": float64(1)},
+	}}
+
+	checker := &Checker{
+		Config:     cfg,
+		ConfigPath: path,
+		Connect:    func(*config.Config) (API, error) { return api, nil },
+	}
+
+	report := checker.Run(context.Background())
+
+	require.GreaterOrEqual(t, len(report.Results), 2)
+	assert.Equal(t, "permissions", report.Results[1].Check)
+	assert.Equal(t, StatusFailed, report.Results[1].Status)
+	assert.Contains(t, report.Results[1].Detail, "chmod 600")
+	assert.Equal(t, ExitCheckFailed, report.ExitCode())
+
+	require.NoError(t, config.RestrictPermissions(path))
+
+	report = checker.Run(context.Background())
+	assert.Equal(t, StatusOK, report.Results[1].Status)
+	assert.Equal(t, "0600", report.Results[1].Detail)
+}
@@ -116,6 +116,12 @@ func Parse(spec string) (tcell.Key, rune, tcell.ModMask, error) {
 
 		r = unicode.ToLower(r)
 
+		// Terminals transmit Ctrl+/ as 0x1F, the same control code as Ctrl+_,
+		// so map it to the rune NormalizeEvent produces for that key.
+		if r == '/' && mods&tcell.ModCtrl != 0 {
+			r = '_'
+		}
+
 		return tcell.KeyRune, r, mods, nil
 	}
 
@@ -27,6 +27,7 @@ func TestParse(t *testing.T) {
 		{"Win+A", tcell.KeyRune, 'a', tcell.ModMeta | tcell.ModShift},
 		{"Shift+F1", tcell.KeyF1, 0, tcell.ModShift},
 		{"Shift+3", tcell.KeyRune, '3', tcell.ModShift},
+		{"Ctrl+/", tcell.KeyRune, '_', tcell.ModCtrl},
 	}
 
 	for _, tc := range cases {
@@ -65,3 +66,14 @@ func TestNormalizeEvent_ShiftDigit(t *testing.T) {
 	assert.Equal(t, '3', r)
 	assert.Equal(t, tcell.ModShift, mod)
 }
+
+func TestNormalizeEvent_CtrlSlash(t *testing.T) {
+	ev := tcell.NewEventKey(tcell.KeyCtrlUnderscore, 0, tcell.ModCtrl)
+	key, r, mod := NormalizeEvent(ev)
+
+	pk, pr, pm, err := Parse("Ctrl+/")
+	assert.NoError(t, err)
+	assert.Equal(t, pk, key)
+	assert.Equal(t, pr, r)
+	assert.Equal(t, pm, mod)
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HistorySize is the number of recent log entries kept in memory for the
+// in-app log viewer.
+const HistorySize = 2000
+
+// Entry is a logged message.
+type Entry struct {
+	Time      time.Time
+	Level     Level
+	Component string // Package or subsystem that logged the entry, empty for the application
+	Message   string
+}
+
+// String renders the entry in the console format.
+func (e Entry) String() string {
+	timestamp := e.Time.Format("2006-01-02 15:04:05")
+	if e.Component != "" {
+		return fmt.Sprintf("[%s] [%s] [%s] %s", timestamp, e.Level.String(), e.Component, e.Message)
+	}
+
+	return fmt.Sprintf("[%s] [%s] %s", timestamp, e.Level.String(), e.Message)
+}
+
+// history keeps the most recent entries of all loggers in a ring.
+type history struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int // Index of the oldest entry once the ring is full
+}
+
+var recent = &history{}
+
+// add appends an entry, dropping the oldest one when full.
+func (h *history) add(entry Entry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) < HistorySize {
+		h.entries = append(h.entries, entry)
+
+		return
+	}
+
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % HistorySize
+}
+
+// Entries returns the recent entries logged by any logger, oldest first.
+// Entries below a logger's level are not kept.
+func Entries() []Entry {
+	recent.mu.Lock()
+	defer recent.mu.Unlock()
+
+	entries := make([]Entry, 0, len(recent.entries))
+	entries = append(entries, recent.entries[recent.next:]...)
+
+	return append(entries, recent.entries[:recent.next]...)
+}
+
+// Components returns the distinct components of the recent entries, sorted.
+func Components() []string {
+	seen := make(map[string]bool)
+
+	var components []string
+
+	for _, entry := range Entries() {
+		if !seen[entry.Component] {
+			seen[entry.Component] = true
+			components = append(components, entry.Component)
+		}
+	}
+
+	sort.Strings(components)
+
+	return components
+}
@@ -1,14 +1,17 @@
 // Package logger provides a comprehensive logging system designed for TUI applications.
-// It supports multiple log levels, file-based logging, and configurable output destinations.
+// It supports multiple log levels, console or JSON formats, size-rotated log files,
+// per-component loggers and an in-memory history for the in-app log viewer.
 // The logger is designed to avoid stdout interference with terminal user interfaces.
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +41,14 @@ func (l Level) String() string {
 	}
 }
 
+// Format selects how log entries are written.
+type Format string
+
+const (
+	FormatConsole Format = "console" // [time] [LEVEL] [component] message
+	FormatJSON    Format = "json"    // One JSON object per line
+)
+
 // Logger implements the interfaces.Logger interface with configurable output and levels.
 type Logger struct {
 	debugLogger *log.Logger
@@ -45,6 +56,8 @@ type Logger struct {
 	errorLogger *log.Logger
 	level       Level
 	output      io.Writer
+	format      Format
+	component   string
 }
 
 // Config holds configuration for the logger.
@@ -54,6 +67,8 @@ type Config struct {
 	LogToFile  bool
 	LogFile    string
 	TimeFormat string
+	// Format defaults to the format set with SetFormat.
+	Format Format
 }
 
 // NewInternalLogger creates a logger that stores logs in the specified cache directory
@@ -110,8 +125,8 @@ func NewLogger(config *Config) (*Logger, error) {
 			return nil, fmt.Errorf("failed to create log directory: %w", err)
 		}
 
-		// Open the log file
-		file, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		// Open the log file, rotated by size and shared with other loggers
+		file, err := openLogFile(config.LogFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open log file: %w", err)
 		}
@@ -129,12 +144,18 @@ func NewLogger(config *Config) (*Logger, error) {
 	infoLogger := log.New(output, "", 0)
 	errorLogger := log.New(output, "", 0)
 
+	format := config.Format
+	if format == "" {
+		format = globalFormat
+	}
+
 	return &Logger{
 		debugLogger: debugLogger,
 		infoLogger:  infoLogger,
 		errorLogger: errorLogger,
 		level:       config.Level,
 		output:      output,
+		format:      format,
 	}, nil
 }
 
@@ -149,6 +170,15 @@ func NewSimpleLogger(level Level) *Logger {
 	return logger
 }
 
+// NewMemoryLogger creates a logger that only keeps entries in the in-memory
+// history shown by the log viewer. It replaces stdout logging as fallback
+// when the log file can't be opened, since stdout belongs to the TUI.
+func NewMemoryLogger(level Level) *Logger {
+	logger, _ := NewLogger(&Config{Level: level, Output: io.Discard}) // Safe to ignore error with this config
+
+	return logger
+}
+
 // NewFileLogger creates a logger that outputs to a file with the given level.
 func NewFileLogger(level Level, logFile string) (*Logger, error) {
 	config := &Config{
@@ -172,36 +202,72 @@ func NewDualLogger(level Level, logFile string) (*Logger, error) {
 	return NewLogger(config)
 }
 
-// formatMessage creates a formatted log message with timestamp and level.
-func (l *Logger) formatMessage(level Level, format string, args ...interface{}) string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
+// WithComponent returns a logger sharing the output and level of l that
+// tags its entries with component.
+func (l *Logger) WithComponent(component string) *Logger {
+	tagged := *l
+	tagged.component = component
+
+	return &tagged
+}
+
+// formatEntry renders an entry in the format of the logger.
+func (l *Logger) formatEntry(entry Entry) string {
+	if l.format == FormatJSON {
+		line, err := json.Marshal(struct {
+			Time      string `json:"time"`
+			Level     string `json:"level"`
+			Component string `json:"component,omitempty"`
+			Message   string `json:"msg"`
+		}{
+			Time:      entry.Time.Format(time.RFC3339),
+			Level:     strings.ToLower(entry.Level.String()),
+			Component: entry.Component,
+			Message:   entry.Message,
+		})
+		if err == nil {
+			return string(line)
+		}
+	}
+
+	return entry.String()
+}
+
+// log writes a message at level to target and the in-memory history.
+func (l *Logger) log(target *log.Logger, level Level, format string, args ...interface{}) {
+	if l.level > level {
+		return
+	}
+
 	message := fmt.Sprintf(format, args...)
+	if !rawLogging.Load() {
+		message = Redact(message)
+	}
+
+	entry := Entry{
+		Time:      time.Now(),
+		Level:     level,
+		Component: l.component,
+		Message:   message,
+	}
 
-	return fmt.Sprintf("[%s] [%s] %s", timestamp, level.String(), message)
+	recent.add(entry)
+	target.Println(l.formatEntry(entry))
 }
 
 // Debug logs a debug message (implements interfaces.Logger).
 func (l *Logger) Debug(format string, args ...interface{}) {
-	if l.level <= LevelDebug {
-		message := l.formatMessage(LevelDebug, format, args...)
-		l.debugLogger.Println(message)
-	}
+	l.log(l.debugLogger, LevelDebug, format, args...)
 }
 
 // Info logs an info message (implements interfaces.Logger).
 func (l *Logger) Info(format string, args ...interface{}) {
-	if l.level <= LevelInfo {
-		message := l.formatMessage(LevelInfo, format, args...)
-		l.infoLogger.Println(message)
-	}
+	l.log(l.infoLogger, LevelInfo, format, args...)
 }
 
 // Error logs an error message (implements interfaces.Logger).
 func (l *Logger) Error(format string, args ...interface{}) {
-	if l.level <= LevelError {
-		message := l.formatMessage(LevelError, format, args...)
-		l.errorLogger.Println(message)
-	}
+	l.log(l.errorLogger, LevelError, format, args...)
 }
 
 // SetLevel changes the logging level.
@@ -233,6 +299,7 @@ var (
 	globalLoggerOnce sync.Once
 	globalCacheDir   string
 	globalDebugFlag  bool
+	globalFormat     = FormatConsole
 )
 
 // InitGlobalLogger initializes the global logger with the specified cache directory
@@ -246,8 +313,8 @@ func InitGlobalLogger(level Level, cacheDir string) error {
 		// Try to create internal logger with file output
 		globalLogger, err = NewInternalLogger(level, cacheDir)
 		if err != nil {
-			// Fallback to simple logger if file logging fails
-			globalLogger = NewSimpleLogger(level)
+			// Fallback to the in-memory history if file logging fails
+			globalLogger = NewMemoryLogger(level)
 		}
 	})
 
@@ -260,9 +327,9 @@ func InitGlobalLoggerWithValidation(level Level, cacheDir string) error {
 	// Validate cache directory if provided
 	if cacheDir != "" {
 		if err := os.MkdirAll(cacheDir, 0o750); err != nil {
-			// If we can't create the directory, fall back to simple logger
+			// If we can't create the directory, fall back to the in-memory history
 			globalLoggerOnce.Do(func() {
-				globalLogger = NewSimpleLogger(level)
+				globalLogger = NewMemoryLogger(level)
 			})
 			return fmt.Errorf("failed to create cache directory: %w", err)
 		}
@@ -273,9 +340,9 @@ func InitGlobalLoggerWithValidation(level Level, cacheDir string) error {
 			_ = file.Close()
 			_ = os.Remove(testFile) // Clean up test file
 		} else {
-			// If we can't write to the directory, fall back to simple logger
+			// If we can't write to the directory, fall back to the in-memory history
 			globalLoggerOnce.Do(func() {
-				globalLogger = NewSimpleLogger(level)
+				globalLogger = NewMemoryLogger(level)
 			})
 			return fmt.Errorf("cache directory not writable: %w", err)
 		}
@@ -301,6 +368,14 @@ func SetDebugEnabled(enabled bool) {
 	globalDebugFlag = enabled
 }
 
+// SetFormat sets the format of loggers created afterwards. This should be
+// called during application initialization, before loggers are created.
+func SetFormat(format Format) {
+	if format != "" {
+		globalFormat = format
+	}
+}
+
 // GetPackageLogger returns a logger for a specific package using the global cache directory
 // This ensures all packages log to the same unified log file.
 func GetPackageLogger(packageName string) interfaces.Logger {
@@ -317,11 +392,11 @@ func GetPackageLogger(packageName string) interfaces.Logger {
 
 	logger, err := NewInternalLogger(level, cacheDir)
 	if err != nil {
-		// Fallback to simple logger if file logging fails
-		return NewSimpleLogger(level)
+		// Fallback to the in-memory history if file logging fails
+		return NewMemoryLogger(level).WithComponent(packageName)
 	}
 
-	return logger
+	return logger.WithComponent(packageName)
 }
 
 // GetPackageLoggerConcrete returns a concrete Logger instance for packages that need the specific type
@@ -340,9 +415,9 @@ func GetPackageLoggerConcrete(packageName string) *Logger {
 
 	logger, err := NewInternalLogger(level, cacheDir)
 	if err != nil {
-		// Fallback to simple logger if file logging fails
-		return NewSimpleLogger(level)
+		// Fallback to the in-memory history if file logging fails
+		return NewMemoryLogger(level).WithComponent(packageName)
 	}
 
-	return logger
+	return logger.WithComponent(packageName)
 }
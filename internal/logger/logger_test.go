@@ -2,6 +2,7 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -512,3 +513,66 @@ func TestLogger_AppendToExistingFile(t *testing.T) {
 	assert.Equal(t, 1, strings.Count(contentStr, "first message"))
 	assert.Equal(t, 1, strings.Count(contentStr, "second message"))
 }
+
+func TestLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewLogger(&Config{Level: LevelInfo, Output: &buf, Format: FormatJSON})
+	require.NoError(t, err)
+
+	logger.WithComponent("vnc-proxy").Error("port %d in use", 5900)
+
+	var entry map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "vnc-proxy", entry["component"])
+	assert.Equal(t, "port 5900 in use", entry["msg"])
+	assert.NotEmpty(t, entry["time"])
+}
+
+func TestLogger_WithComponent(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewLogger(&Config{Level: LevelInfo, Output: &buf, Format: FormatConsole})
+	require.NoError(t, err)
+
+	logger.WithComponent("scripts").Info("history entry")
+	logger.Info("untagged")
+
+	assert.Contains(t, buf.String(), "[INFO] [scripts] history entry")
+	assert.Contains(t, buf.String(), "[INFO] untagged")
+
+	entries := Entries()
+	require.GreaterOrEqual(t, len(entries), 2)
+	assert.Equal(t, "scripts", entries[len(entries)-2].Component)
+	assert.Equal(t, "history entry", entries[len(entries)-2].Message)
+	assert.Contains(t, Components(), "scripts")
+}
+
+func TestLogger_DebugNotKeptBelowLevel(t *testing.T) {
+	logger := NewMemoryLogger(LevelInfo).WithComponent("hidden-debug")
+	logger.Debug("not kept")
+
+	assert.NotContains(t, Components(), "hidden-debug")
+}
+
+func TestLogFile_Rotation(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "pvetui.log")
+
+	logger, err := NewFileLogger(LevelInfo, logFile)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	logger.Info("%s", strings.Repeat("x", MaxLogSize))
+	logger.Info("after rotation")
+
+	backup, err := os.ReadFile(logFile + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(backup), "xxx")
+
+	current, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "after rotation")
+	assert.NotContains(t, string(current), "xxx")
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"regexp"
+	"sync/atomic"
+)
+
+// Redacted replaces secrets in log messages.
+const Redacted = "[REDACTED]"
+
+// redactions match secrets in logged messages: values of secret keys in
+// JSON, form data and %+v output, API token headers, and ticket-shaped
+// values logged on their own.
+var redactions = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{
+		pattern:     regexp.MustCompile(`(?i)\b(password|passphrase|vncticket|ticket|csrfpreventiontoken|token_secret|tokensecret|secret|token)(["']?\s*[:=]\s*["']?)[^\s"',&;}\]]+`),
+		replacement: "${1}${2}" + Redacted,
+	},
+	{
+		pattern:     regexp.MustCompile(`(PVEAuthCookie=)[^\s"',;]+`),
+		replacement: "${1}" + Redacted,
+	},
+	{
+		pattern:     regexp.MustCompile(`(PVEAPIToken=[^=\s"']+=)[^\s"',;]+`),
+		replacement: "${1}" + Redacted,
+	},
+	{
+		pattern:     regexp.MustCompile(`\bPVE(?:VNC)?:[^\s"',}\]]*::[^\s"',}\]]+`),
+		replacement: Redacted,
+	},
+}
+
+// rawLogging disables redaction of logged messages.
+var rawLogging atomic.Bool
+
+// SetRawLogging turns off the redaction of secrets in logged messages, for
+// debugging locally. Crash reports are redacted regardless.
+func SetRawLogging(enabled bool) {
+	rawLogging.Store(enabled)
+}
+
+// Redact replaces authentication tickets, CSRF tokens, passwords, API token
+// secrets, notification tokens and VNC tickets in message with [REDACTED].
+func Redact(message string) string {
+	for _, redaction := range redactions {
+		message = redaction.pattern.ReplaceAllString(message, redaction.replacement)
+	}
+
+	return message
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "vnc proxy response",
+			message:  "VNC proxy API response for VM web: map[data:map[port:5900 ticket:PVEVNC:6543ABCD::c2lnbmF0dXJl password:abc123 user:root@pam]]",
+			expected: "VNC proxy API response for VM web: map[data:map[port:5900 ticket:[REDACTED] password:[REDACTED] user:root@pam]]",
+		},
+		{
+			name:     "json login response",
+			message:  `{"data":{"CSRFPreventionToken":"6543ABCD:dG9rZW4","ticket":"PVE:root@pam:6543ABCD::c2ln","username":"root@pam"}}`,
+			expected: `{"data":{"CSRFPreventionToken":"[REDACTED]","ticket":"[REDACTED]","username":"root@pam"}}`,
+		},
+		{
+			name:     "form data",
+			message:  "username=root@pam&password=hunter2&realm=pam",
+			expected: "username=root@pam&password=[REDACTED]&realm=pam",
+		},
+		{
+			name:     "auth cookie",
+			message:  "Cookie: PVEAuthCookie=PVE:root@pam:6543ABCD::c2ln",
+			expected: "Cookie: PVEAuthCookie=[REDACTED]",
+		},
+		{
+			name:     "api token header",
+			message:  "Authorization: PVEAPIToken=root@pam!pvetui=0a1b2c3d-4e5f",
+			expected: "Authorization: PVEAPIToken=root@pam!pvetui=[REDACTED]",
+		},
+		{
+			name:     "notification token",
+			message:  "notification backend: {Type:gotify URL:https://gotify.example.com Token:AbCdEf123 Events:[]}",
+			expected: "notification backend: {Type:gotify URL:https://gotify.example.com Token:[REDACTED] Events:[]}",
+		},
+		{
+			name:     "token id is kept",
+			message:  `{"token_id":"pvetui"}`,
+			expected: `{"token_id":"pvetui"}`,
+		},
+		{
+			name:     "bare vnc ticket",
+			message:  "connecting with PVEVNC:6543ABCD::c2lnbmF0dXJl",
+			expected: "connecting with [REDACTED]",
+		},
+		{
+			name:     "lengths are kept",
+			message:  "VNC proxy ticket obtained for VM web (length: 120)",
+			expected: "VNC proxy ticket obtained for VM web (length: 120)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Redact(tt.message))
+		})
+	}
+}
+
+func TestLogger_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewLogger(&Config{Level: LevelDebug, Output: &buf, Format: FormatConsole})
+	require.NoError(t, err)
+
+	logger.WithComponent("redact-test").Debug("login with password=%s", "hunter2")
+
+	assert.Contains(t, buf.String(), "password=[REDACTED]")
+	assert.NotContains(t, buf.String(), "hunter2")
+
+	entries := Entries()
+	require.NotEmpty(t, entries)
+	assert.NotContains(t, entries[len(entries)-1].Message, "hunter2")
+
+	SetRawLogging(true)
+	defer SetRawLogging(false)
+
+	buf.Reset()
+	logger.Debug("login with password=%s", "hunter2")
+	assert.Contains(t, buf.String(), "password=hunter2")
+}
@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Log files are rotated once they reach MaxLogSize. MaxLogBackups older
+// files are kept next to the log file as pvetui.log.1 (newest) and up.
+const (
+	MaxLogSize    = 5 * 1024 * 1024
+	MaxLogBackups = 3
+)
+
+// rotatingFile is a log file rotated by size. Loggers writing to the same
+// path share one rotatingFile so that rotation happens only once.
+type rotatingFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+	refs int
+}
+
+// openFiles holds the log files in use, by path.
+var (
+	openFilesMu sync.Mutex
+	openFiles   = make(map[string]*rotatingFile)
+)
+
+// logFile is one logger's reference to a shared log file. Closing it
+// closes the file once no other logger uses it.
+type logFile struct {
+	file *rotatingFile
+	once sync.Once
+}
+
+// openLogFile opens the log file at path for appending, sharing it with
+// other loggers using the same path.
+func openLogFile(path string) (*logFile, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	openFilesMu.Lock()
+	defer openFilesMu.Unlock()
+
+	file, ok := openFiles[path]
+	if !ok {
+		file = &rotatingFile{path: path}
+		if err := file.open(); err != nil {
+			return nil, err
+		}
+
+		openFiles[path] = file
+	}
+
+	file.refs++
+
+	return &logFile{file: file}, nil
+}
+
+// Write implements io.Writer.
+func (l *logFile) Write(p []byte) (int, error) {
+	return l.file.Write(p)
+}
+
+// Close implements io.Closer.
+func (l *logFile) Close() error {
+	var err error
+
+	l.once.Do(func() {
+		err = l.file.release()
+	})
+
+	return err
+}
+
+// open opens the file at the path of f for appending.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// Write writes p, rotating the file first when p would exceed MaxLogSize.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > MaxLogSize {
+		if err := f.rotate(); err != nil {
+			return 0, fmt.Errorf("rotate log file: %w", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// rotate shifts the backups by one, moves the current file to the first
+// backup and starts a new file. The oldest backup is overwritten.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	f.file = nil
+
+	for i := MaxLogBackups - 1; i >= 1; i-- {
+		_ = os.Rename(backupPath(f.path, i), backupPath(f.path, i+1))
+	}
+
+	if err := os.Rename(f.path, backupPath(f.path, 1)); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// release drops a reference to f and closes it when it was the last one.
+func (f *rotatingFile) release() error {
+	openFilesMu.Lock()
+	defer openFilesMu.Unlock()
+
+	f.refs--
+	if f.refs > 0 {
+		return nil
+	}
+
+	delete(openFiles, f.path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+// backupPath returns the path of the nth backup of the log file at path.
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
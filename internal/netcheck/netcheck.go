@@ -0,0 +1,277 @@
+// Package netcheck tests from the local host whether guests and nodes
+// answer: ICMP echo requests where the host permits them, and TCP connects
+// to a port otherwise or in addition.
+package netcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// ErrICMPNotPermitted is returned by Ping when the host allows neither
+// unprivileged ICMP sockets nor raw sockets.
+var ErrICMPNotPermitted = errors.New("ICMP is not permitted on this host")
+
+// probeInterval is the pause between probes.
+const probeInterval = 200 * time.Millisecond
+
+// Result is the outcome of a series of probes to a target.
+type Result struct {
+	// Target describes what was probed, e.g. "ICMP 192.0.2.10" or
+	// "TCP 192.0.2.10:22".
+	Target string
+	// Sent is the number of probes sent.
+	Sent int
+	// Latencies are the round-trip times of the probes that got a reply or
+	// connected.
+	Latencies []time.Duration
+	// Refused is the number of TCP connects the target refused: the host
+	// answered, but nothing listens on the port.
+	Refused int
+	// Err is set when the probes could not be sent at all.
+	Err error
+}
+
+// Answered reports whether the target answered any probe.
+func (r Result) Answered() bool {
+	return len(r.Latencies) > 0 || r.Refused > 0
+}
+
+// Latency returns the minimum, average and maximum round-trip time of the
+// answered probes.
+func (r Result) Latency() (minimum, average, maximum time.Duration) {
+	if len(r.Latencies) == 0 {
+		return 0, 0, 0
+	}
+
+	var total time.Duration
+
+	minimum = r.Latencies[0]
+
+	for _, latency := range r.Latencies {
+		minimum = min(minimum, latency)
+		maximum = max(maximum, latency)
+		total += latency
+	}
+
+	return minimum, total / time.Duration(len(r.Latencies)), maximum
+}
+
+// Summary describes the result in one line.
+func (r Result) Summary() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+
+	summary := fmt.Sprintf("%d/%d answered", len(r.Latencies), r.Sent)
+
+	if len(r.Latencies) > 0 {
+		minimum, average, maximum := r.Latency()
+		summary += fmt.Sprintf(", latency %s / %s / %s (min/avg/max)",
+			formatLatency(minimum), formatLatency(average), formatLatency(maximum))
+	}
+
+	if r.Refused > 0 {
+		summary += fmt.Sprintf(", %d refused (host up, port closed)", r.Refused)
+	}
+
+	return summary
+}
+
+// formatLatency formats a round-trip time in milliseconds.
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.1f ms", float64(d.Microseconds())/1000)
+}
+
+// Verdict tells whether a guest is reachable from this host from the
+// results of probing the guest and, if known, its node.
+func Verdict(guest []Result, node *Result) string {
+	for _, result := range guest {
+		if result.Answered() {
+			return "The guest is reachable from this host"
+		}
+	}
+
+	switch {
+	case node == nil:
+		return "The guest doesn't answer from this host"
+	case node.Answered():
+		return "Its node answers but the guest doesn't: check the guest's network configuration, firewall and services"
+	default:
+		return "Neither the guest nor its node answer: the network path from this host is the likely problem"
+	}
+}
+
+// resolve returns the IP address of a host name or address.
+func resolve(ctx context.Context, host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address found for %s", host)
+	}
+
+	return addrs[0].IP, nil
+}
+
+// CheckPort connects count times to a TCP port of host, waiting up to
+// timeout for each connect.
+func CheckPort(ctx context.Context, host string, port, count int, timeout time.Duration) Result {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	result := Result{Target: "TCP " + address}
+	dialer := net.Dialer{Timeout: timeout}
+
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		if i > 0 {
+			time.Sleep(probeInterval)
+		}
+
+		result.Sent++
+		start := time.Now()
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			result.Latencies = append(result.Latencies, time.Since(start))
+			_ = conn.Close()
+
+			continue
+		}
+
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			result.Refused++
+
+			continue
+		}
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			result.Err = err
+
+			return result
+		}
+	}
+
+	return result
+}
+
+// Ping sends count ICMP echo requests to host, waiting up to timeout for
+// each reply. Unprivileged ICMP sockets are used where the host permits
+// them (ping_group_range on Linux), raw sockets otherwise; when neither is
+// allowed, ErrICMPNotPermitted is returned.
+func Ping(ctx context.Context, host string, count int, timeout time.Duration) (Result, error) {
+	ip, err := resolve(ctx, host)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Target: "ICMP " + ip.String()}
+
+	protocol, echoType, replyType := 1, icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply)
+	networks := [][2]string{{"udp4", "0.0.0.0"}, {"ip4:icmp", "0.0.0.0"}}
+
+	if ip.To4() == nil {
+		protocol, echoType, replyType = 58, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+		networks = [][2]string{{"udp6", "::"}, {"ip6:ipv6-icmp", "::"}}
+	}
+
+	var (
+		conn       *icmp.PacketConn
+		privileged bool
+	)
+
+	for i, network := range networks {
+		if conn, err = icmp.ListenPacket(network[0], network[1]); err == nil {
+			privileged = i == 1
+
+			break
+		}
+	}
+
+	if conn == nil {
+		return Result{}, fmt.Errorf("%w: %v", ErrICMPNotPermitted, err)
+	}
+	defer conn.Close()
+
+	var destination net.Addr = &net.UDPAddr{IP: ip}
+	if privileged {
+		destination = &net.IPAddr{IP: ip}
+	}
+
+	id := os.Getpid() & 0xffff
+	buf := make([]byte, 1500)
+
+	for seq := 1; seq <= count && ctx.Err() == nil; seq++ {
+		if seq > 1 {
+			time.Sleep(probeInterval)
+		}
+
+		message := icmp.Message{Type: echoType, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("pvetui")}}
+
+		data, err := message.Marshal(nil)
+		if err != nil {
+			return result, err
+		}
+
+		result.Sent++
+		start := time.Now()
+
+		if _, err := conn.WriteTo(data, destination); err != nil {
+			continue
+		}
+
+		if err := conn.SetReadDeadline(start.Add(timeout)); err != nil {
+			return result, err
+		}
+
+		for {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				// Timed out without a reply
+				break
+			}
+
+			reply, err := icmp.ParseMessage(protocol, buf[:n])
+			if err != nil || reply.Type != replyType || !peerIP(peer).Equal(ip) {
+				continue
+			}
+
+			// Unprivileged sockets get their ID rewritten by the kernel, so
+			// only the sequence number identifies the reply
+			if echo, ok := reply.Body.(*icmp.Echo); ok && echo.Seq == seq {
+				result.Latencies = append(result.Latencies, time.Since(start))
+
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// peerIP returns the IP address of the sender of an ICMP message.
+func peerIP(addr net.Addr) net.IP {
+	switch peer := addr.(type) {
+	case *net.UDPAddr:
+		return peer.IP
+	case *net.IPAddr:
+		return peer.IP
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package netcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_ = conn.Close()
+		}
+	}()
+
+	open := CheckPort(context.Background(), "127.0.0.1", port, 2, time.Second)
+	assert.Equal(t, 2, open.Sent)
+	assert.Len(t, open.Latencies, 2)
+	assert.True(t, open.Answered())
+
+	// A closed port is refused: the host still answers
+	require.NoError(t, listener.Close())
+
+	closed := CheckPort(context.Background(), "127.0.0.1", port, 1, time.Second)
+	assert.Empty(t, closed.Latencies)
+	assert.Equal(t, 1, closed.Refused)
+	assert.True(t, closed.Answered())
+	assert.Contains(t, closed.Summary(), "1 refused (host up, port closed)")
+}
+
+func TestPing(t *testing.T) {
+	result, err := Ping(context.Background(), "127.0.0.1", 1, time.Second)
+	if errors.Is(err, ErrICMPNotPermitted) {
+		t.Skip("ICMP is not permitted on this host")
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, "ICMP 127.0.0.1", result.Target)
+	assert.Equal(t, 1, result.Sent)
+	assert.Len(t, result.Latencies, 1)
+}
+
+func TestResult_Summary(t *testing.T) {
+	result := Result{Sent: 3, Latencies: []time.Duration{400 * time.Microsecond, 900 * time.Microsecond}}
+	assert.Equal(t, "2/3 answered, latency 0.4 ms / 0.7 ms / 0.9 ms (min/avg/max)", result.Summary())
+
+	assert.Equal(t, "0/3 answered", Result{Sent: 3}.Summary())
+}
+
+func TestVerdict(t *testing.T) {
+	up := Result{Sent: 1, Latencies: []time.Duration{time.Millisecond}}
+	down := Result{Sent: 1}
+
+	assert.Equal(t, "The guest is reachable from this host", Verdict([]Result{down, up}, &down))
+	assert.Contains(t, Verdict([]Result{down}, &up), "Its node answers but the guest doesn't")
+	assert.Contains(t, Verdict([]Result{down}, &down), "the network path from this host")
+	assert.Equal(t, "The guest doesn't answer from this host", Verdict([]Result{down}, nil))
+}
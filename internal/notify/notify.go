@@ -0,0 +1,138 @@
+// Package notify delivers alerts detected by the interface to outbound
+// backends: generic webhooks, Gotify, ntfy and Slack.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+// SendTimeout bounds the delivery of a single notification.
+const SendTimeout = 10 * time.Second
+
+// gotifyPriority is the priority of Gotify messages; 5 and above raise a
+// notification on Android clients.
+const gotifyPriority = 5
+
+// Message is a notification sent to the backends.
+type Message struct {
+	// Event is one of config.NotificationEvents.
+	Event string
+	Title string
+	Text  string
+	Time  time.Time
+}
+
+// webhookPayload is the JSON body posted to generic webhooks.
+type webhookPayload struct {
+	Event   string    `json:"event"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// Send delivers a message to a backend. Backends that aren't configured for
+// the message event are skipped.
+func Send(ctx context.Context, client *http.Client, notification config.Notification, msg Message) error {
+	if !notification.Notifies(msg.Event) {
+		return nil
+	}
+
+	req, err := newRequest(ctx, notification, msg)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", notification.Type, err)
+	}
+
+	req.Header.Set("User-Agent", "pvetui")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", notification.Type, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if detail := strings.TrimSpace(string(body)); detail != "" {
+			return fmt.Errorf("%s: unexpected response: %s: %s", notification.Type, resp.Status, detail)
+		}
+
+		return fmt.Errorf("%s: unexpected response: %s", notification.Type, resp.Status)
+	}
+
+	return nil
+}
+
+// newRequest builds the request of a backend for a message.
+func newRequest(ctx context.Context, notification config.Notification, msg Message) (*http.Request, error) {
+	switch notification.Type {
+	case config.NotificationGotify:
+		req, err := jsonRequest(ctx, strings.TrimRight(notification.URL, "/")+"/message", map[string]interface{}{
+			"title":    msg.Title,
+			"message":  msg.Text,
+			"priority": gotifyPriority,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Gotify-Key", notification.Token)
+
+		return req, nil
+	case config.NotificationNtfy:
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.URL, strings.NewReader(msg.Text))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Title", msg.Title)
+		req.Header.Set("Tags", "warning")
+
+		if notification.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+notification.Token)
+		}
+
+		return req, nil
+	case config.NotificationSlack:
+		return jsonRequest(ctx, notification.URL, map[string]string{
+			"text": fmt.Sprintf("*%s*\n%s", msg.Title, msg.Text),
+		})
+	case config.NotificationWebhook:
+		return jsonRequest(ctx, notification.URL, webhookPayload{
+			Event:   msg.Event,
+			Title:   msg.Title,
+			Message: msg.Text,
+			Time:    msg.Time,
+		})
+	default:
+		return nil, fmt.Errorf("unknown notification type '%s'", notification.Type)
+	}
+}
+
+// jsonRequest builds a POST request with a JSON body.
+func jsonRequest(ctx context.Context, url string, payload interface{}) (*http.Request, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
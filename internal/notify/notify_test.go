@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+type received struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+func recordingServer(t *testing.T, status int) (*httptest.Server, *[]received) {
+	t.Helper()
+
+	var requests []received
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, received{path: r.URL.Path, header: r.Header, body: string(body)})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestSend(t *testing.T) {
+	server, requests := recordingServer(t, http.StatusOK)
+	msg := Message{
+		Event: config.NotificationEventAlerts,
+		Title: "pvetui alert",
+		Text:  "node pve2 offline",
+		Time:  time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("webhook", func(t *testing.T) {
+		require.NoError(t, Send(context.Background(), server.Client(), config.Notification{Type: config.NotificationWebhook, URL: server.URL + "/hook"}, msg))
+
+		last := (*requests)[len(*requests)-1]
+		assert.Equal(t, "/hook", last.path)
+
+		var payload webhookPayload
+		require.NoError(t, json.Unmarshal([]byte(last.body), &payload))
+		assert.Equal(t, "alerts", payload.Event)
+		assert.Equal(t, "node pve2 offline", payload.Message)
+	})
+
+	t.Run("gotify", func(t *testing.T) {
+		require.NoError(t, Send(context.Background(), server.Client(), config.Notification{Type: config.NotificationGotify, URL: server.URL + "/", Token: "app-token"}, msg))
+
+		last := (*requests)[len(*requests)-1]
+		assert.Equal(t, "/message", last.path)
+		assert.Equal(t, "app-token", last.header.Get("X-Gotify-Key"))
+		assert.Contains(t, last.body, `"priority":5`)
+	})
+
+	t.Run("ntfy", func(t *testing.T) {
+		require.NoError(t, Send(context.Background(), server.Client(), config.Notification{Type: config.NotificationNtfy, URL: server.URL + "/pvetui", Token: "tk"}, msg))
+
+		last := (*requests)[len(*requests)-1]
+		assert.Equal(t, "/pvetui", last.path)
+		assert.Equal(t, "pvetui alert", last.header.Get("Title"))
+		assert.Equal(t, "Bearer tk", last.header.Get("Authorization"))
+		assert.Equal(t, "node pve2 offline", last.body)
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		require.NoError(t, Send(context.Background(), server.Client(), config.Notification{Type: config.NotificationSlack, URL: server.URL}, msg))
+
+		last := (*requests)[len(*requests)-1]
+		assert.JSONEq(t, `{"text":"*pvetui alert*\nnode pve2 offline"}`, last.body)
+	})
+
+	t.Run("event filter", func(t *testing.T) {
+		count := len(*requests)
+		notification := config.Notification{Type: config.NotificationWebhook, URL: server.URL, Events: []string{config.NotificationEventTasks}}
+
+		require.NoError(t, Send(context.Background(), server.Client(), notification, msg))
+		assert.Len(t, *requests, count)
+	})
+}
+
+func TestSendError(t *testing.T) {
+	server, _ := recordingServer(t, http.StatusUnauthorized)
+
+	err := Send(context.Background(), server.Client(), config.Notification{Type: config.NotificationGotify, URL: server.URL}, Message{Event: config.NotificationEventTasks})
+	assert.ErrorContains(t, err, "gotify: unexpected response: 401")
+}
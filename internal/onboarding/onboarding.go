@@ -11,6 +11,8 @@ import (
 	"os"
 	"strings"
 
+	"golang.org/x/term"
+
 	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/ui/components"
 )
@@ -38,6 +40,9 @@ func HandleValidationError(cfg *config.Config, configPath string, noCacheFlag bo
 	}
 
 	fmt.Printf("✅ Success! Configuration file created at %s\n", path)
+
+	// Offer the guided tour on the next start; failing to mark it is harmless
+	_ = config.MarkTourPending()
 	fmt.Println()
 
 	if promptYesNo("Would you like to edit the new config in the interactive editor?") {
@@ -62,6 +67,45 @@ func HandleValidationError(cfg *config.Config, configPath string, noCacheFlag bo
 	return nil
 }
 
+// CheckConfigPermissions warns when the config file holds secrets but can be
+// read by other users, and offers to restrict it to the owner when running
+// in a terminal.
+func CheckConfigPermissions(cfg *config.Config, configPath string) {
+	if configPath == "" || !cfg.HasSecrets() {
+		return
+	}
+
+	mode, exposed, err := config.ExposedPermissions(configPath)
+	if err != nil || !exposed {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("⚠️  WARNING: %s contains passwords or tokens but is accessible by other users (mode %04o).\n", configPath, mode)
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("   Restrict it with: chmod 600 %s\n\n", configPath)
+
+		return
+	}
+
+	if !promptYesNo("Restrict it to your user (mode 0600)?") {
+		fmt.Println("ℹ️  Permissions left unchanged.")
+		fmt.Println()
+
+		return
+	}
+
+	if err := config.RestrictPermissions(configPath); err != nil {
+		fmt.Printf("❌ %v\n\n", err)
+
+		return
+	}
+
+	fmt.Println("✅ Permissions restricted.")
+	fmt.Println()
+}
+
 // promptYesNo is a helper function for yes/no prompts.
 func promptYesNo(prompt string) bool {
 	reader := bufio.NewReader(os.Stdin)
@@ -0,0 +1,103 @@
+package scripts
+
+import (
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/cache"
+)
+
+// ScriptCatalogTTL is how long the cached catalog is used before it is fetched again.
+const ScriptCatalogTTL = 24 * time.Hour
+
+// ScriptCatalogCacheKey is the cache key of the script catalog.
+const ScriptCatalogCacheKey = "github_script_catalog"
+
+// Catalog is the set of scripts available in the repository, as last fetched from GitHub.
+type Catalog struct {
+	Categories []ScriptCategory `json:"categories"`
+	Scripts    []Script         `json:"scripts"`
+	FetchedAt  time.Time        `json:"fetched_at"`
+
+	// Offline is set when GitHub could not be reached and an expired
+	// catalog was returned instead.
+	Offline bool `json:"-"`
+}
+
+// IsExpired reports whether the catalog is older than ScriptCatalogTTL.
+func (c *Catalog) IsExpired(now time.Time) bool {
+	return now.Sub(c.FetchedAt) > ScriptCatalogTTL
+}
+
+// ScriptsByCategory returns the scripts whose path or type matches the category path.
+func (c *Catalog) ScriptsByCategory(category string) []Script {
+	var categoryScripts []Script
+
+	for _, script := range c.Scripts {
+		if strings.HasPrefix(script.ScriptPath, category+"/") || script.Type == category {
+			categoryScripts = append(categoryScripts, script)
+		}
+	}
+
+	return categoryScripts
+}
+
+// LoadCatalog returns the cached script catalog, fetching it from GitHub when
+// it is missing or expired. If GitHub is unreachable an expired catalog is
+// returned with Offline set.
+func LoadCatalog() (*Catalog, error) {
+	return loadCatalog(cache.GetGlobalCache(), fetchScripts, false, time.Now())
+}
+
+// RefreshCatalog fetches the script catalog from GitHub, bypassing all cached
+// metadata. If GitHub is unreachable the cached catalog is returned with
+// Offline set.
+func RefreshCatalog() (*Catalog, error) {
+	return loadCatalog(cache.GetGlobalCache(), fetchScripts, true, time.Now())
+}
+
+// loadCatalog implements LoadCatalog and RefreshCatalog.
+func loadCatalog(c cache.Cache, fetch func(refresh bool) ([]Script, error), refresh bool, now time.Time) (*Catalog, error) {
+	var cached Catalog
+
+	found, err := c.Get(ScriptCatalogCacheKey, &cached)
+	if err != nil {
+		getScriptsLogger().Debug("Cache error for script catalog: %v", err)
+
+		found = false
+	}
+
+	found = found && len(cached.Scripts) > 0
+
+	if found && !refresh && !cached.IsExpired(now) {
+		getScriptsLogger().Debug("Using cached script catalog (%d scripts, fetched %s)", len(cached.Scripts), cached.FetchedAt.Format(time.RFC3339))
+
+		return &cached, nil
+	}
+
+	fetched, err := fetch(refresh)
+	if err != nil {
+		if found {
+			getScriptsLogger().Debug("Failed to fetch script catalog, using cached copy from %s: %v", cached.FetchedAt.Format(time.RFC3339), err)
+
+			cached.Offline = true
+
+			return &cached, nil
+		}
+
+		return nil, err
+	}
+
+	catalog := &Catalog{
+		Categories: GetScriptCategories(),
+		Scripts:    fetched,
+		FetchedAt:  now,
+	}
+
+	// Store without a TTL so an expired catalog remains available offline
+	if err := c.Set(ScriptCatalogCacheKey, catalog, 0); err != nil {
+		getScriptsLogger().Debug("Failed to cache script catalog: %v", err)
+	}
+
+	return catalog, nil
+}
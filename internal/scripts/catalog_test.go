@@ -0,0 +1,99 @@
+package scripts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/cache"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	catalogScripts := []Script{
+		{Name: "Debian", Type: "ct", ScriptPath: "ct/debian.sh"},
+		{Name: "Ubuntu VM", Type: "vm", ScriptPath: "vm/ubuntu.sh"},
+	}
+
+	fetchOK := func(calls *int) func(bool) ([]Script, error) {
+		return func(bool) ([]Script, error) {
+			*calls++
+
+			return catalogScripts, nil
+		}
+	}
+
+	fetchFail := func(bool) ([]Script, error) {
+		return nil, errors.New("github unreachable")
+	}
+
+	t.Run("fetches and caches when empty", func(t *testing.T) {
+		c := cache.NewMemoryCache()
+		calls := 0
+
+		catalog, err := loadCatalog(c, fetchOK(&calls), false, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, catalog.Scripts, 2)
+		assert.False(t, catalog.Offline)
+
+		catalog, err = loadCatalog(c, fetchOK(&calls), false, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls, "fresh catalog should come from the cache")
+		assert.Len(t, catalog.Scripts, 2)
+	})
+
+	t.Run("refetches when expired or refreshed", func(t *testing.T) {
+		c := cache.NewMemoryCache()
+		calls := 0
+
+		_, err := loadCatalog(c, fetchOK(&calls), false, now)
+		require.NoError(t, err)
+
+		_, err = loadCatalog(c, fetchOK(&calls), false, now.Add(ScriptCatalogTTL+time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+
+		_, err = loadCatalog(c, fetchOK(&calls), true, now)
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("falls back to expired catalog when offline", func(t *testing.T) {
+		c := cache.NewMemoryCache()
+		calls := 0
+
+		_, err := loadCatalog(c, fetchOK(&calls), false, now)
+		require.NoError(t, err)
+
+		catalog, err := loadCatalog(c, fetchFail, false, now.Add(2*ScriptCatalogTTL))
+		require.NoError(t, err)
+		assert.True(t, catalog.Offline)
+		assert.Len(t, catalog.Scripts, 2)
+
+		catalog, err = loadCatalog(c, fetchFail, true, now)
+		require.NoError(t, err)
+		assert.True(t, catalog.Offline)
+	})
+
+	t.Run("returns error when offline without cache", func(t *testing.T) {
+		_, err := loadCatalog(cache.NewMemoryCache(), fetchFail, false, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestCatalog_ScriptsByCategory(t *testing.T) {
+	catalog := &Catalog{Scripts: []Script{
+		{Name: "Debian", Type: "ct", ScriptPath: "ct/debian.sh"},
+		{Name: "Ubuntu VM", Type: "vm", ScriptPath: "vm/ubuntu.sh"},
+		{Name: "Post Install", Type: "pve", ScriptPath: "tools/pve/post-install.sh"},
+	}}
+
+	assert.Len(t, catalog.ScriptsByCategory("ct"), 1)
+	assert.Len(t, catalog.ScriptsByCategory("vm"), 1)
+	assert.Len(t, catalog.ScriptsByCategory("tools"), 1)
+	assert.Empty(t, catalog.ScriptsByCategory("misc"))
+}
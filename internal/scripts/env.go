@@ -0,0 +1,117 @@
+package scripts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvVar is an environment variable understood by the community scripts.
+type EnvVar struct {
+	Name        string
+	Label       string
+	Description string
+}
+
+// ScriptResources holds the default resources documented for a script.
+type ScriptResources struct {
+	CPU     int    `json:"cpu"`
+	RAM     int    `json:"ram"`
+	HDD     int    `json:"hdd"`
+	OS      string `json:"os"`
+	Version string `json:"version"`
+}
+
+// containerEnvVars are the variables read by the container build helpers.
+var containerEnvVars = []EnvVar{
+	{Name: "var_ctid", Label: "Container ID", Description: "CTID of the new container"},
+	{Name: "var_hostname", Label: "Hostname", Description: "Hostname of the new container"},
+	{Name: "var_cpu", Label: "CPU Cores", Description: "Number of CPU cores"},
+	{Name: "var_ram", Label: "RAM (MiB)", Description: "Memory in MiB"},
+	{Name: "var_disk", Label: "Disk Size (GB)", Description: "Root disk size in GB"},
+	{Name: "var_unprivileged", Label: "Unprivileged (1/0)", Description: "1 for an unprivileged container"},
+	{Name: "var_brg", Label: "Bridge", Description: "Network bridge"},
+}
+
+// vmEnvVars are the variables read by the VM scripts.
+var vmEnvVars = []EnvVar{
+	{Name: "var_vmid", Label: "VM ID", Description: "VMID of the new virtual machine"},
+	{Name: "var_hostname", Label: "Hostname", Description: "Hostname of the new virtual machine"},
+	{Name: "var_cpu", Label: "CPU Cores", Description: "Number of CPU cores"},
+	{Name: "var_ram", Label: "RAM (MiB)", Description: "Memory in MiB"},
+	{Name: "var_disk", Label: "Disk Size (GB)", Description: "Disk size in GB"},
+	{Name: "var_brg", Label: "Bridge", Description: "Network bridge"},
+}
+
+// envNamePattern matches valid environment variable names.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvVarsForScript returns the environment variables that can be pre-seeded for a script.
+func EnvVarsForScript(script Script) []EnvVar {
+	switch script.Type {
+	case "ct":
+		return containerEnvVars
+	case "vm":
+		return vmEnvVars
+	default:
+		return nil
+	}
+}
+
+// DefaultEnvValues returns pre-filled values for the script's environment
+// variables based on the resources documented in its metadata.
+func DefaultEnvValues(script Script) map[string]string {
+	values := make(map[string]string)
+
+	if script.Resources.CPU > 0 {
+		values["var_cpu"] = strconv.Itoa(script.Resources.CPU)
+	}
+
+	if script.Resources.RAM > 0 {
+		values["var_ram"] = strconv.Itoa(script.Resources.RAM)
+	}
+
+	if script.Resources.HDD > 0 {
+		values["var_disk"] = strconv.Itoa(script.Resources.HDD)
+	}
+
+	return values
+}
+
+// ValidateEnv checks that variable names are valid and that values only
+// contain characters that are safe to pass through the remote shells.
+func ValidateEnv(env map[string]string) error {
+	for name, value := range env {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid environment variable name: %s", name)
+		}
+
+		for _, c := range value {
+			if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+				c == '.' || c == '_' || c == '-' || c == '/' || c == ':' || c == ',' || c == '@') {
+				return fmt.Errorf("invalid character %q in %s", c, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// envAssignments formats the non-empty variables as sorted NAME=value pairs.
+func envAssignments(env map[string]string) string {
+	assignments := make([]string, 0, len(env))
+
+	for name, value := range env {
+		if value == "" {
+			continue
+		}
+
+		assignments = append(assignments, name+"="+value)
+	}
+
+	sort.Strings(assignments)
+
+	return strings.Join(assignments, " ")
+}
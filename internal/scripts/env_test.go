@@ -0,0 +1,47 @@
+package scripts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEnv(t *testing.T) {
+	assert.NoError(t, ValidateEnv(nil))
+	assert.NoError(t, ValidateEnv(map[string]string{
+		"var_ctid":     "120",
+		"var_hostname": "web-01.lan",
+		"var_brg":      "vmbr0",
+		"var_disk":     "",
+	}))
+
+	assert.ErrorContains(t, ValidateEnv(map[string]string{"1var": "x"}), "invalid environment variable name")
+	assert.ErrorContains(t, ValidateEnv(map[string]string{"var_hostname": "a b"}), "invalid character")
+	assert.ErrorContains(t, ValidateEnv(map[string]string{"var_hostname": "x;rm -rf /"}), "invalid character")
+	assert.ErrorContains(t, ValidateEnv(map[string]string{"var_hostname": "$(id)"}), "invalid character")
+}
+
+func TestBuildInstallCommand(t *testing.T) {
+	url := RawGitHubRepo + "/ct/debian.sh"
+
+	assert.Equal(t,
+		`sudo su - root -c "SHELL=/bin/bash /bin/bash -c \"\$(curl -fsSL `+url+`)\""`,
+		buildInstallCommand(url, nil))
+
+	assert.Equal(t,
+		`sudo su - root -c "var_cpu=2 var_ctid=120 SHELL=/bin/bash /bin/bash -c \"\$(curl -fsSL `+url+`)\""`,
+		buildInstallCommand(url, map[string]string{"var_ctid": "120", "var_cpu": "2", "var_ram": ""}))
+}
+
+func TestEnvVarsForScript(t *testing.T) {
+	assert.Equal(t, containerEnvVars, EnvVarsForScript(Script{Type: "ct"}))
+	assert.Equal(t, vmEnvVars, EnvVarsForScript(Script{Type: "vm"}))
+	assert.Empty(t, EnvVarsForScript(Script{Type: "pve"}))
+}
+
+func TestDefaultEnvValues(t *testing.T) {
+	values := DefaultEnvValues(Script{Resources: ScriptResources{CPU: 2, RAM: 1024, HDD: 8}})
+
+	assert.Equal(t, map[string]string{"var_cpu": "2", "var_ram": "1024", "var_disk": "8"}, values)
+	assert.Empty(t, DefaultEnvValues(Script{}))
+}
@@ -0,0 +1,205 @@
+package scripts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// History file layout inside the history directory.
+const (
+	historyFileName = "history.json"
+	historyLogDir   = "logs"
+)
+
+// MaxHistoryEntries is the number of installations kept in the history.
+const MaxHistoryEntries = 50
+
+// HistoryEntry records a single script installation.
+type HistoryEntry struct {
+	Script     string        `json:"script"`
+	ScriptPath string        `json:"script_path"`
+	Source     string        `json:"source,omitempty"`
+	Node       string        `json:"node"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	ExitCode   int           `json:"exit_code"`
+	Error      string        `json:"error,omitempty"`
+	LogFile    string        `json:"log_file"`
+}
+
+// Succeeded reports whether the installation finished without error.
+func (e HistoryEntry) Succeeded() bool {
+	return e.ExitCode == 0 && e.Error == ""
+}
+
+// History stores script installation records and their output logs on disk.
+// Use a single History per directory; its lock does not guard against other
+// instances writing the same history file.
+type History struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewHistory creates a history stored in dir.
+func NewHistory(dir string) *History {
+	return &History{dir: dir}
+}
+
+// Run runs install with a log file as its output and records the result.
+func (h *History) Run(script Script, node string, install func(output io.Writer) error) error {
+	startedAt := time.Now()
+
+	entry := HistoryEntry{
+		Script:     script.Name,
+		ScriptPath: script.ScriptPath,
+		Source:     script.Source,
+		Node:       node,
+		StartedAt:  startedAt,
+	}
+
+	logFile, err := h.createLogFile(script, startedAt)
+	if err != nil {
+		getScriptsLogger().Debug("Failed to create script log file: %v", err)
+	} else {
+		entry.LogFile = logFile.Name()
+	}
+
+	var output io.Writer
+	if logFile != nil {
+		output = logFile
+	}
+
+	installErr := install(output)
+
+	if logFile != nil {
+		_ = logFile.Close()
+	}
+
+	entry.Duration = time.Since(startedAt).Round(time.Second)
+	entry.ExitCode = exitCode(installErr)
+
+	if installErr != nil {
+		entry.Error = installErr.Error()
+	}
+
+	if err := h.Record(entry); err != nil {
+		getScriptsLogger().Debug("Failed to record script history: %v", err)
+	}
+
+	return installErr
+}
+
+// Record adds an entry to the history, dropping the oldest entries and their
+// logs beyond MaxHistoryEntries.
+func (h *History) Record(entry HistoryEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries, err := h.load()
+	if err != nil {
+		return err
+	}
+
+	entries = append([]HistoryEntry{entry}, entries...)
+
+	if len(entries) > MaxHistoryEntries {
+		for _, dropped := range entries[MaxHistoryEntries:] {
+			if dropped.LogFile != "" {
+				_ = os.Remove(dropped.LogFile)
+			}
+		}
+
+		entries = entries[:MaxHistoryEntries]
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode script history: %w", err)
+	}
+
+	if err := os.MkdirAll(h.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(h.dir, historyFileName), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write script history: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns the recorded installations, newest first.
+func (h *History) Entries() ([]HistoryEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.load()
+}
+
+// load reads the history file. A missing file is an empty history.
+func (h *History) load() ([]HistoryEntry, error) {
+	data, err := os.ReadFile(filepath.Join(h.dir, historyFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse script history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// createLogFile creates the output log for an installation.
+func (h *History) createLogFile(script Script, startedAt time.Time) (*os.File, error) {
+	logDir := filepath.Join(h.dir, historyLogDir)
+	if err := os.MkdirAll(logDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(script.ScriptPath), filepath.Ext(script.ScriptPath))
+	if name == "" || name == "." {
+		name = "script"
+	}
+
+	// CreateTemp adds a random suffix, so runs started in the same second get their own log
+	return os.CreateTemp(logDir, fmt.Sprintf("%s-%s-*.log", startedAt.Format("20060102-150405"), name))
+}
+
+// ReadLog returns the contents of an installation log.
+func ReadLog(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// exitCode returns the process exit code of an installation error, 0 for
+// success, and -1 when the installation failed before the script ran.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
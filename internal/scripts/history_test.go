@@ -0,0 +1,84 @@
+package scripts
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_Run(t *testing.T) {
+	history := NewHistory(t.TempDir())
+	script := Script{Name: "Debian", ScriptPath: "ct/debian.sh"}
+
+	err := history.Run(script, "pve1", func(output io.Writer) error {
+		_, err := io.WriteString(output, "installing debian\n")
+
+		return err
+	})
+	require.NoError(t, err)
+
+	installErr := errors.New("ssh: connect to host pve2: Connection refused")
+	err = history.Run(script, "pve2", func(io.Writer) error {
+		return installErr
+	})
+	assert.ErrorIs(t, err, installErr)
+
+	entries, err := history.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// Newest first
+	assert.Equal(t, "pve2", entries[0].Node)
+	assert.False(t, entries[0].Succeeded())
+	assert.Equal(t, -1, entries[0].ExitCode)
+	assert.Contains(t, entries[0].Error, "Connection refused")
+
+	assert.Equal(t, "pve1", entries[1].Node)
+	assert.True(t, entries[1].Succeeded())
+	assert.Equal(t, "Debian", entries[1].Script)
+
+	log, err := ReadLog(entries[1].LogFile)
+	require.NoError(t, err)
+	assert.Equal(t, "installing debian\n", log)
+}
+
+func TestHistory_RecordTrimsOldEntries(t *testing.T) {
+	history := NewHistory(t.TempDir())
+
+	oldLog, err := os.CreateTemp(t.TempDir(), "old-*.log")
+	require.NoError(t, err)
+	require.NoError(t, oldLog.Close())
+
+	require.NoError(t, history.Record(HistoryEntry{Script: "oldest", LogFile: oldLog.Name()}))
+
+	for i := 0; i < MaxHistoryEntries; i++ {
+		require.NoError(t, history.Record(HistoryEntry{Script: "newer", StartedAt: time.Now()}))
+	}
+
+	entries, err := history.Entries()
+	require.NoError(t, err)
+	assert.Len(t, entries, MaxHistoryEntries)
+	assert.NoFileExists(t, oldLog.Name())
+}
+
+func TestHistory_EntriesEmpty(t *testing.T) {
+	entries, err := NewHistory(t.TempDir()).Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, exitCode(nil))
+	assert.Equal(t, -1, exitCode(errors.New("boom")))
+
+	if _, err := exec.LookPath("sh"); err == nil {
+		err := exec.Command("sh", "-c", "exit 3").Run()
+		assert.Equal(t, 3, exitCode(err))
+	}
+}
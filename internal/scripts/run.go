@@ -0,0 +1,89 @@
+package scripts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/ssh"
+)
+
+// ErrCancelled is returned by RunScript when ctx was cancelled before the
+// script finished.
+var ErrCancelled = errors.New("script cancelled")
+
+// scriptStopTimeout is how long a cancelled script has to exit on the node
+// before the SSH session is killed.
+const scriptStopTimeout = 10 * time.Second
+
+// RunScript runs a script on a Proxmox node without a terminal, writing the
+// combined output to opts.Output. Scripts that prompt for input fail because
+// stdin is closed, so pre-seed their settings through opts.Env. Cancelling
+// ctx stops the script on the node with SIGTERM and returns ErrCancelled.
+func RunScript(ctx context.Context, user, nodeIP string, script Script, opts InstallOptions) error {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	installCmd, err := prepareRemoteCommand(ctx, user, nodeIP, script, opts.Env, nil, output, output)
+	if err != nil {
+		return err
+	}
+
+	getScriptsLogger().Debug("Running script %s on node %s without terminal", script.ScriptPath, nodeIP)
+
+	sshArgs := append(ssh.NodeOptions().Args(),
+		"-T",                  // No remote terminal
+		"-o", "BatchMode=yes", // Don't prompt for passwords
+		"-o", "ServerAliveInterval=15",
+		fmt.Sprintf("%s@%s", user, nodeIP),
+		cancelableCommand(installCmd))
+
+	sshCmd := exec.CommandContext(ctx, "ssh", sshArgs...)
+	sshCmd.Stdout = output
+	sshCmd.Stderr = output
+	sshCmd.Env = append(os.Environ(), "TERM=xterm-256color")
+
+	// Closing stdin stops the script on the node; ssh is only killed if the
+	// script doesn't exit in time
+	stdin, err := sshCmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("script run failed: %w", err)
+	}
+
+	sshCmd.Cancel = stdin.Close
+	sshCmd.WaitDelay = scriptStopTimeout
+
+	if err := sshCmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ErrCancelled
+		}
+
+		return fmt.Errorf("script run failed: %w", err)
+	}
+
+	return nil
+}
+
+// cancelableCommand wraps command so that it stops when the stdin of the SSH
+// session closes. The command runs in a process group of its own with stdin
+// from /dev/null, and a watcher reading the session's stdin sends the group
+// SIGTERM at EOF. The shell running command traps SIGTERM, so what follows
+// the script in command, like removing a copied script, still runs. The exit
+// status is the command's.
+func cancelableCommand(command string) string {
+	return "exec 3<&0; " +
+		"setsid sh -c " + shellQuote("trap : TERM; "+command) + " </dev/null 3<&- & pid=$!; " +
+		"(read -r _ <&3; kill -TERM -$pid) >/dev/null 2>&1 & watcher=$!; " +
+		"wait $pid; status=$?; kill $watcher 2>/dev/null; exit $status"
+}
+
+// shellQuote quotes s as a single argument for the shell of the node.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
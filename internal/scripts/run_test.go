@@ -0,0 +1,75 @@
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunScript_Validation(t *testing.T) {
+	var output bytes.Buffer
+
+	err := RunScript(context.Background(), "root", "192.168.254.254",
+		Script{ScriptPath: "ct/$(id).sh"}, InstallOptions{Output: &output})
+	assert.ErrorContains(t, err, "invalid script path character")
+
+	err = RunScript(context.Background(), "root", "192.168.254.254",
+		Script{ScriptPath: "ct/debian.sh"}, InstallOptions{Env: map[string]string{"var_hostname": "a;b"}, Output: &output})
+	assert.ErrorContains(t, err, "invalid character")
+
+	err = RunScript(context.Background(), "root", "192.168.254.254",
+		Script{ScriptPath: "ct/debian.sh", Source: "Team", Location: "ftp://example.com/ct/debian.sh"}, InstallOptions{Output: &output})
+	assert.ErrorContains(t, err, "unsupported script URL scheme")
+
+	// A mistyped scheme is not mistaken for a local file
+	err = RunScript(context.Background(), "root", "192.168.254.254",
+		Script{ScriptPath: "ct/debian.sh", Source: "Team", Location: "htps://example.com/ct/debian.sh"}, InstallOptions{Output: &output})
+	assert.ErrorContains(t, err, "unsupported script URL scheme")
+
+	assert.Empty(t, output.String())
+}
+
+func TestCancelableCommand(t *testing.T) {
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("setsid not available")
+	}
+
+	// The exit status of the command is kept
+	cmd := exec.Command("sh", "-c", cancelableCommand(`echo "it's running"; exit 3`))
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+
+	out, err := cmd.Output()
+	_ = stdin.Close()
+
+	var exitErr *exec.ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode())
+	assert.Equal(t, "it's running\n", string(out))
+
+	// Closing stdin stops everything the command started, and the rest of
+	// the command runs
+	cmd = exec.Command("sh", "-c", cancelableCommand("sleep 30 & sleep 30; status=$?; echo cleaned up; exit $status"))
+	stdin, err = cmd.StdinPipe()
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+
+	require.NoError(t, cmd.Start())
+	time.Sleep(200 * time.Millisecond)
+
+	start := time.Now()
+
+	require.NoError(t, stdin.Close())
+	require.ErrorAs(t, cmd.Wait(), &exitErr)
+	assert.Equal(t, 143, exitErr.ExitCode())
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, "cleaned up\n", output.String())
+}
@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/devnullvoid/pvetui/internal/cache"
+	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/logger"
+	"github.com/devnullvoid/pvetui/internal/ssh"
 	"github.com/devnullvoid/pvetui/internal/ui/utils"
 	"github.com/devnullvoid/pvetui/pkg/api/interfaces"
 )
@@ -41,24 +43,31 @@ type ScriptCategory struct {
 	Name        string
 	Description string
 	Path        string
+
+	// Source is set for categories backed by a custom script source.
+	Source *config.ScriptSource `json:"-"`
 }
 
 // Script represents a single script from the repository.
 type Script struct {
-	Name          string `json:"name"`
-	Slug          string `json:"slug"`
-	Description   string `json:"description"`
-	Categories    []int  `json:"categories"`
-	Type          string `json:"type"` // "ct" for containers, "vm" for VMs
-	Updateable    bool   `json:"updateable"`
-	Privileged    bool   `json:"privileged"`
-	InterfacePort int    `json:"interface_port"`
-	Documentation string `json:"documentation"`
-	Website       string `json:"website"`
-	ConfigPath    string `json:"config_path"`
-	Logo          string `json:"logo"`
-	ScriptPath    string // Added for our use, not in the JSON
-	DateCreated   string `json:"date_created"`
+	Name          string          `json:"name"`
+	Slug          string          `json:"slug"`
+	Description   string          `json:"description"`
+	Categories    []int           `json:"categories"`
+	Type          string          `json:"type"` // "ct" for containers, "vm" for VMs
+	Updateable    bool            `json:"updateable"`
+	Privileged    bool            `json:"privileged"`
+	InterfacePort int             `json:"interface_port"`
+	Documentation string          `json:"documentation"`
+	Website       string          `json:"website"`
+	ConfigPath    string          `json:"config_path"`
+	Logo          string          `json:"logo"`
+	ScriptPath    string          // Added for our use, not in the JSON
+	Resources     ScriptResources // Default resources of the first install method
+	Source        string          `json:"-"` // Name of the custom script source, empty for community scripts
+	Location      string          `json:"-"` // URL or local file of a custom source script
+	Local         bool            `json:"-"` // Whether Location is a file of a local source
+	DateCreated   string          `json:"date_created"`
 }
 
 // GitHubContent represents a file or directory in the GitHub API.
@@ -206,8 +215,7 @@ func GetScriptMetadataFiles() ([]GitHubContent, error) {
 
 // GetScriptMetadata fetches and parses the metadata for a specific script.
 func GetScriptMetadata(metadataURL string) (*Script, error) {
-	// Generate a cache key based on the URL
-	cacheKey := ScriptCacheKeyPrefix + strings.ReplaceAll(metadataURL, "/", "_")
+	cacheKey := scriptCacheKey(metadataURL)
 
 	// Check cache first
 	c := cache.GetGlobalCache()
@@ -272,8 +280,9 @@ func GetScriptMetadata(metadataURL string) (*Script, error) {
 
 	// Extract the script path from the install_methods if available
 	type InstallMethod struct {
-		Type   string `json:"type"`
-		Script string `json:"script"`
+		Type      string          `json:"type"`
+		Script    string          `json:"script"`
+		Resources ScriptResources `json:"resources"`
 	}
 
 	type ScriptWithInstallMethods struct {
@@ -289,6 +298,7 @@ func GetScriptMetadata(metadataURL string) (*Script, error) {
 	// Extract the script path from the first install method
 	if len(scriptWithMethods.InstallMethods) > 0 {
 		script.ScriptPath = scriptWithMethods.InstallMethods[0].Script
+		script.Resources = scriptWithMethods.InstallMethods[0].Resources
 	} else {
 		// If no install methods found, try to guess based on the slug
 		if script.Type == "ct" {
@@ -313,8 +323,27 @@ func GetScriptMetadata(metadataURL string) (*Script, error) {
 	return &script, nil
 }
 
+// scriptCacheKey returns the cache key for the metadata at the given URL.
+func scriptCacheKey(metadataURL string) string {
+	return ScriptCacheKeyPrefix + strings.ReplaceAll(metadataURL, "/", "_")
+}
+
 // FetchScripts fetches all available scripts from the repository.
 func FetchScripts() ([]Script, error) {
+	return fetchScripts(false)
+}
+
+// fetchScripts fetches all available scripts, dropping cached metadata first
+// when refresh is set.
+func fetchScripts(refresh bool) ([]Script, error) {
+	c := cache.GetGlobalCache()
+
+	if refresh {
+		if err := c.Delete(ScriptListCacheKey); err != nil {
+			getScriptsLogger().Debug("Failed to drop cached script list: %v", err)
+		}
+	}
+
 	// Get all metadata files
 	metadataFiles, err := GetScriptMetadataFiles()
 	if err != nil {
@@ -332,6 +361,10 @@ func FetchScripts() ([]Script, error) {
 	var errorCount int
 
 	for _, file := range metadataFiles {
+		if refresh {
+			_ = c.Delete(scriptCacheKey(file.DownloadURL))
+		}
+
 		script, err := GetScriptMetadata(file.DownloadURL)
 		if err != nil {
 			// Skip this script but log the error
@@ -360,22 +393,14 @@ func FetchScripts() ([]Script, error) {
 	return scripts, nil
 }
 
-// GetScriptsByCategory returns scripts for a specific category.
+// GetScriptsByCategory returns scripts for a specific category from the cached catalog.
 func GetScriptsByCategory(category string) ([]Script, error) {
-	allScripts, err := FetchScripts()
+	catalog, err := LoadCatalog()
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter scripts by category
-	var categoryScripts []Script
-
-	for _, script := range allScripts {
-		// If the script path starts with the category name or the type matches
-		if strings.HasPrefix(script.ScriptPath, category+"/") || script.Type == category {
-			categoryScripts = append(categoryScripts, script)
-		}
-	}
+	categoryScripts := catalog.ScriptsByCategory(category)
 
 	if len(categoryScripts) == 0 {
 		return nil, fmt.Errorf("no scripts found for category: %s", category)
@@ -384,13 +409,24 @@ func GetScriptsByCategory(category string) ([]Script, error) {
 	return categoryScripts, nil
 }
 
+// InstallOptions controls how a script is installed.
+type InstallOptions struct {
+	// Env holds variables exported to the script so it can skip the matching
+	// prompts. Empty values are left unset.
+	Env map[string]string
+	// Output receives a copy of the session output when set.
+	Output io.Writer
+}
+
 // InstallScript installs a script on a Proxmox node interactively.
-func InstallScript(user, nodeIP, scriptPath string) error {
+func InstallScript(user, nodeIP, scriptPath string, opts InstallOptions) error {
 	// Validate script path for security
-	for _, c := range scriptPath {
-		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '/' || c == '.' || c == '_' || c == '-') {
-			return fmt.Errorf("invalid script path character: %c", c)
-		}
+	if err := validateScriptPath(scriptPath); err != nil {
+		return err
+	}
+
+	if err := ValidateEnv(opts.Env); err != nil {
+		return err
 	}
 
 	getScriptsLogger().Debug("Installing script: %s on node %s", scriptPath, nodeIP)
@@ -398,16 +434,41 @@ func InstallScript(user, nodeIP, scriptPath string) error {
 	// Build the script installation command using curl (matches official instructions)
 	scriptURL := fmt.Sprintf("%s/%s", RawGitHubRepo, scriptPath)
 	// Switch to root user completely and run in bash environment
-	installCmd := fmt.Sprintf("sudo su - root -c \"SHELL=/bin/bash /bin/bash -c \\\"\\$(curl -fsSL %s)\\\"\"", scriptURL)
+	installCmd := buildInstallCommand(scriptURL, opts.Env)
+
+	return runInstallCommand(user, nodeIP, installCmd, opts.Output)
+}
+
+// validateScriptPath rejects script paths with characters that are unsafe in
+// the remote shell command.
+func validateScriptPath(scriptPath string) error {
+	for _, c := range scriptPath {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '/' || c == '.' || c == '_' || c == '-') {
+			return fmt.Errorf("invalid script path character: %c", c)
+		}
+	}
 
+	return nil
+}
+
+// runInstallCommand runs the installation command on the node in an
+// interactive SSH session and waits for the user before returning. The
+// session output is copied to output when it is not nil.
+func runInstallCommand(user, nodeIP, installCmd string, output io.Writer) error {
 	// Use SSH to run the script installation command interactively with proper terminal environment
-	sshCmd := exec.Command("ssh", "-t", fmt.Sprintf("%s@%s", user, nodeIP), installCmd)
+	sshArgs := append(ssh.NodeOptions().Args(), "-t", fmt.Sprintf("%s@%s", user, nodeIP), installCmd)
+	sshCmd := exec.Command("ssh", sshArgs...)
 
 	// Connect stdin/stdout/stderr for interactive session
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
 	sshCmd.Stderr = os.Stderr
 
+	if output != nil {
+		sshCmd.Stdout = io.MultiWriter(os.Stdout, output)
+		sshCmd.Stderr = io.MultiWriter(os.Stderr, output)
+	}
+
 	// Set environment variables for better terminal compatibility
 	// Override TERM to xterm-256color for better compatibility with remote systems
 	// This fixes issues with terminals like Kitty (xterm-kitty) that aren't recognized on all systems
@@ -428,11 +489,22 @@ func InstallScript(user, nodeIP, scriptPath string) error {
 	return nil
 }
 
+// buildInstallCommand returns the remote command that runs the script as root
+// with the given environment variables set.
+func buildInstallCommand(scriptURL string, env map[string]string) string {
+	assignments := envAssignments(env)
+	if assignments != "" {
+		assignments += " "
+	}
+
+	return fmt.Sprintf("sudo su - root -c \"%sSHELL=/bin/bash /bin/bash -c \\\"\\$(curl -fsSL %s)\\\"\"", assignments, scriptURL)
+}
+
 // ValidateConnection checks if SSH connection to the node is possible.
 func ValidateConnection(user, nodeIP string) error {
 	// Simple command to test SSH connection with timeout
 	// Use similar SSH options as InstallScript for consistency
-	cmd := exec.Command("ssh",
+	sshArgs := append(ssh.NodeOptions().Args(),
 		"-o", "ConnectTimeout=5", // 5 second connection timeout
 		"-o", "ServerAliveInterval=2", // Send keepalive every 2 seconds
 		"-o", "ServerAliveCountMax=1", // Give up after 1 failed keepalive
@@ -443,6 +515,8 @@ func ValidateConnection(user, nodeIP string) error {
 		fmt.Sprintf("%s@%s", user, nodeIP),
 		"echo 'Connection test successful'")
 
+	cmd := exec.Command("ssh", sshArgs...)
+
 	err := cmd.Run()
 	if err != nil {
 		return fmt.Errorf("SSH connection failed: %w", err)
@@ -0,0 +1,273 @@
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+)
+
+// ManifestFileName is the manifest file read from the root of a script source.
+const ManifestFileName = "manifest.yaml"
+
+// sourceFetchTimeout bounds manifest downloads from remote sources.
+const sourceFetchTimeout = 15 * time.Second
+
+// Manifest lists the scripts offered by a custom script source.
+type Manifest struct {
+	Scripts []ManifestScript `yaml:"scripts"`
+}
+
+// ManifestScript describes a single script in a manifest.
+type ManifestScript struct {
+	Name          string `yaml:"name"`
+	Description   string `yaml:"description"`
+	Path          string `yaml:"path"` // Relative to the source root
+	Type          string `yaml:"type"` // Optional: "ct" or "vm" enables environment pre-seeding
+	Documentation string `yaml:"documentation"`
+}
+
+// SourceCategories returns a script category for each configured source.
+func SourceCategories(sources []config.ScriptSource) []ScriptCategory {
+	categories := make([]ScriptCategory, 0, len(sources))
+
+	for i := range sources {
+		source := &sources[i]
+
+		description := source.URL
+		if source.Path != "" {
+			description = source.Path
+		}
+
+		categories = append(categories, ScriptCategory{
+			Name:        source.Name,
+			Description: description,
+			Path:        source.Name,
+			Source:      source,
+		})
+	}
+
+	return categories
+}
+
+// LoadSourceScripts reads the manifest of a custom source and returns its scripts.
+func LoadSourceScripts(source config.ScriptSource) ([]Script, error) {
+	data, err := readManifest(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of %s: %w", source.Name, err)
+	}
+
+	return parseManifest(source, data)
+}
+
+// readManifest returns the raw manifest of a local or remote source.
+func readManifest(source config.ScriptSource) ([]byte, error) {
+	if source.Path != "" {
+		return os.ReadFile(filepath.Join(source.Path, ManifestFileName))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(source.URL, "/")+"/"+ManifestFileName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Add("User-Agent", "pvetui")
+
+	client := &http.Client{Timeout: sourceFetchTimeout}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// parseManifest converts a manifest into scripts located in the source.
+func parseManifest(source config.ScriptSource, data []byte) ([]Script, error) {
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", source.Name, err)
+	}
+
+	var sourceScripts []Script
+
+	for _, entry := range manifest.Scripts {
+		if entry.Name == "" || entry.Path == "" {
+			getScriptsLogger().Debug("Skipping manifest entry without name or path in %s", source.Name)
+
+			continue
+		}
+
+		if err := validateScriptPath(entry.Path); err != nil || strings.Contains(entry.Path, "..") || path.IsAbs(entry.Path) {
+			getScriptsLogger().Debug("Skipping manifest entry %s in %s: unsafe path %q", entry.Name, source.Name, entry.Path)
+
+			continue
+		}
+
+		location := strings.TrimSuffix(source.URL, "/") + "/" + entry.Path
+		if source.Path != "" {
+			location = filepath.Join(source.Path, filepath.FromSlash(entry.Path))
+		}
+
+		sourceScripts = append(sourceScripts, Script{
+			Name:          entry.Name,
+			Description:   entry.Description,
+			Type:          entry.Type,
+			Documentation: entry.Documentation,
+			ScriptPath:    entry.Path,
+			Source:        source.Name,
+			Location:      location,
+			Local:         source.Path != "",
+		})
+	}
+
+	if len(sourceScripts) == 0 {
+		return nil, fmt.Errorf("no scripts found in manifest of %s", source.Name)
+	}
+
+	return sourceScripts, nil
+}
+
+// InstallSourceScript installs a script from a custom source on a Proxmox node
+// interactively. Remote scripts are downloaded on the node like community
+// scripts; local scripts are copied to the node first.
+func InstallSourceScript(user, nodeIP string, script Script, opts InstallOptions) error {
+	installCmd, err := prepareRemoteCommand(context.Background(), user, nodeIP, script, opts.Env, os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	return runInstallCommand(user, nodeIP, installCmd, opts.Output)
+}
+
+// prepareRemoteCommand validates the script and environment and returns the
+// command that runs the script on the node. Scripts from local sources are
+// copied to the node first, with scp attached to the given streams.
+func prepareRemoteCommand(ctx context.Context, user, nodeIP string, script Script, env map[string]string, stdin io.Reader, stdout, stderr io.Writer) (string, error) {
+	if err := ValidateEnv(env); err != nil {
+		return "", err
+	}
+
+	if err := validateScriptPath(script.ScriptPath); err != nil {
+		return "", err
+	}
+
+	if script.Source == "" {
+		return buildInstallCommand(fmt.Sprintf("%s/%s", RawGitHubRepo, script.ScriptPath), env), nil
+	}
+
+	if !script.Local {
+		if err := validateScriptURL(script.Location); err != nil {
+			return "", err
+		}
+
+		getScriptsLogger().Debug("Installing %s script: %s on node %s", script.Source, script.Location, nodeIP)
+
+		return buildInstallCommand(script.Location, env), nil
+	}
+
+	remotePath, err := createRemoteTempFile(ctx, user, nodeIP, stdin, stderr)
+	if err != nil {
+		return "", err
+	}
+
+	getScriptsLogger().Debug("Copying %s script %s to %s:%s", script.Source, script.Location, nodeIP, remotePath)
+
+	scpArgs := append(ssh.NodeOptions().SCPArgs(), "-q", script.Location, fmt.Sprintf("%s@%s:%s", user, nodeIP, remotePath))
+	scpCmd := exec.CommandContext(ctx, "scp", scpArgs...)
+	scpCmd.Stdin = stdin
+	scpCmd.Stdout = stdout
+	scpCmd.Stderr = stderr
+
+	if err := scpCmd.Run(); err != nil {
+		removeRemoteFile(user, nodeIP, remotePath)
+
+		return "", fmt.Errorf("failed to copy script to node: %w", err)
+	}
+
+	return buildLocalInstallCommand(remotePath, env), nil
+}
+
+// remoteTempPattern matches the paths printed by mktemp on the node.
+var remoteTempPattern = regexp.MustCompile(`^/[A-Za-z0-9._/-]+$`)
+
+// createRemoteTempFile creates an empty file only the SSH user can access on
+// the node and returns its path. mktemp picks an unpredictable name, so other
+// users on the node cannot prepare or swap the file that is later run as root.
+func createRemoteTempFile(ctx context.Context, user, nodeIP string, stdin io.Reader, stderr io.Writer) (string, error) {
+	var out bytes.Buffer
+
+	sshArgs := append(ssh.NodeOptions().Args(), "-T", fmt.Sprintf("%s@%s", user, nodeIP), "mktemp -t pvetui-script.XXXXXXXXXX")
+	sshCmd := exec.CommandContext(ctx, "ssh", sshArgs...)
+	sshCmd.Stdin = stdin
+	sshCmd.Stdout = &out
+	sshCmd.Stderr = stderr
+
+	if err := sshCmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to create temporary file on node: %w", err)
+	}
+
+	remotePath := strings.TrimSpace(out.String())
+	if !remoteTempPattern.MatchString(remotePath) {
+		return "", fmt.Errorf("unexpected temporary file path on node: %q", remotePath)
+	}
+
+	return remotePath, nil
+}
+
+// removeRemoteFile deletes a file on the node, ignoring errors.
+func removeRemoteFile(user, nodeIP, remotePath string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	sshArgs := append(ssh.NodeOptions().Args(), "-T", "-o", "BatchMode=yes", fmt.Sprintf("%s@%s", user, nodeIP), "rm -f "+remotePath)
+	if err := exec.CommandContext(ctx, "ssh", sshArgs...).Run(); err != nil {
+		getScriptsLogger().Debug("Failed to remove %s on node %s: %v", remotePath, nodeIP, err)
+	}
+}
+
+// validateScriptURL rejects script URLs with characters that are unsafe in
+// the remote shell command.
+func validateScriptURL(scriptURL string) error {
+	scheme, rest, _ := strings.Cut(scriptURL, "://")
+	if scheme != "https" && scheme != "http" {
+		return fmt.Errorf("unsupported script URL scheme: %s", scheme)
+	}
+
+	// Allow a port in the host, everything else follows the script path rules
+	return validateScriptPath(strings.ReplaceAll(rest, ":", ""))
+}
+
+// buildLocalInstallCommand returns the remote command that runs a copied
+// script as root, removes it whether or not the script succeeded, and exits
+// with the script's status.
+func buildLocalInstallCommand(remotePath string, env map[string]string) string {
+	assignments := envAssignments(env)
+	if assignments != "" {
+		assignments += " "
+	}
+
+	return fmt.Sprintf("sudo su - root -c \"%sSHELL=/bin/bash /bin/bash %s\"; status=$?; rm -f %s; exit $status", assignments, remotePath, remotePath)
+}
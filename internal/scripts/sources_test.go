@@ -0,0 +1,107 @@
+package scripts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+const testManifest = `
+scripts:
+  - name: Docker Host
+    description: Debian container with Docker
+    path: ct/docker.sh
+    type: ct
+  - name: Escape
+    path: ../etc/passwd
+  - name: Injection
+    path: ct/$(id).sh
+  - name: Missing Path
+`
+
+func TestSourceCategories(t *testing.T) {
+	sources := []config.ScriptSource{
+		{Name: "Team", URL: "https://example.com/scripts"},
+		{Name: "Local", Path: "/opt/scripts"},
+	}
+
+	categories := SourceCategories(sources)
+	require.Len(t, categories, 2)
+	assert.Equal(t, "Team", categories[0].Name)
+	assert.Equal(t, "https://example.com/scripts", categories[0].Description)
+	assert.Equal(t, "/opt/scripts", categories[1].Description)
+	assert.Same(t, &sources[1], categories[1].Source)
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Run("remote source", func(t *testing.T) {
+		source := config.ScriptSource{Name: "Team", URL: "https://example.com/scripts/"}
+
+		sourceScripts, err := parseManifest(source, []byte(testManifest))
+		require.NoError(t, err)
+		require.Len(t, sourceScripts, 1)
+
+		script := sourceScripts[0]
+		assert.Equal(t, "Docker Host", script.Name)
+		assert.Equal(t, "ct", script.Type)
+		assert.Equal(t, "Team", script.Source)
+		assert.Equal(t, "https://example.com/scripts/ct/docker.sh", script.Location)
+		assert.False(t, script.Local)
+	})
+
+	t.Run("local source", func(t *testing.T) {
+		source := config.ScriptSource{Name: "Local", Path: "/opt/scripts"}
+
+		sourceScripts, err := parseManifest(source, []byte(testManifest))
+		require.NoError(t, err)
+		require.Len(t, sourceScripts, 1)
+		assert.Equal(t, filepath.Join("/opt/scripts", "ct", "docker.sh"), sourceScripts[0].Location)
+		assert.True(t, sourceScripts[0].Local)
+	})
+
+	t.Run("empty manifest", func(t *testing.T) {
+		_, err := parseManifest(config.ScriptSource{Name: "Empty", Path: "/tmp"}, []byte("scripts: []"))
+		assert.ErrorContains(t, err, "no scripts found")
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		_, err := parseManifest(config.ScriptSource{Name: "Broken", Path: "/tmp"}, []byte("scripts: ["))
+		assert.ErrorContains(t, err, "failed to parse manifest")
+	})
+}
+
+func TestLoadSourceScripts_LocalDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(testManifest), 0o600))
+
+	sourceScripts, err := LoadSourceScripts(config.ScriptSource{Name: "Local", Path: dir})
+	require.NoError(t, err)
+	assert.Len(t, sourceScripts, 1)
+
+	_, err = LoadSourceScripts(config.ScriptSource{Name: "Missing", Path: filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "failed to read manifest of Missing")
+}
+
+func TestValidateScriptURL(t *testing.T) {
+	assert.NoError(t, validateScriptURL("https://example.com:8443/scripts/ct/docker.sh"))
+	assert.Error(t, validateScriptURL("ftp://example.com/docker.sh"))
+	assert.Error(t, validateScriptURL("https://example.com/docker.sh?token=$(id)"))
+}
+
+func TestBuildLocalInstallCommand(t *testing.T) {
+	assert.Equal(t,
+		`sudo su - root -c "var_ctid=120 SHELL=/bin/bash /bin/bash /tmp/pvetui-script.aB3dE5gH7j"; status=$?; rm -f /tmp/pvetui-script.aB3dE5gH7j; exit $status`,
+		buildLocalInstallCommand("/tmp/pvetui-script.aB3dE5gH7j", map[string]string{"var_ctid": "120"}))
+}
+
+func TestRemoteTempPattern(t *testing.T) {
+	assert.True(t, remoteTempPattern.MatchString("/tmp/pvetui-script.aB3dE5gH7j"))
+	assert.False(t, remoteTempPattern.MatchString(""))
+	assert.False(t, remoteTempPattern.MatchString("tmp/relative"))
+	assert.False(t, remoteTempPattern.MatchString("/tmp/x; id"))
+}
@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// broadcastTimeout bounds a command broadcast to nodes.
+const broadcastTimeout = 5 * time.Minute
+
+// StreamNodeCommand runs a shell command on a node with a non-interactive
+// SSH login and calls onLine with each line of its combined output as it
+// arrives. It returns when the command exits, after five minutes or when ctx
+// is cancelled; a non-zero exit status is returned as an *exec.ExitError.
+func StreamNodeCommand(ctx context.Context, execer CommandExecutor, user, host, command string, opts Options, onLine func(line string)) error {
+	if user == "" {
+		return fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, broadcastTimeout)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		command)
+
+	reader, writer := io.Pipe()
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	// Don't wait for processes the command left holding the output
+	cmd.WaitDelay = 2 * time.Second
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			onLine(scanner.Text())
+		}
+
+		// Drain overlong lines so the command never blocks on its output
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	err := cmd.Wait()
+	_ = writer.Close()
+
+	<-done
+
+	return err
+}
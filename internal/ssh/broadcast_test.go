@@ -0,0 +1,51 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptExecutor runs a local shell script instead of ssh.
+type scriptExecutor struct {
+	script   string
+	lastArgs []string
+}
+
+func (s *scriptExecutor) CommandContext(ctx context.Context, _ string, args ...string) *exec.Cmd {
+	s.lastArgs = append([]string(nil), args...)
+
+	return exec.CommandContext(ctx, "sh", "-c", s.script)
+}
+
+func TestStreamNodeCommand(t *testing.T) {
+	se := &scriptExecutor{script: "echo one; echo two >&2; exit 3"}
+
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+
+	err := StreamNodeCommand(context.Background(), se, "root", "192.0.2.1", "pveversion -v", Options{}, func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lines = append(lines, line)
+	})
+
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.ExitCode())
+	assert.Equal(t, []string{"one", "two"}, lines)
+	assert.Equal(t, []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "root@192.0.2.1", "pveversion -v"}, se.lastArgs)
+}
+
+func TestStreamNodeCommand_RequiresHost(t *testing.T) {
+	err := StreamNodeCommand(context.Background(), &scriptExecutor{}, "root", "", "true", Options{}, func(string) {})
+	assert.Error(t, err)
+}
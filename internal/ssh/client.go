@@ -109,7 +109,9 @@ func ExecuteNodeShell(user, nodeIP string) error {
 //
 // Returns an error if the SSH connection fails.
 func ExecuteNodeShellWith(ctx context.Context, execer CommandExecutor, user, nodeIP string) error {
-	sshCmd := execer.CommandContext(ctx, "ssh", fmt.Sprintf("%s@%s", user, nodeIP))
+	sshArgs := append(NodeOptions().Args(), fmt.Sprintf("%s@%s", user, nodeIP))
+
+	sshCmd := execer.CommandContext(ctx, "ssh", sshArgs...)
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
 	sshCmd.Stderr = os.Stderr
@@ -226,6 +228,8 @@ func ExecuteLXCShellWith(ctx context.Context, execer CommandExecutor, user, node
 		sessionType = "LXC"
 	}
 
+	sshArgs = append(NodeOptions().Args(), sshArgs...)
+
 	sshCmd := execer.CommandContext(ctx, "ssh", sshArgs...)
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
@@ -259,10 +263,11 @@ func ExecuteLXCShellWith(ctx context.Context, execer CommandExecutor, user, node
 // Parameters:
 //   - user: SSH username for authentication to the VM
 //   - vmIP: IP address of the target VM
+//   - opts: connection settings for the VM, e.g. its identity file
 //
 // Returns an error if the VM IP is empty or if the SSH connection fails.
-func ExecuteQemuShell(user, vmIP string) error {
-	return ExecuteQemuShellWith(context.Background(), NewDefaultExecutor(), user, vmIP)
+func ExecuteQemuShell(user, vmIP string, opts Options) error {
+	return ExecuteQemuShellWith(context.Background(), NewDefaultExecutor(), user, vmIP, opts)
 }
 
 // ExecuteQemuShellWith attempts to connect to a QEMU VM using SSH with custom execution context.
@@ -278,14 +283,17 @@ func ExecuteQemuShell(user, vmIP string) error {
 //   - execer: Command executor interface for running SSH commands
 //   - user: SSH username for authentication to the VM
 //   - vmIP: IP address of the target VM
+//   - opts: connection settings for the VM, e.g. its identity file
 //
 // Returns an error if the VM IP is empty or if the SSH connection fails.
-func ExecuteQemuShellWith(ctx context.Context, execer CommandExecutor, user, vmIP string) error {
+func ExecuteQemuShellWith(ctx context.Context, execer CommandExecutor, user, vmIP string, opts Options) error {
 	if vmIP == "" {
 		return fmt.Errorf("no IP address available for VM")
 	}
 
-	sshCmd := execer.CommandContext(ctx, "ssh", fmt.Sprintf("%s@%s", user, vmIP))
+	args := append(opts.Args(), fmt.Sprintf("%s@%s", user, vmIP))
+
+	sshCmd := execer.CommandContext(ctx, "ssh", args...)
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
 	sshCmd.Stderr = os.Stderr
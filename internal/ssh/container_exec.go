@@ -0,0 +1,91 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// containerCommandTimeout bounds running a configured command inside a
+// container.
+const containerCommandTimeout = 60 * time.Second
+
+// RunInContainer runs a shell command inside a running container with
+// `pct exec` over a non-interactive SSH login to its node and returns its
+// combined output, also when it fails. Users other than root need
+// passwordless sudo for pct.
+func RunInContainer(ctx context.Context, execer CommandExecutor, user, host string, vmID int, command string, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, containerCommandTimeout)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		containerExec(user, vmID, command))
+
+	var output bytes.Buffer
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+
+	return output.Bytes(), err
+}
+
+// containerExec returns the command that runs script inside a container,
+// with sudo for users other than root.
+func containerExec(user string, vmID int, script string) string {
+	pct := "pct"
+	if user != "root" {
+		pct = "sudo -n pct"
+	}
+
+	return fmt.Sprintf("%s exec %d -- sh -c %s", pct, vmID, shellQuote(script))
+}
+
+// shellQuote quotes s as a single argument for the shell of the node.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runNodeCommand runs a shell command on a node with a non-interactive SSH
+// login and returns its output.
+func runNodeCommand(ctx context.Context, execer CommandExecutor, user, host, command string, timeout time.Duration, opts Options) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		command)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,17 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInContainer(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := RunInContainer(context.Background(), me, "root", "192.0.2.1", 105, "systemctl restart nginx && echo 'done'", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, `pct exec 105 -- sh -c 'systemctl restart nginx && echo '\''done'\'''`, me.lastArgs[len(me.lastArgs)-1])
+}
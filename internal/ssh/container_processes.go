@@ -0,0 +1,29 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// containerProcessesTimeout bounds sampling the processes of a container,
+// which takes a second itself.
+const containerProcessesTimeout = 20 * time.Second
+
+// ReadContainerProcesses samples the processes inside a running container
+// with `pct exec` over a non-interactive SSH login to its node. Users other
+// than root need passwordless sudo for pct. The output is parsed with
+// api.ParseGuestProcesses.
+func ReadContainerProcesses(ctx context.Context, execer CommandExecutor, user, host string, vmID int, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	return runNodeCommand(ctx, execer, user, host, containerExec(user, vmID, api.GuestProcessScript), containerProcessesTimeout, opts)
+}
@@ -0,0 +1,24 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestReadContainerProcesses(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := ReadContainerProcesses(context.Background(), me, "root", "", 105, Options{})
+	assert.ErrorContains(t, err, "host is required")
+	assert.Zero(t, me.called)
+
+	_, err = ReadContainerProcesses(context.Background(), me, "admin", "192.0.2.1", 105, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "admin@192.0.2.1", me.lastArgs[len(me.lastArgs)-2])
+	assert.Equal(t, "sudo -n pct exec 105 -- sh -c '"+api.GuestProcessScript+"'", me.lastArgs[len(me.lastArgs)-1])
+}
@@ -0,0 +1,45 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// containerStatsTimeout bounds reading the stats of a container.
+const containerStatsTimeout = 15 * time.Second
+
+// containerStatsCommand returns the shell command that prints the stats of
+// a container in sections: the task count and pressure stall information of
+// its cgroup (cgroup v2) read on the node, and the load average and process
+// count seen inside the container with `pct exec`. Missing sections are
+// left empty.
+func containerStatsCommand(user string, vmID int) string {
+	cgroup := fmt.Sprintf("/sys/fs/cgroup/lxc/%d", vmID)
+
+	return strings.Join([]string{
+		"echo ==tasks",
+		fmt.Sprintf("cat %s/pids.current 2>/dev/null", cgroup),
+		fmt.Sprintf("for r in cpu memory io; do echo ==$r; cat %s/$r.pressure 2>/dev/null; done", cgroup),
+		"echo ==load",
+		containerExec(user, vmID, "cat /proc/loadavg; echo ==processes; ls -d /proc/[0-9]* | wc -l") + " 2>/dev/null",
+		"true",
+	}, "; ")
+}
+
+// ReadContainerStats reads the stats of a running container on its node
+// with a non-interactive SSH login. Users other than root run `pct exec`
+// with sudo, which must not ask for a password; without it only the cgroup
+// stats are read. The output is parsed with api.ParseContainerStats.
+func ReadContainerStats(ctx context.Context, execer CommandExecutor, user, host string, vmID int, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	return runNodeCommand(ctx, execer, user, host, containerStatsCommand(user, vmID), containerStatsTimeout, opts)
+}
@@ -0,0 +1,29 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadContainerStats(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := ReadContainerStats(context.Background(), me, "", "192.0.2.1", 105, Options{})
+	assert.ErrorContains(t, err, "SSH username is required")
+	assert.Zero(t, me.called)
+
+	_, err = ReadContainerStats(context.Background(), me, "root", "192.0.2.1", 105, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "root@192.0.2.1", me.lastArgs[len(me.lastArgs)-2])
+
+	command := me.lastArgs[len(me.lastArgs)-1]
+	assert.Contains(t, command, "cat /sys/fs/cgroup/lxc/105/pids.current")
+	assert.Contains(t, command, "; pct exec 105 -- ")
+
+	_, err = ReadContainerStats(context.Background(), me, "admin", "192.0.2.1", 105, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, me.lastArgs[len(me.lastArgs)-1], "sudo -n pct exec 105 -- ")
+}
@@ -0,0 +1,25 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// corosyncTimeout bounds reading the corosync link status of a node.
+const corosyncTimeout = 10 * time.Second
+
+// ReadCorosyncLinks reads the state of the corosync links from a node to
+// all other nodes with a non-interactive SSH login. The output is parsed
+// with api.ParseCorosyncLinks.
+func ReadCorosyncLinks(ctx context.Context, execer CommandExecutor, user, host string, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	return runNodeCommand(ctx, execer, user, host, "corosync-cfgtool -n", corosyncTimeout, opts)
+}
@@ -0,0 +1,20 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCorosyncLinks(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := ReadCorosyncLinks(context.Background(), me, "root", "", Options{})
+	assert.ErrorContains(t, err, "host is required")
+
+	_, err = ReadCorosyncLinks(context.Background(), me, "root", "192.0.2.1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root@192.0.2.1", "corosync-cfgtool -n"}, me.lastArgs[len(me.lastArgs)-2:])
+}
@@ -0,0 +1,24 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// neighborsTimeout bounds reading the neighbor table of a node.
+const neighborsTimeout = 10 * time.Second
+
+// ReadNeighbors reads the ARP and NDP neighbor table of a node with a
+// non-interactive SSH login. The output is parsed with api.ParseNeighbors.
+func ReadNeighbors(ctx context.Context, execer CommandExecutor, user, host string, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	return runNodeCommand(ctx, execer, user, host, "ip neigh show", neighborsTimeout, opts)
+}
@@ -0,0 +1,21 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNeighbors(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := ReadNeighbors(context.Background(), me, "", "192.0.2.1", Options{})
+	assert.ErrorContains(t, err, "SSH username is required")
+
+	_, err = ReadNeighbors(context.Background(), me, "root", "192.0.2.1", Options{Port: 2222})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root@192.0.2.1", "ip neigh show"}, me.lastArgs[len(me.lastArgs)-2:])
+	assert.Contains(t, me.lastArgs, "2222")
+}
@@ -0,0 +1,172 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options holds the connection settings used for SSH sessions to Proxmox nodes.
+//
+// Zero values leave the decision to the user's SSH configuration.
+type Options struct {
+	Port         int
+	IdentityFile string
+	// ControlDir holds the sockets of shared connections. When set, the
+	// first connection to a node becomes a master that later sessions reuse
+	// without authenticating again (OpenSSH ControlMaster).
+	ControlDir string
+}
+
+// controlPersist is how long an idle shared connection stays open.
+const controlPersist = 10 * time.Minute
+
+// maxControlDirLen keeps control socket paths, the directory and a 40
+// character hash, within the 104 byte limit of Unix socket paths on macOS.
+const maxControlDirLen = 60
+
+var (
+	nodeOptions   Options
+	nodeOptionsMu sync.RWMutex
+)
+
+// SetNodeOptions sets the options applied to every connection to a node.
+// Connections are not shared on Windows, whose OpenSSH lacks ControlMaster,
+// or when the control directory can't be used.
+func SetNodeOptions(opts Options) {
+	if opts.ControlDir != "" && !usableControlDir(opts.ControlDir) {
+		opts.ControlDir = ""
+	}
+
+	nodeOptionsMu.Lock()
+	defer nodeOptionsMu.Unlock()
+
+	nodeOptions = opts
+}
+
+// NodeOptions returns the options applied to connections to nodes.
+func NodeOptions() Options {
+	nodeOptionsMu.RLock()
+	defer nodeOptionsMu.RUnlock()
+
+	return nodeOptions
+}
+
+// usableControlDir creates the control socket directory, readable only by
+// the user, and reports whether it can hold control sockets.
+func usableControlDir(dir string) bool {
+	if runtime.GOOS == "windows" || len(dir) > maxControlDirLen {
+		return false
+	}
+
+	return os.MkdirAll(dir, 0o700) == nil
+}
+
+// Args returns the ssh command line flags for the options.
+func (o Options) Args() []string {
+	var args []string
+
+	if o.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(o.Port))
+	}
+
+	if o.IdentityFile != "" {
+		args = append(args, "-i", ExpandHome(o.IdentityFile))
+	}
+
+	return append(args, o.controlArgs()...)
+}
+
+// SCPArgs returns the scp command line flags for the options.
+func (o Options) SCPArgs() []string {
+	var args []string
+
+	if o.Port > 0 {
+		args = append(args, "-P", strconv.Itoa(o.Port))
+	}
+
+	if o.IdentityFile != "" {
+		args = append(args, "-i", ExpandHome(o.IdentityFile))
+	}
+
+	return append(args, o.controlArgs()...)
+}
+
+// controlArgs returns the flags that share connections, keyed by user, host
+// and port (%C), between ssh and scp sessions.
+func (o Options) controlArgs() []string {
+	if o.ControlDir == "" {
+		return nil
+	}
+
+	return []string{
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + filepath.Join(o.ControlDir, "%C"),
+		"-o", fmt.Sprintf("ControlPersist=%d", int(controlPersist.Seconds())),
+	}
+}
+
+// ExpandHome replaces a leading "~" in path with the user's home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// TestConnection checks that a non-interactive SSH login to host succeeds
+// with the given options. The error includes the output of ssh, which
+// usually explains why authentication failed.
+func TestConnection(ctx context.Context, execer CommandExecutor, user, host string, opts Options) error {
+	if user == "" {
+		return fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	if opts.IdentityFile != "" {
+		if _, err := os.Stat(ExpandHome(opts.IdentityFile)); err != nil {
+			return fmt.Errorf("identity file: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes", // Fail instead of prompting for passwords
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		"true")
+
+	var output bytes.Buffer
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package ssh
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Args(t *testing.T) {
+	assert.Empty(t, Options{}.Args())
+	assert.Empty(t, Options{}.SCPArgs())
+
+	opts := Options{Port: 2222, IdentityFile: "/keys/id_ed25519"}
+	assert.Equal(t, []string{"-p", "2222", "-i", "/keys/id_ed25519"}, opts.Args())
+	assert.Equal(t, []string{"-P", "2222", "-i", "/keys/id_ed25519"}, opts.SCPArgs())
+}
+
+func TestOptions_ControlArgs(t *testing.T) {
+	opts := Options{Port: 2222, ControlDir: "/run/pvetui/ssh"}
+	control := []string{"-o", "ControlMaster=auto", "-o", "ControlPath=/run/pvetui/ssh/%C", "-o", "ControlPersist=600"}
+
+	assert.Equal(t, append([]string{"-p", "2222"}, control...), opts.Args())
+	assert.Equal(t, append([]string{"-P", "2222"}, control...), opts.SCPArgs())
+}
+
+func TestSetNodeOptions_ControlDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("connections are not shared on Windows")
+	}
+
+	t.Cleanup(func() { SetNodeOptions(Options{}) })
+
+	dir := filepath.Join(t.TempDir(), "ssh")
+	if len(dir) > maxControlDirLen {
+		t.Skip("temporary directory path is too long for control sockets")
+	}
+
+	SetNodeOptions(Options{ControlDir: dir})
+	assert.Equal(t, dir, NodeOptions().ControlDir)
+	assert.DirExists(t, dir)
+
+	// Control sockets in long paths exceed the socket path limit
+	SetNodeOptions(Options{ControlDir: "/" + strings.Repeat("d", maxControlDirLen)})
+	assert.Empty(t, NodeOptions().ControlDir)
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(home, ".ssh", "id_rsa"), ExpandHome("~/.ssh/id_rsa"))
+	assert.Equal(t, "/etc/ssh/key", ExpandHome("/etc/ssh/key"))
+	assert.Equal(t, "~other/key", ExpandHome("~other/key"))
+}
+
+func TestNodeOptions(t *testing.T) {
+	t.Cleanup(func() { SetNodeOptions(Options{}) })
+
+	SetNodeOptions(Options{Port: 2200})
+	assert.Equal(t, Options{Port: 2200}, NodeOptions())
+
+	me := &mockExecutor{}
+	_ = ExecuteNodeShellWith(context.Background(), me, "testuser", "192.0.2.1")
+	require.Equal(t, []string{"-p", "2200", "testuser@192.0.2.1"}, me.lastArgs)
+}
+
+func TestTestConnection(t *testing.T) {
+	me := &mockExecutor{}
+
+	err := TestConnection(context.Background(), me, "", "192.0.2.1", Options{})
+	assert.ErrorContains(t, err, "SSH username is required")
+
+	err = TestConnection(context.Background(), me, "root", "192.0.2.1", Options{IdentityFile: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "identity file")
+
+	_ = TestConnection(context.Background(), me, "root", "192.0.2.1", Options{Port: 2222})
+	assert.Equal(t, []string{"-p", "2222", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "root@192.0.2.1", "true"}, me.lastArgs)
+}
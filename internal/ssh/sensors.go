@@ -0,0 +1,49 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sensorsTimeout bounds reading the sensors of a node.
+const sensorsTimeout = 15 * time.Second
+
+// ReadSensors runs `sensors -j` (lm-sensors) on a node with a
+// non-interactive SSH login and returns its JSON output.
+func ReadSensors(ctx context.Context, execer CommandExecutor, user, host string, opts Options) ([]byte, error) {
+	if user == "" {
+		return nil, fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sensorsTimeout)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		"sensors", "-j")
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
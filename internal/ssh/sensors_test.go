@@ -0,0 +1,25 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSensors(t *testing.T) {
+	me := &mockExecutor{}
+
+	_, err := ReadSensors(context.Background(), me, "", "192.0.2.1", Options{})
+	assert.ErrorContains(t, err, "SSH username is required")
+
+	_, err = ReadSensors(context.Background(), me, "root", "", Options{})
+	assert.ErrorContains(t, err, "host is required")
+	assert.Zero(t, me.called)
+
+	_, err = ReadSensors(context.Background(), me, "root", "192.0.2.1", Options{Port: 2222})
+	require.NoError(t, err)
+	assert.Equal(t, "ssh", me.lastName)
+	assert.Equal(t, []string{"-p", "2222", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "root@192.0.2.1", "sensors", "-j"}, me.lastArgs)
+}
@@ -0,0 +1,59 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// unlockTimeout bounds unlocking a guest.
+const unlockTimeout = 30 * time.Second
+
+// UnlockGuest runs `qm unlock` or `pct unlock` for a guest on its node with a
+// non-interactive SSH login. Users other than root run it with sudo, which
+// must not ask for a password.
+func UnlockGuest(ctx context.Context, execer CommandExecutor, user, host, vmType string, vmID int, opts Options) error {
+	if user == "" {
+		return fmt.Errorf("SSH username is required")
+	}
+
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	command := fmt.Sprintf("qm unlock %d", vmID)
+	if vmType == "lxc" {
+		command = fmt.Sprintf("pct unlock %d", vmID)
+	}
+
+	if user != "root" {
+		command = "sudo -n " + command
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, unlockTimeout)
+	defer cancel()
+
+	args := append(opts.Args(),
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		fmt.Sprintf("%s@%s", user, host),
+		command)
+
+	var output bytes.Buffer
+
+	cmd := execer.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlockGuest(t *testing.T) {
+	me := &mockExecutor{}
+
+	err := UnlockGuest(context.Background(), me, "", "192.0.2.1", "qemu", 100, Options{})
+	assert.ErrorContains(t, err, "SSH username is required")
+	assert.Zero(t, me.called)
+
+	require.NoError(t, UnlockGuest(context.Background(), me, "root", "192.0.2.1", "qemu", 100, Options{}))
+	assert.Equal(t, []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "root@192.0.2.1", "qm unlock 100"}, me.lastArgs)
+
+	require.NoError(t, UnlockGuest(context.Background(), me, "admin", "192.0.2.1", "lxc", 105, Options{}))
+	assert.Equal(t, "sudo -n pct unlock 105", me.lastArgs[len(me.lastArgs)-1])
+}
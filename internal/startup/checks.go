@@ -0,0 +1,108 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckStatus is the state of a startup check.
+type CheckStatus int
+
+const (
+	// CheckPending is a check that has not started yet.
+	CheckPending CheckStatus = iota
+	// CheckRunning is the check in progress.
+	CheckRunning
+	// CheckPassed is a check that succeeded.
+	CheckPassed
+	// CheckPartial is a check that succeeded for only part of what it
+	// covers, e.g. some of the nodes.
+	CheckPartial
+	// CheckFailed is a check that failed.
+	CheckFailed
+	// CheckSkipped is a check that didn't apply, or was not run because a
+	// required check before it failed or the verification was aborted.
+	CheckSkipped
+)
+
+// CheckResult is the state of a check and what it found.
+type CheckResult struct {
+	Status CheckStatus
+	// Detail describes the progress or outcome, e.g. "3/4 nodes".
+	Detail string
+	// Err is why the check failed.
+	Err error
+}
+
+// Check is a step of the startup verification.
+type Check struct {
+	Name string
+	// Required checks must pass for the interface to start. Without the
+	// others the interface starts with partial functionality.
+	Required bool
+	// Run performs the check, passing progress details, e.g. the number of
+	// nodes checked so far, to progress.
+	Run func(ctx context.Context, progress func(detail string)) CheckResult
+}
+
+// Verification is the outcome of the startup checks.
+type Verification struct {
+	Results []CheckResult
+	// Err is the error of the required check that failed, if any.
+	Err error
+}
+
+// Complete reports whether every check passed or didn't apply.
+func (v Verification) Complete() bool {
+	if v.Err != nil {
+		return false
+	}
+
+	for _, result := range v.Results {
+		if result.Status != CheckPassed && result.Status != CheckSkipped {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RunChecks runs the checks in order, passing each change of their state to
+// update. The checks after a failed required check, or after ctx is
+// canceled, are skipped.
+func RunChecks(ctx context.Context, checks []Check, update func(index int, result CheckResult)) Verification {
+	verification := Verification{Results: make([]CheckResult, len(checks))}
+
+	set := func(index int, result CheckResult) {
+		verification.Results[index] = result
+
+		if update != nil {
+			update(index, result)
+		}
+	}
+
+	for i, check := range checks {
+		if verification.Err != nil || ctx.Err() != nil {
+			set(i, CheckResult{Status: CheckSkipped, Detail: "not run"})
+
+			continue
+		}
+
+		set(i, CheckResult{Status: CheckRunning})
+
+		result := check.Run(ctx, func(detail string) {
+			set(i, CheckResult{Status: CheckRunning, Detail: detail})
+		})
+
+		set(i, result)
+
+		if check.Required && result.Status == CheckFailed {
+			verification.Err = result.Err
+			if verification.Err == nil {
+				verification.Err = fmt.Errorf("%s failed", check.Name)
+			}
+		}
+	}
+
+	return verification
+}
@@ -0,0 +1,95 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passing(detail string) func(context.Context, func(string)) CheckResult {
+	return func(context.Context, func(string)) CheckResult {
+		return CheckResult{Status: CheckPassed, Detail: detail}
+	}
+}
+
+func TestRunChecks(t *testing.T) {
+	var updates []CheckResult
+
+	checks := []Check{
+		{Name: "Authentication", Required: true, Run: passing("Proxmox VE 8.2")},
+		{Name: "Nodes reachable", Run: func(_ context.Context, progress func(string)) CheckResult {
+			progress("1/2")
+
+			return CheckResult{Status: CheckPartial, Detail: "1/2 (pve2 unreachable)"}
+		}},
+		{Name: "SSH", Run: func(context.Context, func(string)) CheckResult {
+			return CheckResult{Status: CheckSkipped, Detail: "no ssh_user set"}
+		}},
+	}
+
+	verification := RunChecks(context.Background(), checks, func(_ int, result CheckResult) {
+		updates = append(updates, result)
+	})
+
+	require.NoError(t, verification.Err)
+	assert.False(t, verification.Complete())
+	assert.Equal(t, CheckPartial, verification.Results[1].Status)
+	assert.Equal(t, []CheckStatus{CheckRunning, CheckPassed, CheckRunning, CheckRunning, CheckPartial, CheckRunning, CheckSkipped},
+		statuses(updates))
+	assert.Equal(t, "1/2", updates[3].Detail)
+
+	checks[1].Run = passing("2/2")
+	assert.True(t, RunChecks(context.Background(), checks, nil).Complete())
+}
+
+func TestRunChecks_RequiredFailure(t *testing.T) {
+	authErr := errors.New("authentication failed")
+	ran := false
+
+	verification := RunChecks(context.Background(), []Check{
+		{Name: "Cluster status", Run: func(context.Context, func(string)) CheckResult {
+			return CheckResult{Status: CheckFailed, Err: errors.New("permission denied")}
+		}},
+		{Name: "Authentication", Required: true, Run: func(context.Context, func(string)) CheckResult {
+			return CheckResult{Status: CheckFailed, Err: authErr}
+		}},
+		{Name: "Nodes reachable", Run: func(context.Context, func(string)) CheckResult {
+			ran = true
+
+			return CheckResult{Status: CheckPassed}
+		}},
+	}, nil)
+
+	assert.ErrorIs(t, verification.Err, authErr)
+	assert.False(t, ran)
+	assert.Equal(t, CheckSkipped, verification.Results[2].Status)
+	assert.False(t, verification.Complete())
+}
+
+func TestRunChecks_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	verification := RunChecks(ctx, []Check{
+		{Name: "Authentication", Required: true, Run: func(context.Context, func(string)) CheckResult {
+			cancel()
+
+			return CheckResult{Status: CheckPassed}
+		}},
+		{Name: "Cluster status", Run: passing("")},
+	}, nil)
+
+	require.NoError(t, verification.Err)
+	assert.Equal(t, CheckSkipped, verification.Results[1].Status)
+}
+
+func statuses(results []CheckResult) []CheckStatus {
+	list := make([]CheckStatus, len(results))
+	for i, result := range results {
+		list[i] = result.Status
+	}
+
+	return list
+}
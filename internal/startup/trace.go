@@ -0,0 +1,107 @@
+// Package startup runs the checks verifying the connection before the
+// interface starts and records how long the phases of application startup
+// take, for the --startup-trace mode.
+package startup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phase is a finished startup phase.
+type Phase struct {
+	Name     string
+	Offset   time.Duration // Start of the phase relative to the start of the trace
+	Duration time.Duration
+}
+
+// Trace collects startup phases. It is safe for concurrent use since
+// background phases finish on their own goroutines.
+type Trace struct {
+	mu     sync.Mutex
+	start  time.Time
+	phases []Phase
+}
+
+// NewTrace starts a trace at start.
+func NewTrace(start time.Time) *Trace {
+	return &Trace{start: start}
+}
+
+// Record adds a phase that began at start and ends now.
+func (t *Trace) Record(name string, start time.Time) {
+	t.add(name, start, time.Now())
+}
+
+// add adds a phase from start to end.
+func (t *Trace) add(name string, start, end time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phases = append(t.phases, Phase{
+		Name:     name,
+		Offset:   start.Sub(t.start),
+		Duration: end.Sub(start),
+	})
+}
+
+// Phases returns the recorded phases ordered by start.
+func (t *Trace) Phases() []Phase {
+	t.mu.Lock()
+	phases := make([]Phase, len(t.phases))
+	copy(phases, t.phases)
+	t.mu.Unlock()
+
+	sort.SliceStable(phases, func(i, j int) bool {
+		return phases[i].Offset < phases[j].Offset
+	})
+
+	return phases
+}
+
+// Total returns the time from the start of the trace to the end of the
+// latest phase.
+func (t *Trace) Total() time.Duration {
+	var total time.Duration
+
+	for _, phase := range t.Phases() {
+		total = max(total, phase.Offset+phase.Duration)
+	}
+
+	return total
+}
+
+// Format renders the phases as an aligned plain-text table for logs and
+// bug reports.
+func (t *Trace) Format() string {
+	phases := t.Phases()
+
+	width := len("total")
+	for _, phase := range phases {
+		width = max(width, len(phase.Name))
+	}
+
+	var b strings.Builder
+
+	b.WriteString("Startup trace:\n")
+
+	for _, phase := range phases {
+		fmt.Fprintf(&b, "  %-*s  +%-9s %s\n", width, phase.Name, FormatDuration(phase.Offset), FormatDuration(phase.Duration))
+	}
+
+	fmt.Fprintf(&b, "  %-*s  %s\n", width, "total", FormatDuration(t.Total()))
+
+	return b.String()
+}
+
+// FormatDuration formats a duration in milliseconds, or seconds from 10s up.
+func FormatDuration(d time.Duration) string {
+	if d >= 10*time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,42 @@
+package startup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	start := time.Now()
+	trace := NewTrace(start)
+
+	trace.add("guest enrichment", start.Add(300*time.Millisecond), start.Add(12*time.Second))
+	trace.add("connect", start.Add(10*time.Millisecond), start.Add(110*time.Millisecond))
+	trace.add("cluster status", start.Add(120*time.Millisecond), start.Add(170*time.Millisecond))
+
+	phases := trace.Phases()
+	require.Len(t, phases, 3)
+	assert.Equal(t, "connect", phases[0].Name)
+	assert.Equal(t, 100*time.Millisecond, phases[0].Duration)
+	assert.Equal(t, 120*time.Millisecond, phases[1].Offset)
+	assert.Equal(t, "guest enrichment", phases[2].Name)
+
+	assert.Equal(t, 12*time.Second, trace.Total())
+
+	assert.Equal(t, "Startup trace:\n"+
+		"  connect           +10ms      100ms\n"+
+		"  cluster status    +120ms     50ms\n"+
+		"  guest enrichment  +300ms     11.7s\n"+
+		"  total             12.0s\n", trace.Format())
+}
+
+func TestTrace_Record(t *testing.T) {
+	trace := NewTrace(time.Now())
+	trace.Record("first draw", time.Now().Add(-time.Second))
+
+	phases := trace.Phases()
+	require.Len(t, phases, 1)
+	assert.GreaterOrEqual(t, phases[0].Duration, time.Second)
+}
@@ -2,15 +2,31 @@ package ui
 
 import (
 	"context"
+	"time"
 
 	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/startup"
 	"github.com/devnullvoid/pvetui/internal/ui/components"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
-// RunApp creates and starts the application using the component-based architecture.
-func RunApp(ctx context.Context, client *api.Client, cfg *config.Config, configPath string) error {
+// RunApp creates and starts the application using the component-based
+// architecture. A non-nil trace records the UI startup phases and link opens
+// a node or guest once loaded, running its action.
+func RunApp(ctx context.Context, client *api.Client, cfg *config.Config, configPath string, trace *startup.Trace, link components.DeepLink) error {
+	start := time.Now()
 	app := components.NewApp(ctx, client, cfg, configPath)
+	crash.SetRestore(app.Stop)
+
+	if trace != nil {
+		trace.Record("initial load and UI setup", start)
+		app.SetStartupTrace(trace)
+	}
+
+	if !link.IsZero() {
+		app.OpenOnLoad(link)
+	}
 
 	return app.Run()
 }
@@ -0,0 +1,248 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// alertIcons are the list markers of the alert kinds.
+var alertIcons = map[models.AlertKind]string{
+	models.AlertNodeOffline:      theme.Icon("🔴", "[!]"),
+	models.AlertStorageFull:      theme.Icon("💾", "[S]"),
+	models.AlertGuestStopped:     theme.Icon("⏹", "[X]"),
+	models.AlertGuestRestarted:   theme.Icon("🔄", "[R]"),
+	models.AlertBackupOverdue:    theme.Icon("🗄️", "[B]"),
+	models.AlertAffinityViolated: theme.Icon("🧲", "[A]"),
+	models.AlertStaleSnapshot:    theme.Icon("📸", "[P]"),
+	models.AlertClockSkew:        theme.Icon("🕒", "[T]"),
+}
+
+// currentAlerts returns the alerts for the loaded cluster data, including
+// guests whose backups are overdue or with stale snapshots, broken affinity
+// rules, and nodes with skewed clocks.
+func (a *App) currentAlerts() []models.Alert {
+	vms := models.GlobalState.OriginalVMs
+	alerts := models.CollectAlerts(models.GlobalState.OriginalNodes, vms)
+	alerts = append(alerts, models.CollectClockAlerts(a.config.Clock.MaxSkew())...)
+	alerts = append(alerts, models.CollectAffinityAlerts(models.AffinityGroups(a.config.AffinityRules, vms))...)
+
+	alerts = append(alerts, models.CollectBackupAlerts(vms, a.config.Backups.MaxAge(), time.Now())...)
+
+	return append(alerts, models.CollectSnapshotAlerts(vms, a.staleSnapshotRules(), time.Now())...)
+}
+
+// alertsMenuLabel returns the global menu label of the alerts list.
+func (a *App) alertsMenuLabel() string {
+	if count := len(a.currentAlerts()); count > 0 {
+		return fmt.Sprintf("Alerts (%d)", count)
+	}
+
+	return "Alerts"
+}
+
+// showAlerts lists the current alerts. Selecting one jumps to the node or
+// storage it is about, or offers actions for the guest.
+func (a *App) showAlerts() {
+	alerts := a.currentAlerts()
+	if len(alerts) == 0 {
+		a.header.ShowSuccess("No alerts")
+
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Alerts (%d) ", len(alerts))).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	list.SetMainTextColor(theme.Colors.Primary)
+	list.SetSecondaryTextColor(theme.Colors.Secondary)
+
+	for _, alert := range alerts {
+		secondary := alertHint(alert)
+		if !alert.Time.IsZero() {
+			secondary = fmt.Sprintf("%s, detected %s", secondary, alert.Time.Format("15:04:05"))
+		}
+
+		list.AddItem(fmt.Sprintf("%s %s", alertIcons[alert.Kind], alert.Message), "  "+secondary, 0, nil)
+	}
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		a.closeAlerts()
+		a.openAlert(alerts[index])
+	})
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			a.closeAlerts()
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := len(alerts)*2 + 2
+	if height > 22 {
+		height = 22
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, height, 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("alerts")
+	a.pages.AddPage("alerts", modal, true, true)
+	a.SetFocus(list)
+}
+
+// closeAlerts closes the alerts list and restores focus.
+func (a *App) closeAlerts() {
+	a.removePageIfPresent("alerts")
+
+	if a.lastFocus != nil {
+		a.SetFocus(a.lastFocus)
+	}
+}
+
+// alertHint describes what selecting an alert does.
+func alertHint(alert models.Alert) string {
+	switch alert.Kind {
+	case models.AlertStorageFull:
+		return "Enter: show storage on node"
+	case models.AlertGuestStopped:
+		return "Enter: start or go to guest"
+	case models.AlertGuestRestarted:
+		return "Enter: console, shell or go to guest"
+	case models.AlertBackupOverdue:
+		return "Enter: go to guest"
+	case models.AlertAffinityViolated:
+		return "Enter: migrate guest"
+	case models.AlertStaleSnapshot:
+		return "Enter: list stale snapshots"
+	default:
+		return "Enter: go to node"
+	}
+}
+
+// openAlert jumps to the subject of an alert.
+func (a *App) openAlert(alert models.Alert) {
+	switch alert.Kind {
+	case models.AlertStorageFull:
+		a.selectNodeByName(alert.Node)
+
+		if nodeDetails, ok := a.nodeDetails.(*NodeDetails); ok && nodeDetails.SelectStorage(alert.Storage) {
+			a.SetFocus(nodeDetails)
+		}
+	case models.AlertGuestStopped, models.AlertGuestRestarted:
+		a.showAlertGuestMenu(alert.VM)
+	case models.AlertBackupOverdue:
+		a.selectGuest(alert.VM)
+	case models.AlertAffinityViolated:
+		a.selectGuest(alert.VM)
+		a.showMigrationDialog(alert.VM)
+	case models.AlertStaleSnapshot:
+		a.selectGuest(alert.VM)
+		a.showStaleSnapshots()
+	default:
+		a.selectNodeByName(alert.Node)
+	}
+}
+
+// showAlertGuestMenu offers quick actions for a guest from an alert: start
+// when it is stopped, console and shell access when it is running.
+func (a *App) showAlertGuestMenu(vm *api.VM) {
+	var (
+		menuItems []string
+		shortcuts []rune
+	)
+
+	if vm.Status == api.VMStatusStopped {
+		menuItems = append(menuItems, "Start")
+		shortcuts = append(shortcuts, 's')
+	} else {
+		menuItems = append(menuItems, "Open VNC Console", "Open Shell")
+		shortcuts = append(shortcuts, 'v', 'h')
+	}
+
+	menuItems = append(menuItems, "Go to Guest")
+	shortcuts = append(shortcuts, 'g')
+
+	a.lastFocus = a.GetFocus()
+
+	menu := NewContextMenuWithShortcuts(fmt.Sprintf(" %d - %s ", vm.ID, vm.Name), menuItems, shortcuts, func(index int, action string) {
+		a.CloseContextMenu()
+
+		switch action {
+		case "Start":
+			a.showConfirmationDialog(
+				fmt.Sprintf("Are you sure you want to start VM '%s' (ID: %d)?", vm.Name, vm.ID),
+				func() {
+					a.performVMOperation(vm, a.client.StartVM, "Starting")
+				},
+			)
+		case "Open VNC Console":
+			if a.selectAlertGuest(vm) {
+				a.openVMVNC()
+			}
+		case "Open Shell":
+			if a.selectAlertGuest(vm) {
+				a.openVMShell()
+			}
+		case "Go to Guest":
+			a.selectGuest(vm)
+		}
+	})
+	menu.SetApp(a)
+
+	menuList := menu.Show()
+
+	oldCapture := menuList.GetInputCapture()
+	menuList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.CloseContextMenu()
+
+			return nil
+		}
+
+		if oldCapture != nil {
+			return oldCapture(event)
+		}
+
+		return event
+	})
+
+	a.contextMenu = menuList
+	a.isMenuOpen = true
+
+	a.pages.AddPage("contextMenu", tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(menuList, len(menuItems)+2, 1, true).
+			AddItem(nil, 0, 1, false), 30, 1, true).
+		AddItem(nil, 0, 1, false), true, true)
+	a.SetFocus(menuList)
+}
+
+// selectAlertGuest selects the guest of an alert in the guest list, so the
+// console and shell actions act on it, and reports whether it was found.
+func (a *App) selectAlertGuest(vm *api.VM) bool {
+	a.selectGuest(vm)
+
+	selected := a.vmList.GetSelectedVM()
+
+	return selected != nil && selected.ID == vm.ID && selected.Node == vm.Node
+}
@@ -0,0 +1,176 @@
+package components
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// announcement is a note cluster admins left for everyone, such as a
+// maintenance window.
+type announcement struct {
+	Source string // "Datacenter" or "Node <name>"
+	Text   string // Markdown
+}
+
+// loadAnnouncements fetches the datacenter notes and the notes of the online
+// nodes. On connect they are shown only if they changed since they were last
+// shown; from the global menu they are always shown.
+func (a *App) loadAnnouncements(onConnect bool) {
+	nodes := make([]*api.Node, len(models.GlobalState.OriginalNodes))
+	copy(nodes, models.GlobalState.OriginalNodes)
+
+	if !onConnect {
+		a.header.ShowLoading("Loading announcements...")
+	}
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		var announcements []announcement
+
+		options, err := client.GetClusterOptions()
+		if err != nil {
+			models.GetUILogger().Debug("Failed to load datacenter notes: %v", err)
+		} else if text := strings.TrimSpace(options.Description()); text != "" {
+			announcements = append(announcements, announcement{Source: "Datacenter", Text: text})
+		}
+
+		for _, node := range nodes {
+			if node == nil || !node.Online {
+				continue
+			}
+
+			nodeConfig, err := client.GetNodeConfig(node.Name)
+			if err != nil {
+				models.GetUILogger().Debug("Failed to load notes of node %s: %v", node.Name, err)
+
+				continue
+			}
+
+			if text := strings.TrimSpace(api.SafeStringValue(nodeConfig["description"])); text != "" {
+				announcements = append(announcements, announcement{Source: "Node " + node.Name, Text: text})
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			if !onConnect {
+				a.header.StopLoading()
+			}
+
+			if len(announcements) == 0 {
+				if !onConnect {
+					a.header.ShowSuccess("No announcements")
+				}
+
+				return
+			}
+
+			hash := announcementsHash(announcements)
+			if onConnect && config.IsAnnouncementSeen(hash) {
+				return
+			}
+
+			// Don't cover a menu or the tour; they are shown on the next connect
+			if onConnect && (a.isMenuOpen || a.pages.HasPage("tour")) {
+				return
+			}
+
+			a.showAnnouncements(announcements)
+
+			if err := config.MarkAnnouncementSeen(hash); err != nil {
+				models.GetUILogger().Error("Failed to record seen announcements: %v", err)
+			}
+		})
+	}()
+}
+
+// showAnnouncements shows the datacenter and node notes rendered from
+// markdown.
+func (a *App) showAnnouncements(announcements []announcement) {
+	lastFocus := a.GetFocus()
+	titleColor := theme.ColorToTag(theme.Colors.HeaderText)
+
+	var lines []string
+
+	for i, item := range announcements {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, fmt.Sprintf("[%s::b]%s %s[-::-]", titleColor, theme.Icon("📢", "*"), item.Source))
+		lines = append(lines, utils.RenderMarkdown(item.Text)...)
+	}
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetText(strings.Join(lines, "\n"))
+	textView.SetBorder(true).
+		SetTitle(" Announcements ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	textView.SetBorderPadding(0, 0, 1, 1)
+
+	closeAnnouncements := func() {
+		a.removePageIfPresent("announcements")
+
+		if lastFocus != nil {
+			a.SetFocus(lastFocus)
+		}
+	}
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter ||
+			(event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeAnnouncements()
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := len(lines) + 2
+	if height > 24 {
+		height = 24
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, height, 0, true).
+			AddItem(nil, 0, 1, false), 80, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("announcements")
+	a.pages.AddPage("announcements", modal, true, true)
+	a.SetFocus(textView)
+}
+
+// announcementsHash identifies a set of announcements, so unchanged notes
+// aren't shown again on the next connect.
+func announcementsHash(announcements []announcement) string {
+	sum := sha256.New()
+
+	for _, item := range announcements {
+		fmt.Fprintf(sum, "%s\x00%s\x00", item.Source, item.Text)
+	}
+
+	return hex.EncodeToString(sum.Sum(nil))[:16]
+}
@@ -0,0 +1,202 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// apiErrorExplanation describes a known Proxmox error in plain words.
+type apiErrorExplanation struct {
+	Summary string
+	Hint    string
+}
+
+// apiErrorPatterns map common Proxmox error messages to explanations. The
+// explain functions receive the submatches of the pattern.
+var apiErrorPatterns = []struct {
+	pattern *regexp.Regexp
+	explain func(match []string) apiErrorExplanation
+}{
+	{
+		pattern: regexp.MustCompile(`Permission check failed \(([^,)]+), ([^)]+)\)`),
+		explain: func(match []string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: fmt.Sprintf("The user is missing the %s privilege on %s.", match[2], match[1]),
+				Hint: fmt.Sprintf("Grant a role that includes %s on %s (or a parent path, with propagation) under Datacenter > Permissions. "+
+					"API tokens with privilege separation need their own permission entries.", match[2], match[1]),
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)permission check failed|permission denied|status 403`),
+		explain: func([]string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: "The user is not allowed to do this.",
+				Hint:    "Check the roles of the user or API token under Datacenter > Permissions.",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)(?:VM|CT) is locked \(([\w-]+)\)`),
+		explain: func(match []string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: fmt.Sprintf("The guest is locked by a %s operation.", match[1]),
+				Hint: "Wait for the running task to finish (see the Tasks page). If no task is running the lock is stale; " +
+					"remove it with the Unlock guest action (u).",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)can't lock file '([^']+)'`),
+		explain: func(match []string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: fmt.Sprintf("Another operation holds the lock %s.", match[1]),
+				Hint:    "Wait for running tasks on this guest or node to finish, then try again.",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)storage '([^']+)' does not support content[- ]type '([^']+)'`),
+		explain: func(match []string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: fmt.Sprintf("Storage %s is not set up to hold %s.", match[1], contentTypeLabel(match[2])),
+				Hint: fmt.Sprintf("Add the '%s' content type to the storage under Datacenter > Storage > Edit, or choose another storage.",
+					match[2]),
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)not enough (?:free )?space|no space left on device|insufficient (?:free )?space|out of space`),
+		explain: func([]string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: "The storage does not have enough free space.",
+				Hint:    "Free up space (old backups, snapshots, or unused disks) or choose a storage with more room.",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)detected modified configuration`),
+		explain: func([]string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: "The configuration was changed elsewhere while it was being edited.",
+				Hint:    "Reopen the editor to load the current configuration, then apply your changes again.",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`requires Proxmox VE (\d+\.\d+) or later \(the cluster runs ([^)]+)\)`),
+		explain: func(match []string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: fmt.Sprintf("This needs Proxmox VE %s or later, but the cluster runs %s.", match[1], match[2]),
+				Hint:    fmt.Sprintf("Upgrade the cluster to Proxmox VE %s or later to use this.", match[1]),
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`status 501`),
+		explain: func([]string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: "The Proxmox VE release of the cluster does not implement this API call.",
+				Hint:    "The cluster likely runs an older release; upgrade it to use this, or use the web interface.",
+			}
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)no quorum`),
+		explain: func([]string) apiErrorExplanation {
+			return apiErrorExplanation{
+				Summary: "The cluster has lost quorum, so configuration changes are blocked.",
+				Hint:    "Bring enough nodes back online for a majority, or check corosync on the nodes.",
+			}
+		},
+	},
+}
+
+// contentTypeLabel names a storage content type.
+func contentTypeLabel(content string) string {
+	switch content {
+	case "images":
+		return "VM disks"
+	case "rootdir":
+		return "container volumes"
+	case "backup":
+		return "backups"
+	case "iso":
+		return "ISO images"
+	case "vztmpl":
+		return "container templates"
+	case "snippets":
+		return "snippets"
+	default:
+		return content + " content"
+	}
+}
+
+// explainAPIError returns an explanation of a known Proxmox error.
+func explainAPIError(err error) (apiErrorExplanation, bool) {
+	if err == nil {
+		return apiErrorExplanation{}, false
+	}
+
+	message := err.Error()
+
+	for _, known := range apiErrorPatterns {
+		if match := known.pattern.FindStringSubmatch(message); match != nil {
+			return known.explain(match), true
+		}
+	}
+
+	return apiErrorExplanation{}, false
+}
+
+// showActionError reports a failed action. Failed tasks are shown with the
+// end of their log, and known Proxmox errors are explained with next steps
+// in a modal; others are shown in the header as before.
+func (a *App) showActionError(message string, err error) {
+	// Lost connections are retried in the background instead of explained
+	if isConnectivityError(err) {
+		a.header.ShowError(message + ": connection lost; reconnecting")
+		a.startReconnecting(err)
+
+		return
+	}
+
+	var taskErr *api.TaskError
+	if errors.As(err, &taskErr) {
+		a.showTaskError(message, taskErr)
+
+		return
+	}
+
+	explanation, ok := explainAPIError(err)
+	if !ok {
+		a.header.ShowError(fmt.Sprintf("%s: %v", message, err))
+
+		return
+	}
+
+	a.header.ShowError(message)
+
+	focus := a.GetFocus()
+
+	text := fmt.Sprintf("%s\n\n%s\n\nNext steps: %s\n\nDetails: %s",
+		message, explanation.Summary, explanation.Hint, strings.TrimSpace(err.Error()))
+
+	modal := CreateErrorDialog("Action Failed", tview.Escape(text), func() {
+		a.removePageIfPresent("apiError")
+
+		if focus != nil {
+			a.SetFocus(focus)
+		}
+	})
+
+	a.removePageIfPresent("apiError")
+	a.pages.AddPage("apiError", modal, false, true)
+	a.SetFocus(modal)
+}
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		summary string
+	}{
+		{
+			name:    "missing privilege",
+			err:     errors.New("API request failed with status 403: Permission check failed (/vms/100, VM.PowerMgmt)"),
+			summary: "The user is missing the VM.PowerMgmt privilege on /vms/100.",
+		},
+		{
+			name:    "forbidden",
+			err:     errors.New("API request failed with status 403"),
+			summary: "The user is not allowed to do this.",
+		},
+		{
+			name:    "locked guest",
+			err:     fmt.Errorf("failed to start VM: %w", errors.New("API request failed with status 500: VM is locked (backup)")),
+			summary: "The guest is locked by a backup operation.",
+		},
+		{
+			name:    "lock file",
+			err:     errors.New("can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout"),
+			summary: "Another operation holds the lock /var/lock/qemu-server/lock-100.conf.",
+		},
+		{
+			name:    "content type",
+			err:     errors.New("storage 'local' does not support content-type 'images'"),
+			summary: "Storage local is not set up to hold VM disks.",
+		},
+		{
+			name:    "full storage",
+			err:     errors.New("write failed: No space left on device"),
+			summary: "The storage does not have enough free space.",
+		},
+		{
+			name:    "modified config",
+			err:     errors.New("API request failed with status 500: detected modified configuration - file changed by other user? Try again."),
+			summary: "The configuration was changed elsewhere while it was being edited.",
+		},
+		{
+			name:    "unsupported feature",
+			err:     errors.New("Container interface addresses requires Proxmox VE 8.1 or later (the cluster runs 7.4.3)"),
+			summary: "This needs Proxmox VE 8.1 or later, but the cluster runs 7.4.3.",
+		},
+		{
+			name:    "not implemented",
+			err:     errors.New("API request failed with status 501: Method 'GET /nodes/pve1/lxc/105/interfaces' not implemented"),
+			summary: "The Proxmox VE release of the cluster does not implement this API call.",
+		},
+		{
+			name:    "quorum",
+			err:     errors.New("cluster not ready - no quorum?"),
+			summary: "The cluster has lost quorum, so configuration changes are blocked.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation, ok := explainAPIError(tt.err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.summary, explanation.Summary)
+			assert.NotEmpty(t, explanation.Hint)
+		})
+	}
+
+	_, ok := explainAPIError(errors.New("API request failed with status 500: unexpected"))
+	assert.False(t, ok)
+
+	_, ok = explainAPIError(nil)
+	assert.False(t, ok)
+}
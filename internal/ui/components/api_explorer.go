@@ -0,0 +1,201 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// parseExplorerParams parses the parameters of the API explorer, given as a
+// query string like "type=vm&full=1".
+func parseExplorerParams(text string) (map[string]interface{}, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	params := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		params[name] = value[0]
+	}
+
+	return params, nil
+}
+
+// formatExplorerResponse applies query to a response and pretty-prints the
+// result.
+func formatExplorerResponse(response map[string]interface{}, query string) (string, error) {
+	result, err := api.QueryJSON(response, query)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(text), nil
+}
+
+// showAPIExplorer sends requests to any API path and shows the responses,
+// filtered by a JMESPath-style query, for endpoints pvetui has no view for.
+// Writes are sent after a confirmation.
+func (a *App) showAPIExplorer() {
+	client := a.client
+
+	form := tview.NewForm()
+	form.AddDropDown("Method", api.RequestMethods, 0, nil)
+	form.AddInputField("Path", "/version", 60, nil, nil)
+	form.AddInputField("Parameters", "", 60, nil, nil)
+	form.AddInputField("Query", "", 60, nil, nil)
+
+	report := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	report.SetBorderPadding(0, 0, 1, 1)
+	report.SetText(theme.ReplaceSemanticTags("[secondary]Parameters are a query string like type=vm&full=1. The query selects parts of the response, e.g. data[?status=='running'].name; it applies as you type. PgUp/PgDn scroll the response.[-]"))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 11, 0, true).
+		AddItem(report, 0, 1, false)
+	content.SetBorder(true).
+		SetTitle(" API Explorer ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	var (
+		response map[string]interface{}
+		sending  bool
+	)
+
+	render := func() {
+		if response == nil {
+			return
+		}
+
+		text, err := formatExplorerResponse(response, form.GetFormItemByLabel("Query").(*tview.InputField).GetText())
+		if err != nil {
+			report.SetText(theme.ReplaceSemanticTags("[error]" + tview.Escape(err.Error()) + "[-]"))
+
+			return
+		}
+
+		report.SetText(tview.Escape(text)).ScrollToBeginning()
+	}
+
+	form.GetFormItemByLabel("Query").(*tview.InputField).SetChangedFunc(func(string) {
+		render()
+	})
+
+	send := func(method, path string, params map[string]interface{}) {
+		sending = true
+
+		report.SetText(theme.ReplaceSemanticTags(fmt.Sprintf("[secondary]%s %s...[-]", method, tview.Escape(path))))
+
+		go func() {
+			defer crash.Recover()
+
+			start := time.Now()
+			result, err := client.Request(method, path, params)
+			elapsed := time.Since(start).Round(time.Millisecond)
+
+			a.QueueUpdateDraw(func() {
+				sending = false
+
+				content.SetTitle(fmt.Sprintf(" API Explorer: %s %s (%s) ", method, path, elapsed))
+
+				if err != nil {
+					response = nil
+
+					report.SetText(theme.ReplaceSemanticTags("[error]" + tview.Escape(err.Error()) + "[-]"))
+
+					return
+				}
+
+				response = result
+				render()
+			})
+		}()
+	}
+
+	closeExplorer := func() {
+		a.removePageIfPresent("apiExplorer")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	form.AddButton("Send", func() {
+		if sending {
+			return
+		}
+
+		_, method := form.GetFormItemByLabel("Method").(*tview.DropDown).GetCurrentOption()
+		path := strings.TrimSpace(form.GetFormItemByLabel("Path").(*tview.InputField).GetText())
+
+		if path == "" {
+			report.SetText(theme.ReplaceSemanticTags("[error]Enter the API path, e.g. /nodes[-]"))
+
+			return
+		}
+
+		params, err := parseExplorerParams(form.GetFormItemByLabel("Parameters").(*tview.InputField).GetText())
+		if err != nil {
+			report.SetText(theme.ReplaceSemanticTags("[error]" + tview.Escape(err.Error()) + "[-]"))
+
+			return
+		}
+
+		if method == "GET" {
+			send(method, path, params)
+
+			return
+		}
+
+		a.showConfirmationDialog(fmt.Sprintf("Send %s %s?\n\nThis may change the cluster.", method, path), func() {
+			a.SetFocus(form)
+			send(method, path, params)
+		})
+	})
+	form.AddButton("Close", closeExplorer)
+	form.SetCancelFunc(closeExplorer)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeExplorer()
+
+			return nil
+		case tcell.KeyPgUp, tcell.KeyPgDn:
+			report.InputHandler()(event, nil)
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("apiExplorer")
+	a.pages.AddPage("apiExplorer", modal, true, true)
+	a.SetFocus(form)
+}
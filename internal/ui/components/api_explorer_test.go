@@ -0,0 +1,39 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExplorerParams(t *testing.T) {
+	params, err := parseExplorerParams(" type=vm&full=1&description=hello%20world ")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"type": "vm", "full": "1", "description": "hello world"}, params)
+
+	params, err = parseExplorerParams("")
+	require.NoError(t, err)
+	assert.Empty(t, params)
+
+	_, err = parseExplorerParams("name=%zz")
+	assert.Error(t, err)
+}
+
+func TestFormatExplorerResponse(t *testing.T) {
+	response := map[string]interface{}{"data": []interface{}{
+		map[string]interface{}{"node": "pve1", "status": "online"},
+		map[string]interface{}{"node": "pve2", "status": "offline"},
+	}}
+
+	text, err := formatExplorerResponse(response, "data[?status=='online'].node")
+	require.NoError(t, err)
+	assert.Equal(t, "[\n  \"pve1\"\n]", text)
+
+	text, err = formatExplorerResponse(response, "data[3]")
+	require.NoError(t, err)
+	assert.Equal(t, "null", text)
+
+	_, err = formatExplorerResponse(response, "data[")
+	assert.Error(t, err)
+}
@@ -0,0 +1,301 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Token lifetimes, in days.
+const (
+	defaultTokenLifetimeDays = 90
+	tokenExpiryWarningDays   = 14
+)
+
+// showAPITokenStatus shows the privilege separation and expiry of the API
+// token of the active profile.
+func (a *App) showAPITokenStatus() {
+	if !a.client.IsUsingTokenAuth() {
+		a.header.ShowError("The active profile does not log in with an API token")
+
+		return
+	}
+
+	userID := fmt.Sprintf("%s@%s", a.config.GetUser(), a.config.GetRealm())
+	tokenID := a.config.GetTokenID()
+
+	a.header.ShowLoading("Loading API token...")
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		token, err := client.GetAPIToken(userID, tokenID)
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.header.ShowError(err.Error())
+			})
+
+			return
+		}
+
+		// The ACL may not be readable with the token's privileges
+		aclEntries := -1
+
+		if token.PrivilegeSeparation {
+			if acl, err := client.GetTokenACL(token.FullID()); err == nil {
+				aclEntries = len(acl)
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+			a.showAPITokenModal(token, aclEntries)
+		})
+	}()
+}
+
+// formatTokenExpiry describes when a token expires, colored by how soon.
+func formatTokenExpiry(token *api.APIToken, now time.Time) string {
+	expires, ok := token.ExpiresAt()
+	if !ok {
+		return "[warning]never[-] (consider rotating it to a token with an expiry)"
+	}
+
+	days := int(expires.Sub(now).Hours() / 24)
+	date := expires.Format("2006-01-02")
+
+	switch {
+	case !expires.After(now):
+		return fmt.Sprintf("[error]expired on %s[-]", date)
+	case days < tokenExpiryWarningDays:
+		return fmt.Sprintf("[warning]%s (in %d days)[-]", date, days)
+	default:
+		return fmt.Sprintf("%s (in %d days)", date, days)
+	}
+}
+
+// formatTokenPrivileges describes the privilege separation of a token.
+// aclEntries is negative when the ACL could not be read.
+func formatTokenPrivileges(token *api.APIToken, aclEntries int) string {
+	if !token.PrivilegeSeparation {
+		return fmt.Sprintf("[warning]not separated[-] (all privileges of %s)", token.UserID)
+	}
+
+	if aclEntries < 0 {
+		return "separated (own ACL entries)"
+	}
+
+	return fmt.Sprintf("separated (%d ACL entries)", aclEntries)
+}
+
+// showAPITokenModal renders the token status. r starts a rotation.
+func (a *App) showAPITokenModal(token *api.APIToken, aclEntries int) {
+	comment := token.Comment
+	if comment == "" {
+		comment = "-"
+	}
+
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(theme.ReplaceSemanticTags(fmt.Sprintf(
+			"\n [primary]Token[-]       %s\n [primary]Comment[-]     %s\n [primary]Privileges[-]  %s\n [primary]Expires[-]     %s\n\n [primary]r[-] rotate  [primary]Esc[-] close",
+			tview.Escape(token.FullID()), tview.Escape(comment),
+			formatTokenPrivileges(token, aclEntries), formatTokenExpiry(token, time.Now()))))
+	text.SetBorder(true).
+		SetTitle(" API Token ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			a.removePageIfPresent("apiToken")
+
+			if a.lastFocus != nil {
+				a.SetFocus(a.lastFocus)
+			}
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			a.showAPITokenRotation(token)
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(text, 9, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("apiToken")
+	a.pages.AddPage("apiToken", modal, true, true)
+	a.SetFocus(text)
+}
+
+// showAPITokenRotation asks for the ID and lifetime of the replacement token.
+func (a *App) showAPITokenRotation(current *api.APIToken) {
+	profileName := a.config.GetActiveProfile()
+
+	profile, exists := a.config.Profiles[profileName]
+	if !exists || profile.TokenID != current.TokenID || profile.TokenSecret != a.config.GetTokenSecret() {
+		a.header.ShowError("The token is not set in a config profile; rotate it in the Proxmox web interface")
+
+		return
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(" Rotate API Token ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	form.AddInputField("New token ID", api.RotatedTokenID(current.TokenID, time.Now()), 32, nil, nil)
+	form.AddInputField("Valid for days (0 = never)", strconv.Itoa(defaultTokenLifetimeDays), 6, func(text string, _ rune) bool {
+		_, err := strconv.Atoi(text)
+
+		return err == nil
+	}, nil)
+
+	closeForm := func() {
+		a.removePageIfPresent("apiTokenRotate")
+		a.SetFocus(a.pages)
+	}
+
+	form.AddButton("Rotate", func() {
+		newID := strings.TrimSpace(form.GetFormItemByLabel("New token ID").(*tview.InputField).GetText())
+		days, _ := strconv.Atoi(form.GetFormItemByLabel("Valid for days (0 = never)").(*tview.InputField).GetText())
+
+		if newID == "" || newID == current.TokenID || days < 0 {
+			a.header.ShowError("Enter a new token ID and a lifetime of 0 or more days")
+
+			return
+		}
+
+		a.removePageIfPresent("apiTokenRotate")
+		a.removePageIfPresent("apiToken")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+
+		a.rotateAPIToken(current, profileName, newID, days)
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	info := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(theme.ReplaceSemanticTags(fmt.Sprintf(
+			" Creates the new token with the same privilege separation, comment and ACL entries, saves it to profile [primary]%s[-], then deletes [primary]%s[-].",
+			tview.Escape(profileName), tview.Escape(current.FullID()))))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(info, 3, 0, false).
+		AddItem(form, 0, 1, true)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 12, 0, true).
+			AddItem(nil, 0, 1, false), 72, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("apiTokenRotate", modal, true, true)
+	a.SetFocus(form)
+}
+
+// rotateAPIToken replaces the token of the active profile: it creates the
+// new token, saves it to the profile, switches the connection to it and
+// deletes the old token. A new token that can't be saved is deleted again.
+func (a *App) rotateAPIToken(current *api.APIToken, profileName, newID string, days int) {
+	var expire int64
+	if days > 0 {
+		expire = time.Now().AddDate(0, 0, days).Unix()
+	}
+
+	oldSecret := a.config.GetTokenSecret()
+	client := a.client
+
+	a.header.ShowLoading("Rotating API token...")
+
+	go func() {
+		defer crash.Recover()
+
+		secret, err := client.RotateAPIToken(current, newID, expire)
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.header.ShowError("Token rotation failed: " + err.Error())
+			})
+
+			return
+		}
+
+		replacement := api.APIToken{UserID: current.UserID, TokenID: newID}
+
+		a.QueueUpdateDraw(func() {
+			err := a.config.SetProfileToken(profileName, newID, secret)
+			if err == nil {
+				err = a.saveConfig()
+			}
+
+			if err != nil {
+				_ = a.config.SetProfileToken(profileName, current.TokenID, oldSecret)
+
+				go func() {
+					defer crash.Recover()
+
+					_ = client.DeleteAPIToken(replacement.UserID, replacement.TokenID)
+				}()
+
+				a.header.ShowError("Token rotation canceled: " + err.Error())
+
+				return
+			}
+
+			client.UseAPIToken(replacement.FullID(), secret)
+
+			go func() {
+				defer crash.Recover()
+
+				err := client.DeleteAPIToken(current.UserID, current.TokenID)
+
+				a.QueueUpdateDraw(func() {
+					if err != nil {
+						a.header.ShowWarning(fmt.Sprintf("Now using %s, but the old token was not deleted: %v", replacement.FullID(), err))
+
+						return
+					}
+
+					a.header.ShowSuccess(fmt.Sprintf("API token rotated to %s", replacement.FullID()))
+				})
+			}()
+		})
+	}()
+}
@@ -0,0 +1,28 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestFormatTokenExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
+	assert.Contains(t, formatTokenExpiry(&api.APIToken{}, now), "never")
+	assert.Equal(t, "2027-01-14 (in 90 days)", formatTokenExpiry(&api.APIToken{Expire: now.AddDate(0, 0, 90).Unix()}, now))
+	assert.Equal(t, "[warning]2026-10-21 (in 5 days)[-]", formatTokenExpiry(&api.APIToken{Expire: now.AddDate(0, 0, 5).Unix()}, now))
+	assert.Equal(t, "[error]expired on 2026-10-15[-]", formatTokenExpiry(&api.APIToken{Expire: now.AddDate(0, 0, -1).Unix()}, now))
+}
+
+func TestFormatTokenPrivileges(t *testing.T) {
+	token := &api.APIToken{UserID: "pvetui@pve", TokenID: "tui"}
+	assert.Contains(t, formatTokenPrivileges(token, -1), "not separated")
+
+	token.PrivilegeSeparation = true
+	assert.Equal(t, "separated (3 ACL entries)", formatTokenPrivileges(token, 3))
+	assert.Equal(t, "separated (own ACL entries)", formatTokenPrivileges(token, -1))
+}
@@ -2,17 +2,24 @@ package components
 
 import (
 	"context"
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
 	"github.com/devnullvoid/pvetui/internal/adapters"
 	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/logger"
+	"github.com/devnullvoid/pvetui/internal/scripts"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/startup"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/internal/vnc"
 	"github.com/devnullvoid/pvetui/pkg/api"
 	"github.com/devnullvoid/pvetui/pkg/api/interfaces"
+	"github.com/devnullvoid/pvetui/pkg/plugin"
 )
 
 // App is the main application component.
@@ -23,6 +30,7 @@ type App struct {
 	config        config.Config
 	configPath    string
 	vncService    *vnc.Service
+	scriptHistory *scripts.History // Shared so concurrent script runs do not lose entries
 	pages         *tview.Pages
 	header        HeaderComponent
 	footer        FooterComponent
@@ -34,6 +42,9 @@ type App struct {
 	clusterStatus ClusterStatusComponent
 	helpModal     *HelpModal
 	mainLayout    *tview.Flex
+	splitPages    []splitPage
+	zoomed        bool
+	screen        tcell.Screen
 	searchInput   *tview.InputField
 	contextMenu   *tview.List
 	isMenuOpen    bool
@@ -49,6 +60,64 @@ type App struct {
 	autoRefreshStop          chan bool
 	autoRefreshCountdown     int
 	autoRefreshCountdownStop chan bool
+
+	// reconnecting is set while the API is unreachable and being probed
+	reconnecting atomic.Bool
+
+	// detectedRestarts are guests found restarted during the current refresh
+	detectedRestarts []*api.VM
+	// detectedStops are guests found stopped unexpectedly during the current refresh
+	detectedStops []*api.VM
+
+	// recentGuests and enrichTimer drive lazy guest enrichment on selection
+	recentGuests *models.RecentGuests
+	enrichTimer  *time.Timer
+
+	// startupTrace is set with --startup-trace
+	startupTrace *startup.Trace
+
+	// plugins are the loaded plugins; pluginColumns holds their guest list
+	// values by guest key
+	plugins       []*plugin.Plugin
+	pluginColumns map[string]string
+
+	// notifiedAlerts and notifiedTasks are the alerts and failed tasks the
+	// notification backends already know about, nil until the first load
+	notifiedAlerts map[string]bool
+	notifiedTasks  map[string]bool
+
+	// announcementsPending shows changed announcements after the refresh
+	// following a profile switch
+	announcementsPending bool
+
+	// backupsLoading is set while the latest backups are being collected
+	backupsLoading bool
+
+	// clocksLoading is set while the clocks of the nodes are being read
+	clocksLoading bool
+
+	// snapshotsScanning is set while the snapshots of all guests are scanned
+	snapshotsScanning bool
+
+	// guestFilePaths are the files recently read from each QEMU guest, most
+	// recent first, keyed by "node:vmid"
+	guestFilePaths map[string][]string
+
+	// neighborsReading holds the nodes whose neighbor table is being read
+	neighborsReading map[string]bool
+
+	// broadcastCommand is the last command run on several nodes at once
+	broadcastCommand string
+
+	// startLink is the node or guest opened once loaded, zero when none
+	// was requested or it was opened already
+	startLink DeepLink
+
+	// locked is set while the lock screen hides the interface; lastInput
+	// and lastIdleCheck drive the idle lock
+	locked        bool
+	lastInput     time.Time
+	lastIdleCheck time.Time
 }
 
 // removePageIfPresent removes a page by name if it exists, ignoring errors.
@@ -58,6 +127,23 @@ func (a *App) removePageIfPresent(name string) {
 	}
 }
 
+// nodeSSHOptions returns the options of SSH connections to the nodes, with
+// shared connections unless ssh_multiplex is off.
+func nodeSSHOptions(cfg *config.Config) ssh.Options {
+	opts := ssh.Options{Port: cfg.SSHPort, IdentityFile: cfg.SSHKeyFile}
+	if cfg.SSHMultiplex && cfg.CacheDir != "" {
+		opts.ControlDir = filepath.Join(cfg.CacheDir, "ssh")
+	}
+
+	return opts
+}
+
+// nodeAddress returns the address SSH and other direct connections to a
+// node use: its node_addresses entry, else the IP from the cluster status.
+func (a *App) nodeAddress(node *api.Node) string {
+	return a.config.NodeAddress(node.Name, node.IP)
+}
+
 // NewApp creates a new application instance with all UI components.
 func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configPath string) *App {
 	uiLogger := models.GetUILogger()
@@ -72,6 +158,15 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 		vncLogger = loggerAdapter.GetInternalLogger()
 	}
 
+	// Apply the node SSH port and identity file to shells and scripts
+	ssh.SetNodeOptions(nodeSSHOptions(cfg))
+
+	if err := models.SetMetadataPatterns(cfg.Metadata.EffectivePatterns()); err != nil {
+		uiLogger.Error("Failed to set metadata patterns: %v", err)
+	}
+
+	models.SetMetricsCapacity(cfg.MetricsHistory)
+
 	ctx, cancel := context.WithCancel(ctx)
 	app := &App{
 		Application:        tview.NewApplication(),
@@ -79,13 +174,20 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 		config:             *cfg,
 		configPath:         configPath,
 		vncService:         vnc.NewServiceWithLogger(client, vncLogger),
+		scriptHistory:      scripts.NewHistory(filepath.Join(cfg.CacheDir, "scripts")),
 		pages:              tview.NewPages(),
 		autoRefreshEnabled: false,
 		ctx:                ctx,
 		cancel:             cancel,
 		logger:             uiLogger,
+		recentGuests:       models.NewRecentGuests(cfg.Enrichment.Recent),
 	}
 
+	// Re-logins of two-factor accounts ask for the code in the interface
+	client.SetTFAPrompt(app.promptTFA)
+	client.SetOpenIDAuthorizer(app.openIDAuthorizer())
+	client.SetSchemaDriftHandler(app.notifySchemaDrift)
+
 	uiLogger.Debug("Initializing UI components")
 
 	// Initialize components
@@ -94,10 +196,13 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 	app.footer.UpdateKeybindings(FormatFooterText(cfg.KeyBindings))
 	app.nodeList = NewNodeList()
 	app.vmList = NewVMList()
+	app.vmList.SetPageSize(cfg.GuestLimit)
 	app.nodeDetails = NewNodeDetails()
 	app.vmDetails = NewVMDetails()
 	app.tasksList = NewTasksList()
 	app.clusterStatus = NewClusterStatus()
+	app.clusterStatus.SetMode(cfg.Summary.Mode)
+	app.clusterStatus.SetCompact(cfg.Summary.Compact)
 	app.helpModal = NewHelpModal(cfg.KeyBindings)
 
 	// Set app reference for components that need it
@@ -108,11 +213,15 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 
 	uiLogger.Debug("Loading initial cluster data")
 
-	// Show loading indicator for guest data enrichment
-	app.header.ShowLoading("Loading guest agent data")
+	// Show loading indicator until guests and their details arrive
+	app.header.ShowLoading("Loading guests")
 
-	// Load initial data with error handling
-	if _, err := client.FastGetClusterStatus(func() {
+	// Load nodes first; guests are fetched and enriched in the background
+	if _, err := client.ProgressiveGetClusterStatus(func(err error) {
+		app.QueueUpdateDraw(func() {
+			app.applyLoadedGuests(err)
+		})
+	}, func() {
 		// This callback is called when background VM enrichment completes
 		uiLogger.Debug("VM enrichment callback triggered")
 		app.QueueUpdateDraw(func() {
@@ -285,9 +394,9 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 	// Register keyboard handlers
 	app.setupKeyboardHandlers()
 
-	// Set the root and focus
+	// Set the root and open the configured start page
 	app.SetRoot(app.mainLayout, true)
-	app.SetFocus(app.nodeList)
+	app.showStartPage()
 
 	// Start VNC session monitoring
 	app.startVNCSessionMonitoring()
@@ -295,6 +404,15 @@ func NewApp(ctx context.Context, client *api.Client, cfg *config.Config, configP
 	// Register callback for immediate session count updates
 	app.registerVNCSessionCallback()
 
+	// Look for a newer release, if enabled
+	app.checkForUpdate()
+
+	// Lock the session when idle, if enabled
+	app.startIdleLock()
+
+	// Start the configured plugins
+	app.loadPlugins()
+
 	uiLogger.Debug("App initialization completed successfully")
 
 	return app
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 
+	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 )
 
@@ -12,6 +13,16 @@ func (a *App) Run() error {
 	uiLogger.Debug("Starting application")
 
 	a.startAutoRefresh()
+	a.watchConfigFile()
+	a.loadLatestBackups()
+	a.loadNodeClocks()
+	a.scanSnapshots(false, nil)
+
+	if config.IsTourPending() {
+		a.QueueUpdateDraw(a.showTour)
+	} else {
+		a.loadAnnouncements(true)
+	}
 
 	defer func() {
 		a.stopAutoRefresh()
@@ -3,6 +3,7 @@ package components
 import (
 	"time"
 
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 )
 
@@ -46,6 +47,8 @@ func (a *App) startAutoRefresh() {
 
 	// Start countdown goroutine
 	go func() {
+		defer crash.Recover()
+
 		uiLogger := models.GetUILogger()
 
 		for {
@@ -73,7 +76,10 @@ func (a *App) startAutoRefresh() {
 				// Trigger refresh when countdown reaches 0
 				if a.autoRefreshCountdown == 0 {
 					// Only refresh if not currently loading something and no pending operations
-					if !a.header.IsLoading() && !models.GlobalState.HasPendingOperations() {
+					if a.reconnecting.Load() {
+						// The reconnect loop refreshes once the API answers again
+						a.autoRefreshCountdown = 10
+					} else if !a.header.IsLoading() && !models.GlobalState.HasPendingOperations() {
 						uiLogger.Debug("Auto-refresh triggered by countdown")
 
 						go a.autoRefreshDataWithFooter()
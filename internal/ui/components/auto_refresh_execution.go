@@ -2,7 +2,10 @@ package components
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -18,6 +21,8 @@ func (a *App) autoRefreshDataWithFooter() {
 
 // autoRefreshData performs a lightweight refresh of performance data.
 func (a *App) autoRefreshData() {
+	defer crash.Recover()
+
 	uiLogger := models.GetUILogger()
 
 	// Store current selections to preserve them
@@ -45,12 +50,18 @@ func (a *App) autoRefreshData() {
 	// Check if search is currently active
 	searchWasActive := a.mainLayout.GetItemCount() > 4
 
-	// Fetch fresh cluster resources data (this includes performance metrics)
-	cluster, err := a.client.GetFreshClusterStatus()
+	// Fetch fresh cluster resources data (this includes performance metrics
+	// of all guests, whose details are carried over)
+	cluster, err := a.client.RefreshClusterResources()
 	if err != nil {
 		uiLogger.Debug("Auto-refresh failed: %v", err)
 		a.QueueUpdateDraw(func() {
 			a.footer.SetLoading(false)
+
+			if isConnectivityError(err) && !a.reconnecting.Load() {
+				a.header.ShowWarning("Connection lost; reconnecting")
+				a.startReconnecting(err)
+			}
 		})
 
 		return
@@ -88,6 +99,9 @@ func (a *App) autoRefreshData() {
 						freshNode.KernelVersion = existingNode.KernelVersion
 						freshNode.CPUInfo = existingNode.CPUInfo
 						freshNode.LoadAvg = existingNode.LoadAvg
+						freshNode.Temperatures = existingNode.Temperatures
+						freshNode.Description = existingNode.Description
+						freshNode.DetailsForbidden = existingNode.DetailsForbidden
 						freshNode.CGroupMode = existingNode.CGroupMode
 						freshNode.Level = existingNode.Level
 						freshNode.Storage = existingNode.Storage
@@ -121,6 +135,8 @@ func (a *App) autoRefreshData() {
 		copy(models.GlobalState.FilteredNodes, cluster.Nodes)
 		copy(models.GlobalState.OriginalVMs, vms)
 		copy(models.GlobalState.FilteredVMs, vms)
+		a.trackGuestRestarts(vms)
+		models.RecordMetrics(cluster.Nodes, vms, time.Now())
 
 		// Apply filters if active, otherwise use all data
 		if nodeSearchState != nil && nodeSearchState.Filter != "" {
@@ -149,14 +165,21 @@ func (a *App) autoRefreshData() {
 			a.vmDetails.Update(vm)
 		}
 
-		// Refresh tasks if on tasks page
+		// Refresh tasks if on tasks page, shown in the summary panel, or
+		// failed tasks are sent to notification backends
 		currentPage, _ := a.pages.GetFrontPage()
-		if currentPage == api.PageTasks {
+		if currentPage == api.PageTasks || a.clusterStatus.Mode() == config.SummaryModeTasks ||
+			a.notifiesEvent(config.NotificationEventTasks) {
 			// Refresh tasks data without showing loading indicator (background refresh)
 			go func() {
+				defer crash.Recover()
+
 				tasks, err := a.client.GetClusterTasks()
 				if err == nil {
 					a.QueueUpdateDraw(func() {
+						a.clusterStatus.SetTasks(tasks)
+						a.notifyFailedTasks(tasks)
+
 						// Check if there's an active search filter
 						if state := models.GlobalState.GetSearchState(api.PageTasks); state != nil && state.Filter != "" {
 							// Update global state and apply filter
@@ -177,7 +200,9 @@ func (a *App) autoRefreshData() {
 		a.restoreSearchUI(searchWasActive, nodeSearchState, vmSearchState)
 
 		// Show success message
-		a.header.ShowSuccess("Data refreshed successfully")
+		a.showRefreshSuccess()
+		a.enrichRecentGuests()
+		a.refreshPluginColumns()
 		a.footer.SetLoading(false)
 
 		// Reset countdown after refresh is complete
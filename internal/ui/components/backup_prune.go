@@ -0,0 +1,374 @@
+package components
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// pruneTimeout bounds how long we wait for a prune task to finish.
+const pruneTimeout = 30 * time.Minute
+
+// pruneKeepFields are the form labels of the retention options, in the order
+// of api.PruneOptions.
+var pruneKeepFields = []string{"Keep Last", "Keep Hourly", "Keep Daily", "Keep Weekly", "Keep Monthly", "Keep Yearly"}
+
+// pruneKeepCounts returns the fields of a policy in the order of
+// pruneKeepFields.
+func pruneKeepCounts(options *api.PruneOptions) []*int {
+	return []*int{&options.KeepLast, &options.KeepHourly, &options.KeepDaily, &options.KeepWeekly, &options.KeepMonthly, &options.KeepYearly}
+}
+
+// formatPrunePreview lists the backups of a prune preview by guest with
+// what would happen to them, and returns how many would be removed.
+func formatPrunePreview(entries []api.PruneEntry) (string, int) {
+	if len(entries) == 0 {
+		return "[secondary]No backups on this storage[-]", 0
+	}
+
+	var (
+		lines   []string
+		removed int
+		guest   = -1
+	)
+
+	for _, entry := range entries {
+		if entry.VMID != guest {
+			guest = entry.VMID
+
+			label := fmt.Sprintf("Guest %d", entry.VMID)
+			if entry.VMID == 0 {
+				label = "Other archives"
+			}
+
+			lines = append(lines, "", "[primary]"+label+"[-]")
+		}
+
+		color, mark := "secondary", entry.Mark
+
+		switch entry.Mark {
+		case api.PruneMarkRemove:
+			removed++
+
+			color = "error"
+		case api.PruneMarkKeep:
+			color = "info"
+		case api.PruneMarkRenamed:
+			mark = "kept (non-standard name)"
+		}
+
+		lines = append(lines, fmt.Sprintf("  [%s]%-9s %s  %s[-]",
+			color, mark, entry.CTime.Format("2006-01-02 15:04"), tview.Escape(path.Base(entry.VolID))))
+	}
+
+	summary := fmt.Sprintf("[warning]%d of %d backups would be removed[-]", removed, len(entries))
+	if removed == 0 {
+		summary = fmt.Sprintf("[primary]All %d backups are kept[-]", len(entries))
+	}
+
+	return strings.Join(append([]string{summary}, lines...), "\n"), removed
+}
+
+// showPruneBackupsDialog loads the backup storages of a node, then shows the
+// prune form.
+func (a *App) showPruneBackupsDialog(node *api.Node) {
+	a.header.ShowLoading(fmt.Sprintf("Loading backup storages of %s...", node.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		storages, err := a.client.GetBackupStorages(node.Name)
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to load backup storages of %s: %v", node.Name, err))
+
+				return
+			}
+
+			if len(storages) == 0 {
+				a.showMessage(fmt.Sprintf("Node '%s' has no backup storages", node.Name))
+
+				return
+			}
+
+			a.showPruneBackupsForm(node, storages)
+		})
+	}()
+}
+
+// showPruneBackupsForm lets the user try retention policies against the
+// backups on a storage and prune them once the preview looks right. The
+// policy starts out as the one configured on the storage.
+func (a *App) showPruneBackupsForm(node *api.Node, storages []string) {
+	storage := storages[0]
+
+	// The preview the Prune button applies; editing the form discards it
+	var previewed string
+
+	preview := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	preview.SetBorder(true).
+		SetTitle(" Preview ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Prune Backups on %s ", node.Name)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	setHint := func(text string) {
+		preview.SetText(theme.ReplaceSemanticTags("[secondary]" + text + "[-]"))
+	}
+
+	discardPreview := func() {
+		if previewed != "" {
+			previewed = ""
+
+			setHint("Policy changed; preview it again before pruning")
+		}
+	}
+
+	// readForm returns the policy and guest filter entered in the form
+	readForm := func() (api.PruneOptions, int, error) {
+		var options api.PruneOptions
+
+		for i, count := range pruneKeepCounts(&options) {
+			text := strings.TrimSpace(form.GetFormItemByLabel(pruneKeepFields[i]).(*tview.InputField).GetText())
+			if text == "" {
+				continue
+			}
+
+			value, err := strconv.Atoi(text)
+			if err != nil || value < 0 {
+				return options, 0, fmt.Errorf("%s must be a positive number", pruneKeepFields[i])
+			}
+
+			*count = value
+		}
+
+		if options.IsEmpty() {
+			return options, 0, fmt.Errorf("set at least one Keep option; without any, all backups are kept")
+		}
+
+		vmid := 0
+
+		if text := strings.TrimSpace(form.GetFormItemByLabel("Guest ID").(*tview.InputField).GetText()); text != "" {
+			value, err := strconv.Atoi(text)
+			if err != nil || value < 100 {
+				return options, 0, fmt.Errorf("the guest ID must be a number of at least 100")
+			}
+
+			vmid = value
+		}
+
+		return options, vmid, nil
+	}
+
+	// previewKey identifies what a preview was made for
+	previewKey := func(options api.PruneOptions, vmid int) string {
+		return fmt.Sprintf("%s/%d/%s", storage, vmid, options)
+	}
+
+	// loadPolicy fills the form with the policy configured on the storage
+	loadPolicy := func() {
+		selected := storage
+
+		setHint(fmt.Sprintf("Loading the retention policy of %s...", selected))
+
+		go func() {
+			defer crash.Recover()
+
+			options, err := a.client.GetStoragePruneOptions(selected)
+
+			a.QueueUpdateDraw(func() {
+				if selected != storage {
+					return
+				}
+
+				if err != nil {
+					setHint(fmt.Sprintf("Couldn't load the retention policy of %s: %v", selected, err))
+
+					return
+				}
+
+				for i, count := range pruneKeepCounts(&options) {
+					text := ""
+					if *count > 0 {
+						text = strconv.Itoa(*count)
+					}
+
+					form.GetFormItemByLabel(pruneKeepFields[i]).(*tview.InputField).SetText(text)
+				}
+
+				previewed = ""
+
+				if options.IsEmpty() {
+					setHint(fmt.Sprintf("%s keeps all backups. Enter a policy and press Preview.", selected))
+				} else {
+					setHint(fmt.Sprintf("Loaded the policy of %s (%s). Press Preview to see what it removes.", selected, options))
+				}
+			})
+		}()
+	}
+
+	form.AddDropDown("Storage", storages, 0, func(option string, index int) {
+		if index >= 0 && option != storage {
+			storage = option
+			previewed = ""
+
+			loadPolicy()
+		}
+	})
+	form.AddInputField("Guest ID", "", 10, tview.InputFieldInteger, func(string) { discardPreview() })
+
+	for _, label := range pruneKeepFields {
+		form.AddInputField(label, "", 6, tview.InputFieldInteger, func(string) { discardPreview() })
+	}
+
+	closeForm := func() {
+		a.removePageIfPresent("pruneBackups")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	form.AddButton("Preview", func() {
+		options, vmid, err := readForm()
+		if err != nil {
+			a.header.ShowError(err.Error())
+
+			return
+		}
+
+		selected := storage
+		setHint("Simulating the policy...")
+
+		go func() {
+			defer crash.Recover()
+
+			entries, err := a.client.PreviewPruneBackups(node.Name, selected, options, vmid)
+
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					previewed = ""
+
+					setHint(fmt.Sprintf("Preview failed: %v", err))
+
+					return
+				}
+
+				text, removed := formatPrunePreview(entries)
+				preview.SetText(theme.ReplaceSemanticTags(text))
+				preview.ScrollToBeginning()
+
+				// Nothing to prune, so there is nothing to confirm
+				previewed = ""
+				if removed > 0 {
+					previewed = previewKey(options, vmid)
+				}
+			})
+		}()
+	})
+
+	form.AddButton("Prune", func() {
+		options, vmid, err := readForm()
+		if err != nil {
+			a.header.ShowError(err.Error())
+
+			return
+		}
+
+		if previewed == "" || previewed != previewKey(options, vmid) {
+			a.header.ShowError("Preview the policy first; only a preview that removes backups can be applied")
+
+			return
+		}
+
+		target := "all guests"
+		if vmid > 0 {
+			target = fmt.Sprintf("guest %d", vmid)
+		}
+
+		selected := storage
+
+		a.showConfirmationDialog(
+			fmt.Sprintf("Prune the backups of %s on %s with %s?\n\nThe backups marked \"remove\" in the preview are deleted permanently. Protected backups are kept.",
+				target, selected, options),
+			func() {
+				closeForm()
+				a.performPruneBackups(node.Name, selected, options, vmid)
+			},
+		)
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	content := tview.NewFlex().
+		AddItem(form, 36, 0, true).
+		AddItem(preview, 0, 1, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 22, 0, true).
+			AddItem(nil, 0, 1, false), 110, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("pruneBackups")
+	a.pages.AddPage("pruneBackups", modal, true, true)
+	a.SetFocus(form)
+
+	loadPolicy()
+}
+
+// performPruneBackups starts a prune task and reports its progress in the
+// header.
+func (a *App) performPruneBackups(node, storage string, options api.PruneOptions, vmid int) {
+	a.header.ShowLoading(fmt.Sprintf("Pruning backups on %s", storage))
+
+	go func() {
+		defer crash.Recover()
+
+		upid, err := a.client.PruneBackups(node, storage, options, vmid)
+		if err == nil {
+			err = a.client.WaitForTask(upid, pruneTimeout, nil)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.showActionError(fmt.Sprintf("Pruning backups on %s failed", storage), err)
+				a.loadTasksData()
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Pruned backups on %s", storage))
+			a.loadTasksData()
+		})
+	}()
+}
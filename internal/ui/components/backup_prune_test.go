@@ -0,0 +1,41 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestFormatPrunePreview(t *testing.T) {
+	day := time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)
+
+	entries := []api.PruneEntry{
+		{VolID: "backups:backup/vzdump-qemu-100-2024_01_03-00_00_00.vma.zst", VMID: 100, CTime: day, Mark: api.PruneMarkKeep},
+		{VolID: "backups:backup/vzdump-qemu-100-2024_01_02-00_00_00.vma.zst", VMID: 100, CTime: day.AddDate(0, 0, -1), Mark: api.PruneMarkProtected},
+		{VolID: "backups:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst", VMID: 100, CTime: day.AddDate(0, 0, -2), Mark: api.PruneMarkRemove},
+		{VolID: "backups:backup/vzdump-lxc-105-2024_01_03-00_00_00.tar.zst", VMID: 105, CTime: day, Mark: api.PruneMarkKeep},
+		{VolID: "backups:backup/manual-copy.vma.zst", VMID: 0, CTime: day, Mark: api.PruneMarkRenamed},
+	}
+
+	text, removed := formatPrunePreview(entries)
+
+	assert.Equal(t, 1, removed)
+	assert.True(t, strings.HasPrefix(text, "[warning]1 of 5 backups would be removed"))
+	assert.Contains(t, text, "[primary]Guest 100[-]")
+	assert.Contains(t, text, "[primary]Guest 105[-]")
+	assert.Contains(t, text, "[primary]Other archives[-]")
+	assert.Contains(t, text, "[error]remove    2024-01-01 00:00  vzdump-qemu-100-2024_01_01-00_00_00.vma.zst[-]")
+	assert.Contains(t, text, "kept (non-standard name)")
+
+	text, removed = formatPrunePreview(entries[:1])
+	assert.Equal(t, 0, removed)
+	assert.True(t, strings.HasPrefix(text, "[primary]All 1 backups are kept"))
+
+	text, removed = formatPrunePreview(nil)
+	assert.Equal(t, 0, removed)
+	assert.Contains(t, text, "No backups")
+}
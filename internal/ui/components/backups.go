@@ -0,0 +1,104 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// backupIndexInterval is how often refreshes collect the backups of all
+// guests again. Listing every backup storage is expensive on large clusters
+// and backups rarely run more often.
+const backupIndexInterval = 15 * time.Minute
+
+// loadLatestBackups collects the newest backup of every guest from the
+// backup storages of the online nodes unless that was done within
+// backupIndexInterval. After the first load, guests with overdue backups are
+// reported in the header.
+func (a *App) loadLatestBackups() {
+	loadedAt := models.BackupsLoadedAt()
+	if a.backupsLoading || (!loadedAt.IsZero() && time.Since(loadedAt) < backupIndexInterval) {
+		return
+	}
+
+	var nodes []string
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node != nil && node.Online {
+			nodes = append(nodes, node.Name)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	a.backupsLoading = true
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		latest, err := client.GetLatestBackups(nodes)
+
+		a.QueueUpdateDraw(func() {
+			a.backupsLoading = false
+
+			if err != nil {
+				models.GetUILogger().Error("Failed to collect backups: %v", err)
+
+				return
+			}
+
+			// Results of a client replaced by a profile switch are stale
+			if client != a.client {
+				return
+			}
+
+			models.SetLatestBackups(latest, time.Now())
+			models.GetUILogger().Debug("Collected the latest backups of %d guests", len(latest))
+
+			if vm := a.vmList.GetSelectedVM(); vm != nil {
+				a.vmDetails.Update(vm)
+			}
+
+			if loadedAt.IsZero() {
+				a.warnOverdueBackups()
+			}
+		})
+	}()
+}
+
+// warnOverdueBackups reports the number of guests with overdue backups.
+func (a *App) warnOverdueBackups() {
+	alerts := models.CollectBackupAlerts(models.GlobalState.OriginalVMs, a.config.Backups.MaxAge(), time.Now())
+	if len(alerts) == 0 {
+		return
+	}
+
+	a.header.ShowWarning(fmt.Sprintf("%d guest(s) not backed up within %d days (see Alerts in the global menu)",
+		len(alerts), a.config.Backups.MaxAgeDays))
+}
+
+// lastBackupText describes the newest backup of a guest for the details
+// panel, e.g. "2025-08-09 03:00 (1d ago) on pbs". ok is false while the
+// backups weren't collected.
+func (a *App) lastBackupText(vm *api.VM) (text string, overdue bool, ok bool) {
+	if models.BackupsLoadedAt().IsZero() {
+		return "", false, false
+	}
+
+	now := time.Now()
+	overdue = models.BackupOverdue(vm, a.config.Backups.MaxAge(), now)
+
+	backup, found := models.LatestBackup(vm)
+	if !found {
+		return "Never", overdue, true
+	}
+
+	return fmt.Sprintf("%s (%s ago) on %s", backup.CTime.Format("2006-01-02 15:04"),
+		models.FormatAge(now.Sub(backup.CTime)), backup.Storage), overdue, true
+}
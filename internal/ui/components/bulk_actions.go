@@ -0,0 +1,223 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// bulkAction is a power action that can be applied to a list of guests.
+type bulkAction struct {
+	label string
+	// operationName is passed to performVMOperation
+	operationName string
+	operation     func(*api.Client) func(*api.VM) error
+}
+
+// bulkActions are the actions offered for guests entered by ID.
+var bulkActions = []bulkAction{
+	{vmActionStart, "Starting", func(c *api.Client) func(*api.VM) error { return c.StartVM }},
+	{vmActionShutdown, "Shutting down", func(c *api.Client) func(*api.VM) error { return c.ShutdownVM }},
+	{vmActionStop, "Stopping", func(c *api.Client) func(*api.VM) error { return c.StopVM }},
+	{vmActionRestart, "Restarting", func(c *api.Client) func(*api.VM) error { return c.RestartVM }},
+}
+
+// bulkSkipReason returns why action is not applied to vm, or an empty string
+// if it is.
+func bulkSkipReason(vm *api.VM, action string) string {
+	if pending, operation := models.GlobalState.IsVMPending(vm); pending {
+		return "busy: " + strings.ToLower(operation)
+	}
+
+	if vm.Lock != "" && guestLockConflict(vm, action) != nil {
+		return fmt.Sprintf("locked (%s)", vm.Lock)
+	}
+
+	switch action {
+	case vmActionStart:
+		if vm.Template {
+			return "template"
+		}
+
+		if vm.Status == api.VMStatusRunning {
+			return "already running"
+		}
+	default:
+		if vm.Status != api.VMStatusRunning {
+			return "not running"
+		}
+	}
+
+	return ""
+}
+
+// formatBulkPreview lists the guests an action would be applied to and the
+// ones it skips.
+func formatBulkPreview(action string, targets []models.BulkTarget) string {
+	var (
+		lines   []string
+		applied int
+	)
+
+	for _, target := range targets {
+		switch {
+		case target.VM == nil:
+			lines = append(lines, fmt.Sprintf("  [error]%d  not found[-]", target.ID))
+		case target.Skip != "":
+			lines = append(lines, fmt.Sprintf("  [secondary]%d  %s (%s)  skip: %s[-]",
+				target.ID, tview.Escape(target.VM.Name), target.VM.Node, target.Skip))
+		default:
+			applied++
+
+			lines = append(lines, fmt.Sprintf("  [primary]%d  %s (%s)  %s[-]",
+				target.ID, tview.Escape(target.VM.Name), target.VM.Node, target.VM.Status))
+		}
+	}
+
+	summary := fmt.Sprintf("[primary]%s %d of %d guests[-]", action, applied, len(targets))
+
+	return strings.Join(append([]string{summary, ""}, lines...), "\n")
+}
+
+// showBulkActionByID opens a form to apply a power action to guests entered
+// or pasted as a list of IDs and ranges, regardless of the active filter.
+// The affected guests are previewed before anything runs.
+func (a *App) showBulkActionByID() {
+	a.lastFocus = a.GetFocus()
+
+	action := bulkActions[0]
+
+	var (
+		targets  []models.BulkTarget
+		parseErr error
+		idsText  string
+	)
+
+	preview := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	preview.SetBorder(true).
+		SetTitle(" Preview ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	update := func() {
+		var ids []int
+
+		ids, parseErr = models.ParseVMIDs(idsText)
+		targets = nil
+
+		switch {
+		case parseErr != nil:
+			preview.SetText(theme.ReplaceSemanticTags("[error]" + tview.Escape(parseErr.Error()) + "[-]"))
+		case len(ids) == 0:
+			preview.SetText(theme.ReplaceSemanticTags("[secondary]Enter guest IDs, e.g. 101,104-110[-]"))
+		default:
+			targets = models.ResolveBulkTargets(ids, func(vm *api.VM) string {
+				return bulkSkipReason(vm, action.label)
+			})
+			preview.SetText(theme.ReplaceSemanticTags(formatBulkPreview(action.label, targets)))
+		}
+
+		preview.ScrollToBeginning()
+	}
+
+	closeForm := func() {
+		a.removePageIfPresent("bulkAction")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(" Bulk Action by ID ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	form.AddTextArea("Guest IDs", "", 0, 3, 0, func(text string) {
+		idsText = text
+		update()
+	})
+
+	labels := make([]string, len(bulkActions))
+	for i, candidate := range bulkActions {
+		labels[i] = candidate.label
+	}
+
+	form.AddDropDown("Action", labels, 0, func(_ string, index int) {
+		if index >= 0 {
+			action = bulkActions[index]
+			update()
+		}
+	})
+
+	form.AddButton("Run", func() {
+		var selected []*api.VM
+
+		for _, target := range targets {
+			if target.VM != nil && target.Skip == "" {
+				selected = append(selected, target.VM)
+			}
+		}
+
+		if len(selected) == 0 {
+			a.header.ShowError("No guests to apply the action to")
+
+			return
+		}
+
+		ids := make([]string, len(selected))
+		for i, vm := range selected {
+			ids[i] = fmt.Sprint(vm.ID)
+		}
+
+		chosen := action
+
+		a.removePageIfPresent("bulkAction")
+		a.showConfirmationDialog(
+			fmt.Sprintf("%s %d guests?\n\n%s", chosen.label, len(selected), strings.Join(ids, ", ")),
+			func() {
+				operation := chosen.operation(a.client)
+				for _, vm := range selected {
+					a.performVMOperation(vm, operation, chosen.operationName)
+				}
+			},
+		)
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	update()
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 11, 0, true).
+		AddItem(preview, 0, 1, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 28, 0, true).
+			AddItem(nil, 0, 1, false), 72, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("bulkAction")
+	a.pages.AddPage("bulkAction", modal, true, true)
+	a.SetFocus(form)
+}
@@ -0,0 +1,35 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestBulkSkipReason(t *testing.T) {
+	running := &api.VM{ID: 101, Name: "web", Status: api.VMStatusRunning}
+	stopped := &api.VM{ID: 102, Name: "db", Status: api.VMStatusStopped}
+
+	assert.Equal(t, "already running", bulkSkipReason(running, vmActionStart))
+	assert.Empty(t, bulkSkipReason(stopped, vmActionStart))
+	assert.Empty(t, bulkSkipReason(running, vmActionShutdown))
+	assert.Equal(t, "not running", bulkSkipReason(stopped, vmActionRestart))
+	assert.Equal(t, "template", bulkSkipReason(&api.VM{ID: 9000, Status: api.VMStatusStopped, Template: true}, vmActionStart))
+	assert.Equal(t, "locked (backup)", bulkSkipReason(&api.VM{ID: 103, Status: api.VMStatusRunning, Lock: "backup"}, vmActionStop))
+}
+
+func TestFormatBulkPreview(t *testing.T) {
+	preview := formatBulkPreview(vmActionShutdown, []models.BulkTarget{
+		{ID: 101, VM: &api.VM{ID: 101, Name: "web", Node: "pve1", Status: api.VMStatusRunning}},
+		{ID: 102, VM: &api.VM{ID: 102, Name: "db", Node: "pve2", Status: api.VMStatusStopped}, Skip: "not running"},
+		{ID: 103},
+	})
+
+	assert.Contains(t, preview, "Shutdown 1 of 3 guests")
+	assert.Contains(t, preview, "101  web (pve1)  running")
+	assert.Contains(t, preview, "102  db (pve2)  skip: not running")
+	assert.Contains(t, preview, "103  not found")
+}
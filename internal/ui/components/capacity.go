@@ -0,0 +1,176 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+)
+
+// capacityProbeSizes are the guest memory sizes, in GiB, the capacity report
+// can count free slots for. +/- cycles through them.
+var capacityProbeSizes = []int64{1, 2, 4, 8, 16, 32, 64}
+
+// defaultCapacityProbe is the index of the initial probe size (8 GiB).
+const defaultCapacityProbe = 3
+
+// showCapacityReport shows the allocated, used and free resources of every
+// node with the overcommit ratios of CPU, memory and disk.
+func (a *App) showCapacityReport() {
+	capacities := models.CollectCapacity(models.GlobalState.OriginalNodes, models.GlobalState.OriginalVMs)
+	if len(capacities) == 0 {
+		a.header.ShowError("No node data loaded")
+
+		return
+	}
+
+	probe := defaultCapacityProbe
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 1).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	render := func() {
+		probeSize := capacityProbeSizes[probe] << 30
+
+		table.Clear()
+		table.SetTitle(fmt.Sprintf(" Capacity (+/-: guest size %d GiB) ", capacityProbeSizes[probe]))
+
+		headers := []string{"Node", "Guests", "vCPUs", "CPU x", "Memory", "Allocated", "Mem x", "Headroom", fmt.Sprintf("Fits %dG", capacityProbeSizes[probe]), "Disk", "Provisioned", "Disk x"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+
+		for i, capacity := range capacities {
+			row := i + 1
+
+			nodeColor := theme.Colors.Primary
+			if !capacity.Online {
+				nodeColor = theme.Colors.Error
+			}
+
+			cells := []*tview.TableCell{
+				tview.NewTableCell(capacity.Node).SetTextColor(nodeColor),
+				capacityCell(fmt.Sprintf("%d/%d", capacity.RunningGuests, capacity.Guests)),
+				capacityCell(fmt.Sprintf("%d/%d of %d", capacity.RunningCPUs, capacity.AllocatedCPUs, capacity.CPUs)),
+				overcommitCell(capacity.CPUOvercommit()),
+				capacityCell(fmt.Sprintf("%s/%s", utils.FormatBytes(capacity.MemoryUsed), utils.FormatBytes(capacity.MemoryTotal))),
+				capacityCell(utils.FormatBytes(capacity.AllocatedMemory)),
+				overcommitCell(capacity.MemoryOvercommit()),
+				capacityCell(utils.FormatBytes(capacity.MemoryHeadroom())),
+				capacityCell(fmt.Sprintf("%d", capacity.GuestsFitting(probeSize))),
+				capacityCell(fmt.Sprintf("%s/%s", utils.FormatBytes(capacity.DiskUsed), utils.FormatBytes(capacity.DiskTotal))),
+				capacityCell(utils.FormatBytes(capacity.ProvisionedDisk)),
+				overcommitCell(capacity.DiskOvercommit()),
+			}
+
+			for col, cell := range cells {
+				table.SetCell(row, col, cell.SetExpansion(1))
+			}
+		}
+	}
+
+	render()
+	table.Select(1, 0)
+
+	closeReport := func() {
+		a.removePageIfPresent("capacity")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetSelectedFunc(func(row, _ int) {
+		if row < 1 || row > len(capacities) {
+			return
+		}
+
+		closeReport()
+		a.selectNodeByName(capacities[row-1].Node)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeReport()
+
+			return nil
+		}
+
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case '+':
+				if probe < len(capacityProbeSizes)-1 {
+					probe++
+					render()
+				}
+
+				return nil
+			case '-':
+				if probe > 0 {
+					probe--
+					render()
+				}
+
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	height := len(capacities) + 3
+	if height > 22 {
+		height = 22
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, height, 0, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("capacity")
+	a.pages.AddPage("capacity", modal, true, true)
+	a.SetFocus(table)
+}
+
+// capacityCell renders a plain figure of the capacity report.
+func capacityCell(text string) *tview.TableCell {
+	return tview.NewTableCell(text).SetTextColor(theme.Colors.Secondary)
+}
+
+// overcommitCell renders an overcommit ratio, highlighting ratios above 1.
+func overcommitCell(ratio float64) *tview.TableCell {
+	if ratio == 0 {
+		return tview.NewTableCell("-").SetTextColor(theme.Colors.Secondary)
+	}
+
+	color := theme.Colors.UsageLow
+
+	switch {
+	case ratio > 2:
+		color = theme.Colors.UsageCritical
+	case ratio > 1:
+		color = theme.Colors.UsageHigh
+	case ratio > 0.75:
+		color = theme.Colors.UsageMedium
+	}
+
+	return tview.NewTableCell(fmt.Sprintf("%.2f", ratio)).SetTextColor(color)
+}
@@ -0,0 +1,84 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// yankSelection copies the value selected in the focused list or details
+// panel to the system clipboard.
+func (a *App) yankSelection() {
+	label, value := a.selectedYankValue()
+	if value == "" || value == api.StringNA {
+		a.header.ShowWarning("Nothing to copy")
+
+		return
+	}
+
+	a.copyToClipboard(label, value)
+}
+
+// selectedYankValue returns a label and the value to copy for the focused component.
+func (a *App) selectedYankValue() (string, string) {
+	switch a.GetFocus() {
+	case a.nodeList:
+		if node := a.nodeList.GetSelectedNode(); node != nil {
+			return "node name", node.Name
+		}
+	case a.vmList:
+		if vm := a.vmList.GetSelectedVM(); vm != nil {
+			return "VMID", strconv.Itoa(vm.ID)
+		}
+	case a.tasksList:
+		if task := a.tasksList.GetSelectedTask(); task != nil {
+			return "UPID", task.UPID
+		}
+	case a.nodeDetails:
+		return a.nodeDetails.SelectedValue()
+	case a.vmDetails:
+		return a.vmDetails.SelectedValue()
+	}
+
+	return "", ""
+}
+
+// copyToClipboard posts the value to the system clipboard using the terminal's
+// OSC 52 support and confirms it in the header.
+func (a *App) copyToClipboard(label, value string) {
+	if a.screen == nil {
+		a.header.ShowError("Clipboard is not available")
+
+		return
+	}
+
+	a.screen.SetClipboard([]byte(value))
+
+	a.header.ShowSuccess(fmt.Sprintf("Copied %s: %s", label, value))
+}
+
+// tableRowValue returns the label and value of the selected row of a
+// two-column details table. A string reference on the value cell takes
+// precedence over the displayed text.
+func tableRowValue(table *tview.Table) (string, string) {
+	row, _ := table.GetSelection()
+
+	labelCell := table.GetCell(row, 0)
+	valueCell := table.GetCell(row, 1)
+
+	label := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(utils.StripColorTags(labelCell.Text)), "•▼▶"))
+	if label == "" {
+		label = "value"
+	}
+
+	if ref, ok := valueCell.GetReference().(string); ok && ref != "" {
+		return label, ref
+	}
+
+	return label, strings.TrimSpace(utils.StripColorTags(valueCell.Text))
+}
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+)
+
+// clockCheckInterval is how often refreshes read the clocks of the nodes
+// again.
+const clockCheckInterval = 5 * time.Minute
+
+// loadNodeClocks reads the time of every online node unless that was done
+// within clockCheckInterval, and flags nodes whose clock is out of sync with
+// the cluster in the summary panel. After the first check, they are also
+// reported in the header.
+func (a *App) loadNodeClocks() {
+	checkedAt := models.NodeClocksCheckedAt()
+	if a.clocksLoading || a.config.Clock.MaxSkew() <= 0 || (!checkedAt.IsZero() && time.Since(checkedAt) < clockCheckInterval) {
+		return
+	}
+
+	var nodes []string
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node != nil && node.Online {
+			nodes = append(nodes, node.Name)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	a.clocksLoading = true
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		times, err := client.GetNodeTimes(nodes)
+
+		a.QueueUpdateDraw(func() {
+			a.clocksLoading = false
+
+			if err != nil {
+				models.GetUILogger().Error("Failed to read node clocks: %v", err)
+
+				return
+			}
+
+			// Results of a client replaced by a profile switch are stale
+			if client != a.client {
+				return
+			}
+
+			offsets := make(map[string]time.Duration, len(times))
+			for node, nodeTime := range times {
+				offsets[node] = nodeTime.Offset
+			}
+
+			models.SetNodeClocks(offsets, time.Now())
+			a.updateClockStatus()
+
+			if alerts := models.CollectClockAlerts(a.config.Clock.MaxSkew()); len(alerts) > 0 && checkedAt.IsZero() {
+				a.header.ShowWarning(alerts[0].Message)
+			}
+		})
+	}()
+}
+
+// updateClockStatus shows the result of the last clock check in the summary
+// panel, or hides it while the check is disabled or pending.
+func (a *App) updateClockStatus() {
+	maxSkew := a.config.Clock.MaxSkew()
+	checked := maxSkew > 0 && !models.NodeClocksCheckedAt().IsZero()
+
+	a.clusterStatus.SetClocks(checked, models.SkewedClocks(maxSkew))
+}
@@ -7,18 +7,43 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/internal/ui/utils"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
-// ClusterStatus encapsulates the cluster status panel.
+// Heights of the summary panel in its full and compact forms.
+const (
+	clusterStatusHeight        = 6
+	clusterStatusCompactHeight = 1
+)
+
+// maxSummaryTasks is the number of tasks listed in the full tasks summary.
+const maxSummaryTasks = 3
+
+// ClusterStatus encapsulates the summary panel above the main view. Depending
+// on its mode it shows cluster totals, the selected node, or active tasks,
+// either as two tables or as a single compact line.
 type ClusterStatus struct {
 	*tview.Flex
 
 	SummaryTable  *tview.Table
 	ResourceTable *tview.Table
+	CompactLine   *tview.TextView
 	app           *App
+
+	mode    string
+	compact bool
+	cluster *api.Cluster
+	node    *api.Node
+	tasks   []*api.ClusterTask
+
+	// clocksChecked is set once the node clocks were compared; skewedClocks
+	// are the nodes out of sync
+	clocksChecked bool
+	skewedClocks  []models.ClockSkew
 }
 
 var _ ClusterStatusComponent = (*ClusterStatus)(nil)
@@ -58,13 +83,12 @@ func NewClusterStatus() *ClusterStatus {
 	resourceTable.SetTitleAlign(tview.AlignLeft)
 
 	// Resource table headers
-	resourceHeaders := []string{"Resource", "Used", "Total"}
-	for col, text := range resourceHeaders {
-		cell := tview.NewTableCell(text).
-			SetTextColor(theme.Colors.HeaderText).
-			SetAlign(tview.AlignLeft)
-		resourceTable.SetCell(0, col, cell)
-	}
+	setResourceHeaders(resourceTable, "Resource", "Used", "Total")
+
+	// Single-line summary used in compact mode
+	compactLine := tview.NewTextView()
+	compactLine.SetDynamicColors(true)
+	compactLine.SetText("Loading...")
 
 	// Add both tables to panel with equal space
 	panel.AddItem(summary, 0, 1, false)
@@ -74,6 +98,8 @@ func NewClusterStatus() *ClusterStatus {
 		Flex:          panel,
 		SummaryTable:  summary,
 		ResourceTable: resourceTable,
+		CompactLine:   compactLine,
+		mode:          config.SummaryModeCluster,
 	}
 }
 
@@ -82,15 +108,148 @@ func (cs *ClusterStatus) SetApp(app *App) {
 	cs.app = app
 }
 
-// Update populates both tables with current cluster data.
+// Mode returns the current summary mode.
+func (cs *ClusterStatus) Mode() string {
+	return cs.mode
+}
+
+// IsCompact reports whether the summary is rendered as a single line.
+func (cs *ClusterStatus) IsCompact() bool {
+	return cs.compact
+}
+
+// SetMode changes what the panel shows and redraws it.
+func (cs *ClusterStatus) SetMode(mode string) {
+	cs.mode = mode
+	cs.rebuild()
+}
+
+// SetCompact switches between the full and the single-line summary.
+func (cs *ClusterStatus) SetCompact(compact bool) {
+	cs.compact = compact
+	cs.rebuild()
+}
+
+// Height returns the number of rows the panel needs in its current form.
+func (cs *ClusterStatus) Height() int {
+	switch {
+	case cs.mode == config.SummaryModeNone:
+		return 0
+	case cs.compact:
+		return clusterStatusCompactHeight
+	default:
+		return clusterStatusHeight
+	}
+}
+
+// SetSelectedNode updates the node shown in node mode.
+func (cs *ClusterStatus) SetSelectedNode(node *api.Node) {
+	cs.node = node
+	if cs.mode == config.SummaryModeNode {
+		cs.render()
+	}
+}
+
+// SetTasks updates the tasks shown in tasks mode.
+func (cs *ClusterStatus) SetTasks(tasks []*api.ClusterTask) {
+	cs.tasks = tasks
+	if cs.mode == config.SummaryModeTasks {
+		cs.render()
+	}
+}
+
+// SetClocks updates the clock check shown in cluster mode. The field is
+// hidden until the clocks were checked.
+func (cs *ClusterStatus) SetClocks(checked bool, skewed []models.ClockSkew) {
+	cs.clocksChecked = checked
+	cs.skewedClocks = skewed
+
+	if cs.mode == config.SummaryModeCluster {
+		cs.render()
+	}
+}
+
+// Update stores the latest cluster data and redraws the panel.
 func (cs *ClusterStatus) Update(cluster *api.Cluster) {
 	if cluster == nil {
 		return
 	}
 
-	// Update summary table
-	cs.SummaryTable.SetCell(0, 0, tview.NewTableCell("Cluster Name").SetTextColor(theme.Colors.HeaderText))
-	cs.SummaryTable.SetCell(0, 1, tview.NewTableCell(cluster.Name).SetTextColor(theme.Colors.Primary))
+	cs.cluster = cluster
+
+	// Keep the selected node in sync with refreshed cluster data
+	if cs.node != nil {
+		for _, node := range cluster.Nodes {
+			if node != nil && node.Name == cs.node.Name {
+				cs.node = node
+
+				break
+			}
+		}
+	}
+
+	cs.render()
+}
+
+// rebuild swaps the panel contents between the tables and the compact line.
+func (cs *ClusterStatus) rebuild() {
+	cs.Flex.Clear()
+
+	if cs.compact {
+		cs.SetBorder(false)
+		cs.Flex.AddItem(cs.CompactLine, 0, 1, false)
+	} else {
+		cs.SetBorder(true)
+		cs.Flex.AddItem(cs.SummaryTable, 0, 1, false)
+		cs.Flex.AddItem(cs.ResourceTable, 0, 1, false)
+	}
+
+	cs.render()
+}
+
+// render draws the panel for the current mode.
+func (cs *ClusterStatus) render() {
+	switch cs.mode {
+	case config.SummaryModeNode:
+		cs.SetTitle(" Node Status ")
+		cs.renderNode()
+	case config.SummaryModeTasks:
+		cs.SetTitle(" Tasks ")
+		cs.renderTasks()
+	case config.SummaryModeNone:
+		// Nothing to draw; the panel is hidden
+	default:
+		cs.SetTitle(" Cluster Status ")
+		cs.renderCluster()
+	}
+}
+
+// setResourceHeaders writes the header row of the resource table.
+func setResourceHeaders(table *tview.Table, headers ...string) {
+	for col, text := range headers {
+		cell := tview.NewTableCell(text).
+			SetTextColor(theme.Colors.HeaderText).
+			SetAlign(tview.AlignLeft)
+		table.SetCell(0, col, cell)
+	}
+}
+
+// compactField formats a label/value pair for the compact summary line.
+func compactField(label, value string, color tcell.Color) string {
+	return fmt.Sprintf("[%s]%s:[-] [%s]%s[-]", theme.ColorToTag(theme.Colors.HeaderText), label, theme.ColorToTag(color), value)
+}
+
+// setCompactLine joins the given fields into the compact summary line.
+func (cs *ClusterStatus) setCompactLine(fields ...string) {
+	cs.CompactLine.SetText(" " + strings.Join(fields, "  │  "))
+}
+
+// renderCluster draws the cluster name, quorum and resource totals.
+func (cs *ClusterStatus) renderCluster() {
+	cluster := cs.cluster
+	if cluster == nil {
+		return
+	}
 
 	// Show only the version number (e.g., '8.3.5') in the 'Proxmox VE' row
 	ver := cluster.Version
@@ -98,71 +257,295 @@ func (cs *ClusterStatus) Update(cluster *api.Cluster) {
 		ver = parts[1]
 	}
 
-	cs.SummaryTable.SetCell(1, 0, tview.NewTableCell("Proxmox VE").SetTextColor(theme.Colors.HeaderText))
-	cs.SummaryTable.SetCell(1, 1, tview.NewTableCell(ver).SetTextColor(theme.Colors.Primary))
+	nodeStatusText, nodeStatusColor := clusterNodeStatus(cluster)
+	quorateText, quorateColor := clusterQuorateStatus(cluster)
+	memoryPercent := utils.CalculatePercentage(cluster.MemoryUsed, cluster.MemoryTotal)
+	storagePercent := utils.CalculatePercentageInt(cluster.StorageUsed, cluster.StorageTotal)
 
-	cs.SummaryTable.SetCell(2, 0, tview.NewTableCell("Nodes Online").SetTextColor(theme.Colors.HeaderText))
+	clocksText, clocksColor := cs.clocksStatus()
 
-	// Show different indicators based on node status with proper colors
-	var nodeStatusText string
+	if cs.compact {
+		fields := []string{
+			compactField("Cluster", cluster.Name, theme.Colors.Primary),
+			compactField("PVE", ver, theme.Colors.Primary),
+			compactField("Nodes", nodeStatusText, nodeStatusColor),
+			compactField("Quorate", quorateText, quorateColor),
+		}
 
-	var nodeStatusColor tcell.Color
+		if cs.clocksChecked {
+			fields = append(fields, compactField("Clocks", clocksText, clocksColor))
+		}
 
-	if cluster.OnlineNodes == cluster.TotalNodes {
-		// All nodes online
-		nodeStatusText = fmt.Sprintf("%d/%d 🟢", cluster.OnlineNodes, cluster.TotalNodes)
-		nodeStatusColor = theme.Colors.StatusRunning
-	} else if cluster.OnlineNodes > 0 {
-		// Some nodes offline
-		nodeStatusText = fmt.Sprintf("%d/%d ⚠️", cluster.OnlineNodes, cluster.TotalNodes)
-		nodeStatusColor = theme.Colors.Warning
-	} else {
-		// All nodes offline (critical)
-		nodeStatusText = fmt.Sprintf("%d/%d 🔴", cluster.OnlineNodes, cluster.TotalNodes)
-		nodeStatusColor = theme.Colors.StatusStopped
+		fields = append(fields,
+			compactField("CPU", fmt.Sprintf("%.1f%%", cluster.CPUUsage*100), theme.GetUsageColor(cluster.CPUUsage*100)),
+			compactField("Mem", fmt.Sprintf("%.1f%%", memoryPercent), theme.GetUsageColor(memoryPercent)),
+			compactField("Storage", fmt.Sprintf("%.1f%%", storagePercent), theme.GetUsageColor(storagePercent)),
+		)
+
+		cs.setCompactLine(fields...)
+
+		return
 	}
 
+	cs.SummaryTable.Clear()
+	cs.ResourceTable.Clear()
+	setResourceHeaders(cs.ResourceTable, "Resource", "Used", "Total")
+
+	// Update summary table
+	cs.SummaryTable.SetCell(0, 0, tview.NewTableCell("Cluster Name").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(0, 1, tview.NewTableCell(cluster.Name).SetTextColor(theme.Colors.Primary))
+
+	cs.SummaryTable.SetCell(1, 0, tview.NewTableCell("Proxmox VE").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(1, 1, tview.NewTableCell(ver).SetTextColor(theme.Colors.Primary))
+
+	cs.SummaryTable.SetCell(2, 0, tview.NewTableCell("Nodes Online").SetTextColor(theme.Colors.HeaderText))
 	cs.SummaryTable.SetCell(2, 1, tview.NewTableCell(nodeStatusText).SetTextColor(nodeStatusColor))
 
 	// Quorate status
 	cs.SummaryTable.SetCell(3, 0, tview.NewTableCell("Quorate").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(3, 1, tview.NewTableCell(quorateText).SetTextColor(quorateColor))
 
-	var quorateText string
-
-	var quorateColor tcell.Color
-
-	if cluster.Quorate {
-		quorateText = "Yes 🟢"
-		quorateColor = theme.Colors.StatusRunning
-	} else {
-		quorateText = "No  🔴"
-		quorateColor = theme.Colors.StatusStopped
+	if cs.clocksChecked {
+		cs.SummaryTable.SetCell(3, 2, tview.NewTableCell("  Clocks").SetTextColor(theme.Colors.HeaderText))
+		cs.SummaryTable.SetCell(3, 3, tview.NewTableCell(clocksText).SetTextColor(clocksColor))
 	}
 
-	cs.SummaryTable.SetCell(3, 1, tview.NewTableCell(quorateText).SetTextColor(quorateColor))
-
-	// Update resource table (headers are already set in NewClusterStatus)
 	// CPU row
 	cpuUsageColor := theme.GetUsageColor(cluster.CPUUsage * 100)
 	cs.ResourceTable.SetCell(1, 0, tview.NewTableCell("CPU Cores").SetTextColor(theme.Colors.Info).SetAlign(tview.AlignLeft))
-	cs.ResourceTable.SetCell(1, 1, tview.NewTableCell(fmt.Sprintf("%.1f%%", cluster.CPUUsage*100)).SetTextColor(cpuUsageColor).SetAlign(tview.AlignLeft))
+	cs.ResourceTable.SetCell(1, 1, tview.NewTableCell(usageGaugeText(cluster.CPUUsage*100, fmt.Sprintf("%.1f%%", cluster.CPUUsage*100))).SetTextColor(cpuUsageColor).SetAlign(tview.AlignLeft))
 	cs.ResourceTable.SetCell(1, 2, tview.NewTableCell(fmt.Sprintf("%.1f", cluster.TotalCPU)).SetTextColor(theme.Colors.Primary).SetAlign(tview.AlignLeft))
 
 	// Memory row
 	memoryUsed := utils.FormatBytesFloat(cluster.MemoryUsed)
 	memoryTotal := utils.FormatBytesFloat(cluster.MemoryTotal)
-	memoryPercent := utils.CalculatePercentage(cluster.MemoryUsed, cluster.MemoryTotal)
 	memoryUsageColor := theme.GetUsageColor(memoryPercent)
 	cs.ResourceTable.SetCell(2, 0, tview.NewTableCell("Memory").SetTextColor(theme.Colors.Info).SetAlign(tview.AlignLeft))
-	cs.ResourceTable.SetCell(2, 1, tview.NewTableCell(fmt.Sprintf("%.2f%% (%s)", memoryPercent, memoryUsed)).SetTextColor(memoryUsageColor).SetAlign(tview.AlignLeft))
+	cs.ResourceTable.SetCell(2, 1, tview.NewTableCell(usageGaugeText(memoryPercent, fmt.Sprintf("%.2f%% (%s)", memoryPercent, memoryUsed))).SetTextColor(memoryUsageColor).SetAlign(tview.AlignLeft))
 	cs.ResourceTable.SetCell(2, 2, tview.NewTableCell(memoryTotal).SetTextColor(theme.Colors.Primary).SetAlign(tview.AlignLeft))
 
 	// Storage row
 	storageUsed := utils.FormatBytes(cluster.StorageUsed)
 	storageTotal := utils.FormatBytes(cluster.StorageTotal)
-	storagePercent := utils.CalculatePercentageInt(cluster.StorageUsed, cluster.StorageTotal)
 	storageUsageColor := theme.GetUsageColor(storagePercent)
 	cs.ResourceTable.SetCell(3, 0, tview.NewTableCell("Storage").SetTextColor(theme.Colors.Info).SetAlign(tview.AlignLeft))
-	cs.ResourceTable.SetCell(3, 1, tview.NewTableCell(fmt.Sprintf("%.2f%% (%s)", storagePercent, storageUsed)).SetTextColor(storageUsageColor).SetAlign(tview.AlignLeft))
+	cs.ResourceTable.SetCell(3, 1, tview.NewTableCell(usageGaugeText(storagePercent, fmt.Sprintf("%.2f%% (%s)", storagePercent, storageUsed))).SetTextColor(storageUsageColor).SetAlign(tview.AlignLeft))
 	cs.ResourceTable.SetCell(3, 2, tview.NewTableCell(storageTotal).SetTextColor(theme.Colors.Primary).SetAlign(tview.AlignLeft))
 }
+
+// clusterNodeStatus returns the online node count with an indicator and color.
+func clusterNodeStatus(cluster *api.Cluster) (string, tcell.Color) {
+	switch {
+	case cluster.OnlineNodes == cluster.TotalNodes:
+		// All nodes online
+		return fmt.Sprintf("%d/%d %s", cluster.OnlineNodes, cluster.TotalNodes, theme.Icon("🟢", "OK")), theme.Colors.StatusRunning
+	case cluster.OnlineNodes > 0:
+		// Some nodes offline
+		return fmt.Sprintf("%d/%d %s", cluster.OnlineNodes, cluster.TotalNodes, theme.Icon("⚠️", "WARN")), theme.Colors.Warning
+	default:
+		// All nodes offline (critical)
+		return fmt.Sprintf("%d/%d %s", cluster.OnlineNodes, cluster.TotalNodes, theme.Icon("🔴", "DOWN")), theme.Colors.StatusStopped
+	}
+}
+
+// clusterQuorateStatus returns the quorum state with an indicator and color.
+func clusterQuorateStatus(cluster *api.Cluster) (string, tcell.Color) {
+	if cluster.StatusForbidden {
+		return insufficientPrivileges, theme.Colors.Warning
+	}
+
+	if cluster.Quorate {
+		return "Yes " + theme.Icon("🟢", ""), theme.Colors.StatusRunning
+	}
+
+	return "No  " + theme.Icon("🔴", ""), theme.Colors.StatusStopped
+}
+
+// clocksStatus returns the clock check result with an indicator and color:
+// the most skewed node and how many more are out of sync.
+func (cs *ClusterStatus) clocksStatus() (string, tcell.Color) {
+	if len(cs.skewedClocks) == 0 {
+		return "In sync " + theme.Icon("🟢", ""), theme.Colors.StatusRunning
+	}
+
+	worst := cs.skewedClocks[0]
+
+	text := fmt.Sprintf("%s %s", worst.Node, models.FormatSkew(worst.Skew))
+	if more := len(cs.skewedClocks) - 1; more > 0 {
+		text += fmt.Sprintf(" +%d", more)
+	}
+
+	return text + " " + theme.Icon("⚠️", "WARN"), theme.Colors.Warning
+}
+
+// renderNode draws the stats of the node selected in the node list.
+func (cs *ClusterStatus) renderNode() {
+	node := cs.node
+	if node == nil {
+		if cs.compact {
+			cs.setCompactLine(compactField("Node", "No node selected", theme.Colors.Secondary))
+		} else {
+			cs.SummaryTable.Clear()
+			cs.ResourceTable.Clear()
+			cs.SummaryTable.SetCell(0, 0, tview.NewTableCell("No node selected").SetTextColor(theme.Colors.Secondary))
+		}
+
+		return
+	}
+
+	statusText, statusColor := "Offline "+theme.Icon("🔴", ""), theme.Colors.StatusStopped
+	if node.Online {
+		statusText, statusColor = "Online "+theme.Icon("🟢", ""), theme.Colors.StatusRunning
+	}
+
+	uptime := api.StringNA
+	if node.Uptime > 0 {
+		uptime = utils.FormatUptime(int(node.Uptime))
+	}
+
+	version := node.Version
+	if version == "" {
+		version = api.StringNA
+	}
+
+	cpuPercent := node.CPUUsage * 100
+	memoryPercent := utils.CalculatePercentage(node.MemoryUsed, node.MemoryTotal)
+	storagePercent := utils.CalculatePercentageInt(node.UsedStorage, node.TotalStorage)
+
+	if cs.compact {
+		cs.setCompactLine(
+			compactField("Node", node.Name, theme.Colors.Primary),
+			compactField("Status", statusText, statusColor),
+			compactField("Uptime", uptime, theme.Colors.Primary),
+			compactField("CPU", fmt.Sprintf("%.1f%%", cpuPercent), theme.GetUsageColor(cpuPercent)),
+			compactField("Mem", fmt.Sprintf("%.1f%%", memoryPercent), theme.GetUsageColor(memoryPercent)),
+			compactField("Disk", fmt.Sprintf("%.1f%%", storagePercent), theme.GetUsageColor(storagePercent)),
+		)
+
+		return
+	}
+
+	cs.SummaryTable.Clear()
+	cs.ResourceTable.Clear()
+	setResourceHeaders(cs.ResourceTable, "Resource", "Used", "Total")
+
+	summaryRows := []struct {
+		label, value string
+		color        tcell.Color
+	}{
+		{"Node", node.Name, theme.Colors.Primary},
+		{"Proxmox VE", version, theme.Colors.Primary},
+		{"Status", statusText, statusColor},
+		{"Uptime", uptime, theme.Colors.Primary},
+	}
+
+	for row, item := range summaryRows {
+		cs.SummaryTable.SetCell(row, 0, tview.NewTableCell(item.label).SetTextColor(theme.Colors.HeaderText))
+		cs.SummaryTable.SetCell(row, 1, tview.NewTableCell(item.value).SetTextColor(item.color))
+	}
+
+	resourceRows := []struct {
+		label, used, total string
+		percent            float64
+	}{
+		{"CPU Cores", fmt.Sprintf("%.1f%%", cpuPercent), fmt.Sprintf("%.0f", node.CPUCount), cpuPercent},
+		{"Memory", fmt.Sprintf("%.2f%% (%s)", memoryPercent, utils.FormatBytesFloat(node.MemoryUsed)), utils.FormatBytesFloat(node.MemoryTotal), memoryPercent},
+		{"Root Disk", fmt.Sprintf("%.2f%% (%s)", storagePercent, utils.FormatBytes(node.UsedStorage)), utils.FormatBytes(node.TotalStorage), storagePercent},
+	}
+
+	for i, item := range resourceRows {
+		row := i + 1
+		cs.ResourceTable.SetCell(row, 0, tview.NewTableCell(item.label).SetTextColor(theme.Colors.Info).SetAlign(tview.AlignLeft))
+		cs.ResourceTable.SetCell(row, 1, tview.NewTableCell(usageGaugeText(item.percent, item.used)).SetTextColor(theme.GetUsageColor(item.percent)).SetAlign(tview.AlignLeft))
+		cs.ResourceTable.SetCell(row, 2, tview.NewTableCell(item.total).SetTextColor(theme.Colors.Primary).SetAlign(tview.AlignLeft))
+	}
+}
+
+// renderTasks draws the number of running and failed tasks and lists the most recent ones.
+func (cs *ClusterStatus) renderTasks() {
+	var running, failed []*api.ClusterTask
+
+	for _, task := range cs.tasks {
+		if task == nil {
+			continue
+		}
+
+		switch {
+		case task.EndTime == 0:
+			running = append(running, task)
+		case task.Status != "" && task.Status != api.TaskExitStatusOK:
+			failed = append(failed, task)
+		}
+	}
+
+	runningColor := theme.Colors.Secondary
+	if len(running) > 0 {
+		runningColor = theme.Colors.StatusPending
+	}
+
+	failedColor := theme.Colors.Secondary
+	if len(failed) > 0 {
+		failedColor = theme.Colors.Error
+	}
+
+	if cs.compact {
+		fields := []string{
+			compactField("Running", fmt.Sprintf("%d", len(running)), runningColor),
+			compactField("Failed", fmt.Sprintf("%d", len(failed)), failedColor),
+		}
+
+		if len(running) > 0 {
+			fields = append(fields, compactField("Latest", formatSummaryTask(running[0]), theme.Colors.Primary))
+		}
+
+		cs.setCompactLine(fields...)
+
+		return
+	}
+
+	cs.SummaryTable.Clear()
+	cs.ResourceTable.Clear()
+
+	cs.SummaryTable.SetCell(0, 0, tview.NewTableCell("Running").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(0, 1, tview.NewTableCell(fmt.Sprintf("%d", len(running))).SetTextColor(runningColor))
+	cs.SummaryTable.SetCell(1, 0, tview.NewTableCell("Failed").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(1, 1, tview.NewTableCell(fmt.Sprintf("%d", len(failed))).SetTextColor(failedColor))
+	cs.SummaryTable.SetCell(2, 0, tview.NewTableCell("Recent").SetTextColor(theme.Colors.HeaderText))
+	cs.SummaryTable.SetCell(2, 1, tview.NewTableCell(fmt.Sprintf("%d", len(cs.tasks))).SetTextColor(theme.Colors.Primary))
+
+	// Prefer running tasks, then fill with the most recent failures
+	listed := append(append([]*api.ClusterTask{}, running...), failed...)
+	if len(listed) > maxSummaryTasks {
+		listed = listed[:maxSummaryTasks]
+	}
+
+	setResourceHeaders(cs.ResourceTable, "Task", "Node", "Status")
+
+	if len(listed) == 0 {
+		cs.ResourceTable.SetCell(1, 0, tview.NewTableCell("No active tasks").SetTextColor(theme.Colors.Secondary))
+
+		return
+	}
+
+	for i, task := range listed {
+		row := i + 1
+
+		status, color := "running", theme.Colors.StatusPending
+		if task.EndTime != 0 {
+			status, color = task.Status, theme.Colors.Error
+		}
+
+		cs.ResourceTable.SetCell(row, 0, tview.NewTableCell(formatSummaryTask(task)).SetTextColor(theme.Colors.Info))
+		cs.ResourceTable.SetCell(row, 1, tview.NewTableCell(task.Node).SetTextColor(theme.Colors.Primary))
+		cs.ResourceTable.SetCell(row, 2, tview.NewTableCell(status).SetTextColor(color))
+	}
+}
+
+// formatSummaryTask returns a short "type id" description of a task.
+func formatSummaryTask(task *api.ClusterTask) string {
+	if task.ID == "" {
+		return task.Type
+	}
+
+	return fmt.Sprintf("%s %s", task.Type, task.ID)
+}
@@ -0,0 +1,317 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// configReloadDelay debounces the burst of events editors produce when saving.
+const configReloadDelay = 500 * time.Millisecond
+
+// watchConfigFile reloads the configuration whenever the config file changes
+// on disk, until the application context is cancelled.
+func (a *App) watchConfigFile() {
+	if a.configPath == "" {
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		a.logger.Error("Failed to create config watcher: %v", err)
+
+		return
+	}
+
+	// Watch the directory, as many editors replace the file instead of writing to it
+	if err := watcher.Add(filepath.Dir(a.configPath)); err != nil {
+		a.logger.Error("Failed to watch config directory: %v", err)
+		_ = watcher.Close()
+
+		return
+	}
+
+	configFile := filepath.Clean(a.configPath)
+
+	go func() {
+		defer watcher.Close()
+
+		var timer *time.Timer
+
+		for {
+			select {
+			case <-a.ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != configFile || !(event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+					continue
+				}
+
+				if timer != nil {
+					timer.Stop()
+				}
+
+				timer = time.AfterFunc(configReloadDelay, a.reloadConfig)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				a.logger.Error("Config watcher error: %v", err)
+			}
+		}
+	}()
+}
+
+// reloadConfig reads the config file again and applies the settings that can
+// change at runtime. Invalid files are reported and leave the current
+// configuration in place.
+func (a *App) reloadConfig() {
+	a.logger.Debug("Reloading config file %s", a.configPath)
+
+	cfg := config.NewConfig()
+	if err := cfg.MergeWithFile(a.configPath); err != nil {
+		a.QueueUpdateDraw(func() {
+			a.header.ShowError("Config not reloaded: " + reloadErrorMessage(err))
+		})
+
+		return
+	}
+
+	cfg.SetDefaults()
+
+	if err := config.ValidateKeyBindings(cfg.KeyBindings); err != nil {
+		a.QueueUpdateDraw(func() {
+			a.header.ShowError("Config not reloaded: " + err.Error())
+		})
+
+		return
+	}
+
+	a.QueueUpdateDraw(func() {
+		a.applyReloadedConfig(cfg)
+
+		// Loading the file may print to the terminal (e.g. SOPS decryption)
+		a.Sync()
+	})
+}
+
+// applyReloadedConfig applies a reloaded configuration. Key bindings, theme,
+// layout, summary, metadata, custom actions, script sources, and SSH settings
+// take effect immediately; changed connection settings of the active profile
+// prompt for a reconnect.
+func (a *App) applyReloadedConfig(cfg *config.Config) {
+	a.config.KeyBindings = cfg.KeyBindings
+	a.footer.UpdateKeybindings(FormatFooterText(cfg.KeyBindings))
+	a.helpModal = NewHelpModal(cfg.KeyBindings)
+	a.helpModal.SetApp(a)
+
+	a.config.CompactWidth = cfg.CompactWidth
+
+	// A changed enrichment mode applies to the next connection
+	a.config.Enrichment = cfg.Enrichment
+	a.recentGuests.SetLimit(cfg.Enrichment.Recent)
+
+	if cfg.GuestLimit != a.config.GuestLimit {
+		a.config.GuestLimit = cfg.GuestLimit
+		a.vmList.SetPageSize(cfg.GuestLimit)
+		a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+	}
+
+	a.config.CustomActions = cfg.CustomActions
+	a.config.GuestServices = cfg.GuestServices
+	a.config.GuestSSH = cfg.GuestSSH
+	a.config.ScriptSources = cfg.ScriptSources
+	a.config.Plugins = cfg.Plugins
+	a.config.Hooks = cfg.Hooks
+	a.config.Notifications = cfg.Notifications
+	a.config.Backups = cfg.Backups
+	a.config.Snapshots = cfg.Snapshots
+	a.config.Clock = cfg.Clock
+	a.updateClockStatus()
+	a.config.Costs = cfg.Costs
+	a.config.Placement = cfg.Placement
+	a.config.Lock = cfg.Lock
+	a.config.AffinityRules = cfg.AffinityRules
+	a.config.GuestGroups = cfg.GuestGroups
+	a.loadPlugins()
+	a.config.Sensors = cfg.Sensors
+	a.config.MetricsHistory = cfg.MetricsHistory
+	models.SetMetricsCapacity(cfg.MetricsHistory)
+
+	if cfg.Sparklines != a.config.Sparklines {
+		a.config.Sparklines = cfg.Sparklines
+		a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+	}
+
+	if err := models.SetMetadataPatterns(cfg.Metadata.EffectivePatterns()); err != nil {
+		a.header.ShowError("Metadata patterns not reloaded: " + err.Error())
+	} else {
+		a.config.Metadata = cfg.Metadata
+		a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+	}
+
+	if cfg.Summary != a.config.Summary {
+		a.config.Summary = cfg.Summary
+		a.clusterStatus.SetMode(cfg.Summary.Mode)
+		a.clusterStatus.SetCompact(cfg.Summary.Compact)
+		a.resizeSummaryPanel()
+	}
+
+	themeChanged := cfg.Theme.Name != a.config.Theme.Name || !maps.Equal(cfg.Theme.Colors, a.config.Theme.Colors) ||
+		cfg.Accessible != a.config.Accessible
+	if themeChanged {
+		a.config.Theme = cfg.Theme
+		a.config.Accessible = cfg.Accessible
+		a.applyTheme()
+	}
+
+	profileName := a.config.GetActiveProfile()
+	oldProfile, hadProfile := a.config.Profiles[profileName]
+	newProfile, hasProfile := cfg.Profiles[profileName]
+
+	// Keep using the current profile if default_profile changed
+	a.config.ActiveProfile = profileName
+	a.config.Profiles = cfg.Profiles
+	a.config.DefaultProfile = cfg.DefaultProfile
+
+	if profileName != "" && !hasProfile {
+		a.header.ShowWarning("Config reloaded; profile '" + profileName + "' was removed, keeping the current connection")
+
+		return
+	}
+
+	if hasProfile {
+		a.config.SSHUser = newProfile.SSHUser
+		a.config.SSHPort = newProfile.SSHPort
+		a.config.SSHKeyFile = newProfile.SSHKeyFile
+		a.config.NodeAddresses = newProfile.NodeAddresses
+	}
+
+	a.config.SSHMultiplex = cfg.SSHMultiplex
+	ssh.SetNodeOptions(nodeSSHOptions(&a.config))
+
+	if hadProfile && hasProfile && connectionChanged(oldProfile, newProfile) {
+		a.promptReconnect(profileName)
+
+		return
+	}
+
+	if themeChanged {
+		// Lists and details pick up the new colors when they are redrawn
+		a.manualRefresh()
+
+		return
+	}
+
+	a.header.ShowSuccess("Configuration reloaded")
+}
+
+// promptReconnect asks whether to reconnect after the connection settings of
+// the active profile changed.
+func (a *App) promptReconnect(profileName string) {
+	confirm := CreateConfirmDialog("Connection Settings Changed",
+		"The connection settings of profile '"+profileName+"' changed in the config file.\n\nReconnect now?",
+		func() {
+			a.removePageIfPresent("reconnect")
+			a.SetFocus(a.pages)
+			a.applyConnectionProfile(profileName)
+		},
+		func() {
+			a.removePageIfPresent("reconnect")
+			a.SetFocus(a.pages)
+			a.header.ShowWarning("Config reloaded; still using the previous connection settings")
+		})
+
+	a.pages.AddPage("reconnect", confirm, false, true)
+	a.SetFocus(confirm)
+}
+
+// connectionChanged reports whether two profiles differ in the settings used
+// to connect to the Proxmox API.
+func connectionChanged(old, updated config.ProfileConfig) bool {
+	return old.Addr != updated.Addr ||
+		old.User != updated.User ||
+		old.Password != updated.Password ||
+		old.TokenID != updated.TokenID ||
+		old.TokenSecret != updated.TokenSecret ||
+		old.Realm != updated.Realm ||
+		old.ApiPath != updated.ApiPath ||
+		old.Insecure != updated.Insecure
+}
+
+// themedBox is implemented by all primitives embedding a tview.Box.
+type themedBox interface {
+	SetBorderColor(color tcell.Color) *tview.Box
+	SetTitleColor(color tcell.Color) *tview.Box
+	SetBackgroundColor(color tcell.Color) *tview.Box
+}
+
+// applyTheme activates the configured theme and recolors the main components.
+func (a *App) applyTheme() {
+	theme.SetAccessible(a.config.Accessible)
+	theme.ApplyCustomTheme(&a.config.Theme)
+	theme.ApplyToTview()
+
+	panels := []tview.Primitive{a.nodeList, a.vmList, a.nodeDetails, a.vmDetails, a.tasksList, a.clusterStatus}
+	for _, panel := range panels {
+		if box, ok := panel.(themedBox); ok {
+			box.SetBorderColor(theme.Colors.Border)
+			box.SetTitleColor(theme.Colors.Title)
+			box.SetBackgroundColor(theme.Colors.Background)
+		}
+
+		if list, ok := panel.(interface {
+			SetSelectedStyle(style tcell.Style) *tview.List
+		}); ok {
+			list.SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary))
+		}
+	}
+
+	if box, ok := a.header.(themedBox); ok {
+		box.SetBackgroundColor(theme.Colors.Header)
+	}
+
+	if box, ok := a.footer.(themedBox); ok {
+		box.SetBackgroundColor(theme.Colors.Footer)
+	}
+
+	a.footer.UpdateKeybindings(FormatFooterText(a.config.KeyBindings))
+}
+
+// reloadErrorMessage returns a one-line description of a config loading error.
+// For schema errors it names the first problem and how many more there are.
+func reloadErrorMessage(err error) string {
+	var schemaErrs config.SchemaErrors
+	if errors.As(err, &schemaErrs) && len(schemaErrs) > 0 {
+		if len(schemaErrs) == 1 {
+			return schemaErrs[0].Error()
+		}
+
+		return fmt.Sprintf("%s (and %d more)", schemaErrs[0].Error(), len(schemaErrs)-1)
+	}
+
+	line, _, _ := strings.Cut(err.Error(), "\n")
+
+	return line
+}
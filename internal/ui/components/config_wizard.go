@@ -32,6 +32,10 @@ type CleanConfig struct {
 	ApiPath     string `yaml:"api_path,omitempty"`
 	Insecure    bool   `yaml:"insecure,omitempty"`
 	SSHUser     string `yaml:"ssh_user,omitempty"`
+	SSHPort     int    `yaml:"ssh_port,omitempty"`
+	SSHKeyFile  string `yaml:"ssh_key_file,omitempty"`
+
+	NodeAddresses map[string]string `yaml:"node_addresses,omitempty"`
 }
 
 func configToYAML(cfg *config.Config) ([]byte, error) {
@@ -58,6 +62,9 @@ func configToYAML(cfg *config.Config) ([]byte, error) {
 		cleanConfig.ApiPath = cfg.ApiPath
 		cleanConfig.Insecure = cfg.Insecure
 		cleanConfig.SSHUser = cfg.SSHUser
+		cleanConfig.SSHPort = cfg.SSHPort
+		cleanConfig.SSHKeyFile = cfg.SSHKeyFile
+		cleanConfig.NodeAddresses = cfg.NodeAddresses
 	}
 	// Note: When profiles are used, legacy fields are completely omitted
 
@@ -155,7 +162,8 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 	})
 
 	// Determine which data to use for form fields
-	var addr, user, password, tokenID, tokenSecret, realm, apiPath, sshUser string
+	var addr, user, password, tokenID, tokenSecret, realm, apiPath, sshUser, sshKeyFile string
+	var sshPort int
 	var insecure bool
 
 	// If we have profiles and a default profile, use profile data
@@ -170,6 +178,8 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 			apiPath = profile.ApiPath
 			insecure = profile.Insecure
 			sshUser = profile.SSHUser
+			sshPort = profile.SSHPort
+			sshKeyFile = profile.SSHKeyFile
 		}
 	} else {
 		// Use legacy fields
@@ -182,6 +192,8 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 		apiPath = cfg.ApiPath
 		insecure = cfg.Insecure
 		sshUser = cfg.SSHUser
+		sshPort = cfg.SSHPort
+		sshKeyFile = cfg.SSHKeyFile
 	}
 
 	form.AddInputField("Proxmox API URL", addr, 40, nil, func(text string) {
@@ -266,19 +278,34 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 			cfg.Insecure = checked
 		}
 	})
-	form.AddInputField("SSH Username", sshUser, 20, nil, func(text string) {
+	sshSettings := &wizardSSHSettings{User: sshUser, Port: sshPort, KeyFile: sshKeyFile}
+	sshForm := newSSHSettingsPage(app, pages, form, sshSettings, func() string {
+		if len(cfg.Profiles) > 0 && cfg.DefaultProfile != "" {
+			return hostFromAPIURL(cfg.Profiles[cfg.DefaultProfile].Addr)
+		}
+
+		return hostFromAPIURL(cfg.Addr)
+	}, func() {
 		if len(cfg.Profiles) > 0 && cfg.DefaultProfile != "" {
 			if profile, exists := cfg.Profiles[cfg.DefaultProfile]; exists {
-				profile.SSHUser = strings.TrimSpace(text)
+				profile.SSHUser = sshSettings.User
+				profile.SSHPort = sshSettings.Port
+				profile.SSHKeyFile = sshSettings.KeyFile
 				cfg.Profiles[cfg.DefaultProfile] = profile
 			}
 		} else {
-			cfg.SSHUser = strings.TrimSpace(text)
+			cfg.SSHUser = sshSettings.User
+			cfg.SSHPort = sshSettings.Port
+			cfg.SSHKeyFile = sshSettings.KeyFile
 		}
 	})
 	form.AddCheckbox("Enable Debug Logging", cfg.Debug, func(checked bool) { cfg.Debug = checked })
 	form.AddInputField("Cache Directory", cfg.CacheDir, 40, nil, func(text string) { cfg.CacheDir = strings.TrimSpace(text) })
 	form.AddInputField("Theme Name", cfg.Theme.Name, 20, nil, func(text string) { cfg.Theme.Name = strings.TrimSpace(text) })
+	form.AddButton("SSH Settings", func() {
+		pages.SwitchToPage("ssh")
+		app.SetFocus(sshForm)
+	})
 	form.AddButton("Save", func() {
 		// Validate profile name
 		if profileName == "" {
@@ -288,7 +315,7 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 
 		// Check if profile already exists (for new profiles or renamed profiles)
 		if isNewProfile || profileName != cfg.DefaultProfile {
-			if cfg.Profiles != nil && cfg.Profiles[profileName] != (config.ProfileConfig{}) {
+			if _, exists := cfg.Profiles[profileName]; exists {
 				showWizardModal(pages, form, app, "error", "Profile '"+profileName+"' already exists.", nil)
 				return
 			}
@@ -356,7 +383,9 @@ func NewConfigWizardPage(app *tview.Application, cfg *config.Config, configPath
 				Realm:       strings.TrimSpace(realm),
 				ApiPath:     strings.TrimSpace(apiPath),
 				Insecure:    insecure,
-				SSHUser:     strings.TrimSpace(sshUser),
+				SSHUser:     sshSettings.User,
+				SSHPort:     sshSettings.Port,
+				SSHKeyFile:  sshSettings.KeyFile,
 			}
 
 			// Clear conflicting auth method in new profile
@@ -488,7 +517,12 @@ func SaveConfigToFile(cfg *config.Config, path string) error {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0o600)
+	if err := os.WriteFile(path, data, config.FileMode); err != nil {
+		return err
+	}
+
+	// WriteFile keeps the mode of an existing file
+	return config.RestrictPermissions(path)
 }
 
 // LaunchConfigWizard launches the configuration wizard and returns the result.
@@ -496,6 +530,7 @@ func LaunchConfigWizard(cfg *config.Config, configPath string, activeProfile str
 	tviewApp := tview.NewApplication()
 
 	// Apply theme configuration first, then apply to tview (same as main application)
+	theme.SetAccessible(cfg.Accessible)
 	theme.ApplyCustomTheme(&cfg.Theme)
 	theme.ApplyToTview()
 
@@ -0,0 +1,194 @@
+package components
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// wizardSSHSettings holds the SSH settings edited on the wizard's SSH page.
+type wizardSSHSettings struct {
+	User    string
+	Port    int
+	KeyFile string
+}
+
+// newSSHSettingsPage creates the wizard page for the SSH user, port, and
+// identity file, and adds it to pages as "ssh". Edits update settings and
+// call onChange. defaultHost provides the host used to test the connection
+// when none is entered.
+func newSSHSettingsPage(app *tview.Application, pages *tview.Pages, mainForm *tview.Form, settings *wizardSSHSettings, defaultHost func() string, onChange func()) *tview.Form {
+	form := tview.NewForm().SetHorizontal(false)
+
+	port := ""
+	if settings.Port > 0 {
+		port = strconv.Itoa(settings.Port)
+	}
+
+	testHost := ""
+
+	form.AddInputField("SSH Username", settings.User, 20, nil, func(text string) {
+		settings.User = strings.TrimSpace(text)
+		onChange()
+	})
+	form.AddInputField("SSH Port", port, 6, tview.InputFieldInteger, func(text string) {
+		settings.Port, _ = strconv.Atoi(strings.TrimSpace(text))
+		onChange()
+	})
+
+	keyField := tview.NewInputField().
+		SetLabel("Identity File").
+		SetText(settings.KeyFile).
+		SetFieldWidth(40).
+		SetPlaceholder("Default keys and agent")
+	keyField.SetChangedFunc(func(text string) {
+		settings.KeyFile = strings.TrimSpace(text)
+		onChange()
+	})
+	form.AddFormItem(keyField)
+
+	hostField := tview.NewInputField().
+		SetLabel("Test Host").
+		SetFieldWidth(40).
+		SetPlaceholder("Proxmox API host")
+	hostField.SetChangedFunc(func(text string) {
+		testHost = strings.TrimSpace(text)
+	})
+	form.AddFormItem(hostField)
+
+	showModal := func(modal *tview.Modal) {
+		pages.AddPage("sshModal", modal, false, true)
+		app.SetFocus(modal)
+	}
+
+	closeModal := func() {
+		pages.RemovePage("sshModal")
+		app.SetFocus(form)
+	}
+
+	form.AddButton("Browse Keys", func() {
+		picker := NewFilePicker(sshKeyDir(settings.KeyFile), isPrivateKeyCandidate, func(path string) {
+			pages.RemovePage("keyPicker")
+			keyField.SetText(path)
+			app.SetFocus(form)
+		}, func() {
+			pages.RemovePage("keyPicker")
+			app.SetFocus(form)
+		})
+
+		layout := tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(picker, 20, 1, true).
+				AddItem(nil, 0, 1, false), 70, 1, true).
+			AddItem(nil, 0, 1, false)
+
+		pages.AddPage("keyPicker", layout, true, true)
+		app.SetFocus(picker)
+	})
+
+	form.AddButton("Test Connection", func() {
+		host := testHost
+		if host == "" {
+			host = defaultHost()
+		}
+
+		user := settings.User
+		if user == "" {
+			showModal(CreateErrorDialog("SSH Test", "Enter an SSH username first. Node shells and script installation connect as this user.", closeModal))
+
+			return
+		}
+
+		if host == "" {
+			showModal(CreateErrorDialog("SSH Test", "Enter a test host or set the Proxmox API URL.", closeModal))
+
+			return
+		}
+
+		opts := ssh.Options{Port: settings.Port, IdentityFile: settings.KeyFile}
+
+		showModal(CreateInfoDialog("SSH Test", "Connecting to "+user+"@"+host+"...", closeModal))
+
+		go func() {
+			err := ssh.TestConnection(context.Background(), ssh.NewDefaultExecutor(), user, host, opts)
+
+			app.QueueUpdateDraw(func() {
+				pages.RemovePage("sshModal")
+
+				if err != nil {
+					showModal(CreateErrorDialog("SSH Test Failed", "SSH to "+host+" failed: "+err.Error()+
+						"\n\nShells and script installation need working key-based SSH.", closeModal))
+
+					return
+				}
+
+				showModal(CreateInfoDialog("SSH Test", "Connected to "+host+" as "+user+".", closeModal))
+			})
+		}()
+	})
+
+	back := func() {
+		pages.SwitchToPage("form")
+		app.SetFocus(mainForm)
+	}
+
+	form.AddButton("Back", back)
+
+	form.SetBorder(true).SetTitle("pvetui - SSH Settings").SetTitleColor(theme.Colors.Primary).SetBorderColor(theme.Colors.Border)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			back()
+
+			return nil
+		}
+
+		return event
+	})
+
+	pages.AddPage("ssh", form, true, false)
+
+	return form
+}
+
+// sshKeyDir returns the directory the key picker starts in.
+func sshKeyDir(keyFile string) string {
+	if keyFile != "" {
+		if dir := filepath.Dir(ssh.ExpandHome(keyFile)); dir != "" {
+			if _, err := os.Stat(dir); err == nil {
+				return dir
+			}
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+
+	if _, err := os.Stat(filepath.Join(home, ".ssh")); err == nil {
+		return filepath.Join(home, ".ssh")
+	}
+
+	return home
+}
+
+// hostFromAPIURL returns the host name of a Proxmox API URL.
+func hostFromAPIURL(addr string) string {
+	u, err := url.Parse(strings.TrimSpace(addr))
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
@@ -30,7 +30,7 @@ func (a *App) showConnectionProfilesDialog() {
 	menuItems := make([]string, len(profileNames))
 	for i, name := range profileNames {
 		if name == addNewProfileText {
-			menuItems[i] = "➕ " + name
+			menuItems[i] = theme.Icon("➕", "+") + " " + name
 		} else {
 			// Show star for default profile, and indicate if connected
 			displayName := name
@@ -39,11 +39,11 @@ func (a *App) showConnectionProfilesDialog() {
 
 			if isConnected {
 				// Connected profile gets priority - show as connected
-				displayName = "⚡ " + name
+				displayName = theme.Icon("⚡", ">") + " " + name
 			}
 			if isDefault {
 				// Default profile (when not connected) shows as default
-				displayName = displayName + " ⭐"
+				displayName = displayName + " " + theme.Icon("⭐", "(default)")
 			}
 			menuItems[i] = displayName
 		}
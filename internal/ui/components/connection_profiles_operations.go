@@ -6,7 +6,10 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/devnullvoid/pvetui/internal/adapters"
+	"github.com/devnullvoid/pvetui/internal/cache"
 	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ssh"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -32,13 +35,32 @@ func (a *App) applyConnectionProfile(profileName string) {
 
 		uiLogger.Debug("Profile %s applied successfully to config", profileName)
 
+		ssh.SetNodeOptions(nodeSSHOptions(&a.config))
+		models.ResetUptimes()
+		models.ResetBackups()
+		models.ResetNodeClocks()
+		models.ResetMetrics()
+		models.ResetSnapshots()
+		models.ResetNeighbors()
+
 		// Note: We don't save the config file when switching profiles in the UI
 		// The default_profile should only be changed via the config wizard
 		// This allows temporary profile switching without affecting the saved config
 
 		// Recreate the API client with the new profile
 		uiLogger.Debug("Creating new API client with updated config")
-		client, err := api.NewClient(&a.config, api.WithLogger(models.GetUILogger()))
+		// The cache of the new profile is kept apart from the previous one's
+		options := []api.ClientOption{api.WithLogger(models.GetUILogger()),
+			api.WithCache(adapters.NewCacheAdapterFor(cache.ForProfile(&a.config))),
+			api.WithCacheTTLs(api.CacheTTLs{Status: a.config.Cache.StatusTTL(), Config: a.config.Cache.ConfigTTL(),
+				Agent: a.config.Cache.AgentTTL()}),
+			api.WithLazyEnrichment(a.config.Enrichment.IsLazy()), api.WithTFAPrompt(a.promptTFA),
+			api.WithSchemaDriftHandler(a.notifySchemaDrift)}
+		if a.config.IsUsingOpenID() {
+			options = append(options, api.WithOpenID(a.config.GetOpenIDRedirectURL(), a.openIDAuthorizer()))
+		}
+
+		client, err := api.NewClient(&a.config, options...)
 		if err != nil {
 			uiLogger.Error("Failed to create API client for profile %s: %v", profileName, err)
 			a.QueueUpdateDraw(func() {
@@ -53,6 +75,11 @@ func (a *App) applyConnectionProfile(profileName string) {
 			uiLogger.Debug("Updating app client and VNC service")
 			a.client = client
 
+			// Alerts and announcements of the new cluster start from scratch
+			a.notifiedAlerts, a.notifiedTasks = nil, nil
+			a.announcementsPending = true
+			a.updateClockStatus()
+
 			// Update VNC service with new connection details
 			if a.vncService != nil {
 				uiLogger.Debug("Updating VNC service client")
@@ -203,6 +230,33 @@ func (a *App) setDefaultProfile(profileName string) {
 	a.header.ShowSuccess(fmt.Sprintf("Default profile changed from '%s' to '%s'.", oldDefault, profileName))
 }
 
+// saveConfig writes the config to the file it was loaded from, or to the
+// default config file, re-encrypting it if it was SOPS-encrypted.
+func (a *App) saveConfig() error {
+	configPath := a.configPath
+	if configPath == "" {
+		var found bool
+		if configPath, found = config.FindDefaultConfigPath(); !found {
+			configPath = config.GetDefaultConfigPath()
+		}
+	}
+
+	wasSOPS := false
+	if data, err := os.ReadFile(configPath); err == nil {
+		wasSOPS = config.IsSOPSEncrypted(configPath, data)
+	}
+
+	if err := SaveConfigToFile(&a.config, configPath); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if wasSOPS {
+		return a.reEncryptConfigIfNeeded(configPath)
+	}
+
+	return nil
+}
+
 // reEncryptConfigIfNeeded re-encrypts the config file with SOPS.
 func (a *App) reEncryptConfigIfNeeded(configPath string) error {
 	// Check if SOPS rule exists
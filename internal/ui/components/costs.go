@@ -0,0 +1,240 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+)
+
+// formatCost renders a cost with two decimals and the configured currency.
+func formatCost(value float64, currency string) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", value)
+	}
+
+	return fmt.Sprintf("%.2f %s", value, currency)
+}
+
+// defaultCostsExportPath suggests a dated file in the home directory.
+func defaultCostsExportPath(by models.CostGrouping) string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+
+	return filepath.Join(dir, fmt.Sprintf("pvetui-costs-by-%s-%s.csv", by, time.Now().Format("2006-01-02")))
+}
+
+// showCostReport charges the resources configured for the guests to their
+// pools or tags at the unit costs of the config.
+func (a *App) showCostReport() {
+	rates := a.config.Costs
+	if !rates.Enabled() {
+		a.showMessageSafe("No unit costs are configured.\n\nSet vcpu, memory_gb or storage_gb in the costs section of the config.")
+
+		return
+	}
+
+	if len(models.GlobalState.OriginalVMs) == 0 {
+		a.header.ShowError("No guest data loaded")
+
+		return
+	}
+
+	by := models.CostByPool
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 1).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	var rows int
+
+	render := func() {
+		groups, total := models.CollectCosts(models.GlobalState.OriginalVMs, rates, by)
+		rows = len(groups) + 2
+
+		table.Clear()
+		table.SetTitle(fmt.Sprintf(" Costs by %s (g: group by pool/tag, e: export CSV) ", by))
+
+		headers := []string{strings.ToUpper(string(by[:1])) + string(by[1:]), "Guests", "vCPUs", "Memory", "Storage", "CPU", "Memory", "Storage", "Total"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+
+		for i, group := range append(groups, total) {
+			nameColor := theme.Colors.Primary
+			if i == len(groups) {
+				nameColor = theme.Colors.HeaderText
+			}
+
+			cells := []*tview.TableCell{
+				tview.NewTableCell(group.Name).SetTextColor(nameColor),
+				capacityCell(fmt.Sprintf("%d", group.Guests)),
+				capacityCell(fmt.Sprintf("%d", group.VCPUs)),
+				capacityCell(utils.FormatBytes(group.Memory)),
+				capacityCell(utils.FormatBytes(group.Storage)),
+				capacityCell(formatCost(group.CPUCost, rates.Currency)),
+				capacityCell(formatCost(group.MemoryCost, rates.Currency)),
+				capacityCell(formatCost(group.StorageCost, rates.Currency)),
+				tview.NewTableCell(formatCost(group.Total(), rates.Currency)).SetTextColor(theme.Colors.Primary),
+			}
+
+			for col, cell := range cells {
+				table.SetCell(i+1, col, cell.SetExpansion(1))
+			}
+		}
+
+		if by == models.CostByTag {
+			table.SetCell(rows, 0, tview.NewTableCell("Guests with several tags count towards each of them").
+				SetTextColor(theme.Colors.Secondary).
+				SetSelectable(false))
+		}
+	}
+
+	render()
+	table.Select(1, 0)
+
+	closeReport := func() {
+		a.removePageIfPresent("costs")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeReport()
+
+			return nil
+		}
+
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case 'g':
+				if by == models.CostByPool {
+					by = models.CostByTag
+				} else {
+					by = models.CostByPool
+				}
+
+				render()
+				table.Select(1, 0)
+
+				return nil
+			case 'e':
+				a.showCostsExportDialog(by, table)
+
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, min(rows+3, 24), 0, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("costs")
+	a.pages.AddPage("costs", modal, true, true)
+	a.SetFocus(table)
+}
+
+// showCostsExportDialog asks for the CSV file to save the cost report to
+// and returns the focus to report afterwards.
+func (a *App) showCostsExportDialog(by models.CostGrouping, report tview.Primitive) {
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" Export Costs by %s ", by))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	form.AddInputField("File", defaultCostsExportPath(by), 50, nil, nil)
+
+	closeForm := func() {
+		a.removePageIfPresent("exportCosts")
+		a.SetFocus(report)
+	}
+
+	form.AddButton("Export", func() {
+		path := ssh.ExpandHome(strings.TrimSpace(form.GetFormItemByLabel("File").(*tview.InputField).GetText()))
+		if path == "" {
+			a.header.ShowError("Enter the file to export to")
+
+			return
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			a.header.ShowError(fmt.Sprintf("%s already exists", path))
+
+			return
+		}
+
+		closeForm()
+
+		groups, total := models.CollectCosts(models.GlobalState.OriginalVMs, a.config.Costs, by)
+
+		var data bytes.Buffer
+
+		err := models.WriteCostsCSV(&data, groups, total, by)
+		if err == nil {
+			err = os.WriteFile(path, data.Bytes(), 0o644)
+		}
+
+		if err != nil {
+			a.header.ShowError(fmt.Sprintf("Failed to export costs: %v", err))
+
+			return
+		}
+
+		a.header.ShowSuccess(fmt.Sprintf("Exported costs by %s to %s", by, path))
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 0, true).
+			AddItem(nil, 0, 1, false), 72, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("exportCosts")
+	a.pages.AddPage("exportCosts", modal, true, true)
+	a.SetFocus(form)
+}
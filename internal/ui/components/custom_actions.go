@@ -0,0 +1,71 @@
+package components
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/devnullvoid/pvetui/internal/actions"
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// runCustomAction runs a user-defined command for the given guest.
+func (a *App) runCustomAction(action config.CustomAction, vm *api.VM) {
+	commandLine, err := actions.Expand(action.Command, vm)
+	if err != nil {
+		a.showMessageSafe(fmt.Sprintf("Cannot run '%s': %v", action.Name, err))
+
+		return
+	}
+
+	models.GetUILogger().Debug("Running custom action %q for guest %d: %s", action.Name, vm.ID, commandLine)
+
+	if actions.IsBackground(action) {
+		a.runCustomActionInBackground(action, commandLine)
+
+		return
+	}
+
+	// Temporarily suspend the UI and hand the terminal to the command
+	a.Suspend(func() {
+		fmt.Printf("\nRunning '%s' for %s (%d)...\n$ %s\n\n", action.Name, vm.Name, vm.ID, commandLine)
+
+		cmd := actions.Command(a.ctx, commandLine)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err := cmd.Run()
+		utils.WaitForEnterToReturn(err, fmt.Sprintf("'%s' completed successfully", action.Name), fmt.Sprintf("'%s' failed", action.Name))
+	})
+
+	// Fix for tview suspend/resume issue - comprehensive terminal state restoration
+	a.Sync()
+}
+
+// runCustomActionInBackground runs the command detached and reports the outcome in the header.
+func (a *App) runCustomActionInBackground(action config.CustomAction, commandLine string) {
+	a.header.ShowLoading(fmt.Sprintf("Running '%s'", action.Name))
+
+	go func() {
+		output, err := actions.Command(a.ctx, commandLine).CombinedOutput()
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				models.GetUILogger().Error("Custom action %q failed: %v: %s", action.Name, err, output)
+				a.header.ShowError(fmt.Sprintf("'%s' failed: %v", action.Name, err))
+
+				return
+			}
+
+			message := fmt.Sprintf("'%s' completed", action.Name)
+			if last := actions.LastLine(output); last != "" {
+				message += ": " + last
+			}
+
+			a.header.ShowSuccess(message)
+		})
+	}()
+}
@@ -0,0 +1,301 @@
+package components
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// datacenterOptionLabels are the display names of well-known datacenter
+// options, in display order. Other options are listed after them by key.
+var datacenterOptionLabels = []struct {
+	Key   string
+	Label string
+}{
+	{"keyboard", "Keyboard Layout"},
+	{"console", "Console Viewer"},
+	{"language", "Language"},
+	{"email_from", "Email From"},
+	{"http_proxy", "HTTP Proxy"},
+	{"mac_prefix", "MAC Prefix"},
+	{"max_workers", "Max Workers"},
+	{"bwlimit", "Bandwidth Limits"},
+	{"ha", "HA Settings"},
+	{"crs", "Resource Scheduling"},
+	{"next-id", "Next Free VMID Range"},
+	{"tag-style", "Tag Style"},
+}
+
+// migrationTypes are the migration types offered by the edit form.
+var migrationTypes = []string{"secure", "insecure"}
+
+// showDatacenterOptions loads and shows the datacenter options.
+func (a *App) showDatacenterOptions() {
+	a.header.ShowLoading("Loading datacenter options...")
+
+	go func() {
+		options, err := a.client.GetClusterOptions()
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to load datacenter options: %v", err))
+
+				return
+			}
+
+			a.header.StopLoading()
+			a.showDatacenterOptionsTable(options)
+		})
+	}()
+}
+
+// showDatacenterOptionsTable shows options in a read-only table. The
+// migration settings can be edited from there.
+func (a *App) showDatacenterOptionsTable(options *api.ClusterOptions) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(" Datacenter Options ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	for i, row := range datacenterOptionRows(options) {
+		table.SetCell(i, 0, tview.NewTableCell(row[0]).SetTextColor(theme.Colors.HeaderText).SetAlign(tview.AlignRight))
+		table.SetCell(i, 1, tview.NewTableCell(row[1]).SetTextColor(theme.Colors.Primary).SetExpansion(1))
+	}
+
+	hint := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(theme.ReplaceSemanticTags("[primary]e[-] edit migration settings  [primary]r[-] reload  [primary]Esc[-] close"))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			a.closeDatacenterOptions()
+		case event.Key() == tcell.KeyRune && event.Rune() == 'e':
+			a.showMigrationSettingsForm(options, table)
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			a.removePageIfPresent("datacenterOptions")
+			a.showDatacenterOptions()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(hint, 1, 0, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 20, 0, true).
+			AddItem(nil, 0, 1, false), 76, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("datacenterOptions")
+	a.pages.AddPage("datacenterOptions", modal, true, true)
+	a.SetFocus(table)
+}
+
+// closeDatacenterOptions closes the datacenter options and restores focus.
+func (a *App) closeDatacenterOptions() {
+	a.removePageIfPresent("datacenterOptions")
+
+	if a.lastFocus != nil {
+		a.SetFocus(a.lastFocus)
+	}
+}
+
+// datacenterOptionRows returns the label/value rows shown for options.
+func datacenterOptionRows(options *api.ClusterOptions) [][2]string {
+	migration := options.Migration()
+
+	migrationType := migration.Type
+	if migrationType == "" {
+		migrationType = api.DefaultMigrationType + " (default)"
+	}
+
+	migrationNetwork := migration.Network
+	if migrationNetwork == "" {
+		migrationNetwork = "cluster network (default)"
+	}
+
+	rows := [][2]string{
+		{"Migration Type", migrationType},
+		{"Migration Network", migrationNetwork},
+		{"Migration Limit", formatBandwidthLimit(migration.BandwidthLimit)},
+	}
+
+	shown := map[string]bool{"migration": true}
+
+	for _, option := range datacenterOptionLabels {
+		shown[option.Key] = true
+
+		value := options.Get(option.Key)
+		if value == "" {
+			value = "(default)"
+		}
+
+		rows = append(rows, [2]string{option.Label, tview.Escape(value)})
+	}
+
+	var rest []string
+
+	for key := range options.Values {
+		if !shown[key] {
+			rest = append(rest, key)
+		}
+	}
+
+	sort.Strings(rest)
+
+	for _, key := range rest {
+		value := strings.ReplaceAll(options.Get(key), "\n", " ")
+		rows = append(rows, [2]string{key, tview.Escape(value)})
+	}
+
+	return rows
+}
+
+// formatBandwidthLimit formats a limit in KiB/s, 0 meaning no limit.
+func formatBandwidthLimit(kib int) string {
+	if kib <= 0 {
+		return "none"
+	}
+
+	return api.FormatBytes(int64(kib)*1024) + "/s"
+}
+
+// showMigrationSettingsForm shows a form to edit the migration type, network,
+// and bandwidth limit of the datacenter. Focus returns to returnFocus when
+// the form is closed.
+func (a *App) showMigrationSettingsForm(options *api.ClusterOptions, returnFocus tview.Primitive) {
+	current := options.Migration()
+
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(" Migration Settings ")
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	typeIndex := 0
+
+	for i, migrationType := range migrationTypes {
+		if migrationType == current.Type {
+			typeIndex = i
+		}
+	}
+
+	form.AddDropDown("Type", migrationTypes, typeIndex, nil)
+	form.AddInputField("Network (CIDR)", current.Network, 24, nil, nil)
+
+	limit := ""
+	if current.BandwidthLimit > 0 {
+		limit = strconv.Itoa(current.BandwidthLimit)
+	}
+
+	form.AddInputField("Limit (KiB/s)", limit, 12, func(_ string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}, nil)
+
+	closeForm := func() {
+		a.removePageIfPresent("datacenterOptionsEdit")
+		a.SetFocus(returnFocus)
+	}
+
+	form.AddButton("Save", func() {
+		_, migrationType := form.GetFormItemByLabel("Type").(*tview.DropDown).GetCurrentOption()
+		network := strings.TrimSpace(form.GetFormItemByLabel("Network (CIDR)").(*tview.InputField).GetText())
+		limitText := strings.TrimSpace(form.GetFormItemByLabel("Limit (KiB/s)").(*tview.InputField).GetText())
+
+		settings, err := parseMigrationSettings(migrationType, network, limitText)
+		if err != nil {
+			a.header.ShowError(err.Error())
+
+			return
+		}
+
+		// Keep the default type unset when nothing else is configured
+		if settings.Network == "" && current.Type == "" && settings.Type == api.DefaultMigrationType {
+			settings.Type = ""
+		}
+
+		closeForm()
+		a.header.ShowLoading("Saving migration settings...")
+
+		go func() {
+			err := a.client.UpdateMigrationSettings(options, settings)
+
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					a.showActionError("Failed to save migration settings", err)
+
+					return
+				}
+
+				a.header.ShowSuccess("Migration settings updated")
+				a.removePageIfPresent("datacenterOptions")
+				a.showDatacenterOptions()
+			})
+		}()
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 11, 0, true).
+			AddItem(nil, 0, 1, false), 50, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("datacenterOptionsEdit", modal, true, true)
+	a.SetFocus(form)
+}
+
+// parseMigrationSettings validates the values of the migration settings form.
+func parseMigrationSettings(migrationType, network, limit string) (api.MigrationSettings, error) {
+	settings := api.MigrationSettings{Type: migrationType, Network: network}
+
+	if network != "" {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return settings, fmt.Errorf("invalid migration network %q: expected CIDR like 10.0.0.0/24", network)
+		}
+	}
+
+	if limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 0 {
+			return settings, fmt.Errorf("invalid bandwidth limit %q", limit)
+		}
+
+		settings.BandwidthLimit = value
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,42 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestParseMigrationSettings(t *testing.T) {
+	settings, err := parseMigrationSettings("insecure", "10.10.0.0/16", "2048")
+	require.NoError(t, err)
+	assert.Equal(t, api.MigrationSettings{Type: "insecure", Network: "10.10.0.0/16", BandwidthLimit: 2048}, settings)
+
+	settings, err = parseMigrationSettings("secure", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, api.MigrationSettings{Type: "secure"}, settings)
+
+	_, err = parseMigrationSettings("secure", "10.10.0.1", "")
+	assert.Error(t, err)
+
+	_, err = parseMigrationSettings("secure", "", "fast")
+	assert.Error(t, err)
+}
+
+func TestDatacenterOptionRows(t *testing.T) {
+	options := &api.ClusterOptions{Values: map[string]string{
+		"keyboard":    "en-us",
+		"bwlimit":     "migration=1024",
+		"description": "line one\nline two",
+	}}
+
+	rows := datacenterOptionRows(options)
+
+	assert.Equal(t, [2]string{"Migration Type", "secure (default)"}, rows[0])
+	assert.Equal(t, [2]string{"Migration Network", "cluster network (default)"}, rows[1])
+	assert.Equal(t, [2]string{"Migration Limit", "1.0 MB/s"}, rows[2])
+	assert.Equal(t, [2]string{"Keyboard Layout", "en-us"}, rows[3])
+	assert.Equal(t, [2]string{"description", "line one line two"}, rows[len(rows)-1])
+}
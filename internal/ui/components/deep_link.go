@@ -0,0 +1,176 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Actions a deep link can run on its node or guest.
+const (
+	DeepLinkShell  = "shell"  // SSH shell
+	DeepLinkVNC    = "vnc"    // noVNC console of a guest or shell of a node
+	DeepLinkSerial = "serial" // Serial console of a QEMU guest
+	DeepLinkWebUI  = "web"    // Proxmox web UI in the browser
+)
+
+var (
+	guestDeepLinkActions = []string{DeepLinkShell, DeepLinkVNC, DeepLinkSerial, DeepLinkWebUI}
+	nodeDeepLinkActions  = []string{DeepLinkShell, DeepLinkVNC, DeepLinkWebUI}
+)
+
+// DeepLink names a guest or node to open on startup and the action to run
+// on it, as given with --vm, --node and --action.
+type DeepLink struct {
+	VMID   int
+	Node   string
+	Action string
+}
+
+// IsZero reports whether the link opens nothing.
+func (l DeepLink) IsZero() bool {
+	return l == DeepLink{}
+}
+
+// Validate checks that the link names one guest or node and, if set, an
+// action available for it.
+func (l DeepLink) Validate() error {
+	switch {
+	case l.VMID < 0:
+		return fmt.Errorf("invalid VMID %d", l.VMID)
+	case l.VMID != 0 && l.Node != "":
+		return errors.New("--vm and --node cannot be combined")
+	case l.Action == "":
+		return nil
+	case l.VMID == 0 && l.Node == "":
+		return errors.New("--action needs --vm or --node")
+	}
+
+	actions := guestDeepLinkActions
+	if l.Node != "" {
+		actions = nodeDeepLinkActions
+	}
+
+	if !slices.Contains(actions, l.Action) {
+		return fmt.Errorf("invalid action '%s': must be one of %s", l.Action, strings.Join(actions, ", "))
+	}
+
+	return nil
+}
+
+// OpenOnLoad opens the guest or node of link once it is loaded and runs the
+// link's action on it. Call it before Run.
+func (a *App) OpenOnLoad(link DeepLink) {
+	a.startLink = link
+
+	if link.Node != "" {
+		// Nodes are loaded before the interface starts
+		a.QueueUpdateDraw(a.openStartNode)
+	}
+}
+
+// openStartNode selects the node of the startup link and runs its action.
+func (a *App) openStartNode() {
+	link := a.startLink
+	a.startLink = DeepLink{}
+
+	a.selectNodeByName(link.Node)
+
+	node := a.nodeList.GetSelectedNode()
+	if node == nil || node.Name != link.Node {
+		a.showMessageSafe(fmt.Sprintf("Node %s was not found in the cluster", link.Node))
+
+		return
+	}
+
+	a.focusStartPanel(a.nodeList, a.nodeDetails)
+
+	switch link.Action {
+	case DeepLinkShell:
+		a.openNodeShell()
+	case DeepLinkVNC:
+		a.openNodeVNC()
+	case DeepLinkWebUI:
+		a.openNodeWebUI()
+	}
+}
+
+// openStartGuest selects the guest of the startup link after the guests
+// were loaded and runs its action, at most once.
+func (a *App) openStartGuest() {
+	link := a.startLink
+	if link.VMID == 0 {
+		return
+	}
+
+	a.startLink = DeepLink{}
+
+	var target *api.VM
+
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil && vm.ID == link.VMID {
+			target = vm
+
+			break
+		}
+	}
+
+	if target == nil {
+		// A modal, as the header is taken by the loading progress
+		a.showMessageSafe(fmt.Sprintf("Guest %d was not found in the cluster", link.VMID))
+
+		return
+	}
+
+	a.selectGuest(target)
+	a.focusStartPanel(a.vmList, a.vmDetails)
+
+	switch link.Action {
+	case DeepLinkShell:
+		a.openStartGuestShell(target)
+	case DeepLinkVNC:
+		a.openVMVNC()
+	case DeepLinkSerial:
+		if target.Type != api.VMTypeQemu || target.Status != api.VMStatusRunning {
+			a.showMessageSafe(fmt.Sprintf("The serial console needs a running QEMU VM; %s is not", target.Name))
+
+			return
+		}
+
+		a.openSerialConsole(target)
+	case DeepLinkWebUI:
+		a.openVMWebUI()
+	}
+}
+
+// openStartGuestShell opens a shell to the guest of the startup link. The
+// IPs of QEMU guests come from the guest agent, which is usually not read yet
+// right after startup, so it is read first.
+func (a *App) openStartGuestShell(vm *api.VM) {
+	if vm.Type != api.VMTypeQemu || vm.IP != "" {
+		a.openVMShell()
+
+		return
+	}
+
+	a.header.ShowLoading(fmt.Sprintf("Reading the IP address of %s", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		if err := a.client.EnrichVM(vm); err != nil {
+			models.GetUILogger().Debug("Failed to enrich %s before opening a shell: %v", vm.Name, err)
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+			a.vmDetails.Update(vm)
+			a.openVMShell()
+		})
+	}()
+}
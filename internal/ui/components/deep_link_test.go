@@ -0,0 +1,21 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepLinkValidate(t *testing.T) {
+	assert.NoError(t, DeepLink{}.Validate())
+	assert.NoError(t, DeepLink{VMID: 104}.Validate())
+	assert.NoError(t, DeepLink{VMID: 104, Action: DeepLinkShell}.Validate())
+	assert.NoError(t, DeepLink{VMID: 104, Action: DeepLinkSerial}.Validate())
+	assert.NoError(t, DeepLink{Node: "pve2", Action: DeepLinkVNC}.Validate())
+
+	assert.ErrorContains(t, DeepLink{Node: "pve2", Action: DeepLinkSerial}.Validate(), "invalid action 'serial'")
+	assert.ErrorContains(t, DeepLink{VMID: 104, Action: "reboot"}.Validate(), "must be one of shell, vnc, serial, web")
+	assert.ErrorContains(t, DeepLink{Action: DeepLinkShell}.Validate(), "needs --vm or --node")
+	assert.ErrorContains(t, DeepLink{VMID: 104, Node: "pve2"}.Validate(), "cannot be combined")
+	assert.ErrorContains(t, DeepLink{VMID: -1}.Validate(), "invalid VMID")
+}
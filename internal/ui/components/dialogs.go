@@ -8,6 +8,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/pkg/api"
@@ -119,14 +120,28 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 	form.SetTitleColor(theme.Colors.Primary)
 	form.SetBorderColor(theme.Colors.Border)
 
-	// Target node dropdown
-	nodeOptions := make([]string, len(availableNodes))
+	// Target node dropdown, ordered by the placement suggestion
+	candidates := make([]string, len(availableNodes))
 	for i, node := range availableNodes {
-		nodeOptions[i] = node.Name
+		candidates[i] = node.Name
 	}
 
+	nodeNames, nodeOptions, suggested := a.placementOptions(vm, candidates)
+
+	// Affinity rules the selected node would break are shown as a warning
+	affinityGroups := models.AffinityGroups(a.config.AffinityRules, models.GlobalState.OriginalVMs)
+	affinityField := tview.NewInputField()
+	affinityField.SetLabel("Affinity")
+	affinityField.SetDisabled(true)
+
 	selectedNodeIndex := 0
-	form.AddDropDown("Target Node", nodeOptions, selectedNodeIndex, nil)
+	form.AddDropDown("Target Node", nodeOptions, selectedNodeIndex, func(_ string, index int) {
+		selectedNodeIndex = index
+
+		if index >= 0 {
+			affinityField.SetText(affinitySummary(models.AffinityConflicts(affinityGroups, vm, nodeNames[index])))
+		}
+	})
 
 	// Show migration mode info (read-only)
 	var modeInfo string
@@ -148,16 +163,31 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 	infoField.SetDisabled(true)
 	form.AddFormItem(infoField)
 
-	// Add buttons
-	form.AddButton("Migrate", func() {
-		// Get form values
-		// GetCurrentOption() doesn't return an error, so we can ignore the errcheck warning
-		_, targetNode := form.GetFormItemByLabel("Target Node").(*tview.DropDown).GetCurrentOption()
+	if suggested {
+		suggestionField := tview.NewInputField()
+		suggestionField.SetLabel("Suggestion")
+		suggestionField.SetText(fmt.Sprintf("Ctrl+S: migrate to %s", nodeNames[0]))
+		suggestionField.SetDisabled(true)
+		form.AddFormItem(suggestionField)
+	}
+
+	form.AddFormItem(affinityField)
+
+	migrate := func() {
+		if selectedNodeIndex < 0 {
+			return
+		}
+
+		targetNode := nodeNames[selectedNodeIndex]
 
 		// Show confirmation dialog
 		confirmText := fmt.Sprintf("Migrate %s '%s' (ID: %d) from %s to %s?\n\n%s",
 			strings.ToUpper(vm.Type), vm.Name, vm.ID, vm.Node, targetNode, modeInfo)
 
+		if conflicts := models.AffinityConflicts(affinityGroups, vm, targetNode); len(conflicts) > 0 {
+			confirmText += fmt.Sprintf("\n\n%s  This breaks affinity rules:\n%s", theme.Icon("⚠️", "WARNING:"), strings.Join(conflicts, "\n"))
+		}
+
 		a.showConfirmationDialog(confirmText, func() {
 			// Build migration options with smart defaults
 			options := &api.MigrationOptions{
@@ -180,7 +210,10 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 
 			a.performMigrationOperation(vm, options)
 		})
-	})
+	}
+
+	// Add buttons
+	form.AddButton("Migrate", migrate)
 
 	form.AddButton("Cancel", func() {
 		a.removePageIfPresent("migration")
@@ -193,6 +226,14 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 			return nil
 		}
 
+		// Accept the suggested node with one key
+		if event.Key() == tcell.KeyCtrlS && suggested {
+			form.GetFormItemByLabel("Target Node").(*tview.DropDown).SetCurrentOption(0)
+			migrate()
+
+			return nil
+		}
+
 		return event
 	})
 
@@ -201,8 +242,8 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 		AddItem(nil, 0, 1, false).
 		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 			AddItem(nil, 0, 1, false).
-			AddItem(form, 12, 0, true). // Set minimum height of 12 lines for the form
-			AddItem(nil, 0, 1, false), 60, 1, true).
+			AddItem(form, 16, 0, true). // Set minimum height of 16 lines for the form
+			AddItem(nil, 0, 1, false), 70, 1, true).
 		AddItem(nil, 0, 1, false)
 
 	a.pages.AddPage("migration", modal, true, true)
@@ -211,6 +252,14 @@ func (a *App) showMigrationDialog(vm *api.VM) {
 
 // performMigrationOperation performs an asynchronous VM migration operation.
 func (a *App) performMigrationOperation(vm *api.VM, options *api.MigrationOptions) {
+	a.runWithHooks(config.HookActionVMMigrate, vm, func(post func()) {
+		a.startMigrationOperation(vm, options, post)
+	})
+}
+
+// startMigrationOperation migrates a VM once its pre hooks passed and calls
+// post after the migration completed.
+func (a *App) startMigrationOperation(vm *api.VM, options *api.MigrationOptions, post func()) {
 	// Set pending state immediately for visual feedback
 	const (
 		migrationTypeOffline = "offline"
@@ -255,6 +304,12 @@ func (a *App) performMigrationOperation(vm *api.VM, options *api.MigrationOption
 		if err := a.client.MigrateVM(vm, options); err != nil {
 			// Update message with detailed error on main thread
 			a.QueueUpdateDraw(func() {
+				if _, known := explainAPIError(err); known {
+					a.showActionError(fmt.Sprintf("Migration of %s failed", vm.Name), err)
+
+					return
+				}
+
 				a.header.ShowError(fmt.Sprintf("Migration failed: %v", err))
 				// Also show a modal with more details
 				a.showMessage(fmt.Sprintf("Migration of %s '%s' (ID: %d) to %s failed:\n\n%v\n\nCheck the logs for more details.",
@@ -304,6 +359,7 @@ func (a *App) performMigrationOperation(vm *api.VM, options *api.MigrationOption
 			a.QueueUpdateDraw(func() {
 				a.header.ShowSuccess(fmt.Sprintf("Migration of %s to %s completed successfully", vm.Name, options.Target))
 			})
+			post()
 		} else {
 			a.QueueUpdateDraw(func() {
 				a.header.ShowError(fmt.Sprintf("Migration of %s to %s timed out", vm.Name, options.Target))
@@ -180,6 +180,7 @@ func CreateFormDialog(title string, fields []FormField, onSubmit, onCancel func(
 
 	for _, field := range fields {
 		fieldName := field.Name
+		fieldValues[fieldName] = field.DefaultValue
 		form.AddInputField(field.Label, field.DefaultValue, field.MaxLength, nil, func(text string) {
 			fieldValues[fieldName] = text
 		})
@@ -0,0 +1,161 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// FilePicker is a list for browsing directories and choosing a file.
+type FilePicker struct {
+	*tview.List
+
+	dir      string
+	entries  []string
+	include  func(name string) bool
+	onSelect func(path string)
+	onCancel func()
+}
+
+// NewFilePicker creates a file picker starting in dir. Only files accepted by
+// include are listed; directories are always shown.
+func NewFilePicker(dir string, include func(name string) bool, onSelect func(path string), onCancel func()) *FilePicker {
+	fp := &FilePicker{
+		List: tview.NewList().
+			ShowSecondaryText(false).
+			SetHighlightFullLine(true).
+			SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary)),
+		include:  include,
+		onSelect: onSelect,
+		onCancel: onCancel,
+	}
+
+	fp.SetBorder(true)
+	fp.SetTitleColor(theme.Colors.Title)
+	fp.SetBorderColor(theme.Colors.Border)
+
+	fp.SetSelectedFunc(func(index int, _ string, _ string, _ rune) {
+		fp.open(index)
+	})
+
+	fp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			if fp.onCancel != nil {
+				fp.onCancel()
+			}
+
+			return nil
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			fp.load(filepath.Dir(fp.dir))
+
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'j':
+				return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			case 'h':
+				fp.load(filepath.Dir(fp.dir))
+
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	fp.load(dir)
+
+	return fp
+}
+
+// load lists the contents of dir.
+func (fp *FilePicker) load(dir string) {
+	fp.Clear()
+	fp.entries = nil
+	fp.dir = dir
+	fp.SetTitle(fmt.Sprintf(" %s ", dir))
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		fp.AddItem(fmt.Sprintf("Error: %v", err), "", 0, nil)
+
+		return
+	}
+
+	var dirs, names []string
+
+	for _, file := range files {
+		if file.IsDir() {
+			dirs = append(dirs, file.Name())
+		} else if fp.include == nil || fp.include(file.Name()) {
+			names = append(names, file.Name())
+		}
+	}
+
+	sort.Strings(dirs)
+	sort.Strings(names)
+
+	if parent := filepath.Dir(dir); parent != dir {
+		fp.entries = append(fp.entries, "..")
+		fp.AddItem("../", "", 0, nil)
+	}
+
+	for _, name := range dirs {
+		fp.entries = append(fp.entries, name)
+		fp.AddItem(name+"/", "", 0, nil)
+	}
+
+	for _, name := range names {
+		fp.entries = append(fp.entries, name)
+		fp.AddItem(name, "", 0, nil)
+	}
+}
+
+// open enters the directory or selects the file at index.
+func (fp *FilePicker) open(index int) {
+	if index < 0 || index >= len(fp.entries) {
+		return
+	}
+
+	path := filepath.Join(fp.dir, fp.entries[index])
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	if info.IsDir() {
+		fp.load(path)
+
+		return
+	}
+
+	if fp.onSelect != nil {
+		fp.onSelect(path)
+	}
+}
+
+// isPrivateKeyCandidate reports whether a file in an SSH directory may be a
+// private key, skipping public keys and other well-known SSH files.
+func isPrivateKeyCandidate(name string) bool {
+	switch {
+	case strings.HasSuffix(name, ".pub"),
+		strings.HasPrefix(name, "known_hosts"),
+		name == "authorized_keys",
+		name == "config",
+		name == "environment":
+		return false
+	default:
+		return true
+	}
+}
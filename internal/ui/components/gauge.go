@@ -0,0 +1,106 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// gaugeWidth is the number of cells used by usage gauges in details and summary tables.
+const gaugeWidth = 10
+
+// brailleLevels fill a braille cell dot by dot from the bottom left, giving
+// eight steps per cell.
+var brailleLevels = []rune{'⡀', '⡄', '⡆', '⡇', '⣇', '⣧', '⣷', '⣿'}
+
+// usageGauge renders percent as a bar of width cells, colored by the usage
+// thresholds of the theme. The result contains color tags and is meant for
+// table cells and text views with dynamic colors. Accessible mode uses ASCII
+// characters instead of braille.
+func usageGauge(percent float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	percent = math.Max(0, math.Min(100, percent))
+
+	filledChar, partial, emptyChar := "⣿", brailleLevels, "⣀"
+	steps := len(brailleLevels)
+
+	if theme.IsAccessible() {
+		filledChar, partial, emptyChar = "#", nil, "-"
+		steps = 1
+	}
+
+	// Round to the nearest step so small values still show up
+	total := int(math.Round(percent / 100 * float64(width*steps)))
+	full, rest := total/steps, total%steps
+
+	var filled strings.Builder
+
+	filled.WriteString(strings.Repeat(filledChar, full))
+
+	used := full
+	if rest > 0 && full < width {
+		filled.WriteRune(partial[rest-1])
+
+		used++
+	}
+
+	return fmt.Sprintf("[%s]%s[%s]%s[-]",
+		theme.ColorToTag(theme.GetUsageColor(percent)), filled.String(),
+		theme.ColorToTag(theme.Colors.Border), strings.Repeat(emptyChar, width-used))
+}
+
+// usageGaugeText renders a gauge followed by text, separated by a space.
+func usageGaugeText(percent float64, text string) string {
+	return usageGauge(percent, gaugeWidth) + " " + text
+}
+
+// sparkLevels are the block heights of sparklines; accessible mode uses
+// sparkLevelsASCII.
+var (
+	sparkLevels      = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	sparkLevelsASCII = []rune{'_', '.', '-', '~', '=', '+', '*', '#'}
+)
+
+// sparkline renders the last width values as a line of block heights
+// relative to peak, or to the largest value when peak is zero. Missing
+// values on the left are padded with spaces so lines of one table align.
+func sparkline(values []float64, peak float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	if peak <= 0 {
+		for _, value := range values {
+			peak = math.Max(peak, value)
+		}
+	}
+
+	levels := sparkLevels
+	if theme.IsAccessible() {
+		levels = sparkLevelsASCII
+	}
+
+	var line strings.Builder
+
+	line.WriteString(strings.Repeat(" ", width-len(values)))
+
+	for _, value := range values {
+		level := 0
+		if peak > 0 {
+			level = int(math.Round(math.Max(0, math.Min(1, value/peak)) * float64(len(levels)-1)))
+		}
+
+		line.WriteRune(levels[level])
+	}
+
+	return line.String()
+}
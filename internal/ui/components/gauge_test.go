@@ -0,0 +1,71 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// stripTags removes the color tags from a gauge.
+func stripTags(s string) string {
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetText(s)
+
+	return view.GetText(true)
+}
+
+func TestUsageGauge(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent float64
+		width   int
+		want    string
+	}{
+		{"empty", 0, 4, "⣀⣀⣀⣀"},
+		{"full", 100, 4, "⣿⣿⣿⣿"},
+		{"half", 50, 4, "⣿⣿⣀⣀"},
+		{"partial cell", 30, 4, "⣿⡄⣀⣀"},
+		{"clamped above", 150, 3, "⣿⣿⣿"},
+		{"clamped below", -5, 3, "⣀⣀⣀"},
+		{"no width", 50, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stripTags(usageGauge(tt.percent, tt.width)))
+		})
+	}
+}
+
+func TestUsageGauge_Accessible(t *testing.T) {
+	theme.SetAccessible(true)
+	t.Cleanup(func() { theme.SetAccessible(false) })
+
+	assert.Equal(t, "###-------", stripTags(usageGauge(30, gaugeWidth)))
+	assert.Equal(t, "#####----- 50%", stripTags(usageGaugeText(50, "50%")))
+}
+
+func TestUsageGauge_ThresholdColor(t *testing.T) {
+	assert.Contains(t, usageGauge(95, 4), "["+theme.ColorToTag(theme.GetUsageColor(95))+"]")
+	assert.Contains(t, usageGauge(10, 4), "["+theme.ColorToTag(theme.GetUsageColor(10))+"]")
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▅█", sparkline([]float64{0, 50, 100}, 100, 3))
+	// Without a peak the largest value is the full block
+	assert.Equal(t, "▁▅█", sparkline([]float64{0, 1, 2}, 0, 3))
+	// Short histories are padded on the left, long ones keep the newest values
+	assert.Equal(t, "  ▁█", sparkline([]float64{0, 100}, 100, 4))
+	assert.Equal(t, "▁█", sparkline([]float64{100, 0, 100}, 100, 2))
+	assert.Equal(t, "▁▁", sparkline([]float64{0, 0}, 0, 2))
+	assert.Equal(t, "█", sparkline([]float64{150}, 100, 1))
+	assert.Empty(t, sparkline([]float64{1}, 1, 0))
+
+	theme.SetAccessible(true)
+	t.Cleanup(func() { theme.SetAccessible(false) })
+
+	assert.Equal(t, "_=#", sparkline([]float64{0, 50, 100}, 100, 3))
+}
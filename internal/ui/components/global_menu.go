@@ -11,18 +11,48 @@ func (a *App) ShowGlobalContextMenu() {
 	// Store last focused primitive
 	a.lastFocus = a.GetFocus()
 
+	alertsLabel := a.alertsMenuLabel()
+
 	// Create menu items for global actions
 	menuItems := []string{
 		"Connection Profiles",
 		"Refresh All Data",
 		"Toggle Auto-Refresh",
+		"Cycle Summary Panel",
+		"Toggle Compact Summary",
+		"Datacenter Options",
+		"Bulk Action by ID",
+		"Capacity Report",
+		"Utilization Heatmap",
+		"Cost Report",
+		"Upgrade Readiness",
+		"Quorum Diagnostics",
+		"GPU Usage",
+		"Stale Snapshots",
+		"Announcements",
+		alertsLabel,
+		"Log Viewer",
+		"API Explorer",
 		"Help",
+		"Guided Tour",
 		"About",
+		"API Token",
+		"Lock Session",
 		"Quit",
 	}
 
 	// Define custom shortcuts for global menu
-	shortcuts := []rune{'p', 'r', 'a', '?', 'i', 'q'}
+	shortcuts := []rune{'p', 'r', 'a', 's', 'c', 'd', 'b', 'o', 'H', 'C', 'e', 'Q', 'v', 'z', 'n', 'l', 'x', 'g', '?', 't', 'i', 'y', 'k', 'q'}
+
+	if len(a.config.GuestGroups) > 0 {
+		menuItems = append(menuItems[:7], append([]string{"Guest Groups"}, menuItems[7:]...)...)
+		shortcuts = append(shortcuts[:7], append([]rune{'G'}, shortcuts[7:]...)...)
+	}
+
+	if a.startupTrace != nil {
+		menuItems = append(menuItems[:len(menuItems)-1], "Startup Trace", "Quit")
+		shortcuts = append(shortcuts[:len(shortcuts)-1], 'u', 'q')
+	}
 
 	menu := NewContextMenuWithShortcuts(" Global Actions ", menuItems, shortcuts, func(index int, action string) {
 		a.CloseContextMenu()
@@ -34,14 +64,54 @@ func (a *App) ShowGlobalContextMenu() {
 			a.manualRefresh()
 		case "Toggle Auto-Refresh":
 			a.toggleAutoRefresh()
+		case "Cycle Summary Panel":
+			a.cycleSummaryMode()
+		case "Toggle Compact Summary":
+			a.toggleCompactSummary()
+		case "Datacenter Options":
+			a.showDatacenterOptions()
+		case "Bulk Action by ID":
+			a.showBulkActionByID()
+		case "Guest Groups":
+			a.showGuestGroups(a.config.GuestGroups)
+		case "Capacity Report":
+			a.showCapacityReport()
+		case "Utilization Heatmap":
+			a.showHeatmap()
+		case "Cost Report":
+			a.showCostReport()
+		case "Upgrade Readiness":
+			a.showUpgradeReadiness()
+		case "Quorum Diagnostics":
+			a.showQuorumDiagnostics()
+		case "GPU Usage":
+			a.showGPUUsage()
+		case "Stale Snapshots":
+			a.showStaleSnapshots()
+		case "Announcements":
+			a.loadAnnouncements(false)
+		case alertsLabel:
+			a.showAlerts()
+		case "Log Viewer":
+			a.showLogViewer()
+		case "API Explorer":
+			a.showAPIExplorer()
 		case "Help":
 			if a.pages.HasPage("help") {
 				a.helpModal.Hide()
 			} else {
 				a.helpModal.Show()
 			}
+		case "Guided Tour":
+			a.showTour()
 		case "About":
 			a.showAboutDialog()
+		case "API Token":
+			a.showAPITokenStatus()
+		case "Lock Session":
+			a.lockSession()
+		case "Startup Trace":
+			a.showStartupTrace()
 		case "Quit":
 			a.showQuitConfirmation()
 		}
@@ -0,0 +1,346 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// showGlobalSearch opens a modal that searches nodes, guests, storages and tasks at once.
+// Selecting a result switches to the page that owns the entity and selects it.
+func (a *App) showGlobalSearch() {
+	a.lastFocus = a.GetFocus()
+
+	var storages []*api.Storage
+	if a.client.Cluster != nil && a.client.Cluster.StorageManager != nil {
+		storages = a.client.Cluster.StorageManager.UniqueStorages
+	}
+
+	input := tview.NewInputField().
+		SetLabel("Search: ").
+		SetFieldWidth(0).
+		SetPlaceholder("Nodes, guests, storages and tasks...")
+
+	table := tview.NewTable().
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary))
+
+	// rowResults maps selectable table rows to their search result.
+	rowResults := make(map[int]models.GlobalSearchResult)
+
+	closeSearch := func() {
+		a.removePageIfPresent("globalSearch")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	jump := func(result models.GlobalSearchResult) {
+		a.removePageIfPresent("globalSearch")
+		a.jumpToGlobalSearchResult(result)
+	}
+
+	render := func(query string) {
+		table.Clear()
+
+		rowResults = make(map[int]models.GlobalSearchResult)
+		results := models.GlobalSearch(query, storages)
+
+		if results.Total() == 0 {
+			message := "Type to search across the cluster"
+			if query != "" {
+				message = "No matches"
+			}
+
+			table.SetCell(0, 0, tview.NewTableCell(message).
+				SetTextColor(theme.Colors.Secondary).
+				SetSelectable(false))
+
+			return
+		}
+
+		row := 0
+		firstRow := -1
+
+		for _, group := range [][]models.GlobalSearchResult{results.Nodes, results.Guests, results.Storages, results.Tasks} {
+			if len(group) == 0 {
+				continue
+			}
+
+			table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%s (%d)", group[0].Kind, len(group))).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+			row++
+
+			for _, result := range group {
+				table.SetCell(row, 0, tview.NewTableCell("  "+globalSearchLabel(result)).
+					SetTextColor(theme.Colors.Primary).
+					SetExpansion(1))
+				rowResults[row] = result
+
+				if firstRow < 0 {
+					firstRow = row
+				}
+
+				row++
+			}
+		}
+
+		table.Select(firstRow, 0)
+		table.ScrollToBeginning()
+	}
+
+	input.SetChangedFunc(render)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeSearch()
+
+			return nil
+		case tcell.KeyEnter:
+			// Enter jumps straight to the best (first) match
+			row, _ := table.GetSelection()
+			if result, ok := rowResults[row]; ok {
+				jump(result)
+			}
+
+			return nil
+		case tcell.KeyDown, tcell.KeyTab:
+			if len(rowResults) > 0 {
+				a.SetFocus(table)
+			}
+
+			return nil
+		}
+
+		return event
+	})
+
+	table.SetSelectedFunc(func(row, _ int) {
+		if result, ok := rowResults[row]; ok {
+			jump(result)
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeSearch()
+
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			a.SetFocus(input)
+
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'j':
+				return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			case '/':
+				a.SetFocus(input)
+
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	render("")
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(tview.NewBox(), 1, 0, false).
+		AddItem(table, 0, 1, false)
+	content.SetBorder(true)
+	content.SetTitle(" Global Search ")
+	content.SetTitleColor(theme.Colors.Primary)
+	content.SetBorderColor(theme.Colors.Border)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 3, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("globalSearch", modal, true, true)
+	a.SetFocus(input)
+}
+
+// globalSearchLabel formats a single result line for the global search table.
+func globalSearchLabel(result models.GlobalSearchResult) string {
+	switch result.Kind {
+	case models.GlobalSearchNode:
+		status := "offline"
+		if result.Node.Online {
+			status = "online"
+		}
+
+		if result.Node.IP != "" {
+			return fmt.Sprintf("%s  (%s, %s)", result.Node.Name, status, result.Node.IP)
+		}
+
+		return fmt.Sprintf("%s  (%s)", result.Node.Name, status)
+	case models.GlobalSearchGuest:
+		vm := result.VM
+
+		return fmt.Sprintf("%d  %s  (%s on %s, %s)", vm.ID, vm.Name, vm.Type, vm.Node, vm.Status)
+	case models.GlobalSearchStorage:
+		storage := result.Storage
+		if storage.IsShared() {
+			return fmt.Sprintf("%s  (%s, shared)", storage.Name, storage.Plugintype)
+		}
+
+		return fmt.Sprintf("%s  (%s on %s)", storage.Name, storage.Plugintype, storage.Node)
+	case models.GlobalSearchTask:
+		task := result.Task
+
+		startTime := "N/A"
+		if task.StartTime > 0 {
+			startTime = time.Unix(task.StartTime, 0).Format("01-02 15:04")
+		}
+
+		status := task.Status
+		if status == "" {
+			status = "running"
+		}
+
+		return fmt.Sprintf("%s  %s %s on %s  (%s)", startTime, task.Type, task.ID, task.Node, status)
+	}
+
+	return ""
+}
+
+// jumpToGlobalSearchResult switches to the page that owns the result and selects it.
+// Storages have no page of their own, so they select the node that hosts them.
+func (a *App) jumpToGlobalSearchResult(result models.GlobalSearchResult) {
+	switch result.Kind {
+	case models.GlobalSearchNode:
+		a.selectNodeByName(result.Node.Name)
+	case models.GlobalSearchStorage:
+		a.selectNodeByName(result.Storage.Node)
+	case models.GlobalSearchGuest:
+		a.selectGuest(result.VM)
+	case models.GlobalSearchTask:
+		a.selectTask(result.Task)
+	}
+}
+
+// selectNodeByName switches to the nodes page and selects the named node,
+// clearing any active node filter that hides it.
+func (a *App) selectNodeByName(name string) {
+	a.pages.SwitchToPage(api.PageNodes)
+	a.SetFocus(a.nodeList)
+
+	findIndex := func() int {
+		for i, node := range a.nodeList.GetNodes() {
+			if node != nil && node.Name == name {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	idx := findIndex()
+	if idx < 0 {
+		clearSearchFilter(api.PageNodes)
+		models.FilterNodes("")
+		a.nodeList.SetNodes(models.GlobalState.FilteredNodes)
+
+		idx = findIndex()
+	}
+
+	if idx < 0 {
+		a.header.ShowWarning(fmt.Sprintf("Node %s is no longer available", name))
+
+		return
+	}
+
+	a.nodeList.SetCurrentItem(idx)
+
+	if node := a.nodeList.GetSelectedNode(); node != nil {
+		a.nodeDetails.Update(node, a.client.Cluster.Nodes)
+	}
+}
+
+// selectGuest switches to the guests page and selects the guest,
+// clearing any active guest filter that hides it.
+func (a *App) selectGuest(target *api.VM) {
+	a.pages.SwitchToPage(api.PageGuests)
+	a.SetFocus(a.vmList)
+
+	findIndex := func() int {
+		for i, vm := range a.vmList.GetVMs() {
+			if vm != nil && vm.ID == target.ID && vm.Node == target.Node {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	idx := findIndex()
+	if idx < 0 && a.vmList.RevealVM(target) {
+		idx = findIndex()
+	}
+
+	if idx < 0 {
+		clearSearchFilter(api.PageGuests)
+		models.FilterVMs("")
+		a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+		a.vmList.RevealVM(target)
+
+		idx = findIndex()
+	}
+
+	if idx < 0 {
+		a.header.ShowWarning(fmt.Sprintf("Guest %d is no longer available", target.ID))
+
+		return
+	}
+
+	a.vmList.SetCurrentItem(idx)
+
+	if vm := a.vmList.GetSelectedVM(); vm != nil {
+		a.vmDetails.Update(vm)
+	}
+}
+
+// selectTask switches to the tasks page and selects the task,
+// clearing any active task filter that hides it.
+func (a *App) selectTask(task *api.ClusterTask) {
+	a.pages.SwitchToPage(api.PageTasks)
+	a.SetFocus(a.tasksList)
+
+	if a.tasksList.SelectTask(task.UPID) {
+		return
+	}
+
+	clearSearchFilter(api.PageTasks)
+	models.FilterTasks("")
+	a.tasksList.SetFilteredTasks(models.GlobalState.FilteredTasks)
+
+	if !a.tasksList.SelectTask(task.UPID) {
+		a.header.ShowWarning("Task is no longer in the task list")
+	}
+}
+
+// clearSearchFilter resets the saved per-page search filter.
+func clearSearchFilter(page string) {
+	if state := models.GlobalState.GetSearchState(page); state != nil {
+		state.Filter = ""
+		state.SelectedIndex = 0
+	}
+}
@@ -0,0 +1,298 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// gpuUsage is a GPU of a node with its mediated device types and the guests
+// holding it or one of its mdevs.
+type gpuUsage struct {
+	Node    string
+	Device  api.PCIDevice
+	Types   []api.MDevType
+	Holders []guestPassthrough
+}
+
+// profileHolders returns the holders of an mdev type; an empty type returns
+// the guests passing through the whole device.
+func (g gpuUsage) profileHolders(mdevType string) []guestPassthrough {
+	var holders []guestPassthrough
+
+	for _, holder := range g.Holders {
+		if holder.Passthrough.MDev == mdevType {
+			holders = append(holders, holder)
+		}
+	}
+
+	return holders
+}
+
+// collectGPUUsage matches the GPUs of a node with the guest entries passing
+// them through. types holds the mdev types by PCI address.
+func collectGPUUsage(node string, devices []api.PCIDevice, types map[string][]api.MDevType, entries []guestPassthrough) []gpuUsage {
+	var gpus []gpuUsage
+
+	for _, device := range devices {
+		if !device.IsGPU() {
+			continue
+		}
+
+		gpu := gpuUsage{Node: node, Device: device, Types: types[device.ID]}
+
+		for _, entry := range entries {
+			if entry.Passthrough.MatchesPCI(device) {
+				gpu.Holders = append(gpu.Holders, entry)
+			}
+		}
+
+		gpus = append(gpus, gpu)
+	}
+
+	return gpus
+}
+
+// showGPUUsage collects the GPUs of all online nodes with their mdev types
+// and allocations.
+func (a *App) showGPUUsage() {
+	var nodes []*api.Node
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node != nil && node.Online {
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		a.header.ShowError("No online nodes")
+
+		return
+	}
+
+	guests := make(map[string][]*api.VM)
+
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil && vm.Type == api.VMTypeQemu {
+			guests[vm.Node] = append(guests[vm.Node], vm)
+		}
+	}
+
+	a.header.ShowLoading("Loading GPU usage...")
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		var gpus []gpuUsage
+
+		for _, node := range nodes {
+			devices, err := client.GetNodePCIDevices(node.Name)
+			if err != nil {
+				models.GetUILogger().Debug("Failed to load PCI devices of %s: %v", node.Name, err)
+
+				continue
+			}
+
+			types := make(map[string][]api.MDevType)
+			hasGPU := false
+
+			for _, device := range devices {
+				if !device.IsGPU() {
+					continue
+				}
+
+				hasGPU = true
+
+				if device.MDev {
+					if types[device.ID], err = client.GetPCIMDevTypes(node.Name, device.ID); err != nil {
+						models.GetUILogger().Debug("Failed to load mdev types of %s on %s: %v", device.ID, node.Name, err)
+					}
+				}
+			}
+
+			if !hasGPU {
+				continue
+			}
+
+			var entries []guestPassthrough
+
+			for _, vm := range guests[node.Name] {
+				passthrough, err := client.GetGuestPassthrough(vm)
+				if err != nil {
+					models.GetUILogger().Debug("Failed to load passthrough devices of VM %d: %v", vm.ID, err)
+
+					continue
+				}
+
+				for _, entry := range passthrough {
+					entries = append(entries, guestPassthrough{VM: vm, Passthrough: entry})
+				}
+			}
+
+			gpus = append(gpus, collectGPUUsage(node.Name, devices, types, entries)...)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if len(gpus) == 0 {
+				a.header.ShowError("No GPUs found on the online nodes")
+
+				return
+			}
+
+			a.header.StopLoading()
+			a.showGPUUsageTable(gpus)
+		})
+	}()
+}
+
+// showGPUUsageTable renders one row per GPU and mdev type. Enter selects the
+// first guest holding it.
+func (a *App) showGPUUsageTable(gpus []gpuUsage) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	for col, header := range []string{"Node", "GPU", "Profile", "Free", "Used by"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(theme.Colors.HeaderText).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+
+	row := 1
+	inUse := 0
+
+	addRow := func(gpu gpuUsage, profile, free string, freeColor tcell.Color, holders []guestPassthrough) {
+		usedBy := make([]string, len(holders))
+		for i, holder := range holders {
+			usedBy[i] = fmt.Sprintf("%s (%d)", holder.VM.Name, holder.VM.ID)
+		}
+
+		name := strings.TrimSpace(gpu.Device.DeviceName)
+		if name == "" {
+			name = fmt.Sprintf("%s:%s", gpu.Device.VendorID, gpu.Device.DeviceID)
+		}
+
+		cells := []*tview.TableCell{
+			hardwareCell(gpu.Node, theme.Colors.Primary),
+			hardwareCell(fmt.Sprintf("%s %s", gpu.Device.ID, name), theme.Colors.Primary),
+			hardwareCell(profile, theme.Colors.Primary),
+			hardwareCell(free, freeColor),
+			hardwareCell(strings.Join(usedBy, ", "), theme.Colors.Warning),
+		}
+
+		for col, cell := range cells {
+			if len(holders) > 0 {
+				cell.SetReference(holders[0].VM)
+			}
+
+			table.SetCell(row, col, cell.SetExpansion(1))
+		}
+
+		inUse += len(holders)
+		row++
+	}
+
+	for _, gpu := range gpus {
+		for _, mdevType := range gpu.Types {
+			freeColor := theme.Colors.Success
+			if mdevType.Available == 0 {
+				freeColor = theme.Colors.Warning
+			}
+
+			profile := mdevType.Type
+			if mdevType.Name != "" {
+				profile = fmt.Sprintf("%s (%s)", mdevType.Name, mdevType.Type)
+			}
+
+			addRow(gpu, profile, strconv.Itoa(mdevType.Available), freeColor, gpu.profileHolders(mdevType.Type))
+		}
+
+		// mdevs of types the device no longer lists, e.g. after a driver update
+		listed := make(map[string]bool, len(gpu.Types))
+		for _, mdevType := range gpu.Types {
+			listed[mdevType.Type] = true
+		}
+
+		for _, holder := range gpu.Holders {
+			if mdevType := holder.Passthrough.MDev; mdevType != "" && !listed[mdevType] {
+				listed[mdevType] = true
+				addRow(gpu, mdevType, api.StringNA, theme.Colors.Secondary, gpu.profileHolders(mdevType))
+			}
+		}
+
+		// Whole-device passthrough
+		whole := gpu.profileHolders("")
+		if len(gpu.Types) == 0 || len(whole) > 0 {
+			free, freeColor := "yes", theme.Colors.Success
+			if len(whole) > 0 {
+				free, freeColor = "no", theme.Colors.Warning
+			}
+
+			addRow(gpu, "whole device", free, freeColor, whole)
+		}
+	}
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" GPU Usage (%d GPUs, %d allocations) ", len(gpus), inUse)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	table.Select(1, 0)
+
+	closeUsage := func() {
+		a.removePageIfPresent("gpuUsage")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetSelectedFunc(func(selected, _ int) {
+		vm, ok := table.GetCell(selected, 0).GetReference().(*api.VM)
+		if !ok {
+			return
+		}
+
+		closeUsage()
+		a.selectGuest(vm)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeUsage()
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := row + 2
+	if height > 24 {
+		height = 24
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, height, 0, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("gpuUsage")
+	a.pages.AddPage("gpuUsage", modal, true, true)
+	a.SetFocus(table)
+}
@@ -0,0 +1,43 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCollectGPUUsage(t *testing.T) {
+	devices := []api.PCIDevice{
+		{ID: "0000:01:00.0", Class: "0x030000", DeviceName: "Tesla P4", MDev: true},
+		{ID: "0000:02:00.0", Class: "0x030000", DeviceName: "GTX 1080"},
+		{ID: "0000:02:00.1", Class: "0x040300", DeviceName: "GP104 HD Audio"},
+		{ID: "0000:03:00.0", Class: "0x020000", DeviceName: "I350 Gigabit"},
+	}
+	types := map[string][]api.MDevType{
+		"0000:01:00.0": {{Type: "nvidia-63", Name: "GRID P4-1Q", Available: 6}},
+	}
+	vdi1 := &api.VM{ID: 101, Name: "vdi1"}
+	vdi2 := &api.VM{ID: 102, Name: "vdi2"}
+	gaming := &api.VM{ID: 103, Name: "gaming"}
+	entries := []guestPassthrough{
+		{VM: vdi1, Passthrough: api.Passthrough{Key: "hostpci0", Host: "0000:01:00.0", MDev: "nvidia-63"}},
+		{VM: vdi2, Passthrough: api.Passthrough{Key: "hostpci0", Host: "01:00.0", MDev: "nvidia-63"}},
+		{VM: gaming, Passthrough: api.Passthrough{Key: "hostpci0", Host: "0000:02:00"}},
+		{VM: gaming, Passthrough: api.Passthrough{Key: "hostpci1", Host: "0000:03:00.0"}},
+	}
+
+	gpus := collectGPUUsage("pve1", devices, types, entries)
+	require.Len(t, gpus, 2, "only display controllers and mdev devices")
+
+	assert.Equal(t, "0000:01:00.0", gpus[0].Device.ID)
+	assert.Len(t, gpus[0].Types, 1)
+	assert.Len(t, gpus[0].profileHolders("nvidia-63"), 2)
+	assert.Empty(t, gpus[0].profileHolders(""))
+
+	assert.Equal(t, "0000:02:00.0", gpus[1].Device.ID)
+	require.Len(t, gpus[1].profileHolders(""), 1)
+	assert.Equal(t, gaming, gpus[1].profileHolders("")[0].VM)
+}
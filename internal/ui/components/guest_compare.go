@@ -0,0 +1,376 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Categories of guest config keys, in the order they are compared.
+var guestConfigCategories = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"CPU", regexp.MustCompile(`^(cores|sockets|cpu|cpulimit|cpuunits|vcpus|numa|affinity|arch)$`)},
+	{"Memory", regexp.MustCompile(`^(memory|balloon|shares|swap|hugepages|keephugepages)$`)},
+	{"Disks", regexp.MustCompile(`^((ide|sata|scsi|virtio|mp|unused)\d+|rootfs|efidisk0|tpmstate0|scsihw|bootdisk)$`)},
+	{"Network", regexp.MustCompile(`^(net\d+|ipconfig\d+|nameserver|searchdomain|hostname)$`)},
+}
+
+// guestConfigCategory returns the category of a config key.
+func guestConfigCategory(key string) int {
+	for i, category := range guestConfigCategories {
+		if category.pattern.MatchString(key) {
+			return i
+		}
+	}
+
+	return len(guestConfigCategories)
+}
+
+// guestConfigCategoryName returns the name of a category index.
+func guestConfigCategoryName(index int) string {
+	if index < len(guestConfigCategories) {
+		return guestConfigCategories[index].name
+	}
+
+	return "Other"
+}
+
+// macAddress matches MAC addresses in network interfaces.
+var macAddress = regexp.MustCompile(`(?i)([0-9a-f]{2}:){5}[0-9a-f]{2}`)
+
+// guestVolumeName matches the guest-specific part of a volume, e.g.
+// vm-100-disk-0 in local-lvm:vm-100-disk-0.
+var guestVolumeName = regexp.MustCompile(`^([^:,]+):[^,]*(vm|subvol|base)-\d+-disk-\d+[^,]*`)
+
+// comparableConfigValue removes what always differs between guests, volume
+// names and MAC addresses, from a config value.
+func comparableConfigValue(key, value string) string {
+	switch guestConfigCategoryName(guestConfigCategory(key)) {
+	case "Disks":
+		return guestVolumeName.ReplaceAllString(value, "$1:")
+	case "Network":
+		return macAddress.ReplaceAllString(value, "")
+	default:
+		return value
+	}
+}
+
+// configDiffRow is one config key of two compared guests.
+type configDiffRow struct {
+	Key      string
+	Category string
+	Values   [2]string
+	// Differs is set when the values differ in more than volume names or
+	// MAC addresses.
+	Differs bool
+}
+
+// guestConfigDiffRows compares two guest configs key by key, grouped by
+// category.
+func guestConfigDiffRows(first, second api.GuestConfig) []configDiffRow {
+	keys := make(map[string]bool)
+
+	for _, config := range []api.GuestConfig{first, second} {
+		for key := range config {
+			keys[key] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		ci, cj := guestConfigCategory(sorted[i]), guestConfigCategory(sorted[j])
+		if ci != cj {
+			return ci < cj
+		}
+
+		return sorted[i] < sorted[j]
+	})
+
+	rows := make([]configDiffRow, len(sorted))
+
+	for i, key := range sorted {
+		a, b := first[key], second[key]
+
+		rows[i] = configDiffRow{
+			Key:      key,
+			Category: guestConfigCategoryName(guestConfigCategory(key)),
+			Values:   [2]string{a, b},
+			Differs:  comparableConfigValue(key, a) != comparableConfigValue(key, b),
+		}
+	}
+
+	return rows
+}
+
+// showGuestComparePicker lets the user choose a guest to compare the config
+// of vm with. Typing filters the guests by ID or name.
+func (a *App) showGuestComparePicker(vm *api.VM) {
+	var candidates []*api.VM
+
+	for _, other := range models.GlobalState.OriginalVMs {
+		if other != nil && !(other.ID == vm.ID && other.Node == vm.Node) {
+			candidates = append(candidates, other)
+		}
+	}
+
+	if len(candidates) == 0 {
+		a.header.ShowError("There is no other guest to compare with")
+
+		return
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	input := tview.NewInputField().
+		SetLabel("Filter: ").
+		SetFieldWidth(0)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetMainTextColor(theme.Colors.Primary)
+
+	var shown []*api.VM
+
+	render := func(filter string) {
+		filter = strings.ToLower(strings.TrimSpace(filter))
+		shown = nil
+
+		list.Clear()
+
+		for _, other := range candidates {
+			label := fmt.Sprintf("%d - %s (%s, %s)", other.ID, other.Name, other.Node, other.Type)
+			if filter == "" || strings.Contains(strings.ToLower(label), filter) {
+				shown = append(shown, other)
+				list.AddItem(tview.Escape(label), "", 0, nil)
+			}
+		}
+	}
+
+	closePicker := func() {
+		a.removePageIfPresent("guestCompare")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	compare := func() {
+		index := list.GetCurrentItem()
+		if index < 0 || index >= len(shown) {
+			return
+		}
+
+		a.removePageIfPresent("guestCompare")
+		a.loadGuestComparison(vm, shown[index])
+	}
+
+	input.SetChangedFunc(render)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closePicker()
+
+			return nil
+		case tcell.KeyEnter:
+			compare()
+
+			return nil
+		case tcell.KeyDown, tcell.KeyUp:
+			// Keep typing in the filter while moving through the list
+			handler := list.InputHandler()
+			handler(event, func(p tview.Primitive) {})
+
+			return nil
+		}
+
+		return event
+	})
+
+	render("")
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Compare %s with... ", vm.Name)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	height := len(candidates) + 3
+	if height > 20 {
+		height = 20
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, height, 0, true).
+			AddItem(nil, 0, 1, false), 60, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("guestCompare")
+	a.pages.AddPage("guestCompare", modal, true, true)
+	a.SetFocus(input)
+}
+
+// loadGuestComparison fetches the configs of two guests and shows them side
+// by side.
+func (a *App) loadGuestComparison(first, second *api.VM) {
+	a.header.ShowLoading(fmt.Sprintf("Loading configs of %s and %s...", first.Name, second.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		firstConfig, err := a.client.GetGuestConfig(first)
+
+		var secondConfig api.GuestConfig
+		if err == nil {
+			secondConfig, err = a.client.GetGuestConfig(second)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to load configs: %v", err))
+
+				return
+			}
+
+			a.header.StopLoading()
+			a.showGuestComparison(first, second, guestConfigDiffRows(firstConfig, secondConfig))
+		})
+	}()
+}
+
+// showGuestComparison shows the configs of two guests side by side with the
+// differing keys highlighted. d toggles showing only the differences.
+func (a *App) showGuestComparison(first, second *api.VM, rows []configDiffRow) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorderPadding(0, 0, 1, 1)
+	table.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	differences := 0
+
+	for _, row := range rows {
+		if row.Differs {
+			differences++
+		}
+	}
+
+	onlyDifferences := false
+
+	render := func() {
+		table.Clear()
+
+		for col, header := range []string{"Category", "Key", fmt.Sprintf("%d - %s", first.ID, first.Name), fmt.Sprintf("%d - %s", second.ID, second.Name)} {
+			table.SetCell(0, col, tview.NewTableCell(tview.Escape(header)).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false).
+				SetExpansion(map[bool]int{true: 1, false: 0}[col >= 2]))
+		}
+
+		line := 1
+		lastCategory := ""
+
+		for _, row := range rows {
+			if onlyDifferences && !row.Differs {
+				continue
+			}
+
+			category := ""
+			if row.Category != lastCategory {
+				category, lastCategory = row.Category, row.Category
+			}
+
+			color := theme.Colors.Secondary
+			if row.Differs {
+				color = theme.Colors.Warning
+			}
+
+			table.SetCell(line, 0, tview.NewTableCell(category).SetTextColor(theme.Colors.HeaderText))
+			table.SetCell(line, 1, tview.NewTableCell(row.Key).SetTextColor(color))
+
+			for i, value := range row.Values {
+				if value == "" {
+					value = "-"
+				}
+
+				// Descriptions and other multi-line values are shown on one line
+				value = strings.Join(strings.Fields(value), " ")
+
+				table.SetCell(line, i+2, tview.NewTableCell(tview.Escape(value)).SetTextColor(color).SetExpansion(1).SetMaxWidth(60))
+			}
+
+			line++
+		}
+
+		filter := "all keys"
+		if onlyDifferences {
+			filter = "differences only"
+		}
+
+		table.SetTitle(fmt.Sprintf(" Config Comparison: %d differences (%s, d: toggle) ", differences, filter))
+		table.Select(1, 0)
+		table.ScrollToBeginning()
+	}
+
+	render()
+
+	closeComparison := func() {
+		a.removePageIfPresent("guestComparison")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closeComparison()
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'd':
+			onlyDifferences = !onlyDifferences
+			render()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("guestComparison")
+	a.pages.AddPage("guestComparison", modal, true, true)
+	a.SetFocus(table)
+}
@@ -0,0 +1,64 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGuestConfigDiffRows(t *testing.T) {
+	first := api.GuestConfig{
+		"name":   "web-1",
+		"cores":  "4",
+		"memory": "8192",
+		"scsi0":  "local-lvm:vm-100-disk-0,iothread=1,size=32G",
+		"net0":   "virtio=BC:24:11:2E:61:0A,bridge=vmbr0",
+		"ostype": "l26",
+		"agent":  "1",
+	}
+	second := api.GuestConfig{
+		"name":   "web-2",
+		"cores":  "2",
+		"memory": "8192",
+		"scsi0":  "local-lvm:vm-101-disk-0,iothread=1,size=32G",
+		"net0":   "virtio=BC:24:11:AA:BB:CC,bridge=vmbr0",
+		"ostype": "l26",
+	}
+
+	rows := guestConfigDiffRows(first, second)
+
+	keys := make([]string, len(rows))
+	byKey := make(map[string]configDiffRow)
+
+	for i, row := range rows {
+		keys[i] = row.Key
+		byKey[row.Key] = row
+	}
+
+	// CPU, memory, disks and network come first, then the rest by name
+	assert.Equal(t, []string{"cores", "memory", "scsi0", "net0", "agent", "name", "ostype"}, keys)
+	assert.Equal(t, "CPU", byKey["cores"].Category)
+	assert.Equal(t, "Other", byKey["agent"].Category)
+
+	assert.True(t, byKey["cores"].Differs)
+	assert.False(t, byKey["memory"].Differs)
+	assert.True(t, byKey["name"].Differs)
+
+	// Keys set on one guest only differ
+	assert.True(t, byKey["agent"].Differs)
+	assert.Equal(t, [2]string{"1", ""}, byKey["agent"].Values)
+
+	// Volume names and MAC addresses always differ and are ignored
+	assert.False(t, byKey["scsi0"].Differs)
+	assert.False(t, byKey["net0"].Differs)
+}
+
+func TestComparableConfigValue(t *testing.T) {
+	assert.Equal(t, "local-lvm:,size=32G", comparableConfigValue("scsi0", "local-lvm:vm-100-disk-0,size=32G"))
+	assert.Equal(t, "tank:,size=8G", comparableConfigValue("rootfs", "tank:subvol-105-disk-0,size=8G"))
+	assert.Equal(t, "local:iso/debian.iso,media=cdrom", comparableConfigValue("ide2", "local:iso/debian.iso,media=cdrom"))
+	assert.Equal(t, "virtio=,bridge=vmbr1", comparableConfigValue("net0", "virtio=BC:24:11:2E:61:0A,bridge=vmbr1"))
+	assert.Equal(t, "host", comparableConfigValue("cpu", "host"))
+}
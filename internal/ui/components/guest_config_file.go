@@ -0,0 +1,359 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// createFromConfigTimeout is how long creating a VM from a config waits for
+// its task, which allocates the disks.
+const createFromConfigTimeout = 30 * time.Minute
+
+// configStorageFromFile keeps the storages named in the config file.
+const configStorageFromFile = "Storages in the file"
+
+// isYAMLConfigFile reports whether a config file path is written as YAML;
+// other files use the Proxmox .conf format.
+func isYAMLConfigFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	return ext == ".yml" || ext == ".yaml"
+}
+
+// isGuestConfigFile reports whether a file can hold an exported config.
+func isGuestConfigFile(name string) bool {
+	return isYAMLConfigFile(name) || strings.EqualFold(filepath.Ext(name), ".conf")
+}
+
+// defaultConfigExportPath suggests a file in the home directory named after
+// the guest.
+func defaultConfigExportPath(vm *api.VM) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '-'
+		}
+
+		return r
+	}, vm.Name)
+
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+
+	return filepath.Join(dir, fmt.Sprintf("%s-%d.conf", name, vm.ID))
+}
+
+// showExportConfigDialog asks for the file to save the config of a guest to.
+func (a *App) showExportConfigDialog(vm *api.VM) {
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" Export Config: %s '%s' (ID: %d) ", strings.ToUpper(vm.Type), vm.Name, vm.ID))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	form.AddInputField("File", defaultConfigExportPath(vm), 50, nil, nil)
+	form.AddTextView("", "Files ending in .yml or .yaml are written as YAML, others in the .conf format of /etc/pve.", 50, 2, true, false)
+
+	closeForm := func() {
+		a.removePageIfPresent("exportConfig")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	form.AddButton("Export", func() {
+		path := ssh.ExpandHome(strings.TrimSpace(form.GetFormItemByLabel("File").(*tview.InputField).GetText()))
+		if path == "" {
+			a.header.ShowError("Enter the file to export to")
+
+			return
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			a.header.ShowError(fmt.Sprintf("%s already exists", path))
+
+			return
+		}
+
+		closeForm()
+		a.exportGuestConfig(vm, path)
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 10, 0, true).
+			AddItem(nil, 0, 1, false), 72, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.pages.AddPage("exportConfig", modal, true, true)
+	a.SetFocus(form)
+}
+
+// exportGuestConfig saves the config of a guest to path.
+func (a *App) exportGuestConfig(vm *api.VM, path string) {
+	a.header.ShowLoading(fmt.Sprintf("Exporting config of %s...", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		config, err := a.client.GetGuestConfig(vm)
+
+		var data []byte
+		if err == nil {
+			if isYAMLConfigFile(path) {
+				data, err = config.FormatYAML()
+			} else {
+				data = config.FormatConf()
+			}
+		}
+
+		if err == nil {
+			// Configs may hold cloud-init passwords
+			err = os.WriteFile(path, data, 0o600)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to export config of %s: %v", vm.Name, err))
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Exported config of %s to %s", vm.Name, path))
+		})
+	}()
+}
+
+// showCreateFromConfigPicker lets the user choose an exported config to
+// create a VM from on node.
+func (a *App) showCreateFromConfigPicker(node *api.Node) {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+
+	closePicker := func() {
+		a.removePageIfPresent("configPicker")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	picker := NewFilePicker(dir, isGuestConfigFile, func(path string) {
+		a.removePageIfPresent("configPicker")
+		a.loadConfigForNewVM(node, path)
+	}, closePicker)
+
+	layout := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(picker, 20, 1, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.pages.AddPage("configPicker", layout, true, true)
+	a.SetFocus(picker)
+}
+
+// loadConfigForNewVM reads a config file and the next free VMID, then shows
+// the form for creating the VM.
+func (a *App) loadConfigForNewVM(node *api.Node, path string) {
+	data, err := os.ReadFile(path)
+
+	var config api.GuestConfig
+	if err == nil {
+		config, err = api.ParseGuestConfig(data, isYAMLConfigFile(path))
+	}
+
+	if err == nil && config.IsContainer() {
+		err = fmt.Errorf("it is the config of a container; containers need an OS template or a backup")
+	}
+
+	if err != nil {
+		a.header.ShowError(fmt.Sprintf("Can't create a VM from %s: %v", filepath.Base(path), err))
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+
+		return
+	}
+
+	a.header.ShowLoading("Loading next VMID...")
+
+	go func() {
+		defer crash.Recover()
+
+		nextID, err := a.client.GetNextVMID()
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+
+			if err != nil {
+				a.header.ShowError(err.Error())
+
+				return
+			}
+
+			a.showCreateFromConfigForm(node, filepath.Base(path), config, nextID)
+		})
+	}()
+}
+
+// showCreateFromConfigForm asks for the ID, name and storage of a VM created
+// from a config file.
+func (a *App) showCreateFromConfigForm(node *api.Node, fileName string, config api.GuestConfig, nextID int) {
+	storages := a.moveTargetStorages(&api.VM{Node: node.Name, Type: api.VMTypeQemu})
+	storageIndex := 0
+
+	storageLabels := []string{configStorageFromFile}
+	for _, storage := range storages {
+		storageLabels = append(storageLabels, fmt.Sprintf("%s (%s free)", storage.Name, api.FormatBytes(storage.MaxDisk-storage.Disk)))
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" Create VM on %s from %s ", node.Name, fileName))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	form.AddInputField("New VMID", strconv.Itoa(nextID), 10, tview.InputFieldInteger, nil)
+	form.AddInputField("Name", config["name"], 30, nil, nil)
+	form.AddDropDown("Disk Storage", storageLabels, 0, func(_ string, index int) {
+		storageIndex = index
+	})
+
+	closeForm := func() {
+		a.removePageIfPresent("createFromConfig")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	form.AddButton("Create", func() {
+		vmid, err := strconv.Atoi(strings.TrimSpace(form.GetFormItemByLabel("New VMID").(*tview.InputField).GetText()))
+		if err != nil || vmid < 100 {
+			a.header.ShowError("The new VMID must be a number of at least 100")
+
+			return
+		}
+
+		if existing := findGuestByID(vmid); existing != nil {
+			a.header.ShowError(fmt.Sprintf("VMID %d is already used by '%s'", vmid, existing.Name))
+
+			return
+		}
+
+		storage := ""
+		if storageIndex > 0 {
+			storage = storages[storageIndex-1].Name
+		}
+
+		prepared, err := config.ForNewVM(storage)
+		if err != nil {
+			a.header.ShowError(err.Error())
+
+			return
+		}
+
+		if name := strings.TrimSpace(form.GetFormItemByLabel("Name").(*tview.InputField).GetText()); name != "" {
+			prepared["name"] = name
+		}
+
+		storageText := "the storages in the file"
+		if storage != "" {
+			storageText = storage
+		}
+
+		a.showConfirmationDialog(
+			fmt.Sprintf("Create VM %d '%s' on %s from %s?\n\nNew, empty disks are allocated on %s and the network interfaces get new MAC addresses.",
+				vmid, prepared["name"], node.Name, fileName, storageText),
+			func() {
+				closeForm()
+				a.performCreateFromConfig(node.Name, vmid, prepared)
+			},
+		)
+	})
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 11, 0, true).
+			AddItem(nil, 0, 1, false), 72, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("createFromConfig", modal, true, true)
+	a.SetFocus(form)
+}
+
+// performCreateFromConfig starts the creation task and reports its progress
+// in the header.
+func (a *App) performCreateFromConfig(node string, vmid int, config api.GuestConfig) {
+	a.header.ShowLoading(fmt.Sprintf("Creating VM %d on %s", vmid, node))
+
+	go func() {
+		defer crash.Recover()
+
+		upid, err := a.client.CreateVMFromConfig(node, vmid, config)
+		if err == nil {
+			err = a.client.WaitForTask(upid, createFromConfigTimeout, nil)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.showActionError(fmt.Sprintf("Creating VM %d failed", vmid), err)
+				a.loadTasksData()
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Created VM %d on %s", vmid, node))
+			a.client.ClearAPICache()
+			a.manualRefresh()
+		})
+	}()
+}
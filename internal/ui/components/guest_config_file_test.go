@@ -0,0 +1,21 @@
+package components
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGuestConfigFileNames(t *testing.T) {
+	assert.True(t, isYAMLConfigFile("/tmp/web.YAML"))
+	assert.False(t, isYAMLConfigFile("/tmp/web.conf"))
+	assert.True(t, isGuestConfigFile("100.conf"))
+	assert.True(t, isGuestConfigFile("web.yml"))
+	assert.False(t, isGuestConfigFile("notes.txt"))
+
+	t.Setenv("HOME", "/home/alice")
+	assert.Equal(t, filepath.Join("/home/alice", "web-server-100.conf"), defaultConfigExportPath(&api.VM{ID: 100, Name: "web server"}))
+}
@@ -0,0 +1,321 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// guestFileViewLimit is the most of a file shown; larger files show their
+// end, where logs have their latest lines.
+const guestFileViewLimit = 256 * 1024
+
+// guestFileRecentLimit is how many recently read files are listed per guest.
+const guestFileRecentLimit = 10
+
+// linuxTriagePaths and windowsTriagePaths are files worth a look when
+// triaging a guest.
+var (
+	linuxTriagePaths = []string{
+		"/etc/os-release",
+		"/etc/hostname",
+		"/etc/hosts",
+		"/etc/resolv.conf",
+		"/etc/fstab",
+		"/etc/network/interfaces",
+		"/proc/loadavg",
+		"/proc/meminfo",
+		"/proc/mounts",
+		"/var/log/syslog",
+		"/var/log/messages",
+		"/var/log/auth.log",
+		"/var/log/cloud-init-output.log",
+	}
+	windowsTriagePaths = []string{
+		`C:\Windows\System32\drivers\etc\hosts`,
+		`C:\Windows\Panther\setupact.log`,
+		`C:\Windows\Logs\CBS\CBS.log`,
+		`C:\Windows\WindowsUpdate.log`,
+	}
+)
+
+// guestFileKey identifies a guest in the recent file lists.
+func guestFileKey(vm *api.VM) string {
+	return fmt.Sprintf("%s:%d", vm.Node, vm.ID)
+}
+
+// rememberGuestFile moves path to the front of the recently read files of a
+// guest.
+func (a *App) rememberGuestFile(vm *api.VM, path string) {
+	if a.guestFilePaths == nil {
+		a.guestFilePaths = make(map[string][]string)
+	}
+
+	key := guestFileKey(vm)
+	paths := []string{path}
+
+	for _, recent := range a.guestFilePaths[key] {
+		if recent != path && len(paths) < guestFileRecentLimit {
+			paths = append(paths, recent)
+		}
+	}
+
+	a.guestFilePaths[key] = paths
+}
+
+// guestFileExcerpt returns the end of content if it is longer than limit,
+// starting at a line, and whether it was cut.
+func guestFileExcerpt(content string, limit int) (string, bool) {
+	if len(content) <= limit {
+		return content, false
+	}
+
+	excerpt := content[len(content)-limit:]
+	if i := strings.IndexByte(excerpt, '\n'); i >= 0 && i < len(excerpt)-1 {
+		excerpt = excerpt[i+1:]
+	}
+
+	return excerpt, true
+}
+
+// showGuestFileBrowser lets the user read files inside a running QEMU guest
+// with its guest agent: a typed path, a recently read file or a common
+// triage file. Files are only read, never changed.
+func (a *App) showGuestFileBrowser(vm *api.VM, path string) {
+	if vm.Type != api.VMTypeQemu || vm.Status != api.VMStatusRunning || !vm.AgentEnabled {
+		a.header.ShowError("Reading files needs a running QEMU VM with the guest agent enabled")
+
+		return
+	}
+
+	suggestions := linuxTriagePaths
+	if api.IsWindowsGuest(vm) {
+		suggestions = windowsTriagePaths
+	}
+
+	recent := a.guestFilePaths[guestFileKey(vm)]
+
+	paths := append([]string{}, recent...)
+
+	for _, suggestion := range suggestions {
+		known := false
+
+		for _, r := range recent {
+			known = known || r == suggestion
+		}
+
+		if !known {
+			paths = append(paths, suggestion)
+		}
+	}
+
+	input := tview.NewInputField().
+		SetLabel("Path: ").
+		SetFieldWidth(0).
+		SetText(path)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetMainTextColor(theme.Colors.Primary)
+
+	for i, p := range paths {
+		label := tview.Escape(p)
+		if i < len(recent) {
+			label += theme.ReplaceSemanticTags(" [secondary](recent)[-]")
+		}
+
+		list.AddItem(label, "", 0, nil)
+	}
+
+	closeBrowser := func() {
+		a.removePageIfPresent("guestFiles")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	open := func(path string) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return
+		}
+
+		a.removePageIfPresent("guestFiles")
+		a.loadGuestFile(vm, path)
+	}
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeBrowser()
+
+			return nil
+		case tcell.KeyEnter:
+			open(input.GetText())
+
+			return nil
+		case tcell.KeyTab, tcell.KeyDown:
+			if len(paths) > 0 {
+				a.SetFocus(list)
+			}
+
+			return nil
+		}
+
+		return event
+	})
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		open(paths[index])
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeBrowser()
+
+			return nil
+		case event.Key() == tcell.KeyTab,
+			event.Key() == tcell.KeyUp && list.GetCurrentItem() == 0:
+			a.SetFocus(input)
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'e':
+			// Edit the path, e.g. to read a rotated log next to it
+			input.SetText(paths[list.GetCurrentItem()])
+			a.SetFocus(input)
+
+			return nil
+		}
+
+		return event
+	})
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(theme.ReplaceSemanticTags("[secondary]Enter: read, Tab: switch, e: edit path, Esc: close. The agent reads files only; it can't list directories.[-]"))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false).
+		AddItem(help, 2, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Read File in %s ", vm.Name)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, min(len(paths)+5, 22), 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	// Reopened from the file view: focus still returns to where the browser
+	// was first opened from
+	if !a.pages.HasPage("guestFile") {
+		a.lastFocus = a.GetFocus()
+	}
+
+	a.removePageIfPresent("guestFile")
+	a.removePageIfPresent("guestFiles")
+	a.pages.AddPage("guestFiles", modal, true, true)
+	a.SetFocus(input)
+}
+
+// loadGuestFile reads a file from a guest in the background and shows it.
+func (a *App) loadGuestFile(vm *api.VM, path string) {
+	a.header.ShowLoading(fmt.Sprintf("Reading %s from %s", path, vm.Name))
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		file, err := client.ReadGuestFile(vm, path)
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to read %s: %v", path, err))
+				a.showGuestFileBrowser(vm, path)
+
+				return
+			}
+
+			a.header.StopLoading()
+			a.rememberGuestFile(vm, path)
+			a.showGuestFile(vm, file)
+		})
+	}()
+}
+
+// showGuestFile shows a file read from a guest. Esc returns to the file
+// browser and r reads the file again.
+func (a *App) showGuestFile(vm *api.VM, file *api.GuestFile) {
+	text := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetWrap(false)
+	text.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	title := fmt.Sprintf(" %s (%s) ", file.Path, api.FormatBytes(file.BytesRead))
+
+	switch {
+	case file.IsBinary():
+		text.SetText("This file looks binary and isn't shown.")
+	case file.Content == "":
+		text.SetText("The file is empty.")
+	default:
+		excerpt, cut := guestFileExcerpt(file.Content, guestFileViewLimit)
+		text.SetText(excerpt)
+
+		if cut {
+			title = fmt.Sprintf(" %s (%s, showing the last %s) ", file.Path, api.FormatBytes(file.BytesRead), api.FormatBytes(int64(len(excerpt))))
+			text.ScrollToEnd()
+		}
+	}
+
+	if file.Truncated {
+		title = strings.TrimSuffix(title, " ") + " - only the first 16 MiB were read "
+	}
+
+	text.SetTitle(title + "; Esc: back, r: reload ")
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape,
+			event.Key() == tcell.KeyRune && event.Rune() == 'q':
+			a.showGuestFileBrowser(vm, file.Path)
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			a.removePageIfPresent("guestFile")
+			a.loadGuestFile(vm, file.Path)
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(text, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("guestFile")
+	a.pages.AddPage("guestFile", modal, true, true)
+	a.SetFocus(text)
+}
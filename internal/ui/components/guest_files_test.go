@@ -0,0 +1,36 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGuestFileExcerpt(t *testing.T) {
+	excerpt, cut := guestFileExcerpt("one\ntwo\n", 100)
+	assert.Equal(t, "one\ntwo\n", excerpt)
+	assert.False(t, cut)
+
+	// The end is kept, starting at a whole line
+	excerpt, cut = guestFileExcerpt("first line\nsecond\nthird\n", 12)
+	assert.Equal(t, "third\n", excerpt)
+	assert.True(t, cut)
+}
+
+func TestRememberGuestFile(t *testing.T) {
+	a := &App{}
+	vm := &api.VM{ID: 100, Node: "pve1"}
+
+	for i := 0; i < guestFileRecentLimit+2; i++ {
+		a.rememberGuestFile(vm, string(rune('a'+i)))
+	}
+
+	a.rememberGuestFile(vm, "e")
+
+	paths := a.guestFilePaths["pve1:100"]
+	assert.Len(t, paths, guestFileRecentLimit)
+	assert.Equal(t, []string{"e", "l", "k", "j"}, paths[:4])
+	assert.NotContains(t, paths[1:], "e")
+}
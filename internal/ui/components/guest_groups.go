@@ -0,0 +1,302 @@
+package components
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Guest group waits are checked this often and TCP connections attempted
+// with this timeout.
+const (
+	groupPollInterval = 2 * time.Second
+	groupDialTimeout  = 3 * time.Second
+)
+
+// formatGroupOrder lists the guests of a group in the order they are
+// started or stopped, e.g. "101 db → 102 app".
+func formatGroupOrder(steps []models.GroupStep) string {
+	parts := make([]string, len(steps))
+
+	for i, step := range steps {
+		parts[i] = strconv.Itoa(step.ID)
+		if step.VM != nil {
+			parts[i] += " " + step.VM.Name
+		}
+	}
+
+	return strings.Join(parts, " → ")
+}
+
+// showGuestGroups lists a start and a stop entry for each group; the chosen
+// one runs after a confirmation.
+func (a *App) showGuestGroups(groups []config.GuestGroup) {
+	if len(groups) == 0 {
+		a.header.ShowError("No guest groups are configured")
+
+		return
+	}
+
+	list := tview.NewList()
+	list.SetMainTextColor(theme.Colors.Primary).
+		SetSecondaryTextColor(theme.Colors.Secondary)
+	list.SetBorder(true).
+		SetTitle(" Guest Groups ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	closeList := func() {
+		a.removePageIfPresent("guestGroups")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	type entry struct {
+		group config.GuestGroup
+		stop  bool
+	}
+
+	var entries []entry
+
+	for _, group := range groups {
+		for _, stop := range []bool{false, true} {
+			verb := "Start"
+			if stop {
+				verb = "Stop"
+			}
+
+			steps := models.GroupSteps(group, models.GlobalState.OriginalVMs, stop)
+			list.AddItem(fmt.Sprintf("%s %s", verb, tview.Escape(group.Name)), "  "+tview.Escape(formatGroupOrder(steps)), 0, nil)
+
+			entries = append(entries, entry{group: group, stop: stop})
+		}
+	}
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		chosen := entries[index]
+
+		closeList()
+		a.confirmGuestGroup(chosen.group, chosen.stop)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeList()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, min(len(entries)*2+2, 22), 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("guestGroups")
+	a.pages.AddPage("guestGroups", modal, true, true)
+	a.SetFocus(list)
+}
+
+// confirmGuestGroup asks before starting or stopping a group, showing the
+// order of its guests. Groups with guests that are not loaded are refused.
+func (a *App) confirmGuestGroup(group config.GuestGroup, stop bool) {
+	steps := models.GroupSteps(group, models.GlobalState.OriginalVMs, stop)
+
+	for _, step := range steps {
+		if step.VM == nil {
+			a.showMessageSafe(fmt.Sprintf("Guest %d of group %s was not found in the cluster", step.ID, group.Name))
+
+			return
+		}
+	}
+
+	message := fmt.Sprintf("Start group %s?\n\nEach guest starts once the previous one is ready:\n%s", group.Name, formatGroupOrder(steps))
+	if stop {
+		message = fmt.Sprintf("Shut down group %s?\n\nEach guest shuts down once the previous one stopped:\n%s", group.Name, formatGroupOrder(steps))
+	}
+
+	a.showConfirmationDialog(message, func() {
+		a.runGuestGroup(group, steps, stop)
+	})
+}
+
+// runGuestGroup starts or shuts down the guests of a group one after the
+// other in the background and stops at the first guest that fails.
+func (a *App) runGuestGroup(group config.GuestGroup, steps []models.GroupStep, stop bool) {
+	verb := "Starting"
+	if stop {
+		verb = "Shutting down"
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		for i, step := range steps {
+			vm := step.VM
+
+			a.QueueUpdateDraw(func() {
+				a.header.ShowLoading(fmt.Sprintf("%s group %s: %s (%d/%d)", verb, group.Name, vm.Name, i+1, len(steps)))
+			})
+
+			models.GlobalState.SetVMPending(vm, verb)
+			a.QueueUpdateDraw(a.updateVMListWithSelectionPreservation)
+
+			var err error
+			if stop {
+				err = a.stopGroupGuest(vm, group.WaitTimeout())
+			} else {
+				err = a.startGroupGuest(step, group.WaitTimeout())
+			}
+
+			models.GlobalState.ClearVMPending(vm)
+
+			if err != nil {
+				a.QueueUpdateDraw(func() {
+					a.header.StopLoading()
+					a.showActionError(fmt.Sprintf("%s group %s stopped at %s", verb, group.Name, vm.Name), err)
+					a.manualRefresh()
+				})
+
+				return
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.ShowSuccess(fmt.Sprintf("%s group %s completed", verb, group.Name))
+			a.manualRefresh()
+		})
+	}()
+}
+
+// startGroupGuest starts a guest unless it is running and waits until it
+// passes the wait of its step.
+func (a *App) startGroupGuest(step models.GroupStep, timeout time.Duration) error {
+	vm := step.VM
+	deadline := time.Now().Add(timeout)
+
+	if vm.Status != api.VMStatusRunning {
+		if err := a.client.StartVM(vm); err != nil {
+			return err
+		}
+	}
+
+	running := waitUntil(deadline, func() bool {
+		fresh, err := a.client.RefreshVMStatus(vm)
+
+		return err == nil && fresh.Status == api.VMStatusRunning
+	})
+	if !running {
+		return fmt.Errorf("%s was not running after %s", vm.Name, timeout)
+	}
+
+	switch step.Wait {
+	case config.GroupWaitAgent:
+		if !waitUntil(deadline, func() bool { return a.client.PingGuestAgent(vm) == nil }) {
+			return fmt.Errorf("the guest agent of %s did not answer within %s", vm.Name, timeout)
+		}
+	case config.GroupWaitTCP:
+		if err := a.waitGroupPort(step, deadline); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitGroupPort waits until the port of a step accepts TCP connections on
+// its host or, without one, on the IP reported for the guest.
+func (a *App) waitGroupPort(step models.GroupStep, deadline time.Time) error {
+	vm := step.VM
+	host := step.Host
+
+	var address string
+
+	open := waitUntil(deadline, func() bool {
+		if host == "" {
+			// QEMU guests report their IP once the guest agent runs
+			if err := a.client.EnrichVM(vm); err != nil || vm.IP == "" {
+				return false
+			}
+
+			host = vm.IP
+		}
+
+		address = net.JoinHostPort(host, strconv.Itoa(step.Port))
+
+		conn, err := net.DialTimeout("tcp", address, groupDialTimeout)
+		if err != nil {
+			return false
+		}
+
+		_ = conn.Close()
+
+		return true
+	})
+
+	switch {
+	case open:
+		return nil
+	case host == "":
+		return fmt.Errorf("no IP address was reported for %s; set host for its tcp wait", vm.Name)
+	default:
+		return fmt.Errorf("%s did not accept connections before the timeout", address)
+	}
+}
+
+// stopGroupGuest shuts down a running guest and waits until it stopped.
+func (a *App) stopGroupGuest(vm *api.VM, timeout time.Duration) error {
+	if vm.Status != api.VMStatusRunning {
+		return nil
+	}
+
+	models.ExpectGuestStop(vm, time.Now())
+
+	if err := a.client.ShutdownVM(vm); err != nil {
+		return err
+	}
+
+	stopped := waitUntil(time.Now().Add(timeout), func() bool {
+		fresh, err := a.client.RefreshVMStatus(vm)
+
+		return err == nil && fresh.Status != api.VMStatusRunning
+	})
+	if !stopped {
+		return fmt.Errorf("%s did not shut down within %s", vm.Name, timeout)
+	}
+
+	return nil
+}
+
+// waitUntil calls check every groupPollInterval until it returns true or
+// the deadline passed, and reports whether it returned true.
+func waitUntil(deadline time.Time, check func() bool) bool {
+	for {
+		if check() {
+			return true
+		}
+
+		if time.Now().Add(groupPollInterval).After(deadline) {
+			return false
+		}
+
+		time.Sleep(groupPollInterval)
+	}
+}
@@ -0,0 +1,81 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// applyLoadedGuests fills the guest list once the guests fetched in the
+// background at startup arrive. Nodes are already listed at that point.
+func (a *App) applyLoadedGuests(err error) {
+	if err != nil {
+		a.header.ShowError(fmt.Sprintf("Failed to load guests: %v", err))
+
+		return
+	}
+
+	defer a.openStartGuest()
+
+	cluster := a.client.Cluster
+	if cluster == nil {
+		return
+	}
+
+	var vms []*api.VM
+
+	for _, node := range cluster.Nodes {
+		if node == nil {
+			continue
+		}
+
+		for _, vm := range node.VMs {
+			if vm != nil {
+				vms = append(vms, vm)
+			}
+		}
+	}
+
+	a.logger.Debug("Loaded %d guests in the background", len(vms))
+
+	models.GlobalState.OriginalVMs = make([]*api.VM, len(vms))
+	copy(models.GlobalState.OriginalVMs, vms)
+
+	// Baseline for detecting guest restarts on later refreshes
+	models.RecordUptimes(vms, time.Now())
+
+	if vmState := models.GlobalState.GetSearchState(api.PageGuests); vmState != nil && vmState.Filter != "" {
+		models.FilterVMs(vmState.Filter)
+	} else {
+		models.GlobalState.FilteredVMs = make([]*api.VM, len(vms))
+		copy(models.GlobalState.FilteredVMs, vms)
+	}
+
+	a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+
+	if vm := a.vmList.GetSelectedVM(); vm != nil {
+		a.vmDetails.Update(vm)
+	}
+
+	if node := a.nodeList.GetSelectedNode(); node != nil {
+		a.nodeDetails.Update(node, cluster.Nodes)
+	}
+
+	a.clusterStatus.Update(cluster)
+	a.refreshPluginColumns()
+	a.applyNeighborIPs()
+	a.notifyAlerts()
+
+	if a.client.LazyEnrichment() {
+		// Guest agent data is loaded for the guests that get selected
+		a.header.ShowSuccess("Guests loaded")
+		a.enrichRecentGuests()
+		a.warmUpGuests()
+
+		return
+	}
+
+	a.header.ShowLoading("Loading guest agent data")
+}
@@ -0,0 +1,313 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// guestProcessesInterval is how often the process list refreshes itself.
+const guestProcessesInterval = 5 * time.Second
+
+// guestProcessReader returns a function that samples the processes of a
+// running guest: with the guest agent for Linux QEMU VMs and with
+// `pct exec` over SSH for containers. It returns an error when neither is
+// possible.
+func (a *App) guestProcessReader(vm *api.VM) (func() ([]api.GuestProcess, error), error) {
+	if vm.Status != api.VMStatusRunning {
+		return nil, fmt.Errorf("%s is not running", vm.Name)
+	}
+
+	client := a.client
+
+	if vm.Type == api.VMTypeQemu {
+		if !vm.AgentEnabled {
+			return nil, fmt.Errorf("the guest agent is not enabled for %s", vm.Name)
+		}
+
+		if api.IsWindowsGuest(vm) {
+			return nil, fmt.Errorf("processes can only be listed in Linux guests")
+		}
+
+		return func() ([]api.GuestProcess, error) {
+			return client.GetGuestProcesses(vm)
+		}, nil
+	}
+
+	user := a.config.SSHUser
+	if user == "" {
+		return nil, fmt.Errorf("listing container processes needs an SSH user")
+	}
+
+	var nodeIP string
+
+	for _, node := range client.Cluster.Nodes {
+		if node.Name == vm.Node {
+			nodeIP = a.nodeAddress(node)
+
+			break
+		}
+	}
+
+	return func() ([]api.GuestProcess, error) {
+		output, err := ssh.ReadContainerProcesses(a.ctx, ssh.NewDefaultExecutor(), user, nodeIP, vm.ID, ssh.NodeOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list processes: %w", err)
+		}
+
+		return api.ParseGuestProcesses(output)
+	}, nil
+}
+
+// showGuestProcesses lists the processes running inside a guest with their
+// CPU and memory usage, refreshing every few seconds. c and m sort by CPU
+// or memory, a toggles the auto-refresh and r refreshes now.
+func (a *App) showGuestProcesses(vm *api.VM) {
+	read, err := a.guestProcessReader(vm)
+	if err != nil {
+		a.header.ShowError(err.Error())
+
+		return
+	}
+
+	var (
+		processes []api.GuestProcess
+		loadErr   error
+		updated   time.Time
+		byMemory  bool
+		auto      = true
+		loading   bool
+		open      = true
+		timer     *time.Timer
+	)
+
+	table := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	status := tview.NewTextView().SetDynamicColors(true)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(status, 2, 0, false)
+	content.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	render := func() {
+		// Keep the selected process selected across refreshes
+		selectedPID := 0
+		if row, _ := table.GetSelection(); row >= 1 && row <= table.GetRowCount()-1 {
+			if ref, ok := table.GetCell(row, 0).GetReference().(int); ok {
+				selectedPID = ref
+			}
+		}
+
+		table.Clear()
+
+		for col, header := range []string{"PID", "Name", "State", "CPU %", "Memory", "Mem %", "Threads"} {
+			cell := tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false)
+			if col >= 3 {
+				cell.SetAlign(tview.AlignRight)
+			}
+
+			table.SetCell(0, col, cell)
+		}
+
+		selected := 1
+
+		for i, process := range processes {
+			row := i + 1
+
+			cells := []string{
+				fmt.Sprintf("%d", process.PID),
+				process.Name,
+				process.State,
+				fmt.Sprintf("%.1f", process.CPU),
+				api.FormatBytes(process.RSS),
+				fmt.Sprintf("%.1f", process.Memory),
+				fmt.Sprintf("%d", process.Threads),
+			}
+
+			for col, text := range cells {
+				cell := tview.NewTableCell(tview.Escape(text)).SetTextColor(theme.Colors.Primary)
+
+				switch col {
+				case 0:
+					cell.SetReference(process.PID)
+				case 1:
+					cell.SetExpansion(1)
+				case 3:
+					cell.SetTextColor(theme.GetUsageColor(process.CPU))
+				}
+
+				if col >= 3 {
+					cell.SetAlign(tview.AlignRight)
+				}
+
+				table.SetCell(row, col, cell)
+			}
+
+			if process.PID == selectedPID {
+				selected = row
+			}
+		}
+
+		if len(processes) > 0 {
+			table.Select(selected, 0)
+		}
+
+		sortName := "CPU"
+		if byMemory {
+			sortName = "memory"
+		}
+
+		content.SetTitle(fmt.Sprintf(" Processes in %s (%d), by %s ", vm.Name, len(processes), sortName))
+
+		refresh := "off"
+		if auto {
+			refresh = fmt.Sprintf("every %s", guestProcessesInterval)
+		}
+
+		line := fmt.Sprintf("[secondary]Auto-refresh %s", refresh)
+		if !updated.IsZero() {
+			line += ", updated " + updated.Format("15:04:05")
+		}
+
+		switch {
+		case loading:
+			line += " [info]refreshing..."
+		case loadErr != nil:
+			line += " [error]" + tview.Escape(loadErr.Error())
+		}
+
+		status.SetText(theme.ReplaceSemanticTags(line + "[-]\n[secondary]c: sort by CPU, m: by memory, a: auto-refresh, r: refresh, Esc: close[-]"))
+	}
+
+	var load func()
+
+	load = func() {
+		if loading || !open {
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		loading = true
+
+		render()
+
+		go func() {
+			defer crash.Recover()
+
+			result, err := read()
+
+			a.QueueUpdateDraw(func() {
+				loading = false
+
+				if !open {
+					return
+				}
+
+				loadErr = err
+				if err == nil {
+					processes, updated = result, time.Now()
+					api.SortGuestProcesses(processes, byMemory)
+				}
+
+				render()
+
+				if auto {
+					timer = time.AfterFunc(guestProcessesInterval, func() {
+						a.QueueUpdateDraw(func() {
+							if auto {
+								load()
+							}
+						})
+					})
+				}
+			})
+		}()
+	}
+
+	closeList := func() {
+		open = false
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		a.removePageIfPresent("guestProcesses")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeList()
+
+			return nil
+		}
+
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'q':
+			closeList()
+		case 'c', 'm':
+			byMemory = event.Rune() == 'm'
+			api.SortGuestProcesses(processes, byMemory)
+			render()
+			table.Select(1, 0)
+			table.ScrollToBeginning()
+		case 'a':
+			auto = !auto
+			if auto {
+				load()
+			} else if timer != nil {
+				timer.Stop()
+			}
+
+			render()
+		case 'r':
+			load()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("guestProcesses")
+	a.pages.AddPage("guestProcesses", modal, true, true)
+	a.SetFocus(table)
+
+	load()
+}
@@ -0,0 +1,91 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// maxRestartsListed limits the guests named in a restart or stop notification.
+const maxRestartsListed = 3
+
+// trackGuestRestarts records guest uptimes after a refresh and remembers
+// the guests that restarted since the previous refresh, e.g. after crashing
+// and being restarted by HA, and the guests that stopped unexpectedly. Call
+// it before rebuilding the guest list so the restart badges are drawn.
+func (a *App) trackGuestRestarts(vms []*api.VM) {
+	restarted, stopped := models.RecordUptimes(vms, time.Now())
+
+	a.detectedRestarts = append(a.detectedRestarts, restarted...)
+	a.detectedStops = append(a.detectedStops, stopped...)
+}
+
+// showRefreshSuccess reports a finished refresh, warning about guests that
+// restarted or stopped unexpectedly instead when any were detected.
+func (a *App) showRefreshSuccess() {
+	restarted, stopped := a.detectedRestarts, a.detectedStops
+	a.detectedRestarts, a.detectedStops = nil, nil
+
+	a.loadLatestBackups()
+	a.loadNodeClocks()
+	a.scanSnapshots(false, nil)
+	a.applyNeighborIPs()
+	a.notifyAlerts()
+
+	if a.announcementsPending {
+		a.announcementsPending = false
+		a.loadAnnouncements(true)
+	}
+
+	if len(restarted) == 0 && len(stopped) == 0 {
+		a.header.ShowSuccess("Data refreshed successfully")
+
+		return
+	}
+
+	var warnings []string
+
+	if len(stopped) > 0 {
+		models.GetUILogger().Info("Detected unexpected guest stops: %s", guestNames(stopped))
+		warnings = append(warnings, guestChangeWarning(stopped, "stopped unexpectedly"))
+	}
+
+	if len(restarted) > 0 {
+		models.GetUILogger().Info("Detected guest restarts: %s", guestNames(restarted))
+		warnings = append(warnings, guestChangeWarning(restarted, "restarted since the last refresh"))
+	}
+
+	a.header.ShowWarning(strings.Join(warnings, "; ") + " (see Alerts in the global menu)")
+}
+
+// guestChangeWarning describes guests that changed state, naming up to
+// maxRestartsListed of them.
+func guestChangeWarning(vms []*api.VM, change string) string {
+	if len(vms) == 1 {
+		vm := vms[0]
+
+		return fmt.Sprintf("Guest %d (%s) on %s %s", vm.ID, vm.Name, vm.Node, change)
+	}
+
+	return fmt.Sprintf("%d guests %s: %s", len(vms), change, guestNames(vms))
+}
+
+// guestNames lists up to maxRestartsListed guests as "ID (name)".
+func guestNames(vms []*api.VM) string {
+	names := make([]string, 0, maxRestartsListed+1)
+
+	for i, vm := range vms {
+		if i == maxRestartsListed {
+			names = append(names, fmt.Sprintf("and %d more", len(vms)-maxRestartsListed))
+
+			break
+		}
+
+		names = append(names, fmt.Sprintf("%d (%s)", vm.ID, vm.Name))
+	}
+
+	return strings.Join(names, ", ")
+}
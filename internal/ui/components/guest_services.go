@@ -0,0 +1,241 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// guestServiceTimeout bounds running a guest service command with the guest
+// agent.
+const guestServiceTimeout = 60 * time.Second
+
+// guestServices returns the configured services offered for a guest, or
+// nil if commands can't run in it: QEMU VMs need the guest agent and
+// containers an SSH user.
+func (a *App) guestServices(vm *api.VM) []config.GuestService {
+	if vm.Status != api.VMStatusRunning ||
+		(vm.Type == api.VMTypeQemu && !vm.AgentEnabled) ||
+		(vm.Type == api.VMTypeLXC && a.config.SSHUser == "") {
+		return nil
+	}
+
+	var services []config.GuestService
+
+	for _, service := range a.config.GuestServices {
+		if service.Matches(vm.ID, vm.Name) {
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+// showGuestServices lists the services configured for a guest; the chosen
+// one runs after a confirmation.
+func (a *App) showGuestServices(vm *api.VM) {
+	services := a.guestServices(vm)
+	if len(services) == 0 {
+		a.header.ShowError(fmt.Sprintf("No guest services are configured for %s", vm.Name))
+
+		return
+	}
+
+	list := tview.NewList()
+	list.SetMainTextColor(theme.Colors.Primary).
+		SetSecondaryTextColor(theme.Colors.Secondary)
+	list.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Services of %s ", vm.Name)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	closeList := func() {
+		a.removePageIfPresent("guestServices")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	for i, service := range services {
+		shortcut := rune(0)
+		if i < 9 {
+			shortcut = rune('1' + i)
+		}
+
+		list.AddItem(tview.Escape(service.Name), "  $ "+tview.Escape(service.Command), shortcut, nil)
+	}
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		service := services[index]
+
+		closeList()
+		a.showConfirmationDialog(
+			fmt.Sprintf("Run '%s' in %s (%d)?\n\n$ %s", service.Name, vm.Name, vm.ID, service.Command),
+			func() {
+				a.runGuestService(vm, service)
+			},
+		)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeList()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, min(len(services)*2+2, 22), 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("guestServices")
+	a.pages.AddPage("guestServices", modal, true, true)
+	a.SetFocus(list)
+}
+
+// execGuestService runs a service command inside a guest and returns its
+// output and exit code: with the guest agent for QEMU VMs, through cmd on
+// Windows, and with `pct exec` over SSH for containers.
+func (a *App) execGuestService(vm *api.VM, service config.GuestService) (string, int, error) {
+	if vm.Type == api.VMTypeQemu {
+		command := []string{"sh", "-c", service.Command}
+		if api.IsWindowsGuest(vm) {
+			command = []string{"cmd", "/c", service.Command}
+		}
+
+		result, err := a.client.GuestExec(vm, command, guestServiceTimeout)
+		if err != nil {
+			return "", 0, err
+		}
+
+		return strings.TrimRight(result.Stdout, "\n") + "\n" + result.Stderr, result.ExitCode, nil
+	}
+
+	var nodeIP string
+
+	for _, node := range a.client.Cluster.Nodes {
+		if node.Name == vm.Node {
+			nodeIP = a.nodeAddress(node)
+
+			break
+		}
+	}
+
+	output, err := ssh.RunInContainer(a.ctx, ssh.NewDefaultExecutor(), a.config.SSHUser, nodeIP, vm.ID, service.Command, ssh.NodeOptions())
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(output), exitErr.ExitCode(), nil
+	}
+
+	return string(output), 0, err
+}
+
+// runGuestService runs a service command in the background and shows its
+// output.
+func (a *App) runGuestService(vm *api.VM, service config.GuestService) {
+	a.header.ShowLoading(fmt.Sprintf("Running '%s' in %s", service.Name, vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		output, code, err := a.execGuestService(vm, service)
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.showActionError(fmt.Sprintf("Failed to run '%s' in %s", service.Name, vm.Name), err)
+
+				return
+			}
+
+			models.GetUILogger().Debug("Guest service %q in guest %d exited with code %d", service.Name, vm.ID, code)
+
+			if code != 0 {
+				a.header.ShowError(fmt.Sprintf("'%s' failed in %s with exit code %d", service.Name, vm.Name, code))
+			} else {
+				a.header.ShowSuccess(fmt.Sprintf("'%s' completed in %s", service.Name, vm.Name))
+			}
+
+			a.showGuestServiceOutput(vm, service, output, code)
+		})
+	}()
+}
+
+// showGuestServiceOutput shows the output of a service command. r runs it
+// again.
+func (a *App) showGuestServiceOutput(vm *api.VM, service config.GuestService, output string, code int) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		output = "(no output)"
+	}
+
+	text := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetText(fmt.Sprintf("$ %s\n\n%s", service.Command, output))
+	text.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s in %s (exit code %d); r: run again, Esc: close ", service.Name, vm.Name, code)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	if code != 0 {
+		text.SetBorderColor(theme.Colors.Error)
+	}
+
+	closeOutput := func() {
+		a.removePageIfPresent("guestServiceOutput")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape,
+			event.Key() == tcell.KeyRune && event.Rune() == 'q':
+			closeOutput()
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			closeOutput()
+			a.runGuestService(vm, service)
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(text, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("guestServiceOutput")
+	a.pages.AddPage("guestServiceOutput", modal, true, true)
+	a.SetFocus(text)
+}
@@ -20,6 +20,7 @@ type Header struct {
 	stopLoading    chan bool
 	app            *tview.Application
 	currentProfile string // Track the current active profile
+	updateHint     string // Shown after the profile, e.g. "v1.1.0 available"
 }
 
 var _ HeaderComponent = (*Header)(nil)
@@ -94,7 +95,7 @@ func (h *Header) ShowSuccess(message string) {
 	// Mark not loading before changing text to prevent race with animateLoading
 	h.isLoading = false
 	h.StopLoading()
-	h.SetText(theme.ReplaceSemanticTags("[success]✓ " + message + "[-]"))
+	h.SetText(theme.ReplaceSemanticTags("[success]" + theme.Icon("✓", "OK:") + " " + message + "[-]"))
 
 	// Clear the message after 2 seconds (shorter than error messages)
 	h.clearMessageAfterDelay(2 * time.Second)
@@ -104,7 +105,7 @@ func (h *Header) ShowSuccess(message string) {
 func (h *Header) ShowError(message string) {
 	h.isLoading = false
 	h.StopLoading()
-	h.SetText(theme.ReplaceSemanticTags("[error]✗ " + message + "[-]"))
+	h.SetText(theme.ReplaceSemanticTags("[error]" + theme.Icon("✗", "Error:") + " " + message + "[-]"))
 
 	// Clear the message after 3 seconds
 	h.clearMessageAfterDelay(3 * time.Second)
@@ -114,7 +115,7 @@ func (h *Header) ShowError(message string) {
 func (h *Header) ShowWarning(message string) {
 	h.isLoading = false
 	h.StopLoading()
-	h.SetText(theme.ReplaceSemanticTags("[warning]⚠ " + message + "[-]"))
+	h.SetText(theme.ReplaceSemanticTags("[warning]" + theme.Icon("⚠", "Warning:") + " " + message + "[-]"))
 
 	// Clear the message after 3 seconds
 	h.clearMessageAfterDelay(3 * time.Second)
@@ -122,11 +123,16 @@ func (h *Header) ShowWarning(message string) {
 
 // formatProfileText creates the formatted header text for a profile.
 func (h *Header) formatProfileText(profileName string) string {
-	if profileName == "" {
-		return appName
+	text := appName
+	if profileName != "" {
+		text = fmt.Sprintf("%s [info][%s[][-]", appName, profileName)
 	}
 
-	return theme.ReplaceSemanticTags(fmt.Sprintf("%s [info][%s[][-]", appName, profileName))
+	if h.updateHint != "" {
+		text += fmt.Sprintf("  [secondary]%s %s[-]", theme.Icon("⬆", "^"), tview.Escape(h.updateHint))
+	}
+
+	return theme.ReplaceSemanticTags(text)
 }
 
 // ShowActiveProfile displays the active profile in the header.
@@ -137,6 +143,16 @@ func (h *Header) ShowActiveProfile(profileName string) {
 	h.SetText(h.formatProfileText(profileName))
 }
 
+// SetUpdateHint shows a short note next to the profile, such as an
+// available update. An empty hint removes it.
+func (h *Header) SetUpdateHint(hint string) {
+	h.updateHint = hint
+
+	if !h.isLoading {
+		h.restoreProfile()
+	}
+}
+
 // GetCurrentProfile returns the currently connected profile name.
 func (h *Header) GetCurrentProfile() string {
 	return h.currentProfile
@@ -158,12 +174,8 @@ func (h *Header) clearMessageAfterDelay(delay time.Duration) {
 				if h.isLoading {
 					return
 				}
-				// Restore the current profile if it exists, otherwise reset to default
-				if h.currentProfile != "" {
-					h.restoreProfile()
-				} else {
-					h.SetText(appName)
-				}
+				// Restore the current profile and update hint, if any
+				h.restoreProfile()
 			})
 		}
 	}()
@@ -0,0 +1,355 @@
+package components
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// heatmapCellWidth is the number of columns of a guest cell, the cell and
+// a space.
+const heatmapCellWidth = 2
+
+// heatmapLine is a line of the heatmap: the header of a node, or a row of
+// its guests given as indexes into the guests of the heatmap.
+type heatmapLine struct {
+	node   *models.HeatmapNode
+	guests []int
+}
+
+// heatmapLayout splits every node into a header line and rows of at most
+// columns guests.
+func heatmapLayout(groups []models.HeatmapNode, columns int) []heatmapLine {
+	columns = max(1, columns)
+
+	var (
+		lines []heatmapLine
+		next  int
+	)
+
+	for i := range groups {
+		lines = append(lines, heatmapLine{node: &groups[i]})
+
+		for start := 0; start < len(groups[i].Guests); start += columns {
+			count := min(columns, len(groups[i].Guests)-start)
+			row := make([]int, count)
+
+			for j := range row {
+				row[j] = next + j
+			}
+
+			lines = append(lines, heatmapLine{guests: row})
+			next += count
+		}
+	}
+
+	return lines
+}
+
+// heatmapView draws every guest as a cell colored by its CPU or memory
+// usage, grouped by node, with the selected guest described on the last
+// line.
+type heatmapView struct {
+	*tview.Box
+
+	groups   []models.HeatmapNode
+	guests   []*api.VM // Guests of all groups in display order
+	metric   models.HeatmapMetric
+	selected int
+	offset   int // First line drawn
+	columns  int // Guests per row at the last draw
+	onSelect func(vm *api.VM)
+}
+
+// newHeatmapView creates a heatmap of the guests of groups.
+func newHeatmapView(groups []models.HeatmapNode) *heatmapView {
+	h := &heatmapView{Box: tview.NewBox(), metric: models.HeatmapCPU, columns: 1}
+	h.setGroups(groups)
+
+	return h
+}
+
+// setGroups replaces the guests shown, keeping the selected guest if it is
+// still there.
+func (h *heatmapView) setGroups(groups []models.HeatmapNode) {
+	previous := h.selectedVM()
+
+	h.groups = groups
+	h.guests = h.guests[:0]
+	h.selected = 0
+
+	for _, group := range groups {
+		h.guests = append(h.guests, group.Guests...)
+	}
+
+	for i, vm := range h.guests {
+		if previous != nil && vm.ID == previous.ID {
+			h.selected = i
+		}
+	}
+}
+
+// selectedVM returns the selected guest, or nil if there are no guests.
+func (h *heatmapView) selectedVM() *api.VM {
+	if h.selected < 0 || h.selected >= len(h.guests) {
+		return nil
+	}
+
+	return h.guests[h.selected]
+}
+
+// Draw draws the node headers and guest cells that fit, scrolled so the
+// selected guest is visible, and the status line.
+func (h *heatmapView) Draw(screen tcell.Screen) {
+	h.DrawForSubclass(screen, h)
+
+	x, y, width, height := h.GetInnerRect()
+	if width <= 0 || height <= 1 {
+		return
+	}
+
+	h.columns = max(1, width/heatmapCellWidth)
+	lines := heatmapLayout(h.groups, h.columns)
+
+	// Keep the selected guest and, when at the top of its node, its header
+	// in view
+	rows := height - 1
+	selectedLine, _ := h.position(lines)
+
+	first := selectedLine
+	if selectedLine > 0 && lines[selectedLine-1].node != nil {
+		first--
+	}
+
+	h.offset = max(0, min(h.offset, first))
+	if selectedLine >= h.offset+rows {
+		h.offset = selectedLine - rows + 1
+	}
+
+	for i := h.offset; i < len(lines) && i < h.offset+rows; i++ {
+		line := lines[i]
+		lineY := y + i - h.offset
+
+		if line.node != nil {
+			tview.Print(screen, h.nodeHeader(line.node), x, lineY, width, tview.AlignLeft, theme.Colors.Primary)
+
+			continue
+		}
+
+		for col, index := range line.guests {
+			r, style := h.cell(h.guests[index])
+			if index == h.selected {
+				style = style.Reverse(true)
+			}
+
+			screen.SetContent(x+col*heatmapCellWidth, lineY, r, nil, style)
+		}
+	}
+
+	tview.Print(screen, h.status(), x, y+height-1, width, tview.AlignLeft, theme.Colors.Secondary)
+}
+
+// position returns the line and column of the selected guest.
+func (h *heatmapView) position(lines []heatmapLine) (int, int) {
+	for i, line := range lines {
+		for col, index := range line.guests {
+			if index == h.selected {
+				return i, col
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+// cell returns the character and style of a guest: a block in the usage
+// color for running guests and a dot for the others. Accessible mode
+// shows the usage in tens of percent instead, so it can be read without
+// colors.
+func (h *heatmapView) cell(vm *api.VM) (rune, tcell.Style) {
+	usage, running := models.GuestUsage(vm, h.metric)
+	if !running {
+		return '·', tcell.StyleDefault.Foreground(theme.Colors.Secondary)
+	}
+
+	style := tcell.StyleDefault.Foreground(theme.GetUsageColor(usage))
+
+	if theme.IsAccessible() {
+		return rune('0' + int(math.Min(9, math.Max(0, usage/10)))), style
+	}
+
+	return '■', style
+}
+
+// nodeHeader describes a node with its own usage and running guests.
+func (h *heatmapView) nodeHeader(group *models.HeatmapNode) string {
+	running := 0
+
+	for _, vm := range group.Guests {
+		if vm.Status == api.VMStatusRunning {
+			running++
+		}
+	}
+
+	header := fmt.Sprintf("[%s::b]%s[-::-]", theme.ColorToTag(theme.Colors.HeaderText), tview.Escape(group.Node.Name))
+
+	if !group.Node.Online {
+		return header + theme.ReplaceSemanticTags("  [error]offline[-]")
+	}
+
+	usage := models.NodeUsage(group.Node, h.metric)
+
+	return header + fmt.Sprintf("  %s %s  [%s]%d/%d guests running[-]", h.metric,
+		usageGauge(usage, gaugeWidth), theme.ColorToTag(theme.Colors.Secondary), running, len(group.Guests))
+}
+
+// status describes the selected guest.
+func (h *heatmapView) status() string {
+	vm := h.selectedVM()
+	if vm == nil {
+		return "No guests"
+	}
+
+	text := fmt.Sprintf("%d %s on %s: ", vm.ID, tview.Escape(vm.Name), vm.Node)
+
+	if vm.Status != api.VMStatusRunning {
+		return text + vm.Status
+	}
+
+	cpu, _ := models.GuestUsage(vm, models.HeatmapCPU)
+	memory, _ := models.GuestUsage(vm, models.HeatmapMemory)
+
+	return text + fmt.Sprintf("CPU %.1f%%, memory %.1f%%", cpu, memory)
+}
+
+// moveRow selects the guest in the same column of the next row with
+// guests above (-1) or below (1), or the last guest of a shorter row.
+func (h *heatmapView) moveRow(direction int) {
+	lines := heatmapLayout(h.groups, h.columns)
+	line, col := h.position(lines)
+
+	for i := line + direction; i >= 0 && i < len(lines); i += direction {
+		if guests := lines[i].guests; len(guests) > 0 {
+			h.selected = guests[min(col, len(guests)-1)]
+
+			return
+		}
+	}
+}
+
+// InputHandler moves the selection with the arrow keys and the vi keys and
+// selects the guest with Enter.
+func (h *heatmapView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return h.WrapInputHandler(func(event *tcell.EventKey, _ func(p tview.Primitive)) {
+		if len(h.guests) == 0 {
+			return
+		}
+
+		key := event.Key()
+		if key == tcell.KeyRune {
+			switch event.Rune() {
+			case 'h':
+				key = tcell.KeyLeft
+			case 'l':
+				key = tcell.KeyRight
+			case 'k':
+				key = tcell.KeyUp
+			case 'j':
+				key = tcell.KeyDown
+			}
+		}
+
+		switch key {
+		case tcell.KeyLeft:
+			h.selected = max(0, h.selected-1)
+		case tcell.KeyRight:
+			h.selected = min(len(h.guests)-1, h.selected+1)
+		case tcell.KeyUp:
+			h.moveRow(-1)
+		case tcell.KeyDown:
+			h.moveRow(1)
+		case tcell.KeyHome:
+			h.selected = 0
+		case tcell.KeyEnd:
+			h.selected = len(h.guests) - 1
+		case tcell.KeyEnter:
+			if h.onSelect != nil {
+				h.onSelect(h.selectedVM())
+			}
+		}
+	})
+}
+
+// showHeatmap shows every guest as a cell colored by its CPU or memory
+// usage, grouped by node. Enter jumps to the selected guest.
+func (a *App) showHeatmap() {
+	groups := models.GroupHeatmap(models.GlobalState.OriginalNodes, models.GlobalState.OriginalVMs)
+	if len(groups) == 0 {
+		a.header.ShowError("No node data loaded")
+
+		return
+	}
+
+	lastFocus := a.GetFocus()
+	view := newHeatmapView(groups)
+	view.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	view.SetBorderPadding(0, 0, 1, 1)
+
+	setTitle := func() {
+		view.SetTitle(fmt.Sprintf(" Heatmap: %s (c: CPU, m: memory, r: reload, Enter: go to guest) ", view.metric))
+	}
+
+	setTitle()
+
+	closeHeatmap := func() {
+		a.removePageIfPresent("heatmap")
+
+		if lastFocus != nil {
+			a.SetFocus(lastFocus)
+		}
+	}
+
+	view.onSelect = func(vm *api.VM) {
+		a.removePageIfPresent("heatmap")
+		a.selectGuest(vm)
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeHeatmap()
+
+			return nil
+		}
+
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'c':
+			view.metric = models.HeatmapCPU
+		case 'm':
+			view.metric = models.HeatmapMemory
+		case 'r':
+			view.setGroups(models.GroupHeatmap(models.GlobalState.OriginalNodes, models.GlobalState.OriginalVMs))
+		default:
+			return event
+		}
+
+		setTitle()
+
+		return nil
+	})
+
+	a.removePageIfPresent("heatmap")
+	a.pages.AddPage("heatmap", view, true, true)
+	a.SetFocus(view)
+}
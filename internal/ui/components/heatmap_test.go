@@ -0,0 +1,59 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestHeatmapLayout(t *testing.T) {
+	guests := func(ids ...int) []*api.VM {
+		vms := make([]*api.VM, len(ids))
+		for i, id := range ids {
+			vms[i] = &api.VM{ID: id}
+		}
+
+		return vms
+	}
+
+	groups := []models.HeatmapNode{
+		{Node: &api.Node{Name: "pve1"}, Guests: guests(100, 101, 102, 103, 104)},
+		{Node: &api.Node{Name: "pve2"}},
+		{Node: &api.Node{Name: "pve3"}, Guests: guests(200, 201)},
+	}
+
+	lines := heatmapLayout(groups, 2)
+	require.Len(t, lines, 7)
+	assert.Equal(t, "pve1", lines[0].node.Node.Name)
+	assert.Equal(t, []int{0, 1}, lines[1].guests)
+	assert.Equal(t, []int{4}, lines[3].guests)
+	assert.Equal(t, "pve2", lines[4].node.Node.Name)
+	assert.Equal(t, "pve3", lines[5].node.Node.Name)
+	assert.Equal(t, []int{5, 6}, lines[6].guests)
+
+	view := newHeatmapView(groups)
+	view.columns = 2
+
+	// Moving down keeps the column, or takes the last guest of a shorter
+	// row, and skips headers and nodes without guests
+	view.selected = 1
+	view.moveRow(1)
+	assert.Equal(t, 3, view.selected)
+	view.moveRow(1)
+	assert.Equal(t, 4, view.selected)
+	view.moveRow(1)
+	assert.Equal(t, 5, view.selected)
+	view.moveRow(1)
+	assert.Equal(t, 5, view.selected)
+	view.moveRow(-1)
+	assert.Equal(t, 4, view.selected)
+
+	// Reloading keeps the selected guest
+	view.selected = 6
+	view.setGroups(groups[2:])
+	assert.Equal(t, 201, view.selectedVM().ID)
+}
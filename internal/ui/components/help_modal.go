@@ -66,9 +66,14 @@ func buildHelpText(keys config.KeyBindings) string {
 		{Key: keys.NodesPage, Desc: "Switch to Nodes tab"},
 		{Key: keys.GuestsPage, Desc: "Switch to Guests tab"},
 		{Key: keys.TasksPage, Desc: "Switch to Tasks tab"},
+		{Key: keys.ToggleZoom, Desc: "Show focused list/details panel full-screen"},
 		{Cat: ""}, // Spacer
 		{Cat: "[warning]Actions[-]"},
 		{Key: keys.Search, Desc: "Search/Filter current list"},
+		{Key: keys.GlobalSearch, Desc: "Search nodes, guests, storages and tasks"},
+		{Key: keys.Yank, Desc: "Copy selected value (node, VMID, IP, UPID) to clipboard"},
+		{Key: keys.NodeGuests, Desc: "Guests of the selected node / back to the node"},
+		{Key: keys.Sparklines, Desc: "Show/hide CPU and memory sparklines in the guest list"},
 		{Key: keys.Shell, Desc: "Open SSH shell (node/guest)"},
 		{Key: keys.VNC, Desc: "Open VNC console (node/guest)"},
 		{Key: keys.Menu, Desc: "Open context menu"},
@@ -77,11 +82,21 @@ func buildHelpText(keys config.KeyBindings) string {
 		{Key: keys.AutoRefresh, Desc: "Toggle auto-refresh (10s interval)"},
 		{Key: keys.Quit, Desc: "Quit application"},
 		{Cat: ""},
+		{Cat: "[warning]Guest Details[-]"},
+		{Key: "Enter / Space", Desc: "Collapse/expand the selected section"},
+		{Key: "Tab / Shift+Tab", Desc: "Jump to next/previous section"},
+		{Key: "+ / -", Desc: "Expand/collapse all sections"},
+		{Cat: ""},
 		{Cat: "[warning]Tips & Usage[-]"},
 		{Desc: fmt.Sprintf("• Use search ([primary]%s[-]) to quickly find nodes or guests.", keys.Search)},
+		{Desc: fmt.Sprintf("• Global search ([primary]%s[-]) jumps to any match across the cluster.", keys.GlobalSearch)},
 		{Desc: fmt.Sprintf("• The context menu ([primary]%s[-]) provides quick access to actions.", keys.Menu)},
 		{Desc: "• Press [primary]Esc[-] to open the global menu for app-wide actions."},
 		{Desc: "• The 'g' key is still available for global menu if configured in key_bindings."},
+		{Desc: fmt.Sprintf("• The global menu ([primary]%s[-]) can switch or shrink the summary panel.", keys.GlobalMenu)},
+		{Desc: "• Narrow terminals stack lists above details; tune with compact_width."},
+		{Desc: "• Replay the guided tour from the global menu (Guided Tour)."},
+		{Desc: "• Press [primary]Enter[-] on the Tasks tab to read the log of the selected task."},
 		{Desc: "• VNC opens in your default web browser."},
 		{Desc: "• SSH sessions suspend the UI until the session is closed."},
 	}
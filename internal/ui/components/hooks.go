@@ -0,0 +1,164 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/actions"
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// vmOperationHookActions maps the names of guest power operations to the
+// actions hooks are configured for.
+var vmOperationHookActions = map[string]string{
+	"Starting":      config.HookActionVMStart,
+	"Shutting down": config.HookActionVMShutdown,
+	"Stopping":      config.HookActionVMStop,
+	"Restarting":    config.HookActionVMRestart,
+	"Resetting":     config.HookActionVMReset,
+}
+
+// runWithHooks runs the configured hooks of an action around run. A value
+// is asked for first if a hook has a prompt, then the pre hooks run in
+// order and the first failure aborts the action. run receives a function
+// that starts the post hooks and must call it once the action succeeded;
+// it may do so from any goroutine.
+func (a *App) runWithHooks(action string, vm *api.VM, run func(post func())) {
+	pre := actions.Hooks(a.config.Hooks, action, config.HookPre)
+	post := actions.Hooks(a.config.Hooks, action, config.HookPost)
+
+	if len(pre) == 0 && len(post) == 0 {
+		run(func() {})
+
+		return
+	}
+
+	start := func(input string) {
+		runPost := func() { a.runPostHooks(action, post, vm, input) }
+
+		if len(pre) == 0 {
+			run(runPost)
+
+			return
+		}
+
+		a.header.ShowLoading(fmt.Sprintf("Running %s hooks for %s", action, vm.Name))
+
+		go func() {
+			defer crash.Recover()
+
+			for _, hook := range pre {
+				output, err := actions.RunHook(a.ctx, hook, vm, input)
+				models.GetUILogger().Debug("Pre hook of %s for guest %d: %s", action, vm.ID, output)
+
+				if err != nil {
+					models.GetUILogger().Error("Pre hook of %s for guest %d failed: %v", action, vm.ID, err)
+
+					a.QueueUpdateDraw(func() {
+						a.header.StopLoading()
+						a.header.ShowError(fmt.Sprintf("%s aborted, pre hook failed: %v", action, err))
+					})
+
+					return
+				}
+			}
+
+			a.QueueUpdateDraw(func() {
+				a.header.StopLoading()
+				run(runPost)
+			})
+		}()
+	}
+
+	if prompt := actions.HookPrompt(pre, post); prompt != "" {
+		a.showHookPrompt(action, prompt, start)
+
+		return
+	}
+
+	start("")
+}
+
+// runPostHooks runs the post hooks of an action in the background. Failures
+// are logged and shown in the header but don't undo the action.
+func (a *App) runPostHooks(action string, hooks []config.Hook, vm *api.VM, input string) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		for _, hook := range hooks {
+			output, err := actions.RunHook(a.ctx, hook, vm, input)
+			models.GetUILogger().Debug("Post hook of %s for guest %d: %s", action, vm.ID, output)
+
+			if err != nil {
+				models.GetUILogger().Error("Post hook of %s for guest %d failed: %v", action, vm.ID, err)
+
+				a.QueueUpdateDraw(func() {
+					a.header.ShowWarning(fmt.Sprintf("Post hook of %s failed: %v", action, err))
+				})
+			}
+		}
+	}()
+}
+
+// showHookPrompt asks for the value passed to the hooks of an action as
+// {input}. The action is cancelled if the dialog is closed.
+func (a *App) showHookPrompt(action, prompt string, onSubmit func(string)) {
+	a.lastFocus = a.GetFocus()
+
+	closeForm := func() {
+		a.removePageIfPresent("hookPrompt")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	fields := []FormField{{Name: "input", Label: prompt, MaxLength: 128}}
+
+	form := CreateFormDialog(action, fields, func(values map[string]string) {
+		input := strings.TrimSpace(values["input"])
+		if input == "" {
+			a.showMessageSafe(fmt.Sprintf("%s is required for %s", prompt, action))
+
+			return
+		}
+
+		closeForm()
+		onSubmit(input)
+	}, func(map[string]string) {
+		closeForm()
+		a.header.ShowWarning(fmt.Sprintf("%s cancelled", action))
+	})
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			a.header.ShowWarning(fmt.Sprintf("%s cancelled", action))
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("hookPrompt", modal, true, true)
+	a.SetFocus(form)
+}
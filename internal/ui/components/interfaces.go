@@ -1,7 +1,9 @@
 package components
 
 import (
+	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
+	"github.com/devnullvoid/pvetui/pkg/plugin"
 	"github.com/rivo/tview"
 )
 
@@ -23,6 +25,8 @@ type VMListComponent interface {
 	SetVMs([]*api.VM)
 	GetSelectedVM() *api.VM
 	GetVMs() []*api.VM
+	SetPageSize(int)
+	RevealVM(*api.VM) bool
 	SetVMSelectedFunc(func(*api.VM))
 	SetVMChangedFunc(func(*api.VM))
 	SetCurrentItem(int) *tview.List
@@ -34,6 +38,7 @@ type NodeDetailsComponent interface {
 	SetApp(*App)
 	Update(*api.Node, []*api.Node)
 	Clear() *tview.Table
+	SelectedValue() (string, string)
 }
 
 type VMDetailsComponent interface {
@@ -41,6 +46,8 @@ type VMDetailsComponent interface {
 	SetApp(*App)
 	Update(*api.VM)
 	Clear() *tview.Table
+	SelectedValue() (string, string)
+	SetPlugins([]*plugin.Plugin)
 }
 
 type TasksListComponent interface {
@@ -49,12 +56,21 @@ type TasksListComponent interface {
 	SetTasks([]*api.ClusterTask)
 	SetFilteredTasks([]*api.ClusterTask)
 	GetSelectedTask() *api.ClusterTask
+	SelectTask(upid string) bool
 	Select(row, column int) *tview.Table
 }
 
 type ClusterStatusComponent interface {
 	tview.Primitive
 	Update(*api.Cluster)
+	SetSelectedNode(*api.Node)
+	SetTasks([]*api.ClusterTask)
+	SetClocks(bool, []models.ClockSkew)
+	Mode() string
+	SetMode(string)
+	IsCompact() bool
+	SetCompact(bool)
+	Height() int
 }
 
 type HeaderComponent interface {
@@ -69,6 +85,7 @@ type HeaderComponent interface {
 	SetTitle(string)
 	ShowActiveProfile(string)
 	GetCurrentProfile() string
+	SetUpdateHint(string)
 }
 
 type FooterComponent interface {
@@ -2,6 +2,7 @@ package components
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -86,10 +87,18 @@ func createNavigationInputCapture(app *App, leftTarget, rightTarget tview.Primit
 // setupKeyboardHandlers configures global keyboard shortcuts.
 func (a *App) setupKeyboardHandlers() {
 	a.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		a.lastInput = time.Now()
+
+		// The lock screen gets all keys, which are not logged
+		if a.locked {
+			return event
+		}
+
 		if config.DebugEnabled {
 			key, r, mod := keys.NormalizeEvent(event)
 			models.GetUILogger().Debug("input key=%d rune=%q mod=%d", key, r, mod)
 		}
+
 		// Check if search is active by seeing if the search input is in the main layout
 		searchActive := a.mainLayout.GetItemCount() > 4
 
@@ -100,14 +109,61 @@ func (a *App) setupKeyboardHandlers() {
 			a.pages.HasPage("scriptSelector") ||
 			a.pages.HasPage("message") ||
 			a.pages.HasPage("confirmation") ||
+			a.pages.HasPage("reconnect") ||
+			a.pages.HasPage("tour") ||
 			a.pages.HasPage("migration") ||
+			a.pages.HasPage("moveDisk") ||
+			a.pages.HasPage("restoreBackup") ||
+			a.pages.HasPage("exportConfig") ||
+			a.pages.HasPage("configPicker") ||
+			a.pages.HasPage("createFromConfig") ||
+			a.pages.HasPage("guestCompare") ||
+			a.pages.HasPage("guestComparison") ||
+			a.pages.HasPage("pruneBackups") ||
+			a.pages.HasPage("staleSnapshots") ||
+			a.pages.HasPage("guestFiles") ||
+			a.pages.HasPage("guestFile") ||
+			a.pages.HasPage("guestProcesses") ||
+			a.pages.HasPage("guestServices") ||
+			a.pages.HasPage("guestServiceOutput") ||
+			a.pages.HasPage("guestGroups") ||
+			a.pages.HasPage("netCheck") ||
+			a.pages.HasPage("nodeBroadcast") ||
+			a.pages.HasPage("nodeBroadcastOutput") ||
+			a.pages.HasPage("globalSearch") ||
+			a.pages.HasPage("bulkAction") ||
 			a.pages.HasPage("help") ||
 			a.pages.HasPage("vmConfig") ||
+			a.pages.HasPage("vmConfigConflict") ||
 			a.pages.HasPage("resizeStorage") ||
 			a.pages.HasPage("profileWizard") ||
 			a.pages.HasPage("profileName") ||
 			a.pages.HasPage("contextMenu") ||
 			a.pages.HasPage("about") ||
+			a.pages.HasPage("datacenterOptions") ||
+			a.pages.HasPage("datacenterOptionsEdit") ||
+			a.pages.HasPage("notesEditor") ||
+			a.pages.HasPage("alerts") ||
+			a.pages.HasPage("capacity") ||
+			a.pages.HasPage("heatmap") ||
+			a.pages.HasPage("costs") ||
+			a.pages.HasPage("exportCosts") ||
+			a.pages.HasPage("apiExplorer") ||
+			a.pages.HasPage("upgradeReadiness") ||
+			a.pages.HasPage("quorum") ||
+			a.pages.HasPage("taskLog") ||
+			a.pages.HasPage("nodeHardware") ||
+			a.pages.HasPage("gpuUsage") ||
+			a.pages.HasPage("tfaPrompt") ||
+			a.pages.HasPage("apiToken") ||
+			a.pages.HasPage("apiTokenRotate") ||
+			a.pages.HasPage("apiError") ||
+			a.pages.HasPage("nodeCompare") ||
+			a.pages.HasPage("nodeComparison") ||
+			a.pages.HasPage("startupTrace") ||
+			a.pages.HasPage("logs") ||
+			a.pages.HasPage("hookPrompt") ||
+			a.pages.HasPage("announcements") ||
 			a.pages.HasPage("snapshots") ||
 			a.pages.HasPage("createSnapshot")
 
@@ -212,6 +268,39 @@ func (a *App) setupKeyboardHandlers() {
 			return nil
 		}
 
+		if keyMatch(event, a.config.KeyBindings.GlobalSearch) {
+			a.showGlobalSearch()
+
+			return nil
+		}
+
+		if keyMatch(event, a.config.KeyBindings.ToggleZoom) {
+			currentPage, _ := a.pages.GetFrontPage()
+			if currentPage == api.PageNodes || currentPage == api.PageGuests {
+				a.toggleZoom()
+
+				return nil
+			}
+		}
+
+		if keyMatch(event, a.config.KeyBindings.Yank) {
+			a.yankSelection()
+
+			return nil
+		}
+
+		if keyMatch(event, a.config.KeyBindings.NodeGuests) {
+			a.toggleNodeGuests()
+
+			return nil
+		}
+
+		if keyMatch(event, a.config.KeyBindings.Sparklines) {
+			a.toggleSparklines()
+
+			return nil
+		}
+
 		if keyMatch(event, a.config.KeyBindings.Shell) {
 			// Open shell session based on current page
 			currentPage, _ := a.pages.GetFrontPage()
@@ -1,8 +1,10 @@
 package components
 
 import (
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/devnullvoid/pvetui/internal/config"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -19,6 +21,22 @@ func (a *App) createMainLayout() *tview.Flex {
 		AddItem(a.vmList, 0, 1, true).
 		AddItem(a.vmDetails, 0, 2, false)
 
+	a.splitPages = []splitPage{
+		{flex: nodesPage, list: a.nodeList, details: a.nodeDetails},
+		{flex: vmsPage, list: a.vmList, details: a.vmDetails},
+	}
+
+	// Adapt the split pages to the terminal size and focus before every draw
+	a.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		// Keep a handle on the screen for clipboard access
+		a.screen = screen
+
+		width, _ := screen.Size()
+		a.updateResponsiveLayout(width)
+
+		return false
+	})
+
 	// Setup Tasks page
 	tasksPage := a.tasksList
 
@@ -31,11 +49,103 @@ func (a *App) createMainLayout() *tview.Flex {
 	return tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(a.header, 1, 0, false).
-		AddItem(a.clusterStatus, 6, 0, false).
+		AddItem(a.clusterStatus, a.clusterStatus.Height(), 0, false).
 		AddItem(a.pages, 0, 1, true).
 		AddItem(a.footer, 1, 0, false)
 }
 
+// splitPage is a page showing a list next to the details of its selection.
+type splitPage struct {
+	flex    *tview.Flex
+	list    tview.Primitive
+	details tview.Primitive
+}
+
+// apply arranges the list and details panels. In compact mode they are
+// stacked vertically with equal height; when zoomed only the panel holding
+// the focus is shown.
+func (s splitPage) apply(compact, zoomed bool) {
+	direction, listSize, detailsSize := tview.FlexColumn, 1, 2
+	if compact {
+		direction, detailsSize = tview.FlexRow, 1
+	}
+
+	if zoomed {
+		if s.details.HasFocus() {
+			listSize = 0
+		} else {
+			detailsSize = 0
+		}
+	}
+
+	s.flex.SetDirection(direction)
+	s.flex.ResizeItem(s.list, 0, listSize)
+	s.flex.ResizeItem(s.details, 0, detailsSize)
+}
+
+// updateResponsiveLayout switches between the side-by-side and the compact
+// stacked layout depending on the terminal width.
+func (a *App) updateResponsiveLayout(width int) {
+	compact := a.config.CompactWidth > 0 && width < a.config.CompactWidth
+
+	for _, page := range a.splitPages {
+		page.apply(compact, a.zoomed)
+	}
+}
+
+// toggleZoom toggles showing only the focused list or details panel.
+func (a *App) toggleZoom() {
+	a.zoomed = !a.zoomed
+
+	if a.zoomed {
+		a.header.ShowSuccess("Zoomed in on focused panel")
+	} else {
+		a.header.ShowSuccess("Zoom disabled")
+	}
+}
+
+// cycleSummaryMode switches the summary panel to the next mode.
+func (a *App) cycleSummaryMode() {
+	next := config.SummaryModes[0]
+
+	for i, mode := range config.SummaryModes {
+		if mode == a.clusterStatus.Mode() {
+			next = config.SummaryModes[(i+1)%len(config.SummaryModes)]
+
+			break
+		}
+	}
+
+	a.clusterStatus.SetMode(next)
+	a.resizeSummaryPanel()
+
+	// Tasks are otherwise only fetched for the Tasks page
+	if next == config.SummaryModeTasks {
+		a.loadTasksData()
+	}
+
+	a.header.ShowSuccess("Summary panel: " + next)
+}
+
+// toggleCompactSummary switches the summary panel between full and single-line form.
+func (a *App) toggleCompactSummary() {
+	a.clusterStatus.SetCompact(!a.clusterStatus.IsCompact())
+	a.resizeSummaryPanel()
+
+	if a.clusterStatus.IsCompact() {
+		a.header.ShowSuccess("Compact summary enabled")
+	} else {
+		a.header.ShowSuccess("Compact summary disabled")
+	}
+}
+
+// resizeSummaryPanel applies the summary panel height to the main layout.
+func (a *App) resizeSummaryPanel() {
+	if a.mainLayout != nil {
+		a.mainLayout.ResizeItem(a.clusterStatus, a.clusterStatus.Height(), 0)
+	}
+}
+
 // setupComponentConnections wires up the interactions between components.
 func (a *App) setupComponentConnections() {
 	// Update cluster status
@@ -55,10 +165,12 @@ func (a *App) setupComponentConnections() {
 	a.nodeList.SetApp(a)
 	a.nodeList.SetNodeSelectedFunc(func(node *api.Node) {
 		a.nodeDetails.Update(node, a.client.Cluster.Nodes)
+		a.clusterStatus.SetSelectedNode(node)
 		// No longer filtering VM list based on node selection
 	})
 	a.nodeList.SetNodeChangedFunc(func(node *api.Node) {
 		a.nodeDetails.Update(node, a.client.Cluster.Nodes)
+		a.clusterStatus.SetSelectedNode(node)
 		// No longer filtering VM list based on node selection
 	})
 
@@ -69,6 +181,7 @@ func (a *App) setupComponentConnections() {
 	// Use the selected node from the node list (which is sorted) instead of raw original nodes
 	if selectedNode := a.nodeList.GetSelectedNode(); selectedNode != nil {
 		a.nodeDetails.Update(selectedNode, a.client.Cluster.Nodes)
+		a.clusterStatus.SetSelectedNode(selectedNode)
 	}
 
 	// Set up VM list with all VMs
@@ -80,6 +193,7 @@ func (a *App) setupComponentConnections() {
 	})
 	a.vmList.SetVMChangedFunc(func(vm *api.VM) {
 		a.vmDetails.Update(vm)
+		a.enrichSelectedGuest(vm)
 	})
 
 	// Now set the VMs - check for existing search filters first
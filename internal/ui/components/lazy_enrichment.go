@@ -0,0 +1,93 @@
+package components
+
+import (
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// selectionEnrichDelay debounces enrichment while scrolling through guests.
+const selectionEnrichDelay = 250 * time.Millisecond
+
+// enrichSelectedGuest loads guest agent data for a newly selected guest with
+// lazy enrichment, once the selection has settled.
+func (a *App) enrichSelectedGuest(vm *api.VM) {
+	if !a.client.LazyEnrichment() || vm == nil {
+		return
+	}
+
+	a.recentGuests.Touch(vm)
+
+	if a.enrichTimer != nil {
+		a.enrichTimer.Stop()
+	}
+
+	a.enrichTimer = time.AfterFunc(selectionEnrichDelay, func() {
+		a.enrichGuests([]*api.VM{vm})
+	})
+}
+
+// enrichRecentGuests reloads guest agent data for the recently viewed guests
+// after a refresh replaced them, with lazy enrichment.
+func (a *App) enrichRecentGuests() {
+	if !a.client.LazyEnrichment() {
+		return
+	}
+
+	recent := a.recentGuests.Filter(models.GlobalState.OriginalVMs)
+	if vm := a.vmList.GetSelectedVM(); vm != nil {
+		recent = append([]*api.VM{vm}, recent...)
+	}
+
+	go a.enrichGuests(recent)
+}
+
+// warmUpGuests enriches all running guests in the background after startup
+// when lazy enrichment is configured with warm_up.
+func (a *App) warmUpGuests() {
+	cluster := a.client.Cluster
+	if !a.client.LazyEnrichment() || !a.config.Enrichment.WarmUp || cluster == nil {
+		return
+	}
+
+	go func() {
+		if err := a.client.EnrichVMs(cluster); err != nil {
+			a.logger.Debug("Guest warm-up finished with errors: %v", err)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if vm := a.vmList.GetSelectedVM(); vm != nil {
+				a.vmDetails.Update(vm)
+			}
+		})
+	}()
+}
+
+// enrichGuests enriches running guests one after another and redraws the
+// guest details when the selected guest was among them. It blocks.
+func (a *App) enrichGuests(vms []*api.VM) {
+	enriched := make(map[*api.VM]bool, len(vms))
+
+	for _, vm := range vms {
+		if vm.Status != api.VMStatusRunning || enriched[vm] {
+			continue
+		}
+
+		if err := a.client.EnrichVM(vm); err != nil {
+			a.logger.Debug("Failed to enrich guest %d on %s: %v", vm.ID, vm.Node, err)
+		}
+
+		enriched[vm] = true
+	}
+
+	if len(enriched) == 0 {
+		return
+	}
+
+	a.QueueUpdateDraw(func() {
+		if selected := a.vmList.GetSelectedVM(); selected != nil && enriched[selected] {
+			a.vmDetails.Update(selected)
+		}
+	})
+}
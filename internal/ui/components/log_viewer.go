@@ -0,0 +1,172 @@
+package components
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/logger"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// logViewerLevels are the minimum levels cycled through in the log viewer.
+var logViewerLevels = []logger.Level{logger.LevelDebug, logger.LevelInfo, logger.LevelError}
+
+// logLevelTags colors the level of each entry in the log viewer.
+var logLevelTags = map[logger.Level]string{
+	logger.LevelDebug: "[secondary]",
+	logger.LevelInfo:  "[info]",
+	logger.LevelError: "[error]",
+}
+
+// logViewerFilter selects the entries shown in the log viewer.
+type logViewerFilter struct {
+	level     int    // Index into logViewerLevels
+	component string // Shown component, if filtered
+	filtered  bool   // Whether entries are filtered by component
+}
+
+// matches reports whether an entry passes the filter.
+func (f logViewerFilter) matches(entry logger.Entry) bool {
+	if entry.Level < logViewerLevels[f.level] {
+		return false
+	}
+
+	return !f.filtered || entry.Component == f.component
+}
+
+// nextComponent cycles the component filter through all components,
+// starting and ending with no filter.
+func (f *logViewerFilter) nextComponent(components []string) {
+	index := -1
+
+	if f.filtered {
+		for i, component := range components {
+			if component == f.component {
+				index = i
+
+				break
+			}
+		}
+	}
+
+	if index+1 >= len(components) {
+		f.filtered = false
+		f.component = ""
+
+		return
+	}
+
+	f.filtered = true
+	f.component = components[index+1]
+}
+
+// componentLabel names a component in the log viewer.
+func componentLabel(component string) string {
+	if component == "" {
+		return "app"
+	}
+
+	return component
+}
+
+// showLogViewer shows the recent log entries of all components, which are
+// also written to the log file in the cache directory.
+func (a *App) showLogViewer() {
+	filter := logViewerFilter{}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	view.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	render := func() {
+		var b strings.Builder
+
+		shown := 0
+
+		for _, entry := range logger.Entries() {
+			if !filter.matches(entry) {
+				continue
+			}
+
+			fmt.Fprintf(&b, "[secondary]%s[-] %s%-5s[-] [primary]%s[-] %s\n",
+				entry.Time.Format("15:04:05"),
+				logLevelTags[entry.Level],
+				entry.Level.String(),
+				tview.Escape(componentLabel(entry.Component)),
+				tview.Escape(entry.Message))
+
+			shown++
+		}
+
+		if shown == 0 {
+			b.WriteString("[secondary]No log entries match the filter[-]\n")
+		}
+
+		component := "all"
+		if filter.filtered {
+			component = componentLabel(filter.component)
+		}
+
+		view.SetTitle(fmt.Sprintf(" Logs (%s and above, %s) ", logViewerLevels[filter.level], component))
+		view.SetText(theme.ReplaceSemanticTags(b.String()))
+		view.ScrollToEnd()
+	}
+
+	render()
+
+	hint := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(theme.ReplaceSemanticTags(fmt.Sprintf(
+			"[primary]l[-] level  [primary]c[-] component  [primary]r[-] reload  [primary]Esc[-] close  [secondary]%s[-]",
+			tview.Escape(filepath.Join(a.config.CacheDir, "pvetui.log")))))
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			a.removePageIfPresent("logs")
+
+			if a.lastFocus != nil {
+				a.SetFocus(a.lastFocus)
+			}
+		case event.Key() == tcell.KeyRune && event.Rune() == 'l':
+			filter.level = (filter.level + 1) % len(logViewerLevels)
+			render()
+		case event.Key() == tcell.KeyRune && event.Rune() == 'c':
+			filter.nextComponent(logger.Components())
+			render()
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			render()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, true).
+		AddItem(hint, 1, 0, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("logs")
+	a.pages.AddPage("logs", modal, true, true)
+	a.SetFocus(view)
+}
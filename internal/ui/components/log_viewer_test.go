@@ -0,0 +1,37 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/logger"
+)
+
+func TestLogViewerFilter(t *testing.T) {
+	filter := logViewerFilter{}
+
+	debug := logger.Entry{Level: logger.LevelDebug, Component: "vnc-proxy"}
+	info := logger.Entry{Level: logger.LevelInfo}
+
+	assert.True(t, filter.matches(debug))
+
+	filter.level = 1
+	assert.False(t, filter.matches(debug))
+	assert.True(t, filter.matches(info))
+
+	components := []string{"", "vnc-proxy"}
+
+	filter.nextComponent(components)
+	assert.True(t, filter.filtered)
+	assert.Equal(t, "", filter.component)
+	assert.True(t, filter.matches(info))
+
+	filter.nextComponent(components)
+	assert.Equal(t, "vnc-proxy", filter.component)
+	assert.False(t, filter.matches(info))
+
+	filter.nextComponent(components)
+	assert.False(t, filter.filtered)
+	assert.True(t, filter.matches(info))
+}
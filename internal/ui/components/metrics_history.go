@@ -0,0 +1,108 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+)
+
+// historyWidth is the number of cells of the sparklines in the details
+// panels; longer histories show their newest samples.
+const historyWidth = 30
+
+// sampleValues returns one value of every sample.
+func sampleValues(samples []models.MetricSample, value func(models.MetricSample) float64) []float64 {
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = value(sample)
+	}
+
+	return values
+}
+
+// historySpan returns the time covered by the samples shown in a sparkline.
+func historySpan(samples []models.MetricSample) string {
+	if len(samples) > historyWidth {
+		samples = samples[len(samples)-historyWidth:]
+	}
+
+	span := samples[len(samples)-1].Time.Sub(samples[0].Time)
+	if span < time.Minute {
+		return fmt.Sprintf("%ds", int(span.Seconds()))
+	}
+
+	return models.FormatAge(span)
+}
+
+// usageHistoryText renders the CPU and memory history of a node or guest,
+// e.g. "CPU ▁▂▅▃ Mem ▅▅▅▆ (last 15m)". It is empty until two samples were
+// recorded.
+func usageHistoryText(samples []models.MetricSample) string {
+	if len(samples) < 2 {
+		return ""
+	}
+
+	width := min(len(samples), historyWidth)
+	cpu := sparkline(sampleValues(samples, func(s models.MetricSample) float64 { return s.CPU }), 100, width)
+	mem := sparkline(sampleValues(samples, func(s models.MetricSample) float64 { return s.Memory }), 100, width)
+
+	return fmt.Sprintf("CPU %s  Mem %s  (last %s)", cpu, mem, historySpan(samples))
+}
+
+// rateHistoryText renders the history of a rate scaled to its peak, e.g.
+// "▁▁▃█▂ peak 12.5 MB/s". It is empty until a rate was derived.
+func rateHistoryText(samples []models.MetricSample, rate func(models.MetricSample) float64) string {
+	if len(samples) < 2 {
+		return ""
+	}
+
+	values := sampleValues(samples, rate)
+	if len(values) > historyWidth {
+		values = values[len(values)-historyWidth:]
+	}
+
+	peak := 0.0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+
+	return fmt.Sprintf("%s peak %s/s", sparkline(values, peak, len(values)), utils.FormatBytes(int64(peak)))
+}
+
+// trendWidth is the number of cells of the sparklines in the guest list.
+const trendWidth = 8
+
+// guestTrendText renders the CPU and memory trend of a guest for the guest
+// list, each sparkline colored by the latest usage. It is empty until two
+// samples were recorded.
+func guestTrendText(samples []models.MetricSample) string {
+	if len(samples) < 2 {
+		return ""
+	}
+
+	last := samples[len(samples)-1]
+	cpu := sparkline(sampleValues(samples, func(s models.MetricSample) float64 { return s.CPU }), 100, trendWidth)
+	mem := sparkline(sampleValues(samples, func(s models.MetricSample) float64 { return s.Memory }), 100, trendWidth)
+
+	return fmt.Sprintf("[%s]%s[-] [%s]%s[-]",
+		theme.ColorToTag(theme.GetUsageColor(last.CPU)), cpu,
+		theme.ColorToTag(theme.GetUsageColor(last.Memory)), mem)
+}
+
+// toggleSparklines shows or hides the trend sparklines of the guest list.
+func (a *App) toggleSparklines() {
+	a.config.Sparklines = !a.config.Sparklines
+	a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+
+	switch {
+	case !a.config.Sparklines:
+		a.header.ShowSuccess("Guest sparklines hidden")
+	case a.config.MetricsHistory == 0:
+		a.header.ShowWarning("Guest sparklines need metrics_history, which is 0")
+	default:
+		a.header.ShowSuccess("Guest sparklines shown (CPU, memory)")
+	}
+}
@@ -0,0 +1,94 @@
+package components
+
+import (
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// neighborsInterval is how often the neighbor table of a node is read
+// again.
+const neighborsInterval = 2 * time.Minute
+
+// applyNeighborIPs fills in the IPs of guests without one from the neighbor
+// tables of their nodes when the ARP fallback is enabled. Tables older than
+// neighborsInterval are read again over SSH, only from online nodes running
+// such guests, and the guest list is updated when they arrive.
+func (a *App) applyNeighborIPs() {
+	if !a.config.Enrichment.ARPFallback || a.config.SSHUser == "" {
+		return
+	}
+
+	vms := models.GlobalState.OriginalVMs
+	if models.ApplyNeighborIPs(vms) > 0 {
+		a.updateGuestIPs()
+	}
+
+	needed := make(map[string]bool)
+
+	for _, vm := range vms {
+		if models.NeedsNeighborIP(vm) {
+			needed[vm.Node] = true
+		}
+	}
+
+	if a.neighborsReading == nil {
+		a.neighborsReading = make(map[string]bool)
+	}
+
+	client := a.client
+	user := a.config.SSHUser
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node == nil || !node.Online || a.nodeAddress(node) == "" || !needed[node.Name] || a.neighborsReading[node.Name] {
+			continue
+		}
+
+		if readAt := models.NeighborsReadAt(node.Name); !readAt.IsZero() && time.Since(readAt) < neighborsInterval {
+			continue
+		}
+
+		a.neighborsReading[node.Name] = true
+
+		go func(node *api.Node, addr string) {
+			defer crash.Recover()
+
+			output, err := ssh.ReadNeighbors(a.ctx, ssh.NewDefaultExecutor(), user, addr, ssh.NodeOptions())
+
+			a.QueueUpdateDraw(func() {
+				delete(a.neighborsReading, node.Name)
+
+				if err != nil {
+					models.GetUILogger().Debug("Failed to read the neighbor table of %s: %v", node.Name, err)
+
+					return
+				}
+
+				// Results of a client replaced by a profile switch are stale
+				if client != a.client {
+					return
+				}
+
+				models.SetNodeNeighbors(node.Name, api.ParseNeighbors(output), time.Now())
+
+				if applied := models.ApplyNeighborIPs(models.GlobalState.OriginalVMs); applied > 0 {
+					models.GetUILogger().Debug("Inferred the IPs of %d guests on %s from its neighbor table", applied, node.Name)
+					a.updateGuestIPs()
+				}
+			})
+		}(node, a.nodeAddress(node))
+	}
+}
+
+// updateGuestIPs redraws the guest list and details after guest IPs were
+// inferred.
+func (a *App) updateGuestIPs() {
+	a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+
+	if vm := a.vmList.GetSelectedVM(); vm != nil {
+		a.vmDetails.Update(vm)
+	}
+}
@@ -0,0 +1,221 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/netcheck"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+const (
+	// netCheckProbes is the number of probes sent to each target.
+	netCheckProbes = 3
+	// netCheckTimeout is how long each probe waits for an answer.
+	netCheckTimeout = 2 * time.Second
+	// nodeAPIPort is the port of the Proxmox API, probed on the guest's node
+	// to tell guest problems from network path problems.
+	nodeAPIPort = 8006
+)
+
+// formatNetCheckResult formats the result of probing a target as a line of
+// the report.
+func formatNetCheckResult(label string, result netcheck.Result) string {
+	color := "error"
+	if result.Answered() {
+		color = "success"
+	}
+
+	return fmt.Sprintf("[primary]%s[-] %s\n  [%s]%s[-]", label, tview.Escape(result.Target), color, tview.Escape(result.Summary()))
+}
+
+// showNetCheck tests from this host whether a guest answers: ICMP echo
+// requests if the host permits them and TCP connects to a chosen port, plus
+// the API port of its node to tell guest problems from network problems.
+func (a *App) showNetCheck(vm *api.VM) {
+	port := "22"
+	if vm.Type == api.VMTypeQemu && api.IsWindowsGuest(vm) {
+		port = "3389"
+	}
+
+	var nodeIP string
+
+	for _, node := range a.client.Cluster.Nodes {
+		if node.Name == vm.Node {
+			nodeIP = a.nodeAddress(node)
+
+			break
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("Address", vm.IP, 40, nil, nil)
+	form.AddInputField("TCP port", port, 8, func(text string, _ rune) bool {
+		_, err := strconv.Atoi(text)
+
+		return err == nil
+	}, nil)
+
+	report := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	report.SetBorderPadding(0, 0, 1, 1)
+
+	if vm.IP == "" {
+		report.SetText(theme.ReplaceSemanticTags("[warning]The guest's IP address is unknown; enter its address.[-]"))
+	}
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 7, 0, true).
+		AddItem(report, 0, 1, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Ping / Port Check: %s ", vm.Name)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	closeCheck := func() {
+		a.removePageIfPresent("netCheck")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	checking := false
+
+	check := func() {
+		if checking {
+			return
+		}
+
+		host := strings.TrimSpace(form.GetFormItemByLabel("Address").(*tview.InputField).GetText())
+		if host == "" {
+			report.SetText(theme.ReplaceSemanticTags("[error]Enter the address of the guest[-]"))
+
+			return
+		}
+
+		portNumber, err := strconv.Atoi(form.GetFormItemByLabel("TCP port").(*tview.InputField).GetText())
+		if err != nil || portNumber < 1 || portNumber > 65535 {
+			report.SetText(theme.ReplaceSemanticTags("[error]Enter a TCP port between 1 and 65535[-]"))
+
+			return
+		}
+
+		checking = true
+
+		report.SetText(theme.ReplaceSemanticTags(fmt.Sprintf("[secondary]Probing %s...[-]", tview.Escape(host))))
+
+		go func() {
+			defer crash.Recover()
+
+			ctx, cancel := context.WithCancel(a.ctx)
+			defer cancel()
+
+			var (
+				wg       sync.WaitGroup
+				ping     netcheck.Result
+				pingErr  error
+				tcp      netcheck.Result
+				node     netcheck.Result
+				haveNode = nodeIP != ""
+			)
+
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				ping, pingErr = netcheck.Ping(ctx, host, netCheckProbes, netCheckTimeout)
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				tcp = netcheck.CheckPort(ctx, host, portNumber, netCheckProbes, netCheckTimeout)
+			}()
+
+			if haveNode {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					node = netcheck.CheckPort(ctx, nodeIP, nodeAPIPort, netCheckProbes, netCheckTimeout)
+				}()
+			}
+
+			wg.Wait()
+
+			guest := []netcheck.Result{tcp}
+			lines := make([]string, 0, 4)
+
+			switch {
+			case errors.Is(pingErr, netcheck.ErrICMPNotPermitted):
+				lines = append(lines, "[primary]Ping[-]\n  [secondary]ICMP is not permitted on this host; only the TCP check was run[-]")
+			case pingErr != nil:
+				lines = append(lines, fmt.Sprintf("[primary]Ping[-]\n  [error]%s[-]", tview.Escape(pingErr.Error())))
+			default:
+				guest = append(guest, ping)
+				lines = append(lines, formatNetCheckResult("Ping", ping))
+			}
+
+			lines = append(lines, formatNetCheckResult("Port", tcp))
+
+			var nodeResult *netcheck.Result
+			if haveNode {
+				nodeResult = &node
+				lines = append(lines, formatNetCheckResult("Node "+vm.Node, node))
+			}
+
+			lines = append(lines, "\n[info]"+tview.Escape(netcheck.Verdict(guest, nodeResult))+"[-]")
+
+			a.QueueUpdateDraw(func() {
+				checking = false
+
+				report.SetText(theme.ReplaceSemanticTags(strings.Join(lines, "\n")))
+			})
+		}()
+	}
+
+	form.AddButton("Check", check)
+	form.AddButton("Close", closeCheck)
+	form.SetCancelFunc(closeCheck)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeCheck()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 20, 0, true).
+			AddItem(nil, 0, 1, false), 80, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("netCheck")
+	a.pages.AddPage("netCheck", modal, true, true)
+	a.SetFocus(form)
+
+	if vm.IP != "" {
+		check()
+	}
+}
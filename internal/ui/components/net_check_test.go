@@ -0,0 +1,19 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/netcheck"
+)
+
+func TestFormatNetCheckResult(t *testing.T) {
+	up := netcheck.Result{Target: "TCP 192.0.2.10:22", Sent: 1, Latencies: []time.Duration{time.Millisecond}}
+	assert.Equal(t, "[primary]Port[-] TCP 192.0.2.10:22\n  [success]1/1 answered, latency 1.0 ms / 1.0 ms / 1.0 ms (min/avg/max)[-]",
+		formatNetCheckResult("Port", up))
+
+	down := netcheck.Result{Target: "ICMP 192.0.2.10", Sent: 3}
+	assert.Contains(t, formatNetCheckResult("Ping", down), "[error]0/3 answered[-]")
+}
@@ -0,0 +1,359 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// broadcastMaxLines is the number of output lines kept for each node.
+const broadcastMaxLines = 5000
+
+// broadcastStatus describes how a command ended on a node.
+func broadcastStatus(ctx context.Context, err error) (string, bool) {
+	var exitErr *exec.ExitError
+
+	switch {
+	case err == nil:
+		return "exit 0", true
+	case ctx.Err() != nil:
+		return "stopped", false
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 255:
+		return "SSH failed", false
+	case errors.As(err, &exitErr):
+		return fmt.Sprintf("exit %d", exitErr.ExitCode()), false
+	default:
+		return "failed: " + err.Error(), false
+	}
+}
+
+// showNodeBroadcast asks for a shell command and the online nodes to run it
+// on. The selected node is marked to begin with.
+func (a *App) showNodeBroadcast() {
+	if a.config.SSHUser == "" {
+		a.header.ShowError("Running commands on nodes needs an SSH user")
+
+		return
+	}
+
+	var nodes []*api.Node
+
+	for _, node := range a.nodeList.GetNodes() {
+		if node != nil && node.Online && a.nodeAddress(node) != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		a.header.ShowError("No online nodes with a known address")
+
+		return
+	}
+
+	marked := make(map[string]bool)
+	if selected := a.nodeList.GetSelectedNode(); selected != nil {
+		marked[selected.Name] = true
+	}
+
+	input := tview.NewInputField().
+		SetLabel("Command: ").
+		SetText(a.broadcastCommand)
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetMainTextColor(theme.Colors.Primary)
+
+	itemText := func(node *api.Node) string {
+		mark := "[ ]"
+		if marked[node.Name] {
+			mark = "[x]"
+		}
+
+		return fmt.Sprintf("%s %s", tview.Escape(mark), node.Name)
+	}
+
+	for _, node := range nodes {
+		list.AddItem(itemText(node), "", 0, nil)
+	}
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(theme.ReplaceSemanticTags("[secondary]Enter: run, Tab: switch, Space: mark, a: mark all, Esc: close[-]"))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 2, 0, true).
+		AddItem(list, 0, 1, false).
+		AddItem(help, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Run Command on Nodes ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	content.SetBorderPadding(0, 0, 1, 1)
+
+	closePicker := func() {
+		a.removePageIfPresent("nodeBroadcast")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	run := func() {
+		command := strings.TrimSpace(input.GetText())
+		if command == "" {
+			a.header.ShowError("Enter a command to run")
+			a.SetFocus(input)
+
+			return
+		}
+
+		var selected []*api.Node
+
+		for _, node := range nodes {
+			if marked[node.Name] {
+				selected = append(selected, node)
+			}
+		}
+
+		if len(selected) == 0 {
+			a.header.ShowError("Mark at least one node with Space")
+			a.SetFocus(list)
+
+			return
+		}
+
+		a.broadcastCommand = command
+
+		a.removePageIfPresent("nodeBroadcast")
+		a.runNodeBroadcast(selected, command)
+	}
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			run()
+		case tcell.KeyTab, tcell.KeyBacktab:
+			a.SetFocus(list)
+		case tcell.KeyEscape:
+			closePicker()
+		}
+	})
+
+	list.SetSelectedFunc(func(_ int, _, _ string, _ rune) {
+		run()
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closePicker()
+
+			return nil
+		case event.Key() == tcell.KeyTab || event.Key() == tcell.KeyBacktab:
+			a.SetFocus(input)
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			index := list.GetCurrentItem()
+			node := nodes[index]
+			marked[node.Name] = !marked[node.Name]
+			list.SetItemText(index, itemText(node), "")
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'a':
+			// Mark all nodes, or clear the marks if all are marked
+			all := true
+
+			for _, node := range nodes {
+				all = all && marked[node.Name]
+			}
+
+			for i, node := range nodes {
+				marked[node.Name] = !all
+				list.SetItemText(i, itemText(node), "")
+			}
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, min(len(nodes)+6, 24), 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("nodeBroadcast")
+	a.pages.AddPage("nodeBroadcast", modal, true, true)
+	a.SetFocus(input)
+}
+
+// runNodeBroadcast runs a shell command on several nodes at once over SSH
+// and streams the output of each node into its own pane. Tab moves between
+// the panes, r runs the command again and Esc closes the view, stopping
+// commands that are still running.
+func (a *App) runNodeBroadcast(nodes []*api.Node, command string) {
+	ctx, cancel := context.WithCancel(a.ctx)
+
+	cols := min(len(nodes), 2)
+	rows := (len(nodes) + cols - 1) / cols
+
+	grid := tview.NewGrid().
+		SetRows(make([]int, rows)...).
+		SetColumns(make([]int, cols)...)
+
+	panes := make([]*tview.TextView, len(nodes))
+
+	for i, node := range nodes {
+		pane := tview.NewTextView().
+			SetDynamicColors(false).
+			SetScrollable(true).
+			SetMaxLines(broadcastMaxLines)
+		pane.SetBorder(true).
+			SetTitle(fmt.Sprintf(" %s: running ", node.Name)).
+			SetTitleColor(theme.Colors.Title).
+			SetBorderColor(theme.Colors.Border)
+
+		panes[i] = pane
+		grid.AddItem(pane, i/cols, i%cols, 1, 1, 0, 0, i == 0)
+	}
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(theme.ReplaceSemanticTags("[secondary]Tab: next node, r: run again, Esc: close (stops running commands)[-]"))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(grid, 0, 1, true).
+		AddItem(help, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" $ %s ", command)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	var (
+		// drawPending coalesces redraws while output streams in
+		drawPending atomic.Bool
+		finished    int
+		failed      int
+	)
+
+	closeOutput := func() {
+		if finished < len(nodes) {
+			a.header.StopLoading()
+		}
+
+		cancel()
+		a.removePageIfPresent("nodeBroadcastOutput")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	focused := 0
+
+	for _, pane := range panes {
+		pane.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			switch {
+			case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+				closeOutput()
+
+				return nil
+			case event.Key() == tcell.KeyTab:
+				focused = (focused + 1) % len(panes)
+				a.SetFocus(panes[focused])
+
+				return nil
+			case event.Key() == tcell.KeyBacktab:
+				focused = (focused + len(panes) - 1) % len(panes)
+				a.SetFocus(panes[focused])
+
+				return nil
+			case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+				cancel()
+				a.removePageIfPresent("nodeBroadcastOutput")
+				a.runNodeBroadcast(nodes, command)
+
+				return nil
+			}
+
+			return event
+		})
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("nodeBroadcastOutput")
+	a.pages.AddPage("nodeBroadcastOutput", modal, true, true)
+	a.SetFocus(panes[0])
+
+	a.header.ShowLoading(fmt.Sprintf("Running '%s' on %d nodes", command, len(nodes)))
+
+	user := a.config.SSHUser
+
+	for i, node := range nodes {
+		pane := panes[i]
+
+		go func(node *api.Node, addr string) {
+			defer crash.Recover()
+
+			err := ssh.StreamNodeCommand(ctx, ssh.NewDefaultExecutor(), user, addr, command, ssh.NodeOptions(), func(line string) {
+				_, _ = fmt.Fprintln(pane, line)
+
+				if drawPending.CompareAndSwap(false, true) {
+					a.QueueUpdateDraw(func() {
+						drawPending.Store(false)
+					})
+				}
+			})
+
+			status, ok := broadcastStatus(ctx, err)
+
+			a.QueueUpdateDraw(func() {
+				finished++
+
+				pane.SetTitle(fmt.Sprintf(" %s: %s ", node.Name, status))
+
+				if ok {
+					pane.SetBorderColor(theme.Colors.Success)
+				} else {
+					failed++
+
+					pane.SetBorderColor(theme.Colors.Error)
+				}
+
+				if finished < len(nodes) || ctx.Err() != nil {
+					return
+				}
+
+				if failed > 0 {
+					a.header.ShowError(fmt.Sprintf("'%s' failed on %d of %d nodes", command, failed, len(nodes)))
+				} else {
+					a.header.ShowSuccess(fmt.Sprintf("'%s' completed on %d nodes", command, len(nodes)))
+				}
+			})
+		}(node, a.nodeAddress(node))
+	}
+}
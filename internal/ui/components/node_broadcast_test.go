@@ -0,0 +1,37 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcastStatus(t *testing.T) {
+	exitCode := func(code string) error {
+		return exec.Command("sh", "-c", "exit "+code).Run()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	status, ok := broadcastStatus(ctx, nil)
+	assert.Equal(t, "exit 0", status)
+	assert.True(t, ok)
+
+	status, ok = broadcastStatus(ctx, exitCode("2"))
+	assert.Equal(t, "exit 2", status)
+	assert.False(t, ok)
+
+	status, _ = broadcastStatus(ctx, exitCode("255"))
+	assert.Equal(t, "SSH failed", status)
+
+	status, _ = broadcastStatus(ctx, errors.New("exec: \"ssh\": executable file not found in $PATH"))
+	assert.Equal(t, "failed: exec: \"ssh\": executable file not found in $PATH", status)
+
+	cancel()
+
+	status, _ = broadcastStatus(ctx, exitCode("1"))
+	assert.Equal(t, "stopped", status)
+}
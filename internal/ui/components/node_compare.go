@@ -0,0 +1,293 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// comparisonRow is one attribute of the node comparison.
+type comparisonRow struct {
+	Label  string
+	Values []string // One per node
+	// Skew marks attributes that should match across a cluster, such as
+	// versions, whose values differ.
+	Skew bool
+}
+
+// nodeComparisonRows builds the node comparison, one value per node for
+// every attribute.
+func nodeComparisonRows(nodes []*api.Node, vms []*api.VM) []comparisonRow {
+	capacities := make(map[string]models.NodeCapacity)
+	for _, capacity := range models.CollectCapacity(nodes, vms) {
+		capacities[capacity.Node] = capacity
+	}
+
+	type attribute struct {
+		label string
+		skew  bool
+		value func(node *api.Node, capacity models.NodeCapacity) string
+	}
+
+	attributes := []attribute{
+		{"Status", false, func(node *api.Node, _ models.NodeCapacity) string {
+			if node.Online {
+				return "online"
+			}
+
+			return "offline"
+		}},
+		{"PVE Version", true, func(node *api.Node, _ models.NodeCapacity) string {
+			return node.Version
+		}},
+		{"Kernel", true, func(node *api.Node, _ models.NodeCapacity) string {
+			kernel := node.KernelVersion
+			if idx := strings.Index(kernel, "#"); idx != -1 {
+				kernel = strings.TrimSpace(kernel[:idx])
+			}
+
+			return kernel
+		}},
+		{"CPU Model", true, func(node *api.Node, _ models.NodeCapacity) string {
+			if node.CPUInfo == nil {
+				return ""
+			}
+
+			return node.CPUInfo.Model
+		}},
+		{"CPUs", false, func(node *api.Node, _ models.NodeCapacity) string {
+			if node.CPUInfo != nil && node.CPUInfo.Sockets > 0 {
+				return fmt.Sprintf("%.0f (%d sockets)", node.CPUCount, node.CPUInfo.Sockets)
+			}
+
+			return fmt.Sprintf("%.0f", node.CPUCount)
+		}},
+		{"CPU Usage", false, func(node *api.Node, _ models.NodeCapacity) string {
+			return fmt.Sprintf("%.1f%%", node.CPUUsage*100)
+		}},
+		{"Load Avg", false, func(node *api.Node, _ models.NodeCapacity) string {
+			return strings.Join(node.LoadAvg, ", ")
+		}},
+		{"Memory", false, func(_ *api.Node, capacity models.NodeCapacity) string {
+			return fmt.Sprintf("%s / %s", utils.FormatBytes(capacity.MemoryUsed), utils.FormatBytes(capacity.MemoryTotal))
+		}},
+		{"Guest Storage", false, func(_ *api.Node, capacity models.NodeCapacity) string {
+			return fmt.Sprintf("%s / %s", utils.FormatBytes(capacity.DiskUsed), utils.FormatBytes(capacity.DiskTotal))
+		}},
+		{"Guests", false, func(_ *api.Node, capacity models.NodeCapacity) string {
+			return fmt.Sprintf("%d running / %d", capacity.RunningGuests, capacity.Guests)
+		}},
+		{"Uptime", false, func(node *api.Node, _ models.NodeCapacity) string {
+			if node.Uptime <= 0 {
+				return ""
+			}
+
+			return utils.FormatUptime(int(node.Uptime))
+		}},
+	}
+
+	rows := make([]comparisonRow, len(attributes))
+
+	for i, attr := range attributes {
+		row := comparisonRow{Label: attr.label, Values: make([]string, len(nodes))}
+
+		for j, node := range nodes {
+			value := attr.value(node, capacities[node.Name])
+			if value == "" {
+				value = api.StringNA
+			}
+
+			row.Values[j] = value
+
+			if attr.skew && node.Online && value != api.StringNA && row.Values[0] != value {
+				row.Skew = true
+			}
+		}
+
+		rows[i] = row
+	}
+
+	return rows
+}
+
+// showNodeComparePicker lets the user mark the nodes to compare. The
+// selected node is marked initially.
+func (a *App) showNodeComparePicker() {
+	nodes := a.nodeList.GetNodes()
+	if len(nodes) < 2 {
+		a.header.ShowError("At least two nodes are needed for a comparison")
+
+		return
+	}
+
+	marked := make(map[string]bool)
+	if selected := a.nodeList.GetSelectedNode(); selected != nil {
+		marked[selected.Name] = true
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).
+		SetTitle(" Compare Nodes (Space: mark, Enter: compare) ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	list.SetMainTextColor(theme.Colors.Primary)
+
+	itemText := func(node *api.Node) string {
+		mark := "[ ]"
+		if marked[node.Name] {
+			mark = "[x]"
+		}
+
+		return fmt.Sprintf("%s %s", tview.Escape(mark), node.Name)
+	}
+
+	for _, node := range nodes {
+		list.AddItem(itemText(node), "", 0, nil)
+	}
+
+	closePicker := func() {
+		a.removePageIfPresent("nodeCompare")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	list.SetSelectedFunc(func(_ int, _, _ string, _ rune) {
+		var selected []*api.Node
+
+		for _, node := range nodes {
+			if marked[node.Name] {
+				selected = append(selected, node)
+			}
+		}
+
+		if len(selected) < 2 {
+			a.header.ShowError("Mark at least two nodes with Space")
+
+			return
+		}
+
+		a.showNodeComparison(selected)
+	})
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closePicker()
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			index := list.GetCurrentItem()
+			node := nodes[index]
+			marked[node.Name] = !marked[node.Name]
+			list.SetItemText(index, itemText(node), "")
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := len(nodes) + 2
+	if height > 20 {
+		height = 20
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, height, 0, true).
+			AddItem(nil, 0, 1, false), 50, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("nodeCompare")
+	a.pages.AddPage("nodeCompare", modal, true, true)
+	a.SetFocus(list)
+}
+
+// showNodeComparison shows the given nodes side by side. Versions, kernels and
+// CPU models that differ from the first node are highlighted.
+func (a *App) showNodeComparison(nodes []*api.Node) {
+	rows := nodeComparisonRows(nodes, models.GlobalState.OriginalVMs)
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 1).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Node Comparison (%d nodes) ", len(nodes))).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	table.SetCell(0, 0, tview.NewTableCell("").SetSelectable(false))
+
+	for col, node := range nodes {
+		table.SetCell(0, col+1, tview.NewTableCell(node.Name).
+			SetTextColor(theme.Colors.HeaderText).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+
+	for i, row := range rows {
+		label := row.Label
+		if row.Skew {
+			label = theme.Icon("⚠️", "!") + " " + label
+		}
+
+		table.SetCell(i+1, 0, tview.NewTableCell(label).SetTextColor(theme.Colors.HeaderText))
+
+		for col, value := range row.Values {
+			color := theme.Colors.Primary
+			if row.Skew && value != row.Values[0] {
+				color = theme.Colors.Warning
+			}
+
+			table.SetCell(i+1, col+1, tview.NewTableCell(tview.Escape(value)).SetTextColor(color).SetExpansion(1))
+		}
+	}
+
+	table.Select(1, 0)
+
+	closeComparison := func() {
+		a.removePageIfPresent("nodeComparison")
+		a.removePageIfPresent("nodeCompare")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeComparison()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, len(rows)+3, 0, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("nodeComparison")
+	a.pages.AddPage("nodeComparison", modal, true, true)
+	a.SetFocus(table)
+}
@@ -0,0 +1,41 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestNodeComparisonRows(t *testing.T) {
+	nodes := []*api.Node{
+		{Name: "pve1", Online: true, Version: "pve-manager/8.2.4", KernelVersion: "6.8.8-2-pve #1 SMP", CPUCount: 16, MemoryTotal: 64, MemoryUsed: 16, CPUInfo: &api.CPUInfo{Model: "EPYC 7302", Sockets: 1}},
+		{Name: "pve2", Online: true, Version: "pve-manager/8.1.3", KernelVersion: "6.8.8-2-pve #1 SMP", CPUCount: 16, MemoryTotal: 64, MemoryUsed: 8, CPUInfo: &api.CPUInfo{Model: "EPYC 7302", Sockets: 1}},
+		{Name: "pve3", Online: false},
+	}
+	vms := []*api.VM{
+		{ID: 100, Node: "pve1", Status: api.VMStatusRunning},
+		{ID: 101, Node: "pve1", Status: api.VMStatusStopped},
+	}
+
+	rows := nodeComparisonRows(nodes, vms)
+
+	byLabel := make(map[string]comparisonRow)
+	for _, row := range rows {
+		require.Len(t, row.Values, 3)
+		byLabel[row.Label] = row
+	}
+
+	assert.True(t, byLabel["PVE Version"].Skew)
+	assert.Equal(t, []string{"pve-manager/8.2.4", "pve-manager/8.1.3", api.StringNA}, byLabel["PVE Version"].Values)
+
+	// Offline nodes without data don't count as skew
+	assert.False(t, byLabel["Kernel"].Skew)
+	assert.Equal(t, "6.8.8-2-pve", byLabel["Kernel"].Values[0])
+	assert.False(t, byLabel["CPU Model"].Skew)
+
+	assert.Equal(t, "1 running / 2", byLabel["Guests"].Values[0])
+	assert.Equal(t, "offline", byLabel["Status"].Values[2])
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/internal/ui/utils"
 	"github.com/devnullvoid/pvetui/pkg/api"
@@ -19,11 +20,21 @@ const (
 	vmTypeLXC     = "lxc"
 )
 
+// insufficientPrivileges replaces values the user or API token may not read.
+const insufficientPrivileges = "Insufficient privileges"
+
 // NodeDetails encapsulates the node details panel.
 type NodeDetails struct {
 	*tview.Table
 
 	app *App
+
+	// node and allNodes are the last rendered node, kept to redraw the
+	// panel when temperatures read over SSH arrive.
+	node     *api.Node
+	allNodes []*api.Node
+	sensors  map[string]*sensorReading
+	boot     map[string]*bootReading
 }
 
 var _ NodeDetailsComponent = (*NodeDetails)(nil)
@@ -34,9 +45,14 @@ func NewNodeDetails() *NodeDetails {
 	table.SetBorders(false)
 	table.SetTitle(" Node Details ")
 	table.SetBorder(true)
+	table.SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary))
 	table.Clear()
 	table.SetCell(0, 0, tview.NewTableCell("Select a node").SetTextColor(theme.Colors.Primary))
 
+	// Only highlight the selected row while the panel has focus.
+	table.SetFocusFunc(func() { table.SetSelectable(true, false) })
+	table.SetBlurFunc(func() { table.SetSelectable(false, false) })
+
 	return &NodeDetails{
 		Table: table,
 	}
@@ -47,6 +63,39 @@ func (nd *NodeDetails) Clear() *tview.Table {
 	return nd.Table.Clear()
 }
 
+// SelectedValue returns the label and value of the selected row.
+func (nd *NodeDetails) SelectedValue() (string, string) {
+	return tableRowValue(nd.Table)
+}
+
+// SelectStorage selects the row of the named storage and reports whether
+// the shown node has it.
+func (nd *NodeDetails) SelectStorage(name string) bool {
+	for row := 0; row < nd.GetRowCount(); row++ {
+		if nd.GetCell(row, 0).Text != "  • "+name {
+			continue
+		}
+
+		if ref, ok := nd.GetCell(row, 1).GetReference().(string); ok && ref == name {
+			nd.Select(row, 0)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeStatusCell returns a cell for a value read from the node status, or a
+// placeholder when the node status is forbidden to the user.
+func nodeStatusCell(node *api.Node, value string) *tview.TableCell {
+	if node.DetailsForbidden {
+		return tview.NewTableCell(insufficientPrivileges + " (Sys.Audit)").SetTextColor(theme.Colors.Warning)
+	}
+
+	return tview.NewTableCell(value).SetTextColor(theme.Colors.Primary)
+}
+
 // SetApp sets the parent app reference for focus management.
 func (nd *NodeDetails) SetApp(app *App) {
 	nd.app = app
@@ -58,6 +107,7 @@ func (nd *NodeDetails) SetApp(app *App) {
 // Update fills the node details table for the given node.
 func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 	if node == nil {
+		nd.node = nil
 		nd.Clear()
 		nd.SetCell(0, 0, tview.NewTableCell("Select a node").SetTextColor(theme.Colors.Primary))
 
@@ -66,6 +116,7 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 
 	nd.Clear()
 
+	nd.node, nd.allNodes = node, allNodes
 	row := 0
 
 	// Basic Info
@@ -73,7 +124,7 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 	// nd.SetCell(row, 1, tview.NewTableCell(node.Name).SetTextColor(theme.Colors.Primary))
 	// row++
 
-	nd.SetCell(row, 0, tview.NewTableCell("🆔 ID").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🆔", "ID")).SetTextColor(theme.Colors.HeaderText))
 	nd.SetCell(row, 1, tview.NewTableCell(node.ID).SetTextColor(theme.Colors.Primary))
 
 	row++
@@ -91,29 +142,38 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 		statusColor = theme.Colors.StatusStopped
 	}
 
-	nd.SetCell(row, 0, tview.NewTableCell("🟢 Status").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🟢", "Status")).SetTextColor(theme.Colors.HeaderText))
 	nd.SetCell(row, 1, tview.NewTableCell(statusText).SetTextColor(statusColor))
 
 	row++
 
-	nd.SetCell(row, 0, tview.NewTableCell("📡 IP").SetTextColor(theme.Colors.HeaderText))
-	nd.SetCell(row, 1, tview.NewTableCell(node.IP).SetTextColor(theme.Colors.Primary))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("📡", "IP")).SetTextColor(theme.Colors.HeaderText))
+	ipValue := node.IP
+	if nd.app != nil {
+		if addr := nd.app.nodeAddress(node); addr != node.IP {
+			ipValue = fmt.Sprintf("%s (connect via %s)", node.IP, addr)
+		}
+	}
+
+	nd.SetCell(row, 1, tview.NewTableCell(ipValue).SetTextColor(theme.Colors.Primary))
 
 	row++
 
+	row = renderNotes(nd.Table, "", node.Description, row)
+
 	// CPU Usage
-	nd.SetCell(row, 0, tview.NewTableCell("🧮 CPU").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🧮", "CPU")).SetTextColor(theme.Colors.HeaderText))
 
 	cpuValue := api.StringNA
 	cpuUsageColor := theme.Colors.Primary
 
 	if node.CPUUsage >= 0 && node.CPUCount > 0 {
 		cpuPercent := node.CPUUsage * 100
-		cpuValue = fmt.Sprintf("%.1f%% of %.0f cores", cpuPercent, node.CPUCount)
+		cpuValue = usageGaugeText(cpuPercent, fmt.Sprintf("%.1f%% of %.0f cores", cpuPercent, node.CPUCount))
 		cpuUsageColor = theme.GetUsageColor(cpuPercent)
 	} else if node.CPUUsage >= 0 {
 		cpuPercent := node.CPUUsage * 100
-		cpuValue = fmt.Sprintf("%.1f%%", cpuPercent)
+		cpuValue = usageGaugeText(cpuPercent, fmt.Sprintf("%.1f%%", cpuPercent))
 		cpuUsageColor = theme.GetUsageColor(cpuPercent)
 	}
 
@@ -122,19 +182,19 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 	row++
 
 	// Load Average
-	nd.SetCell(row, 0, tview.NewTableCell("📊 Load Avg").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("📊", "Load Avg")).SetTextColor(theme.Colors.HeaderText))
 
 	loadAvg := api.StringNA
 	if len(node.LoadAvg) >= 3 {
 		loadAvg = fmt.Sprintf("%s, %s, %s", node.LoadAvg[0], node.LoadAvg[1], node.LoadAvg[2])
 	}
 
-	nd.SetCell(row, 1, tview.NewTableCell(loadAvg).SetTextColor(theme.Colors.Primary))
+	nd.SetCell(row, 1, nodeStatusCell(node, loadAvg))
 
 	row++
 
 	// Memory Usage
-	nd.SetCell(row, 0, tview.NewTableCell("🧠 Memory").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🧠", "Memory")).SetTextColor(theme.Colors.HeaderText))
 
 	memValue := api.StringNA
 	memUsageColor := theme.Colors.Primary
@@ -143,7 +203,7 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 		memUsedFormatted := utils.FormatBytes(int64(node.MemoryUsed * 1073741824))
 		memTotalFormatted := utils.FormatBytes(int64(node.MemoryTotal * 1073741824))
 		memoryPercent := utils.CalculatePercentage(node.MemoryUsed, node.MemoryTotal)
-		memValue = fmt.Sprintf("%.2f%% (%s) / %s", memoryPercent, memUsedFormatted, memTotalFormatted)
+		memValue = usageGaugeText(memoryPercent, fmt.Sprintf("%.2f%% (%s) / %s", memoryPercent, memUsedFormatted, memTotalFormatted))
 		memUsageColor = theme.GetUsageColor(memoryPercent)
 	}
 
@@ -151,11 +211,20 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 
 	row++
 
+	if history := usageHistoryText(models.NodeMetrics(node.Name)); history != "" {
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("📈", "History")).SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 1, tview.NewTableCell(history).SetTextColor(theme.Colors.Info))
+
+		row++
+	}
+
+	row = nd.renderTemperatures(node, row)
+
 	// Storage Usage
 	// Remove the Rootfs row
 
 	// Uptime
-	nd.SetCell(row, 0, tview.NewTableCell("🕒 Uptime").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🕒", "Uptime")).SetTextColor(theme.Colors.HeaderText))
 
 	uptimeValue := api.StringNA
 	if node.Uptime > 0 {
@@ -167,33 +236,35 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 	row++
 
 	// Version
-	nd.SetCell(row, 0, tview.NewTableCell("🔧 Version").SetTextColor(theme.Colors.HeaderText))
-	nd.SetCell(row, 1, tview.NewTableCell(node.Version).SetTextColor(theme.Colors.Primary))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🔧", "Version")).SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 1, nodeStatusCell(node, node.Version))
 
 	row++
 
 	// Kernel
-	nd.SetCell(row, 0, tview.NewTableCell("🧬 Kernel").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🧬", "Kernel")).SetTextColor(theme.Colors.HeaderText))
 
 	kernelValue := node.KernelVersion
 	if idx := strings.Index(kernelValue, "#"); idx != -1 {
 		kernelValue = strings.TrimSpace(kernelValue[:idx])
 	}
 
-	nd.SetCell(row, 1, tview.NewTableCell(kernelValue).SetTextColor(theme.Colors.Primary))
+	nd.SetCell(row, 1, nodeStatusCell(node, kernelValue))
 
 	row++
 
+	row = nd.renderBootInfo(node, row)
+
 	// CGroup Mode (int)
 	if node.CGroupMode != 0 {
-		nd.SetCell(row, 0, tview.NewTableCell("🧩 CGroup Mode").SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🧩", "CGroup Mode")).SetTextColor(theme.Colors.HeaderText))
 		nd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d", node.CGroupMode)).SetTextColor(theme.Colors.Primary))
 
 		row++
 	}
 	// Level
 	if node.Level != "" {
-		nd.SetCell(row, 0, tview.NewTableCell("📈 Level").SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("📈", "Level")).SetTextColor(theme.Colors.HeaderText))
 		nd.SetCell(row, 1, tview.NewTableCell(node.Level).SetTextColor(theme.Colors.Primary))
 
 		row++
@@ -226,7 +297,7 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 	yellowTag := theme.ColorToTag(theme.Colors.Warning)
 	vmText := fmt.Sprintf("[%s]%d running[-], [%s]%d stopped[-], [%s]%d templates[-]", greenTag, vmRunning, redTag, vmStopped, yellowTag, vmTemplates)
 
-	nd.SetCell(row, 0, tview.NewTableCell("💻 VMs").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("💻", "VMs")).SetTextColor(theme.Colors.HeaderText))
 	nd.SetCell(row, 1, tview.NewTableCell(vmText))
 
 	row++
@@ -252,14 +323,14 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 
 	lxcText := fmt.Sprintf("[%s]%d running[-], [%s]%d stopped[-]", greenTag, lxcRunning, redTag, lxcStopped)
 
-	nd.SetCell(row, 0, tview.NewTableCell("📦 LXC").SetTextColor(theme.Colors.HeaderText))
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("📦", "LXC")).SetTextColor(theme.Colors.HeaderText))
 	nd.SetCell(row, 1, tview.NewTableCell(lxcText))
 
 	row++
 
 	// Storage Information (per-pool breakdown)
 	if len(node.Storage) > 0 {
-		nd.SetCell(row, 0, tview.NewTableCell("💾 Storage").SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("💾", "Storage")).SetTextColor(theme.Colors.HeaderText))
 
 		row++
 
@@ -274,15 +345,15 @@ func (nd *NodeDetails) Update(node *api.Node, allNodes []*api.Node) {
 
 				usageColor := theme.GetUsageColor(usedPercent)
 				nd.SetCell(row, 0, tview.NewTableCell("  • "+storage.Name).SetTextColor(theme.Colors.Info))
-				nd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.2f%% (%s/%s)",
+				nd.SetCell(row, 1, tview.NewTableCell(usageGaugeText(usedPercent, fmt.Sprintf("%.2f%% (%s/%s)",
 					usedPercent,
 					utils.FormatBytes(storage.Disk),
-					utils.FormatBytes(storage.MaxDisk))).SetTextColor(usageColor))
+					utils.FormatBytes(storage.MaxDisk)))).SetTextColor(usageColor).SetReference(storage.Name))
 
 				row++
 			} else {
 				nd.SetCell(row, 0, tview.NewTableCell("  • "+storage.Name).SetTextColor(theme.Colors.Info))
-				nd.SetCell(row, 1, tview.NewTableCell(api.StringNA).SetTextColor(theme.Colors.Primary))
+				nd.SetCell(row, 1, tview.NewTableCell(api.StringNA).SetTextColor(theme.Colors.Primary).SetReference(storage.Name))
 
 				row++
 			}
@@ -0,0 +1,126 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// bootInfoTTL is how long the boot environment of a node is reused before
+// it is read again.
+const bootInfoTTL = 10 * time.Minute
+
+// bootReading caches the boot environment of a node.
+type bootReading struct {
+	loading bool
+	loaded  bool
+	info    *api.NodeBootInfo
+	fetched time.Time
+}
+
+// bootInfo returns the boot environment of node, starting a background read
+// when the cached reading is missing or stale.
+func (nd *NodeDetails) bootInfo(node *api.Node) *bootReading {
+	if nd.app == nil || nd.app.client == nil || !node.Online {
+		return nil
+	}
+
+	if nd.boot == nil {
+		nd.boot = make(map[string]*bootReading)
+	}
+
+	reading, ok := nd.boot[node.Name]
+	if !ok {
+		reading = &bootReading{}
+		nd.boot[node.Name] = reading
+	}
+
+	if !reading.loading && (!reading.loaded || time.Since(reading.fetched) > bootInfoTTL) {
+		reading.loading = true
+
+		go nd.readBootInfo(node.Name, reading)
+	}
+
+	return reading
+}
+
+// readBootInfo reads the boot environment of a node and redraws the panel if
+// the node is still shown.
+func (nd *NodeDetails) readBootInfo(name string, reading *bootReading) {
+	info, err := nd.app.client.GetNodeBootInfo(name)
+
+	nd.app.QueueUpdateDraw(func() {
+		reading.loading, reading.loaded = false, true
+		reading.info, reading.fetched = info, time.Now()
+
+		if err != nil {
+			nd.app.logger.Debug("Failed to read boot info of node %s: %v", name, err)
+		}
+
+		if nd.node != nil && nd.node.Name == name {
+			nd.Update(nd.node, nd.allNodes)
+		}
+	})
+}
+
+// bootModeText describes how a node booted, e.g. "UEFI, Secure Boot".
+func bootModeText(info *api.NodeBootInfo) string {
+	switch info.Mode {
+	case api.BootModeEFI:
+		if info.SecureBoot {
+			return "UEFI, Secure Boot"
+		}
+
+		return "UEFI"
+	case api.BootModeLegacy:
+		return "Legacy BIOS"
+	default:
+		return info.Mode
+	}
+}
+
+// renderBootInfo draws the boot mode, a pending kernel and the ZFS and Ceph
+// versions of the node, returning the next free row. Nothing is drawn until
+// the boot environment has been read.
+func (nd *NodeDetails) renderBootInfo(node *api.Node, row int) int {
+	reading := nd.bootInfo(node)
+	if reading == nil || reading.info == nil {
+		return row
+	}
+
+	info := reading.info
+
+	if info.RebootRequired() {
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("⚠️", "Reboot")).SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("Required for kernel %s", info.InstalledKernel)).SetTextColor(theme.Colors.Warning))
+
+		row++
+	}
+
+	if mode := bootModeText(info); mode != "" {
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🥾", "Boot")).SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 1, tview.NewTableCell(mode).SetTextColor(theme.Colors.Primary))
+
+		row++
+	}
+
+	if info.ZFSVersion != "" {
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🗄️", "ZFS")).SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 1, tview.NewTableCell(info.ZFSVersion).SetTextColor(theme.Colors.Primary))
+
+		row++
+	}
+
+	if info.CephVersion != "" {
+		nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🐙", "Ceph")).SetTextColor(theme.Colors.HeaderText))
+		nd.SetCell(row, 1, tview.NewTableCell(info.CephVersion).SetTextColor(theme.Colors.Primary))
+
+		row++
+	}
+
+	return row
+}
@@ -0,0 +1,164 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// sensorsTTL is how long temperatures read over SSH are reused before they
+// are read again.
+const sensorsTTL = time.Minute
+
+// sensorReading caches the temperatures of a node read over SSH.
+type sensorReading struct {
+	loading bool
+	loaded  bool
+	temps   []api.Temperature
+	err     error
+	fetched time.Time
+}
+
+// temperatures returns the temperatures of node. Readings from the node
+// status are used when present; otherwise they are read over SSH if enabled,
+// starting a background read when the cached reading is missing or stale.
+func (nd *NodeDetails) temperatures(node *api.Node) *sensorReading {
+	if len(node.Temperatures) > 0 {
+		return &sensorReading{loaded: true, temps: node.Temperatures}
+	}
+
+	if nd.app == nil || !nd.app.config.Sensors.SSH || nd.app.config.SSHUser == "" || nd.app.nodeAddress(node) == "" || !node.Online {
+		return nil
+	}
+
+	if nd.sensors == nil {
+		nd.sensors = make(map[string]*sensorReading)
+	}
+
+	reading, ok := nd.sensors[node.Name]
+	if !ok {
+		reading = &sensorReading{}
+		nd.sensors[node.Name] = reading
+	}
+
+	if !reading.loading && (!reading.loaded || time.Since(reading.fetched) > sensorsTTL) {
+		reading.loading = true
+
+		go nd.readSensors(node.Name, nd.app.config.SSHUser, nd.app.nodeAddress(node), reading)
+	}
+
+	return reading
+}
+
+// readSensors reads the temperatures of a node over SSH and redraws the
+// panel if the node is still shown.
+func (nd *NodeDetails) readSensors(name, user, ip string, reading *sensorReading) {
+	output, err := ssh.ReadSensors(nd.app.ctx, ssh.NewDefaultExecutor(), user, ip, ssh.NodeOptions())
+
+	var temps []api.Temperature
+	if err == nil {
+		temps, err = api.ParseSensorsJSON(output)
+	}
+
+	nd.app.QueueUpdateDraw(func() {
+		reading.loading, reading.loaded = false, true
+		reading.temps, reading.err, reading.fetched = temps, err, time.Now()
+
+		if err != nil {
+			nd.app.logger.Debug("Failed to read sensors of node %s: %v", name, err)
+		}
+
+		if nd.node != nil && nd.node.Name == name {
+			nd.Update(nd.node, nd.allNodes)
+		}
+	})
+}
+
+// temperatureColor returns the color of a temperature for the configured thresholds.
+func (nd *NodeDetails) temperatureColor(celsius float64) tcell.Color {
+	warning, critical := float64(0), float64(0)
+	if nd.app != nil {
+		warning, critical = nd.app.config.Sensors.Warning, nd.app.config.Sensors.Critical
+	}
+
+	switch {
+	case critical > 0 && celsius >= critical:
+		return theme.Colors.Error
+	case warning > 0 && celsius >= warning:
+		return theme.Colors.Warning
+	default:
+		return theme.Colors.Success
+	}
+}
+
+// renderTemperatures draws the hottest reading of the node and of each
+// sensor chip, returning the next free row. Nothing is drawn when no
+// temperatures are available.
+func (nd *NodeDetails) renderTemperatures(node *api.Node, row int) int {
+	reading := nd.temperatures(node)
+	if reading == nil {
+		return row
+	}
+
+	nd.SetCell(row, 0, tview.NewTableCell(theme.Label("🌡️", "Temperature")).SetTextColor(theme.Colors.HeaderText))
+
+	switch {
+	case !reading.loaded:
+		nd.SetCell(row, 1, tview.NewTableCell("Loading...").SetTextColor(theme.Colors.Secondary))
+
+		return row + 1
+	case reading.err != nil:
+		nd.SetCell(row, 1, tview.NewTableCell("Unavailable (is lm-sensors installed?)").SetTextColor(theme.Colors.Secondary))
+
+		return row + 1
+	case len(reading.temps) == 0:
+		nd.SetCell(row, 1, tview.NewTableCell("No sensors found").SetTextColor(theme.Colors.Secondary))
+
+		return row + 1
+	}
+
+	hottest := make(map[string]api.Temperature)
+	var order []string
+	overall := reading.temps[0]
+
+	for _, temp := range reading.temps {
+		current, ok := hottest[temp.Sensor]
+		if !ok {
+			order = append(order, temp.Sensor)
+		}
+
+		if !ok || temp.Celsius > current.Celsius {
+			hottest[temp.Sensor] = temp
+		}
+
+		if temp.Celsius > overall.Celsius {
+			overall = temp
+		}
+	}
+
+	nd.SetCell(row, 1, tview.NewTableCell(formatTemperature(overall)).SetTextColor(nd.temperatureColor(overall.Celsius)))
+
+	row++
+
+	for _, sensor := range order {
+		temp := hottest[sensor]
+
+		nd.SetCell(row, 0, tview.NewTableCell("  • "+sensor).SetTextColor(theme.Colors.Info))
+		nd.SetCell(row, 1, tview.NewTableCell(formatTemperature(temp)).SetTextColor(nd.temperatureColor(temp.Celsius)))
+
+		row++
+	}
+
+	return row
+}
+
+// formatTemperature formats a reading as "54.0°C (Package id 0)".
+func formatTemperature(temp api.Temperature) string {
+	return fmt.Sprintf("%.1f°C (%s)", temp.Celsius, temp.Label)
+}
@@ -0,0 +1,87 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// toggleNodeGuests jumps from the selected node to the Guests page filtered
+// to its guests, and from the Guests page back to the node of the filter or
+// of the selected guest.
+func (a *App) toggleNodeGuests() {
+	currentPage, _ := a.pages.GetFrontPage()
+
+	switch currentPage {
+	case api.PageNodes:
+		if node := a.nodeList.GetSelectedNode(); node != nil {
+			a.showNodeGuests(node.Name)
+		}
+	case api.PageGuests:
+		a.returnToGuestNode()
+	}
+}
+
+// showNodeGuests switches to the Guests page and filters it to the guests
+// of a node.
+func (a *App) showNodeGuests(node string) {
+	filter := models.NodeFilter(node)
+
+	state := models.GlobalState.GetSearchState(api.PageGuests)
+	if state == nil {
+		state = &models.SearchState{CurrentPage: api.PageGuests}
+		models.GlobalState.SearchStates[api.PageGuests] = state
+	}
+
+	state.Filter = filter
+	state.SelectedIndex = 0
+
+	models.FilterVMs(filter)
+	a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+
+	a.pages.SwitchToPage(api.PageGuests)
+	a.SetFocus(a.vmList)
+
+	if len(models.GlobalState.FilteredVMs) == 0 {
+		a.vmDetails.Clear()
+		a.header.ShowWarning(fmt.Sprintf("No guests on %s", node))
+
+		return
+	}
+
+	a.vmList.SetCurrentItem(0)
+
+	if vm := a.vmList.GetSelectedVM(); vm != nil {
+		a.vmDetails.Update(vm)
+	}
+
+	a.header.ShowSuccess(fmt.Sprintf("Guests on %s (%s to return)", node, a.config.KeyBindings.NodeGuests))
+}
+
+// returnToGuestNode clears a node filter of the Guests page and selects the
+// filtered node, or the node of the selected guest, on the Nodes page.
+func (a *App) returnToGuestNode() {
+	var node string
+
+	if state := models.GlobalState.GetSearchState(api.PageGuests); state != nil {
+		if filtered, ok := models.FilteredNode(state.Filter); ok {
+			node = filtered
+
+			clearSearchFilter(api.PageGuests)
+			models.FilterVMs("")
+			a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+		}
+	}
+
+	if node == "" {
+		vm := a.vmList.GetSelectedVM()
+		if vm == nil {
+			return
+		}
+
+		node = vm.Node
+	}
+
+	a.selectNodeByName(node)
+}
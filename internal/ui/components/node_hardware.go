@@ -0,0 +1,297 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// guestPassthrough is a device entry of a guest config.
+type guestPassthrough struct {
+	VM          *api.VM
+	Passthrough api.Passthrough
+}
+
+// label returns e.g. "gaming (101) hostpci0", with the mdev type if any.
+func (g guestPassthrough) label() string {
+	label := fmt.Sprintf("%s (%d) %s", g.VM.Name, g.VM.ID, g.Passthrough.Key)
+	if g.Passthrough.MDev != "" {
+		label += " " + g.Passthrough.MDev
+	}
+
+	return label
+}
+
+// nodeHardware is the hardware inventory of a node.
+type nodeHardware struct {
+	PCI         []api.PCIDevice
+	USB         []api.USBDevice
+	Passthrough []guestPassthrough
+}
+
+// showNodeHardware lists the PCI and USB devices of a node with their IOMMU
+// groups and the guests they are passed through to.
+func (a *App) showNodeHardware(node *api.Node) {
+	if node == nil {
+		return
+	}
+
+	if !node.Online {
+		a.header.ShowError(fmt.Sprintf("Node %s is offline", node.Name))
+
+		return
+	}
+
+	var guests []*api.VM
+
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil && vm.Node == node.Name && vm.Type == api.VMTypeQemu {
+			guests = append(guests, vm)
+		}
+	}
+
+	a.header.ShowLoading(fmt.Sprintf("Loading hardware of %s...", node.Name))
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		var hardware nodeHardware
+
+		pci, pciErr := client.GetNodePCIDevices(node.Name)
+		usb, usbErr := client.GetNodeUSBDevices(node.Name)
+
+		if pciErr != nil && usbErr != nil {
+			a.QueueUpdateDraw(func() {
+				a.header.ShowError(fmt.Sprintf("Failed to load hardware of %s: %v", node.Name, pciErr))
+			})
+
+			return
+		}
+
+		hardware.PCI = pci
+		hardware.USB = usb
+
+		for _, vm := range guests {
+			entries, err := client.GetGuestPassthrough(vm)
+			if err != nil {
+				models.GetUILogger().Debug("Failed to load passthrough devices of VM %d: %v", vm.ID, err)
+
+				continue
+			}
+
+			for _, entry := range entries {
+				hardware.Passthrough = append(hardware.Passthrough, guestPassthrough{VM: vm, Passthrough: entry})
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+			a.showNodeHardwareTable(node.Name, hardware)
+		})
+	}()
+}
+
+// showNodeHardwareTable renders the hardware inventory. Enter on a device
+// that is passed through selects its guest.
+func (a *App) showNodeHardwareTable(nodeName string, hardware nodeHardware) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Hardware of %s (Enter: go to guest) ", nodeName)).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	table.SetBorderPadding(0, 0, 1, 1)
+
+	row := 0
+
+	section := func(title string, headers ...string) {
+		if row > 0 {
+			table.SetCell(row, 0, tview.NewTableCell("").SetSelectable(false))
+			row++
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(title).
+			SetTextColor(theme.Colors.Title).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+		row++
+
+		for col, header := range headers {
+			table.SetCell(row, col, tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		row++
+	}
+
+	addRow := func(users []guestPassthrough, cells ...*tview.TableCell) {
+		usedBy := make([]string, len(users))
+		for i, user := range users {
+			usedBy[i] = user.label()
+		}
+
+		cells = append(cells, hardwareCell(strings.Join(usedBy, ", "), theme.Colors.Warning))
+
+		for col, cell := range cells {
+			if len(users) > 0 {
+				cell.SetReference(users[0].VM)
+			}
+
+			table.SetCell(row, col, cell.SetExpansion(1))
+		}
+		row++
+	}
+
+	groupSizes := make(map[int]int)
+	for _, device := range hardware.PCI {
+		groupSizes[device.IOMMUGroup]++
+	}
+
+	section(theme.Label("🔌", "PCI Devices"), "Address", "IOMMU", "Device", "Passed through to")
+
+	if len(hardware.PCI) == 0 {
+		table.SetCell(row, 0, hardwareCell("No PCI devices reported", theme.Colors.Secondary))
+		row++
+	}
+
+	for _, device := range hardware.PCI {
+		groupText := api.StringNA
+		groupColor := theme.Colors.Secondary
+
+		if device.IOMMUGroup >= 0 {
+			groupText = strconv.Itoa(device.IOMMUGroup)
+			groupColor = theme.Colors.Primary
+
+			// The whole group has to be passed through together
+			if others := groupSizes[device.IOMMUGroup] - 1; others > 0 {
+				groupText += fmt.Sprintf(" (+%d)", others)
+				groupColor = theme.Colors.Warning
+			}
+		}
+
+		name := strings.TrimSpace(device.VendorName + " " + device.DeviceName)
+		if name == "" {
+			name = fmt.Sprintf("%s:%s", device.VendorID, device.DeviceID)
+		}
+
+		if device.MDev {
+			name += " [mdev]"
+		}
+
+		addRow(passthroughUsers(hardware.Passthrough, func(p api.Passthrough) bool { return p.MatchesPCI(device) }),
+			hardwareCell(device.ID, theme.Colors.Primary),
+			hardwareCell(groupText, groupColor),
+			hardwareCell(name, theme.Colors.Primary))
+	}
+
+	section(theme.Label("🖱️", "USB Devices"), "Port", "ID", "Device", "Passed through to")
+
+	if len(hardware.USB) == 0 {
+		table.SetCell(row, 0, hardwareCell("No USB devices reported", theme.Colors.Secondary))
+		row++
+	}
+
+	for _, device := range hardware.USB {
+		name := strings.TrimSpace(device.Manufacturer + " " + device.Product)
+
+		addRow(passthroughUsers(hardware.Passthrough, func(p api.Passthrough) bool { return p.MatchesUSB(device) }),
+			hardwareCell(device.Path, theme.Colors.Primary),
+			hardwareCell(fmt.Sprintf("%s:%s", strings.TrimPrefix(device.VendorID, "0x"), strings.TrimPrefix(device.ProductID, "0x")), theme.Colors.Primary),
+			hardwareCell(name, theme.Colors.Primary))
+	}
+
+	// Devices assigned through cluster resource mappings
+	var mapped []guestPassthrough
+
+	for _, entry := range hardware.Passthrough {
+		if entry.Passthrough.Mapping != "" {
+			mapped = append(mapped, entry)
+		}
+	}
+
+	if len(mapped) > 0 {
+		section(theme.Label("🔗", "Resource Mappings"), "Mapping", "", "", "Passed through to")
+
+		for _, entry := range mapped {
+			addRow([]guestPassthrough{entry}, hardwareCell(entry.Passthrough.Mapping, theme.Colors.Primary), hardwareCell("", theme.Colors.Primary), hardwareCell("", theme.Colors.Primary))
+		}
+	}
+
+	table.Select(2, 0) // First device row
+
+	closeHardware := func() {
+		a.removePageIfPresent("nodeHardware")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	table.SetSelectedFunc(func(selected, _ int) {
+		vm, ok := table.GetCell(selected, 0).GetReference().(*api.VM)
+		if !ok {
+			return
+		}
+
+		closeHardware()
+		a.selectGuest(vm)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			closeHardware()
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := row + 2
+	if height > 30 {
+		height = 30
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, height, 0, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("nodeHardware")
+	a.pages.AddPage("nodeHardware", modal, true, true)
+	a.SetFocus(table)
+}
+
+// passthroughUsers returns the guest entries passing through a device.
+func passthroughUsers(entries []guestPassthrough, matches func(api.Passthrough) bool) []guestPassthrough {
+	var users []guestPassthrough
+
+	for _, entry := range entries {
+		if matches(entry.Passthrough) {
+			users = append(users, entry)
+		}
+	}
+
+	return users
+}
+
+// hardwareCell creates an escaped table cell.
+func hardwareCell(text string, color tcell.Color) *tview.TableCell {
+	return tview.NewTableCell(tview.Escape(text)).SetTextColor(color)
+}
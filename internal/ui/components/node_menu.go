@@ -9,7 +9,14 @@ import (
 const (
 	nodeActionOpenShell = "Open Shell"
 	nodeActionOpenVNC   = "Open VNC Console"
+	nodeActionOpenWebUI = "Open in Web UI"
+	nodeActionEditNotes = "Edit Notes"
 	nodeActionInstall   = "Install Community Script"
+	nodeActionCompare   = "Compare Nodes"
+	nodeActionHardware  = "Hardware Inventory"
+	nodeActionFromConf  = "Create VM from Config"
+	nodeActionPrune     = "Prune Backups"
+	nodeActionBroadcast = "Run Command on Nodes"
 	nodeActionRefresh   = "Refresh"
 )
 
@@ -27,13 +34,20 @@ func (a *App) ShowNodeContextMenu() {
 	menuItems := []string{
 		nodeActionOpenShell,
 		nodeActionOpenVNC,
+		nodeActionOpenWebUI,
 		// "View Logs",
+		nodeActionEditNotes,
 		nodeActionInstall,
+		nodeActionCompare,
+		nodeActionHardware,
+		nodeActionFromConf,
+		nodeActionPrune,
+		nodeActionBroadcast,
 		nodeActionRefresh,
 	}
 
 	// Define letter shortcuts for node actions
-	shortcuts := []rune{'s', 'v', 'i', 'r'}
+	shortcuts := []rune{'s', 'v', 'w', 'n', 'i', 'c', 'd', 'f', 'b', 'x', 'r'}
 
 	menu := NewContextMenuWithShortcuts(" Node Actions ", menuItems, shortcuts, func(index int, action string) {
 		a.CloseContextMenu()
@@ -43,10 +57,24 @@ func (a *App) ShowNodeContextMenu() {
 			a.openNodeShell()
 		case nodeActionOpenVNC:
 			a.openNodeVNC()
+		case nodeActionOpenWebUI:
+			a.openNodeWebUI()
 		// case "View Logs":
 		// 	a.showMessage("Viewing logs for node: " + node.Name)
+		case nodeActionEditNotes:
+			a.editNodeNotes(node)
 		case nodeActionInstall:
 			a.openScriptSelector(node, nil)
+		case nodeActionCompare:
+			a.showNodeComparePicker()
+		case nodeActionHardware:
+			a.showNodeHardware(node)
+		case nodeActionFromConf:
+			a.showCreateFromConfigPicker(node)
+		case nodeActionPrune:
+			a.showPruneBackupsDialog(node)
+		case nodeActionBroadcast:
+			a.showNodeBroadcast()
 		case nodeActionRefresh:
 			a.refreshNodeData(node)
 		}
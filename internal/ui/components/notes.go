@@ -0,0 +1,151 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// maxNotesLines is the number of notes lines shown in the details panels.
+const maxNotesLines = 8
+
+// renderNotes draws notes rendered from markdown into table starting at row,
+// one line per row, and returns the next free row. Labels are prefixed with
+// indent to match the surrounding rows.
+func renderNotes(table *tview.Table, indent, description string, row int) int {
+	lines := utils.RenderMarkdown(description)
+
+	for i, line := range lines {
+		label := ""
+		if i == 0 {
+			label = indent + theme.Label("📝", "Notes")
+		}
+
+		if i == maxNotesLines {
+			table.SetCell(row, 0, tview.NewTableCell(""))
+			table.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("… %d more lines (edit notes to see all)", len(lines)-i)).
+				SetTextColor(theme.Colors.Secondary))
+
+			return row + 1
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(label).SetTextColor(theme.Colors.HeaderText))
+		table.SetCell(row, 1, tview.NewTableCell(line).SetTextColor(theme.Colors.Info))
+
+		row++
+	}
+
+	return row
+}
+
+// showNotesEditor opens a multi-line editor for notes. save runs in the
+// background with the edited text; onSaved runs on the UI thread afterwards.
+func (a *App) showNotesEditor(title, notes string, save func(string) error, onSaved func()) {
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" %s ", title))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	textArea := tview.NewTextArea().
+		SetText(notes, false).
+		SetPlaceholder("Markdown notes, e.g. owner, purpose, change info")
+	textArea.SetLabel("")
+	textArea.SetSize(14, 0)
+	form.AddFormItem(textArea)
+
+	closeEditor := func() {
+		a.removePageIfPresent("notesEditor")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	saveNotes := func() {
+		text := utils.TrimTrailingWhitespace(textArea.GetText())
+
+		closeEditor()
+		a.header.ShowLoading("Saving notes...")
+
+		go func() {
+			err := save(text)
+
+			a.QueueUpdateDraw(func() {
+				if err != nil {
+					a.showActionError("Failed to save notes", err)
+
+					return
+				}
+
+				a.header.ShowSuccess("Notes saved")
+
+				if onSaved != nil {
+					onSaved()
+				}
+			})
+		}()
+	}
+
+	form.AddButton("Save", saveNotes)
+	form.AddButton("Cancel", closeEditor)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeEditor()
+
+			return nil
+		case tcell.KeyCtrlS:
+			saveNotes()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 20, 0, true).
+			AddItem(nil, 0, 1, false), 80, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.pages.AddPage("notesEditor", modal, true, true)
+	a.SetFocus(form)
+}
+
+// editVMNotes opens the notes editor for a guest.
+func (a *App) editVMNotes(vm *api.VM) {
+	guestType := "VM"
+	if vm.Type == api.VMTypeLXC {
+		guestType = "CT"
+	}
+
+	a.showNotesEditor(fmt.Sprintf("Notes: %s %d - %s", guestType, vm.ID, vm.Name), vm.Description,
+		func(text string) error {
+			return a.client.UpdateVMDescription(vm, text)
+		},
+		func() {
+			a.refreshVMData(vm)
+		})
+}
+
+// editNodeNotes opens the notes editor for a node.
+func (a *App) editNodeNotes(node *api.Node) {
+	a.showNotesEditor(fmt.Sprintf("Notes: node %s", node.Name), node.Description,
+		func(text string) error {
+			return a.client.UpdateNodeDescription(node.Name, text)
+		},
+		func() {
+			a.refreshNodeData(node)
+		})
+}
@@ -0,0 +1,163 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/notify"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// notifiesEvent reports whether any notification backend is sent the event.
+func (a *App) notifiesEvent(event string) bool {
+	for _, notification := range a.config.Notifications {
+		if notification.Notifies(event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notifyAlerts sends the alerts that appeared since the previous call to
+// the notification backends. The first call only records the alerts present
+// at startup so they aren't sent again every time pvetui is started.
+func (a *App) notifyAlerts() {
+	if !a.notifiesEvent(config.NotificationEventAlerts) {
+		return
+	}
+
+	seeded := a.notifiedAlerts != nil
+	current := make(map[string]bool)
+
+	var messages []notify.Message
+
+	for _, alert := range a.currentAlerts() {
+		key := alertKey(alert)
+		current[key] = true
+
+		if seeded && !a.notifiedAlerts[key] {
+			messages = append(messages, notify.Message{
+				Event: config.NotificationEventAlerts,
+				Title: "pvetui alert",
+				Text:  alert.Message,
+				Time:  time.Now(),
+			})
+		}
+	}
+
+	// Resolved alerts are forgotten so they are sent again if they come back
+	a.notifiedAlerts = current
+	a.sendNotifications(messages)
+}
+
+// notifyFailedTasks sends the tasks that failed since the previous call to
+// the notification backends. Like notifyAlerts, the first call only records
+// the failures already listed.
+func (a *App) notifyFailedTasks(tasks []*api.ClusterTask) {
+	if !a.notifiesEvent(config.NotificationEventTasks) {
+		return
+	}
+
+	seeded := a.notifiedTasks != nil
+	current := make(map[string]bool)
+
+	var messages []notify.Message
+
+	for _, task := range tasks {
+		if task == nil || task.EndTime == 0 || task.Status == "" || task.Status == api.TaskExitStatusOK {
+			continue
+		}
+
+		current[task.UPID] = true
+
+		if seeded && !a.notifiedTasks[task.UPID] {
+			messages = append(messages, notify.Message{
+				Event: config.NotificationEventTasks,
+				Title: "pvetui task failed",
+				Text:  failedTaskText(task),
+				Time:  time.Unix(task.EndTime, 0),
+			})
+		}
+	}
+
+	a.notifiedTasks = current
+	a.sendNotifications(messages)
+}
+
+// notifySchemaDrift sends a field of an API response that is missing or has
+// an unexpected type to the notification backends. The client reports each
+// one once.
+func (a *App) notifySchemaDrift(drift api.SchemaDrift) {
+	if !a.notifiesEvent(config.NotificationEventSchemaDrift) {
+		return
+	}
+
+	text := drift.String()
+	if drift.Version.Major > 0 {
+		text = fmt.Sprintf("%s (Proxmox VE %s)", text, drift.Version)
+	}
+
+	a.sendNotifications([]notify.Message{{
+		Event: config.NotificationEventSchemaDrift,
+		Title: "pvetui API schema drift",
+		Text:  text,
+		Time:  time.Now(),
+	}})
+}
+
+// sendNotifications delivers messages to all backends in the background.
+// Failures are logged only.
+func (a *App) sendNotifications(messages []notify.Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	notifications := a.config.Notifications
+
+	go func() {
+		defer crash.Recover()
+
+		client := &http.Client{Timeout: notify.SendTimeout}
+
+		for _, msg := range messages {
+			for _, notification := range notifications {
+				ctx, cancel := context.WithTimeout(a.ctx, notify.SendTimeout)
+				err := notify.Send(ctx, client, notification, msg)
+
+				cancel()
+
+				if err != nil {
+					models.GetUILogger().Error("Failed to send notification: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// alertKey identifies an alert across refreshes. Guest alerts include the
+// detection time so a guest that restarts again is reported again.
+func alertKey(alert models.Alert) string {
+	var vmid int
+	if alert.VM != nil {
+		vmid = alert.VM.ID
+	}
+
+	return fmt.Sprintf("%d/%s/%s/%d/%d", alert.Kind, alert.Node, alert.Storage, vmid, alert.Time.Unix())
+}
+
+// failedTaskText describes a failed task, e.g.
+// "VM Start 104 on pve1 failed: command 'qm start 104' failed".
+func failedTaskText(task *api.ClusterTask) string {
+	name := formatTaskType(task.Type)
+	if task.ID != "" {
+		name += " " + task.ID
+	}
+
+	return fmt.Sprintf("%s on %s failed: %s", name, task.Node, task.Status)
+}
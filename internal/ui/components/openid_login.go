@@ -0,0 +1,16 @@
+package components
+
+import (
+	"github.com/devnullvoid/pvetui/internal/adapters"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// openIDAuthorizer repeats OpenID logins in the browser, e.g. when the
+// ticket expires or a profile is switched, telling the user in the header.
+func (a *App) openIDAuthorizer() api.OpenIDAuthorizer {
+	return adapters.NewBrowserOpenIDAuthorizer(a.config.GetOpenIDRedirectURL(), func(string) {
+		a.QueueUpdateDraw(func() {
+			a.header.ShowLoading("Complete the OpenID login in your browser")
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// placementOptions orders candidate target nodes for a guest by the configured
+// placement strategy and returns them with their dropdown labels. The first
+// node is the suggestion; nodes without enough free memory come last.
+// suggested is false if no node has room for the guest.
+func (a *App) placementOptions(vm *api.VM, candidates []string) (nodes, labels []string, suggested bool) {
+	capacities := models.CollectCapacity(models.GlobalState.OriginalNodes, models.GlobalState.OriginalVMs)
+	placements := models.RankPlacements(capacities, candidates, vm, models.PlacementScorerFor(a.config.Placement.Strategy))
+
+	ranked := make(map[string]bool, len(placements))
+
+	for i, placement := range placements {
+		label := fmt.Sprintf("%s (%s)", placement.Node, placement.Reason)
+		if i == 0 {
+			label = fmt.Sprintf("%s %s (suggested: %s)", placement.Node, theme.Icon("⭐", "*"), placement.Reason)
+		}
+
+		nodes = append(nodes, placement.Node)
+		labels = append(labels, label)
+		ranked[placement.Node] = true
+	}
+
+	for _, node := range candidates {
+		if ranked[node] {
+			continue
+		}
+
+		nodes = append(nodes, node)
+		labels = append(labels, node+" (not enough free memory)")
+	}
+
+	return nodes, labels, len(placements) > 0
+}
+
+// affinitySummary renders the affinity rules a target node would break for
+// a dialog field.
+func affinitySummary(conflicts []string) string {
+	if len(conflicts) == 0 {
+		return "no conflicts"
+	}
+
+	return theme.Icon("⚠️", "!") + " " + strings.Join(conflicts, "; ")
+}
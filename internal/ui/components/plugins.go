@@ -0,0 +1,196 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+	"github.com/devnullvoid/pvetui/pkg/plugin"
+)
+
+// pluginAction is a guest menu entry contributed by a plugin.
+type pluginAction struct {
+	plugin *plugin.Plugin
+	action plugin.Action
+}
+
+// loadPlugins starts the configured plugins in the background and applies
+// their actions, sections and columns once they are described. Plugins that
+// fail to load are logged and reported in the header.
+func (a *App) loadPlugins() {
+	configs := a.config.Plugins
+	if len(configs) == 0 && len(a.plugins) == 0 {
+		return
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		uiLogger := models.GetUILogger()
+
+		var (
+			loaded []*plugin.Plugin
+			failed []string
+		)
+
+		for _, cfg := range configs {
+			p, err := plugin.Load(a.ctx, ssh.ExpandHome(cfg.Path), cfg.Args, time.Duration(cfg.Timeout)*time.Second)
+			if err != nil {
+				uiLogger.Error("Failed to load plugin: %v", err)
+				failed = append(failed, cfg.Path)
+
+				continue
+			}
+
+			uiLogger.Info("Loaded plugin %s from %s", p.Name(), p.Path)
+			loaded = append(loaded, p)
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.plugins = loaded
+			a.pluginColumns = nil
+			a.vmDetails.SetPlugins(loaded)
+
+			if len(failed) > 0 {
+				a.header.ShowWarning(fmt.Sprintf("Failed to load plugin(s): %s (see logs)", strings.Join(failed, ", ")))
+			}
+
+			a.refreshPluginColumns()
+		})
+	}()
+}
+
+// pluginActions returns the guest menu entries of all plugins.
+func (a *App) pluginActions() []pluginAction {
+	var entries []pluginAction
+
+	for _, p := range a.plugins {
+		for _, action := range p.Manifest.Actions {
+			entries = append(entries, pluginAction{plugin: p, action: action})
+		}
+	}
+
+	return entries
+}
+
+// runPluginAction runs a plugin action for a guest in the background and
+// shows its message in the header.
+func (a *App) runPluginAction(entry pluginAction, vm *api.VM) {
+	label := entry.action.Label
+	a.header.ShowLoading(fmt.Sprintf("Running '%s'", label))
+
+	guest := pluginGuest(vm)
+
+	go func() {
+		defer crash.Recover()
+
+		message, err := entry.plugin.Action(a.ctx, entry.action.ID, guest)
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				models.GetUILogger().Error("Plugin action %q failed: %v", label, err)
+				a.header.ShowError(fmt.Sprintf("'%s' failed: %v", label, err))
+
+				return
+			}
+
+			if message == "" {
+				message = fmt.Sprintf("'%s' completed", label)
+			}
+
+			a.header.ShowSuccess(message)
+		})
+	}()
+}
+
+// refreshPluginColumns asks the plugins with columns for the values of all
+// guests and redraws the guest list with them.
+func (a *App) refreshPluginColumns() {
+	var plugins []*plugin.Plugin
+
+	for _, p := range a.plugins {
+		if len(p.Manifest.Columns) > 0 {
+			plugins = append(plugins, p)
+		}
+	}
+
+	if len(plugins) == 0 {
+		return
+	}
+
+	guests := make([]plugin.Guest, 0, len(models.GlobalState.OriginalVMs))
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil {
+			guests = append(guests, pluginGuest(vm))
+		}
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		columns := make(map[string]string)
+
+		for _, p := range plugins {
+			values, err := p.Columns(a.ctx, guests)
+			if err != nil {
+				models.GetUILogger().Error("Failed to load plugin columns: %v", err)
+
+				continue
+			}
+
+			for key, formatted := range formatPluginColumns(p.Manifest.Columns, values) {
+				columns[key] = strings.TrimSpace(columns[key] + " " + formatted)
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.pluginColumns = columns
+			a.vmList.SetVMs(models.GlobalState.FilteredVMs)
+		})
+	}()
+}
+
+// formatPluginColumns renders the column values of each guest as
+// "title=value" pairs in manifest order.
+func formatPluginColumns(columns []plugin.Column, values map[string]map[string]string) map[string]string {
+	formatted := make(map[string]string, len(values))
+
+	for key, guestValues := range values {
+		var parts []string
+
+		for _, column := range columns {
+			if value := guestValues[column.ID]; value != "" {
+				parts = append(parts, column.Title+"="+value)
+			}
+		}
+
+		if len(parts) > 0 {
+			formatted[key] = strings.Join(parts, " ")
+		}
+	}
+
+	return formatted
+}
+
+// pluginColumnText returns the plugin column values of a guest.
+func (a *App) pluginColumnText(vm *api.VM) string {
+	return a.pluginColumns[plugin.GuestKey(vm.Node, vm.ID)]
+}
+
+// pluginGuest describes a guest to plugins.
+func pluginGuest(vm *api.VM) plugin.Guest {
+	return plugin.Guest{
+		ID:     vm.ID,
+		Name:   vm.Name,
+		Node:   vm.Node,
+		Type:   vm.Type,
+		Status: vm.Status,
+		IP:     vm.IP,
+		Tags:   vm.Tags,
+		Uptime: vm.Uptime,
+	}
+}
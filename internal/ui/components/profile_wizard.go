@@ -78,7 +78,8 @@ func (a *App) createEmbeddedConfigWizard(cfg *config.Config, resultChan chan<- W
 	}
 
 	// Determine which data to use for form fields
-	var addr, user, password, tokenID, tokenSecret, realm, apiPath, sshUser string
+	var addr, user, password, tokenID, tokenSecret, realm, apiPath, sshUser, sshKeyFile string
+	var sshPort int
 	var insecure bool
 
 	// If we have profiles and a default profile, use profile data
@@ -93,6 +94,8 @@ func (a *App) createEmbeddedConfigWizard(cfg *config.Config, resultChan chan<- W
 			apiPath = profile.ApiPath
 			insecure = profile.Insecure
 			sshUser = profile.SSHUser
+			sshPort = profile.SSHPort
+			sshKeyFile = profile.SSHKeyFile
 		}
 	} else {
 		// Use legacy fields
@@ -105,6 +108,8 @@ func (a *App) createEmbeddedConfigWizard(cfg *config.Config, resultChan chan<- W
 		apiPath = cfg.ApiPath
 		insecure = cfg.Insecure
 		sshUser = cfg.SSHUser
+		sshPort = cfg.SSHPort
+		sshKeyFile = cfg.SSHKeyFile
 	}
 
 	form.AddInputField("Proxmox API URL", addr, 40, nil, func(text string) {
@@ -189,17 +194,32 @@ func (a *App) createEmbeddedConfigWizard(cfg *config.Config, resultChan chan<- W
 			cfg.Insecure = checked
 		}
 	})
-	form.AddInputField("SSH Username", sshUser, 20, nil, func(text string) {
+	sshSettings := &wizardSSHSettings{User: sshUser, Port: sshPort, KeyFile: sshKeyFile}
+	sshForm := newSSHSettingsPage(a.Application, pages, form, sshSettings, func() string {
+		if len(cfg.Profiles) > 0 && cfg.DefaultProfile != "" {
+			return hostFromAPIURL(cfg.Profiles[cfg.DefaultProfile].Addr)
+		}
+
+		return hostFromAPIURL(cfg.Addr)
+	}, func() {
 		if len(cfg.Profiles) > 0 && cfg.DefaultProfile != "" {
 			if profile, exists := cfg.Profiles[cfg.DefaultProfile]; exists {
-				profile.SSHUser = strings.TrimSpace(text)
+				profile.SSHUser = sshSettings.User
+				profile.SSHPort = sshSettings.Port
+				profile.SSHKeyFile = sshSettings.KeyFile
 				cfg.Profiles[cfg.DefaultProfile] = profile
 			}
 		} else {
-			cfg.SSHUser = strings.TrimSpace(text)
+			cfg.SSHUser = sshSettings.User
+			cfg.SSHPort = sshSettings.Port
+			cfg.SSHKeyFile = sshSettings.KeyFile
 		}
 	})
 
+	form.AddButton("SSH Settings", func() {
+		pages.SwitchToPage("ssh")
+		a.Application.SetFocus(sshForm)
+	})
 	form.AddButton("Save", func() {
 		// Validate profile name for all profiles
 		if profileName == "" {
@@ -209,7 +229,7 @@ func (a *App) createEmbeddedConfigWizard(cfg *config.Config, resultChan chan<- W
 
 		// Check if profile already exists (for new profiles or renamed profiles)
 		if isNewProfile || profileName != cfg.DefaultProfile {
-			if a.config.Profiles != nil && a.config.Profiles[profileName] != (config.ProfileConfig{}) {
+			if _, exists := a.config.Profiles[profileName]; exists {
 				showWizardModal(pages, form, a.Application, "error", "Profile '"+profileName+"' already exists.", nil)
 				return
 			}
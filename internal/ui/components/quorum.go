@@ -0,0 +1,249 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// quorumLinkSource picks the node the link health is read from: the node
+// answering the API if it is online, else the first online node.
+func quorumLinkSource(status *api.QuorumStatus) *api.Node {
+	var online []*api.Node
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node != nil && node.Online && node.IP != "" {
+			online = append(online, node)
+		}
+	}
+
+	for _, member := range status.Nodes {
+		if !member.Local {
+			continue
+		}
+
+		for _, node := range online {
+			if node.Name == member.Name {
+				return node
+			}
+		}
+	}
+
+	if len(online) > 0 {
+		return online[0]
+	}
+
+	return nil
+}
+
+// showQuorumDiagnostics reads the corosync configuration and quorum of the
+// cluster and, with an SSH user, the link health seen by one node, and
+// shows them with the problems found.
+func (a *App) showQuorumDiagnostics() {
+	a.header.ShowLoading("Reading corosync status...")
+
+	client := a.client
+	user := a.config.SSHUser
+
+	go func() {
+		defer crash.Recover()
+
+		status, err := client.GetQuorumStatus()
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.header.StopLoading()
+				a.showActionError("Failed to read the corosync status", err)
+			})
+
+			return
+		}
+
+		var (
+			links   []api.CorosyncLink
+			source  *api.Node
+			linkErr error
+		)
+
+		if user != "" {
+			if source = quorumLinkSource(status); source != nil {
+				var output []byte
+
+				output, linkErr = ssh.ReadCorosyncLinks(a.ctx, ssh.NewDefaultExecutor(), user, a.nodeAddress(source), ssh.NodeOptions())
+				links = api.ParseCorosyncLinks(output)
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+			a.showQuorumReport(status, links, source, linkErr)
+		})
+	}()
+}
+
+// showQuorumReport renders the quorum diagnostics.
+func (a *App) showQuorumReport(status *api.QuorumStatus, links []api.CorosyncLink, source *api.Node, linkErr error) {
+	lastFocus := a.GetFocus()
+	titleColor := theme.ColorToTag(theme.Colors.HeaderText)
+
+	heading := func(text string) string {
+		return fmt.Sprintf("[%s::b]%s[-::-]", titleColor, text)
+	}
+
+	lines := []string{heading("Cluster " + tview.Escape(status.ClusterName))}
+	lines = append(lines, fmt.Sprintf("  config version %d, transport %s, link mode %s, secauth %s",
+		status.ConfigVersion, status.Transport, valueOr(status.LinkMode, "passive"), valueOr(status.SecAuth, "on")))
+
+	quorum := "[success]quorate[-]"
+	if !status.Quorate {
+		quorum = "[error]NOT QUORATE[-]"
+	}
+
+	lines = append(lines, theme.ReplaceSemanticTags(fmt.Sprintf("  %s, %d of %d expected votes, %d needed",
+		quorum, status.TotalVotes(), status.ExpectedVotes(), status.Quorum())))
+
+	header := fmt.Sprintf("  %-4s %-16s %-6s %-8s", "ID", "Node", "Votes", "Status")
+	for _, link := range status.Links {
+		header += fmt.Sprintf(" %-18s", "Link "+strconv.Itoa(link))
+	}
+
+	lines = append(lines, "", heading("Nodes"), header)
+
+	for _, node := range status.Nodes {
+		name := node.Name
+		if node.Local {
+			name += " *"
+		}
+
+		state := "[success]online [-]"
+		if !node.Online {
+			state = "[error]offline[-]"
+		}
+
+		line := fmt.Sprintf("  %-4d %-16s %-6d %s ", node.NodeID, tview.Escape(name), node.Votes, state)
+		for _, link := range status.Links {
+			line += fmt.Sprintf(" %-18s", valueOr(node.Links[link], "-"))
+		}
+
+		lines = append(lines, theme.ReplaceSemanticTags(line))
+	}
+
+	lines = append(lines, "  * answers the API requests", "", heading("Link health"))
+
+	switch {
+	case a.config.SSHUser == "":
+		lines = append(lines, "  set ssh_user to read the link state with corosync-cfgtool")
+	case source == nil:
+		lines = append(lines, "  no online node to read the link state from")
+	case linkErr != nil:
+		lines = append(lines, theme.ReplaceSemanticTags(fmt.Sprintf("  [error]%s: %s[-]", source.Name, tview.Escape(linkErr.Error()))))
+	default:
+		lines = append(lines, fmt.Sprintf("  as seen from %s", source.Name))
+		lines = append(lines, quorumLinkLines(status, links)...)
+	}
+
+	warnings := models.QuorumWarnings(status, links)
+
+	lines = append(lines, "", heading("Problems"))
+	if len(warnings) == 0 {
+		lines = append(lines, theme.ReplaceSemanticTags("  [success]none found[-]"))
+	}
+
+	for _, warning := range warnings {
+		lines = append(lines, theme.ReplaceSemanticTags("  [warning]"+tview.Escape(warning)+"[-]"))
+	}
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetText(strings.Join(lines, "\n"))
+	textView.SetBorder(true).
+		SetTitle(" Quorum Diagnostics (r: reload) ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	textView.SetBorderPadding(0, 0, 1, 1)
+
+	closeReport := func() {
+		a.removePageIfPresent("quorum")
+
+		if lastFocus != nil {
+			a.SetFocus(lastFocus)
+		}
+	}
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closeReport()
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			closeReport()
+			a.showQuorumDiagnostics()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, min(len(lines)+2, 30), 0, true).
+			AddItem(nil, 0, 1, false), 100, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("quorum")
+	a.pages.AddPage("quorum", modal, true, true)
+	a.SetFocus(textView)
+}
+
+// quorumLinkLines renders the state of each link to another node.
+func quorumLinkLines(status *api.QuorumStatus, links []api.CorosyncLink) []string {
+	if len(links) == 0 {
+		return []string{"  no links reported"}
+	}
+
+	names := make(map[int]string, len(status.Nodes))
+	for _, node := range status.Nodes {
+		names[node.NodeID] = node.Name
+	}
+
+	lines := make([]string, len(links))
+
+	for i, link := range links {
+		state := "[success]connected   [-]"
+
+		switch {
+		case !link.Enabled:
+			state = "[secondary]disabled    [-]"
+		case !link.Connected:
+			state = "[error]disconnected[-]"
+		}
+
+		name := valueOr(names[link.NodeID], strconv.Itoa(link.NodeID))
+		lines[i] = theme.ReplaceSemanticTags(fmt.Sprintf("  %-16s link %d  %s  %s", tview.Escape(name), link.Link, state, link.Addresses))
+	}
+
+	return lines
+}
+
+// valueOr returns value, or fallback when value is empty.
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
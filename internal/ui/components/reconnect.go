@@ -0,0 +1,91 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Bounds of the backoff between reconnect attempts.
+const (
+	reconnectBaseDelay = 2 * time.Second
+	reconnectMaxDelay  = time.Minute
+)
+
+// isConnectivityError reports whether an API call failed because the cluster
+// could not be reached, as after a laptop sleeps or a VPN drops, rather than
+// because Proxmox rejected it.
+func isConnectivityError(err error) bool {
+	return errors.Is(err, api.ErrConnection)
+}
+
+// reconnectBackoff returns the delay before a reconnect attempt, doubling
+// from reconnectBaseDelay up to reconnectMaxDelay.
+func reconnectBackoff(attempt int) time.Duration {
+	delay := reconnectBaseDelay
+
+	for i := 0; i < attempt && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, reconnectMaxDelay)
+}
+
+// startReconnecting enters the reconnecting state after a connectivity
+// error: refreshes pause and the cluster is probed with exponential backoff
+// until it answers again, after which the data is refreshed. It must be
+// called on the UI goroutine and does nothing while already reconnecting.
+func (a *App) startReconnecting(err error) {
+	if !a.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+
+	models.GetUILogger().Debug("Lost connection to the API, reconnecting: %v", err)
+
+	go a.reconnectLoop()
+}
+
+// reconnectLoop probes the cluster until it answers, showing the next
+// attempt in the header.
+func (a *App) reconnectLoop() {
+	defer crash.Recover()
+
+	uiLogger := models.GetUILogger()
+
+	for attempt := 0; ; attempt++ {
+		delay := reconnectBackoff(attempt)
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		var res map[string]interface{}
+
+		err := a.client.GetNoRetry("/version", &res)
+		if err == nil {
+			break
+		}
+
+		uiLogger.Debug("Reconnect attempt %d failed: %v", attempt+1, err)
+
+		next := reconnectBackoff(attempt + 1)
+
+		a.QueueUpdateDraw(func() {
+			a.header.ShowLoading(fmt.Sprintf("Connection lost; reconnecting in %s (attempt %d)", next, attempt+2))
+		})
+	}
+
+	uiLogger.Debug("Reconnected to the API")
+
+	a.QueueUpdateDraw(func() {
+		a.reconnecting.Store(false)
+		a.header.StopLoading()
+		a.manualRefresh()
+	})
+}
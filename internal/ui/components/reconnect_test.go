@@ -0,0 +1,110 @@
+package components
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+// requestError returns the error of a GET request the API client sends to a
+// server answering with handler.
+func requestError(t *testing.T, handler http.HandlerFunc, timeout time.Duration) error {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := api.NewHTTPClient(&http.Client{Timeout: timeout}, server.URL, testutils.NewTestLogger())
+
+	var result map[string]interface{}
+
+	return client.Get(context.Background(), "/cluster/status", &result)
+}
+
+func TestIsConnectivityError(t *testing.T) {
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}
+
+	tests := []struct {
+		name string
+		err  func(t *testing.T) error
+		want bool
+	}{
+		{
+			name: "refused connection",
+			err: func(t *testing.T) error {
+				return fmt.Errorf("failed to get cluster status: %w: %w", api.ErrConnection, opErr)
+			},
+			want: true,
+		},
+		{
+			name: "mid-body EOF",
+			err: func(t *testing.T) error {
+				return requestError(t, func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Length", "100")
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"data":`))
+					w.(http.Flusher).Flush()
+
+					panic(http.ErrAbortHandler)
+				}, 0)
+			},
+			want: true,
+		},
+		{
+			name: "timeout",
+			err: func(t *testing.T) error {
+				return requestError(t, func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(200 * time.Millisecond)
+				}, 50*time.Millisecond)
+			},
+			want: true,
+		},
+		{name: "nil", err: func(t *testing.T) error { return nil }, want: false},
+		{
+			name: "untrusted certificate",
+			err: func(t *testing.T) error {
+				return fmt.Errorf("request failed: %w", &tls.CertificateVerificationError{})
+			},
+			want: false,
+		},
+		{
+			name: "rejected API token",
+			err: func(t *testing.T) error {
+				return fmt.Errorf("API token %w: 401 Unauthorized", api.ErrAuthFailed)
+			},
+			want: false,
+		},
+		{
+			name: "locked guest",
+			err: func(t *testing.T) error {
+				return errors.New("API request failed with status 500: VM is locked (backup)")
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConnectivityError(tt.err(t)))
+		})
+	}
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, reconnectBackoff(0))
+	assert.Equal(t, 4*time.Second, reconnectBackoff(1))
+	assert.Equal(t, 32*time.Second, reconnectBackoff(4))
+	assert.Equal(t, time.Minute, reconnectBackoff(5))
+	assert.Equal(t, time.Minute, reconnectBackoff(50))
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -38,6 +39,8 @@ func (a *App) manualRefresh() {
 
 	// Run data refresh in goroutine to avoid blocking UI
 	go func() {
+		defer crash.Recover()
+
 		// Wait a moment for API changes to propagate to cluster resources endpoint
 		// This ensures we get fresh data after configuration updates
 		time.Sleep(500 * time.Millisecond)
@@ -46,6 +49,15 @@ func (a *App) manualRefresh() {
 		cluster, err := a.client.GetFreshClusterStatus()
 		if err != nil {
 			a.QueueUpdateDraw(func() {
+				a.footer.SetLoading(false)
+
+				if isConnectivityError(err) {
+					a.header.ShowError("Refresh failed: connection lost; reconnecting")
+					a.startReconnecting(err)
+
+					return
+				}
+
 				a.header.ShowError(fmt.Sprintf("Refresh failed: %v", err))
 			})
 
@@ -87,6 +99,8 @@ func (a *App) applyInitialClusterUpdate(cluster *api.Cluster) {
 		}
 		models.GlobalState.OriginalVMs = make([]*api.VM, len(vms))
 		copy(models.GlobalState.OriginalVMs, vms)
+		a.trackGuestRestarts(vms)
+		models.RecordMetrics(cluster.Nodes, vms, time.Now())
 
 		// Apply VM filter if active
 		if vmState := models.GlobalState.GetSearchState(api.PageGuests); vmState != nil && vmState.Filter != "" {
@@ -106,6 +120,8 @@ func (a *App) applyInitialClusterUpdate(cluster *api.Cluster) {
 // enrichNodesSequentially enriches node data one-by-one and finalizes the refresh
 func (a *App) enrichNodesSequentially(cluster *api.Cluster, hasSelectedNode bool, selectedNodeName string, hasSelectedVM bool, selectedVMID int, selectedVMNode string, searchWasActive bool) {
 	go func() {
+		defer crash.Recover()
+
 		// Collect current node filter to avoid repeated lookups
 		nodeState := models.GlobalState.GetSearchState(api.PageNodes)
 		activeFilter := ""
@@ -209,7 +225,9 @@ func (a *App) enrichNodesSequentially(cluster *api.Cluster, hasSelectedNode bool
 			}
 
 			a.restoreSearchUI(searchWasActive, nodeSearchState, vmSearchState)
-			a.header.ShowSuccess("Data refreshed successfully")
+			a.showRefreshSuccess()
+			a.enrichRecentGuests()
+			a.refreshPluginColumns()
 			a.footer.SetLoading(false)
 			a.loadTasksData()
 		})
@@ -256,6 +274,8 @@ func (a *App) refreshNodeData(node *api.Node) {
 	}
 
 	go func() {
+		defer crash.Recover()
+
 		freshNode, err := a.client.RefreshNodeData(node.Name)
 		a.QueueUpdateDraw(func() {
 			if err != nil {
@@ -316,6 +336,8 @@ func (a *App) refreshNodeData(node *api.Node) {
 // loadTasksData loads and updates task data with proper filtering.
 func (a *App) loadTasksData() {
 	go func() {
+		defer crash.Recover()
+
 		tasks, err := a.client.GetClusterTasks()
 		if err == nil {
 			a.QueueUpdateDraw(func() {
@@ -324,6 +346,8 @@ func (a *App) loadTasksData() {
 				models.GlobalState.FilteredTasks = make([]*api.ClusterTask, len(tasks))
 				copy(models.GlobalState.OriginalTasks, tasks)
 				copy(models.GlobalState.FilteredTasks, tasks)
+				a.clusterStatus.SetTasks(tasks)
+				a.notifyFailedTasks(tasks)
 
 				// Check for existing search filters
 				taskSearchState := models.GlobalState.GetSearchState(api.PageTasks)
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
@@ -18,6 +19,8 @@ func (a *App) refreshVMData(vm *api.VM) {
 
 	// Run refresh in goroutine to avoid blocking UI
 	go func() {
+		defer crash.Recover()
+
 		// Fetch fresh VM data with callback for when enrichment completes
 		freshVM, err := a.client.RefreshVMData(vm, func(enrichedVM *api.VM) {
 			// This callback is called after guest agent data has been loaded
@@ -120,6 +123,8 @@ func (a *App) refreshVMDataAndTasks(vm *api.VM) {
 
 	// Run refresh in goroutine to avoid blocking UI
 	go func() {
+		defer crash.Recover()
+
 		// Fetch fresh VM data with callback for when enrichment completes
 		freshVM, err := a.client.RefreshVMData(vm, func(enrichedVM *api.VM) {
 			// This callback is called after guest agent data has been loaded
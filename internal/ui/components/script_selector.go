@@ -38,6 +38,9 @@ type ScriptSelector struct {
 	loadingText     *tview.TextView // For animation updates
 	animationTicker *time.Ticker    // For loading animation
 	searchActive    bool            // Whether search mode is active
+	history         *scripts.History
+	historyList     *tview.List
+	historyEntries  []scripts.HistoryEntry
 }
 
 // NewScriptSelector creates a new script selector.
@@ -53,15 +56,16 @@ func NewScriptSelector(app *App, node *api.Node, vm *api.VM, user string) *Scrip
 		vm:        vm,
 		isForNode: vm == nil,
 		pages:     tview.NewPages(), // Internal pages for categories/scripts
+		history:   app.scriptHistory,
 	}
 
 	// Set node IP
 	if node != nil {
-		s.nodeIP = node.IP
+		s.nodeIP = app.nodeAddress(node)
 	}
 
 	// Initialize the layout
-	s.categories = scripts.GetScriptCategories()
+	s.categories = append(scripts.GetScriptCategories(), scripts.SourceCategories(app.config.ScriptSources)...)
 	s.createLayout()
 
 	return s
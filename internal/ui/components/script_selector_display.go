@@ -28,7 +28,17 @@ func (s *ScriptSelector) showScriptInfo(script scripts.Script) {
 	installButton := tview.NewButton("Install").
 		SetSelectedFunc(func() {
 			s.app.pages.RemovePage("scriptInfo")
-			s.installScript(script)
+			s.showEnvForm(script, func(env map[string]string) {
+				s.installScript(script, env)
+			})
+		})
+
+	runButton := tview.NewButton("Run in TUI").
+		SetSelectedFunc(func() {
+			s.app.pages.RemovePage("scriptInfo")
+			s.showEnvForm(script, func(env map[string]string) {
+				s.runScriptInTUI(script, env)
+			})
 		})
 
 	cancelButton := tview.NewButton("Cancel").
@@ -40,6 +50,7 @@ func (s *ScriptSelector) showScriptInfo(script scripts.Script) {
 	// Create spacers with proper background for centering
 	leftSpacer := tview.NewBox().SetBackgroundColor(theme.Colors.Background)
 	middleSpacer := tview.NewBox().SetBackgroundColor(theme.Colors.Background)
+	runSpacer := tview.NewBox().SetBackgroundColor(theme.Colors.Background)
 	rightSpacer := tview.NewBox().SetBackgroundColor(theme.Colors.Background)
 
 	// Create button container with centered buttons
@@ -47,6 +58,8 @@ func (s *ScriptSelector) showScriptInfo(script scripts.Script) {
 		SetDirection(tview.FlexColumn).
 		AddItem(leftSpacer, 0, 1, false).
 		AddItem(installButton, 12, 0, true).
+		AddItem(runSpacer, 2, 0, false).
+		AddItem(runButton, 14, 0, false).
 		AddItem(middleSpacer, 2, 0, false).
 		AddItem(cancelButton, 12, 0, false).
 		AddItem(rightSpacer, 0, 1, false)
@@ -81,6 +94,8 @@ func (s *ScriptSelector) showScriptInfo(script scripts.Script) {
 			if currentFocus == textView {
 				s.app.SetFocus(installButton)
 			} else if currentFocus == installButton {
+				s.app.SetFocus(runButton)
+			} else if currentFocus == runButton {
 				s.app.SetFocus(cancelButton)
 			} else if currentFocus == cancelButton {
 				s.app.SetFocus(textView)
@@ -104,6 +119,16 @@ func (s *ScriptSelector) showScriptInfo(script scripts.Script) {
 	s.app.SetFocus(textView)
 }
 
+// selectCategory opens the category at the given list index. The item after
+// the categories opens the installation history.
+func (s *ScriptSelector) selectCategory(idx int) {
+	if idx >= 0 && idx < len(s.categories) {
+		s.fetchScriptsForCategory(s.categories[idx])
+	} else if idx == s.categoryList.GetItemCount()-1 {
+		s.showHistory()
+	}
+}
+
 // Hide hides the script selector.
 func (s *ScriptSelector) Hide() {
 	// Stop loading animation and indicator if running
@@ -156,11 +181,7 @@ func (s *ScriptSelector) Show() {
 			return nil
 		} else if event.Key() == tcell.KeyEnter {
 			// Manually trigger the selection
-			idx := s.categoryList.GetCurrentItem()
-			if idx >= 0 && idx < len(s.categories) {
-				category := s.categories[idx]
-				s.fetchScriptsForCategory(category)
-			}
+			s.selectCategory(s.categoryList.GetCurrentItem())
 
 			return nil
 		} else if event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 {
@@ -180,11 +201,11 @@ func (s *ScriptSelector) Show() {
 
 				return nil
 			case 'l': // VI-like right navigation - select category (same as Enter)
-				idx := s.categoryList.GetCurrentItem()
-				if idx >= 0 && idx < len(s.categories) {
-					category := s.categories[idx]
-					s.fetchScriptsForCategory(category)
-				}
+				s.selectCategory(s.categoryList.GetCurrentItem())
+
+				return nil
+			case 'r': // Refresh the cached script catalog
+				s.refreshCatalog()
 
 				return nil
 			}
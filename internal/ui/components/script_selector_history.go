@@ -0,0 +1,146 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/scripts"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// createHistoryPage creates the page listing past script installations.
+func (s *ScriptSelector) createHistoryPage() *tview.Flex {
+	s.historyList = tview.NewList().
+		ShowSecondaryText(true).
+		SetHighlightFullLine(true).
+		SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary))
+
+	s.historyList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2:
+			s.showCategories()
+
+			return nil
+		case tcell.KeyEnter:
+			s.showSelectedHistoryLog()
+
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'j':
+				return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			case 'h':
+				s.showCategories()
+
+				return nil
+			case 'l':
+				s.showSelectedHistoryLog()
+
+				return nil
+			}
+		}
+
+		return event
+	})
+
+	return tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewTextView().
+			SetText("Installation History (Enter = View Log, Backspace = Back)").
+			SetTextAlign(tview.AlignCenter), 1, 0, false).
+		AddItem(s.historyList, 0, 1, true)
+}
+
+// showHistory loads the installation history and switches to its page.
+func (s *ScriptSelector) showHistory() {
+	entries, err := s.history.Entries()
+	if err != nil {
+		s.app.showMessageSafe(fmt.Sprintf("Error loading installation history: %v", err))
+
+		return
+	}
+
+	s.historyEntries = entries
+	s.historyList.Clear()
+
+	if len(entries) == 0 {
+		s.historyList.AddItem("No installations recorded yet", "", 0, nil)
+	}
+
+	for _, entry := range entries {
+		status := fmt.Sprintf("[%s]%s[-]", theme.ColorToTag(theme.Colors.Success), theme.Icon("✔", "OK"))
+		if !entry.Succeeded() {
+			status = fmt.Sprintf("[%s]%s[-]", theme.ColorToTag(theme.Colors.Error), theme.Icon("✘", "FAIL"))
+		}
+
+		mainText := fmt.Sprintf("%s %s on %s", status, entry.Script, entry.Node)
+		secondaryText := fmt.Sprintf("%s, took %s, exit code %d",
+			entry.StartedAt.Format("2006-01-02 15:04:05"), entry.Duration, entry.ExitCode)
+
+		s.historyList.AddItem(mainText, secondaryText, 0, nil)
+	}
+
+	s.pages.SwitchToPage("history")
+	s.app.SetFocus(s.historyList)
+}
+
+// showSelectedHistoryLog shows the output log of the selected history entry.
+func (s *ScriptSelector) showSelectedHistoryLog() {
+	idx := s.historyList.GetCurrentItem()
+	if idx < 0 || idx >= len(s.historyEntries) {
+		return
+	}
+
+	entry := s.historyEntries[idx]
+
+	var content string
+
+	if entry.LogFile == "" {
+		content = "No output was captured for this installation."
+	} else if log, err := scripts.ReadLog(entry.LogFile); err != nil {
+		content = fmt.Sprintf("Error: %v", err)
+	} else {
+		content = tview.TranslateANSI(log)
+	}
+
+	if entry.Error != "" {
+		content += fmt.Sprintf("\n[%s]Error:[-] %s\n", theme.ColorToTag(theme.Colors.Error), tview.Escape(entry.Error))
+	}
+
+	logView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(content)
+	logView.SetTitle(fmt.Sprintf(" %s on %s ", entry.Script, entry.Node))
+	logView.SetTitleColor(theme.Colors.Title)
+	logView.SetBorder(true)
+	logView.SetBorderColor(theme.Colors.Border)
+	logView.ScrollToEnd()
+
+	logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 ||
+			(event.Key() == tcell.KeyRune && event.Rune() == 'h') {
+			s.pages.RemovePage("historyLog")
+			s.pages.SwitchToPage("history")
+			s.app.SetFocus(s.historyList)
+
+			return nil
+		}
+
+		return event
+	})
+
+	s.pages.AddPage("historyLog", logView, true, true)
+	s.app.SetFocus(logView)
+}
+
+// showCategories returns to the category list.
+func (s *ScriptSelector) showCategories() {
+	s.pages.SwitchToPage("categories")
+	s.app.SetFocus(s.categoryList)
+}
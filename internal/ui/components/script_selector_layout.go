@@ -32,6 +32,9 @@ func (s *ScriptSelector) createLayout() {
 		s.categoryList.AddItem("No categories found", "Check script configuration", 'x', nil)
 	}
 
+	// Installation history follows the categories
+	s.categoryList.AddItem("Installation History", "Review past installations and their output", 0, nil)
+
 	// Create the script list
 	s.scriptList = tview.NewList().
 		ShowSecondaryText(true).
@@ -56,7 +59,7 @@ func (s *ScriptSelector) createLayout() {
 	categoryPage := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(tview.NewTextView().
-			SetText(fmt.Sprintf("Select a Script Category (%d categories)", len(s.categories))).
+			SetText(fmt.Sprintf("Select a Script Category (%d categories, r = Refresh Catalog)", len(s.categories))).
 			SetTextAlign(tview.AlignCenter), 1, 0, false).
 		AddItem(s.categoryList, 0, 1, true)
 
@@ -113,6 +116,7 @@ func (s *ScriptSelector) createLayout() {
 	s.pages.AddPage("categories", categoryPage, true, true)
 	s.pages.AddPage("scripts", scriptPage, true, false)
 	s.pages.AddPage("loading", loadingPage, true, false)
+	s.pages.AddPage("history", s.createHistoryPage(), true, false)
 
 	// Set border and title on the pages component
 	s.pages.SetBorder(true).
@@ -2,11 +2,13 @@ package components
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 
 	"github.com/devnullvoid/pvetui/internal/scripts"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
@@ -32,6 +34,10 @@ func (s *ScriptSelector) formatScriptInfo(script scripts.Script) string {
 		sb.WriteString(fmt.Sprintf("[%s]Script Path:[-] %s\n", labelColor, script.ScriptPath))
 	}
 
+	if script.Source != "" {
+		sb.WriteString(fmt.Sprintf("[%s]Source:[-] %s (%s)\n", labelColor, script.Source, script.Location))
+	}
+
 	if script.Website != "" {
 		sb.WriteString(fmt.Sprintf("[%s]Website:[-] %s\n", labelColor, script.Website))
 	}
@@ -61,14 +67,90 @@ func (s *ScriptSelector) formatScriptInfo(script scripts.Script) string {
 	return sb.String()
 }
 
-// installScript installs the selected script.
-func (s *ScriptSelector) installScript(script scripts.Script) {
+// showEnvForm asks for the script's environment variables before running it
+// with onSubmit. Fields left empty are prompted for by the script itself.
+func (s *ScriptSelector) showEnvForm(script scripts.Script, onSubmit func(env map[string]string)) {
+	envVars := scripts.EnvVarsForScript(script)
+	if len(envVars) == 0 {
+		onSubmit(nil)
+
+		return
+	}
+
+	defaults := scripts.DefaultEnvValues(script)
+	fields := make([]FormField, 0, len(envVars))
+
+	for _, envVar := range envVars {
+		fields = append(fields, FormField{
+			Name:         envVar.Name,
+			Label:        envVar.Label,
+			DefaultValue: defaults[envVar.Name],
+			MaxLength:    64,
+		})
+	}
+
+	closeForm := func() {
+		s.app.pages.RemovePage("scriptEnv")
+		s.app.SetFocus(s.scriptList)
+	}
+
+	form := CreateFormDialog(fmt.Sprintf("Install %s", script.Name), fields, func(values map[string]string) {
+		if err := scripts.ValidateEnv(values); err != nil {
+			s.app.showMessageSafe(fmt.Sprintf("Invalid value: %v", err))
+
+			return
+		}
+
+		s.app.pages.RemovePage("scriptEnv")
+		onSubmit(values)
+	}, func(map[string]string) {
+		closeForm()
+	})
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	s.app.pages.AddPage("scriptEnv", s.createEnvFormLayout(form, len(fields)), true, true)
+	s.app.SetFocus(form)
+}
+
+// createEnvFormLayout centers the environment form on screen.
+func (s *ScriptSelector) createEnvFormLayout(form *tview.Form, fieldCount int) *tview.Flex {
+	// Two rows per field plus the buttons and border
+	height := fieldCount*2 + 5
+
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, height, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// installScript installs the selected script with the given environment variables.
+func (s *ScriptSelector) installScript(script scripts.Script, env map[string]string) {
 	// Temporarily suspend the UI for interactive script installation (same pattern as working shell functions)
 	s.app.Suspend(func() {
 		// Install the script interactively
 		fmt.Printf("Installing %s...\n", script.Name)
 
-		err := scripts.InstallScript(s.user, s.nodeIP, script.ScriptPath)
+		// Record the installation and capture its output for the history page
+		err := s.history.Run(script, s.node.Name, func(output io.Writer) error {
+			opts := scripts.InstallOptions{Env: env, Output: output}
+			if script.Source != "" {
+				return scripts.InstallSourceScript(s.user, s.nodeIP, script, opts)
+			}
+
+			return scripts.InstallScript(s.user, s.nodeIP, script.ScriptPath, opts)
+		})
 		if err != nil {
 			fmt.Printf("\nScript installation failed: %v\n", err)
 		}
@@ -160,7 +242,7 @@ func (s *ScriptSelector) fetchScriptsForCategory(category scripts.ScriptCategory
 
 	// Fetch scripts in a goroutine to prevent UI blocking
 	go func() {
-		fetchedScripts, err := scripts.GetScriptsByCategory(category.Path)
+		fetchedScripts, warning, err := loadCategoryScripts(category)
 
 		// Update UI on the main thread
 		s.app.QueueUpdateDraw(func() {
@@ -301,8 +383,76 @@ func (s *ScriptSelector) fetchScriptsForCategory(category scripts.ScriptCategory
 			s.pages.SwitchToPage("scripts")
 			s.app.SetFocus(s.scriptList)
 
-			// Show success message in header
-			s.app.header.ShowSuccess(fmt.Sprintf("Loaded %d %s scripts", len(fetchedScripts), category.Name))
+			// Show success message in header, or warn when GitHub was unreachable
+			if warning != "" {
+				s.app.header.ShowWarning(warning)
+			} else {
+				s.app.header.ShowSuccess(fmt.Sprintf("Loaded %d %s scripts", len(fetchedScripts), category.Name))
+			}
+		})
+	}()
+}
+
+// loadCategoryScripts returns the scripts of a category and, when GitHub was
+// unreachable, a warning describing the cached catalog in use.
+func loadCategoryScripts(category scripts.ScriptCategory) ([]scripts.Script, string, error) {
+	if category.Source != nil {
+		sourceScripts, err := scripts.LoadSourceScripts(*category.Source)
+
+		return sourceScripts, "", err
+	}
+
+	catalog, err := scripts.LoadCatalog()
+	if err != nil {
+		return nil, "", err
+	}
+
+	categoryScripts := catalog.ScriptsByCategory(category.Path)
+	if len(categoryScripts) == 0 {
+		return nil, "", fmt.Errorf("no scripts found for category: %s", category.Path)
+	}
+
+	var warning string
+	if catalog.Offline {
+		warning = fmt.Sprintf("GitHub unreachable, showing %d %s scripts cached %s",
+			len(categoryScripts), category.Name, catalog.FetchedAt.Format("2006-01-02 15:04"))
+	}
+
+	return categoryScripts, warning, nil
+}
+
+// refreshCatalog fetches the script catalog from GitHub, replacing the cached copy.
+func (s *ScriptSelector) refreshCatalog() {
+	if s.isLoading {
+		return
+	}
+
+	s.isLoading = true
+	s.app.header.ShowLoading("Refreshing script catalog")
+
+	s.pages.SwitchToPage("loading")
+	s.app.SetFocus(s.pages)
+	s.startLoadingAnimation()
+
+	go func() {
+		catalog, err := scripts.RefreshCatalog()
+
+		s.app.QueueUpdateDraw(func() {
+			s.stopLoadingAnimation()
+			s.isLoading = false
+
+			s.pages.SwitchToPage("categories")
+			s.app.SetFocus(s.categoryList)
+
+			switch {
+			case err != nil:
+				s.app.header.ShowError(fmt.Sprintf("Failed to refresh script catalog: %v", err))
+			case catalog.Offline:
+				s.app.header.ShowWarning(fmt.Sprintf("GitHub unreachable, keeping script catalog from %s",
+					catalog.FetchedAt.Format("2006-01-02 15:04")))
+			default:
+				s.app.header.ShowSuccess(fmt.Sprintf("Script catalog refreshed (%d scripts)", len(catalog.Scripts)))
+			}
 		})
 	}()
 }
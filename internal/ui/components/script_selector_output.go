@@ -0,0 +1,121 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/scripts"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// runScriptInTUI runs the script without a terminal and streams its output
+// into a scrollable pane, keeping the rest of the UI active.
+func (s *ScriptSelector) runScriptInTUI(script scripts.Script, env map[string]string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	running := true
+
+	outputView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+	outputView.SetTitle(fmt.Sprintf(" %s on %s ", script.Name, s.node.Name))
+	outputView.SetTitleColor(theme.Colors.Title)
+	outputView.SetBorder(true)
+	outputView.SetBorderColor(theme.Colors.Border)
+	outputView.SetChangedFunc(func() {
+		s.app.Draw()
+	})
+
+	statusView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Running...[-] Esc = Cancel", theme.ColorToTag(theme.Colors.Warning)))
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(outputView, 0, 1, true).
+		AddItem(statusView, 1, 0, false)
+
+	closePane := func() {
+		s.app.pages.RemovePage("scriptOutput")
+		s.Hide()
+
+		// Pick up guests created by the script
+		s.app.client.ClearAPICache()
+
+		s.app.manualRefresh()
+	}
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEscape {
+			return event
+		}
+
+		if running {
+			confirm := CreateConfirmDialog("Cancel Script",
+				fmt.Sprintf("Stop %s on %s? Whatever it has set up so far is left as is.", script.Name, s.node.Name),
+				func() {
+					s.app.pages.RemovePage("scriptCancel")
+					cancel()
+					s.app.SetFocus(outputView)
+				}, func() {
+					s.app.pages.RemovePage("scriptCancel")
+					s.app.SetFocus(outputView)
+				})
+
+			s.app.pages.AddPage("scriptCancel", confirm, false, true)
+			s.app.SetFocus(confirm)
+
+			return nil
+		}
+
+		closePane()
+
+		return nil
+	})
+
+	s.app.pages.AddPage("scriptOutput", layout, true, true)
+	s.app.SetFocus(outputView)
+	s.app.header.ShowLoading(fmt.Sprintf("Running %s", script.Name))
+
+	go func() {
+		defer cancel()
+
+		writer := tview.ANSIWriter(outputView)
+
+		err := s.history.Run(script, s.node.Name, func(logOutput io.Writer) error {
+			output := writer
+			if logOutput != nil {
+				output = io.MultiWriter(writer, logOutput)
+			}
+
+			return scripts.RunScript(ctx, s.user, s.nodeIP, script, scripts.InstallOptions{Env: env, Output: output})
+		})
+
+		s.app.QueueUpdateDraw(func() {
+			running = false
+
+			if errors.Is(err, scripts.ErrCancelled) {
+				statusView.SetText(fmt.Sprintf("[%s]Cancelled[-]  Esc = Close", theme.ColorToTag(theme.Colors.Warning)))
+				s.app.header.ShowWarning(fmt.Sprintf("%s cancelled on %s", script.Name, s.node.Name))
+
+				return
+			}
+
+			if err != nil {
+				statusView.SetText(fmt.Sprintf("[%s]Failed:[-] %s  Esc = Close", theme.ColorToTag(theme.Colors.Error), tview.Escape(err.Error())))
+				s.app.header.ShowError(fmt.Sprintf("%s failed: %v", script.Name, err))
+
+				return
+			}
+
+			statusView.SetText(fmt.Sprintf("[%s]Finished[-]  Esc = Close", theme.ColorToTag(theme.Colors.Success)))
+			s.app.header.ShowSuccess(fmt.Sprintf("%s finished on %s", script.Name, s.node.Name))
+		})
+	}()
+}
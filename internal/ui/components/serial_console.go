@@ -0,0 +1,138 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"golang.org/x/term"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// serialEscapeKey disconnects from a serial console (Ctrl+]), as in telnet.
+const serialEscapeKey = 0x1d
+
+// bridgeSerialConsole copies guest output to out and local input from in to
+// the console until the escape key is pressed, then closes the console. When
+// the guest side closes first, it waits for one more key before returning so
+// the user can read the last output.
+func bridgeSerialConsole(console io.ReadWriteCloser, in io.Reader, out io.Writer) {
+	var detached atomic.Bool
+
+	closed := make(chan struct{})
+
+	go func() {
+		defer crash.Recover()
+		defer close(closed)
+
+		_, _ = io.Copy(out, console)
+
+		if !detached.Load() {
+			_, _ = fmt.Fprint(out, "\r\n[serial console closed; press any key to return]\r\n")
+		}
+	}()
+
+	defer func() {
+		detached.Store(true)
+		_ = console.Close()
+		<-closed
+	}()
+
+	buf := make([]byte, 256)
+
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-closed:
+			return
+		default:
+		}
+
+		data := buf[:n]
+		if i := bytes.IndexByte(data, serialEscapeKey); i >= 0 {
+			if i > 0 {
+				_, _ = console.Write(data[:i])
+			}
+
+			return
+		}
+
+		if _, err := console.Write(data); err != nil {
+			return
+		}
+	}
+}
+
+// openSerialConsole attaches the terminal to the first socket-backed serial
+// port of a running QEMU VM. Ctrl+] returns to the TUI.
+func (a *App) openSerialConsole(vm *api.VM) {
+	a.header.ShowLoading(fmt.Sprintf("Connecting to the serial console of %s", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		cfg, err := a.client.GetGuestConfig(vm)
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.showActionError(fmt.Sprintf("Failed to open the serial console of %s", vm.Name), err)
+			})
+
+			return
+		}
+
+		ports := cfg.SerialPorts()
+		if len(ports) == 0 {
+			a.QueueUpdateDraw(func() {
+				a.header.ShowError(fmt.Sprintf("%s has no serial port; add one with a socket backend (qm set %d -serial0 socket)", vm.Name, vm.ID))
+			})
+
+			return
+		}
+
+		console, err := a.client.OpenSerialConsole(vm, ports[0])
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.showActionError(fmt.Sprintf("Failed to open the serial console of %s", vm.Name), err)
+			})
+
+			return
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+
+			a.Suspend(func() {
+				fmt.Printf("\nConnected to %s of %s (ID: %d). Press Ctrl+] to return.\n\n", ports[0], vm.Name, vm.ID)
+
+				fd := int(os.Stdin.Fd())
+
+				state, err := term.MakeRaw(fd)
+				if err != nil {
+					_ = console.Close()
+
+					fmt.Printf("\nError setting up the terminal: %v\n", err)
+
+					return
+				}
+				defer func() { _ = term.Restore(fd, state) }()
+
+				if cols, rows, err := term.GetSize(fd); err == nil {
+					_ = console.Resize(cols, rows)
+				}
+
+				bridgeSerialConsole(console, os.Stdin, os.Stdout)
+			})
+
+			// Fix for tview suspend/resume issue - comprehensive terminal state restoration
+			a.Sync()
+		})
+	}()
+}
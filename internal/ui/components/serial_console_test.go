@@ -0,0 +1,71 @@
+package components
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSerialConsole plays back output and records input until closed.
+type fakeSerialConsole struct {
+	mu     sync.Mutex
+	output io.Reader
+	input  bytes.Buffer
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeSerialConsole(output string) *fakeSerialConsole {
+	return &fakeSerialConsole{output: strings.NewReader(output), closed: make(chan struct{})}
+}
+
+func (f *fakeSerialConsole) Read(p []byte) (int, error) {
+	n, err := f.output.Read(p)
+	if err == io.EOF {
+		// Stay open like a real guest until the console is closed
+		<-f.closed
+
+		return 0, io.EOF
+	}
+
+	return n, err
+}
+
+func (f *fakeSerialConsole) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.input.Write(p)
+}
+
+func (f *fakeSerialConsole) Close() error {
+	f.once.Do(func() { close(f.closed) })
+
+	return nil
+}
+
+func TestBridgeSerialConsole_EscapeKey(t *testing.T) {
+	console := newFakeSerialConsole("login: ")
+
+	var out bytes.Buffer
+
+	bridgeSerialConsole(console, strings.NewReader("root\r\x1dignored"), &out)
+
+	assert.Equal(t, "root\r", console.input.String())
+	assert.Equal(t, "login: ", out.String())
+}
+
+func TestBridgeSerialConsole_InputEnds(t *testing.T) {
+	console := newFakeSerialConsole("")
+
+	var out bytes.Buffer
+
+	bridgeSerialConsole(console, strings.NewReader("ls\r"), &out)
+
+	assert.Equal(t, "ls\r", console.input.String())
+	assert.Empty(t, out.String())
+}
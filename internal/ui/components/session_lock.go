@@ -0,0 +1,135 @@
+package components
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// idleCheckInterval is how often the idle lock checks for inactivity.
+const idleCheckInterval = 15 * time.Second
+
+// lockSecret returns what unlocks the session: the configured passphrase,
+// or the password of the active profile.
+func (a *App) lockSecret() string {
+	if a.config.Lock.Passphrase != "" {
+		return a.config.Lock.Passphrase
+	}
+
+	return a.config.GetPassword()
+}
+
+// lockSession replaces the interface with a passphrase prompt. The
+// connection, refreshes and background tasks keep running.
+func (a *App) lockSession() {
+	if a.locked {
+		return
+	}
+
+	secret := a.lockSecret()
+	if secret == "" {
+		a.header.ShowError("Set lock.passphrase in the config to lock the session")
+
+		return
+	}
+
+	focus := a.GetFocus()
+
+	status := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(" " + theme.Label("🔒", "Session Locked") + " ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	form.AddPasswordField("Passphrase", "", 24, '*', nil)
+
+	field, _ := form.GetFormItemByLabel("Passphrase").(*tview.InputField)
+
+	unlock := func() {
+		if field == nil || subtle.ConstantTimeCompare([]byte(field.GetText()), []byte(secret)) != 1 {
+			if field != nil {
+				field.SetText("")
+			}
+
+			status.SetText(theme.ReplaceSemanticTags("[error]Wrong passphrase[-]"))
+
+			return
+		}
+
+		a.locked = false
+		a.lastInput = time.Now()
+		a.SetRoot(a.mainLayout, true)
+
+		if focus != nil {
+			a.SetFocus(focus)
+		}
+	}
+
+	form.AddButton("Unlock", unlock)
+
+	if field != nil {
+		field.SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				unlock()
+			}
+		})
+	}
+
+	screen := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 0, true).
+			AddItem(status, 1, 0, false).
+			AddItem(nil, 0, 1, false), 44, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.locked = true
+	a.SetRoot(screen, true)
+	a.SetFocus(form)
+}
+
+// startIdleLock locks the session once no key was pressed for the
+// configured idle time. The setting is read on every check, so it follows
+// config reloads.
+func (a *App) startIdleLock() {
+	a.lastInput = time.Now()
+	a.lastIdleCheck = time.Now()
+
+	go func() {
+		ticker := time.NewTicker(idleCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-a.ctx.Done():
+				return
+			}
+
+			a.QueueUpdateDraw(func() {
+				now := time.Now()
+
+				// Checks are held back while a shell or editor has the
+				// terminal; that time does not count as idle
+				if now.Sub(a.lastIdleCheck) > 2*idleCheckInterval {
+					a.lastInput = now
+				}
+
+				a.lastIdleCheck = now
+
+				timeout := a.config.Lock.IdleTimeout()
+				if timeout > 0 && !a.locked && now.Sub(a.lastInput) >= timeout && a.lockSecret() != "" {
+					a.lockSession()
+				}
+			})
+		}
+	}()
+}
@@ -21,19 +21,21 @@ func (a *App) openNodeShell() {
 	}
 
 	node := a.nodeList.GetSelectedNode()
-	if node == nil || node.IP == "" {
+	if node == nil || a.nodeAddress(node) == "" {
 		a.showMessage("Node IP address not available")
 
 		return
 	}
 
+	addr := a.nodeAddress(node)
+
 	// Temporarily suspend the UI
 	a.Suspend(func() {
 		// Display connecting message
-		fmt.Printf("\nConnecting to node %s (%s) as user %s...\n", node.Name, node.IP, a.config.SSHUser)
+		fmt.Printf("\nConnecting to node %s (%s) as user %s...\n", node.Name, addr, a.config.SSHUser)
 
 		// Execute SSH command
-		err := ssh.ExecuteNodeShell(a.config.SSHUser, node.IP)
+		err := ssh.ExecuteNodeShell(a.config.SSHUser, addr)
 		if err != nil {
 			fmt.Printf("\nError connecting to node: %v\n", err)
 		}
@@ -215,16 +217,19 @@ func (a *App) openVMVNC() {
 }
 
 // openVMShell opens a shell session to the currently selected VM/container.
+// QEMU VMs are logged in to with the user and key of the first matching
+// guest_ssh rule, containers through their node as ssh_user.
 func (a *App) openVMShell() {
-	if a.config.SSHUser == "" {
-		a.showMessageSafe("SSH user not configured. Please set PROXMOX_SSH_USER environment variable or use --ssh-user flag.")
+	vm := a.vmList.GetSelectedVM()
+	if vm == nil {
+		a.showMessageSafe("Selected VM not found")
 
 		return
 	}
 
-	vm := a.vmList.GetSelectedVM()
-	if vm == nil {
-		a.showMessageSafe("Selected VM not found")
+	guestUser, guestKeyFile := a.config.GuestSSHLogin(vm.ID, vm.Name, vm.Tags)
+	if (vm.Type == api.VMTypeQemu && guestUser == "") || (vm.Type != api.VMTypeQemu && a.config.SSHUser == "") {
+		a.showMessageSafe("SSH user not configured. Please set PROXMOX_SSH_USER environment variable or use --ssh-user flag.")
 
 		return
 	}
@@ -234,7 +239,7 @@ func (a *App) openVMShell() {
 
 	for _, node := range a.client.Cluster.Nodes {
 		if node.Name == vm.Node {
-			nodeIP = node.IP
+			nodeIP = a.nodeAddress(node)
 
 			break
 		}
@@ -277,10 +282,10 @@ func (a *App) openVMShell() {
 			}
 		} else if vm.Type == "qemu" {
 			// For QEMU VMs, use direct SSH connection
-			fmt.Printf("\nConnecting to QEMU VM %s (ID: %d) via SSH at %s...\n",
-				vm.Name, vm.ID, vm.IP)
+			fmt.Printf("\nConnecting to QEMU VM %s (ID: %d) via SSH at %s as %s...\n",
+				vm.Name, vm.ID, vm.IP, guestUser)
 
-			err := ssh.ExecuteQemuShell(a.config.SSHUser, vm.IP)
+			err := ssh.ExecuteQemuShell(guestUser, vm.IP, ssh.Options{IdentityFile: guestKeyFile})
 			if err != nil {
 				fmt.Printf("\nFailed to SSH to VM: %v\n", err)
 			}
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/pkg/api"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -51,7 +52,7 @@ func (sf *SnapshotForm) ShowCreateForm(onSuccess func()) {
 		}
 
 		if name == "" {
-			sf.app.showMessageSafe("❌ Snapshot name is required")
+			sf.app.showMessageSafe(theme.Label("❌", "Snapshot name is required"))
 			return
 		}
 
@@ -68,7 +69,7 @@ func (sf *SnapshotForm) ShowCreateForm(onSuccess func()) {
 			err := operations.CreateSnapshot(name, description, vmState)
 			if err != nil {
 				sf.app.Application.QueueUpdateDraw(func() {
-					sf.app.header.ShowError(fmt.Sprintf("Failed to create snapshot: %v", err))
+					sf.app.showActionError("Failed to create snapshot", err)
 				})
 			} else {
 				// Use the same polling method as delete/rollback
@@ -0,0 +1,433 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// snapshotScanInterval is how often refreshes scan the snapshots of all
+// guests again. Every guest is queried, and snapshots rarely change.
+const snapshotScanInterval = 15 * time.Minute
+
+// staleSnapshotRules returns the configured rules for stale snapshots. The
+// patterns were validated when the config was loaded.
+func (a *App) staleSnapshotRules() models.StaleSnapshotRules {
+	rules, err := models.NewStaleSnapshotRules(a.config.Snapshots.MaxAge(), a.config.Snapshots.Patterns)
+	if err != nil {
+		models.GetUILogger().Error("Stale snapshot rules: %v", err)
+	}
+
+	return rules
+}
+
+// scanSnapshots collects the snapshots of all guests on online nodes when
+// stale snapshots are configured, unless that was done within
+// snapshotScanInterval or force is set, and reports whether a scan started.
+// then runs after the scan; without it, an open stale snapshot list is
+// updated, and stale snapshots found by the first scan are reported in the
+// header.
+func (a *App) scanSnapshots(force bool, then func()) bool {
+	scannedAt := models.SnapshotsScannedAt()
+	if !a.config.Snapshots.Enabled() || a.snapshotsScanning ||
+		(!force && !scannedAt.IsZero() && time.Since(scannedAt) < snapshotScanInterval) {
+		return false
+	}
+
+	online := make(map[string]bool)
+
+	for _, node := range models.GlobalState.OriginalNodes {
+		if node != nil && node.Online {
+			online[node.Name] = true
+		}
+	}
+
+	var guests []*api.VM
+
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil && !vm.Template && online[vm.Node] {
+			guests = append(guests, vm)
+		}
+	}
+
+	if len(guests) == 0 {
+		return false
+	}
+
+	a.snapshotsScanning = true
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		scanned := make([]models.GuestSnapshots, 0, len(guests))
+
+		for _, vm := range guests {
+			list, err := client.GetSnapshots(vm)
+			if err != nil {
+				models.GetUILogger().Debug("Skipping snapshots of guest %d: %v", vm.ID, err)
+
+				continue
+			}
+
+			scanned = append(scanned, models.GuestSnapshots{VM: vm, Snapshots: list})
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.snapshotsScanning = false
+
+			// Results of a client replaced by a profile switch are stale
+			if client != a.client {
+				return
+			}
+
+			models.SetScannedSnapshots(scanned, time.Now())
+			models.GetUILogger().Debug("Scanned the snapshots of %d guests", len(scanned))
+
+			switch {
+			case then != nil:
+				then()
+			case a.staleSnapshotsInFront():
+				a.showStaleSnapshots()
+			case scannedAt.IsZero():
+				a.warnStaleSnapshots()
+			}
+		})
+	}()
+
+	return true
+}
+
+// warnStaleSnapshots reports the number of stale snapshots.
+func (a *App) warnStaleSnapshots() {
+	stale := models.FindStaleSnapshots(models.GlobalState.OriginalVMs, a.staleSnapshotRules(), time.Now())
+	if len(stale) == 0 {
+		return
+	}
+
+	a.header.ShowWarning(fmt.Sprintf("%d stale snapshot(s) found (see Stale Snapshots in the global menu)", len(stale)))
+}
+
+// rescanSnapshots scans the snapshots of all guests and then shows the stale
+// ones.
+func (a *App) rescanSnapshots() {
+	if a.snapshotsScanning {
+		a.header.ShowWarning("Snapshots are being scanned; try again in a moment")
+
+		return
+	}
+
+	a.header.ShowLoading("Scanning snapshots...")
+
+	if !a.scanSnapshots(true, a.showStaleSnapshots) {
+		a.header.ShowError("No guests on online nodes to scan")
+	}
+}
+
+// staleSnapshotsInFront reports whether the stale snapshot list is open and
+// not covered by a dialog, so it can be rebuilt.
+func (a *App) staleSnapshotsInFront() bool {
+	name, _ := a.pages.GetFrontPage()
+
+	return name == "staleSnapshots"
+}
+
+// snapshotKey identifies a snapshot of a guest.
+func snapshotKey(stale models.StaleSnapshot) string {
+	return fmt.Sprintf("%s:%d:%s", stale.VM.Node, stale.VM.ID, stale.Snapshot.Name)
+}
+
+// showStaleSnapshots lists the stale snapshots of all guests with the RAM
+// state they hold and lets the user delete them in bulk. Space marks a
+// snapshot, a marks all, d deletes the marked ones and r scans again.
+func (a *App) showStaleSnapshots() {
+	if !a.config.Snapshots.Enabled() {
+		a.showMessage("No stale snapshot rules are configured.\n\nSet snapshots.max_age_days or snapshots.patterns in the config file.")
+
+		return
+	}
+
+	if models.SnapshotsScannedAt().IsZero() {
+		a.rescanSnapshots()
+
+		return
+	}
+
+	a.header.StopLoading()
+
+	stale := models.FindStaleSnapshots(models.GlobalState.OriginalVMs, a.staleSnapshotRules(), time.Now())
+
+	// Rebuilt after a scan or deletion: focus still returns to where the
+	// list was first opened from
+	if !a.pages.HasPage("staleSnapshots") {
+		a.lastFocus = a.GetFocus()
+	}
+
+	marked := make(map[string]bool)
+	sizes := make(map[string]int64)
+
+	table := tview.NewTable().
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorderPadding(0, 0, 1, 1)
+	table.SetBorder(true).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	render := func() {
+		row, _ := table.GetSelection()
+
+		table.Clear()
+
+		for col, header := range []string{"", "Guest", "Node", "Snapshot", "Created", "Reason", "RAM State"} {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+
+		var total int64
+
+		for i, item := range stale {
+			key := snapshotKey(item)
+
+			mark := " "
+			if marked[key] {
+				mark = "*"
+			}
+
+			state := "-"
+
+			if item.Snapshot.VMState {
+				state = "..."
+
+				if size, ok := sizes[key]; ok {
+					state = api.FormatBytes(size)
+					total += size
+				}
+			}
+
+			color := theme.Colors.Primary
+			if marked[key] {
+				color = theme.Colors.Warning
+			}
+
+			cells := []string{
+				mark,
+				fmt.Sprintf("%d %s", item.VM.ID, item.VM.Name),
+				item.VM.Node,
+				item.Snapshot.Name,
+				item.Snapshot.SnapTime.Format("2006-01-02 15:04"),
+				item.Reason,
+				state,
+			}
+
+			for col, text := range cells {
+				table.SetCell(i+1, col, tview.NewTableCell(tview.Escape(text)).SetTextColor(color).SetExpansion(map[bool]int{true: 1, false: 0}[col == 3]))
+			}
+		}
+
+		table.SetTitle(fmt.Sprintf(" Stale Snapshots (%d), RAM state %s; Space: mark, a: all, d: delete, r: rescan ",
+			len(stale), api.FormatBytes(total)))
+
+		if row < 1 {
+			row = 1
+		}
+
+		table.Select(row, 0)
+	}
+
+	closeList := func() {
+		a.removePageIfPresent("staleSnapshots")
+
+		if a.lastFocus != nil {
+			a.SetFocus(a.lastFocus)
+		}
+	}
+
+	deleteMarked := func() {
+		var selected []models.StaleSnapshot
+
+		for _, item := range stale {
+			if marked[snapshotKey(item)] {
+				selected = append(selected, item)
+			}
+		}
+
+		// Without marks, the snapshot under the cursor is deleted
+		if len(selected) == 0 {
+			if row, _ := table.GetSelection(); row >= 1 && row <= len(stale) {
+				selected = append(selected, stale[row-1])
+			}
+		}
+
+		if len(selected) == 0 {
+			return
+		}
+
+		names := make([]string, 0, len(selected))
+		for _, item := range selected {
+			names = append(names, fmt.Sprintf("%d: %s", item.VM.ID, item.Snapshot.Name))
+		}
+
+		if len(names) > 10 {
+			names = append(names[:10], fmt.Sprintf("and %d more", len(selected)-10))
+		}
+
+		a.showConfirmationDialog(
+			fmt.Sprintf("Delete %d snapshot(s)?\n\n%s\n\nThis cannot be undone.", len(selected), strings.Join(names, "\n")),
+			func() {
+				a.deleteStaleSnapshots(selected)
+			},
+		)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeList()
+
+			return nil
+		}
+
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'q':
+			closeList()
+		case ' ':
+			if row, _ := table.GetSelection(); row >= 1 && row <= len(stale) {
+				key := snapshotKey(stale[row-1])
+				marked[key] = !marked[key]
+
+				render()
+				table.Select(min(row+1, len(stale)), 0)
+			}
+		case 'a':
+			all := len(marked) < len(stale)
+			for key := range marked {
+				delete(marked, key)
+			}
+
+			if all {
+				for _, item := range stale {
+					marked[snapshotKey(item)] = true
+				}
+			}
+
+			render()
+		case 'd':
+			deleteMarked()
+		case 'r':
+			a.rescanSnapshots()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	render()
+
+	if len(stale) == 0 {
+		table.SetCell(1, 1, tview.NewTableCell("No stale snapshots").SetTextColor(theme.Colors.Secondary).SetSelectable(false))
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("staleSnapshots")
+	a.pages.AddPage("staleSnapshots", modal, true, true)
+	a.SetFocus(table)
+
+	// The sizes of saved RAM states need a request per snapshot
+	var withState []models.StaleSnapshot
+
+	for _, item := range stale {
+		if item.Snapshot.VMState {
+			withState = append(withState, item)
+		}
+	}
+
+	if len(withState) == 0 {
+		return
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		for _, item := range withState {
+			size, err := a.client.GetSnapshotStateSize(item.VM, item.Snapshot.Name)
+			if err != nil {
+				models.GetUILogger().Debug("RAM state size of snapshot %s of guest %d: %v", item.Snapshot.Name, item.VM.ID, err)
+			}
+
+			key := snapshotKey(item)
+
+			a.QueueUpdateDraw(func() {
+				sizes[key] = size
+				render()
+			})
+		}
+	}()
+}
+
+// deleteStaleSnapshots deletes snapshots one after another, since deleting
+// a snapshot locks its guest, and reports the outcome in the header.
+func (a *App) deleteStaleSnapshots(selected []models.StaleSnapshot) {
+	a.header.ShowLoading(fmt.Sprintf("Deleting %d snapshot(s)", len(selected)))
+
+	go func() {
+		defer crash.Recover()
+
+		var failed []string
+
+		var lastErr error
+
+		for i, item := range selected {
+			a.QueueUpdateDraw(func() {
+				a.header.ShowLoading(fmt.Sprintf("Deleting snapshot %d of %d: %s of %s", i+1, len(selected), item.Snapshot.Name, item.VM.Name))
+			})
+
+			if err := a.client.DeleteSnapshot(item.VM, item.Snapshot.Name); err != nil {
+				failed = append(failed, fmt.Sprintf("%d: %s", item.VM.ID, item.Snapshot.Name))
+				lastErr = err
+
+				continue
+			}
+
+			models.ForgetSnapshot(item.VM, item.Snapshot.Name)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if a.staleSnapshotsInFront() {
+				a.showStaleSnapshots()
+			}
+
+			if lastErr != nil {
+				a.showActionError(fmt.Sprintf("Deleted %d of %d snapshots; failed: %s",
+					len(selected)-len(failed), len(selected), strings.Join(failed, ", ")), lastErr)
+				a.loadTasksData()
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Deleted %d snapshot(s)", len(selected)))
+			a.loadTasksData()
+		})
+	}()
+}
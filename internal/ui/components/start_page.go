@@ -0,0 +1,36 @@
+package components
+
+import (
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// showStartPage switches to the configured start page and focuses its list
+// or, with default_focus: details, its details panel.
+func (a *App) showStartPage() {
+	page, list, details := api.PageNodes, tview.Primitive(a.nodeList), tview.Primitive(a.nodeDetails)
+
+	switch a.config.StartPage {
+	case config.StartPageGuests:
+		page, list, details = api.PageGuests, a.vmList, a.vmDetails
+	case config.StartPageTasks:
+		page, list, details = api.PageTasks, a.tasksList, nil
+	}
+
+	a.pages.SwitchToPage(page)
+	a.focusStartPanel(list, details)
+}
+
+// focusStartPanel focuses details if it exists and is the configured
+// default focus, or list otherwise.
+func (a *App) focusStartPanel(list, details tview.Primitive) {
+	if details != nil && a.config.DefaultFocus == config.FocusDetails {
+		a.SetFocus(details)
+
+		return
+	}
+
+	a.SetFocus(list)
+}
@@ -0,0 +1,151 @@
+package components
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/startup"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// checkMark returns the mark and color of a check in the startup checklist.
+func checkMark(status startup.CheckStatus) (string, string) {
+	switch status {
+	case startup.CheckRunning:
+		return theme.Icon("…", "[..]"), "info"
+	case startup.CheckPassed:
+		return theme.Icon("✓", "[OK]"), "success"
+	case startup.CheckPartial:
+		return theme.Icon("~", "[~~]"), "warning"
+	case startup.CheckFailed:
+		return theme.Icon("✗", "[XX]"), "error"
+	case startup.CheckSkipped:
+		return theme.Icon("-", "[--]"), "secondary"
+	}
+
+	return theme.Icon("·", "[  ]"), "secondary"
+}
+
+// formatCheckResult renders the mark and detail of a check with semantic
+// color tags.
+func formatCheckResult(result startup.CheckResult) (string, string) {
+	mark, color := checkMark(result.Status)
+
+	detail := result.Detail
+	if result.Status == startup.CheckFailed && detail == "" && result.Err != nil {
+		detail = result.Err.Error()
+	}
+
+	return fmt.Sprintf("[%s]%s[-]", color, tview.Escape(mark)), fmt.Sprintf("[%s]%s[-]", color, tview.Escape(detail))
+}
+
+// RunStartupChecklist runs the startup checks on a checklist that updates
+// as they progress. When every check passes the interface starts right
+// away; otherwise the user continues with partial functionality or aborts.
+// It returns the outcome and whether to start the interface.
+func RunStartupChecklist(cfg *config.Config, title string, checks []startup.Check) (startup.Verification, bool) {
+	tviewApp := tview.NewApplication()
+	crash.SetRestore(tviewApp.Stop)
+
+	theme.SetAccessible(cfg.Accessible)
+	theme.ApplyCustomTheme(&cfg.Theme)
+	theme.ApplyToTview()
+
+	table := tview.NewTable().SetSelectable(false, false)
+	for i, check := range checks {
+		mark, detail := formatCheckResult(startup.CheckResult{})
+		name := check.Name
+		if !check.Required {
+			name += " (optional)"
+		}
+
+		table.SetCell(i, 0, tview.NewTableCell(theme.ReplaceSemanticTags(mark)))
+		table.SetCell(i, 1, tview.NewTableCell(tview.Escape(name)).SetTextColor(theme.Colors.Primary))
+		table.SetCell(i, 2, tview.NewTableCell(theme.ReplaceSemanticTags(detail)).SetExpansion(1))
+	}
+
+	hint := tview.NewTextView().SetDynamicColors(true)
+	hint.SetText(theme.ReplaceSemanticTags("[secondary]Esc aborts[-]"))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, len(checks), 0, false).
+		AddItem(nil, 1, 0, false).
+		AddItem(hint, 0, 1, false)
+	content.SetBorder(true).
+		SetBorderPadding(1, 0, 2, 2).
+		SetTitle(" " + title + " ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	layout := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, len(checks)+6, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		verification startup.Verification
+		finished     bool
+		proceed      bool
+	)
+
+	tviewApp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || event.Rune() == 'q':
+			cancel()
+			tviewApp.Stop()
+		case event.Key() == tcell.KeyEnter && finished:
+			proceed = verification.Err == nil
+			tviewApp.Stop()
+		}
+
+		return nil
+	})
+
+	go func() {
+		defer crash.Recover()
+
+		result := startup.RunChecks(ctx, checks, func(index int, result startup.CheckResult) {
+			mark, detail := formatCheckResult(result)
+
+			tviewApp.QueueUpdateDraw(func() {
+				table.GetCell(index, 0).SetText(theme.ReplaceSemanticTags(mark))
+				table.GetCell(index, 2).SetText(theme.ReplaceSemanticTags(detail))
+			})
+		})
+
+		tviewApp.QueueUpdateDraw(func() {
+			verification = result
+			finished = true
+
+			switch {
+			case ctx.Err() != nil:
+				return
+			case result.Complete():
+				proceed = true
+				tviewApp.Stop()
+			case result.Err != nil:
+				hint.SetText(theme.ReplaceSemanticTags("[error]" + tview.Escape(result.Err.Error()) + "[-]\n\n[secondary]Enter or Esc exits[-]"))
+			default:
+				hint.SetText(theme.ReplaceSemanticTags("[warning]Some checks failed; parts of the interface may not work.[-]\n\n[secondary]Enter continues, Esc aborts[-]"))
+			}
+		})
+	}()
+
+	if err := tviewApp.SetRoot(layout, true).Run(); err != nil {
+		return startup.Verification{Err: err}, false
+	}
+
+	return verification, proceed
+}
@@ -0,0 +1,124 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/startup"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+// traceBarWidth is the width of the timeline bars in the startup trace.
+const traceBarWidth = 30
+
+// SetStartupTrace enables the startup trace panel and records the first
+// draw of the interface. Call it before Run.
+func (a *App) SetStartupTrace(trace *startup.Trace) {
+	a.startupTrace = trace
+
+	runStart := time.Now()
+
+	a.SetAfterDrawFunc(func(tcell.Screen) {
+		a.SetAfterDrawFunc(nil)
+		trace.Record("first draw", runStart)
+	})
+}
+
+// showStartupTrace shows how long each startup phase took as a timeline.
+// Background phases still running are added on reload.
+func (a *App) showStartupTrace() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(" Startup Trace ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	render := func() {
+		table.Clear()
+
+		phases := a.startupTrace.Phases()
+		total := a.startupTrace.Total()
+
+		for col, header := range []string{"Phase", "Start", "Duration", "Timeline"} {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(theme.Colors.HeaderText).
+				SetSelectable(false))
+		}
+
+		for i, phase := range phases {
+			row := i + 1
+
+			table.SetCell(row, 0, tview.NewTableCell(phase.Name).SetTextColor(theme.Colors.Primary))
+			table.SetCell(row, 1, tview.NewTableCell("+"+startup.FormatDuration(phase.Offset)).
+				SetTextColor(theme.Colors.Secondary).SetAlign(tview.AlignRight))
+			table.SetCell(row, 2, tview.NewTableCell(startup.FormatDuration(phase.Duration)).
+				SetTextColor(theme.Colors.Info).SetAlign(tview.AlignRight))
+			table.SetCell(row, 3, tview.NewTableCell(traceBar(phase, total)).SetTextColor(theme.Colors.Warning))
+		}
+
+		table.SetCell(len(phases)+1, 0, tview.NewTableCell("total").
+			SetTextColor(theme.Colors.HeaderText).SetSelectable(false))
+		table.SetCell(len(phases)+1, 2, tview.NewTableCell(startup.FormatDuration(total)).
+			SetTextColor(theme.Colors.HeaderText).SetAlign(tview.AlignRight).SetSelectable(false))
+	}
+
+	render()
+
+	hint := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(theme.ReplaceSemanticTags("[primary]r[-] reload  [primary]Esc[-] close  (also printed on exit)"))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			a.removePageIfPresent("startupTrace")
+
+			if a.lastFocus != nil {
+				a.SetFocus(a.lastFocus)
+			}
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			render()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(hint, 1, 0, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(content, 18, 0, true).
+			AddItem(nil, 0, 1, false), 86, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.lastFocus = a.GetFocus()
+	a.removePageIfPresent("startupTrace")
+	a.pages.AddPage("startupTrace", modal, true, true)
+	a.SetFocus(table)
+}
+
+// traceBar draws a phase on a timeline of the total startup time.
+func traceBar(phase startup.Phase, total time.Duration) string {
+	if total <= 0 {
+		return ""
+	}
+
+	offset := int(int64(phase.Offset) * traceBarWidth / int64(total))
+	width := max(int(int64(phase.Duration)*traceBarWidth/int64(total)), 1)
+	offset = min(max(offset, 0), traceBarWidth-width)
+
+	return strings.Repeat(" ", offset) + strings.Repeat("█", width)
+}
@@ -0,0 +1,149 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// taskLabel names the task of a UPID, e.g. "VM Start 100", falling back to
+// the UPID itself if it is malformed.
+func taskLabel(upid string) string {
+	// UPID:node:pid:pstart:starttime:type:id:user:
+	parts := strings.Split(upid, ":")
+	if len(parts) < 7 || parts[0] != "UPID" {
+		return upid
+	}
+
+	label := formatTaskType(parts[5])
+	if parts[6] != "" {
+		label += " " + parts[6]
+	}
+
+	return label
+}
+
+// taskErrorText describes a failed task with the end of its log and, for
+// known Proxmox errors, an explanation.
+func taskErrorText(message string, taskErr *api.TaskError) string {
+	text := fmt.Sprintf("%s\n\n%s failed on %s: %s", message, taskLabel(taskErr.UPID), taskErr.Node, strings.TrimSpace(taskErr.ExitStatus))
+
+	if explanation, ok := explainAPIError(taskErr); ok {
+		text += fmt.Sprintf("\n\n%s\n\nNext steps: %s", explanation.Summary, explanation.Hint)
+	}
+
+	if len(taskErr.LogTail) > 0 {
+		text += "\n\nEnd of the task log:\n" + strings.Join(taskErr.LogTail, "\n")
+	}
+
+	return text
+}
+
+// showTaskError reports an action whose task failed, with the end of the
+// task log and a button opening the full log.
+func (a *App) showTaskError(message string, taskErr *api.TaskError) {
+	a.header.ShowError(message)
+
+	focus := a.GetFocus()
+
+	closeModal := func() {
+		a.removePageIfPresent("apiError")
+
+		if focus != nil {
+			a.SetFocus(focus)
+		}
+	}
+
+	modal := tview.NewModal().
+		SetText(tview.Escape(taskErrorText(message, taskErr))).
+		SetTextColor(theme.Colors.Error).
+		AddButtons([]string{"View Full Log", "OK"}).
+		SetDoneFunc(func(_ int, label string) {
+			closeModal()
+
+			if label == "View Full Log" {
+				a.showTaskLog(taskErr.Node, taskErr.UPID)
+			}
+		})
+	modal.SetBorderColor(theme.Colors.Border)
+	modal.SetTitle(" Task Failed ").
+		SetTitleColor(theme.Colors.Title)
+
+	a.removePageIfPresent("apiError")
+	a.pages.AddPage("apiError", modal, false, true)
+	a.SetFocus(modal)
+}
+
+// showTaskLog reads the full log of a task and shows it scrolled to the end.
+func (a *App) showTaskLog(node, upid string) {
+	a.header.ShowLoading("Loading task log...")
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		lines, err := client.GetFullTaskLog(node, upid)
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+
+			if err != nil {
+				a.showActionError("Failed to load the task log", err)
+
+				return
+			}
+
+			a.showTaskLogView(upid, lines)
+		})
+	}()
+}
+
+// showTaskLogView shows the lines of a task log.
+func (a *App) showTaskLogView(upid string, lines []string) {
+	lastFocus := a.GetFocus()
+
+	textView := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(strings.Join(lines, "\n"))
+	textView.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Task Log: %s (%d lines) ", taskLabel(upid), len(lines))).
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	textView.SetBorderPadding(0, 0, 1, 1)
+	textView.ScrollToEnd()
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q') {
+			a.removePageIfPresent("taskLog")
+
+			if lastFocus != nil {
+				a.SetFocus(lastFocus)
+			}
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("taskLog")
+	a.pages.AddPage("taskLog", modal, true, true)
+	a.SetFocus(textView)
+}
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestTaskLabel(t *testing.T) {
+	assert.Equal(t, "VM Start 100", taskLabel("UPID:pve1:0000A1B2:0012C3D4:64F0A1B2:qmstart:100:root@pam:"))
+	assert.Equal(t, "not-a-upid", taskLabel("not-a-upid"))
+}
+
+func TestTaskErrorText(t *testing.T) {
+	taskErr := &api.TaskError{
+		UPID:       "UPID:pve1:0000A1B2:0012C3D4:64F0A1B2:qmstart:100:root@pam:",
+		Node:       "pve1",
+		ExitStatus: "VM is locked (backup)",
+		LogTail:    []string{"trying to acquire lock...", "TASK ERROR: VM is locked (backup)"},
+	}
+
+	text := taskErrorText("Error starting web", taskErr)
+
+	assert.Contains(t, text, "VM Start 100 failed on pve1: VM is locked (backup)")
+	assert.Contains(t, text, "The guest is locked by a backup operation.")
+	assert.Contains(t, text, "End of the task log:\ntrying to acquire lock...\nTASK ERROR: VM is locked (backup)")
+}
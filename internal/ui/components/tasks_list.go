@@ -37,6 +37,13 @@ func NewTasksList() *TasksList {
 		tasks: make([]*api.ClusterTask, 0),
 	}
 
+	// Enter opens the log of the selected task
+	table.SetSelectedFunc(func(_, _ int) {
+		if task := tl.GetSelectedTask(); task != nil && tl.app != nil {
+			tl.app.showTaskLog(task.Node, task.UPID)
+		}
+	})
+
 	tl.setupKeyHandlers()
 
 	return tl
@@ -66,7 +73,7 @@ func (tl *TasksList) GetSelectedTask() *api.ClusterTask {
 		return nil
 	}
 
-	return tl.tasks[row-1] // -1 because row 0 is the header
+	return tl.sortedTasks()[row-1] // -1 because row 0 is the header
 }
 
 // Select wraps the table Select method to match the interface.
@@ -74,6 +81,31 @@ func (tl *TasksList) Select(row, column int) *tview.Table {
 	return tl.Table.Select(row, column)
 }
 
+// SelectTask selects the row of the task with the given UPID.
+// It returns false if the task is not in the list.
+func (tl *TasksList) SelectTask(upid string) bool {
+	for i, task := range tl.sortedTasks() {
+		if task.UPID == upid {
+			tl.Table.Select(i+1, 0) // +1 because row 0 is the header
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedTasks returns a copy of the tasks in display order (newest first).
+func (tl *TasksList) sortedTasks() []*api.ClusterTask {
+	sorted := make([]*api.ClusterTask, len(tl.tasks))
+	copy(sorted, tl.tasks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime > sorted[j].StartTime
+	})
+
+	return sorted
+}
+
 func (tl *TasksList) noTasksCell() *tview.TableCell {
 	return tview.NewTableCell("No tasks available").
 		SetTextColor(theme.Colors.Warning).
@@ -98,12 +130,7 @@ func (tl *TasksList) updateTable() {
 		return
 	}
 
-	// Sort tasks by start time (newest first)
-	sortedTasks := make([]*api.ClusterTask, len(tl.tasks))
-	copy(sortedTasks, tl.tasks)
-	sort.Slice(sortedTasks, func(i, j int) bool {
-		return sortedTasks[i].StartTime > sortedTasks[j].StartTime
-	})
+	sortedTasks := tl.sortedTasks()
 
 	// Set headers: Time, Node, Type, Status, User, ID, Duration
 	headers := []string{"Time", "Node", "Type", "Status", "User", "ID", "Duration"}
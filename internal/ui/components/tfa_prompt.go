@@ -0,0 +1,122 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// tfaPromptTimeout bounds how long a re-login waits for the TOTP code.
+const tfaPromptTimeout = 2 * time.Minute
+
+// promptTFA asks for the TOTP code of a re-login, e.g. when the ticket
+// expires or a profile is switched. It blocks the calling goroutine, so it
+// must not be called from the UI goroutine; the API client calls it on a
+// login goroutine of its own.
+func (a *App) promptTFA(api.TFAChallenge) (string, error) {
+	result := make(chan string, 1)
+
+	a.QueueUpdateDraw(func() {
+		a.showTFAPrompt(func(code string) {
+			result <- code
+		})
+	})
+
+	select {
+	case code := <-result:
+		if code == "" {
+			return "", fmt.Errorf("two-factor login canceled")
+		}
+
+		return code, nil
+	case <-time.After(tfaPromptTimeout):
+		a.QueueUpdateDraw(func() {
+			a.removePageIfPresent("tfaPrompt")
+		})
+
+		return "", fmt.Errorf("no two-factor code entered within %s", tfaPromptTimeout)
+	case <-a.ctx.Done():
+		return "", a.ctx.Err()
+	}
+}
+
+// showTFAPrompt shows the TOTP code form. done receives the code, or ""
+// when canceled.
+func (a *App) showTFAPrompt(done func(code string)) {
+	form := tview.NewForm()
+	form.SetBorder(true).
+		SetTitle(" Two-Factor Login ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+
+	form.AddPasswordField("TOTP Code", "", 12, '*', nil)
+
+	lastFocus := a.GetFocus()
+	answered := false
+
+	finish := func(code string) {
+		if answered {
+			return
+		}
+
+		answered = true
+
+		a.removePageIfPresent("tfaPrompt")
+
+		if lastFocus != nil {
+			a.SetFocus(lastFocus)
+		}
+
+		done(code)
+	}
+
+	submit := func() {
+		field, ok := form.GetFormItemByLabel("TOTP Code").(*tview.InputField)
+		if !ok {
+			return
+		}
+
+		if code := strings.TrimSpace(field.GetText()); code != "" {
+			finish(code)
+		}
+	}
+
+	form.AddButton("Login", submit)
+	form.AddButton("Cancel", func() { finish("") })
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			finish("")
+
+			return nil
+		}
+
+		return event
+	})
+
+	if field, ok := form.GetFormItemByLabel("TOTP Code").(*tview.InputField); ok {
+		field.SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				submit()
+			}
+		})
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 0, true).
+			AddItem(nil, 0, 1, false), 40, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("tfaPrompt")
+	a.pages.AddPage("tfaPrompt", modal, true, true)
+	a.SetFocus(form)
+}
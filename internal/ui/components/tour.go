@@ -0,0 +1,196 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Size of the guided tour overlay.
+const (
+	tourWidth  = 66
+	tourHeight = 10
+)
+
+// tourStep is a single page of the guided tour.
+type tourStep struct {
+	Title string
+	Text  string
+	// Page is the main page shown during the step, empty to keep the current one.
+	Page string
+	// Target is the panel highlighted during the step, nil for none.
+	Target tview.Primitive
+}
+
+// highlightable is implemented by panels whose border can be highlighted.
+type highlightable interface {
+	GetBorderColor() tcell.Color
+	SetBorderColor(color tcell.Color) *tview.Box
+}
+
+// tourSteps returns the guided tour, describing the configured key bindings.
+func (a *App) tourSteps() []tourStep {
+	keys := a.config.KeyBindings
+
+	return []tourStep{
+		{
+			Title: "Welcome to pvetui",
+			Text: "This short tour shows the main panels and keys.\n\n" +
+				"[primary]Enter[-] or [primary]→[-] next, [primary]←[-] back, [primary]Esc[-] to skip the tour.",
+		},
+		{
+			Title:  "Summary",
+			Text:   fmt.Sprintf("The summary panel shows cluster totals, the selected node, or active tasks. Change or shrink it from the global menu ([primary]%s[-]).", keys.GlobalMenu),
+			Target: a.clusterStatus,
+		},
+		{
+			Title:  "Nodes",
+			Text:   fmt.Sprintf("The Nodes page lists the cluster nodes. Press [primary]%s[-] / [primary]%s[-] to cycle through Nodes, Guests, and Tasks, or jump with [primary]%s[-], [primary]%s[-], and [primary]%s[-].", keys.SwitchView, keys.SwitchViewReverse, keys.NodesPage, keys.GuestsPage, keys.TasksPage),
+			Page:   api.PageNodes,
+			Target: a.nodeList,
+		},
+		{
+			Title:  "Details",
+			Text:   fmt.Sprintf("Details of the selected item are shown next to the list. Move between list and details with [primary]←[-] / [primary]→[-] (or [primary]h[-] / [primary]l[-]); [primary]%s[-] zooms the focused panel.", keys.ToggleZoom),
+			Page:   api.PageNodes,
+			Target: a.nodeDetails,
+		},
+		{
+			Title:  "Guests",
+			Text:   fmt.Sprintf("The Guests page lists all VMs and containers. [primary]%s[-] opens an SSH shell, [primary]%s[-] a VNC console, and [primary]%s[-] the menu with all actions for the selection.", keys.Shell, keys.VNC, keys.Menu),
+			Page:   api.PageGuests,
+			Target: a.vmList,
+		},
+		{
+			Title:  "Search",
+			Text:   fmt.Sprintf("Press [primary]%s[-] to filter the current list, or [primary]%s[-] to search nodes, guests, storages, and tasks across the cluster.", keys.Search, keys.GlobalSearch),
+			Page:   api.PageGuests,
+			Target: a.vmList,
+		},
+		{
+			Title: "Menus",
+			Text:  fmt.Sprintf("The footer lists the most important keys. [primary]%s[-] (or [primary]Esc[-]) opens the global menu with profiles, refresh, and this tour.", keys.GlobalMenu),
+		},
+		{
+			Title: "That's it",
+			Text:  fmt.Sprintf("Press [primary]%s[-] at any time to see all key bindings, and [primary]%s[-] to quit.\n\nEnjoy pvetui!", keys.Help, keys.Quit),
+		},
+	}
+}
+
+// showTour shows the guided tour overlay. Finishing or skipping the tour
+// clears the first-run marker so it is not shown again.
+func (a *App) showTour() {
+	steps := a.tourSteps()
+	current := 0
+
+	startPage, _ := a.pages.GetFrontPage()
+	startFocus := a.GetFocus()
+
+	var (
+		highlighted    highlightable
+		previousBorder tcell.Color
+	)
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true)
+	textView.SetBorder(true).
+		SetBorderColor(theme.Colors.Warning).
+		SetTitleColor(theme.Colors.Title).
+		SetBackgroundColor(theme.Colors.Background)
+	textView.SetBorderPadding(0, 0, 1, 1)
+
+	clearHighlight := func() {
+		if highlighted != nil {
+			highlighted.SetBorderColor(previousBorder)
+			highlighted = nil
+		}
+	}
+
+	showStep := func() {
+		step := steps[current]
+
+		clearHighlight()
+
+		if step.Page != "" {
+			// Switching hides every other page, including the overlay itself
+			a.pages.SwitchToPage(step.Page)
+
+			if a.pages.HasPage("tour") {
+				a.pages.ShowPage("tour")
+			}
+		}
+
+		if target, ok := step.Target.(highlightable); ok {
+			highlighted, previousBorder = target, target.GetBorderColor()
+			target.SetBorderColor(theme.Colors.Warning)
+		}
+
+		textView.SetTitle(fmt.Sprintf(" %s (%d/%d) ", step.Title, current+1, len(steps)))
+		textView.SetText(theme.ReplaceSemanticTags(step.Text))
+	}
+
+	closeTour := func() {
+		clearHighlight()
+		a.removePageIfPresent("tour")
+
+		if startPage != "" {
+			a.pages.SwitchToPage(startPage)
+		}
+
+		if startFocus != nil {
+			a.SetFocus(startFocus)
+		}
+
+		if err := config.ClearTourPending(); err != nil {
+			a.logger.Debug("Failed to clear tour marker: %v", err)
+		}
+	}
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closeTour()
+		case event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyRight ||
+			(event.Key() == tcell.KeyRune && (event.Rune() == ' ' || event.Rune() == 'n' || event.Rune() == 'l')):
+			if current == len(steps)-1 {
+				closeTour()
+
+				return nil
+			}
+
+			current++
+			showStep()
+		case event.Key() == tcell.KeyLeft || event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 ||
+			(event.Key() == tcell.KeyRune && (event.Rune() == 'p' || event.Rune() == 'h')):
+			if current > 0 {
+				current--
+				showStep()
+			}
+		}
+
+		return nil
+	})
+
+	// Keep the overlay near the bottom so the highlighted panels stay visible
+	overlay := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, tourWidth, 0, true).
+			AddItem(nil, 0, 1, false), tourHeight, 0, true).
+		AddItem(nil, 2, 0, false)
+
+	a.removePageIfPresent("tour")
+	showStep()
+	a.pages.AddPage("tour", overlay, true, true)
+	a.SetFocus(textView)
+}
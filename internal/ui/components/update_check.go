@@ -0,0 +1,46 @@
+package components
+
+import (
+	"context"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/update"
+	"github.com/devnullvoid/pvetui/internal/version"
+)
+
+// checkForUpdate looks up the latest release in the background when the
+// update check is enabled, and notes a newer release in the header.
+func (a *App) checkForUpdate() {
+	if !a.config.UpdateCheck || version.IsDevBuild() {
+		return
+	}
+
+	go func() {
+		defer crash.Recover()
+
+		uiLogger := models.GetUILogger()
+
+		ctx, cancel := context.WithTimeout(a.ctx, update.CheckTimeout)
+		defer cancel()
+
+		release, err := update.NewClient().Latest(ctx)
+		if err != nil {
+			uiLogger.Debug("Update check failed: %v", err)
+
+			return
+		}
+
+		if !update.IsNewer(version.GetBuildInfo().Version, release.Tag) {
+			uiLogger.Debug("No update available, latest release is %s", release.Tag)
+
+			return
+		}
+
+		uiLogger.Info("Update available: %s", release.Tag)
+
+		a.QueueUpdateDraw(func() {
+			a.header.SetUpdateHint("v" + release.Version() + " available")
+		})
+	}()
+}
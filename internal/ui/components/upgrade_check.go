@@ -0,0 +1,185 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// checkLabels are the pve7to8-style labels of the check statuses.
+var checkLabels = map[models.CheckStatus]struct {
+	text  string
+	color string
+}{
+	models.CheckPass: {"PASS", "success"},
+	models.CheckSkip: {"SKIP", "secondary"},
+	models.CheckWarn: {"WARN", "warning"},
+	models.CheckFail: {"FAIL", "error"},
+}
+
+// showUpgradeReadiness checks whether the cluster is ready for an upgrade and
+// shows the recommended upgrade order with a checklist per node.
+func (a *App) showUpgradeReadiness() {
+	nodes := make([]*api.Node, len(models.GlobalState.OriginalNodes))
+	copy(nodes, models.GlobalState.OriginalNodes)
+
+	vms := make([]*api.VM, len(models.GlobalState.OriginalVMs))
+	copy(vms, models.GlobalState.OriginalVMs)
+
+	if len(nodes) == 0 {
+		a.header.ShowError("No node data loaded")
+
+		return
+	}
+
+	a.header.ShowLoading("Checking upgrade readiness...")
+
+	client := a.client
+
+	go func() {
+		defer crash.Recover()
+
+		data := make([]models.NodeUpgradeData, 0, len(nodes))
+
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+
+			nodeData := models.NodeUpgradeData{Node: node}
+
+			if node.Online {
+				nodeData.Updates, nodeData.UpdatesErr = client.GetPendingUpdates(node.Name)
+				nodeData.ZFSPools, nodeData.ZFSErr = client.GetZFSPools(node.Name)
+			}
+
+			data = append(data, nodeData)
+		}
+
+		// Clusters without Ceph fail the status request
+		ceph, err := client.GetCephHealth()
+		if err != nil {
+			models.GetUILogger().Debug("Ceph status unavailable: %v", err)
+		}
+
+		report := models.BuildUpgradeReport(data, vms, ceph)
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+			a.showUpgradeReport(report)
+		})
+	}()
+}
+
+// showUpgradeReport renders the upgrade readiness report.
+func (a *App) showUpgradeReport(report models.UpgradeReport) {
+	lastFocus := a.GetFocus()
+	titleColor := theme.ColorToTag(theme.Colors.HeaderText)
+
+	heading := func(text string) string {
+		return fmt.Sprintf("[%s::b]%s[-::-]", titleColor, text)
+	}
+
+	var lines []string
+
+	lines = append(lines, heading("Cluster"))
+	lines = append(lines, upgradeCheckLines(report.Cluster)...)
+
+	var order []string
+
+	for _, node := range report.Nodes {
+		if node.Status() != models.CheckFail {
+			order = append(order, node.Node)
+		}
+	}
+
+	lines = append(lines, "", heading("Recommended upgrade order"))
+
+	if len(order) == 0 {
+		lines = append(lines, "  no node is ready, fix the failed checks first")
+	} else {
+		lines = append(lines, "  "+strings.Join(order, " "+theme.Icon("→", "->")+" "))
+		lines = append(lines, "  one node at a time, moving its guests away first")
+	}
+
+	for _, node := range report.Nodes {
+		lines = append(lines, "", heading("Node "+node.Node))
+		lines = append(lines, upgradeCheckLines(node.Checks)...)
+	}
+
+	passed, warnings, failures, skipped := report.Counts()
+	lines = append(lines, "", theme.ReplaceSemanticTags(fmt.Sprintf(
+		"TOTAL: %d  [success]PASSED: %d[-]  [warning]WARNINGS: %d[-]  [error]FAILURES: %d[-]  [secondary]SKIPPED: %d[-]",
+		passed+warnings+failures+skipped, passed, warnings, failures, skipped)))
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetText(strings.Join(lines, "\n"))
+	textView.SetBorder(true).
+		SetTitle(" Upgrade Readiness (r: recheck) ").
+		SetTitleColor(theme.Colors.Title).
+		SetBorderColor(theme.Colors.Border)
+	textView.SetBorderPadding(0, 0, 1, 1)
+
+	closeReport := func() {
+		a.removePageIfPresent("upgradeReadiness")
+
+		if lastFocus != nil {
+			a.SetFocus(lastFocus)
+		}
+	}
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || (event.Key() == tcell.KeyRune && event.Rune() == 'q'):
+			closeReport()
+
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			closeReport()
+			a.showUpgradeReadiness()
+
+			return nil
+		}
+
+		return event
+	})
+
+	height := len(lines) + 2
+	if height > 30 {
+		height = 30
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, height, 0, true).
+			AddItem(nil, 0, 1, false), 90, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.removePageIfPresent("upgradeReadiness")
+	a.pages.AddPage("upgradeReadiness", modal, true, true)
+	a.SetFocus(textView)
+}
+
+// upgradeCheckLines renders checks as "  PASS message" lines.
+func upgradeCheckLines(checks []models.UpgradeCheck) []string {
+	lines := make([]string, len(checks))
+
+	for i, check := range checks {
+		label := checkLabels[check.Status]
+		lines[i] = theme.ReplaceSemanticTags(fmt.Sprintf("  [%s]%s[-] %s", label.color, label.text, tview.Escape(check.Message)))
+	}
+
+	return lines
+}
@@ -23,6 +23,9 @@ type VMConfigPage struct {
 	app    *App
 	vm     *api.VM
 	config *api.VMConfig
+	// loaded is the config as it was loaded, to detect changes made
+	// elsewhere before saving
+	loaded api.VMConfig
 	saveFn func(*api.VMConfig) error
 }
 
@@ -34,6 +37,7 @@ func NewVMConfigPage(app *App, vm *api.VM, config *api.VMConfig, saveFn func(*ap
 		app:    app,
 		vm:     vm,
 		config: config,
+		loaded: *config,
 		saveFn: saveFn,
 	}
 
@@ -121,68 +125,7 @@ func NewVMConfigPage(app *App, vm *api.VM, config *api.VMConfig, saveFn func(*ap
 		page.config.OnBoot = &checked
 	})
 	// Save/Cancel buttons
-	form.AddButton("Save", func() {
-		// Validate hostname format before saving
-		var validationError string
-		if vm.Type == api.VMTypeQemu && page.config.Name != "" {
-			if !isValidHostname(page.config.Name) {
-				validationError = fmt.Sprintf("Invalid VM name: %s", page.config.Name)
-			}
-		} else if vm.Type == api.VMTypeLXC && page.config.Hostname != "" {
-			if !isValidHostname(page.config.Hostname) {
-				validationError = fmt.Sprintf("Invalid hostname: %s", page.config.Hostname)
-			}
-		}
-
-		if validationError != "" {
-			app.header.ShowError(validationError)
-			return
-		}
-
-		// Show loading indicator
-		app.header.ShowLoading(fmt.Sprintf("Saving configuration for %s...", vm.Name))
-
-		// Run save operation in goroutine to avoid blocking UI
-		go func() {
-			err := page.saveFn(page.config)
-
-			app.QueueUpdateDraw(func() {
-				if err != nil {
-					app.header.ShowError(fmt.Sprintf("Failed to save config: %v", err))
-				} else {
-					app.header.ShowSuccess("Configuration updated successfully.")
-
-					// Update the VM name in the current VM object for title update
-					if vm.Type == api.VMTypeQemu && page.config.Name != "" {
-						vm.Name = page.config.Name
-					} else if vm.Type == api.VMTypeLXC && page.config.Hostname != "" {
-						vm.Name = page.config.Hostname
-					}
-
-					// Remove the config page first
-					app.removePageIfPresent("vmConfig")
-
-					// Show loading indicator while waiting for API changes to propagate
-					app.header.ShowLoading("Waiting for configuration changes to propagate...")
-
-					// Poll Proxmox API to verify the name change has propagated
-					// This is more professional than arbitrary delays
-					go func() {
-						// Store the expected new name
-						expectedName := ""
-						if vm.Type == api.VMTypeQemu && page.config.Name != "" {
-							expectedName = page.config.Name
-						} else if vm.Type == api.VMTypeLXC && page.config.Hostname != "" {
-							expectedName = page.config.Hostname
-						}
-
-						// Use the dedicated polling function
-						app.pollForConfigChange(vm, expectedName)
-					}()
-				}
-			})
-		}()
-	})
+	form.AddButton("Save", page.save)
 	form.AddButton("Cancel", func() {
 		app.removePageIfPresent("vmConfig")
 	})
@@ -216,6 +159,161 @@ func NewVMConfigPage(app *App, vm *api.VM, config *api.VMConfig, saveFn func(*ap
 	return page
 }
 
+// save validates the edited config and saves it, unless settings shown in
+// the editor were changed elsewhere since it was loaded.
+func (p *VMConfigPage) save() {
+	// Validate hostname format before saving
+	var validationError string
+	if p.vm.Type == api.VMTypeQemu && p.config.Name != "" {
+		if !isValidHostname(p.config.Name) {
+			validationError = fmt.Sprintf("Invalid VM name: %s", p.config.Name)
+		}
+	} else if p.vm.Type == api.VMTypeLXC && p.config.Hostname != "" {
+		if !isValidHostname(p.config.Hostname) {
+			validationError = fmt.Sprintf("Invalid hostname: %s", p.config.Hostname)
+		}
+	}
+
+	if validationError != "" {
+		p.app.header.ShowError(validationError)
+		return
+	}
+
+	// Show loading indicator
+	p.app.header.ShowLoading(fmt.Sprintf("Saving configuration for %s...", p.vm.Name))
+
+	// Run save operation in goroutine to avoid blocking UI
+	go func() {
+		if current, changes := p.conflictingConfig(); len(changes) > 0 {
+			p.app.QueueUpdateDraw(func() {
+				p.app.header.StopLoading()
+				p.showConflict(current, changes)
+			})
+
+			return
+		}
+
+		err := p.saveFn(p.config)
+
+		p.app.QueueUpdateDraw(func() {
+			if err != nil {
+				p.app.showActionError("Failed to save config", err)
+			} else {
+				p.app.header.ShowSuccess("Configuration updated successfully.")
+
+				// Update the VM name in the current VM object for title update
+				if p.vm.Type == api.VMTypeQemu && p.config.Name != "" {
+					p.vm.Name = p.config.Name
+				} else if p.vm.Type == api.VMTypeLXC && p.config.Hostname != "" {
+					p.vm.Name = p.config.Hostname
+				}
+
+				// Remove the config page first
+				p.app.removePageIfPresent("vmConfig")
+
+				// Show loading indicator while waiting for API changes to propagate
+				p.app.header.ShowLoading("Waiting for configuration changes to propagate...")
+
+				// Poll Proxmox API to verify the name change has propagated
+				// This is more professional than arbitrary delays
+				go func() {
+					// Store the expected new name
+					expectedName := ""
+					if p.vm.Type == api.VMTypeQemu && p.config.Name != "" {
+						expectedName = p.config.Name
+					} else if p.vm.Type == api.VMTypeLXC && p.config.Hostname != "" {
+						expectedName = p.config.Hostname
+					}
+
+					// Use the dedicated polling function
+					p.app.pollForConfigChange(p.vm, expectedName)
+				}()
+			}
+		})
+	}()
+}
+
+// conflictingConfig re-reads the config and, if it was changed elsewhere
+// since the editor loaded it, returns it with the changed settings. Changes
+// to settings the editor doesn't write, like disks, are not conflicts.
+func (p *VMConfigPage) conflictingConfig() (*api.VMConfig, []api.ConfigChange) {
+	if p.app == nil || p.app.client == nil || p.loaded.Digest == "" {
+		return nil, nil
+	}
+
+	// If the config can't be read, Proxmox still rejects an outdated digest
+	current, err := p.app.client.GetVMConfig(p.vm)
+	if err != nil || current.Digest == p.loaded.Digest {
+		return nil, nil
+	}
+
+	changes := api.DiffVMConfig(&p.loaded, current)
+	if len(changes) == 0 {
+		p.config.Digest = current.Digest
+	}
+
+	return current, changes
+}
+
+// showConflict lists the settings changed elsewhere and asks whether to
+// overwrite them with the edited config or to reload the editor.
+func (p *VMConfigPage) showConflict(current *api.VMConfig, changes []api.ConfigChange) {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "The configuration of %s was changed elsewhere (e.g. in the web UI) since you opened the editor:\n\n", p.vm.Name)
+
+	for _, change := range changes {
+		fmt.Fprintf(&text, "%s: %s → %s\n", change.Setting, formatConfigValue(change.Old), formatConfigValue(change.New))
+	}
+
+	text.WriteString("\nOverwrite saves your edits over these changes. Reload discards your edits and shows the current configuration.")
+
+	modal := tview.NewModal().
+		SetText(tview.Escape(text.String())).
+		SetTextColor(theme.Colors.Primary).
+		AddButtons([]string{"Overwrite", "Reload", "Cancel"})
+	modal.SetBorderColor(theme.Colors.Warning)
+	modal.SetTitle(" Configuration Changed ")
+	modal.SetTitleColor(theme.Colors.Title)
+
+	modal.SetDoneFunc(func(_ int, label string) {
+		p.app.removePageIfPresent("vmConfigConflict")
+
+		switch label {
+		case "Overwrite":
+			p.loaded = *current
+			p.config.Digest = current.Digest
+			p.app.SetFocus(p)
+			p.save()
+		case "Reload":
+			p.app.removePageIfPresent("vmConfig")
+
+			page := NewVMConfigPage(p.app, p.vm, current, p.saveFn)
+			p.app.pages.AddPage("vmConfig", page, true, true)
+			p.app.SetFocus(page)
+		default:
+			p.app.SetFocus(p)
+		}
+	})
+
+	p.app.pages.AddPage("vmConfigConflict", modal, false, true)
+	p.app.SetFocus(modal)
+}
+
+// formatConfigValue shortens a config value for the conflict dialog.
+func formatConfigValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+
+	runes := []rune(strings.Join(strings.Fields(value), " "))
+	if len(runes) > 40 {
+		return string(runes[:37]) + "..."
+	}
+
+	return string(runes)
+}
+
 // isValidHostnameChar validates if a character is allowed in a hostname.
 // Hostnames can only contain letters (a-z, A-Z), digits (0-9), and hyphens (-).
 // They cannot start or end with hyphens, and cannot contain underscores or other special characters.
@@ -349,7 +447,7 @@ func showResizeStorageModal(app *App, vm *api.VM) {
 			err := app.client.ResizeVMStorage(vm, dev.Device, sizeStr)
 			app.QueueUpdateDraw(func() {
 				if err != nil {
-					app.header.ShowError(fmt.Sprintf("Resize failed: %v", err))
+					app.showActionError("Resize failed", err)
 				} else {
 					app.header.ShowSuccess("Resize operation started successfully.")
 					// Remove the modal first
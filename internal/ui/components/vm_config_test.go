@@ -89,3 +89,18 @@ func TestHostnameCharValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatConfigValue(t *testing.T) {
+	tests := map[string]string{
+		"":                      "(unset)",
+		"4":                     "4",
+		"line one\nline two":    "line one line two",
+		strings.Repeat("é", 50): strings.Repeat("é", 37) + "...",
+	}
+
+	for input, expected := range tests {
+		if got := formatConfigValue(input); got != expected {
+			t.Errorf("formatConfigValue(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
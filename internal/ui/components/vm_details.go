@@ -3,20 +3,74 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/devnullvoid/pvetui/internal/ui/models"
 	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/internal/ui/utils"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
+// VM details section identifiers.
+const (
+	vmSectionOverview    = "overview"
+	vmSectionNetwork     = "network"
+	vmSectionFilesystems = "filesystems"
+	vmSectionConfig      = "config"
+	vmSectionSnapshots   = "snapshots"
+	vmSectionBackups     = "backups"
+)
+
+// vmDetailsSection describes a collapsible section of the VM details panel.
+type vmDetailsSection struct {
+	id    string
+	icon  string
+	title string
+	// render draws the section body starting at row and returns the next free row.
+	render func(vd *VMDetails, vm *api.VM, row int) int
+	// summary returns a short description shown next to the section title.
+	summary func(vd *VMDetails, vm *api.VM) string
+}
+
+// vmDetailsSections lists the sections in display order.
+var vmDetailsSections = []vmDetailsSection{
+	{id: vmSectionOverview, icon: "📋", title: "Overview", render: (*VMDetails).renderOverview, summary: overviewSummary},
+	{id: vmSectionNetwork, icon: "🌐", title: "Network", render: (*VMDetails).renderNetwork, summary: networkSummary},
+	{id: vmSectionFilesystems, icon: "📂", title: "Filesystems", render: (*VMDetails).renderFilesystems, summary: filesystemsSummary},
+	{id: vmSectionConfig, icon: "🔨", title: "Configuration", render: (*VMDetails).renderConfig, summary: configSummary},
+	{id: vmSectionSnapshots, icon: "📸", title: "Snapshots", render: (*VMDetails).renderSnapshots, summary: (*VMDetails).snapshotsSummary},
+	{id: vmSectionBackups, icon: "🗄️", title: "Backups", render: (*VMDetails).renderBackups, summary: (*VMDetails).backupsSummary},
+}
+
 // VMDetails encapsulates the VM details panel.
+//
+// The panel is split into collapsible sections. When focused, rows can be
+// navigated with the arrow keys (or j/k), Tab/Shift+Tab jump between sections,
+// Enter/Space toggle the current section, and +/- expand or collapse all.
 type VMDetails struct {
 	*tview.Table
 
 	app *App
+	vm  *api.VM
+
+	// collapsed tracks collapsed sections; it persists across guests.
+	collapsed map[string]bool
+	// rowSections maps each table row to the section it belongs to.
+	rowSections []string
+	// headerRows maps section IDs to the row of their header.
+	headerRows map[string]int
+	// sections are the built-in sections followed by plugin sections.
+	sections []vmDetailsSection
+	// pluginSections maps the IDs of plugin sections to their plugin.
+	pluginSections map[string]pluginSection
+
+	history vmHistory
+
+	// containerReadings caches container stats read over SSH by "node:vmid".
+	containerReadings map[string]*containerStatsReading
 }
 
 var _ VMDetailsComponent = (*VMDetails)(nil)
@@ -27,12 +81,28 @@ func NewVMDetails() *VMDetails {
 	table.SetBorders(false)
 	table.SetTitle(" Guest Details ")
 	table.SetBorder(true)
+	table.SetSelectedStyle(tcell.StyleDefault.Background(theme.Colors.Selection).Foreground(theme.Colors.Primary))
 	table.Clear()
 	table.SetCell(0, 0, tview.NewTableCell("Select a guest").SetTextColor(theme.Colors.Primary))
 
-	return &VMDetails{
+	vd := &VMDetails{
 		Table: table,
+		// Keep the panel compact by default; the heavier sections load on demand.
+		collapsed: map[string]bool{
+			vmSectionFilesystems: true,
+			vmSectionConfig:      true,
+			vmSectionSnapshots:   true,
+			vmSectionBackups:     true,
+		},
+		headerRows: make(map[string]int),
+		sections:   vmDetailsSections,
 	}
+
+	// Only highlight the selected row while the panel has focus.
+	table.SetFocusFunc(func() { table.SetSelectable(true, false) })
+	table.SetBlurFunc(func() { table.SetSelectable(false, false) })
+
+	return vd
 }
 
 // Clear wraps the table Clear method to satisfy the interface.
@@ -44,51 +114,216 @@ func (vd *VMDetails) Clear() *tview.Table {
 func (vd *VMDetails) SetApp(app *App) {
 	vd.app = app
 
-	// Set up input capture for arrow keys and VI-like navigation (hjkl)
-	vd.SetInputCapture(createNavigationInputCapture(vd.app, vd.app.vmList, nil))
+	// Set up input capture for arrow keys and VI-like navigation (hjkl),
+	// plus the section toggling keys.
+	navigation := createNavigationInputCapture(vd.app, vd.app.vmList, nil)
+
+	vd.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			vd.toggleSelectedSection()
+
+			return nil
+		case tcell.KeyTab:
+			vd.jumpSection(1)
+
+			return nil
+		case tcell.KeyBacktab:
+			vd.jumpSection(-1)
+
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case ' ':
+				vd.toggleSelectedSection()
+
+				return nil
+			case '+':
+				vd.setAllCollapsed(false)
+
+				return nil
+			case '-':
+				vd.setAllCollapsed(true)
+
+				return nil
+			}
+		}
+
+		return navigation(event)
+	})
 }
 
 // Update fills the VM details table for the given VM.
 func (vd *VMDetails) Update(vm *api.VM) {
 	if vm == nil {
+		vd.vm = nil
+		vd.rowSections = nil
 		vd.Clear()
 		vd.SetCell(0, 0, tview.NewTableCell("Select a guest").SetTextColor(theme.Colors.Primary))
 
 		return
 	}
 
+	changed := vd.vm == nil || vd.vm.ID != vm.ID || vd.vm.Node != vm.Node
+	vd.vm = vm
+
+	if changed {
+		vd.history.reset(vm)
+	}
+
+	vd.loadExpandedHistory()
+	vd.render(changed)
+}
+
+// render redraws all sections for the current VM. If resetScroll is false the
+// selected section is preserved.
+func (vd *VMDetails) render(resetScroll bool) {
+	vm := vd.vm
+	if vm == nil {
+		return
+	}
+
+	selectedRow, _ := vd.GetSelection()
+	selectedSection := vd.selectedSection()
+
 	vd.Clear()
 
+	vd.rowSections = vd.rowSections[:0]
+	vd.headerRows = make(map[string]int)
+
 	row := 0
 
+	for _, section := range vd.sections {
+		collapsed := vd.collapsed[section.id]
+
+		indicator := theme.Icon("▼", "-")
+		if collapsed {
+			indicator = theme.Icon("▶", "+")
+		}
+
+		vd.headerRows[section.id] = row
+		vd.SetCell(row, 0, tview.NewTableCell(indicator+" "+theme.Label(section.icon, section.title)).
+			SetTextColor(theme.Colors.Title).
+			SetAttributes(tcell.AttrBold))
+		vd.SetCell(row, 1, tview.NewTableCell(section.summary(vd, vm)).SetTextColor(theme.Colors.Secondary))
+
+		row++
+
+		if !collapsed {
+			row = section.render(vd, vm, row)
+		}
+
+		for len(vd.rowSections) < row {
+			vd.rowSections = append(vd.rowSections, section.id)
+		}
+	}
+
+	if resetScroll || selectedSection == "" {
+		vd.Select(0, 0)
+		vd.ScrollToBeginning()
+
+		return
+	}
+
+	// Keep the selected row if it still belongs to the same section,
+	// otherwise fall back to the section header.
+	if selectedRow < len(vd.rowSections) && vd.rowSections[selectedRow] == selectedSection {
+		vd.Select(selectedRow, 0)
+	} else if headerRow, ok := vd.headerRows[selectedSection]; ok {
+		vd.Select(headerRow, 0)
+	}
+}
+
+// SelectedValue returns the label and value of the selected row.
+func (vd *VMDetails) SelectedValue() (string, string) {
+	return tableRowValue(vd.Table)
+}
+
+// selectedSection returns the section of the currently selected row.
+func (vd *VMDetails) selectedSection() string {
+	row, _ := vd.GetSelection()
+	if row >= 0 && row < len(vd.rowSections) {
+		return vd.rowSections[row]
+	}
+
+	return ""
+}
+
+// toggleSelectedSection collapses or expands the section containing the selected row.
+func (vd *VMDetails) toggleSelectedSection() {
+	section := vd.selectedSection()
+	if section == "" {
+		return
+	}
+
+	vd.collapsed[section] = !vd.collapsed[section]
+	vd.loadExpandedHistory()
+	vd.Select(vd.headerRows[section], 0)
+	vd.render(false)
+}
+
+// setAllCollapsed collapses or expands every section.
+func (vd *VMDetails) setAllCollapsed(collapsed bool) {
+	for _, section := range vd.sections {
+		vd.collapsed[section.id] = collapsed
+	}
+
+	vd.loadExpandedHistory()
+	vd.render(false)
+}
+
+// jumpSection moves the selection to the header of the next (dir > 0) or previous section.
+func (vd *VMDetails) jumpSection(dir int) {
+	current := vd.selectedSection()
+
+	idx := 0
+
+	for i, section := range vd.sections {
+		if section.id == current {
+			idx = i
+
+			break
+		}
+	}
+
+	idx = (idx + dir + len(vd.sections)) % len(vd.sections)
+
+	if headerRow, ok := vd.headerRows[vd.sections[idx].id]; ok {
+		vd.Select(headerRow, 0)
+	}
+}
+
+// renderOverview draws the basic guest information and resource usage.
+func (vd *VMDetails) renderOverview(vm *api.VM, row int) int {
 	// Basic Info
-	vd.SetCell(row, 0, tview.NewTableCell("🆔 ID").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🆔", "ID")).SetTextColor(theme.Colors.HeaderText))
 	vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d", vm.ID)).SetTextColor(theme.Colors.Primary))
 
 	row++
 
-	vd.SetCell(row, 0, tview.NewTableCell("📛 Name").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("📛", "Name")).SetTextColor(theme.Colors.HeaderText))
 	vd.SetCell(row, 1, tview.NewTableCell(vm.Name).SetTextColor(theme.Colors.Primary))
 
 	row++
 
-	// Show description if available
-	if vm.Description != "" {
-		cleanDesc := sanitizeDescription(vm.Description)
-		if cleanDesc != "" {
-			vd.SetCell(row, 0, tview.NewTableCell("📝 Description").SetTextColor(theme.Colors.HeaderText))
-			vd.SetCell(row, 1, tview.NewTableCell(cleanDesc).SetTextColor(theme.Colors.Info))
+	// Notes (description) rendered from markdown
+	row = renderNotes(vd.Table, "  ", vm.Description, row)
 
-			row++
-		}
+	// Metadata parsed from tags and notes
+	if metadata := models.GuestMetadata(vm); len(metadata) > 0 {
+		vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🏷️", "Metadata")).SetTextColor(theme.Colors.HeaderText))
+		vd.SetCell(row, 1, tview.NewTableCell(tview.Escape(models.FormatGuestMetadata(vm, models.SortedMetadataKeys(metadata)))).
+			SetTextColor(theme.Colors.Info))
+
+		row++
 	}
 
-	vd.SetCell(row, 0, tview.NewTableCell("📍 Node").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("📍", "Node")).SetTextColor(theme.Colors.HeaderText))
 	vd.SetCell(row, 1, tview.NewTableCell(vm.Node).SetTextColor(theme.Colors.Primary))
 
 	row++
 
-	vd.SetCell(row, 0, tview.NewTableCell("📦 Type").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("📦", "Type")).SetTextColor(theme.Colors.HeaderText))
 	vd.SetCell(row, 1, tview.NewTableCell(strings.ToUpper(vm.Type)).SetTextColor(theme.Colors.Primary))
 
 	row++
@@ -114,13 +349,35 @@ func (vd *VMDetails) Update(vm *api.VM) {
 		statusColor = theme.Colors.StatusPending
 	}
 
-	vd.SetCell(row, 0, tview.NewTableCell(statusEmoji+" Status").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label(statusEmoji, "Status")).SetTextColor(theme.Colors.HeaderText))
 	vd.SetCell(row, 1, tview.NewTableCell(statusText).SetTextColor(statusColor))
 
 	row++
 
+	if vm.Lock != "" {
+		vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🔒", "Lock")).SetTextColor(theme.Colors.HeaderText))
+		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%s (%s)", vm.Lock, api.LockReason(vm.Lock))).SetTextColor(theme.Colors.Warning))
+
+		row++
+	}
+
+	// Last backup across all backup storages, once collected
+	if vd.app != nil {
+		if text, overdue, ok := vd.app.lastBackupText(vm); ok {
+			color := theme.Colors.Primary
+			if overdue {
+				color = theme.Colors.Warning
+			}
+
+			vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🗄️", "Last Backup")).SetTextColor(theme.Colors.HeaderText))
+			vd.SetCell(row, 1, tview.NewTableCell(text).SetTextColor(color))
+
+			row++
+		}
+	}
+
 	// Tags (if set)
-	vd.SetCell(row, 0, tview.NewTableCell("🏷️ Tags").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🏷️", "Tags")).SetTextColor(theme.Colors.HeaderText))
 
 	if vm.Tags != "" {
 		vd.SetCell(row, 1, tview.NewTableCell(vm.Tags).SetTextColor(theme.Colors.Info))
@@ -131,30 +388,34 @@ func (vd *VMDetails) Update(vm *api.VM) {
 	row++
 
 	// IP Address
-	vd.SetCell(row, 0, tview.NewTableCell("📡 IP").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("📡", "IP")).SetTextColor(theme.Colors.HeaderText))
 
 	ipValue := api.StringNA
 	if vm.IP != "" {
 		ipValue = vm.IP
 	}
 
+	if models.IPFromNeighbors(vm) {
+		ipValue += " (ARP)"
+	}
+
 	vd.SetCell(row, 1, tview.NewTableCell(ipValue).SetTextColor(theme.Colors.Primary))
 
 	row++
 
 	// CPU Usage
-	vd.SetCell(row, 0, tview.NewTableCell("🧮 CPU").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🧮", "CPU")).SetTextColor(theme.Colors.HeaderText))
 
 	cpuValue := api.StringNA
 	cpuUsageColor := theme.Colors.Primary
 
 	if vm.CPU >= 0 && vm.CPUCores > 0 {
 		cpuPercent := vm.CPU * 100
-		cpuValue = fmt.Sprintf("%.1f%% of %d cores", cpuPercent, vm.CPUCores)
+		cpuValue = usageGaugeText(cpuPercent, fmt.Sprintf("%.1f%% of %d cores", cpuPercent, vm.CPUCores))
 		cpuUsageColor = theme.GetUsageColor(cpuPercent)
 	} else if vm.CPU >= 0 {
 		cpuPercent := vm.CPU * 100
-		cpuValue = fmt.Sprintf("%.1f%%", cpuPercent)
+		cpuValue = usageGaugeText(cpuPercent, fmt.Sprintf("%.1f%%", cpuPercent))
 		cpuUsageColor = theme.GetUsageColor(cpuPercent)
 	}
 
@@ -162,7 +423,7 @@ func (vd *VMDetails) Update(vm *api.VM) {
 
 	row++
 
-	vd.SetCell(row, 0, tview.NewTableCell("🧠 Memory").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🧠", "Memory")).SetTextColor(theme.Colors.HeaderText))
 
 	memValue := api.StringNA
 	memUsageColor := theme.Colors.Primary
@@ -171,7 +432,7 @@ func (vd *VMDetails) Update(vm *api.VM) {
 		memUsedFormatted := utils.FormatBytes(vm.Mem)
 		memTotalFormatted := utils.FormatBytes(vm.MaxMem)
 		memoryPercent := utils.CalculatePercentageInt(vm.Mem, vm.MaxMem)
-		memValue = fmt.Sprintf("%.2f%% (%s) / %s", memoryPercent, memUsedFormatted, memTotalFormatted)
+		memValue = usageGaugeText(memoryPercent, fmt.Sprintf("%.2f%% (%s) / %s", memoryPercent, memUsedFormatted, memTotalFormatted))
 		memUsageColor = theme.GetUsageColor(memoryPercent)
 	}
 
@@ -179,7 +440,7 @@ func (vd *VMDetails) Update(vm *api.VM) {
 
 	row++
 
-	vd.SetCell(row, 0, tview.NewTableCell("💾 Disk").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("💾", "Disk")).SetTextColor(theme.Colors.HeaderText))
 
 	diskValue := api.StringNA
 	diskUsageColor := theme.Colors.Primary
@@ -188,7 +449,7 @@ func (vd *VMDetails) Update(vm *api.VM) {
 		diskUsedFormatted := utils.FormatBytes(vm.Disk)
 		diskTotalFormatted := utils.FormatBytes(vm.MaxDisk)
 		diskPercent := utils.CalculatePercentageInt(vm.Disk, vm.MaxDisk)
-		diskValue = fmt.Sprintf("%.2f%% (%s) / %s", diskPercent, diskUsedFormatted, diskTotalFormatted)
+		diskValue = usageGaugeText(diskPercent, fmt.Sprintf("%.2f%% (%s) / %s", diskPercent, diskUsedFormatted, diskTotalFormatted))
 		diskUsageColor = theme.GetUsageColor(diskPercent)
 	}
 
@@ -196,22 +457,43 @@ func (vd *VMDetails) Update(vm *api.VM) {
 
 	row++
 
-	vd.SetCell(row, 0, tview.NewTableCell("🕒 Uptime").SetTextColor(theme.Colors.HeaderText))
+	samples := models.GuestMetrics(vm)
+	if history := usageHistoryText(samples); history != "" {
+		vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("📈", "History")).SetTextColor(theme.Colors.HeaderText))
+		vd.SetCell(row, 1, tview.NewTableCell(history).SetTextColor(theme.Colors.Info))
+
+		row++
+	}
+
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🕒", "Uptime")).SetTextColor(theme.Colors.HeaderText))
 
 	uptimeValue := api.StringNA
+	uptimeColor := theme.Colors.Primary
+
 	if vm.Uptime > 0 {
-		uptimeValue = utils.FormatUptime(int(vm.Uptime))
+		bootTime := time.Now().Add(-time.Duration(vm.Uptime) * time.Second)
+		uptimeValue = fmt.Sprintf("%s (booted %s)", utils.FormatUptime(int(vm.Uptime)), bootTime.Format("2006-01-02 15:04"))
+
+		if detected, ok := models.RestartedAt(vm); ok {
+			uptimeValue += fmt.Sprintf(", restart detected at %s", detected.Format("15:04"))
+			uptimeColor = theme.Colors.Warning
+		}
 	}
 
-	vd.SetCell(row, 1, tview.NewTableCell(uptimeValue).SetTextColor(theme.Colors.Primary))
+	vd.SetCell(row, 1, tview.NewTableCell(uptimeValue).SetTextColor(uptimeColor))
 
 	row++
 
 	// Network IO summary
-	vd.SetCell(row, 0, tview.NewTableCell("🔃 Network IO").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🔃", "Network IO")).SetTextColor(theme.Colors.HeaderText))
 
 	if vm.NetIn > 0 || vm.NetOut > 0 {
-		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("In: %s, Out: %s", utils.FormatBytes(vm.NetIn), utils.FormatBytes(vm.NetOut))).SetTextColor(theme.Colors.Primary))
+		netValue := fmt.Sprintf("In: %s, Out: %s", utils.FormatBytes(vm.NetIn), utils.FormatBytes(vm.NetOut))
+		if history := rateHistoryText(samples, func(s models.MetricSample) float64 { return s.NetIn + s.NetOut }); history != "" {
+			netValue += "  " + history
+		}
+
+		vd.SetCell(row, 1, tview.NewTableCell(netValue).SetTextColor(theme.Colors.Primary))
 	} else {
 		vd.SetCell(row, 1, tview.NewTableCell(api.StringNA).SetTextColor(theme.Colors.Secondary))
 	}
@@ -219,10 +501,15 @@ func (vd *VMDetails) Update(vm *api.VM) {
 	row++
 
 	// Disk IO summary
-	vd.SetCell(row, 0, tview.NewTableCell("🔄 Disk IO").SetTextColor(theme.Colors.HeaderText))
+	vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🔄", "Disk IO")).SetTextColor(theme.Colors.HeaderText))
 
 	if vm.DiskRead > 0 || vm.DiskWrite > 0 {
-		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("Read: %s, Write: %s", utils.FormatBytes(vm.DiskRead), utils.FormatBytes(vm.DiskWrite))).SetTextColor(theme.Colors.Primary))
+		diskIOValue := fmt.Sprintf("Read: %s, Write: %s", utils.FormatBytes(vm.DiskRead), utils.FormatBytes(vm.DiskWrite))
+		if history := rateHistoryText(samples, func(s models.MetricSample) float64 { return s.DiskRead + s.DiskWrite }); history != "" {
+			diskIOValue += "  " + history
+		}
+
+		vd.SetCell(row, 1, tview.NewTableCell(diskIOValue).SetTextColor(theme.Colors.Primary))
 	} else {
 		vd.SetCell(row, 1, tview.NewTableCell(api.StringNA).SetTextColor(theme.Colors.Secondary))
 	}
@@ -231,7 +518,7 @@ func (vd *VMDetails) Update(vm *api.VM) {
 
 	// Guest Agent (QEMU only)
 	if vm.Type == api.VMTypeQemu {
-		vd.SetCell(row, 0, tview.NewTableCell("🤖 Guest Agent").SetTextColor(theme.Colors.HeaderText))
+		vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("🤖", "Guest Agent")).SetTextColor(theme.Colors.HeaderText))
 
 		agentStatus := "Not enabled"
 		agentColor := theme.Colors.Secondary
@@ -251,54 +538,18 @@ func (vd *VMDetails) Update(vm *api.VM) {
 		row++
 	}
 
-	// Filesystems (detailed storage breakdown)
-	if len(vm.Filesystems) > 0 {
-		vd.SetCell(row, 0, tview.NewTableCell("📂 Filesystems").SetTextColor(theme.Colors.HeaderText))
-		vd.SetCell(row, 1, tview.NewTableCell("").SetTextColor(theme.Colors.Primary))
-
-		row++
-
-		for _, fs := range vm.Filesystems {
-			fsName := fs.Mountpoint
-			if fsName == "" {
-				fsName = getFriendlyFilesystemName(fs)
-			}
-
-			var usedPercent float64
-			if fs.TotalBytes > 0 {
-				usedPercent = float64(fs.UsedBytes) / float64(fs.TotalBytes) * 100
-			} else {
-				usedPercent = 0
-			}
-
-			usageColor := theme.GetUsageColor(usedPercent)
-			vd.SetCell(row, 0, tview.NewTableCell("  • "+fsName).SetTextColor(theme.Colors.Info))
-			vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.2f%% (%s/%s)%s",
-				usedPercent,
-				utils.FormatBytes(fs.UsedBytes),
-				utils.FormatBytes(fs.TotalBytes),
-				func() string {
-					if fs.Type != "" {
-						return " [" + fs.Type + "]"
-					}
-					return ""
-				}(),
-			)).SetTextColor(usageColor))
+	// Containers have no guest agent; their stats are read over SSH
+	row = vd.renderContainerStats(vm, row)
 
-			row++
-		}
-	}
+	return row
+}
 
+// renderNetwork draws the merged configured and guest agent network interfaces.
+func (vd *VMDetails) renderNetwork(vm *api.VM, row int) int {
 	// Detailed Network Interfaces (merged config + guest agent)
 	enhancedNetworks := mergeNetworkInterfaces(vm.ConfiguredNetworks, vm.NetInterfaces)
 
-	vd.SetCell(row, 0, tview.NewTableCell("🌐 Network Interfaces").SetTextColor(theme.Colors.HeaderText))
-
 	if len(enhancedNetworks) > 0 {
-		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d interface(s)", len(enhancedNetworks))).SetTextColor(theme.Colors.Primary))
-
-		row++
-
 		for _, net := range enhancedNetworks {
 			// Interface name with model/type and status
 			interfaceText := ""
@@ -313,9 +564,9 @@ func (vd *VMDetails) Update(vm *api.VM) {
 			// Add status indicator if we have guest agent data
 			if net.HasGuestAgent {
 				if net.IsUp {
-					interfaceText += " 🟢"
+					interfaceText += " " + theme.Icon("🟢", "(up)")
 				} else {
-					interfaceText += " 🔴"
+					interfaceText += " " + theme.Icon("🔴", "(down)")
 				}
 			}
 			// Mark guest-only interfaces
@@ -388,79 +639,141 @@ func (vd *VMDetails) Update(vm *api.VM) {
 			}
 		}
 	} else {
-		vd.SetCell(row, 1, tview.NewTableCell(api.StringNA).SetTextColor(theme.Colors.Secondary))
+		vd.SetCell(row, 0, tview.NewTableCell("  No network interfaces").SetTextColor(theme.Colors.Secondary))
 
 		row++
 	}
 
-	// Storage Devices (from config)
-	if len(vm.StorageDevices) > 0 {
-		vd.SetCell(row, 0, tview.NewTableCell("💽 Storage Devices").SetTextColor(theme.Colors.HeaderText))
-		vd.SetCell(row, 1, tview.NewTableCell("").SetTextColor(theme.Colors.Primary))
-
-		row++
+	return row
+}
 
-		for _, storage := range vm.StorageDevices {
-			deviceText := storage.Device
-			if storage.Size != "" {
-				deviceText += fmt.Sprintf(" (%s)", storage.Size)
+// renderFilesystems draws the filesystems reported by the guest agent.
+func (vd *VMDetails) renderFilesystems(vm *api.VM, row int) int {
+	// Filesystems (detailed storage breakdown)
+	if len(vm.Filesystems) > 0 {
+		for _, fs := range vm.Filesystems {
+			fsName := fs.Mountpoint
+			if fsName == "" {
+				fsName = getFriendlyFilesystemName(fs)
 			}
 
-			vd.SetCell(row, 0, tview.NewTableCell("  • "+deviceText).SetTextColor(theme.Colors.Info))
-
-			storageText := storage.Storage
-			if storage.Format != "" {
-				storageText += fmt.Sprintf(" [%s]", storage.Format)
+			var usedPercent float64
+			if fs.TotalBytes > 0 {
+				usedPercent = float64(fs.UsedBytes) / float64(fs.TotalBytes) * 100
+			} else {
+				usedPercent = 0
 			}
 
-			vd.SetCell(row, 1, tview.NewTableCell(storageText).SetTextColor(theme.Colors.Secondary))
+			usageColor := theme.GetUsageColor(usedPercent)
+			vd.SetCell(row, 0, tview.NewTableCell("  • "+fsName).SetTextColor(theme.Colors.Info))
+			vd.SetCell(row, 1, tview.NewTableCell(usageGaugeText(usedPercent, fmt.Sprintf("%.2f%% (%s/%s)%s",
+				usedPercent,
+				utils.FormatBytes(fs.UsedBytes),
+				utils.FormatBytes(fs.TotalBytes),
+				func() string {
+					if fs.Type != "" {
+						return " [" + fs.Type + "]"
+					}
+					return ""
+				}(),
+			))).SetTextColor(usageColor))
 
 			row++
+		}
+	} else {
+		message := "Not available (guest agent not running)"
+		if vm.Type == api.VMTypeLXC {
+			message = "Not reported for containers"
+		}
 
-			var options []string
-			if storage.Cache != "" {
-				options = append(options, fmt.Sprintf("Cache: %s", storage.Cache))
-			}
+		vd.SetCell(row, 0, tview.NewTableCell("  "+message).SetTextColor(theme.Colors.Secondary))
 
-			if storage.IOThread {
-				options = append(options, "IOThread")
-			}
+		row++
+	}
 
-			if storage.SSD {
-				options = append(options, "SSD")
-			}
+	return row
+}
 
-			if storage.Discard != "" {
-				options = append(options, fmt.Sprintf("Discard: %s", storage.Discard))
-			}
+// firmwareNames are the display names of the QEMU firmware options.
+var firmwareNames = map[string]string{
+	"seabios": "SeaBIOS",
+	"ovmf":    "OVMF (UEFI)",
+}
 
-			if storage.Serial != "" {
-				options = append(options, fmt.Sprintf("Serial: %s", storage.Serial))
-			}
+// renderHardware draws the machine type, CPU model, firmware and display of a
+// QEMU VM. Unset values show QEMU's default.
+func (vd *VMDetails) renderHardware(vm *api.VM, row int) int {
+	machineText := hardwareValue(vm.MachineType, api.DefaultMachineType)
+	machineColor := theme.Colors.Primary
 
-			if !storage.Backup {
-				options = append(options, "No Backup")
-			}
+	if api.MachineTypeDeprecated(vm.MachineType) {
+		version, _ := api.MachineVersion(vm.MachineType)
+		machineText += fmt.Sprintf(" %s version %s is deprecated by QEMU", theme.Icon("⚠️", "!"), version)
+		machineColor = theme.Colors.Warning
+	}
 
-			if storage.Replicate {
-				options = append(options, "Replicate")
-			}
+	firmware := vm.BIOS
+	if name, ok := firmwareNames[firmware]; ok {
+		firmware = name
+	}
 
-			if len(options) > 0 {
-				vd.SetCell(row, 0, tview.NewTableCell("").SetTextColor(theme.Colors.Info))
-				vd.SetCell(row, 1, tview.NewTableCell(strings.Join(options, ", ")).SetTextColor(theme.Colors.Secondary))
+	rows := []struct {
+		label string
+		value string
+		color tcell.Color
+	}{
+		{"Machine", machineText, machineColor},
+		{"CPU Type", hardwareValue(vm.CPUModel, api.DefaultCPUModel), theme.Colors.Primary},
+		{"Firmware", hardwareValue(firmware, firmwareNames[api.DefaultBIOS]), theme.Colors.Primary},
+		{"Display", hardwareValue(displayText(vm.Display), api.DefaultDisplay), theme.Colors.Primary},
+	}
 
-				row++
-			}
+	for _, hw := range rows {
+		vd.SetCell(row, 0, tview.NewTableCell("  • "+hw.label).SetTextColor(theme.Colors.Info))
+		vd.SetCell(row, 1, tview.NewTableCell(hw.value).SetTextColor(hw.color))
+
+		row++
+	}
+
+	return row
+}
+
+// hardwareValue returns value, or the default marked as such.
+func hardwareValue(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue + " (default)"
+	}
+
+	return value
+}
+
+// displayText formats a vga setting like "qxl,memory=32" as "qxl (32 MiB)".
+func displayText(vga string) string {
+	if vga == "" {
+		return ""
+	}
+
+	parts := strings.Split(vga, ",")
+
+	var options []string
+
+	for _, option := range parts[1:] {
+		if memory, found := strings.CutPrefix(option, "memory="); found {
+			option = memory + " MiB"
 		}
+
+		options = append(options, option)
 	}
 
-	// Configuration Section
-	vd.SetCell(row, 0, tview.NewTableCell("🔨 Configuration").SetTextColor(theme.Colors.HeaderText))
-	vd.SetCell(row, 1, tview.NewTableCell("").SetTextColor(theme.Colors.Primary))
+	if len(options) == 0 {
+		return parts[0]
+	}
 
-	row++
+	return fmt.Sprintf("%s (%s)", parts[0], strings.Join(options, ", "))
+}
 
+// renderConfig draws the hardware configuration and storage devices.
+func (vd *VMDetails) renderConfig(vm *api.VM, row int) int {
 	// CPU Configuration (always show)
 	cpuText := api.StringNA
 	if vm.CPUCores > 0 && vm.CPUSockets > 0 {
@@ -491,6 +804,10 @@ func (vd *VMDetails) Update(vm *api.VM) {
 
 	row++
 
+	if vm.Type == api.VMTypeQemu {
+		row = vd.renderHardware(vm, row)
+	}
+
 	// Boot Order
 	if vm.BootOrder != "" {
 		vd.SetCell(row, 0, tview.NewTableCell("  • Boot Order").SetTextColor(theme.Colors.Info))
@@ -511,5 +828,95 @@ func (vd *VMDetails) Update(vm *api.VM) {
 	vd.SetCell(row, 0, tview.NewTableCell("  • Auto-start").SetTextColor(theme.Colors.Info))
 	vd.SetCell(row, 1, tview.NewTableCell(autoStartText).SetTextColor(autoStartColor))
 
-	vd.ScrollToBeginning()
+	row++
+
+	// Storage Devices (from config)
+	if len(vm.StorageDevices) > 0 {
+		vd.SetCell(row, 0, tview.NewTableCell("  "+theme.Label("💽", "Storage Devices")).SetTextColor(theme.Colors.Info))
+		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d device(s)", len(vm.StorageDevices))).SetTextColor(theme.Colors.Primary))
+
+		row++
+
+		for _, storage := range vm.StorageDevices {
+			deviceText := storage.Device
+			if storage.Size != "" {
+				deviceText += fmt.Sprintf(" (%s)", storage.Size)
+			}
+
+			vd.SetCell(row, 0, tview.NewTableCell("    • "+deviceText).SetTextColor(theme.Colors.Info))
+
+			storageText := storage.Storage
+			if storage.Format != "" {
+				storageText += fmt.Sprintf(" [%s]", storage.Format)
+			}
+
+			// Copy the full volume ID (e.g. local-lvm:vm-100-disk-0) rather than the displayed text
+			volID := storage.VolID
+			if volID == "" {
+				volID = storage.Storage
+			}
+
+			vd.SetCell(row, 1, tview.NewTableCell(storageText).SetTextColor(theme.Colors.Secondary).SetReference(volID))
+
+			row++
+
+			var options []string
+			if storage.Cache != "" {
+				options = append(options, fmt.Sprintf("Cache: %s", storage.Cache))
+			}
+
+			if storage.IOThread {
+				options = append(options, "IOThread")
+			}
+
+			if storage.SSD {
+				options = append(options, "SSD")
+			}
+
+			if storage.Discard != "" {
+				options = append(options, fmt.Sprintf("Discard: %s", storage.Discard))
+			}
+
+			if storage.Serial != "" {
+				options = append(options, fmt.Sprintf("Serial: %s", storage.Serial))
+			}
+
+			if !storage.Backup {
+				options = append(options, "No Backup")
+			}
+
+			if storage.Replicate {
+				options = append(options, "Replicate")
+			}
+
+			if len(options) > 0 {
+				vd.SetCell(row, 0, tview.NewTableCell("").SetTextColor(theme.Colors.Info))
+				vd.SetCell(row, 1, tview.NewTableCell(strings.Join(options, ", ")).SetTextColor(theme.Colors.Secondary))
+
+				row++
+			}
+		}
+	}
+
+	return row
+}
+
+func overviewSummary(_ *VMDetails, vm *api.VM) string {
+	return fmt.Sprintf("%s (%d)", vm.Name, vm.ID)
+}
+
+func networkSummary(_ *VMDetails, vm *api.VM) string {
+	return fmt.Sprintf("%d interface(s)", len(mergeNetworkInterfaces(vm.ConfiguredNetworks, vm.NetInterfaces)))
+}
+
+func filesystemsSummary(_ *VMDetails, vm *api.VM) string {
+	if len(vm.Filesystems) == 0 {
+		return api.StringNA
+	}
+
+	return fmt.Sprintf("%d filesystem(s)", len(vm.Filesystems))
+}
+
+func configSummary(_ *VMDetails, vm *api.VM) string {
+	return fmt.Sprintf("%d disk(s)", len(vm.StorageDevices))
 }
@@ -0,0 +1,166 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// containerStatsTTL is how long container stats read over SSH are reused
+// before they are read again.
+const containerStatsTTL = 30 * time.Second
+
+// containerStatsReading caches the stats of a container read over SSH.
+type containerStatsReading struct {
+	loading bool
+	loaded  bool
+	stats   api.ContainerStats
+	err     error
+	fetched time.Time
+}
+
+// containerStats returns the stats of a running container read over SSH if
+// enabled, starting a background read when the cached reading is missing or
+// stale.
+func (vd *VMDetails) containerStats(vm *api.VM) *containerStatsReading {
+	if vd.app == nil || !vd.app.config.Enrichment.LXCSSH || vd.app.config.SSHUser == "" ||
+		vm.Type != api.VMTypeLXC || vm.Status != api.VMStatusRunning {
+		return nil
+	}
+
+	var nodeIP string
+
+	if vd.app.client != nil && vd.app.client.Cluster != nil {
+		for _, node := range vd.app.client.Cluster.Nodes {
+			if node != nil && node.Name == vm.Node {
+				nodeIP = vd.app.nodeAddress(node)
+
+				break
+			}
+		}
+	}
+
+	if nodeIP == "" {
+		return nil
+	}
+
+	if vd.containerReadings == nil {
+		vd.containerReadings = make(map[string]*containerStatsReading)
+	}
+
+	key := fmt.Sprintf("%s:%d", vm.Node, vm.ID)
+
+	reading, ok := vd.containerReadings[key]
+	if !ok {
+		reading = &containerStatsReading{}
+		vd.containerReadings[key] = reading
+	}
+
+	if !reading.loading && (!reading.loaded || time.Since(reading.fetched) > containerStatsTTL) {
+		reading.loading = true
+
+		go vd.readContainerStats(vm.Node, vm.ID, vd.app.config.SSHUser, nodeIP, reading)
+	}
+
+	return reading
+}
+
+// readContainerStats reads the stats of a container over SSH and redraws
+// the panel if the container is still shown.
+func (vd *VMDetails) readContainerStats(node string, vmID int, user, ip string, reading *containerStatsReading) {
+	output, err := ssh.ReadContainerStats(vd.app.ctx, ssh.NewDefaultExecutor(), user, ip, vmID, ssh.NodeOptions())
+
+	var stats api.ContainerStats
+	if err == nil {
+		stats, err = api.ParseContainerStats(output)
+	}
+
+	vd.app.QueueUpdateDraw(func() {
+		reading.loading, reading.loaded = false, true
+		reading.stats, reading.err, reading.fetched = stats, err, time.Now()
+
+		if err != nil {
+			vd.app.logger.Debug("Failed to read stats of container %d on %s: %v", vmID, node, err)
+		}
+
+		if vd.vm != nil && vd.vm.ID == vmID && vd.vm.Node == node {
+			vd.render(false)
+		}
+	})
+}
+
+// renderContainerStats draws the stats of a running container read over
+// SSH, returning the next free row. Nothing is drawn when they are not
+// enabled.
+func (vd *VMDetails) renderContainerStats(vm *api.VM, row int) int {
+	reading := vd.containerStats(vm)
+	if reading == nil {
+		return row
+	}
+
+	label := func(icon, text string) *tview.TableCell {
+		return tview.NewTableCell("  " + theme.Label(icon, text)).SetTextColor(theme.Colors.HeaderText)
+	}
+
+	switch {
+	case !reading.loaded:
+		vd.SetCell(row, 0, label("📈", "Load"))
+		vd.SetCell(row, 1, tview.NewTableCell("Loading...").SetTextColor(theme.Colors.Secondary))
+
+		return row + 1
+	case reading.err != nil:
+		vd.SetCell(row, 0, label("📈", "Load"))
+		vd.SetCell(row, 1, tview.NewTableCell("Unavailable over SSH").SetTextColor(theme.Colors.Secondary))
+
+		return row + 1
+	}
+
+	stats := reading.stats
+
+	if stats.HasLoad {
+		vd.SetCell(row, 0, label("📈", "Load"))
+		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.2f, %.2f, %.2f", stats.Load[0], stats.Load[1], stats.Load[2])).
+			SetTextColor(theme.Colors.Primary))
+
+		row++
+	}
+
+	if text := formatContainerProcesses(stats); text != "" {
+		vd.SetCell(row, 0, label("⚙️", "Processes"))
+		vd.SetCell(row, 1, tview.NewTableCell(text).SetTextColor(theme.Colors.Primary))
+
+		row++
+	}
+
+	if stats.HasPressure {
+		highest := max(stats.CPUPressure, stats.MemoryPressure, stats.IOPressure)
+
+		vd.SetCell(row, 0, label("⏳", "Pressure"))
+		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("CPU %.1f%%, Memory %.1f%%, IO %.1f%% stalled (10s)",
+			stats.CPUPressure, stats.MemoryPressure, stats.IOPressure)).SetTextColor(theme.GetUsageColor(highest)))
+
+		row++
+	}
+
+	return row
+}
+
+// formatContainerProcesses describes the process and task counts of a
+// container, e.g. "23 (57 tasks)".
+func formatContainerProcesses(stats api.ContainerStats) string {
+	switch {
+	case stats.Processes > 0 && stats.Tasks > 0:
+		return fmt.Sprintf("%d (%d tasks)", stats.Processes, stats.Tasks)
+	case stats.Processes > 0:
+		return fmt.Sprintf("%d", stats.Processes)
+	case stats.Tasks > 0:
+		return fmt.Sprintf("%d tasks", stats.Tasks)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,197 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// historyLoadState tracks lazy loading of the snapshot and backup sections.
+type historyLoadState int
+
+const (
+	historyNotLoaded historyLoadState = iota
+	historyLoading
+	historyLoaded
+)
+
+// vmHistory caches the snapshots and backups of the guest shown in the
+// details panel. They are only fetched once their section is expanded.
+type vmHistory struct {
+	key string
+
+	snapshotsState historyLoadState
+	snapshots      []api.Snapshot
+	snapshotsErr   error
+
+	backupsState historyLoadState
+	backups      []api.Backup
+	backupsErr   error
+
+	// plugins caches plugin sections by section ID
+	plugins map[string]*pluginSectionData
+}
+
+// reset discards cached history when a different guest is selected.
+func (h *vmHistory) reset(vm *api.VM) {
+	*h = vmHistory{key: fmt.Sprintf("%s:%d", vm.Node, vm.ID)}
+}
+
+// loadExpandedHistory starts loading snapshots and backups for expanded sections
+// that have not been loaded for the current guest yet.
+func (vd *VMDetails) loadExpandedHistory() {
+	if vd.vm == nil || vd.app == nil || vd.app.client == nil {
+		return
+	}
+
+	vm := vd.vm
+	key := vd.history.key
+
+	if !vd.collapsed[vmSectionSnapshots] && vd.history.snapshotsState == historyNotLoaded {
+		vd.history.snapshotsState = historyLoading
+
+		go func() {
+			snapshots, err := vd.app.client.GetSnapshots(vm)
+
+			vd.app.QueueUpdateDraw(func() {
+				if vd.history.key != key {
+					return // selection moved on
+				}
+
+				vd.history.snapshots, vd.history.snapshotsErr = filterSnapshots(snapshots), err
+				vd.history.snapshotsState = historyLoaded
+				vd.render(false)
+			})
+		}()
+	}
+
+	if !vd.collapsed[vmSectionBackups] && vd.history.backupsState == historyNotLoaded {
+		vd.history.backupsState = historyLoading
+
+		go func() {
+			backups, err := vd.app.client.GetGuestBackups(vm)
+
+			vd.app.QueueUpdateDraw(func() {
+				if vd.history.key != key {
+					return
+				}
+
+				vd.history.backups, vd.history.backupsErr = backups, err
+				vd.history.backupsState = historyLoaded
+				vd.render(false)
+			})
+		}()
+	}
+
+	vd.loadExpandedPluginSections(vm, key)
+}
+
+// filterSnapshots removes the "current" pseudo-snapshot returned by the API.
+func filterSnapshots(snapshots []api.Snapshot) []api.Snapshot {
+	filtered := make([]api.Snapshot, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		if snapshot.Name != "current" {
+			filtered = append(filtered, snapshot)
+		}
+	}
+
+	return filtered
+}
+
+// renderSnapshots draws the guest snapshots, newest last as returned by the API.
+func (vd *VMDetails) renderSnapshots(_ *api.VM, row int) int {
+	if row, done := vd.renderHistoryState(row, vd.history.snapshotsState, vd.history.snapshotsErr, len(vd.history.snapshots), "No snapshots"); done {
+		return row
+	}
+
+	for _, snapshot := range vd.history.snapshots {
+		name := snapshot.Name
+		if snapshot.VMState {
+			name += " (RAM)"
+		}
+
+		vd.SetCell(row, 0, tview.NewTableCell("  • "+name).SetTextColor(theme.Colors.Info))
+
+		details := snapshot.SnapTime.Format("2006-01-02 15:04")
+		if snapshot.Description != "" {
+			details += " - " + sanitizeDescription(snapshot.Description)
+		}
+
+		vd.SetCell(row, 1, tview.NewTableCell(details).SetTextColor(theme.Colors.Secondary))
+
+		row++
+	}
+
+	return row
+}
+
+// renderBackups draws the guest backups, newest first.
+func (vd *VMDetails) renderBackups(_ *api.VM, row int) int {
+	if row, done := vd.renderHistoryState(row, vd.history.backupsState, vd.history.backupsErr, len(vd.history.backups), "No backups"); done {
+		return row
+	}
+
+	for _, backup := range vd.history.backups {
+		label := backup.CTime.Format("2006-01-02 15:04")
+		if backup.Protected {
+			label += " " + theme.Icon("🔒", "(protected)")
+		}
+
+		vd.SetCell(row, 0, tview.NewTableCell("  • "+label).SetTextColor(theme.Colors.Info))
+		vd.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%s on %s [%s]", utils.FormatBytes(backup.Size), backup.Storage, backup.Format)).SetTextColor(theme.Colors.Secondary))
+
+		row++
+	}
+
+	return row
+}
+
+// renderHistoryState draws a placeholder row while history is loading, failed or empty.
+// It returns done=true if the caller has nothing else to draw.
+func (vd *VMDetails) renderHistoryState(row int, state historyLoadState, err error, count int, emptyText string) (int, bool) {
+	var (
+		text  string
+		color = theme.Colors.Secondary
+	)
+
+	switch {
+	case state != historyLoaded:
+		text = "Loading..."
+	case err != nil:
+		text = fmt.Sprintf("Failed to load: %v", err)
+		color = theme.Colors.Error
+	case count == 0:
+		text = emptyText
+	default:
+		return row, false
+	}
+
+	vd.SetCell(row, 0, tview.NewTableCell("  "+text).SetTextColor(color))
+
+	return row + 1, true
+}
+
+func (vd *VMDetails) snapshotsSummary(_ *api.VM) string {
+	if vd.history.snapshotsState != historyLoaded || vd.history.snapshotsErr != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d snapshot(s)", len(vd.history.snapshots))
+}
+
+func (vd *VMDetails) backupsSummary(_ *api.VM) string {
+	if vd.history.backupsState != historyLoaded || vd.history.backupsErr != nil {
+		return ""
+	}
+
+	if len(vd.history.backups) == 0 {
+		return "0 backup(s)"
+	}
+
+	return fmt.Sprintf("%d backup(s), latest %s", len(vd.history.backups), vd.history.backups[0].CTime.Format("2006-01-02"))
+}
@@ -0,0 +1,112 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+	"github.com/devnullvoid/pvetui/pkg/plugin"
+)
+
+// pluginSectionData caches the rows of a plugin section for the guest
+// shown in the details panel.
+type pluginSectionData struct {
+	state historyLoadState
+	rows  []plugin.Row
+	err   error
+}
+
+// pluginSection is a details section contributed by a plugin.
+type pluginSection struct {
+	plugin  *plugin.Plugin
+	section plugin.Section
+}
+
+// SetPlugins adds the details sections of the plugins after the built-in
+// ones. They start collapsed and are loaded when expanded.
+func (vd *VMDetails) SetPlugins(plugins []*plugin.Plugin) {
+	vd.pluginSections = make(map[string]pluginSection)
+	vd.sections = append([]vmDetailsSection(nil), vmDetailsSections...)
+
+	for _, p := range plugins {
+		for _, section := range p.Manifest.Sections {
+			id := fmt.Sprintf("plugin:%s:%s", p.Name(), section.ID)
+			if _, ok := vd.collapsed[id]; !ok {
+				vd.collapsed[id] = true
+			}
+
+			vd.pluginSections[id] = pluginSection{plugin: p, section: section}
+			vd.sections = append(vd.sections, vmDetailsSection{
+				id:      id,
+				icon:    "🧩",
+				title:   section.Title,
+				render:  pluginSectionRenderer(id),
+				summary: func(*VMDetails, *api.VM) string { return p.Name() },
+			})
+		}
+	}
+
+	vd.history.plugins = nil
+	vd.loadExpandedHistory()
+	vd.render(false)
+}
+
+// loadExpandedPluginSections starts loading expanded plugin sections that
+// have not been loaded for the current guest yet.
+func (vd *VMDetails) loadExpandedPluginSections(vm *api.VM, key string) {
+	for id, ps := range vd.pluginSections {
+		if vd.collapsed[id] || vd.history.plugins[id] != nil {
+			continue
+		}
+
+		if vd.history.plugins == nil {
+			vd.history.plugins = make(map[string]*pluginSectionData)
+		}
+
+		data := &pluginSectionData{state: historyLoading}
+		vd.history.plugins[id] = data
+		guest := pluginGuest(vm)
+
+		go func() {
+			defer crash.Recover()
+
+			rows, err := ps.plugin.Section(vd.app.ctx, ps.section.ID, guest)
+
+			vd.app.QueueUpdateDraw(func() {
+				if vd.history.key != key {
+					return
+				}
+
+				data.rows, data.err = rows, err
+				data.state = historyLoaded
+				vd.render(false)
+			})
+		}()
+	}
+}
+
+// pluginSectionRenderer returns the render function of a plugin section.
+func pluginSectionRenderer(id string) func(vd *VMDetails, vm *api.VM, row int) int {
+	return func(vd *VMDetails, _ *api.VM, row int) int {
+		data := vd.history.plugins[id]
+		if data == nil {
+			data = &pluginSectionData{}
+		}
+
+		if row, done := vd.renderHistoryState(row, data.state, data.err, len(data.rows), "Nothing to show"); done {
+			return row
+		}
+
+		for _, r := range data.rows {
+			vd.SetCell(row, 0, tview.NewTableCell("  "+tview.Escape(r.Label)).SetTextColor(theme.Colors.HeaderText))
+			vd.SetCell(row, 1, tview.NewTableCell(tview.Escape(r.Value)).SetTextColor(theme.Colors.Primary))
+
+			row++
+		}
+
+		return row
+	}
+}
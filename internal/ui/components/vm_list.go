@@ -17,12 +17,17 @@ import (
 type VMList struct {
 	*tview.List
 
-	vms       []*api.VM
+	vms       []*api.VM // Listed guests in display order
+	sorted    []*api.VM // All guests in display order
 	onSelect  func(*api.VM)
 	onChanged func(*api.VM)
 	app       *App
 	// suppressCallbacks prevents onChanged from firing during programmatic updates
 	suppressCallbacks bool
+	// pageSize is the number of guests listed per "load more" step, zero
+	// lists all guests; shown is the number currently listed.
+	pageSize int
+	shown    int
 }
 
 var _ VMListComponent = (*VMList)(nil)
@@ -54,6 +59,45 @@ func (vl *VMList) SetApp(app *App) {
 	vl.SetInputCapture(createNavigationInputCapture(vl.app, nil, vl.app.vmDetails))
 }
 
+// SetPageSize limits the list to pageSize guests followed by a "load more"
+// entry. Zero lists all guests.
+func (vl *VMList) SetPageSize(pageSize int) {
+	if pageSize < 0 {
+		pageSize = 0
+	}
+
+	vl.pageSize = pageSize
+	vl.shown = pageSize
+}
+
+// LoadMore lists the next page of guests.
+func (vl *VMList) LoadMore() {
+	current := vl.GetCurrentItem()
+
+	vl.shown += vl.pageSize
+	vl.SetVMs(vl.sorted)
+	vl.List.SetCurrentItem(current)
+}
+
+// RevealVM extends the list until it includes the given guest and reports
+// whether the guest is in the list at all.
+func (vl *VMList) RevealVM(target *api.VM) bool {
+	for i, vm := range vl.sorted {
+		if vm == nil || vm.ID != target.ID || vm.Node != target.Node {
+			continue
+		}
+
+		if i >= len(vl.vms) {
+			vl.shown = (i/vl.pageSize + 1) * vl.pageSize
+			vl.SetVMs(vl.sorted)
+		}
+
+		return true
+	}
+
+	return false
+}
+
 // SetVMs updates the list with the provided VMs.
 func (vl *VMList) SetVMs(vms []*api.VM) {
 	// Preserve previously selected VM to restore selection after rebuilding
@@ -89,10 +133,16 @@ func (vl *VMList) SetVMs(vms []*api.VM) {
 		return sortedVMs[i].ID < sortedVMs[j].ID
 	})
 
-	// Update the internal vms slice to match the sorted order
+	// Update the internal vms slice to match the sorted order, keeping only
+	// the listed page on large clusters
+	vl.sorted = sortedVMs
 	vl.vms = sortedVMs
 
-	for _, vm := range sortedVMs {
+	if vl.pageSize > 0 && len(sortedVMs) > vl.shown {
+		vl.vms = sortedVMs[:vl.shown]
+	}
+
+	for _, vm := range vl.vms {
 		if vm != nil {
 			// Check if this VM has a pending operation
 			isPending, operation := models.GlobalState.IsVMPending(vm)
@@ -117,6 +167,37 @@ func (vl *VMList) SetVMs(vms []*api.VM) {
 				mainText = statusIndicator + fmt.Sprintf("[primary]%s[-]", vmText)
 			}
 
+			if vm.Template {
+				mainText += " [info]" + theme.Icon("📄", "(template)") + "[-]"
+			}
+
+			if vm.Lock != "" {
+				mainText += " [warning]" + theme.Icon("🔒", "(locked)") + " " + tview.Escape(vm.Lock) + "[-]"
+			}
+
+			// Flag guests that restarted since an earlier refresh
+			if _, restarted := models.RestartedAt(vm); restarted {
+				mainText += " [warning]" + theme.Icon("↻", "(restarted)") + "[-]"
+			}
+
+			// CPU and memory trend from the metrics history
+			if vl.app != nil && vl.app.config.Sparklines && vm.Status == api.VMStatusRunning {
+				if trend := guestTrendText(models.GuestMetrics(vm)); trend != "" {
+					mainText += " " + trend
+				}
+			}
+
+			// Metadata columns configured in the metadata section of the config
+			if vl.app != nil {
+				if metadata := models.FormatGuestMetadata(vm, vl.app.config.Metadata.Columns); metadata != "" {
+					mainText += " [info]" + tview.Escape(metadata) + "[-]"
+				}
+
+				if columns := vl.app.pluginColumnText(vm); columns != "" {
+					mainText += " [info]" + tview.Escape(columns) + "[-]"
+				}
+			}
+
 			mainText = theme.ReplaceSemanticTags(mainText)
 
 			// Store node info in secondary text (not visible but used for search functionality)
@@ -126,17 +207,26 @@ func (vl *VMList) SetVMs(vms []*api.VM) {
 		}
 	}
 
+	if remaining := len(sortedVMs) - len(vl.vms); remaining > 0 {
+		next := min(remaining, vl.pageSize)
+		vl.AddItem(theme.ReplaceSemanticTags(fmt.Sprintf("[secondary]… load %d more (%d of %d listed)[-]",
+			next, len(vl.vms), len(sortedVMs))), "", 0, nil)
+		vl.SetTitle(fmt.Sprintf(" Guests (%d of %d) ", len(vl.vms), len(sortedVMs)))
+	} else {
+		vl.SetTitle(" Guests ")
+	}
+
 	// Restore selection to previously selected VM if present
 	restoreIdx := -1
 	if prevID >= 0 {
-		for i, vm := range sortedVMs {
+		for i, vm := range vl.vms {
 			if vm != nil && vm.ID == prevID && vm.Node == prevNode {
 				restoreIdx = i
 				break
 			}
 		}
 	}
-	if restoreIdx == -1 && len(sortedVMs) > 0 {
+	if restoreIdx == -1 && len(vl.vms) > 0 {
 		restoreIdx = 0
 	}
 	if restoreIdx >= 0 {
@@ -165,6 +255,12 @@ func (vl *VMList) SetVMSelectedFunc(handler func(*api.VM)) {
 	vl.onSelect = handler
 
 	vl.SetSelectedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		if index == len(vl.vms) && len(vl.sorted) > len(vl.vms) {
+			vl.LoadMore()
+
+			return
+		}
+
 		if index >= 0 && index < len(vl.vms) {
 			if vl.onSelect != nil {
 				vl.onSelect(vl.vms[index])
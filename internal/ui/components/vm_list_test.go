@@ -0,0 +1,83 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestVMList_PageSize(t *testing.T) {
+	vms := make([]*api.VM, 0, 5)
+	for id := 105; id >= 101; id-- {
+		vms = append(vms, &api.VM{ID: id, Node: "pve1", Name: "guest", Status: api.VMStatusRunning})
+	}
+
+	list := NewVMList()
+	list.SetPageSize(2)
+	list.SetVMs(vms)
+
+	// Two guests plus the "load more" entry
+	require.Len(t, list.GetVMs(), 2)
+	assert.Equal(t, 101, list.GetVMs()[0].ID)
+	assert.Equal(t, 3, list.GetItemCount())
+	assert.Equal(t, " Guests (2 of 5) ", list.GetTitle())
+
+	list.SetCurrentItem(2)
+	assert.Nil(t, list.GetSelectedVM())
+
+	list.LoadMore()
+	assert.Len(t, list.GetVMs(), 4)
+	assert.Equal(t, 5, list.GetItemCount())
+
+	// Revealing a guest beyond the listed page lists it
+	assert.True(t, list.RevealVM(&api.VM{ID: 105, Node: "pve1"}))
+	assert.Len(t, list.GetVMs(), 5)
+	assert.Equal(t, 5, list.GetItemCount())
+	assert.Equal(t, " Guests ", list.GetTitle())
+
+	assert.False(t, list.RevealVM(&api.VM{ID: 105, Node: "pve2"}))
+}
+
+func TestVMList_NoPageSize(t *testing.T) {
+	list := NewVMList()
+	list.SetVMs([]*api.VM{{ID: 100}, {ID: 101}})
+
+	assert.Len(t, list.GetVMs(), 2)
+	assert.Equal(t, 2, list.GetItemCount())
+}
+
+func TestVMList_Sparklines(t *testing.T) {
+	models.ResetMetrics()
+	t.Cleanup(models.ResetMetrics)
+
+	running := &api.VM{ID: 101, Node: "pve1", Name: "web", Status: api.VMStatusRunning, CPU: 0.1, Mem: 256, MaxMem: 1024}
+	stopped := &api.VM{ID: 102, Node: "pve1", Name: "db", Status: api.VMStatusStopped}
+	start := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	models.RecordMetrics(nil, []*api.VM{running, stopped}, start)
+	running.CPU = 0.9
+	models.RecordMetrics(nil, []*api.VM{running, stopped}, start.Add(10*time.Second))
+
+	app := &App{}
+	list := NewVMList()
+	list.SetApp(app)
+
+	list.SetVMs([]*api.VM{running, stopped})
+	mainText, _ := list.GetItemText(0)
+	assert.NotContains(t, stripTags(mainText), "█")
+
+	app.config.Sparklines = true
+	list.SetVMs([]*api.VM{running, stopped})
+
+	mainText, _ = list.GetItemText(0)
+	assert.Contains(t, stripTags(mainText), "      ▂▇       ▃▃")
+
+	// Stopped guests have no trend
+	mainText, _ = list.GetItemText(1)
+	assert.NotContains(t, stripTags(mainText), "▁")
+}
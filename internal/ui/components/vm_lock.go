@@ -0,0 +1,110 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ssh"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// vmActionUnlock removes the lock of a guest.
+const vmActionUnlock = "Unlock"
+
+// lockBlockedActions are the guest actions Proxmox refuses while the guest
+// is locked.
+var lockBlockedActions = map[string]bool{
+	vmActionEditConfig: true,
+	vmActionEditNotes:  true,
+	vmActionStart:      true,
+	vmActionShutdown:   true,
+	vmActionStop:       true,
+	vmActionRestart:    true,
+	vmActionReset:      true,
+	vmActionMigrate:    true,
+	vmActionMoveDisk:   true,
+	vmActionMoveVolume: true,
+	vmActionTemplate:   true,
+	vmActionRestore:    true,
+	vmActionDelete:     true,
+}
+
+// guestLockConflict returns why the lock of vm blocks action, or nil if it
+// doesn't. Starting a hibernated VM resumes it, so that isn't blocked.
+func guestLockConflict(vm *api.VM, action string) error {
+	if !lockBlockedActions[action] || (action == vmActionStart && vm.Lock == api.LockSuspended) {
+		return nil
+	}
+
+	return api.CheckGuestLock(vm)
+}
+
+// showGuestLocked explains why an action on a locked guest was not started.
+func (a *App) showGuestLocked(err error) {
+	a.showMessageSafe(fmt.Sprintf("%v.\n\nWait for the task to finish (see the Tasks page). If no task is running the lock is stale; remove it with %s (u).",
+		err, vmActionUnlock))
+}
+
+// showUnlockDialog asks before removing the lock of a guest.
+func (a *App) showUnlockDialog(vm *api.VM) {
+	a.showConfirmationDialog(
+		fmt.Sprintf("Remove the %s lock of '%s' (ID: %d)?\n\nOnly do this if no task is running for the guest: %s.",
+			vm.Lock, vm.Name, vm.ID, api.LockReason(vm.Lock)),
+		func() {
+			a.unlockVM(vm)
+		},
+	)
+}
+
+// unlockVM removes the lock of a guest. QEMU VMs are unlocked through the
+// API, which only root@pam may do; containers, and VMs the API refused, are
+// unlocked with `qm unlock` or `pct unlock` over SSH when an SSH user is set.
+func (a *App) unlockVM(vm *api.VM) {
+	sshUser := a.config.SSHUser
+
+	var nodeIP string
+
+	for _, node := range a.client.Cluster.Nodes {
+		if node.Name == vm.Node {
+			nodeIP = a.nodeAddress(node)
+
+			break
+		}
+	}
+
+	a.header.ShowLoading(fmt.Sprintf("Unlocking %s", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		var err error
+
+		if vm.Type == api.VMTypeQemu || sshUser == "" || nodeIP == "" {
+			err = a.client.UnlockVM(vm)
+		}
+
+		if (err != nil || vm.Type != api.VMTypeQemu) && sshUser != "" && nodeIP != "" {
+			if sshErr := ssh.UnlockGuest(a.ctx, ssh.NewDefaultExecutor(), sshUser, nodeIP, vm.Type, vm.ID, ssh.NodeOptions()); sshErr != nil {
+				if err == nil {
+					err = sshErr
+				} else {
+					err = fmt.Errorf("%w; over SSH: %v", err, sshErr)
+				}
+			} else {
+				err = nil
+			}
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.showActionError("Unlocking "+vm.Name+" failed", err)
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Removed the %s lock of %s", vm.Lock, vm.Name))
+			vm.Lock = ""
+			a.refreshVMData(vm)
+		})
+	}()
+}
@@ -0,0 +1,23 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGuestLockConflict(t *testing.T) {
+	backup := &api.VM{Name: "web", Lock: "backup"}
+
+	assert.ErrorContains(t, guestLockConflict(backup, vmActionMigrate), "web is locked (backup)")
+	assert.Error(t, guestLockConflict(backup, vmActionStart))
+	assert.NoError(t, guestLockConflict(backup, vmActionOpenShell))
+	assert.NoError(t, guestLockConflict(backup, vmActionUnlock))
+	assert.NoError(t, guestLockConflict(&api.VM{Name: "web"}, vmActionDelete))
+
+	hibernated := &api.VM{Name: "web", Lock: api.LockSuspended}
+	assert.NoError(t, guestLockConflict(hibernated, vmActionStart))
+	assert.Error(t, guestLockConflict(hibernated, vmActionMigrate))
+}
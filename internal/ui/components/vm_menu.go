@@ -3,6 +3,8 @@ package components
 import (
 	"fmt"
 
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/pkg/api"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -12,8 +14,18 @@ import (
 const (
 	vmActionOpenShell  = "Open Shell"
 	vmActionOpenVNC    = "Open VNC Console"
+	vmActionSerial     = "Serial Console"
+	vmActionOpenWebUI  = "Open in Web UI"
 	vmActionEditConfig = "Edit Configuration"
+	vmActionEditNotes  = "Edit Notes"
+	vmActionExportConf = "Export Config"
+	vmActionCompare    = "Compare Config"
+	vmActionReadFile   = "Read Guest File"
+	vmActionProcesses  = "Top Processes"
+	vmActionServices   = "Services"
+	vmActionNetCheck   = "Ping / Port Check"
 	vmActionSnapshots  = "Manage Snapshots"
+	vmActionGroups     = "Guest Groups"
 	vmActionRefresh    = "Refresh"
 	vmActionStart      = "Start"
 	vmActionShutdown   = "Shutdown"
@@ -21,6 +33,10 @@ const (
 	vmActionRestart    = "Restart"
 	vmActionReset      = "Reset (hard)"
 	vmActionMigrate    = "Migrate"
+	vmActionMoveDisk   = "Move Disk"
+	vmActionMoveVolume = "Move Volume"
+	vmActionTemplate   = "Convert to Template"
+	vmActionRestore    = "Restore Backup"
 	vmActionDelete     = "Delete"
 )
 
@@ -38,6 +54,7 @@ func (a *App) ShowVMContextMenu() {
 	menuItems := []string{
 		vmActionOpenShell,
 		vmActionEditConfig,
+		vmActionEditNotes,
 		vmActionSnapshots,
 		vmActionRefresh,
 	}
@@ -49,28 +66,115 @@ func (a *App) ShowVMContextMenu() {
 	if vm.Status == api.VMStatusRunning {
 		// When running, offer graceful Shutdown, force Stop, and Restart
 		menuItems = append(menuItems, vmActionShutdown, vmActionStop, vmActionRestart)
-		// Hard Reset is QEMU-only
+		// Hard Reset and the serial console are QEMU-only
 		if vm.Type == api.VMTypeQemu {
-			menuItems = append(menuItems, vmActionReset)
+			menuItems = append(menuItems, vmActionReset, vmActionSerial)
 		}
-	} else if vm.Status == api.VMStatusStopped {
+
+		menuItems = append(menuItems, vmActionNetCheck)
+
+		if len(a.guestServices(vm)) > 0 {
+			menuItems = append(menuItems, vmActionServices)
+		}
+
+		// Processes are listed with the QEMU guest agent or pct exec over SSH
+		if (vm.Type == api.VMTypeQemu && vm.AgentEnabled && !api.IsWindowsGuest(vm)) ||
+			(vm.Type == api.VMTypeLXC && a.config.SSHUser != "") {
+			menuItems = append(menuItems, vmActionProcesses)
+		}
+
+		// Files are read through the QEMU guest agent
+		if vm.Type == api.VMTypeQemu && vm.AgentEnabled {
+			menuItems = append(menuItems, vmActionReadFile)
+		}
+	} else if vm.Status == api.VMStatusStopped && !vm.Template {
+		// Templates can't be started, only cloned
 		menuItems = append(menuItems, vmActionStart)
 	}
 
+	if len(models.GuestGroupsOf(a.config.GuestGroups, vm.ID)) > 0 {
+		menuItems = append(menuItems, vmActionGroups)
+	}
+
 	menuItems = append(menuItems, vmActionMigrate)
-	menuItems = append(menuItems, vmActionDelete)
+
+	if vm.Type == api.VMTypeLXC {
+		menuItems = append(menuItems, vmActionMoveVolume)
+	} else {
+		menuItems = append(menuItems, vmActionMoveDisk)
+	}
+
+	if vm.Status == api.VMStatusStopped && !vm.Template {
+		menuItems = append(menuItems, vmActionTemplate)
+	}
+
+	menuItems = append(menuItems, vmActionRestore, vmActionExportConf, vmActionCompare, vmActionOpenWebUI, vmActionDelete)
+
+	if vm.Lock != "" {
+		menuItems = append(menuItems, vmActionUnlock)
+	}
 
 	// Generate letter shortcuts based on menu items
 	shortcuts := generateVMShortcuts(menuItems)
 
+	// User-defined actions follow the built-in ones, numbered 1-9
+	customStart := len(menuItems)
+	customActions := a.config.CustomActions
+
+	for i, action := range customActions {
+		menuItems = append(menuItems, action.Name)
+
+		shortcut := rune(0)
+		if i < 9 {
+			shortcut = rune('1' + i)
+		}
+
+		shortcuts = append(shortcuts, shortcut)
+	}
+
+	// Plugin actions come last and continue the numbering
+	pluginStart := len(menuItems)
+	pluginActions := a.pluginActions()
+
+	for i, entry := range pluginActions {
+		menuItems = append(menuItems, entry.action.Label)
+
+		shortcut := rune(0)
+		if n := len(customActions) + i; n < 9 {
+			shortcut = rune('1' + n)
+		}
+
+		shortcuts = append(shortcuts, shortcut)
+	}
+
 	menu := NewContextMenuWithShortcuts(" Guest Actions ", menuItems, shortcuts, func(index int, action string) {
 		a.CloseContextMenu()
 
+		if index >= pluginStart {
+			a.runPluginAction(pluginActions[index-pluginStart], vm)
+
+			return
+		}
+
+		if index >= customStart {
+			a.runCustomAction(customActions[index-customStart], vm)
+
+			return
+		}
+
+		if err := guestLockConflict(vm, action); err != nil {
+			a.showGuestLocked(err)
+
+			return
+		}
+
 		switch action {
 		case vmActionOpenShell:
 			a.openVMShell()
 		case vmActionOpenVNC:
 			a.openVMVNC()
+		case vmActionOpenWebUI:
+			a.openVMWebUI()
 		case vmActionEditConfig:
 			go func() {
 				cfg, err := a.client.GetVMConfig(vm)
@@ -88,6 +192,8 @@ func (a *App) ShowVMContextMenu() {
 					a.SetFocus(page)
 				})
 			}()
+		case vmActionEditNotes:
+			a.editVMNotes(vm)
 		case vmActionSnapshots:
 			snapshotManager := NewSnapshotManager(a, vm)
 			a.pages.AddPage("snapshots", snapshotManager, true, true)
@@ -110,7 +216,7 @@ func (a *App) ShowVMContextMenu() {
 			)
 		case vmActionStop:
 			a.showConfirmationDialog(
-				fmt.Sprintf("⚠️  Force stop '%s' (ID: %d)?\n\nThis is equivalent to power off and may cause data loss.", vm.Name, vm.ID),
+				fmt.Sprintf("%s  Force stop '%s' (ID: %d)?\n\nThis is equivalent to power off and may cause data loss.", theme.Icon("⚠️", "WARNING:"), vm.Name, vm.ID),
 				func() {
 					a.performVMOperation(vm, a.client.StopVM, "Stopping")
 				},
@@ -125,7 +231,7 @@ func (a *App) ShowVMContextMenu() {
 		case vmActionReset:
 			if vm.Type == api.VMTypeQemu {
 				a.showConfirmationDialog(
-					fmt.Sprintf("⚠️  Hard reset '%s' (ID: %d)?\n\nThis is an immediate reset (like pressing reset) and may cause data loss.", vm.Name, vm.ID),
+					fmt.Sprintf("%s  Hard reset '%s' (ID: %d)?\n\nThis is an immediate reset (like pressing reset) and may cause data loss.", theme.Icon("⚠️", "WARNING:"), vm.Name, vm.ID),
 					func() {
 						a.performVMOperation(vm, a.client.ResetVM, "Resetting")
 					},
@@ -133,12 +239,36 @@ func (a *App) ShowVMContextMenu() {
 			}
 		case vmActionMigrate:
 			a.showMigrationDialog(vm)
+		case vmActionMoveDisk, vmActionMoveVolume:
+			a.showMoveDiskDialog(vm)
+		case vmActionTemplate:
+			a.showConvertToTemplateDialog(vm)
+		case vmActionRestore:
+			a.showRestoreBackupDialog(vm)
+		case vmActionExportConf:
+			a.showExportConfigDialog(vm)
+		case vmActionCompare:
+			a.showGuestComparePicker(vm)
+		case vmActionReadFile:
+			a.showGuestFileBrowser(vm, "")
+		case vmActionProcesses:
+			a.showGuestProcesses(vm)
+		case vmActionServices:
+			a.showGuestServices(vm)
+		case vmActionNetCheck:
+			a.showNetCheck(vm)
+		case vmActionGroups:
+			a.showGuestGroups(models.GuestGroupsOf(a.config.GuestGroups, vm.ID))
+		case vmActionSerial:
+			a.openSerialConsole(vm)
+		case vmActionUnlock:
+			a.showUnlockDialog(vm)
 		case vmActionDelete:
 			if vm.Status == api.VMStatusRunning {
 				a.showDeleteRunningVMDialog(vm)
 			} else {
 				a.showConfirmationDialog(
-					fmt.Sprintf("⚠️  DANGER: Are you sure you want to permanently DELETE VM '%s' (ID: %d)?\n\nThis action is IRREVERSIBLE and will destroy all VM data including disks!", vm.Name, vm.ID),
+					fmt.Sprintf("%s  DANGER: Are you sure you want to permanently DELETE VM '%s' (ID: %d)?\n\nThis action is IRREVERSIBLE and will destroy all VM data including disks!", theme.Icon("⚠️", "WARNING:"), vm.Name, vm.ID),
 					func() {
 						a.performVMDeleteOperation(vm, false)
 					},
@@ -190,6 +320,8 @@ func generateVMShortcuts(menuItems []string) []rune {
 			shortcuts[i] = 's'
 		case vmActionOpenVNC:
 			shortcuts[i] = 'v'
+		case vmActionOpenWebUI:
+			shortcuts[i] = 'w'
 		case vmActionEditConfig:
 			shortcuts[i] = 'e'
 		case vmActionRefresh:
@@ -207,10 +339,36 @@ func generateVMShortcuts(menuItems []string) []rune {
 			shortcuts[i] = 'R'
 		case vmActionMigrate:
 			shortcuts[i] = 'm'
+		case vmActionMoveDisk, vmActionMoveVolume:
+			shortcuts[i] = 'o'
+		case vmActionTemplate:
+			shortcuts[i] = 'T'
+		case vmActionRestore:
+			shortcuts[i] = 'b'
+		case vmActionExportConf:
+			shortcuts[i] = 'E'
+		case vmActionCompare:
+			shortcuts[i] = 'C'
+		case vmActionReadFile:
+			shortcuts[i] = 'f'
+		case vmActionProcesses:
+			shortcuts[i] = 'p'
+		case vmActionServices:
+			shortcuts[i] = 'S'
+		case vmActionNetCheck:
+			shortcuts[i] = 'P'
+		case vmActionSerial:
+			shortcuts[i] = 'l'
 		case vmActionDelete:
 			shortcuts[i] = 'x'
 		case vmActionSnapshots:
 			shortcuts[i] = 'n'
+		case vmActionEditNotes:
+			shortcuts[i] = 'N'
+		case vmActionUnlock:
+			shortcuts[i] = 'u'
+		case vmActionGroups:
+			shortcuts[i] = 'G'
 		default:
 			// Fallback to number if no specific shortcut defined
 			shortcuts[i] = rune('1' + i)
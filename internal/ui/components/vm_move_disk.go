@@ -0,0 +1,241 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// moveDiskTimeout bounds how long we wait for a move disk/volume task to finish.
+const moveDiskTimeout = 2 * time.Hour
+
+// movableStorageDevices returns the guest disks/volumes that can be moved to another storage.
+func movableStorageDevices(vm *api.VM) []api.StorageDevice {
+	var devices []api.StorageDevice
+
+	for _, dev := range vm.StorageDevices {
+		if dev.Media == "cdrom" {
+			continue // ISO images are not movable volumes
+		}
+
+		if dev.Storage == "" || strings.HasPrefix(dev.Storage, "/dev/") || dev.Storage == "none" {
+			continue // passthrough devices are not backed by a storage
+		}
+
+		devices = append(devices, dev)
+	}
+
+	return devices
+}
+
+// moveTargetStorages returns storages on the guest's node that accept guest disks.
+func (a *App) moveTargetStorages(vm *api.VM) []*api.Storage {
+	if a.client.Cluster == nil || a.client.Cluster.StorageManager == nil {
+		return nil
+	}
+
+	contentType := "images"
+	if vm.Type == api.VMTypeLXC {
+		contentType = "rootdir"
+	}
+
+	var storages []*api.Storage
+
+	for _, storage := range a.client.Cluster.StorageManager.AllStorages {
+		if storage == nil || storage.Node != vm.Node {
+			continue
+		}
+
+		if !strings.Contains(storage.Content, contentType) {
+			continue
+		}
+
+		storages = append(storages, storage)
+	}
+
+	return storages
+}
+
+// showMoveDiskDialog displays a dialog for moving a guest disk or volume to another storage.
+func (a *App) showMoveDiskDialog(vm *api.VM) {
+	// Running VMs move their disks, but containers have to be stopped
+	if vm.Type == api.VMTypeLXC && vm.Status != api.VMStatusStopped {
+		a.showMessage(fmt.Sprintf("Stop '%s' before moving its volumes", vm.Name))
+
+		return
+	}
+
+	devices := movableStorageDevices(vm)
+	if len(devices) == 0 {
+		a.showMessage(fmt.Sprintf("No movable disks found for '%s'", vm.Name))
+
+		return
+	}
+
+	storages := a.moveTargetStorages(vm)
+	if len(storages) == 0 {
+		a.showMessage(fmt.Sprintf("No suitable target storage found on node %s", vm.Node))
+
+		return
+	}
+
+	deviceLabels := make([]string, len(devices))
+	for i, dev := range devices {
+		deviceLabels[i] = fmt.Sprintf("%s (%s, %s)", dev.Device, dev.Storage, dev.Size)
+	}
+
+	storageLabels := make([]string, len(storages))
+	for i, storage := range storages {
+		storageLabels[i] = fmt.Sprintf("%s (%s free)", storage.Name, api.FormatBytes(storage.MaxDisk-storage.Disk))
+	}
+
+	diskLabel := "Disk"
+	action := "Move Disk"
+
+	if vm.Type == api.VMTypeLXC {
+		diskLabel = "Volume"
+		action = "Move Volume"
+	}
+
+	selectedDevice := 0
+	selectedStorage := 0
+	deleteSource := false
+
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" %s: %s '%s' (ID: %d) ", action, strings.ToUpper(vm.Type), vm.Name, vm.ID))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	form.AddDropDown(diskLabel, deviceLabels, 0, func(_ string, index int) {
+		selectedDevice = index
+	})
+	form.AddDropDown("Target Storage", storageLabels, 0, func(_ string, index int) {
+		selectedStorage = index
+	})
+	form.AddCheckbox("Delete source", false, func(checked bool) {
+		deleteSource = checked
+	})
+
+	form.AddButton("Move", func() {
+		if selectedDevice < 0 || selectedStorage < 0 {
+			return
+		}
+
+		device := devices[selectedDevice]
+		target := storages[selectedStorage]
+
+		if device.Storage == target.Name {
+			a.header.ShowError(fmt.Sprintf("%s is already on storage %s", device.Device, target.Name))
+
+			return
+		}
+
+		options := &api.MoveDiskOptions{
+			Disk:    device.Device,
+			Storage: target.Name,
+			Delete:  deleteSource,
+		}
+
+		confirmText := fmt.Sprintf("Move %s of '%s' (ID: %d) from %s to %s?", device.Device, vm.Name, vm.ID, device.Storage, target.Name)
+		if deleteSource {
+			confirmText += "\n\nThe source volume will be deleted after a successful copy."
+		} else {
+			confirmText += "\n\nThe source volume will be kept as an unused disk."
+		}
+
+		a.showConfirmationDialog(confirmText, func() {
+			a.removePageIfPresent("moveDisk")
+			a.performMoveDiskOperation(vm, options)
+		})
+	})
+
+	form.AddButton("Cancel", func() {
+		a.removePageIfPresent("moveDisk")
+	})
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.removePageIfPresent("moveDisk")
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 11, 0, true).
+			AddItem(nil, 0, 1, false), 70, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("moveDisk", modal, true, true)
+	a.SetFocus(form)
+}
+
+// performMoveDiskOperation starts a move disk/volume task and reports its progress in the header.
+func (a *App) performMoveDiskOperation(vm *api.VM, options *api.MoveDiskOptions) {
+	models.GlobalState.SetVMPending(vm, "Moving disk")
+	a.header.ShowLoading(fmt.Sprintf("Moving %s of %s to %s", options.Disk, vm.Name, options.Storage))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		a.QueueUpdateDraw(func() {
+			a.updateVMListWithSelectionPreservation()
+		})
+	}()
+
+	go func() {
+		defer func() {
+			models.GlobalState.ClearVMPending(vm)
+			a.QueueUpdateDraw(func() {
+				a.updateVMListWithSelectionPreservation()
+			})
+		}()
+
+		var (
+			upid string
+			err  error
+		)
+
+		if vm.Type == api.VMTypeLXC {
+			upid, err = a.client.MoveVolume(vm, options)
+		} else {
+			upid, err = a.client.MoveDisk(vm, options)
+		}
+
+		if err == nil {
+			err = a.client.WaitForTask(upid, moveDiskTimeout, func(line string) {
+				a.QueueUpdateDraw(func() {
+					a.header.ShowLoading(fmt.Sprintf("Moving %s of %s: %s", options.Disk, vm.Name, line))
+				})
+			})
+		}
+
+		if err != nil {
+			a.QueueUpdateDraw(func() {
+				a.showActionError(fmt.Sprintf("Moving %s of %s failed", options.Disk, vm.Name), err)
+				a.loadTasksData()
+			})
+
+			return
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.ShowSuccess(fmt.Sprintf("Moved %s of %s to %s", options.Disk, vm.Name, options.Storage))
+		})
+
+		time.Sleep(2 * time.Second)
+		a.refreshVMDataAndTasks(vm)
+	}()
+}
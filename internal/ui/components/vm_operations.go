@@ -5,7 +5,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/internal/crash"
 	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
 	"github.com/devnullvoid/pvetui/pkg/api"
 )
 
@@ -43,8 +46,21 @@ func (a *App) updateVMListWithSelectionPreservation() {
 
 // performVMOperation performs an asynchronous VM operation and shows status message.
 func (a *App) performVMOperation(vm *api.VM, operation func(*api.VM) error, operationName string) {
+	a.runWithHooks(vmOperationHookActions[operationName], vm, func(post func()) {
+		a.startVMOperation(vm, operation, operationName, post)
+	})
+}
+
+// startVMOperation runs a VM operation once its pre hooks passed and calls
+// post after it completed.
+func (a *App) startVMOperation(vm *api.VM, operation func(*api.VM) error, operationName string, post func()) {
 	models.GlobalState.SetVMPending(vm, operationName)
 
+	// Requested stops are not reported as unexpected
+	if operationName == "Stopping" || operationName == "Shutting down" {
+		models.ExpectGuestStop(vm, time.Now())
+	}
+
 	go func() {
 		time.Sleep(50 * time.Millisecond)
 		a.QueueUpdateDraw(func() {
@@ -56,7 +72,7 @@ func (a *App) performVMOperation(vm *api.VM, operation func(*api.VM) error, oper
 	var originalUptime int64 = -1
 
 	if op := strings.ToLower(operationName); op == "restarting" {
-		freshVM, err := a.client.RefreshVMData(vm, nil)
+		freshVM, err := a.client.RefreshVMStatus(vm)
 		if err == nil {
 			originalUptime = freshVM.Uptime
 		}
@@ -70,9 +86,11 @@ func (a *App) performVMOperation(vm *api.VM, operation func(*api.VM) error, oper
 			})
 		}()
 
+		started := time.Now()
+
 		if err := operation(vm); err != nil {
 			a.QueueUpdateDraw(func() {
-				a.header.ShowError(fmt.Sprintf("Error %s %s: %v", strings.ToLower(operationName), vm.Name, err))
+				a.showActionError(fmt.Sprintf("Error %s %s", strings.ToLower(operationName), vm.Name), err)
 			})
 
 			return
@@ -86,16 +104,29 @@ func (a *App) performVMOperation(vm *api.VM, operation func(*api.VM) error, oper
 			a.QueueUpdateDraw(func() {
 				a.header.ShowLoading(fmt.Sprintf("Waiting for %s %s to complete...", op, vm.Name))
 			})
+
+			var err error
 			if op == "restarting" {
-				a.waitForVMRestartCompletionWithRefresh(vm, originalUptime)
+				err = a.waitForVMRestartCompletionWithRefresh(vm, originalUptime, started)
 			} else {
-				a.waitForVMOperationCompletionWithRefresh(vm, operationName)
+				err = a.waitForVMOperationCompletionWithRefresh(vm, operationName, started)
+			}
+
+			if err != nil {
+				a.QueueUpdateDraw(func() {
+					a.showActionError(fmt.Sprintf("Error %s %s", op, vm.Name), err)
+					a.refreshVMData(vm)
+					a.loadTasksData()
+				})
+
+				return
 			}
 		}
 
 		a.QueueUpdateDraw(func() {
 			a.header.ShowSuccess(fmt.Sprintf("%s %s completed successfully", operationName, vm.Name))
 		})
+		post()
 		time.Sleep(1500 * time.Millisecond)
 		a.QueueUpdateDraw(func() {
 			// Only show the pre-refresh loading if we're not already loading for another reason
@@ -114,6 +145,14 @@ func (a *App) performVMOperation(vm *api.VM, operation func(*api.VM) error, oper
 
 // performVMDeleteOperation performs an asynchronous VM delete operation and refreshes the VM list.
 func (a *App) performVMDeleteOperation(vm *api.VM, forced bool) {
+	a.runWithHooks(config.HookActionVMDelete, vm, func(post func()) {
+		a.startVMDeleteOperation(vm, forced, post)
+	})
+}
+
+// startVMDeleteOperation deletes a VM once its pre hooks passed and calls
+// post after the deletion succeeded.
+func (a *App) startVMDeleteOperation(vm *api.VM, forced bool, post func()) {
 	models.GlobalState.SetVMPending(vm, "Deleting")
 
 	go func() {
@@ -148,7 +187,7 @@ func (a *App) performVMDeleteOperation(vm *api.VM, forced bool) {
 
 		if err != nil {
 			a.QueueUpdateDraw(func() {
-				a.header.ShowError(fmt.Sprintf("Error deleting %s: %v", vm.Name, err))
+				a.showActionError("Error deleting "+vm.Name, err)
 			})
 		} else {
 			a.QueueUpdateDraw(func() {
@@ -164,6 +203,7 @@ func (a *App) performVMDeleteOperation(vm *api.VM, forced bool) {
 				}()
 			})
 			a.client.ClearAPICache()
+			post()
 
 			go func() {
 				time.Sleep(5 * time.Second)
@@ -177,38 +217,50 @@ func (a *App) performVMDeleteOperation(vm *api.VM, forced bool) {
 
 // showDeleteRunningVMDialog shows a dialog with options for deleting a running VM.
 func (a *App) showDeleteRunningVMDialog(vm *api.VM) {
-	message := fmt.Sprintf("⚠️  VM '%s' (ID: %d) is currently RUNNING\n\nProxmox can force delete running VMs.\n\nAre you sure you want to FORCE DELETE this running VM?\n\nThis will IMMEDIATELY DESTROY the VM and ALL its data!", vm.Name, vm.ID)
+	message := fmt.Sprintf("%s  VM '%s' (ID: %d) is currently RUNNING\n\nProxmox can force delete running VMs.\n\nAre you sure you want to FORCE DELETE this running VM?\n\nThis will IMMEDIATELY DESTROY the VM and ALL its data!", theme.Icon("⚠️", "WARNING:"), vm.Name, vm.ID)
 	a.showConfirmationDialog(message, func() {
 		a.performVMDeleteOperation(vm, true)
 	})
 }
 
-// waitForVMRestartCompletionWithRefresh waits for a VM to complete a restart by polling with RefreshVMData.
-func (a *App) waitForVMRestartCompletionWithRefresh(vm *api.VM, originalUptime int64) {
+// waitForVMRestartCompletionWithRefresh waits for a VM to complete a restart by polling with RefreshVMStatus.
+// It returns the error of a task of the guest that failed since started.
+func (a *App) waitForVMRestartCompletionWithRefresh(vm *api.VM, originalUptime int64, started time.Time) error {
 	const maxWait = 2 * time.Minute
 
 	const pollInterval = 2 * time.Second
 
 	start := time.Now()
 	for time.Since(start) < maxWait {
-		freshVM, err := a.client.RefreshVMData(vm, nil)
+		if err := a.failedGuestTask(vm, started); err != nil {
+			return err
+		}
+
+		freshVM, err := a.client.RefreshVMStatus(vm)
 		if err == nil && freshVM != nil && freshVM.Uptime > 0 && freshVM.Uptime < originalUptime-10 {
 			break
 		}
 
 		time.Sleep(pollInterval)
 	}
+
+	return nil
 }
 
-// waitForVMOperationCompletionWithRefresh waits for a VM operation (start, stop, etc.) to complete by polling with RefreshVMData.
-func (a *App) waitForVMOperationCompletionWithRefresh(vm *api.VM, operationName string) {
+// waitForVMOperationCompletionWithRefresh waits for a VM operation (start, stop, etc.) to complete by polling with RefreshVMStatus.
+// It returns the error of a task of the guest that failed since started.
+func (a *App) waitForVMOperationCompletionWithRefresh(vm *api.VM, operationName string, started time.Time) error {
 	const maxWait = 2 * time.Minute
 
 	const pollInterval = 2 * time.Second
 
 	start := time.Now()
 	for time.Since(start) < maxWait {
-		freshVM, err := a.client.RefreshVMData(vm, nil)
+		if err := a.failedGuestTask(vm, started); err != nil {
+			return err
+		}
+
+		freshVM, err := a.client.RefreshVMStatus(vm)
 		if err == nil && freshVM != nil {
 			if strings.ToLower(operationName) == "stopping" && freshVM.Status != api.VMStatusRunning {
 				break
@@ -221,4 +273,74 @@ func (a *App) waitForVMOperationCompletionWithRefresh(vm *api.VM, operationName
 
 		time.Sleep(pollInterval)
 	}
+
+	return nil
+}
+
+// failedGuestTask returns the error of a task of the guest that failed since
+// started. Power actions don't wait for their task, so a start that fails
+// after the request was accepted is only noticed this way. Failures to list
+// the tasks are ignored.
+func (a *App) failedGuestTask(vm *api.VM, started time.Time) error {
+	taskErr, err := a.client.GetFailedGuestTask(vm, started)
+	if err != nil {
+		models.GetUILogger().Debug("Failed to check the tasks of %s: %v", vm.Name, err)
+
+		return nil
+	}
+
+	if taskErr != nil {
+		return taskErr
+	}
+
+	return nil
+}
+
+// showConvertToTemplateDialog confirms converting a guest to a template,
+// explaining first why it can't be converted if it doesn't qualify.
+func (a *App) showConvertToTemplateDialog(vm *api.VM) {
+	if err := api.CheckTemplateConversion(vm); err != nil {
+		a.showMessageSafe(fmt.Sprintf("Can't convert to a template: %v", err))
+
+		return
+	}
+
+	a.showConfirmationDialog(
+		fmt.Sprintf("Convert '%s' (ID: %d) to a template?\n\nTemplates can't be started anymore, only cloned. This can't be undone.", vm.Name, vm.ID),
+		func() {
+			a.performConvertToTemplate(vm)
+		},
+	)
+}
+
+// performConvertToTemplate converts a guest to a template in the background
+// and marks it as a template in the list once done.
+func (a *App) performConvertToTemplate(vm *api.VM) {
+	models.GlobalState.SetVMPending(vm, "Converting")
+	a.updateVMListWithSelectionPreservation()
+	a.header.ShowLoading(fmt.Sprintf("Converting %s to a template", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		err := a.client.ConvertToTemplate(vm)
+
+		a.QueueUpdateDraw(func() {
+			models.GlobalState.ClearVMPending(vm)
+
+			if err != nil {
+				a.showActionError("Error converting "+vm.Name, err)
+				a.updateVMListWithSelectionPreservation()
+
+				return
+			}
+
+			vm.Template = true
+			a.client.ClearAPICache()
+			a.updateVMListWithSelectionPreservation()
+
+			a.header.ShowSuccess(fmt.Sprintf("%s converted to a template", vm.Name))
+			a.loadTasksData()
+		})
+	}()
 }
@@ -0,0 +1,317 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/crash"
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+	"github.com/devnullvoid/pvetui/internal/ui/utils"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// restoreTimeout bounds how long we wait for a restore task to finish.
+const restoreTimeout = 6 * time.Hour
+
+// restoreStorageFromBackup is the storage option that keeps the storages
+// recorded in the backup.
+const restoreStorageFromBackup = "(as in backup)"
+
+// showRestoreBackupDialog loads the backups of a guest and the next free VMID,
+// then shows the restore dialog.
+func (a *App) showRestoreBackupDialog(vm *api.VM) {
+	a.header.ShowLoading(fmt.Sprintf("Loading backups of %s...", vm.Name))
+
+	go func() {
+		defer crash.Recover()
+
+		backups, err := a.client.GetGuestBackups(vm)
+
+		var nextID int
+		if err == nil {
+			nextID, err = a.client.GetNextVMID()
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.header.StopLoading()
+
+			if err != nil {
+				a.header.ShowError(fmt.Sprintf("Failed to load backups of %s: %v", vm.Name, err))
+
+				return
+			}
+
+			if len(backups) == 0 {
+				a.showMessage(fmt.Sprintf("No backups found for '%s'", vm.Name))
+
+				return
+			}
+
+			a.showRestoreBackupForm(vm, backups, nextID)
+		})
+	}()
+}
+
+// restoreTargetNodes returns the online nodes a backup can be restored on.
+// Archives on a storage local to the guest's node can only be read there.
+func (a *App) restoreTargetNodes(vm *api.VM, backup api.Backup) []string {
+	if a.client.Cluster == nil {
+		return []string{vm.Node}
+	}
+
+	if !a.isSharedStorage(backup.Storage) {
+		return []string{vm.Node}
+	}
+
+	var nodes []string
+
+	for _, node := range a.client.Cluster.Nodes {
+		if node != nil && node.Online {
+			nodes = append(nodes, node.Name)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return []string{vm.Node}
+	}
+
+	return nodes
+}
+
+// isSharedStorage reports whether a storage is shared across the cluster.
+func (a *App) isSharedStorage(name string) bool {
+	if a.client.Cluster == nil || a.client.Cluster.StorageManager == nil {
+		return false
+	}
+
+	for _, storage := range a.client.Cluster.StorageManager.AllStorages {
+		if storage != nil && storage.Name == name {
+			return storage.IsShared()
+		}
+	}
+
+	return false
+}
+
+// restoreTargetStorages returns the storages of a node that can hold the
+// disks or volumes of the given guest type.
+func (a *App) restoreTargetStorages(node, guestType string) []*api.Storage {
+	return a.moveTargetStorages(&api.VM{Node: node, Type: guestType})
+}
+
+// showRestoreBackupForm shows the dialog for restoring one of the backups of a
+// guest as a new guest.
+func (a *App) showRestoreBackupForm(vm *api.VM, backups []api.Backup, nextID int) {
+	var (
+		selectedBackup = 0
+		nodes          = a.restoreTargetNodes(vm, backups[0])
+		selectedNode   = 0
+		storages       []*api.Storage
+		storageIndex   = 0
+		uniqueMAC      = true
+		startAfter     = false
+	)
+
+	backupLabels := make([]string, len(backups))
+	for i, backup := range backups {
+		backupLabels[i] = fmt.Sprintf("%s  %s on %s", backup.CTime.Format("2006-01-02 15:04"), utils.FormatBytes(backup.Size), backup.Storage)
+	}
+
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" Restore Backup: %s '%s' (ID: %d) ", strings.ToUpper(vm.Type), vm.Name, vm.ID))
+	form.SetTitleColor(theme.Colors.Primary)
+	form.SetBorderColor(theme.Colors.Border)
+
+	storageDropDown := tview.NewDropDown().SetLabel("Target Storage")
+	nodeDropDown := tview.NewDropDown().SetLabel("Target Node")
+
+	// The storage choices depend on the node, the node choices on the backup
+	updateStorages := func() {
+		storages = a.restoreTargetStorages(nodes[selectedNode], backups[selectedBackup].Type)
+
+		labels := []string{restoreStorageFromBackup}
+		for _, storage := range storages {
+			labels = append(labels, fmt.Sprintf("%s (%s free)", storage.Name, api.FormatBytes(storage.MaxDisk-storage.Disk)))
+		}
+
+		storageIndex = 0
+		storageDropDown.SetOptions(labels, func(_ string, index int) {
+			storageIndex = index
+		})
+		storageDropDown.SetCurrentOption(0)
+	}
+
+	selectNode := func(index int) {
+		if index < 0 || index == selectedNode {
+			return
+		}
+
+		selectedNode = index
+		updateStorages()
+	}
+
+	// With several possible nodes, the placement suggestion comes first
+	updateNodes := func() {
+		nodes = a.restoreTargetNodes(vm, backups[selectedBackup])
+		labels := nodes
+		selectedNode = 0
+
+		if len(nodes) > 1 {
+			nodes, labels, _ = a.placementOptions(vm, nodes)
+		}
+
+		nodeDropDown.SetOptions(labels, func(_ string, index int) {
+			selectNode(index)
+		})
+		nodeDropDown.SetCurrentOption(selectedNode)
+		updateStorages()
+	}
+
+	form.AddDropDown("Backup", backupLabels, 0, func(_ string, index int) {
+		if index < 0 || index == selectedBackup {
+			return
+		}
+
+		selectedBackup = index
+		updateNodes()
+	})
+	form.AddInputField("New VMID", strconv.Itoa(nextID), 10, tview.InputFieldInteger, nil)
+	form.AddInputField("Name", vm.Name+"-restored", 30, nil, nil)
+	form.AddFormItem(nodeDropDown)
+	form.AddFormItem(storageDropDown)
+	form.AddInputField("Bandwidth Limit (MiB/s)", "0", 10, tview.InputFieldInteger, nil)
+	form.AddCheckbox("Unique MAC addresses", uniqueMAC, func(checked bool) {
+		uniqueMAC = checked
+	})
+	form.AddCheckbox("Start after restore", startAfter, func(checked bool) {
+		startAfter = checked
+	})
+
+	updateNodes()
+
+	closeForm := func() {
+		a.removePageIfPresent("restoreBackup")
+	}
+
+	form.AddButton("Restore", func() {
+		backup := backups[selectedBackup]
+
+		vmid, err := strconv.Atoi(strings.TrimSpace(form.GetFormItemByLabel("New VMID").(*tview.InputField).GetText()))
+		if err != nil || vmid < 100 {
+			a.header.ShowError("The new VMID must be a number of at least 100")
+
+			return
+		}
+
+		if existing := findGuestByID(vmid); existing != nil {
+			a.header.ShowError(fmt.Sprintf("VMID %d is already used by '%s'", vmid, existing.Name))
+
+			return
+		}
+
+		bandwidth, err := strconv.Atoi(strings.TrimSpace(form.GetFormItemByLabel("Bandwidth Limit (MiB/s)").(*tview.InputField).GetText()))
+		if err != nil || bandwidth < 0 {
+			a.header.ShowError("The bandwidth limit must be a positive number of MiB/s, or 0")
+
+			return
+		}
+
+		options := &api.RestoreOptions{
+			VMID:           vmid,
+			Node:           nodes[selectedNode],
+			Name:           strings.TrimSpace(form.GetFormItemByLabel("Name").(*tview.InputField).GetText()),
+			BandwidthLimit: bandwidth * 1024,
+			UniqueMAC:      uniqueMAC,
+			Start:          startAfter,
+		}
+
+		storageText := "the storages of the backup"
+		if storageIndex > 0 {
+			options.Storage = storages[storageIndex-1].Name
+			storageText = options.Storage
+		}
+
+		confirmText := fmt.Sprintf("Restore the backup of '%s' from %s as new %s %d on %s (%s)?",
+			vm.Name, backup.CTime.Format("2006-01-02 15:04"), strings.ToUpper(backup.Type), vmid, options.Node, storageText)
+		if !uniqueMAC {
+			confirmText += "\n\nThe restored guest keeps the MAC addresses of the original; don't run both on the same network."
+		}
+
+		a.showConfirmationDialog(confirmText, func() {
+			closeForm()
+			a.performRestoreBackup(backup, options)
+		})
+	})
+
+	form.AddButton("Cancel", closeForm)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+
+			return nil
+		}
+
+		return event
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 21, 0, true).
+			AddItem(nil, 0, 1, false), 76, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	a.pages.AddPage("restoreBackup", modal, true, true)
+	a.SetFocus(form)
+}
+
+// findGuestByID returns the guest with the given VMID, if any.
+func findGuestByID(vmid int) *api.VM {
+	for _, vm := range models.GlobalState.OriginalVMs {
+		if vm != nil && vm.ID == vmid {
+			return vm
+		}
+	}
+
+	return nil
+}
+
+// performRestoreBackup starts a restore task and reports its progress in the header.
+func (a *App) performRestoreBackup(backup api.Backup, options *api.RestoreOptions) {
+	a.header.ShowLoading(fmt.Sprintf("Restoring %d on %s", options.VMID, options.Node))
+
+	go func() {
+		defer crash.Recover()
+
+		upid, err := a.client.RestoreBackup(backup, options)
+		if err == nil {
+			err = a.client.WaitForTask(upid, restoreTimeout, func(line string) {
+				a.QueueUpdateDraw(func() {
+					a.header.ShowLoading(fmt.Sprintf("Restoring %d: %s", options.VMID, line))
+				})
+			})
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.showActionError(fmt.Sprintf("Restoring %d failed", options.VMID), err)
+				a.loadTasksData()
+
+				return
+			}
+
+			a.header.ShowSuccess(fmt.Sprintf("Restored backup as %d on %s", options.VMID, options.Node))
+			a.client.ClearAPICache()
+			a.manualRefresh()
+		})
+	}()
+}
@@ -178,7 +178,7 @@ func (sm *SnapshotManager) loadSnapshots() {
 		sm.app.Application.QueueUpdateDraw(func() {
 			sm.loading = false
 			if err != nil {
-				sm.updateInfoText(fmt.Sprintf("❌ Error loading snapshots: %v", err))
+				sm.updateInfoText(theme.Label("❌", fmt.Sprintf("Error loading snapshots: %v", err)))
 				return
 			}
 
@@ -198,7 +198,7 @@ func (sm *SnapshotManager) displaySnapshots(snapshots []api.Snapshot) {
 
 	// Count real snapshots (excluding "current")
 	realSnapshotCount := sm.snapshotTable.GetSnapshotCount()
-	sm.updateInfoText(fmt.Sprintf("✅ Loaded %d snapshots", realSnapshotCount))
+	sm.updateInfoText(theme.Label("✅", fmt.Sprintf("Loaded %d snapshots", realSnapshotCount)))
 }
 
 // updateInfoText updates the info text at the bottom.
@@ -218,7 +218,7 @@ func (sm *SnapshotManager) createSnapshot() {
 func (sm *SnapshotManager) deleteSnapshot() {
 	snapshot := sm.snapshotTable.GetSelectedSnapshot()
 	if snapshot == nil {
-		sm.updateInfoText("❌ No snapshot selected.")
+		sm.updateInfoText(theme.Label("❌", "No snapshot selected."))
 		return
 	}
 
@@ -236,7 +236,7 @@ func (sm *SnapshotManager) deleteSnapshot() {
 func (sm *SnapshotManager) rollbackSnapshot() {
 	snapshot := sm.snapshotTable.GetSelectedSnapshot()
 	if snapshot == nil {
-		sm.updateInfoText("❌ No snapshot selected.")
+		sm.updateInfoText(theme.Label("❌", "No snapshot selected."))
 		return
 	}
 
@@ -259,7 +259,7 @@ func (sm *SnapshotManager) performSnapshotOperation(
 ) {
 	snapshot := sm.snapshotTable.GetSelectedSnapshot()
 	if snapshot == nil {
-		sm.app.showMessageSafe(fmt.Sprintf("❌ Please select a snapshot to %s", operationName))
+		sm.app.showMessageSafe(theme.Label("❌", "Please select a snapshot to "+operationName))
 		return
 	}
 
@@ -286,7 +286,7 @@ func (sm *SnapshotManager) performSnapshotOperation(
 			err := operation()
 			if err != nil {
 				sm.app.Application.QueueUpdateDraw(func() {
-					sm.app.header.ShowError(fmt.Sprintf("%s: %v", errorMessage, err))
+					sm.app.showActionError(errorMessage, err)
 				})
 			} else {
 				// Poll for snapshot list updates
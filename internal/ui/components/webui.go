@@ -0,0 +1,46 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/devnullvoid/pvetui/internal/ui/models"
+	"github.com/devnullvoid/pvetui/internal/vnc"
+)
+
+// openVMWebUI opens the Proxmox web UI for the currently selected guest.
+func (a *App) openVMWebUI() {
+	vm := a.vmList.GetSelectedVM()
+	if vm == nil {
+		a.showMessageSafe("Selected VM not found")
+
+		return
+	}
+
+	a.openWebUI(vm.Name, a.client.GenerateWebUIURL(vm))
+}
+
+// openNodeWebUI opens the Proxmox web UI for the currently selected node.
+func (a *App) openNodeWebUI() {
+	node := a.nodeList.GetSelectedNode()
+	if node == nil {
+		a.showMessageSafe("Selected node not found")
+
+		return
+	}
+
+	a.openWebUI(node.Name, a.client.GenerateNodeWebUIURL(node.Name))
+}
+
+// openWebUI launches the browser with the given web UI URL. If no browser can
+// be started, the URL is shown so it can be opened manually.
+func (a *App) openWebUI(name, webURL string) {
+	models.GetUILogger().Debug("Opening web UI for %s: %s", name, webURL)
+
+	if err := vnc.OpenBrowser(webURL); err != nil {
+		a.showMessageSafe(fmt.Sprintf("Failed to open browser: %v\n\nOpen this URL manually:\n%s", err, webURL))
+
+		return
+	}
+
+	a.header.ShowSuccess(fmt.Sprintf("Opened %s in the web UI", name))
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// AffinityGroup is a set of guests that should share a node (affinity) or
+// run on different nodes (anti-affinity).
+type AffinityGroup struct {
+	Name   string
+	Type   string // config.AffinityTogether or config.AffinitySeparate
+	Guests []*api.VM
+}
+
+// label returns e.g. "anti-affinity group db".
+func (g AffinityGroup) label() string {
+	return fmt.Sprintf("%s group %s", g.Type, g.Name)
+}
+
+// AffinityGroups collects the groups of the configured rules and of guest
+// tags like "anti-affinity-db", sorted by type and name. Templates are left
+// out since they never run.
+func AffinityGroups(rules []config.AffinityRule, vms []*api.VM) []AffinityGroup {
+	groups := make(map[string]*AffinityGroup)
+	members := make(map[string]map[*api.VM]bool)
+
+	add := func(groupType, name string, vm *api.VM) {
+		key := groupType + "/" + name
+
+		group, ok := groups[key]
+		if !ok {
+			group = &AffinityGroup{Name: name, Type: groupType}
+			groups[key] = group
+			members[key] = make(map[*api.VM]bool)
+		}
+
+		if vm != nil && !members[key][vm] {
+			members[key][vm] = true
+			group.Guests = append(group.Guests, vm)
+		}
+	}
+
+	byID := make(map[int][]*api.VM)
+
+	for _, vm := range vms {
+		if vm == nil || vm.Template {
+			continue
+		}
+
+		byID[vm.ID] = append(byID[vm.ID], vm)
+
+		for _, tag := range strings.FieldsFunc(vm.Tags, func(r rune) bool {
+			return r == ';' || r == ',' || r == ' '
+		}) {
+			if groupType, name, ok := parseAffinityTag(tag); ok {
+				add(groupType, name, vm)
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		add(rule.Type, rule.Group, nil)
+
+		for _, vmid := range rule.Guests {
+			for _, vm := range byID[vmid] {
+				add(rule.Type, rule.Group, vm)
+			}
+		}
+	}
+
+	result := make([]AffinityGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.Guests, func(i, j int) bool {
+			return group.Guests[i].ID < group.Guests[j].ID
+		})
+
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type > result[j].Type // anti-affinity first
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// parseAffinityTag parses guest tags like "anti-affinity-db" or "affinity-web".
+func parseAffinityTag(tag string) (groupType, name string, ok bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	// Check anti-affinity first, "affinity-" is a suffix of it
+	for _, candidate := range []string{config.AffinitySeparate, config.AffinityTogether} {
+		if rest, found := strings.CutPrefix(tag, candidate+"-"); found && rest != "" {
+			return candidate, rest, true
+		}
+	}
+
+	return "", "", false
+}
+
+// CollectAffinityAlerts returns an alert for every anti-affinity group with
+// guests sharing a node and every affinity group spread over several nodes.
+// Only running guests are considered.
+func CollectAffinityAlerts(groups []AffinityGroup) []Alert {
+	var alerts []Alert
+
+	for _, group := range groups {
+		byNode := make(map[string][]*api.VM)
+
+		var nodes []string
+
+		for _, vm := range group.Guests {
+			if vm.Status != api.VMStatusRunning {
+				continue
+			}
+
+			if _, ok := byNode[vm.Node]; !ok {
+				nodes = append(nodes, vm.Node)
+			}
+
+			byNode[vm.Node] = append(byNode[vm.Node], vm)
+		}
+
+		sort.Strings(nodes)
+
+		switch group.Type {
+		case config.AffinitySeparate:
+			for _, node := range nodes {
+				if len(byNode[node]) < 2 {
+					continue
+				}
+
+				alerts = append(alerts, Alert{
+					Kind:    AlertAffinityViolated,
+					Message: fmt.Sprintf("%s: %s share node %s", group.label(), guestNames(byNode[node]), node),
+					Node:    node,
+					VM:      byNode[node][0],
+				})
+			}
+		case config.AffinityTogether:
+			if len(nodes) < 2 {
+				continue
+			}
+
+			alerts = append(alerts, Alert{
+				Kind:    AlertAffinityViolated,
+				Message: fmt.Sprintf("%s: guests spread over %s", group.label(), strings.Join(nodes, ", ")),
+				Node:    nodes[0],
+				VM:      byNode[nodes[0]][0],
+			})
+		}
+	}
+
+	return alerts
+}
+
+// AffinityConflicts describes the rules a guest would break on the target
+// node, e.g. when migrating it there.
+func AffinityConflicts(groups []AffinityGroup, vm *api.VM, target string) []string {
+	var conflicts []string
+
+	for _, group := range groups {
+		if !containsGuest(group.Guests, vm) {
+			continue
+		}
+
+		var onTarget, elsewhere []*api.VM
+
+		for _, other := range group.Guests {
+			if sameGuest(other, vm) || other.Status != api.VMStatusRunning {
+				continue
+			}
+
+			if other.Node == target {
+				onTarget = append(onTarget, other)
+			} else {
+				elsewhere = append(elsewhere, other)
+			}
+		}
+
+		switch {
+		case group.Type == config.AffinitySeparate && len(onTarget) > 0:
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s already on %s", group.label(), guestNames(onTarget), target))
+		case group.Type == config.AffinityTogether && len(elsewhere) > 0:
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s not on %s", group.label(), guestNames(elsewhere), target))
+		}
+	}
+
+	return conflicts
+}
+
+// containsGuest reports whether vm is in guests.
+func containsGuest(guests []*api.VM, vm *api.VM) bool {
+	for _, guest := range guests {
+		if sameGuest(guest, vm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameGuest compares node and VMID, so refreshed copies of a guest match.
+func sameGuest(a, b *api.VM) bool {
+	return a != nil && b != nil && a.ID == b.ID && a.Node == b.Node
+}
+
+// guestNames lists guests as "web1 (101), web2 (102)".
+func guestNames(vms []*api.VM) string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		names[i] = fmt.Sprintf("%s (%d)", vm.Name, vm.ID)
+	}
+
+	return strings.Join(names, ", ")
+}
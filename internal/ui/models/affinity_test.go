@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestAffinityGroups(t *testing.T) {
+	db1 := &api.VM{ID: 101, Name: "db1", Node: "pve1", Status: api.VMStatusRunning, Tags: "prod;anti-affinity-db"}
+	db2 := &api.VM{ID: 102, Name: "db2", Node: "pve1", Status: api.VMStatusRunning}
+	db3 := &api.VM{ID: 103, Name: "db3", Node: "pve2", Status: api.VMStatusRunning, Tags: "anti-affinity-db"}
+	web1 := &api.VM{ID: 201, Name: "web1", Node: "pve1", Status: api.VMStatusRunning, Tags: "affinity-web"}
+	web2 := &api.VM{ID: 202, Name: "web2", Node: "pve2", Status: api.VMStatusRunning, Tags: "affinity-web"}
+	tmpl := &api.VM{ID: 900, Name: "tmpl", Node: "pve2", Template: true, Tags: "anti-affinity-db"}
+
+	rules := []config.AffinityRule{{Group: "db", Type: config.AffinitySeparate, Guests: []int{101, 102}}}
+	groups := AffinityGroups(rules, []*api.VM{db1, db2, db3, web1, web2, tmpl})
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, "db", groups[0].Name)
+	assert.Equal(t, []*api.VM{db1, db2, db3}, groups[0].Guests)
+	assert.Equal(t, "web", groups[1].Name)
+
+	alerts := CollectAffinityAlerts(groups)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, AlertAffinityViolated, alerts[0].Kind)
+	assert.Equal(t, "anti-affinity group db: db1 (101), db2 (102) share node pve1", alerts[0].Message)
+	assert.Equal(t, "affinity group web: guests spread over pve1, pve2", alerts[1].Message)
+
+	assert.Equal(t, []string{"anti-affinity group db: db3 (103) already on pve2"}, AffinityConflicts(groups, db2, "pve2"))
+	assert.Equal(t, []string{"affinity group web: web1 (201) not on pve3"}, AffinityConflicts(groups, web2, "pve3"))
+	assert.Empty(t, AffinityConflicts(groups, web2, "pve1"))
+	assert.Empty(t, AffinityConflicts(groups, &api.VM{ID: 300, Node: "pve1"}, "pve2"))
+}
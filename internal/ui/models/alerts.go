@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// StorageAlertThreshold is the storage usage percentage that raises an alert.
+const StorageAlertThreshold = 90.0
+
+// AlertKind identifies what an alert is about.
+type AlertKind int
+
+const (
+	// AlertNodeOffline is raised for nodes that are not online.
+	AlertNodeOffline AlertKind = iota
+	// AlertStorageFull is raised for storages above StorageAlertThreshold.
+	AlertStorageFull
+	// AlertGuestStopped is raised for guests that stopped unexpectedly.
+	AlertGuestStopped
+	// AlertGuestRestarted is raised for guests that restarted recently.
+	AlertGuestRestarted
+	// AlertBackupOverdue is raised for guests without a recent backup.
+	AlertBackupOverdue
+	// AlertAffinityViolated is raised for affinity groups whose guests run
+	// on the wrong nodes.
+	AlertAffinityViolated
+	// AlertStaleSnapshot is raised for guests with stale snapshots.
+	AlertStaleSnapshot
+	// AlertClockSkew is raised for nodes whose clock is out of sync with
+	// the cluster.
+	AlertClockSkew
+)
+
+// Alert is an actionable problem found in the cluster data.
+type Alert struct {
+	Kind    AlertKind
+	Message string
+	Time    time.Time // When a guest change was detected, zero for node alerts
+	Node    string    // Node the alert belongs to
+	Storage string    // Storage name for AlertStorageFull
+	VM      *api.VM   // Guest for guest alerts
+}
+
+// CollectAlerts returns the alerts for the given nodes and guests: offline
+// nodes and full storages first, then unexpected guest stops and recent
+// restarts, newest first. Shared storages are reported once.
+func CollectAlerts(nodes []*api.Node, vms []*api.VM) []Alert {
+	var alerts []Alert
+
+	sharedSeen := make(map[string]bool)
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		if !node.Online {
+			alerts = append(alerts, Alert{
+				Kind:    AlertNodeOffline,
+				Message: fmt.Sprintf("node %s offline", node.Name),
+				Node:    node.Name,
+			})
+
+			continue
+		}
+
+		for _, storage := range node.Storage {
+			if storage == nil || storage.GetUsagePercent() < StorageAlertThreshold {
+				continue
+			}
+
+			if storage.IsShared() {
+				if sharedSeen[storage.Name] {
+					continue
+				}
+
+				sharedSeen[storage.Name] = true
+			}
+
+			alerts = append(alerts, Alert{
+				Kind:    AlertStorageFull,
+				Message: fmt.Sprintf("node %s storage %s %.0f%%", node.Name, storage.Name, storage.GetUsagePercent()),
+				Node:    node.Name,
+				Storage: storage.Name,
+			})
+		}
+	}
+
+	var guestAlerts []Alert
+
+	for _, vm := range vms {
+		if vm == nil {
+			continue
+		}
+
+		if at, ok := StoppedAt(vm); ok && vm.Status == api.VMStatusStopped {
+			guestAlerts = append(guestAlerts, Alert{
+				Kind:    AlertGuestStopped,
+				Message: fmt.Sprintf("%s stopped unexpectedly", guestLabel(vm)),
+				Time:    at,
+				Node:    vm.Node,
+				VM:      vm,
+			})
+		} else if at, ok := RestartedAt(vm); ok {
+			guestAlerts = append(guestAlerts, Alert{
+				Kind:    AlertGuestRestarted,
+				Message: fmt.Sprintf("%s restarted", guestLabel(vm)),
+				Time:    at,
+				Node:    vm.Node,
+				VM:      vm,
+			})
+		}
+	}
+
+	sort.SliceStable(guestAlerts, func(i, j int) bool {
+		return guestAlerts[i].Time.After(guestAlerts[j].Time)
+	})
+
+	return append(alerts, guestAlerts...)
+}
+
+// guestLabel names a guest as "VM 104 (web)" or "CT 105 (dns)".
+func guestLabel(vm *api.VM) string {
+	guestType := "VM"
+	if vm.Type == api.VMTypeLXC {
+		guestType = "CT"
+	}
+
+	return fmt.Sprintf("%s %d (%s)", guestType, vm.ID, vm.Name)
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCollectAlerts(t *testing.T) {
+	ResetUptimes()
+	t.Cleanup(ResetUptimes)
+
+	nfs := func() *api.Storage {
+		return &api.Storage{Name: "nfs", Shared: 1, Disk: 95, MaxDisk: 100}
+	}
+
+	nodes := []*api.Node{
+		{Name: "pve1", Online: true, Storage: []*api.Storage{
+			{Name: "local", Disk: 50, MaxDisk: 100},
+			{Name: "local-lvm", Disk: 92, MaxDisk: 100},
+			nfs(),
+		}},
+		{Name: "pve2", Online: true, Storage: []*api.Storage{nfs()}},
+		{Name: "pve3", Online: false},
+	}
+
+	now := time.Now()
+	web := &api.VM{ID: 104, Name: "web", Node: "pve1", Type: api.VMTypeQemu, Status: api.VMStatusRunning, Uptime: 5000}
+	dns := &api.VM{ID: 105, Name: "dns", Node: "pve2", Type: api.VMTypeLXC, Status: api.VMStatusRunning, Uptime: 5000}
+	RecordUptimes([]*api.VM{web, dns}, now)
+
+	web = &api.VM{ID: 104, Name: "web", Node: "pve1", Type: api.VMTypeQemu, Status: api.VMStatusStopped}
+	dns = &api.VM{ID: 105, Name: "dns", Node: "pve2", Type: api.VMTypeLXC, Status: api.VMStatusRunning, Uptime: 10}
+	RecordUptimes([]*api.VM{web, dns}, now.Add(time.Minute))
+
+	alerts := CollectAlerts(nodes, []*api.VM{web, dns})
+	require.Len(t, alerts, 5)
+
+	messages := make([]string, len(alerts))
+	for i, alert := range alerts {
+		messages[i] = alert.Message
+	}
+
+	assert.Equal(t, []string{
+		"node pve1 storage local-lvm 92%",
+		"node pve1 storage nfs 95%",
+		"node pve3 offline",
+		"VM 104 (web) stopped unexpectedly",
+		"CT 105 (dns) restarted",
+	}, messages)
+
+	assert.Equal(t, AlertStorageFull, alerts[0].Kind)
+	assert.Equal(t, "local-lvm", alerts[0].Storage)
+	assert.Equal(t, AlertGuestStopped, alerts[3].Kind)
+	assert.Same(t, web, alerts[3].VM)
+}
+
+func TestCollectAlerts_Empty(t *testing.T) {
+	ResetUptimes()
+	t.Cleanup(ResetUptimes)
+
+	assert.Empty(t, CollectAlerts([]*api.Node{{Name: "pve1", Online: true}}, []*api.VM{{ID: 100, Status: api.VMStatusStopped}}))
+}
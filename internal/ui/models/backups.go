@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// backupIndex holds the newest backup archive of every guest, collected
+// across all backup storages of the cluster.
+type backupIndex struct {
+	mu       sync.RWMutex
+	latest   map[int]api.Backup // Key: VMID
+	loadedAt time.Time
+}
+
+var backups backupIndex
+
+// SetLatestBackups replaces the newest backup of every guest, keyed by VMID.
+func SetLatestBackups(latest map[int]api.Backup, now time.Time) {
+	backups.mu.Lock()
+	defer backups.mu.Unlock()
+
+	backups.latest = latest
+	backups.loadedAt = now
+}
+
+// ResetBackups forgets the backups, e.g. after switching to another cluster.
+func ResetBackups() {
+	SetLatestBackups(nil, time.Time{})
+}
+
+// BackupsLoadedAt returns when the backups were last collected, zero if
+// they weren't yet.
+func BackupsLoadedAt() time.Time {
+	backups.mu.RLock()
+	defer backups.mu.RUnlock()
+
+	return backups.loadedAt
+}
+
+// LatestBackup returns the newest backup of a guest. ok is false if the
+// guest has no backup or the backups weren't collected yet.
+func LatestBackup(vm *api.VM) (backup api.Backup, ok bool) {
+	backups.mu.RLock()
+	defer backups.mu.RUnlock()
+
+	backup, ok = backups.latest[vm.ID]
+
+	return backup, ok
+}
+
+// BackupOverdue reports whether a guest's last backup is older than maxAge
+// or it was never backed up. Templates are never overdue, and no guest is
+// while maxAge is 0 or the backups weren't collected yet.
+func BackupOverdue(vm *api.VM, maxAge time.Duration, now time.Time) bool {
+	if maxAge <= 0 || vm == nil || vm.Template || BackupsLoadedAt().IsZero() {
+		return false
+	}
+
+	backup, ok := LatestBackup(vm)
+
+	return !ok || now.Sub(backup.CTime) > maxAge
+}
+
+// CollectBackupAlerts returns an alert for every guest whose backup is
+// overdue, the guests never backed up first, then the oldest backups.
+func CollectBackupAlerts(vms []*api.VM, maxAge time.Duration, now time.Time) []Alert {
+	var overdue []*api.VM
+
+	for _, vm := range vms {
+		if BackupOverdue(vm, maxAge, now) {
+			overdue = append(overdue, vm)
+		}
+	}
+
+	// Guests without a backup have a zero time and sort first
+	lastBackup := func(vm *api.VM) time.Time {
+		backup, _ := LatestBackup(vm)
+
+		return backup.CTime
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return lastBackup(overdue[i]).Before(lastBackup(overdue[j]))
+	})
+
+	alerts := make([]Alert, 0, len(overdue))
+
+	for _, vm := range overdue {
+		message := fmt.Sprintf("%s never backed up", guestLabel(vm))
+		if backup, ok := LatestBackup(vm); ok {
+			message = fmt.Sprintf("%s last backed up %s ago", guestLabel(vm), FormatAge(now.Sub(backup.CTime)))
+		}
+
+		alerts = append(alerts, Alert{
+			Kind:    AlertBackupOverdue,
+			Message: message,
+			Node:    vm.Node,
+			VM:      vm,
+		})
+	}
+
+	return alerts
+}
+
+// FormatAge formats a duration coarsely, e.g. "45m", "5h" or "3d".
+func FormatAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
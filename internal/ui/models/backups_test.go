@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCollectBackupAlerts(t *testing.T) {
+	ResetBackups()
+	t.Cleanup(ResetBackups)
+
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	maxAge := 7 * 24 * time.Hour
+
+	fresh := &api.VM{ID: 100, Name: "web", Node: "pve1", Type: api.VMTypeQemu}
+	stale := &api.VM{ID: 101, Name: "db", Node: "pve1", Type: api.VMTypeQemu}
+	never := &api.VM{ID: 102, Name: "dns", Node: "pve2", Type: api.VMTypeLXC}
+	template := &api.VM{ID: 9000, Name: "debian", Node: "pve1", Type: api.VMTypeQemu, Template: true}
+	vms := []*api.VM{fresh, stale, never, template}
+
+	// Nothing is flagged before the backups were collected
+	assert.Empty(t, CollectBackupAlerts(vms, maxAge, now))
+
+	SetLatestBackups(map[int]api.Backup{
+		100: {VMID: 100, CTime: now.Add(-24 * time.Hour)},
+		101: {VMID: 101, CTime: now.Add(-10 * 24 * time.Hour)},
+	}, now)
+
+	backup, ok := LatestBackup(fresh)
+	assert.True(t, ok)
+	assert.Equal(t, 100, backup.VMID)
+
+	alerts := CollectBackupAlerts(vms, maxAge, now)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "CT 102 (dns) never backed up", alerts[0].Message)
+	assert.Equal(t, "VM 101 (db) last backed up 10d ago", alerts[1].Message)
+	assert.Equal(t, AlertBackupOverdue, alerts[1].Kind)
+
+	// A zero window disables the alerts
+	assert.Empty(t, CollectBackupAlerts(vms, 0, now))
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "45m", FormatAge(45*time.Minute))
+	assert.Equal(t, "30h", FormatAge(30*time.Hour))
+	assert.Equal(t, "3d", FormatAge(72*time.Hour))
+}
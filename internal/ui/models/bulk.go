@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// maxBulkGuests limits how many IDs a list may expand to, so that a
+// mistyped range like 100-100000 is rejected instead of previewed.
+const maxBulkGuests = 1000
+
+// ParseVMIDs parses a list of guest IDs and ranges such as "101,104-110".
+// Items may be separated by commas, semicolons or whitespace, including the
+// newlines of a pasted list. Duplicates are dropped and the order of first
+// appearance is kept.
+func ParseVMIDs(text string) ([]int, error) {
+	items := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+
+	var ids []int
+
+	seen := make(map[int]bool)
+
+	for _, item := range items {
+		first, last, isRange := strings.Cut(item, "-")
+
+		start, err := parseVMID(first)
+		if err != nil {
+			return nil, err
+		}
+
+		end := start
+		if isRange {
+			if end, err = parseVMID(last); err != nil {
+				return nil, err
+			}
+
+			if end < start {
+				return nil, fmt.Errorf("range %s is reversed", item)
+			}
+		}
+
+		if end-start >= maxBulkGuests {
+			return nil, fmt.Errorf("range %s has more than %d IDs", item, maxBulkGuests)
+		}
+
+		for id := start; id <= end; id++ {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) > maxBulkGuests {
+			return nil, fmt.Errorf("more than %d IDs", maxBulkGuests)
+		}
+	}
+
+	return ids, nil
+}
+
+// parseVMID parses a single guest ID; Proxmox IDs are 100 or higher.
+func parseVMID(text string) (int, error) {
+	id, err := strconv.Atoi(text)
+	if err != nil || id < 100 {
+		return 0, fmt.Errorf("%q is not a guest ID", text)
+	}
+
+	return id, nil
+}
+
+// BulkTarget is a guest requested by ID for a bulk action.
+type BulkTarget struct {
+	ID int
+	// VM is nil when no guest has the ID.
+	VM *api.VM
+	// Skip is why the action is not applied to the guest, empty if it is.
+	Skip string
+}
+
+// ResolveBulkTargets looks up the guests with the given IDs among all guests,
+// regardless of the active filter. check returns why the action can't be
+// applied to a guest, or an empty string if it can.
+func ResolveBulkTargets(ids []int, check func(*api.VM) string) []BulkTarget {
+	byID := make(map[int]*api.VM, len(GlobalState.OriginalVMs))
+
+	for _, vm := range GlobalState.OriginalVMs {
+		if vm != nil {
+			byID[vm.ID] = vm
+		}
+	}
+
+	targets := make([]BulkTarget, 0, len(ids))
+
+	for _, id := range ids {
+		target := BulkTarget{ID: id, VM: byID[id]}
+
+		switch {
+		case target.VM == nil:
+			target.Skip = "not found"
+		case check != nil:
+			target.Skip = check(target.VM)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
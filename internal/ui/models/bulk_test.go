@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestParseVMIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr string
+	}{
+		{"empty", "  ", nil, ""},
+		{"list and range", "101,104-106", []int{101, 104, 105, 106}, ""},
+		{"pasted lines", "101\n102\r\n 103;104\t105", []int{101, 102, 103, 104, 105}, ""},
+		{"duplicates", "105, 104-106, 101", []int{105, 104, 106, 101}, ""},
+		{"not a number", "101,web", nil, `"web" is not a guest ID`},
+		{"below 100", "99", nil, `"99" is not a guest ID`},
+		{"reversed range", "110-104", nil, "range 110-104 is reversed"},
+		{"huge range", "100-100000", nil, "more than 1000 IDs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := ParseVMIDs(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestResolveBulkTargets(t *testing.T) {
+	vms := GlobalState.OriginalVMs
+	defer func() { GlobalState.OriginalVMs = vms }()
+
+	running := &api.VM{ID: 101, Name: "web", Status: api.VMStatusRunning}
+	stopped := &api.VM{ID: 102, Name: "db", Status: api.VMStatusStopped}
+	GlobalState.OriginalVMs = []*api.VM{running, stopped}
+
+	targets := ResolveBulkTargets([]int{101, 102, 103}, func(vm *api.VM) string {
+		if vm.Status != api.VMStatusRunning {
+			return "not running"
+		}
+
+		return ""
+	})
+
+	assert.Equal(t, []BulkTarget{
+		{ID: 101, VM: running},
+		{ID: 102, VM: stopped, Skip: "not running"},
+		{ID: 103, Skip: "not found"},
+	}, targets)
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// bytesPerGiB converts the node memory figures, which are reported in GiB.
+const bytesPerGiB = 1073741824
+
+// NodeCapacity compares the resources of a node with what its guests are
+// configured to use. Allocated figures cover all guests except templates,
+// running figures only the running ones.
+type NodeCapacity struct {
+	Node   string
+	Online bool
+
+	Guests        int
+	RunningGuests int
+
+	CPUs          int     // Physical CPU threads of the node
+	CPUUsage      float64 // Current node CPU usage (0.0-1.0)
+	AllocatedCPUs int     // vCPUs of all guests
+	RunningCPUs   int     // vCPUs of the running guests
+
+	MemoryTotal     int64 // Bytes
+	MemoryUsed      int64 // Bytes
+	AllocatedMemory int64 // Configured memory of all guests, in bytes
+	RunningMemory   int64 // Configured memory of the running guests, in bytes
+
+	DiskTotal       int64 // Size of the storages that hold guest disks, in bytes
+	DiskUsed        int64 // Bytes
+	ProvisionedDisk int64 // Configured disk size of all guests, in bytes
+}
+
+// CPUOvercommit is the ratio of vCPUs allocated to the guests to the node CPUs.
+func (c NodeCapacity) CPUOvercommit() float64 {
+	return ratio(float64(c.AllocatedCPUs), float64(c.CPUs))
+}
+
+// MemoryOvercommit is the ratio of memory allocated to the guests to the node memory.
+func (c NodeCapacity) MemoryOvercommit() float64 {
+	return ratio(float64(c.AllocatedMemory), float64(c.MemoryTotal))
+}
+
+// DiskOvercommit is the ratio of provisioned guest disk to the guest storage size.
+func (c NodeCapacity) DiskOvercommit() float64 {
+	return ratio(float64(c.ProvisionedDisk), float64(c.DiskTotal))
+}
+
+// MemoryHeadroom is the memory left for new guests: the node memory minus the
+// larger of the memory in use and the memory configured for running guests.
+func (c NodeCapacity) MemoryHeadroom() int64 {
+	committed := c.MemoryUsed
+	if c.RunningMemory > committed {
+		committed = c.RunningMemory
+	}
+
+	if headroom := c.MemoryTotal - committed; headroom > 0 {
+		return headroom
+	}
+
+	return 0
+}
+
+// GuestsFitting returns how many more guests with the given memory fit in the
+// memory headroom.
+func (c NodeCapacity) GuestsFitting(memory int64) int {
+	if memory <= 0 {
+		return 0
+	}
+
+	return int(c.MemoryHeadroom() / memory)
+}
+
+// ratio returns part/total, or 0 if total is unknown.
+func ratio(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return part / total
+}
+
+// CollectCapacity computes the capacity of every node, sorted by node name.
+// Shared storages count towards the disk size of every node they are
+// available on.
+func CollectCapacity(nodes []*api.Node, vms []*api.VM) []NodeCapacity {
+	byNode := make(map[string]*NodeCapacity, len(nodes))
+
+	var capacities []*NodeCapacity
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		capacity := &NodeCapacity{
+			Node:        node.Name,
+			Online:      node.Online,
+			CPUs:        int(node.CPUCount),
+			CPUUsage:    node.CPUUsage,
+			MemoryTotal: int64(node.MemoryTotal * bytesPerGiB),
+			MemoryUsed:  int64(node.MemoryUsed * bytesPerGiB),
+		}
+
+		for _, storage := range node.Storage {
+			if storage == nil || !holdsGuestDisks(storage) {
+				continue
+			}
+
+			capacity.DiskTotal += storage.MaxDisk
+			capacity.DiskUsed += storage.Disk
+		}
+
+		byNode[node.Name] = capacity
+		capacities = append(capacities, capacity)
+	}
+
+	for _, vm := range vms {
+		if vm == nil || vm.Template {
+			continue
+		}
+
+		capacity, ok := byNode[vm.Node]
+		if !ok {
+			continue
+		}
+
+		capacity.Guests++
+		capacity.AllocatedCPUs += vm.MaxCPU
+		capacity.AllocatedMemory += vm.MaxMem
+		capacity.ProvisionedDisk += vm.MaxDisk
+
+		if vm.Status == api.VMStatusRunning {
+			capacity.RunningGuests++
+			capacity.RunningCPUs += vm.MaxCPU
+			capacity.RunningMemory += vm.MaxMem
+		}
+	}
+
+	sort.Slice(capacities, func(i, j int) bool {
+		return capacities[i].Node < capacities[j].Node
+	})
+
+	result := make([]NodeCapacity, len(capacities))
+	for i, capacity := range capacities {
+		result[i] = *capacity
+	}
+
+	return result
+}
+
+// holdsGuestDisks reports whether a storage holds VM disks or container volumes.
+func holdsGuestDisks(storage *api.Storage) bool {
+	for _, content := range strings.Split(storage.Content, ",") {
+		if content == "images" || content == "rootdir" {
+			return true
+		}
+	}
+
+	return false
+}
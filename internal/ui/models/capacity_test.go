@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCollectCapacity(t *testing.T) {
+	const gib = int64(bytesPerGiB)
+
+	nodes := []*api.Node{
+		{Name: "pve2", Online: true, CPUCount: 8, MemoryTotal: 32, MemoryUsed: 8},
+		{Name: "pve1", Online: true, CPUCount: 16, MemoryTotal: 64, MemoryUsed: 20, Storage: []*api.Storage{
+			{Name: "local", Content: "iso,vztmpl,backup", Disk: 10 * gib, MaxDisk: 100 * gib},
+			{Name: "local-lvm", Content: "images,rootdir", Disk: 200 * gib, MaxDisk: 500 * gib},
+			{Name: "ceph", Content: "images", Shared: 1, Disk: 100 * gib, MaxDisk: 1000 * gib},
+		}},
+	}
+
+	vms := []*api.VM{
+		{ID: 100, Node: "pve1", Status: api.VMStatusRunning, MaxCPU: 8, MaxMem: 32 * gib, MaxDisk: 300 * gib},
+		{ID: 101, Node: "pve1", Status: api.VMStatusStopped, MaxCPU: 16, MaxMem: 64 * gib, MaxDisk: 1200 * gib},
+		{ID: 102, Node: "pve1", Status: api.VMStatusStopped, MaxCPU: 4, MaxMem: 8 * gib, Template: true},
+		{ID: 103, Node: "pve3", Status: api.VMStatusRunning, MaxCPU: 2, MaxMem: 2 * gib},
+	}
+
+	capacities := CollectCapacity(nodes, vms)
+	require.Len(t, capacities, 2)
+
+	pve1 := capacities[0]
+	assert.Equal(t, "pve1", pve1.Node)
+	assert.Equal(t, 2, pve1.Guests)
+	assert.Equal(t, 1, pve1.RunningGuests)
+	assert.Equal(t, 24, pve1.AllocatedCPUs)
+	assert.Equal(t, 8, pve1.RunningCPUs)
+	assert.InDelta(t, 1.5, pve1.CPUOvercommit(), 0.001)
+	assert.InDelta(t, 1.5, pve1.MemoryOvercommit(), 0.001)
+	assert.Equal(t, 1500*gib, pve1.DiskTotal)
+	assert.InDelta(t, 1.0, pve1.DiskOvercommit(), 0.001)
+
+	// The running guest reserves more than is currently in use
+	assert.Equal(t, 32*gib, pve1.MemoryHeadroom())
+	assert.Equal(t, 4, pve1.GuestsFitting(8*gib))
+
+	pve2 := capacities[1]
+	assert.Zero(t, pve2.Guests)
+	assert.Zero(t, pve2.DiskOvercommit())
+	assert.Equal(t, 24*gib, pve2.MemoryHeadroom())
+	assert.Zero(t, pve2.GuestsFitting(0))
+}
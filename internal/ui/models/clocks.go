@@ -0,0 +1,128 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// clockIndex holds how far the clock of every node is ahead of the local
+// clock.
+type clockIndex struct {
+	mu        sync.RWMutex
+	offsets   map[string]time.Duration // Key: node name
+	checkedAt time.Time
+}
+
+var clocks clockIndex
+
+// SetNodeClocks replaces the clock offsets of the nodes, keyed by node name.
+func SetNodeClocks(offsets map[string]time.Duration, now time.Time) {
+	clocks.mu.Lock()
+	defer clocks.mu.Unlock()
+
+	clocks.offsets = offsets
+	clocks.checkedAt = now
+}
+
+// ResetNodeClocks forgets the clock offsets, e.g. after switching to
+// another cluster.
+func ResetNodeClocks() {
+	SetNodeClocks(nil, time.Time{})
+}
+
+// NodeClocksCheckedAt returns when the node clocks were last read, zero if
+// they weren't yet.
+func NodeClocksCheckedAt() time.Time {
+	clocks.mu.RLock()
+	defer clocks.mu.RUnlock()
+
+	return clocks.checkedAt
+}
+
+// ClockSkew is how far a node's clock is ahead of the cluster, negative if
+// it is behind.
+type ClockSkew struct {
+	Node string
+	Skew time.Duration
+}
+
+// SkewedClocks returns the nodes whose clock differs from the cluster by
+// more than maxSkew, the largest difference first. Clocks are compared with
+// the median clock, so the local clock doesn't matter and a single node is
+// never skewed. Nothing is returned while maxSkew is 0.
+func SkewedClocks(maxSkew time.Duration) []ClockSkew {
+	clocks.mu.RLock()
+	defer clocks.mu.RUnlock()
+
+	return skewedClocks(clocks.offsets, maxSkew)
+}
+
+// skewedClocks implements SkewedClocks for the given offsets.
+func skewedClocks(offsets map[string]time.Duration, maxSkew time.Duration) []ClockSkew {
+	if maxSkew <= 0 || len(offsets) < 2 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, 0, len(offsets))
+	for _, offset := range offsets {
+		sorted = append(sorted, offset)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// With an even count the lower middle clock is the reference, so two
+	// nodes are compared with each other rather than with their average
+	median := sorted[(len(sorted)-1)/2]
+
+	var skewed []ClockSkew
+
+	for node, offset := range offsets {
+		if skew := offset - median; skew > maxSkew || skew < -maxSkew {
+			skewed = append(skewed, ClockSkew{Node: node, Skew: skew})
+		}
+	}
+
+	sort.Slice(skewed, func(i, j int) bool {
+		a, b := skewed[i].Skew.Abs(), skewed[j].Skew.Abs()
+		if a != b {
+			return a > b
+		}
+
+		return skewed[i].Node < skewed[j].Node
+	})
+
+	return skewed
+}
+
+// CollectClockAlerts returns an alert for every node whose clock is out of
+// sync by more than maxSkew.
+func CollectClockAlerts(maxSkew time.Duration) []Alert {
+	skewed := SkewedClocks(maxSkew)
+	alerts := make([]Alert, 0, len(skewed))
+
+	for _, clock := range skewed {
+		alerts = append(alerts, Alert{
+			Kind:    AlertClockSkew,
+			Message: fmt.Sprintf("node %s clock %s", clock.Node, FormatSkew(clock.Skew)),
+			Node:    clock.Node,
+		})
+	}
+
+	return alerts
+}
+
+// FormatSkew describes a clock difference in whole seconds, e.g.
+// "4s ahead" or "2m5s behind".
+func FormatSkew(skew time.Duration) string {
+	direction := "ahead"
+	if skew < 0 {
+		direction = "behind"
+	}
+
+	seconds := time.Duration(math.Round(skew.Abs().Seconds())) * time.Second
+
+	return fmt.Sprintf("%s %s", seconds, direction)
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkewedClocks(t *testing.T) {
+	ResetNodeClocks()
+	t.Cleanup(ResetNodeClocks)
+
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	maxSkew := 2 * time.Second
+
+	// Nothing is flagged before the clocks were read
+	assert.Empty(t, CollectClockAlerts(maxSkew))
+
+	// The local clock is a minute behind every node, which doesn't matter
+	SetNodeClocks(map[string]time.Duration{
+		"pve1": time.Minute,
+		"pve2": time.Minute + 500*time.Millisecond,
+		"pve3": time.Minute + 4*time.Second,
+		"pve4": time.Minute - 125*time.Second,
+		"pve5": time.Minute - time.Second,
+	}, now)
+
+	assert.Equal(t, now, NodeClocksCheckedAt())
+
+	alerts := CollectClockAlerts(maxSkew)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "node pve4 clock 2m5s behind", alerts[0].Message)
+	assert.Equal(t, "node pve3 clock 4s ahead", alerts[1].Message)
+	assert.Equal(t, AlertClockSkew, alerts[1].Kind)
+	assert.Equal(t, "pve3", alerts[1].Node)
+
+	// A zero threshold disables the check
+	assert.Empty(t, CollectClockAlerts(0))
+}
+
+func TestSkewedClocks_TwoNodes(t *testing.T) {
+	// Two nodes are compared with each other, not with their average
+	skewed := skewedClocks(map[string]time.Duration{"pve1": 0, "pve2": 3 * time.Second}, 2*time.Second)
+	assert.Equal(t, []ClockSkew{{Node: "pve2", Skew: 3 * time.Second}}, skewed)
+
+	// A single node is never skewed
+	assert.Empty(t, skewedClocks(map[string]time.Duration{"pve1": time.Hour}, 2*time.Second))
+}
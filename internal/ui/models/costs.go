@@ -0,0 +1,160 @@
+package models
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// CostGrouping selects what the cost report sums costs by.
+type CostGrouping string
+
+// Cost report groupings.
+const (
+	CostByPool CostGrouping = "pool"
+	CostByTag  CostGrouping = "tag"
+)
+
+// Names of the groups of guests without a pool or tags.
+const (
+	CostNoPool   = "(no pool)"
+	CostUntagged = "(untagged)"
+)
+
+// CostGroup sums the resources configured for the guests of a pool or tag
+// and what they cost.
+type CostGroup struct {
+	Name    string
+	Guests  int
+	VCPUs   int
+	Memory  int64 // Bytes
+	Storage int64 // Bytes
+
+	CPUCost     float64
+	MemoryCost  float64
+	StorageCost float64
+}
+
+// Total returns the cost of all resources of the group.
+func (g CostGroup) Total() float64 {
+	return g.CPUCost + g.MemoryCost + g.StorageCost
+}
+
+// add counts a guest towards the group.
+func (g *CostGroup) add(vm *api.VM, rates config.CostsConfig) {
+	g.Guests++
+	g.VCPUs += vm.MaxCPU
+	g.Memory += vm.MaxMem
+	g.Storage += vm.MaxDisk
+
+	g.CPUCost += float64(vm.MaxCPU) * rates.VCPU
+	g.MemoryCost += float64(vm.MaxMem) / bytesPerGiB * rates.MemoryGB
+	g.StorageCost += float64(vm.MaxDisk) / bytesPerGiB * rates.StorageGB
+}
+
+// costGroupNames returns the pool or tags a guest is charged to.
+func costGroupNames(vm *api.VM, by CostGrouping) []string {
+	if by == CostByTag {
+		tags := strings.FieldsFunc(vm.Tags, func(r rune) bool {
+			return r == ';' || r == ',' || r == ' '
+		})
+		if len(tags) == 0 {
+			return []string{CostUntagged}
+		}
+
+		return tags
+	}
+
+	if vm.Pool == "" {
+		return []string{CostNoPool}
+	}
+
+	return []string{vm.Pool}
+}
+
+// CollectCosts charges the vCPUs, memory and disk configured for every
+// guest except templates, whether running or not, to its pool or to each of
+// its tags, so with tags a guest can count towards several groups. Groups
+// are sorted by total cost, most expensive first. The total of all guests
+// is returned separately.
+func CollectCosts(vms []*api.VM, rates config.CostsConfig, by CostGrouping) ([]CostGroup, CostGroup) {
+	byName := make(map[string]*CostGroup)
+	total := CostGroup{Name: "Total"}
+
+	for _, vm := range vms {
+		if vm == nil || vm.Template {
+			continue
+		}
+
+		total.add(vm, rates)
+
+		for _, name := range costGroupNames(vm, by) {
+			group, ok := byName[name]
+			if !ok {
+				group = &CostGroup{Name: name}
+				byName[name] = group
+			}
+
+			group.add(vm, rates)
+		}
+	}
+
+	groups := make([]CostGroup, 0, len(byName))
+	for _, group := range byName {
+		groups = append(groups, *group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Total() != groups[j].Total() {
+			return groups[i].Total() > groups[j].Total()
+		}
+
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups, total
+}
+
+// WriteCostsCSV writes the groups and the total as CSV with a header row.
+// Memory and storage are given in GiB, costs with two decimals.
+func WriteCostsCSV(w io.Writer, groups []CostGroup, total CostGroup, by CostGrouping) error {
+	out := csv.NewWriter(w)
+
+	if err := out.Write([]string{string(by), "guests", "vcpus", "memory_gib", "storage_gib",
+		"cpu_cost", "memory_cost", "storage_cost", "total_cost"}); err != nil {
+		return err
+	}
+
+	gib := func(bytes int64) string {
+		return strconv.FormatFloat(float64(bytes)/bytesPerGiB, 'f', 2, 64)
+	}
+	cost := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+
+	for _, group := range append(slices.Clip(groups), total) {
+		if err := out.Write([]string{
+			group.Name,
+			strconv.Itoa(group.Guests),
+			strconv.Itoa(group.VCPUs),
+			gib(group.Memory),
+			gib(group.Storage),
+			cost(group.CPUCost),
+			cost(group.MemoryCost),
+			cost(group.StorageCost),
+			cost(group.Total()),
+		}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+
+	return out.Error()
+}
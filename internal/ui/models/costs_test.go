@@ -0,0 +1,54 @@
+package models
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestCollectCosts(t *testing.T) {
+	rates := config.CostsConfig{VCPU: 5, MemoryGB: 2, StorageGB: 0.1}
+	vms := []*api.VM{
+		{ID: 101, Pool: "web", Tags: "prod;frontend", MaxCPU: 2, MaxMem: 4 * bytesPerGiB, MaxDisk: 20 * bytesPerGiB, Status: api.VMStatusRunning},
+		{ID: 102, Pool: "web", Tags: "prod", MaxCPU: 1, MaxMem: 2 * bytesPerGiB, MaxDisk: 10 * bytesPerGiB},
+		{ID: 103, MaxCPU: 4, MaxMem: 8 * bytesPerGiB},
+		{ID: 9000, Pool: "web", Template: true, MaxCPU: 8},
+	}
+
+	groups, total := CollectCosts(vms, rates, CostByPool)
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, CostNoPool, groups[0].Name)
+	assert.InDelta(t, 36, groups[0].Total(), 0.001)
+
+	assert.Equal(t, "web", groups[1].Name)
+	assert.Equal(t, 2, groups[1].Guests)
+	assert.Equal(t, 3, groups[1].VCPUs)
+	assert.InDelta(t, 15, groups[1].CPUCost, 0.001)
+	assert.InDelta(t, 12, groups[1].MemoryCost, 0.001)
+	assert.InDelta(t, 3, groups[1].StorageCost, 0.001)
+	assert.InDelta(t, 30, groups[1].Total(), 0.001)
+
+	assert.InDelta(t, 66, total.Total(), 0.001)
+	assert.Equal(t, 3, total.Guests)
+
+	// Guests count towards each of their tags
+	groups, total = CollectCosts(vms, rates, CostByTag)
+	require.Len(t, groups, 3)
+	assert.Equal(t, CostUntagged, groups[0].Name)
+	assert.Equal(t, "prod", groups[1].Name)
+	assert.Equal(t, 2, groups[1].Guests)
+	assert.Equal(t, "frontend", groups[2].Name)
+	assert.InDelta(t, 66, total.Total(), 0.001)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteCostsCSV(&out, groups[2:], total, CostByTag))
+	assert.Equal(t, "tag,guests,vcpus,memory_gib,storage_gib,cpu_cost,memory_cost,storage_cost,total_cost\n"+
+		"frontend,1,2,4.00,20.00,10.00,8.00,2.00,20.00\n"+
+		"Total,3,7,14.00,30.00,35.00,28.00,3.00,66.00\n", out.String())
+}
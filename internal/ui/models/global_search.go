@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// GlobalSearchKind identifies the entity type of a global search result.
+type GlobalSearchKind int
+
+// Entity types returned by GlobalSearch, in the order they are displayed.
+const (
+	GlobalSearchNode GlobalSearchKind = iota
+	GlobalSearchGuest
+	GlobalSearchStorage
+	GlobalSearchTask
+)
+
+// String returns the category heading for the kind.
+func (k GlobalSearchKind) String() string {
+	switch k {
+	case GlobalSearchNode:
+		return "Nodes"
+	case GlobalSearchGuest:
+		return "Guests"
+	case GlobalSearchStorage:
+		return "Storages"
+	case GlobalSearchTask:
+		return "Tasks"
+	default:
+		return "Unknown"
+	}
+}
+
+// GlobalSearchResult is a single match from a cluster-wide search.
+// Exactly one of the entity pointers is set, according to Kind.
+type GlobalSearchResult struct {
+	Kind    GlobalSearchKind
+	Node    *api.Node
+	VM      *api.VM
+	Storage *api.Storage
+	Task    *api.ClusterTask
+}
+
+// GlobalSearchResults groups cluster-wide search matches by entity type.
+type GlobalSearchResults struct {
+	Nodes    []GlobalSearchResult
+	Guests   []GlobalSearchResult
+	Storages []GlobalSearchResult
+	Tasks    []GlobalSearchResult
+}
+
+// Total returns the total number of matches across all categories.
+func (r *GlobalSearchResults) Total() int {
+	return len(r.Nodes) + len(r.Guests) + len(r.Storages) + len(r.Tasks)
+}
+
+// GlobalSearch matches the query against the unfiltered nodes, guests and tasks
+// in GlobalState as well as the given storages. Matching uses the same rules
+// as the per-page filters. An empty query returns no results.
+func GlobalSearch(query string, storages []*api.Storage) *GlobalSearchResults {
+	results := &GlobalSearchResults{}
+
+	filter := strings.ToLower(strings.TrimSpace(query))
+	if filter == "" {
+		return results
+	}
+
+	for _, node := range GlobalState.OriginalNodes {
+		if nodeMatches(node, filter) {
+			results.Nodes = append(results.Nodes, GlobalSearchResult{Kind: GlobalSearchNode, Node: node})
+		}
+	}
+
+	for _, vm := range GlobalState.OriginalVMs {
+		if vmMatches(vm, filter) {
+			results.Guests = append(results.Guests, GlobalSearchResult{Kind: GlobalSearchGuest, VM: vm})
+		}
+	}
+
+	for _, storage := range storages {
+		if storageMatches(storage, filter) {
+			results.Storages = append(results.Storages, GlobalSearchResult{Kind: GlobalSearchStorage, Storage: storage})
+		}
+	}
+
+	for _, task := range GlobalState.OriginalTasks {
+		if taskMatches(task, filter) {
+			results.Tasks = append(results.Tasks, GlobalSearchResult{Kind: GlobalSearchTask, Task: task})
+		}
+	}
+
+	return results
+}
+
+// storageMatches reports whether a storage matches a lowercase filter by name, node, type or content.
+func storageMatches(storage *api.Storage, filter string) bool {
+	if storage == nil {
+		return false
+	}
+
+	return containsAny(filter, storage.Name, storage.Node, storage.Plugintype, storage.Content)
+}
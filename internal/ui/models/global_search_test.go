@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGlobalSearch(t *testing.T) {
+	nodes, vms, tasks := GlobalState.OriginalNodes, GlobalState.OriginalVMs, GlobalState.OriginalTasks
+	defer func() {
+		GlobalState.OriginalNodes, GlobalState.OriginalVMs, GlobalState.OriginalTasks = nodes, vms, tasks
+	}()
+
+	GlobalState.OriginalNodes = []*api.Node{
+		{Name: "pve1", IP: "10.0.0.1", Online: true},
+		{Name: "pve2", IP: "10.0.0.2", Online: false},
+	}
+	GlobalState.OriginalVMs = []*api.VM{
+		{ID: 100, Name: "web", Node: "pve1", Type: api.VMTypeQemu, Status: api.VMStatusRunning},
+		{ID: 200, Name: "db", Node: "pve2", Type: api.VMTypeLXC, Status: api.VMStatusStopped},
+	}
+	GlobalState.OriginalTasks = []*api.ClusterTask{
+		{UPID: "UPID:pve1:1:1:1:qmstart:100:root@pam:", Node: "pve1", Type: "qmstart", ID: "100"},
+	}
+	storages := []*api.Storage{
+		{Name: "local-lvm", Node: "pve1", Plugintype: "lvmthin"},
+		{Name: "backup-nfs", Node: "pve2", Plugintype: "nfs"},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		nodes    int
+		guests   int
+		storages int
+		tasks    int
+	}{
+		{name: "empty query", query: "  "},
+		{name: "node name", query: "pve1", nodes: 1, guests: 1, storages: 1, tasks: 1},
+		{name: "guest ID", query: "200", guests: 1},
+		{name: "case insensitive", query: "WEB", guests: 1},
+		{name: "storage type", query: "nfs", storages: 1},
+		{name: "task type", query: "qmstart", tasks: 1},
+		{name: "no match", query: "nothing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := GlobalSearch(tt.query, storages)
+			assert.Len(t, results.Nodes, tt.nodes)
+			assert.Len(t, results.Guests, tt.guests)
+			assert.Len(t, results.Storages, tt.storages)
+			assert.Len(t, results.Tasks, tt.tasks)
+			assert.Equal(t, tt.nodes+tt.guests+tt.storages+tt.tasks, results.Total())
+		})
+	}
+}
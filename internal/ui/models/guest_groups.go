@@ -0,0 +1,50 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// GroupStep is a guest of a guest group with the loaded guest it refers to.
+// VM is nil when no guest with the ID is loaded.
+type GroupStep struct {
+	config.GroupGuest
+	VM *api.VM
+}
+
+// GroupSteps returns the guests of group in start order, or in shutdown
+// order (the reverse) when stop is set, looked up in vms.
+func GroupSteps(group config.GuestGroup, vms []*api.VM, stop bool) []GroupStep {
+	byID := make(map[int]*api.VM, len(vms))
+	for _, vm := range vms {
+		if vm != nil && !vm.Template {
+			byID[vm.ID] = vm
+		}
+	}
+
+	steps := make([]GroupStep, len(group.Guests))
+	for i, guest := range group.Guests {
+		steps[i] = GroupStep{GroupGuest: guest, VM: byID[guest.ID]}
+	}
+
+	if stop {
+		slices.Reverse(steps)
+	}
+
+	return steps
+}
+
+// GuestGroupsOf returns the groups the guest with the given VMID is in.
+func GuestGroupsOf(groups []config.GuestGroup, id int) []config.GuestGroup {
+	var found []config.GuestGroup
+
+	for _, group := range groups {
+		if group.Contains(id) {
+			found = append(found, group)
+		}
+	}
+
+	return found
+}
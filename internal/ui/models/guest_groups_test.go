@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGroupSteps(t *testing.T) {
+	db := &api.VM{ID: 101, Name: "db"}
+	app := &api.VM{ID: 102, Name: "app"}
+	group := config.GuestGroup{Name: "lab", Guests: []config.GroupGuest{
+		{ID: 101, Wait: config.GroupWaitTCP, Port: 5432},
+		{ID: 102},
+		{ID: 103},
+	}}
+
+	steps := GroupSteps(group, []*api.VM{app, db}, false)
+	require.Len(t, steps, 3)
+	assert.Same(t, db, steps[0].VM)
+	assert.Equal(t, 5432, steps[0].Port)
+	assert.Same(t, app, steps[1].VM)
+	assert.Nil(t, steps[2].VM)
+
+	steps = GroupSteps(group, []*api.VM{app, db}, true)
+	assert.Equal(t, 103, steps[0].ID)
+	assert.Same(t, db, steps[2].VM)
+}
+
+func TestGuestGroupsOf(t *testing.T) {
+	groups := []config.GuestGroup{
+		{Name: "lab", Guests: []config.GroupGuest{{ID: 101}, {ID: 102}}},
+		{Name: "web", Guests: []config.GroupGuest{{ID: 102}}},
+	}
+
+	assert.Len(t, GuestGroupsOf(groups, 102), 2)
+	assert.Equal(t, "lab", GuestGroupsOf(groups, 101)[0].Name)
+	assert.Empty(t, GuestGroupsOf(groups, 104))
+}
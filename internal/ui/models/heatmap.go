@@ -0,0 +1,87 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// HeatmapMetric is the usage the cells of the heatmap are colored by.
+type HeatmapMetric string
+
+// Heatmap metrics.
+const (
+	HeatmapCPU    HeatmapMetric = "CPU"
+	HeatmapMemory HeatmapMetric = "memory"
+)
+
+// HeatmapNode is a node of the heatmap with its guests.
+type HeatmapNode struct {
+	Node   *api.Node
+	Guests []*api.VM // Sorted by ID
+}
+
+// GroupHeatmap groups the guests by node, both sorted by name and ID.
+// Templates are left out; nodes without guests are kept so offline nodes
+// still show up.
+func GroupHeatmap(nodes []*api.Node, vms []*api.VM) []HeatmapNode {
+	index := make(map[string]int, len(nodes))
+	groups := make([]HeatmapNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		index[node.Name] = len(groups)
+		groups = append(groups, HeatmapNode{Node: node})
+	}
+
+	for _, vm := range vms {
+		if vm == nil || vm.Template {
+			continue
+		}
+
+		i, ok := index[vm.Node]
+		if !ok {
+			continue
+		}
+
+		groups[i].Guests = append(groups[i].Guests, vm)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Node.Name < groups[j].Node.Name })
+
+	for _, group := range groups {
+		sort.Slice(group.Guests, func(i, j int) bool { return group.Guests[i].ID < group.Guests[j].ID })
+	}
+
+	return groups
+}
+
+// GuestUsage returns the CPU or memory usage of a running guest in percent,
+// and false for guests that aren't running.
+func GuestUsage(vm *api.VM, metric HeatmapMetric) (float64, bool) {
+	if vm.Status != api.VMStatusRunning {
+		return 0, false
+	}
+
+	if metric == HeatmapMemory {
+		if vm.MaxMem <= 0 {
+			return 0, true
+		}
+
+		return float64(vm.Mem) / float64(vm.MaxMem) * 100, true
+	}
+
+	return vm.CPU * 100, true
+}
+
+// NodeUsage returns the CPU or memory usage of a node in percent.
+func NodeUsage(node *api.Node, metric HeatmapMetric) float64 {
+	if metric == HeatmapMemory {
+		return ratio(node.MemoryUsed, node.MemoryTotal) * 100
+	}
+
+	return node.CPUUsage * 100
+}
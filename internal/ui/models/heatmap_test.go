@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestGroupHeatmap(t *testing.T) {
+	nodes := []*api.Node{
+		{Name: "pve2", Online: true, CPUUsage: 0.5, MemoryTotal: 64, MemoryUsed: 48},
+		{Name: "pve1", Online: true},
+		{Name: "pve3"},
+	}
+	vms := []*api.VM{
+		{ID: 103, Node: "pve1", Status: api.VMStatusRunning, CPU: 0.25, Mem: 512, MaxMem: 2048},
+		{ID: 101, Node: "pve1", Status: api.VMStatusStopped},
+		{ID: 9000, Node: "pve1", Template: true},
+		{ID: 102, Node: "pve2", Status: api.VMStatusRunning},
+		{ID: 104, Node: "gone", Status: api.VMStatusRunning},
+	}
+
+	groups := GroupHeatmap(nodes, vms)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "pve1", groups[0].Node.Name)
+	require.Len(t, groups[0].Guests, 2)
+	assert.Equal(t, 101, groups[0].Guests[0].ID)
+	assert.Equal(t, 103, groups[0].Guests[1].ID)
+	assert.Len(t, groups[1].Guests, 1)
+	assert.Empty(t, groups[2].Guests)
+
+	usage, running := GuestUsage(vms[0], HeatmapCPU)
+	assert.True(t, running)
+	assert.InDelta(t, 25, usage, 0.001)
+
+	usage, _ = GuestUsage(vms[0], HeatmapMemory)
+	assert.InDelta(t, 25, usage, 0.001)
+
+	_, running = GuestUsage(vms[1], HeatmapCPU)
+	assert.False(t, running)
+
+	assert.InDelta(t, 50, NodeUsage(nodes[0], HeatmapCPU), 0.001)
+	assert.InDelta(t, 75, NodeUsage(nodes[0], HeatmapMemory), 0.001)
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// metadataFilterPattern matches "key:value" and "key=value" search filters.
+var metadataFilterPattern = regexp.MustCompile(`^([a-z][\w-]*)[:=](.*)$`)
+
+// metadataPatterns extract key/value metadata from guest tags and notes.
+var (
+	metadataMu       sync.RWMutex
+	metadataPatterns []*regexp.Regexp
+)
+
+// SetMetadataPatterns sets the regular expressions used to extract guest
+// metadata. Each pattern is matched against every tag and every notes line;
+// its first two capture groups are the key and the value.
+func SetMetadataPatterns(patterns []string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid metadata pattern %q: %w", pattern, err)
+		}
+
+		if re.NumSubexp() < 2 {
+			return fmt.Errorf("metadata pattern %q needs two capture groups (key and value)", pattern)
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	metadataMu.Lock()
+	metadataPatterns = compiled
+	metadataMu.Unlock()
+
+	return nil
+}
+
+// GuestMetadata returns the metadata of a guest parsed from its tags and
+// notes, keyed by lowercase key. Values from notes win over values from tags.
+func GuestMetadata(vm *api.VM) map[string]string {
+	metadata := make(map[string]string)
+
+	if vm == nil {
+		return metadata
+	}
+
+	metadataMu.RLock()
+	patterns := metadataPatterns
+	metadataMu.RUnlock()
+
+	if len(patterns) == 0 {
+		return metadata
+	}
+
+	tags := strings.FieldsFunc(vm.Tags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+
+	for _, source := range [][]string{tags, strings.Split(vm.Description, "\n")} {
+		for _, text := range source {
+			for _, re := range patterns {
+				match := re.FindStringSubmatch(strings.TrimSpace(text))
+				if match == nil || match[1] == "" {
+					continue
+				}
+
+				metadata[strings.ToLower(match[1])] = strings.TrimSpace(match[2])
+
+				break
+			}
+		}
+	}
+
+	return metadata
+}
+
+// FormatGuestMetadata formats the values of the given metadata keys as
+// "key=value" pairs, skipping keys the guest does not have.
+func FormatGuestMetadata(vm *api.VM, keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+
+	metadata := GuestMetadata(vm)
+
+	var parts []string
+
+	for _, key := range keys {
+		if value, ok := metadata[strings.ToLower(key)]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// SortedMetadataKeys returns the keys of metadata in alphabetical order.
+func SortedMetadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// metadataMatches reports whether a lowercase "key:value" or "key=value"
+// filter matches the guest metadata. ok is false for other filters.
+func metadataMatches(vm *api.VM, filter string) (matches, ok bool) {
+	match := metadataFilterPattern.FindStringSubmatch(filter)
+	if match == nil {
+		return false, false
+	}
+
+	value, found := GuestMetadata(vm)[match[1]]
+
+	return found && strings.Contains(strings.ToLower(value), match[2]), true
+}
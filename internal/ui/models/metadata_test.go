@@ -0,0 +1,101 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func useMetadataPatterns(t *testing.T, patterns ...string) {
+	t.Helper()
+
+	require.NoError(t, SetMetadataPatterns(patterns))
+	t.Cleanup(func() { _ = SetMetadataPatterns(nil) })
+}
+
+func TestGuestMetadata(t *testing.T) {
+	useMetadataPatterns(t, config.DefaultMetadataPattern, `^(owner|env)-(.+)$`)
+
+	vm := &api.VM{
+		Tags:        "env-staging;web;owner-bob",
+		Description: "# Web server\n\n- Owner: alice\nTicket=OPS-42\nSee https://wiki.example.com",
+	}
+
+	assert.Equal(t, map[string]string{
+		"env":    "staging",
+		"owner":  "alice",
+		"ticket": "OPS-42",
+	}, GuestMetadata(vm))
+
+	assert.Equal(t, "owner=alice env=staging", FormatGuestMetadata(vm, []string{"owner", "env", "team"}))
+	assert.Equal(t, []string{"env", "owner", "ticket"}, SortedMetadataKeys(GuestMetadata(vm)))
+}
+
+func TestGuestMetadata_NoPatterns(t *testing.T) {
+	useMetadataPatterns(t)
+
+	assert.Empty(t, GuestMetadata(&api.VM{Description: "owner: alice"}))
+}
+
+func TestSetMetadataPatterns_Invalid(t *testing.T) {
+	assert.Error(t, SetMetadataPatterns([]string{"(owner"}))
+	assert.Error(t, SetMetadataPatterns([]string{"^owner-.+$"}))
+}
+
+func TestFilterVMs_Metadata(t *testing.T) {
+	useMetadataPatterns(t, config.DefaultMetadataPattern)
+
+	original, filtered := GlobalState.OriginalVMs, GlobalState.FilteredVMs
+	defer func() {
+		GlobalState.OriginalVMs, GlobalState.FilteredVMs = original, filtered
+	}()
+
+	alice := &api.VM{ID: 100, Name: "web", Description: "owner: alice\nenv: prod"}
+	bob := &api.VM{ID: 101, Name: "db", Description: "owner: bob\nenv: dev", Tags: "database"}
+	GlobalState.OriginalVMs = []*api.VM{alice, bob}
+
+	FilterVMs("owner:ali")
+	assert.Equal(t, []*api.VM{alice}, GlobalState.FilteredVMs)
+
+	FilterVMs("ENV=dev")
+	assert.Equal(t, []*api.VM{bob}, GlobalState.FilteredVMs)
+
+	FilterVMs("team:ops")
+	assert.Empty(t, GlobalState.FilteredVMs)
+
+	// Plain filters also match tags and metadata values
+	FilterVMs("database")
+	assert.Equal(t, []*api.VM{bob}, GlobalState.FilteredVMs)
+
+	FilterVMs("prod")
+	assert.Equal(t, []*api.VM{alice}, GlobalState.FilteredVMs)
+}
+
+func TestFilterVMs_Node(t *testing.T) {
+	original, filtered := GlobalState.OriginalVMs, GlobalState.FilteredVMs
+	defer func() {
+		GlobalState.OriginalVMs, GlobalState.FilteredVMs = original, filtered
+	}()
+
+	web := &api.VM{ID: 100, Name: "web", Node: "pve1"}
+	db := &api.VM{ID: 101, Name: "db", Node: "pve10"}
+	named := &api.VM{ID: 102, Name: "pve1-backup", Node: "pve2"}
+	GlobalState.OriginalVMs = []*api.VM{web, db, named}
+
+	FilterVMs(NodeFilter("PVE1"))
+	assert.Equal(t, []*api.VM{web}, GlobalState.FilteredVMs)
+
+	FilterVMs("pve1")
+	assert.Equal(t, []*api.VM{web, db, named}, GlobalState.FilteredVMs)
+
+	node, ok := FilteredNode(NodeFilter("pve2"))
+	assert.True(t, ok)
+	assert.Equal(t, "pve2", node)
+
+	_, ok = FilteredNode("owner:alice")
+	assert.False(t, ok)
+}
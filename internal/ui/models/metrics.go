@@ -0,0 +1,265 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// minMetricsInterval keeps refreshes that run back to back from recording
+// the same data twice.
+const minMetricsInterval = 2 * time.Second
+
+// MetricSample holds the usage of a node or guest at one refresh. Nodes
+// have no network and disk counters in the cluster resources, so their
+// rates stay zero.
+type MetricSample struct {
+	Time   time.Time
+	CPU    float64 // Percent of the available CPUs
+	Memory float64 // Percent of the available memory
+	// Rates in bytes per second since the previous sample; zero for the
+	// first sample of a series and after the counters were reset
+	NetIn     float64
+	NetOut    float64
+	DiskRead  float64
+	DiskWrite float64
+}
+
+// metricsSeries is a ring buffer of the latest samples of a node or guest.
+type metricsSeries struct {
+	samples []MetricSample
+	next    int // Index the next sample is written to
+	count   int
+
+	// Counters of the previous sample the rates are derived from
+	netIn, netOut, diskRead, diskWrite int64
+}
+
+// add appends a sample, overwriting the oldest one when the buffer is full.
+func (s *metricsSeries) add(sample MetricSample) {
+	s.samples[s.next] = sample
+	s.next = (s.next + 1) % len(s.samples)
+
+	if s.count < len(s.samples) {
+		s.count++
+	}
+}
+
+// list returns the samples, oldest first.
+func (s *metricsSeries) list() []MetricSample {
+	samples := make([]MetricSample, 0, s.count)
+
+	start := (s.next - s.count + len(s.samples)) % len(s.samples)
+	for i := range s.count {
+		samples = append(samples, s.samples[(start+i)%len(s.samples)])
+	}
+
+	return samples
+}
+
+// last returns the newest sample.
+func (s *metricsSeries) last() (MetricSample, bool) {
+	if s.count == 0 {
+		return MetricSample{}, false
+	}
+
+	return s.samples[(s.next-1+len(s.samples))%len(s.samples)], true
+}
+
+// resized returns a series with the given capacity holding the newest
+// samples of s.
+func (s *metricsSeries) resized(capacity int) *metricsSeries {
+	series := &metricsSeries{
+		samples:   make([]MetricSample, capacity),
+		netIn:     s.netIn,
+		netOut:    s.netOut,
+		diskRead:  s.diskRead,
+		diskWrite: s.diskWrite,
+	}
+
+	samples := s.list()
+	if len(samples) > capacity {
+		samples = samples[len(samples)-capacity:]
+	}
+
+	for _, sample := range samples {
+		series.add(sample)
+	}
+
+	return series
+}
+
+// metricsHistory keeps the latest samples of every node and guest, collected
+// from the data of the regular refreshes rather than the RRD endpoints.
+type metricsHistory struct {
+	mu       sync.RWMutex
+	capacity int
+	guests   map[int]*metricsSeries    // Key: VMID, which survives migrations
+	nodes    map[string]*metricsSeries // Key: node name
+	recorded time.Time
+}
+
+var metrics = metricsHistory{capacity: 90}
+
+// SetMetricsCapacity sets the number of samples kept per node and guest,
+// keeping the newest samples already recorded. Zero discards them all and
+// stops recording.
+func SetMetricsCapacity(capacity int) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	if capacity == metrics.capacity {
+		return
+	}
+
+	metrics.capacity = capacity
+
+	if capacity <= 0 {
+		metrics.guests, metrics.nodes = nil, nil
+
+		return
+	}
+
+	for id, series := range metrics.guests {
+		metrics.guests[id] = series.resized(capacity)
+	}
+
+	for name, series := range metrics.nodes {
+		metrics.nodes[name] = series.resized(capacity)
+	}
+}
+
+// ResetMetrics forgets all samples, e.g. after switching to another cluster.
+func ResetMetrics() {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	metrics.guests, metrics.nodes = nil, nil
+	metrics.recorded = time.Time{}
+}
+
+// RecordMetrics appends a sample for every online node and every guest
+// that isn't a template; stopped guests are recorded as idle. Nodes and
+// guests that are gone are forgotten. Calls within minMetricsInterval of the
+// previous one are ignored.
+func RecordMetrics(nodes []*api.Node, vms []*api.VM, now time.Time) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	if metrics.capacity <= 0 || (!metrics.recorded.IsZero() && now.Sub(metrics.recorded) < minMetricsInterval) {
+		return
+	}
+
+	if metrics.guests == nil {
+		metrics.guests = make(map[int]*metricsSeries)
+		metrics.nodes = make(map[string]*metricsSeries)
+	}
+
+	seenNodes := make(map[string]bool, len(nodes))
+
+	for _, node := range nodes {
+		if node == nil || !node.Online {
+			continue
+		}
+
+		seenNodes[node.Name] = true
+
+		sample := MetricSample{Time: now, CPU: node.CPUUsage * 100}
+		if node.MemoryTotal > 0 {
+			sample.Memory = node.MemoryUsed / node.MemoryTotal * 100
+		}
+
+		seriesFor(metrics.nodes, node.Name, metrics.capacity).add(sample)
+	}
+
+	seenGuests := make(map[int]bool, len(vms))
+
+	for _, vm := range vms {
+		if vm == nil || vm.Template {
+			continue
+		}
+
+		seenGuests[vm.ID] = true
+
+		series := seriesFor(metrics.guests, vm.ID, metrics.capacity)
+		sample := MetricSample{Time: now}
+
+		if vm.Status == api.VMStatusRunning {
+			sample.CPU = vm.CPU * 100
+			if vm.MaxMem > 0 {
+				sample.Memory = float64(vm.Mem) / float64(vm.MaxMem) * 100
+			}
+
+			if previous, ok := series.last(); ok {
+				seconds := now.Sub(previous.Time).Seconds()
+				sample.NetIn = counterRate(series.netIn, vm.NetIn, seconds)
+				sample.NetOut = counterRate(series.netOut, vm.NetOut, seconds)
+				sample.DiskRead = counterRate(series.diskRead, vm.DiskRead, seconds)
+				sample.DiskWrite = counterRate(series.diskWrite, vm.DiskWrite, seconds)
+			}
+		}
+
+		series.netIn, series.netOut = vm.NetIn, vm.NetOut
+		series.diskRead, series.diskWrite = vm.DiskRead, vm.DiskWrite
+		series.add(sample)
+	}
+
+	for name := range metrics.nodes {
+		if !seenNodes[name] {
+			delete(metrics.nodes, name)
+		}
+	}
+
+	for id := range metrics.guests {
+		if !seenGuests[id] {
+			delete(metrics.guests, id)
+		}
+	}
+
+	metrics.recorded = now
+}
+
+// seriesFor returns the series of key, creating it with the given capacity
+// if needed.
+func seriesFor[K comparable](all map[K]*metricsSeries, key K, capacity int) *metricsSeries {
+	if all[key] == nil {
+		all[key] = &metricsSeries{samples: make([]MetricSample, capacity)}
+	}
+
+	return all[key]
+}
+
+// counterRate returns the per-second rate between two readings of a
+// counter, zero if the counter was reset, e.g. by a guest restart.
+func counterRate(previous, current int64, seconds float64) float64 {
+	if seconds <= 0 || current < previous || previous == 0 {
+		return 0
+	}
+
+	return float64(current-previous) / seconds
+}
+
+// GuestMetrics returns the recorded samples of a guest, oldest first.
+func GuestMetrics(vm *api.VM) []MetricSample {
+	metrics.mu.RLock()
+	defer metrics.mu.RUnlock()
+
+	if series := metrics.guests[vm.ID]; series != nil {
+		return series.list()
+	}
+
+	return nil
+}
+
+// NodeMetrics returns the recorded samples of a node, oldest first.
+func NodeMetrics(node string) []MetricSample {
+	metrics.mu.RLock()
+	defer metrics.mu.RUnlock()
+
+	if series := metrics.nodes[node]; series != nil {
+		return series.list()
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	ResetMetrics()
+	SetMetricsCapacity(3)
+	t.Cleanup(func() {
+		ResetMetrics()
+		SetMetricsCapacity(90)
+	})
+
+	start := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	node := &api.Node{Name: "pve1", Online: true, CPUUsage: 0.25, MemoryTotal: 64, MemoryUsed: 16}
+	vm := &api.VM{ID: 101, Node: "pve1", Status: api.VMStatusRunning, CPU: 0.5, Mem: 512, MaxMem: 1024, NetIn: 1000, DiskWrite: 500}
+	template := &api.VM{ID: 9000, Node: "pve1", Template: true}
+
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm, template}, start)
+
+	samples := GuestMetrics(vm)
+	require.Len(t, samples, 1)
+	assert.InDelta(t, 50, samples[0].CPU, 0.001)
+	assert.InDelta(t, 50, samples[0].Memory, 0.001)
+	// The first sample has no previous counters to derive rates from
+	assert.Zero(t, samples[0].NetIn)
+	assert.Empty(t, GuestMetrics(template))
+
+	nodeSamples := NodeMetrics("pve1")
+	require.Len(t, nodeSamples, 1)
+	assert.InDelta(t, 25, nodeSamples[0].CPU, 0.001)
+	assert.InDelta(t, 25, nodeSamples[0].Memory, 0.001)
+
+	// Refreshes right after each other are recorded once
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm}, start.Add(time.Second))
+	assert.Len(t, GuestMetrics(vm), 1)
+
+	vm.NetIn, vm.DiskWrite = 11000, 2500
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm}, start.Add(10*time.Second))
+
+	samples = GuestMetrics(vm)
+	require.Len(t, samples, 2)
+	assert.InDelta(t, 1000, samples[1].NetIn, 0.001)
+	assert.InDelta(t, 200, samples[1].DiskWrite, 0.001)
+
+	// A restart resets the counters, which must not show as a negative rate
+	vm.NetIn, vm.DiskWrite = 100, 0
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm}, start.Add(20*time.Second))
+
+	vm.Status = api.VMStatusStopped
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm}, start.Add(30*time.Second))
+
+	// The capacity keeps the newest samples
+	samples = GuestMetrics(vm)
+	require.Len(t, samples, 3)
+	assert.Equal(t, start.Add(10*time.Second), samples[0].Time)
+	assert.Zero(t, samples[1].NetIn)
+	assert.Zero(t, samples[2].CPU)
+	assert.Zero(t, samples[2].Memory)
+
+	SetMetricsCapacity(2)
+
+	samples = GuestMetrics(vm)
+	require.Len(t, samples, 2)
+	assert.Equal(t, start.Add(30*time.Second), samples[1].Time)
+
+	// Guests and nodes that are gone are forgotten
+	RecordMetrics(nil, nil, start.Add(40*time.Second))
+	assert.Empty(t, GuestMetrics(vm))
+	assert.Empty(t, NodeMetrics("pve1"))
+
+	SetMetricsCapacity(0)
+	RecordMetrics([]*api.Node{node}, []*api.VM{vm}, start.Add(50*time.Second))
+	assert.Empty(t, GuestMetrics(vm))
+}
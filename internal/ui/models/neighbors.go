@@ -0,0 +1,123 @@
+package models
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// neighborIndex holds the neighbor tables (MAC to IP) read from the nodes,
+// used to infer the IP of guests that neither the guest agent nor the API
+// report one for.
+type neighborIndex struct {
+	mu       sync.RWMutex
+	byNode   map[string]map[string]string // Key: node, then upper-case MAC
+	readAt   map[string]time.Time         // Key: node
+	inferred map[string]string            // Key: "node:vmid", value: inferred IP
+}
+
+var neighbors neighborIndex
+
+// SetNodeNeighbors replaces the neighbor table of a node.
+func SetNodeNeighbors(node string, byMAC map[string]string, now time.Time) {
+	neighbors.mu.Lock()
+	defer neighbors.mu.Unlock()
+
+	if neighbors.byNode == nil {
+		neighbors.byNode = make(map[string]map[string]string)
+		neighbors.readAt = make(map[string]time.Time)
+	}
+
+	neighbors.byNode[node] = byMAC
+	neighbors.readAt[node] = now
+}
+
+// ResetNeighbors forgets the neighbor tables, e.g. after switching to
+// another cluster.
+func ResetNeighbors() {
+	neighbors.mu.Lock()
+	defer neighbors.mu.Unlock()
+
+	neighbors.byNode, neighbors.readAt, neighbors.inferred = nil, nil, nil
+}
+
+// NeighborsReadAt returns when the neighbor table of a node was last read,
+// zero if it wasn't yet.
+func NeighborsReadAt(node string) time.Time {
+	neighbors.mu.RLock()
+	defer neighbors.mu.RUnlock()
+
+	return neighbors.readAt[node]
+}
+
+// guestMACs returns the configured MAC addresses of a guest.
+func guestMACs(vm *api.VM) []string {
+	var macs []string
+
+	for _, network := range vm.ConfiguredNetworks {
+		if network.MACAddr != "" {
+			macs = append(macs, network.MACAddr)
+		}
+	}
+
+	for mac := range vm.ConfiguredMACs {
+		macs = append(macs, mac)
+	}
+
+	return macs
+}
+
+// NeedsNeighborIP reports whether the IP of a guest is unknown and could be
+// inferred from the neighbor table of its node.
+func NeedsNeighborIP(vm *api.VM) bool {
+	return vm != nil && vm.IP == "" && vm.Status == api.VMStatusRunning && len(guestMACs(vm)) > 0
+}
+
+// ApplyNeighborIPs sets the IP of running guests without one to the address
+// the neighbor table of their node lists for a configured MAC address, and
+// returns the number of guests updated.
+func ApplyNeighborIPs(vms []*api.VM) int {
+	neighbors.mu.Lock()
+	defer neighbors.mu.Unlock()
+
+	if neighbors.inferred == nil {
+		neighbors.inferred = make(map[string]string)
+	}
+
+	applied := 0
+
+	for _, vm := range vms {
+		if !NeedsNeighborIP(vm) {
+			continue
+		}
+
+		table := neighbors.byNode[vm.Node]
+
+		for _, mac := range guestMACs(vm) {
+			if ip, ok := table[mac]; ok {
+				vm.IP = ip
+				neighbors.inferred[fmt.Sprintf("%s:%d", vm.Node, vm.ID)] = ip
+				applied++
+
+				break
+			}
+		}
+	}
+
+	return applied
+}
+
+// IPFromNeighbors reports whether the IP of a guest was inferred from the
+// neighbor table of its node.
+func IPFromNeighbors(vm *api.VM) bool {
+	if vm == nil || vm.IP == "" {
+		return false
+	}
+
+	neighbors.mu.RLock()
+	defer neighbors.mu.RUnlock()
+
+	return neighbors.inferred[fmt.Sprintf("%s:%d", vm.Node, vm.ID)] == vm.IP
+}
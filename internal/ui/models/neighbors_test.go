@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestApplyNeighborIPs(t *testing.T) {
+	ResetNeighbors()
+	t.Cleanup(ResetNeighbors)
+
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	web := &api.VM{ID: 100, Node: "pve1", Status: api.VMStatusRunning,
+		ConfiguredNetworks: []api.ConfiguredNetwork{{Interface: "net0", MACAddr: "BC:24:11:AA:BB:CC"}}}
+	known := &api.VM{ID: 101, Node: "pve1", Status: api.VMStatusRunning, IP: "192.0.2.51",
+		ConfiguredMACs: map[string]bool{"BC:24:11:00:00:01": true}}
+	other := &api.VM{ID: 102, Node: "pve2", Status: api.VMStatusRunning,
+		ConfiguredMACs: map[string]bool{"BC:24:11:00:00:02": true}}
+	stopped := &api.VM{ID: 103, Node: "pve1", Status: api.VMStatusStopped,
+		ConfiguredMACs: map[string]bool{"BC:24:11:00:00:03": true}}
+	vms := []*api.VM{web, known, other, stopped}
+
+	assert.True(t, NeedsNeighborIP(web))
+	assert.False(t, NeedsNeighborIP(known))
+	assert.False(t, NeedsNeighborIP(stopped))
+
+	SetNodeNeighbors("pve1", map[string]string{
+		"BC:24:11:AA:BB:CC": "192.0.2.50",
+		"BC:24:11:00:00:01": "192.0.2.99",
+		"BC:24:11:00:00:03": "192.0.2.53",
+	}, now)
+	assert.Equal(t, now, NeighborsReadAt("pve1"))
+	assert.True(t, NeighborsReadAt("pve2").IsZero())
+
+	assert.Equal(t, 1, ApplyNeighborIPs(vms))
+	assert.Equal(t, "192.0.2.50", web.IP)
+	assert.True(t, IPFromNeighbors(web))
+
+	// Reported IPs are kept, and the tables of other nodes aren't used
+	assert.Equal(t, "192.0.2.51", known.IP)
+	assert.False(t, IPFromNeighbors(known))
+	assert.Empty(t, other.IP)
+	assert.Empty(t, stopped.IP)
+}
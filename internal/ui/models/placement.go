@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// PlacementScorer rates how well a node suits a guest, from 0 (worst) to 1
+// (best). It is only called for online nodes with enough memory headroom.
+type PlacementScorer func(capacity NodeCapacity, vm *api.VM) float64
+
+// placementScorers are the scorers of the placement strategies.
+var placementScorers = map[string]PlacementScorer{
+	config.PlacementStrategyBalanced: scoreBalanced,
+	config.PlacementStrategyMemory:   scoreMemory,
+	config.PlacementStrategyCPU:      scoreCPU,
+}
+
+// PlacementScorerFor returns the scorer of a placement strategy, falling back
+// to the balanced strategy.
+func PlacementScorerFor(strategy string) PlacementScorer {
+	if scorer, ok := placementScorers[strategy]; ok {
+		return scorer
+	}
+
+	return scoreBalanced
+}
+
+// Placement is a ranked target node for a guest.
+type Placement struct {
+	Node   string
+	Score  float64
+	Reason string // e.g. "41.2 GB free, CPU 12%"
+}
+
+// RankPlacements returns the candidate nodes for a guest, best first.
+// Offline nodes and nodes without memory headroom for the guest are left out.
+func RankPlacements(capacities []NodeCapacity, candidates []string, vm *api.VM, scorer PlacementScorer) []Placement {
+	if scorer == nil {
+		scorer = scoreBalanced
+	}
+
+	byNode := make(map[string]NodeCapacity, len(capacities))
+	for _, capacity := range capacities {
+		byNode[capacity.Node] = capacity
+	}
+
+	var placements []Placement
+
+	for _, node := range candidates {
+		capacity, ok := byNode[node]
+		if !ok || !capacity.Online || capacity.MemoryHeadroom() < guestMemory(vm) {
+			continue
+		}
+
+		placements = append(placements, Placement{
+			Node:   node,
+			Score:  scorer(capacity, vm),
+			Reason: fmt.Sprintf("%s free, CPU %.0f%%", formatGiB(capacity.MemoryHeadroom()), capacity.CPUUsage*100),
+		})
+	}
+
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].Score > placements[j].Score
+	})
+
+	return placements
+}
+
+// guestMemory returns the memory a guest needs on its new node.
+func guestMemory(vm *api.VM) int64 {
+	if vm == nil {
+		return 0
+	}
+
+	return vm.MaxMem
+}
+
+// scoreMemory prefers nodes with the most memory left after placing the guest.
+func scoreMemory(capacity NodeCapacity, vm *api.VM) float64 {
+	return ratio(float64(capacity.MemoryHeadroom()-guestMemory(vm)), float64(capacity.MemoryTotal))
+}
+
+// scoreCPU prefers the least busy nodes with the fewest running vCPUs per CPU.
+func scoreCPU(capacity NodeCapacity, _ *api.VM) float64 {
+	busy := 1 - capacity.CPUUsage
+
+	committed := ratio(float64(capacity.RunningCPUs), float64(capacity.CPUs))
+	if committed > 1 {
+		committed = 1
+	}
+
+	return (busy + 1 - committed) / 2
+}
+
+// scoreBalanced weighs free memory most, then CPU and free guest storage.
+func scoreBalanced(capacity NodeCapacity, vm *api.VM) float64 {
+	storage := 1 - ratio(float64(capacity.DiskUsed), float64(capacity.DiskTotal))
+
+	return 0.5*scoreMemory(capacity, vm) + 0.3*scoreCPU(capacity, vm) + 0.2*storage
+}
+
+// formatGiB formats bytes as GB with one decimal, like the node details.
+func formatGiB(bytes int64) string {
+	return fmt.Sprintf("%.1f GB", float64(bytes)/bytesPerGiB)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestRankPlacements(t *testing.T) {
+	const gib = int64(bytesPerGiB)
+
+	capacities := []NodeCapacity{
+		{Node: "pve1", Online: true, CPUs: 16, CPUUsage: 0.1, MemoryTotal: 64 * gib, MemoryUsed: 48 * gib},
+		{Node: "pve2", Online: true, CPUs: 16, CPUUsage: 0.7, MemoryTotal: 64 * gib, MemoryUsed: 16 * gib},
+		{Node: "pve3", Online: true, CPUs: 8, CPUUsage: 0.05, MemoryTotal: 16 * gib, MemoryUsed: 12 * gib},
+		{Node: "pve4", Online: false, CPUs: 32, MemoryTotal: 256 * gib},
+	}
+	vm := &api.VM{ID: 100, MaxMem: 8 * gib}
+	candidates := []string{"pve1", "pve2", "pve3", "pve4"}
+
+	memory := RankPlacements(capacities, candidates, vm, PlacementScorerFor(config.PlacementStrategyMemory))
+	require.Len(t, memory, 2, "offline nodes and nodes without headroom are left out")
+	assert.Equal(t, "pve2", memory[0].Node)
+	assert.Equal(t, "48.0 GB free, CPU 70%", memory[0].Reason)
+
+	cpu := RankPlacements(capacities, candidates, vm, PlacementScorerFor(config.PlacementStrategyCPU))
+	require.Len(t, cpu, 2)
+	assert.Equal(t, "pve1", cpu[0].Node)
+
+	// Only the given candidates are ranked
+	only := RankPlacements(capacities, []string{"pve1"}, vm, PlacementScorerFor("unknown"))
+	require.Len(t, only, 1)
+	assert.Equal(t, "pve1", only[0].Node)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// QuorumWarnings lists the problems found in the quorum status of a cluster
+// and in the links read from one of its nodes, most severe first.
+func QuorumWarnings(status *api.QuorumStatus, links []api.CorosyncLink) []string {
+	var warnings []string
+
+	names := make(map[int]string, len(status.Nodes))
+	for _, node := range status.Nodes {
+		names[node.NodeID] = node.Name
+	}
+
+	if !status.Quorate {
+		warnings = append(warnings, fmt.Sprintf("The cluster is not quorate: %d of %d votes, %d needed; guests can't be started or changed",
+			status.TotalVotes(), status.ExpectedVotes(), status.Quorum()))
+	} else if len(status.Nodes) > 1 && status.TotalVotes() == status.Quorum() {
+		warnings = append(warnings, "Losing one more vote loses quorum")
+	}
+
+	for _, node := range status.Nodes {
+		if !node.Online {
+			warnings = append(warnings, fmt.Sprintf("Node %s is offline", node.Name))
+		}
+
+		for _, link := range status.Links {
+			if node.Links[link] == "" {
+				warnings = append(warnings, fmt.Sprintf("Node %s has no address on link %d", node.Name, link))
+			}
+		}
+	}
+
+	if len(status.Links) == 1 && len(status.Nodes) > 1 {
+		warnings = append(warnings, "Only one corosync link is configured; a second link keeps the cluster quorate when the first network fails")
+	}
+
+	for _, link := range links {
+		name := names[link.NodeID]
+		if name == "" {
+			name = fmt.Sprintf("node %d", link.NodeID)
+		}
+
+		switch {
+		case !link.Enabled:
+			warnings = append(warnings, fmt.Sprintf("Link %d to %s is disabled", link.Link, name))
+		case !link.Connected:
+			warnings = append(warnings, fmt.Sprintf("Link %d to %s is down (%s)", link.Link, name, link.Addresses))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestQuorumWarnings(t *testing.T) {
+	status := &api.QuorumStatus{
+		Quorate: true,
+		Links:   []int{0, 1},
+		Nodes: []api.CorosyncNode{
+			{Name: "pve1", NodeID: 1, Votes: 1, Online: true, Links: map[int]string{0: "10.0.0.1", 1: "10.1.0.1"}},
+			{Name: "pve2", NodeID: 2, Votes: 1, Online: true, Links: map[int]string{0: "10.0.0.2", 1: "10.1.0.2"}},
+			{Name: "pve3", NodeID: 3, Votes: 1, Online: true, Links: map[int]string{0: "10.0.0.3", 1: "10.1.0.3"}},
+		},
+	}
+
+	assert.Empty(t, QuorumWarnings(status, []api.CorosyncLink{{NodeID: 2, Link: 0, Enabled: true, Connected: true}}))
+
+	status.Nodes[2].Online = false
+	status.Nodes[2].Links = map[int]string{0: "10.0.0.3"}
+
+	assert.Equal(t, []string{
+		"Losing one more vote loses quorum",
+		"Node pve3 is offline",
+		"Node pve3 has no address on link 1",
+		"Link 1 to pve2 is down (10.1.0.1->10.1.0.2)",
+	}, QuorumWarnings(status, []api.CorosyncLink{
+		{NodeID: 2, Link: 0, Enabled: true, Connected: true},
+		{NodeID: 2, Link: 1, Addresses: "10.1.0.1->10.1.0.2", Enabled: true},
+	}))
+
+	status.Quorate = false
+	assert.Contains(t, QuorumWarnings(status, nil)[0], "not quorate: 2 of 3 votes, 2 needed")
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// RecentGuests remembers the most recently viewed guests, e.g. to keep their
+// guest agent data loaded across refreshes with lazy enrichment.
+type RecentGuests struct {
+	mu    sync.Mutex
+	keys  []string // Keys as "node:vmid", most recent first
+	limit int
+}
+
+// NewRecentGuests creates a tracker that remembers up to limit guests.
+func NewRecentGuests(limit int) *RecentGuests {
+	return &RecentGuests{limit: limit}
+}
+
+// SetLimit changes the number of remembered guests, forgetting the oldest.
+func (r *RecentGuests) SetLimit(limit int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = limit
+	r.trim()
+}
+
+// Touch marks a guest as viewed now.
+func (r *RecentGuests) Touch(vm *api.VM) {
+	if vm == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := guestKey(vm)
+	r.keys = slices.DeleteFunc(r.keys, func(k string) bool { return k == key })
+	r.keys = slices.Insert(r.keys, 0, key)
+	r.trim()
+}
+
+// Filter returns the remembered guests among vms, most recent first.
+func (r *RecentGuests) Filter(vms []*api.VM) []*api.VM {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byKey := make(map[string]*api.VM, len(vms))
+	for _, vm := range vms {
+		if vm != nil {
+			byKey[guestKey(vm)] = vm
+		}
+	}
+
+	recent := make([]*api.VM, 0, len(r.keys))
+
+	for _, key := range r.keys {
+		if vm, ok := byKey[key]; ok {
+			recent = append(recent, vm)
+		}
+	}
+
+	return recent
+}
+
+// trim drops the oldest guests beyond the limit. The caller holds mu.
+func (r *RecentGuests) trim() {
+	if len(r.keys) > r.limit {
+		r.keys = r.keys[:max(r.limit, 0)]
+	}
+}
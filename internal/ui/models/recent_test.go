@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestRecentGuests(t *testing.T) {
+	web := &api.VM{ID: 100, Node: "pve1"}
+	db := &api.VM{ID: 101, Node: "pve1"}
+	dns := &api.VM{ID: 100, Node: "pve2"}
+
+	recent := NewRecentGuests(2)
+	recent.Touch(web)
+	recent.Touch(db)
+	recent.Touch(web)
+	recent.Touch(nil)
+
+	// Refreshed guests are matched by node and ID, most recent first
+	fresh := []*api.VM{{ID: 101, Node: "pve1"}, {ID: 100, Node: "pve1"}, dns}
+	assert.Equal(t, []*api.VM{fresh[1], fresh[0]}, recent.Filter(fresh))
+
+	recent.Touch(dns)
+	assert.Equal(t, []*api.VM{dns, fresh[1]}, recent.Filter(fresh))
+
+	recent.SetLimit(0)
+	assert.Empty(t, recent.Filter(fresh))
+}
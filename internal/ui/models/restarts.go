@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// RestartBadgeDuration is how long a restarted guest stays flagged in the guest list.
+const RestartBadgeDuration = 15 * time.Minute
+
+// uptimeTolerance absorbs small differences between the uptimes reported by
+// the cluster resources and the guest status endpoints.
+const uptimeTolerance = 60
+
+// expectedStopWindow is how long a requested stop covers a stopped guest.
+const expectedStopWindow = 10 * time.Minute
+
+// uptimeTracker remembers guest uptimes between refreshes to detect restarts
+// and unexpected stops.
+type uptimeTracker struct {
+	mu        sync.Mutex
+	uptimes   map[string]int64     // Key: "node:vmid", uptime at the previous refresh
+	restarted map[string]time.Time // Key: "node:vmid", when the restart was detected
+	stopped   map[string]time.Time // Key: "node:vmid", when the unexpected stop was detected
+	expected  map[string]time.Time // Key: "node:vmid", when a stop was requested from this application
+}
+
+var restarts uptimeTracker
+
+// guestKey returns the key used to track a guest.
+func guestKey(vm *api.VM) string {
+	return fmt.Sprintf("%s:%d", vm.Node, vm.ID)
+}
+
+// RecordUptimes stores the uptimes of the running guests. It returns the
+// guests whose uptime went backwards since the previous call, meaning they
+// restarted in between, and the guests that were running at the previous call
+// and are stopped now without the stop being expected (see ExpectGuestStop).
+// Guests seen for the first time are never reported.
+func RecordUptimes(vms []*api.VM, now time.Time) (restarted, stopped []*api.VM) {
+	restarts.mu.Lock()
+	defer restarts.mu.Unlock()
+
+	if restarts.uptimes == nil {
+		restarts.uptimes = make(map[string]int64)
+		restarts.restarted = make(map[string]time.Time)
+		restarts.stopped = make(map[string]time.Time)
+	}
+
+	seen := make(map[string]bool, len(vms))
+
+	for _, vm := range vms {
+		if vm == nil {
+			continue
+		}
+
+		key := guestKey(vm)
+
+		if vm.Status == api.VMStatusStopped {
+			if _, wasRunning := restarts.uptimes[key]; wasRunning {
+				if requested, ok := restarts.expected[key]; ok && now.Sub(requested) <= expectedStopWindow {
+					delete(restarts.expected, key)
+				} else {
+					restarts.stopped[key] = now
+					stopped = append(stopped, vm)
+				}
+			}
+
+			continue
+		}
+
+		if vm.Status != api.VMStatusRunning || vm.Uptime <= 0 {
+			continue
+		}
+
+		seen[key] = true
+
+		delete(restarts.stopped, key)
+
+		if previous, ok := restarts.uptimes[key]; ok && vm.Uptime+uptimeTolerance < previous {
+			restarts.restarted[key] = now
+			restarted = append(restarted, vm)
+		}
+
+		restarts.uptimes[key] = vm.Uptime
+	}
+
+	// Forget stopped and removed guests so a later start is not a restart
+	for key := range restarts.uptimes {
+		if !seen[key] {
+			delete(restarts.uptimes, key)
+		}
+	}
+
+	for key, at := range restarts.restarted {
+		if now.Sub(at) > RestartBadgeDuration {
+			delete(restarts.restarted, key)
+		}
+	}
+
+	for key, at := range restarts.expected {
+		if now.Sub(at) > expectedStopWindow {
+			delete(restarts.expected, key)
+		}
+	}
+
+	return restarted, stopped
+}
+
+// ExpectGuestStop marks a stop of the guest as requested at now, so
+// refreshes within expectedStopWindow do not report it as unexpected.
+func ExpectGuestStop(vm *api.VM, now time.Time) {
+	restarts.mu.Lock()
+	defer restarts.mu.Unlock()
+
+	if restarts.expected == nil {
+		restarts.expected = make(map[string]time.Time)
+	}
+
+	restarts.expected[guestKey(vm)] = now
+}
+
+// StoppedAt returns when an unexpected stop of the guest was detected. The
+// stop is remembered until the guest runs again.
+func StoppedAt(vm *api.VM) (time.Time, bool) {
+	restarts.mu.Lock()
+	defer restarts.mu.Unlock()
+
+	at, ok := restarts.stopped[guestKey(vm)]
+
+	return at, ok
+}
+
+// RestartedAt returns when a restart of the guest was detected within the
+// last RestartBadgeDuration.
+func RestartedAt(vm *api.VM) (time.Time, bool) {
+	restarts.mu.Lock()
+	defer restarts.mu.Unlock()
+
+	at, ok := restarts.restarted[guestKey(vm)]
+	if !ok || time.Since(at) > RestartBadgeDuration {
+		return time.Time{}, false
+	}
+
+	return at, true
+}
+
+// ResetUptimes forgets all recorded uptimes, e.g. after switching profiles.
+func ResetUptimes() {
+	restarts.mu.Lock()
+	defer restarts.mu.Unlock()
+
+	restarts.uptimes = nil
+	restarts.restarted = nil
+	restarts.stopped = nil
+	restarts.expected = nil
+}
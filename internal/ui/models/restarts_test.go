@@ -0,0 +1,99 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestRecordUptimes(t *testing.T) {
+	ResetUptimes()
+	t.Cleanup(ResetUptimes)
+
+	guest := func(id int, status string, uptime int64) *api.VM {
+		return &api.VM{ID: id, Node: "pve1", Status: status, Uptime: uptime}
+	}
+
+	assertNoChanges := func(restarted, stopped []*api.VM) {
+		t.Helper()
+		assert.Empty(t, restarted)
+		assert.Empty(t, stopped)
+	}
+
+	now := time.Now()
+
+	// The first refresh only records a baseline
+	assertNoChanges(RecordUptimes([]*api.VM{guest(100, api.VMStatusRunning, 5000), guest(101, api.VMStatusRunning, 7000)}, now))
+
+	// Growing uptime and small jitter between endpoints are not restarts
+	assertNoChanges(RecordUptimes([]*api.VM{guest(100, api.VMStatusRunning, 5010), guest(101, api.VMStatusRunning, 6990)}, now))
+
+	restarted, _ := RecordUptimes([]*api.VM{guest(100, api.VMStatusRunning, 20), guest(101, api.VMStatusRunning, 7010)}, now)
+	if assert.Len(t, restarted, 1) {
+		assert.Equal(t, 100, restarted[0].ID)
+	}
+
+	at, ok := RestartedAt(guest(100, api.VMStatusRunning, 30))
+	assert.True(t, ok)
+	assert.Equal(t, now, at)
+
+	_, ok = RestartedAt(guest(101, api.VMStatusRunning, 7010))
+	assert.False(t, ok)
+
+	// A guest that was stopped and started again is not reported as restarted
+	restarted, stopped := RecordUptimes([]*api.VM{guest(101, api.VMStatusStopped, 0)}, now)
+	assert.Empty(t, restarted)
+	assert.Len(t, stopped, 1)
+	assertNoChanges(RecordUptimes([]*api.VM{guest(101, api.VMStatusRunning, 10)}, now))
+
+	// Badges expire
+	RecordUptimes(nil, now.Add(RestartBadgeDuration+time.Minute))
+
+	_, ok = RestartedAt(guest(100, api.VMStatusRunning, 30))
+	assert.False(t, ok)
+}
+
+func TestRecordUptimes_Stops(t *testing.T) {
+	ResetUptimes()
+	t.Cleanup(ResetUptimes)
+
+	running := &api.VM{ID: 100, Node: "pve1", Status: api.VMStatusRunning, Uptime: 5000}
+	stopped := &api.VM{ID: 100, Node: "pve1", Status: api.VMStatusStopped}
+	now := time.Now()
+
+	RecordUptimes([]*api.VM{running}, now)
+
+	// Requested stops are not reported
+	ExpectGuestStop(running, now)
+
+	_, unexpected := RecordUptimes([]*api.VM{stopped}, now.Add(time.Minute))
+	assert.Empty(t, unexpected)
+
+	_, ok := StoppedAt(stopped)
+	assert.False(t, ok)
+
+	// Other stops are reported and remembered until the guest runs again
+	RecordUptimes([]*api.VM{running}, now.Add(2*time.Minute))
+
+	_, unexpected = RecordUptimes([]*api.VM{stopped}, now.Add(3*time.Minute))
+	assert.Equal(t, []*api.VM{stopped}, unexpected)
+
+	at, ok := StoppedAt(stopped)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(3*time.Minute), at)
+
+	// Stopped guests are only reported once
+	_, unexpected = RecordUptimes([]*api.VM{stopped}, now.Add(4*time.Minute))
+	assert.Empty(t, unexpected)
+
+	_, ok = StoppedAt(stopped)
+	assert.True(t, ok)
+
+	RecordUptimes([]*api.VM{running}, now.Add(5*time.Minute))
+
+	_, ok = StoppedAt(stopped)
+	assert.False(t, ok)
+}
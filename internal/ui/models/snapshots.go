@@ -0,0 +1,205 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// currentSnapshot is the pseudo snapshot the API lists for the current state.
+const currentSnapshot = "current"
+
+// snapshotIndex holds the snapshots of every guest, collected by a scan of
+// all guests.
+type snapshotIndex struct {
+	mu        sync.RWMutex
+	snapshots map[string][]api.Snapshot // Key: "node:vmid"
+	scannedAt time.Time
+}
+
+var snapshots snapshotIndex
+
+// GuestSnapshots are the snapshots of one guest found by a scan.
+type GuestSnapshots struct {
+	VM        *api.VM
+	Snapshots []api.Snapshot
+}
+
+// SetScannedSnapshots replaces the snapshots of all guests.
+func SetScannedSnapshots(scanned []GuestSnapshots, now time.Time) {
+	index := make(map[string][]api.Snapshot, len(scanned))
+
+	for _, guest := range scanned {
+		if guest.VM != nil {
+			index[guestKey(guest.VM)] = guest.Snapshots
+		}
+	}
+
+	snapshots.mu.Lock()
+	defer snapshots.mu.Unlock()
+
+	snapshots.snapshots = index
+	snapshots.scannedAt = now
+}
+
+// ResetSnapshots forgets the scanned snapshots, e.g. after switching to
+// another cluster.
+func ResetSnapshots() {
+	SetScannedSnapshots(nil, time.Time{})
+}
+
+// SnapshotsScannedAt returns when the snapshots were last scanned, zero if
+// they weren't yet.
+func SnapshotsScannedAt() time.Time {
+	snapshots.mu.RLock()
+	defer snapshots.mu.RUnlock()
+
+	return snapshots.scannedAt
+}
+
+// ForgetSnapshot removes a deleted snapshot from the scanned snapshots.
+func ForgetSnapshot(vm *api.VM, name string) {
+	snapshots.mu.Lock()
+	defer snapshots.mu.Unlock()
+
+	key := guestKey(vm)
+
+	var kept []api.Snapshot
+
+	for _, snapshot := range snapshots.snapshots[key] {
+		if snapshot.Name != name {
+			kept = append(kept, snapshot)
+		}
+	}
+
+	if snapshots.snapshots != nil {
+		snapshots.snapshots[key] = kept
+	}
+}
+
+// StaleSnapshotRules decide which snapshots are stale: the ones older than
+// MaxAge, if set, and the ones whose name matches one of Patterns.
+type StaleSnapshotRules struct {
+	MaxAge   time.Duration
+	Patterns []*regexp.Regexp
+}
+
+// NewStaleSnapshotRules compiles the name patterns of the rules.
+func NewStaleSnapshotRules(maxAge time.Duration, patterns []string) (StaleSnapshotRules, error) {
+	rules := StaleSnapshotRules{MaxAge: maxAge}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return StaleSnapshotRules{}, fmt.Errorf("invalid snapshot pattern '%s': %w", pattern, err)
+		}
+
+		rules.Patterns = append(rules.Patterns, re)
+	}
+
+	return rules, nil
+}
+
+// Enabled reports whether the rules can find any snapshot.
+func (r StaleSnapshotRules) Enabled() bool {
+	return r.MaxAge > 0 || len(r.Patterns) > 0
+}
+
+// Reason returns why a snapshot is stale, e.g. "45d old" or "matches
+// ^before-upgrade", or an empty string if it isn't.
+func (r StaleSnapshotRules) Reason(snapshot api.Snapshot, now time.Time) string {
+	if snapshot.Name == currentSnapshot {
+		return ""
+	}
+
+	for _, pattern := range r.Patterns {
+		if pattern.MatchString(snapshot.Name) {
+			return "matches " + pattern.String()
+		}
+	}
+
+	if r.MaxAge > 0 && !snapshot.SnapTime.IsZero() {
+		if age := now.Sub(snapshot.SnapTime); age > r.MaxAge {
+			return FormatAge(age) + " old"
+		}
+	}
+
+	return ""
+}
+
+// StaleSnapshot is a snapshot found by the stale snapshot rules.
+type StaleSnapshot struct {
+	VM       *api.VM
+	Snapshot api.Snapshot
+	Reason   string
+}
+
+// FindStaleSnapshots returns the stale snapshots of the given guests among
+// the scanned ones, oldest first.
+func FindStaleSnapshots(vms []*api.VM, rules StaleSnapshotRules, now time.Time) []StaleSnapshot {
+	if !rules.Enabled() {
+		return nil
+	}
+
+	snapshots.mu.RLock()
+	defer snapshots.mu.RUnlock()
+
+	var stale []StaleSnapshot
+
+	for _, vm := range vms {
+		if vm == nil {
+			continue
+		}
+
+		for _, snapshot := range snapshots.snapshots[guestKey(vm)] {
+			if reason := rules.Reason(snapshot, now); reason != "" {
+				stale = append(stale, StaleSnapshot{VM: vm, Snapshot: snapshot, Reason: reason})
+			}
+		}
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].Snapshot.SnapTime.Before(stale[j].Snapshot.SnapTime)
+	})
+
+	return stale
+}
+
+// CollectSnapshotAlerts returns an alert for every guest with stale
+// snapshots, in the order of the oldest stale snapshot.
+func CollectSnapshotAlerts(vms []*api.VM, rules StaleSnapshotRules, now time.Time) []Alert {
+	var (
+		guests []*api.VM
+		counts = make(map[*api.VM]int)
+	)
+
+	for _, stale := range FindStaleSnapshots(vms, rules, now) {
+		if counts[stale.VM] == 0 {
+			guests = append(guests, stale.VM)
+		}
+
+		counts[stale.VM]++
+	}
+
+	alerts := make([]Alert, 0, len(guests))
+
+	for _, vm := range guests {
+		message := fmt.Sprintf("%s has a stale snapshot", guestLabel(vm))
+		if counts[vm] > 1 {
+			message = fmt.Sprintf("%s has %d stale snapshots", guestLabel(vm), counts[vm])
+		}
+
+		alerts = append(alerts, Alert{
+			Kind:    AlertStaleSnapshot,
+			Message: message,
+			Node:    vm.Node,
+			VM:      vm,
+		})
+	}
+
+	return alerts
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestFindStaleSnapshots(t *testing.T) {
+	ResetSnapshots()
+	t.Cleanup(ResetSnapshots)
+
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	rules, err := NewStaleSnapshotRules(30*24*time.Hour, []string{"^before-upgrade"})
+	require.NoError(t, err)
+
+	web := &api.VM{ID: 100, Name: "web", Node: "pve1", Type: api.VMTypeQemu}
+	db := &api.VM{ID: 101, Name: "db", Node: "pve2", Type: api.VMTypeQemu}
+	vms := []*api.VM{web, db}
+
+	// Nothing is found before a scan
+	assert.Empty(t, FindStaleSnapshots(vms, rules, now))
+
+	SetScannedSnapshots([]GuestSnapshots{
+		{VM: web, Snapshots: []api.Snapshot{
+			{Name: "old", SnapTime: now.Add(-90 * 24 * time.Hour)},
+			{Name: "recent", SnapTime: now.Add(-2 * 24 * time.Hour)},
+			{Name: "current"},
+		}},
+		{VM: db, Snapshots: []api.Snapshot{
+			{Name: "before-upgrade-8.2", SnapTime: now.Add(-24 * time.Hour), VMState: true},
+			{Name: "older", SnapTime: now.Add(-40 * 24 * time.Hour)},
+		}},
+	}, now)
+
+	stale := FindStaleSnapshots(vms, rules, now)
+	require.Len(t, stale, 3)
+
+	// Oldest first
+	assert.Equal(t, "old", stale[0].Snapshot.Name)
+	assert.Equal(t, "90d old", stale[0].Reason)
+	assert.Equal(t, "older", stale[1].Snapshot.Name)
+	assert.Equal(t, db, stale[1].VM)
+	assert.Equal(t, "before-upgrade-8.2", stale[2].Snapshot.Name)
+	assert.Equal(t, "matches ^before-upgrade", stale[2].Reason)
+
+	alerts := CollectSnapshotAlerts(vms, rules, now)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, AlertStaleSnapshot, alerts[0].Kind)
+	assert.Equal(t, "VM 100 (web) has a stale snapshot", alerts[0].Message)
+	assert.Equal(t, "VM 101 (db) has 2 stale snapshots", alerts[1].Message)
+
+	// Deleted snapshots are no longer reported
+	ForgetSnapshot(db, "older")
+	assert.Len(t, FindStaleSnapshots(vms, rules, now), 2)
+
+	// Without rules nothing is stale
+	assert.Empty(t, FindStaleSnapshots(vms, StaleSnapshotRules{}, now))
+
+	_, err = NewStaleSnapshotRules(0, []string{"(before"})
+	assert.Error(t, err)
+}
@@ -99,39 +99,10 @@ func FilterNodes(filter string) {
 
 	// Add nodes that match the filter
 	for _, node := range GlobalState.OriginalNodes {
-		if node == nil {
-			continue
-		}
-
-		// Check node name
-		if strings.Contains(strings.ToLower(node.Name), filter) {
-			GlobalState.FilteredNodes = append(GlobalState.FilteredNodes, node)
-
-			continue
-		}
-
-		// Check node IP
-		if strings.Contains(strings.ToLower(node.IP), filter) {
-			GlobalState.FilteredNodes = append(GlobalState.FilteredNodes, node)
-
-			continue
-		}
-
-		// Check node status (using online status instead)
-		statusText := "offline"
-		if node.Online {
-			statusText = "online"
-		}
-
-		if strings.Contains(statusText, filter) {
+		if nodeMatches(node, filter) {
 			GlobalState.FilteredNodes = append(GlobalState.FilteredNodes, node)
-
-			continue
 		}
 	}
-	// GetUILogger().Debug("Filtered nodes from %d to %d with filter '%s'",
-	//
-	//	len(GlobalState.OriginalNodes), len(GlobalState.FilteredNodes), filter)
 }
 
 // FilterVMs filters the VMs based on the given search string.
@@ -152,49 +123,10 @@ func FilterVMs(filter string) {
 
 	// Add VMs that match the filter
 	for _, vm := range GlobalState.OriginalVMs {
-		if vm == nil {
-			continue
-		}
-
-		// Check VM name
-		if strings.Contains(strings.ToLower(vm.Name), filter) {
-			GlobalState.FilteredVMs = append(GlobalState.FilteredVMs, vm)
-
-			continue
-		}
-
-		// Check VM ID (convert int to string)
-		vmIDStr := fmt.Sprintf("%d", vm.ID)
-		if strings.Contains(vmIDStr, filter) {
-			GlobalState.FilteredVMs = append(GlobalState.FilteredVMs, vm)
-
-			continue
-		}
-
-		// Check VM type
-		if strings.Contains(strings.ToLower(vm.Type), filter) {
-			GlobalState.FilteredVMs = append(GlobalState.FilteredVMs, vm)
-
-			continue
-		}
-
-		// Check VM status
-		if strings.Contains(strings.ToLower(vm.Status), filter) {
-			GlobalState.FilteredVMs = append(GlobalState.FilteredVMs, vm)
-
-			continue
-		}
-
-		// Check VM node
-		if strings.Contains(strings.ToLower(vm.Node), filter) {
+		if vmMatches(vm, filter) {
 			GlobalState.FilteredVMs = append(GlobalState.FilteredVMs, vm)
-
-			continue
 		}
 	}
-	// GetUILogger().Debug("Filtered VMs from %d to %d with filter '%s'",
-	//
-	//	len(GlobalState.OriginalVMs), len(GlobalState.FilteredVMs), filter)
 }
 
 // FilterTasks filters the tasks based on the given search string.
@@ -215,55 +147,84 @@ func FilterTasks(filter string) {
 
 	// Add tasks that match the filter
 	for _, task := range GlobalState.OriginalTasks {
-		if task == nil {
-			continue
+		if taskMatches(task, filter) {
+			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
 		}
+	}
+}
 
-		// Check task ID
-		if strings.Contains(strings.ToLower(task.ID), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+// nodeMatches reports whether a node matches a lowercase filter by name, IP or status.
+func nodeMatches(node *api.Node, filter string) bool {
+	if node == nil {
+		return false
+	}
 
-			continue
-		}
+	statusText := "offline"
+	if node.Online {
+		statusText = "online"
+	}
 
-		// Check task node
-		if strings.Contains(strings.ToLower(task.Node), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+	return containsAny(filter, node.Name, node.IP, statusText)
+}
 
-			continue
-		}
+// NodeFilterPrefix starts a guest filter that lists the guests of one node,
+// e.g. "node:pve1". Unlike other filters the node name must match exactly, so
+// pve1 does not list the guests of pve10.
+const NodeFilterPrefix = "node:"
 
-		// Check task type
-		if strings.Contains(strings.ToLower(task.Type), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+// NodeFilter returns the guest filter listing the guests of a node.
+func NodeFilter(node string) string {
+	return NodeFilterPrefix + node
+}
 
-			continue
-		}
+// FilteredNode returns the node of a NodeFilter guest filter.
+func FilteredNode(filter string) (string, bool) {
+	node, ok := strings.CutPrefix(filter, NodeFilterPrefix)
 
-		// Check task status
-		if strings.Contains(strings.ToLower(task.Status), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+	return node, ok && node != ""
+}
 
-			continue
-		}
+// vmMatches reports whether a guest matches a lowercase filter by name, ID, type, status, node,
+// tags or metadata values. "key:value" filters match the value of a metadata key.
+func vmMatches(vm *api.VM, filter string) bool {
+	if vm == nil {
+		return false
+	}
 
-		// Check task user
-		if strings.Contains(strings.ToLower(task.User), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+	if node, ok := FilteredNode(filter); ok {
+		return strings.EqualFold(vm.Node, node)
+	}
 
-			continue
-		}
+	if matches, ok := metadataMatches(vm, filter); ok {
+		return matches
+	}
 
-		// Check UPID
-		if strings.Contains(strings.ToLower(task.UPID), filter) {
-			GlobalState.FilteredTasks = append(GlobalState.FilteredTasks, task)
+	values := []string{vm.Name, fmt.Sprintf("%d", vm.ID), vm.Type, vm.Status, vm.Node, vm.Tags}
+	for _, value := range GuestMetadata(vm) {
+		values = append(values, value)
+	}
 
-			continue
+	return containsAny(filter, values...)
+}
+
+// taskMatches reports whether a task matches a lowercase filter by ID, node, type, status, user or UPID.
+func taskMatches(task *api.ClusterTask, filter string) bool {
+	if task == nil {
+		return false
+	}
+
+	return containsAny(filter, task.ID, task.Node, task.Type, task.Status, task.User, task.UPID)
+}
+
+// containsAny reports whether any of the values contains the lowercase filter, ignoring case.
+func containsAny(filter string, values ...string) bool {
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), filter) {
+			return true
 		}
 	}
-	// GetUILogger().Debug("Filtered tasks from %d to %d with filter '%s'",
-	//
-	//	len(GlobalState.OriginalTasks), len(GlobalState.FilteredTasks), filter)
+
+	return false
 }
 
 // SetVMPending marks a VM as having a pending operation.
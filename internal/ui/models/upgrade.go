@@ -0,0 +1,368 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// CheckStatus is the outcome of an upgrade readiness check.
+type CheckStatus int
+
+const (
+	// CheckPass means nothing stands in the way of the upgrade.
+	CheckPass CheckStatus = iota
+	// CheckSkip means the check does not apply or could not run.
+	CheckSkip
+	// CheckWarn means the upgrade is possible but needs attention.
+	CheckWarn
+	// CheckFail means the problem must be fixed before upgrading.
+	CheckFail
+)
+
+// UpgradeCheck is a single line of the upgrade readiness checklist.
+type UpgradeCheck struct {
+	Status  CheckStatus
+	Message string
+}
+
+// NodeUpgradeData is what the upgrade readiness check knows about a node.
+// Errors record data that could not be fetched.
+type NodeUpgradeData struct {
+	Node       *api.Node
+	Updates    []api.PackageUpdate
+	UpdatesErr error
+	ZFSPools   []api.ZFSPool
+	ZFSErr     error
+}
+
+// NodeChecklist is the upgrade readiness checklist of a node.
+type NodeChecklist struct {
+	Node          string
+	RunningGuests int
+	Checks        []UpgradeCheck
+}
+
+// Status returns the worst status of the node checks.
+func (n NodeChecklist) Status() CheckStatus {
+	return worstStatus(n.Checks)
+}
+
+// UpgradeReport is the result of the cluster upgrade readiness check.
+type UpgradeReport struct {
+	Cluster []UpgradeCheck
+	// Nodes are in the recommended upgrade order: ready nodes with the fewest
+	// running guests first, nodes with failed checks and offline nodes last.
+	Nodes []NodeChecklist
+}
+
+// Counts returns the number of passed, warning, failed and skipped checks,
+// like the summary of pve7to8.
+func (r UpgradeReport) Counts() (passed, warnings, failures, skipped int) {
+	checks := append([]UpgradeCheck(nil), r.Cluster...)
+	for _, node := range r.Nodes {
+		checks = append(checks, node.Checks...)
+	}
+
+	for _, check := range checks {
+		switch check.Status {
+		case CheckPass:
+			passed++
+		case CheckWarn:
+			warnings++
+		case CheckFail:
+			failures++
+		case CheckSkip:
+			skipped++
+		}
+	}
+
+	return passed, warnings, failures, skipped
+}
+
+// kernelPackagePrefixes identify kernel updates, which need a reboot.
+var kernelPackagePrefixes = []string{"proxmox-kernel-", "pve-kernel-"}
+
+// BuildUpgradeReport checks whether the cluster is ready for an upgrade:
+// every node online and on the same version, healthy ZFS pools and Ceph, and
+// which nodes have pending updates and running guests to move first. A nil
+// ceph means Ceph is not in use.
+func BuildUpgradeReport(data []NodeUpgradeData, vms []*api.VM, ceph *api.CephHealth) UpgradeReport {
+	running := make(map[string][]*api.VM)
+
+	for _, vm := range vms {
+		if vm != nil && !vm.Template && vm.Status == api.VMStatusRunning {
+			running[vm.Node] = append(running[vm.Node], vm)
+		}
+	}
+
+	newest := ""
+	versions := make(map[string][]string)
+
+	var offline []string
+
+	for _, d := range data {
+		if d.Node == nil {
+			continue
+		}
+
+		if !d.Node.Online {
+			offline = append(offline, d.Node.Name)
+
+			continue
+		}
+
+		version := pveVersion(d.Node.Version)
+		if version == "" {
+			continue
+		}
+
+		versions[version] = append(versions[version], d.Node.Name)
+
+		if newest == "" || compareVersions(version, newest) > 0 {
+			newest = version
+		}
+	}
+
+	report := UpgradeReport{Cluster: clusterUpgradeChecks(len(data), offline, versions, ceph)}
+
+	for _, d := range data {
+		if d.Node == nil {
+			continue
+		}
+
+		report.Nodes = append(report.Nodes, NodeChecklist{
+			Node:          d.Node.Name,
+			RunningGuests: len(running[d.Node.Name]),
+			Checks:        nodeUpgradeChecks(d, running[d.Node.Name], newest),
+		})
+	}
+
+	sort.SliceStable(report.Nodes, func(i, j int) bool {
+		a, b := report.Nodes[i], report.Nodes[j]
+
+		if aFail, bFail := a.Status() == CheckFail, b.Status() == CheckFail; aFail != bFail {
+			return bFail
+		}
+
+		if a.RunningGuests != b.RunningGuests {
+			return a.RunningGuests < b.RunningGuests
+		}
+
+		return a.Node < b.Node
+	})
+
+	return report
+}
+
+// clusterUpgradeChecks checks node availability, version skew and Ceph.
+func clusterUpgradeChecks(nodes int, offline []string, versions map[string][]string, ceph *api.CephHealth) []UpgradeCheck {
+	var checks []UpgradeCheck
+
+	if len(offline) > 0 {
+		sort.Strings(offline)
+		checks = append(checks, UpgradeCheck{CheckFail, fmt.Sprintf("%d of %d nodes offline: %s", len(offline), nodes, strings.Join(offline, ", "))})
+	} else {
+		checks = append(checks, UpgradeCheck{CheckPass, fmt.Sprintf("all %d nodes online", nodes)})
+	}
+
+	switch len(versions) {
+	case 0:
+		checks = append(checks, UpgradeCheck{CheckSkip, "PVE versions unknown"})
+	case 1:
+		for version := range versions {
+			checks = append(checks, UpgradeCheck{CheckPass, "all online nodes run pve-manager " + version})
+		}
+	default:
+		ordered := make([]string, 0, len(versions))
+		for version := range versions {
+			ordered = append(ordered, version)
+		}
+
+		sort.Slice(ordered, func(i, j int) bool {
+			return compareVersions(ordered[i], ordered[j]) < 0
+		})
+
+		parts := make([]string, len(ordered))
+		for i, version := range ordered {
+			names := versions[version]
+			sort.Strings(names)
+			parts[i] = fmt.Sprintf("%s (%s)", version, strings.Join(names, ", "))
+		}
+
+		checks = append(checks, UpgradeCheck{CheckWarn, "mixed pve-manager versions: " + strings.Join(parts, ", ")})
+	}
+
+	switch {
+	case ceph == nil:
+		checks = append(checks, UpgradeCheck{CheckSkip, "Ceph not in use"})
+	case ceph.Status == "HEALTH_OK":
+		checks = append(checks, UpgradeCheck{CheckPass, "Ceph HEALTH_OK"})
+	default:
+		status := CheckWarn
+		if ceph.Status != "HEALTH_WARN" {
+			status = CheckFail
+		}
+
+		message := "Ceph " + ceph.Status
+		if len(ceph.Checks) > 0 {
+			message += ": " + strings.Join(ceph.Checks, "; ")
+		}
+
+		checks = append(checks, UpgradeCheck{status, message})
+	}
+
+	return checks
+}
+
+// nodeUpgradeChecks checks a node against the newest version in the cluster.
+func nodeUpgradeChecks(d NodeUpgradeData, running []*api.VM, newest string) []UpgradeCheck {
+	if !d.Node.Online {
+		return []UpgradeCheck{{CheckFail, "node offline, bring it online before upgrading the cluster"}}
+	}
+
+	var checks []UpgradeCheck
+
+	switch version := pveVersion(d.Node.Version); {
+	case version == "":
+		checks = append(checks, UpgradeCheck{CheckWarn, "pve-manager version unknown"})
+	case compareVersions(version, newest) < 0:
+		checks = append(checks, UpgradeCheck{CheckWarn, fmt.Sprintf("pve-manager %s, behind %s", version, newest)})
+	default:
+		checks = append(checks, UpgradeCheck{CheckPass, "pve-manager " + version})
+	}
+
+	switch {
+	case d.UpdatesErr != nil:
+		checks = append(checks, UpgradeCheck{CheckSkip, "pending updates unknown: " + d.UpdatesErr.Error()})
+	case len(d.Updates) == 0:
+		checks = append(checks, UpgradeCheck{CheckPass, "no pending updates"})
+	default:
+		checks = append(checks, UpgradeCheck{CheckWarn, pendingUpdatesMessage(d.Updates)})
+	}
+
+	if len(running) > 0 {
+		ha := 0
+
+		for _, vm := range running {
+			if vm.HAState != "" {
+				ha++
+			}
+		}
+
+		message := fmt.Sprintf("%d running guests to migrate or shut down", len(running))
+		if ha > 0 {
+			message += fmt.Sprintf(" (%d HA-managed)", ha)
+		}
+
+		checks = append(checks, UpgradeCheck{CheckWarn, message})
+	} else {
+		checks = append(checks, UpgradeCheck{CheckPass, "no running guests"})
+	}
+
+	switch {
+	case d.ZFSErr != nil:
+		checks = append(checks, UpgradeCheck{CheckSkip, "ZFS pools unknown: " + d.ZFSErr.Error()})
+	case len(d.ZFSPools) > 0:
+		var unhealthy []string
+
+		for _, pool := range d.ZFSPools {
+			if pool.Health != "ONLINE" {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s %s", pool.Name, pool.Health))
+			}
+		}
+
+		if len(unhealthy) > 0 {
+			checks = append(checks, UpgradeCheck{CheckFail, "unhealthy ZFS pools: " + strings.Join(unhealthy, ", ")})
+		} else {
+			checks = append(checks, UpgradeCheck{CheckPass, fmt.Sprintf("%d ZFS pools online", len(d.ZFSPools))})
+		}
+	}
+
+	return checks
+}
+
+// pendingUpdatesMessage summarizes pending updates, naming the ones that
+// matter for an upgrade.
+func pendingUpdatesMessage(updates []api.PackageUpdate) string {
+	var notable []string
+
+	kernel := false
+
+	for _, update := range updates {
+		switch update.Package {
+		case "pve-manager", "proxmox-ve":
+			notable = append(notable, fmt.Sprintf("%s %s", update.Package, update.Version))
+		default:
+			for _, prefix := range kernelPackagePrefixes {
+				if strings.HasPrefix(update.Package, prefix) {
+					kernel = true
+				}
+			}
+		}
+	}
+
+	if kernel {
+		notable = append(notable, "a new kernel (reboot required)")
+	}
+
+	message := fmt.Sprintf("%d pending updates", len(updates))
+	if len(notable) > 0 {
+		message += ", including " + strings.Join(notable, ", ")
+	}
+
+	return message
+}
+
+// worstStatus returns the most severe status of the checks.
+func worstStatus(checks []UpgradeCheck) CheckStatus {
+	worst := CheckPass
+
+	for _, check := range checks {
+		if check.Status > worst {
+			worst = check.Status
+		}
+	}
+
+	return worst
+}
+
+// pveVersion extracts the version from a pveversion string like
+// "pve-manager/8.2.4/faa83925c9641325".
+func pveVersion(pveversion string) string {
+	parts := strings.Split(pveversion, "/")
+	if len(parts) >= 2 && parts[0] == "pve-manager" {
+		return parts[1]
+	}
+
+	return strings.TrimSpace(pveversion)
+}
+
+// compareVersions compares dotted versions like "8.2.4" numerically,
+// returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
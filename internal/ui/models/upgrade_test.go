@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+func TestBuildUpgradeReport(t *testing.T) {
+	data := []NodeUpgradeData{
+		{
+			Node:     &api.Node{Name: "pve1", Online: true, Version: "pve-manager/8.2.4/faa83925c9641325"},
+			Updates:  []api.PackageUpdate{{Package: "curl", Version: "7.88.1-10+deb12u5"}},
+			ZFSPools: []api.ZFSPool{{Name: "rpool", Health: "ONLINE"}},
+		},
+		{
+			Node: &api.Node{Name: "pve2", Online: true, Version: "pve-manager/8.1.10/4b06efb5db453f29"},
+			Updates: []api.PackageUpdate{
+				{Package: "proxmox-kernel-6.8", Version: "6.8.8-2"},
+				{Package: "pve-manager", Version: "8.2.4"},
+			},
+			ZFSErr: errors.New("permission denied"),
+		},
+		{
+			Node:       &api.Node{Name: "pve3", Online: true, Version: "pve-manager/8.2.4/faa83925c9641325"},
+			UpdatesErr: errors.New("timeout"),
+			ZFSPools:   []api.ZFSPool{{Name: "tank", Health: "DEGRADED"}},
+		},
+		{Node: &api.Node{Name: "pve4", Online: false}},
+	}
+	vms := []*api.VM{
+		{ID: 100, Node: "pve1", Status: api.VMStatusRunning, HAState: "started"},
+		{ID: 101, Node: "pve1", Status: api.VMStatusRunning},
+		{ID: 102, Node: "pve2", Status: api.VMStatusStopped},
+		{ID: 103, Node: "pve2", Status: api.VMStatusRunning, Template: true},
+	}
+	ceph := &api.CephHealth{Status: "HEALTH_WARN", Checks: []string{"1 osds down"}}
+
+	report := BuildUpgradeReport(data, vms, ceph)
+
+	assert.Equal(t, []UpgradeCheck{
+		{CheckFail, "1 of 4 nodes offline: pve4"},
+		{CheckWarn, "mixed pve-manager versions: 8.1.10 (pve2), 8.2.4 (pve1, pve3)"},
+		{CheckWarn, "Ceph HEALTH_WARN: 1 osds down"},
+	}, report.Cluster)
+
+	// Fewest running guests first, failing nodes last
+	order := make([]string, len(report.Nodes))
+	for i, node := range report.Nodes {
+		order[i] = node.Node
+	}
+
+	assert.Equal(t, []string{"pve2", "pve1", "pve3", "pve4"}, order)
+
+	pve2 := report.Nodes[0]
+	assert.Equal(t, CheckWarn, pve2.Status())
+	assert.Equal(t, []UpgradeCheck{
+		{CheckWarn, "pve-manager 8.1.10, behind 8.2.4"},
+		{CheckWarn, "2 pending updates, including pve-manager 8.2.4, a new kernel (reboot required)"},
+		{CheckPass, "no running guests"},
+		{CheckSkip, "ZFS pools unknown: permission denied"},
+	}, pve2.Checks)
+
+	pve1 := report.Nodes[1]
+	assert.Contains(t, pve1.Checks, UpgradeCheck{CheckWarn, "2 running guests to migrate or shut down (1 HA-managed)"})
+	assert.Contains(t, pve1.Checks, UpgradeCheck{CheckPass, "1 ZFS pools online"})
+
+	pve3 := report.Nodes[2]
+	assert.Equal(t, CheckFail, pve3.Status())
+	assert.Contains(t, pve3.Checks, UpgradeCheck{CheckFail, "unhealthy ZFS pools: tank DEGRADED"})
+
+	passed, warnings, failures, skipped := report.Counts()
+	assert.Equal(t, 5, passed)
+	assert.Equal(t, 6, warnings)
+	assert.Equal(t, 3, failures)
+	assert.Equal(t, 2, skipped)
+
+	// Without Ceph and with a single version the cluster checks pass
+	healthy := BuildUpgradeReport(data[:1], nil, nil)
+	require.Len(t, healthy.Cluster, 3)
+	assert.Equal(t, UpgradeCheck{CheckPass, "all online nodes run pve-manager 8.2.4"}, healthy.Cluster[1])
+	assert.Equal(t, CheckSkip, healthy.Cluster[2].Status)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("8.1.10", "8.2.4"))
+	assert.Equal(t, 1, compareVersions("8.1.10", "8.1.9"))
+	assert.Equal(t, 0, compareVersions("8.2", "8.2.0"))
+}
@@ -0,0 +1,35 @@
+package theme
+
+// accessible replaces emoji with ASCII labels and makes the high-contrast
+// theme the default.
+var accessible bool
+
+// SetAccessible enables or disables accessible mode. Call it before
+// ApplyCustomTheme so the high-contrast base theme is picked up.
+func SetAccessible(enabled bool) {
+	accessible = enabled
+}
+
+// IsAccessible reports whether accessible mode is enabled.
+func IsAccessible() bool {
+	return accessible
+}
+
+// Icon returns the emoji, or its ASCII replacement in accessible mode.
+func Icon(emoji, ascii string) string {
+	if accessible {
+		return ascii
+	}
+
+	return emoji
+}
+
+// Label prefixes text with a decorative emoji, which is dropped in
+// accessible mode.
+func Label(emoji, text string) string {
+	if accessible {
+		return text
+	}
+
+	return emoji + " " + text
+}
@@ -0,0 +1,28 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devnullvoid/pvetui/internal/config"
+)
+
+func TestAccessibleMode(t *testing.T) {
+	t.Cleanup(func() { SetAccessible(false) })
+
+	SetAccessible(false)
+	assert.Equal(t, "🟢", Icon("🟢", "OK"))
+	assert.Equal(t, "💻 VMs", Label("💻", "VMs"))
+	assert.Equal(t, BuiltInThemes["default"]["background"], ResolveTheme(nil)["background"])
+
+	SetAccessible(true)
+	assert.True(t, IsAccessible())
+	assert.Equal(t, "OK", Icon("🟢", "OK"))
+	assert.Equal(t, "VMs", Label("💻", "VMs"))
+	assert.Equal(t, BuiltInThemes["high-contrast"]["background"], ResolveTheme(nil)["background"])
+
+	// An explicitly selected theme wins over the high-contrast default
+	resolved := ResolveTheme(&config.ThemeConfig{Name: "nord"})
+	assert.Equal(t, BuiltInThemes["nord"]["background"], resolved["background"])
+}
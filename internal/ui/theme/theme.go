@@ -502,6 +502,35 @@ var BuiltInThemes = map[string]map[string]string{
 		"usagehigh":     "#e6c384",
 		"usagecritical": "#e46876",
 	},
+	// High contrast, used by accessible mode unless a theme is selected
+	"high-contrast": {
+		"primary":       "white",
+		"secondary":     "silver",
+		"tertiary":      "aqua",
+		"success":       "lime",
+		"warning":       "yellow",
+		"error":         "red",
+		"info":          "aqua",
+		"background":    "black",
+		"border":        "white",
+		"selection":     "yellow",
+		"header":        "white",
+		"headertext":    "black",
+		"footer":        "white",
+		"footertext":    "black",
+		"title":         "yellow",
+		"contrast":      "white",
+		"morecontrast":  "yellow",
+		"inverse":       "black",
+		"statusrunning": "lime",
+		"statusstopped": "red",
+		"statuspending": "yellow",
+		"statuserror":   "red",
+		"usagelow":      "lime",
+		"usagemedium":   "yellow",
+		"usagehigh":     "fuchsia",
+		"usagecritical": "red",
+	},
 	// Everforest (https://github.com/sainnhe/everforest#palette)
 	"everforest": {
 		"primary":       "#d3c6aa",
@@ -536,6 +565,9 @@ var BuiltInThemes = map[string]map[string]string{
 // ResolveTheme merges the selected built-in theme with user overrides.
 func ResolveTheme(cfg *config.ThemeConfig) map[string]string {
 	base := BuiltInThemes["default"]
+	if accessible {
+		base = BuiltInThemes["high-contrast"]
+	}
 
 	if cfg != nil && cfg.Name != "" {
 		if t, ok := BuiltInThemes[cfg.Name]; ok {
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -79,11 +80,11 @@ func FormatStatusIndicator(status string) string {
 
 	switch status {
 	case "running", "online":
-		tag = "[success]▲[-] "
+		tag = "[success]" + theme.Icon("▲", "+") + "[-] "
 	case "stopped", "offline":
-		tag = "[error]▼[-] "
+		tag = "[error]" + theme.Icon("▼", "-") + "[-] "
 	default:
-		tag = "[warning]●[-] "
+		tag = "[warning]" + theme.Icon("●", "~") + "[-] "
 	}
 
 	return theme.ReplaceSemanticTags(tag)
@@ -102,11 +103,11 @@ func FormatPendingStatusIndicator(status string, isPending bool, operation strin
 
 	switch status {
 	case "running", "online":
-		tag = "[success::d]🗘[-::id] "
+		tag = "[success::d]" + theme.Icon("🗘", "*") + "[-::id] "
 	case "stopped", "offline":
-		tag = "[error::d]🗘[-::id] "
+		tag = "[error::d]" + theme.Icon("🗘", "*") + "[-::id] "
 	default:
-		tag = "[warning::d]🗘[-::id] "
+		tag = "[warning::d]" + theme.Icon("🗘", "*") + "[-::id] "
 	}
 
 	return theme.ReplaceSemanticTags(tag)
@@ -138,3 +139,11 @@ func TrimTrailingWhitespace(s string) string {
 		return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 	})
 }
+
+// colorTagPattern matches tview color/style tags like "[red]", "[#ff0000:black:b]" or "[-]".
+var colorTagPattern = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(:([a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(:([bdilrsu]+|-)?)?)?\]`)
+
+// StripColorTags removes tview color tags from a string, leaving the text as displayed.
+func StripColorTags(s string) string {
+	return colorTagPattern.ReplaceAllString(s, "")
+}
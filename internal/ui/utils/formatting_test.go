@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripColorTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"10.0.0.5", "10.0.0.5"},
+		{"[green]2 running[-], [red]1 stopped[-]", "2 running, 1 stopped"},
+		{"[#ff0000:black:b]alert[-:-:-]", "alert"},
+		{"local-lvm [raw]", "local-lvm "},
+		{"array[0]", "array[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripColorTags(tt.input))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/devnullvoid/pvetui/internal/ui/theme"
+)
+
+var (
+	markdownHTMLTag    = regexp.MustCompile(`<[^>]*>`)
+	markdownLink       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownHeading    = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	markdownBullet     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	markdownQuote      = regexp.MustCompile(`^>\s?(.*)$`)
+	markdownBold       = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic     = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	markdownInlineCode = regexp.MustCompile("`([^`]+)`")
+)
+
+// RenderMarkdown renders notes written in basic markdown (headings, lists,
+// quotes, code, bold, italic, and links) as lines with tview color tags.
+// HTML tags are removed and runs of blank lines are collapsed.
+func RenderMarkdown(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	codeColor := theme.ColorToTag(theme.Colors.Secondary)
+	titleColor := theme.ColorToTag(theme.Colors.Title)
+
+	var (
+		lines  []string
+		inCode bool
+	)
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+
+			continue
+		}
+
+		if inCode {
+			lines = append(lines, fmt.Sprintf("[%s]  %s[-]", codeColor, tview.Escape(line)))
+
+			continue
+		}
+
+		line = strings.TrimRight(markdownHTMLTag.ReplaceAllString(line, ""), " \t")
+
+		if line == "" {
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+
+			continue
+		}
+
+		switch {
+		case markdownHeading.MatchString(line):
+			heading := markdownHeading.FindStringSubmatch(line)[1]
+			line = fmt.Sprintf("[%s::b]%s[-::-]", titleColor, renderInlineMarkdown(heading, codeColor))
+		case markdownBullet.MatchString(line):
+			match := markdownBullet.FindStringSubmatch(line)
+			line = match[1] + theme.Icon("•", "-") + " " + renderInlineMarkdown(match[2], codeColor)
+		case markdownQuote.MatchString(line):
+			quote := markdownQuote.FindStringSubmatch(line)[1]
+			line = fmt.Sprintf("[%s]%s[-] %s", codeColor, theme.Icon("│", "|"), renderInlineMarkdown(quote, codeColor))
+		default:
+			line = renderInlineMarkdown(line, codeColor)
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Drop a trailing blank line left by the collapsing above
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// renderInlineMarkdown escapes text and renders links, code, bold, and italic.
+func renderInlineMarkdown(text, codeColor string) string {
+	text = markdownLink.ReplaceAllString(text, "$1 <$2>")
+	text = tview.Escape(text)
+	text = markdownInlineCode.ReplaceAllString(text, "["+codeColor+"]$1[-]")
+	text = markdownBold.ReplaceAllString(text, "[::b]$1$2[::-]")
+	text = markdownItalic.ReplaceAllString(text, "[::i]$1$2[::-]")
+
+	return text
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	input := "# Owner\r\n\r\n\r\n**Team** *infra* and `db_01`\n- see [wiki](https://wiki/x)\n> <b>careful</b> [prod]\n```\nrm -rf [x]\n```\n\n"
+
+	lines := RenderMarkdown(input)
+
+	assert.Len(t, lines, 6)
+	assert.Equal(t, "Owner", StripColorTags(lines[0]))
+	assert.Contains(t, lines[0], "::b]")
+	assert.Equal(t, "", lines[1])
+	assert.Equal(t, "Team infra and db_01", StripColorTags(lines[2]))
+	assert.Contains(t, lines[2], "[::i]infra")
+	assert.Equal(t, "• see wiki <https://wiki/x>", StripColorTags(lines[3]))
+	assert.Contains(t, lines[4], "careful [prod[]")
+	assert.NotContains(t, lines[4], "<b>")
+	assert.Contains(t, lines[5], "rm -rf [x[]")
+}
+
+func TestRenderMarkdown_Empty(t *testing.T) {
+	assert.Empty(t, RenderMarkdown(""))
+	assert.Empty(t, RenderMarkdown("\n\n"))
+}
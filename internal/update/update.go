@@ -0,0 +1,360 @@
+// Package update checks GitHub for newer releases and replaces the running
+// binary with a downloaded release after verifying its checksum.
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devnullvoid/pvetui/internal/version"
+)
+
+// LatestReleaseURL is the GitHub API endpoint of the latest release.
+var LatestReleaseURL = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", version.GitHubUser, version.ProjectName)
+
+// CheckTimeout bounds the startup update check.
+const CheckTimeout = 10 * time.Second
+
+// maxDownloadSize bounds release downloads.
+const maxDownloadSize = 100 * 1024 * 1024
+
+// ErrDevBuild is returned when updating a development build.
+var ErrDevBuild = errors.New("development builds can't be updated, install a release")
+
+// Release is a published release.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the release version without the "v" prefix.
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// asset returns the asset with the given name.
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+
+	return Asset{}, false
+}
+
+// Client talks to the GitHub releases API.
+type Client struct {
+	HTTP       *http.Client
+	ReleaseURL string
+}
+
+// NewClient returns a client for the latest release of pvetui.
+func NewClient() *Client {
+	return &Client{
+		HTTP:       &http.Client{Timeout: 5 * time.Minute},
+		ReleaseURL: LatestReleaseURL,
+	}
+}
+
+// Latest returns the latest published release.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	data, err := c.get(ctx, c.ReleaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+
+	var release Release
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("invalid release response: %w", err)
+	}
+
+	if release.Tag == "" {
+		return nil, fmt.Errorf("invalid release response: missing tag")
+	}
+
+	return &release, nil
+}
+
+// Download fetches the release archive for the platform, verifies it
+// against the release checksums and returns the binary it contains.
+func (c *Client) Download(ctx context.Context, release *Release, goos, goarch string) ([]byte, error) {
+	archiveName := ArchiveName(release.Version(), goos, goarch)
+
+	archiveAsset, ok := release.asset(archiveName)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no archive for %s/%s", release.Tag, goos, goarch)
+	}
+
+	checksumsAsset, ok := release.asset(ChecksumsName(release.Version()))
+	if !ok {
+		return nil, fmt.Errorf("release %s has no checksums file", release.Tag)
+	}
+
+	checksums, err := c.get(ctx, checksumsAsset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	expected, err := findChecksum(checksums, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := c.get(ctx, archiveAsset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", archiveName, err)
+	}
+
+	sum := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, expected, actual)
+	}
+
+	return extractBinary(archive, goos)
+}
+
+// get downloads a URL.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Add("User-Agent", "pvetui")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("download exceeds %d bytes", maxDownloadSize)
+	}
+
+	return data, nil
+}
+
+// ArchiveName returns the name of the release archive for a platform.
+func ArchiveName(releaseVersion, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+
+	return fmt.Sprintf("%s_%s_%s_%s.%s", version.ProjectName, releaseVersion, goos, goarch, ext)
+}
+
+// ChecksumsName returns the name of the checksums file of a release.
+func ChecksumsName(releaseVersion string) string {
+	return fmt.Sprintf("checksums_%s.txt", releaseVersion)
+}
+
+// findChecksum looks up the SHA-256 of a file in a checksums file.
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum for %s", name)
+}
+
+// extractBinary returns the pvetui binary from a release archive.
+func extractBinary(archive []byte, goos string) ([]byte, error) {
+	name := version.ProjectName
+	if goos == "windows" {
+		name += ".exe"
+
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+
+		for _, file := range reader.File {
+			if path.Base(file.Name) != name {
+				continue
+			}
+
+			rc, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+
+			defer func() {
+				_ = rc.Close()
+			}()
+
+			return io.ReadAll(io.LimitReader(rc, maxDownloadSize))
+		}
+
+		return nil, fmt.Errorf("%s not found in archive", name)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	reader := tar.NewReader(gz)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s not found in archive", name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == name {
+			return io.ReadAll(io.LimitReader(reader, maxDownloadSize))
+		}
+	}
+}
+
+// Replace atomically replaces the executable at exePath with binary. The
+// old executable is moved aside first since Windows can't overwrite a
+// running program.
+func Replace(exePath string, binary []byte) error {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(exePath)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(exePath)+".new-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+
+	tmpPath := tmp.Name()
+
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	oldPath := exePath + ".old"
+	_ = os.Remove(oldPath)
+
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return fmt.Errorf("cannot replace %s: %w", exePath, err)
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		_ = os.Rename(oldPath, exePath)
+
+		return fmt.Errorf("cannot replace %s: %w", exePath, err)
+	}
+
+	// Removing the running executable fails on Windows; it is cleaned up
+	// by the next update
+	_ = os.Remove(oldPath)
+
+	return nil
+}
+
+// IsNewer reports whether the release version latest is newer than
+// current. Development builds are never outdated.
+func IsNewer(current, latest string) bool {
+	if current == "" || current == "dev" {
+		return false
+	}
+
+	return compareVersions(strings.TrimPrefix(latest, "v"), strings.TrimPrefix(current, "v")) > 0
+}
+
+// compareVersions compares dotted versions numerically. A pre-release
+// ("1.1.0-rc1") sorts before its release.
+func compareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(a, "-")
+	bCore, bPre, _ := strings.Cut(b, "-")
+
+	aParts, bParts := strings.Split(aCore, "."), strings.Split(bCore, ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+
+		if x != y {
+			if x > y {
+				return 1
+			}
+
+			return -1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
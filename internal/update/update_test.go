@@ -0,0 +1,138 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tarball(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o644, Size: 2, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("hi"))
+	require.NoError(t, err)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func releaseServer(t *testing.T, archive []byte, checksum string) *httptest.Server {
+	t.Helper()
+
+	archiveName := ArchiveName("1.1.0", "linux", "amd64")
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			fmt.Fprintf(w, `{"tag_name":"v1.1.0","html_url":"https://example.com/v1.1.0","assets":[
+				{"name":%q,"browser_download_url":"%s/archive"},
+				{"name":"checksums_1.1.0.txt","browser_download_url":"%s/checksums"}]}`,
+				archiveName, server.URL, server.URL)
+		case "/archive":
+			_, _ = w.Write(archive)
+		case "/checksums":
+			fmt.Fprintf(w, "%s  %s\n%s  other.tar.gz\n", checksum, archiveName, checksum)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_LatestAndDownload(t *testing.T) {
+	archive := tarball(t, "pvetui", []byte("new binary"))
+	sum := sha256.Sum256(archive)
+
+	server := releaseServer(t, archive, hex.EncodeToString(sum[:]))
+	client := &Client{HTTP: server.Client(), ReleaseURL: server.URL + "/latest"}
+
+	release, err := client.Latest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", release.Version())
+
+	binary, err := client.Download(context.Background(), release, "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "new binary", string(binary))
+
+	_, err = client.Download(context.Background(), release, "linux", "arm64")
+	assert.ErrorContains(t, err, "no archive for linux/arm64")
+}
+
+func TestClient_Download_ChecksumMismatch(t *testing.T) {
+	archive := tarball(t, "pvetui", []byte("tampered"))
+
+	server := releaseServer(t, archive, "0000")
+	client := &Client{HTTP: server.Client(), ReleaseURL: server.URL + "/latest"}
+
+	release, err := client.Latest(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.Download(context.Background(), release, "linux", "amd64")
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestReplace(t *testing.T) {
+	exePath := filepath.Join(t.TempDir(), "pvetui")
+	require.NoError(t, os.WriteFile(exePath, []byte("old"), 0o755))
+
+	require.NoError(t, Replace(exePath, []byte("new")))
+
+	content, err := os.ReadFile(exePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+
+	info, err := os.Stat(exePath)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode().Perm()&0o100)
+
+	entries, err := os.ReadDir(filepath.Dir(exePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		newer           bool
+	}{
+		{"1.0.5", "v1.0.6", true},
+		{"1.0.5", "v1.0.5", false},
+		{"1.0.10", "v1.0.9", false},
+		{"1.0.5", "v1.1", true},
+		{"1.1.0-rc1", "v1.1.0", true},
+		{"1.1.0", "v1.1.0-rc1", false},
+		{"dev", "v9.9.9", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.newer, IsNewer(tt.current, tt.latest), "%s -> %s", tt.current, tt.latest)
+	}
+}
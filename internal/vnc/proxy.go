@@ -26,11 +26,12 @@ type ProxyConfig struct {
 	Ticket   string
 	Password string
 
-	// Proxmox server details
-	ProxmoxHost string
-	NodeName    string
-	VMID        int
-	VMType      string // "qemu" or "lxc"
+	// Proxmox server details. ProxmoxURL is the websocket base URL of the
+	// server, e.g. wss://pve:8006, with the path prefix of a reverse proxy.
+	ProxmoxURL string
+	NodeName   string
+	VMID       int
+	VMType     string // "qemu" or "lxc"
 
 	// Authentication
 	AuthToken string
@@ -78,7 +79,7 @@ func NewWebSocketProxyWithSessionAndLogger(config *ProxyConfig, session SessionN
 
 	proxyLogger.Info("Creating new WebSocket proxy for %s (Type: %s, Node: %s)",
 		getTargetName(config), config.VMType, config.NodeName)
-	proxyLogger.Debug("Proxy config - Port: %s, Proxmox Host: %s", config.Port, config.ProxmoxHost)
+	proxyLogger.Debug("Proxy config - Port: %s, Proxmox URL: %s", config.Port, config.ProxmoxURL)
 
 	return &WebSocketProxy{
 		config:  config,
@@ -258,7 +259,7 @@ func (p *WebSocketProxy) connectToProxmox() (*websocket.Conn, error) {
 	p.logger.Debug("Building Proxmox VNC websocket URL for %s", targetName)
 
 	// Build the Proxmox VNC websocket URL
-	// Format: wss://hostname:port/api2/json/nodes/{node}/qemu/{vmid}/vncwebsocket?port={port}&vncticket={ticket}
+	// Format: wss://hostname:port[/prefix]/api2/json/nodes/{node}/qemu/{vmid}/vncwebsocket?port={port}&vncticket={ticket}
 	var vncPath string
 	if p.config.VMType == api.VMTypeQemu {
 		vncPath = fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/vncwebsocket",
@@ -275,8 +276,8 @@ func (p *WebSocketProxy) connectToProxmox() (*websocket.Conn, error) {
 	}
 
 	// Add query parameters
-	vncURL := fmt.Sprintf("wss://%s%s?port=%s&vncticket=%s",
-		p.config.ProxmoxHost, vncPath, p.config.Port, url.QueryEscape(p.config.Ticket))
+	vncURL := fmt.Sprintf("%s%s?port=%s&vncticket=%s",
+		p.config.ProxmoxURL, vncPath, p.config.Port, url.QueryEscape(p.config.Ticket))
 
 	p.logger.Debug("Proxmox VNC websocket URL for %s: %s", targetName, vncURL)
 
@@ -436,17 +437,9 @@ func CreateVMProxyConfigWithLogger(client *api.Client, vm *api.VM, sharedLogger
 	configLogger.Debug("VNC proxy response for VM %s - Port: %s, Ticket length: %d, Password length: %d",
 		vm.Name, proxy.Port, len(proxy.Ticket), len(proxy.Password))
 
-	// Extract hostname from client base URL
-	baseURL := client.GetBaseURL()
-
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		configLogger.Error("Failed to parse client base URL for VM %s: %v", vm.Name, err)
-
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
-	}
-
-	configLogger.Debug("Extracted Proxmox host for VM %s: %s", vm.Name, u.Host)
+	// The websocket URL keeps the port and path prefix of the client base URL
+	proxmoxURL := api.WebSocketURL(client.GetBaseURL())
+	configLogger.Debug("Proxmox websocket URL for VM %s: %s", vm.Name, proxmoxURL)
 
 	// Get authentication token
 	authToken := client.GetAuthToken()
@@ -461,15 +454,15 @@ func CreateVMProxyConfigWithLogger(client *api.Client, vm *api.VM, sharedLogger
 	}
 
 	config := &ProxyConfig{
-		Port:        proxy.Port,
-		Ticket:      proxy.Ticket,
-		Password:    password,
-		ProxmoxHost: u.Host,
-		NodeName:    vm.Node,
-		VMID:        vm.ID,
-		VMType:      vm.Type,
-		AuthToken:   authToken,
-		Timeout:     30 * time.Minute, // Increased to 30 minutes for VNC sessions
+		Port:       proxy.Port,
+		Ticket:     proxy.Ticket,
+		Password:   password,
+		ProxmoxURL: proxmoxURL,
+		NodeName:   vm.Node,
+		VMID:       vm.ID,
+		VMType:     vm.Type,
+		AuthToken:  authToken,
+		Timeout:    30 * time.Minute, // Increased to 30 minutes for VNC sessions
 	}
 
 	configLogger.Info("VNC proxy configuration created successfully for VM %s", vm.Name)
@@ -513,17 +506,9 @@ func CreateNodeProxyConfigWithLogger(client *api.Client, nodeName string, shared
 	configLogger.Debug("VNC shell proxy response for node %s - Port: %s, Ticket length: %d, Password length: %d",
 		nodeName, proxy.Port, len(proxy.Ticket), len(proxy.Password))
 
-	// Extract hostname from client base URL
-	baseURL := client.GetBaseURL()
-
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		configLogger.Error("Failed to parse client base URL for node %s: %v", nodeName, err)
-
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
-	}
-
-	configLogger.Debug("Extracted Proxmox host for node %s: %s", nodeName, u.Host)
+	// The websocket URL keeps the port and path prefix of the client base URL
+	proxmoxURL := api.WebSocketURL(client.GetBaseURL())
+	configLogger.Debug("Proxmox websocket URL for node %s: %s", nodeName, proxmoxURL)
 
 	// Get authentication token
 	authToken := client.GetAuthToken()
@@ -538,15 +523,15 @@ func CreateNodeProxyConfigWithLogger(client *api.Client, nodeName string, shared
 	}
 
 	config := &ProxyConfig{
-		Port:        proxy.Port,
-		Ticket:      proxy.Ticket,
-		Password:    password,
-		ProxmoxHost: u.Host,
-		NodeName:    nodeName,
-		VMID:        0, // Not applicable for node shells
-		VMType:      "node",
-		AuthToken:   authToken,
-		Timeout:     30 * time.Minute, // Increased to 30 minutes for VNC sessions
+		Port:       proxy.Port,
+		Ticket:     proxy.Ticket,
+		Password:   password,
+		ProxmoxURL: proxmoxURL,
+		NodeName:   nodeName,
+		VMID:       0, // Not applicable for node shells
+		VMType:     "node",
+		AuthToken:  authToken,
+		Timeout:    30 * time.Minute, // Increased to 30 minutes for VNC sessions
 	}
 
 	configLogger.Info("VNC proxy configuration created successfully for node %s", nodeName)
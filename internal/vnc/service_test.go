@@ -13,12 +13,12 @@ func TestOpenBrowserXdgOpenDetection(t *testing.T) {
 		t.Skip("This test is only relevant on Linux")
 	}
 
-	// Test that openBrowser returns an error when xdg-open is not found
+	// Test that OpenBrowser returns an error when xdg-open is not found
 	// We can simulate this by temporarily renaming xdg-open if it exists
 	_, err := exec.LookPath("xdg-open")
 	if err != nil {
 		// xdg-open doesn't exist, so our function should return an error
-		err := openBrowser("http://example.com")
+		err := OpenBrowser("http://example.com")
 		if err == nil {
 			t.Error("Expected error when xdg-open is not found, but got nil")
 		}
@@ -29,7 +29,7 @@ func TestOpenBrowserXdgOpenDetection(t *testing.T) {
 	}
 
 	// xdg-open exists, so our function should work (or at least not return the specific error)
-	err = openBrowser("http://example.com")
+	err = OpenBrowser("http://example.com")
 	if err != nil && strings.Contains(err.Error(), "xdg-open not found") {
 		t.Errorf("Unexpected 'xdg-open not found' error when xdg-open exists: %v", err)
 	}
@@ -12,11 +12,12 @@
 //   - Robust error handling and retry logic
 //   - Full support for VMs, containers, nodes, and cluster operations
 //   - Thread-safe operations with proper concurrency handling
+//   - Concurrent identical GET requests sent once
 //
 // Basic Usage:
 //
 //	// Create configuration
-//	config := &Config{
+//	config := &api.Config{
 //		Addr:     "https://pve.example.com:8006",
 //		User:     "root",
 //		Password: "password",
@@ -24,17 +25,27 @@
 //	}
 //
 //	// Create client with optional logger and cache
-//	client, err := NewClient(config, WithLogger(logger), WithCache(cache))
+//	client, err := api.NewClient(config, api.WithLogger(logger), api.WithCache(cache))
 //	if err != nil {
 //		log.Fatal(err)
 //	}
 //
 //	// Use the client
-//	vms, err := client.GetVmList(context.Background())
+//	cluster, err := client.GetClusterStatus()
 //	if err != nil {
 //		log.Fatal(err)
 //	}
 //
+// Contexts:
+//
+// Client.WithContext returns a copy of the client whose requests are
+// canceled with a context and follow its deadline:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//
+//	cluster, err := client.WithContext(ctx).GetClusterStatus()
+//
 // Authentication:
 //
 // The package supports two authentication methods:
@@ -43,6 +54,8 @@
 //   - Automatically handles ticket-based authentication
 //   - Manages CSRF tokens for write operations
 //   - Handles token refresh and expiration
+//   - Asks for the TOTP code of two-factor logins through a TFAPrompt
+//   - Can log in through an OpenID Connect realm instead of a password (see WithOpenID)
 //
 // 2. API Token Authentication:
 //   - Uses Proxmox API tokens for stateless authentication
@@ -54,6 +67,12 @@
 // All client operations are thread-safe and can be used concurrently
 // from multiple goroutines. Internal state is protected with appropriate
 // synchronization primitives.
+//
+// Stability:
+//
+// The package has no dependencies on the internal packages of pvetui and
+// follows the semantic versioning of the module, so it can be used by other
+// programs.
 package api
 
 import (
@@ -121,10 +140,23 @@ type AuthManager struct {
 	password   string            // Password for password authentication
 	token      string            // API token for token authentication
 	authToken  *AuthToken        // Cached authentication token
+	tfaPrompt  TFAPrompt         // Asks for the TOTP code of two-factor logins
+	openID     *openIDSettings   // OpenID Connect login, replacing the password
 	logger     interfaces.Logger // Logger for debugging and monitoring
+	login      *loginAttempt     // Login in progress, if any
 	mu         sync.RWMutex      // Mutex for thread-safe access
 }
 
+// loginAttempt is a login in progress, shared by the requests waiting for
+// a ticket. It runs without holding the mutex of the manager, so asking for
+// a TOTP code doesn't hold up other requests.
+type loginAttempt struct {
+	done      chan struct{} // Closed when the login finished
+	prompting chan struct{} // Closed when the TOTP code is asked for
+	token     *AuthToken
+	err       error
+}
+
 // NewAuthManagerWithPassword creates a new authentication manager for password-based authentication.
 //
 // This method sets up the manager to use username/password authentication with
@@ -153,6 +185,28 @@ func NewAuthManagerWithPassword(httpClient *HTTPClient, username, password strin
 	}
 }
 
+// NewAuthManagerWithOpenID creates a new authentication manager that logs in
+// through an OpenID Connect realm.
+//
+// Instead of a password, the identity provider of the realm authenticates
+// the user in a browser: authorize is called with the login URL and returns
+// the query of the redirect to redirectURL. The resulting ticket is only
+// kept in memory, so every session (and every ticket renewal) logs in again.
+//
+// Parameters:
+//   - httpClient: HTTP client configured for the Proxmox server
+//   - realm: Name of the OpenID realm (e.g., "company-sso")
+//   - redirectURL: URL the identity provider redirects to after the login
+//   - authorize: Lets the user log in and waits for the redirect
+//   - logger: Logger for debugging and error reporting
+func NewAuthManagerWithOpenID(httpClient *HTTPClient, realm, redirectURL string, authorize OpenIDAuthorizer, logger interfaces.Logger) *AuthManager {
+	return &AuthManager{
+		httpClient: httpClient,
+		openID:     &openIDSettings{realm: realm, redirectURL: redirectURL, authorize: authorize},
+		logger:     logger,
+	}
+}
+
 // NewAuthManagerWithToken creates a new authentication manager for API token authentication.
 //
 // This method sets up the manager to use Proxmox API tokens for stateless
@@ -197,8 +251,9 @@ func (am *AuthManager) EnsureAuthenticated() error {
 		return nil
 	}
 
-	// Using password authentication, need to get a ticket
-	_, err := am.GetValidToken(context.Background())
+	// Using password authentication, need to get a ticket, waiting for the
+	// TOTP code if one is asked for
+	_, err := am.authenticate(context.Background(), true)
 
 	return err
 }
@@ -211,7 +266,10 @@ func (am *AuthManager) EnsureAuthenticated() error {
 // or missing.
 //
 // This method is thread-safe and handles concurrent access properly. Multiple
-// goroutines can call this method simultaneously without issues.
+// goroutines can call this method simultaneously without issues. If the
+// login asks for a TOTP code, it fails with ErrTFARequired instead of waiting
+// for the code, which may be asked for on the goroutine of the caller (e.g.
+// the UI); the login completes in the background.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
@@ -246,7 +304,7 @@ func (am *AuthManager) GetValidToken(ctx context.Context) (*AuthToken, error) {
 	am.mu.RUnlock()
 
 	// Token is invalid or missing, need to authenticate
-	return am.authenticate(ctx)
+	return am.authenticate(ctx, false)
 }
 
 // authenticate performs the authentication flow with Proxmox API using username/password.
@@ -257,30 +315,97 @@ func (am *AuthManager) GetValidToken(ctx context.Context) (*AuthToken, error) {
 //   - Creates and caches the AuthToken with proper expiration
 //   - Handles concurrent authentication attempts safely
 //
-// The method uses form-encoded data as required by the Proxmox API and
-// automatically sets appropriate headers and user agent.
+// Concurrent calls share one login, which runs in the background so that
+// callers can stop waiting: when ctx is done, or, unless waitForCode is set,
+// when the login asks for a TOTP code.
 //
 // This is an internal method and should not be called directly. Use
 // GetValidToken() or EnsureAuthenticated() instead.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
+//   - waitForCode: Whether to wait while the TOTP code is asked for
 //
 // Returns the new authentication token or an error if authentication fails.
-func (am *AuthManager) authenticate(ctx context.Context) (*AuthToken, error) {
+func (am *AuthManager) authenticate(ctx context.Context, waitForCode bool) (*AuthToken, error) {
 	am.mu.Lock()
-	defer am.mu.Unlock()
 
 	// Double-check after acquiring write lock
 	if am.authToken != nil && am.authToken.IsValid() {
-		return am.authToken, nil
+		token := am.authToken
+		am.mu.Unlock()
+
+		return token, nil
 	}
 
-	am.logger.Debug("Authenticating with Proxmox API: %s", am.username)
+	attempt := am.login
+	if attempt == nil {
+		attempt = &loginAttempt{done: make(chan struct{}), prompting: make(chan struct{})}
+		am.login = attempt
 
-	// Prepare authentication request
-	authURL := EndpointAccessTicket
-	am.logger.Debug("Authentication URL: %s", authURL)
+		// The login is shared, so it outlives the context of the caller
+		// starting it
+		go am.runLogin(context.WithoutCancel(ctx), attempt)
+	}
+	am.mu.Unlock()
+
+	prompting := attempt.prompting
+	if waitForCode {
+		prompting = nil
+	}
+
+	select {
+	case <-attempt.done:
+		return attempt.token, attempt.err
+	case <-prompting:
+		return nil, fmt.Errorf("%w: waiting for the TOTP code", ErrTFARequired)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runLogin logs in and completes attempt with the new token.
+func (am *AuthManager) runLogin(ctx context.Context, attempt *loginAttempt) {
+	var (
+		data *ticketData
+		err  error
+	)
+
+	if am.openID != nil {
+		data, err = am.loginOpenID(ctx)
+	} else {
+		data, err = am.loginPassword(ctx, attempt)
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.login = nil
+
+	if err != nil {
+		attempt.err = err
+		close(attempt.done)
+
+		return
+	}
+
+	// Create token with 2-hour expiration (Proxmox default)
+	attempt.token = &AuthToken{
+		Ticket:    data.Ticket,
+		CSRFToken: data.CSRFPreventionToken,
+		Username:  data.Username,
+		ExpiresAt: time.Now().Add(2 * time.Hour),
+	}
+
+	am.authToken = attempt.token
+	am.logger.Debug("Authentication successful for user: %s", attempt.token.Username)
+	close(attempt.done)
+}
+
+// loginPassword logs in with username and password, asking for the second
+// factor if the account requires one.
+func (am *AuthManager) loginPassword(ctx context.Context, attempt *loginAttempt) (*ticketData, error) {
+	am.logger.Debug("Authenticating with Proxmox API: %s", am.username)
 
 	// Create form data
 	formData := url.Values{}
@@ -288,10 +413,56 @@ func (am *AuthManager) authenticate(ctx context.Context) (*AuthToken, error) {
 	formData.Set("password", am.password)
 	am.logger.Debug("Form data: username=%s, password=<hidden>", am.username)
 
+	data, err := am.requestTicket(ctx, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accounts with two-factor authentication get a partial ticket first
+	if IsTFATicket(data.Ticket) {
+		if data, err = am.completeTFA(ctx, data.Ticket, attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// ticketData is the data of an /access/ticket response.
+type ticketData struct {
+	Ticket              string `json:"ticket"`
+	CSRFPreventionToken string `json:"CSRFPreventionToken"`
+	Username            string `json:"username"`
+}
+
+// requestTicket posts the form to the /access/ticket endpoint.
+func (am *AuthManager) requestTicket(ctx context.Context, formData url.Values) (*ticketData, error) {
+	// Parse response
+	var authResponse struct {
+		Data ticketData `json:"data"`
+	}
+
+	if err := am.postForm(ctx, EndpointAccessTicket, formData, &authResponse); err != nil {
+		return nil, err
+	}
+
+	// Validate response
+	if authResponse.Data.Ticket == "" {
+		return nil, fmt.Errorf("%w: no ticket received", ErrAuthFailed)
+	}
+
+	return &authResponse.Data, nil
+}
+
+// postForm posts an unauthenticated form to one of the login endpoints and
+// decodes the JSON response into result.
+func (am *AuthManager) postForm(ctx context.Context, authURL string, formData url.Values, result interface{}) error {
+	am.logger.Debug("Authentication URL: %s", authURL)
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, am.httpClient.baseURL+authURL, strings.NewReader(formData.Encode()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create authentication request: %w", err)
+		return fmt.Errorf("failed to create authentication request: %w", err)
 	}
 
 	// Set headers
@@ -303,7 +474,11 @@ func (am *AuthManager) authenticate(ctx context.Context) (*AuthToken, error) {
 	// Execute request
 	resp, err := am.httpClient.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("authentication request failed: %w", err)
+		if !isConnectionFailure(ctx, err) {
+			return fmt.Errorf("authentication request failed: %w", err)
+		}
+
+		return fmt.Errorf("authentication request failed: %w: %w", ErrConnection, err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -317,39 +492,69 @@ func (am *AuthManager) authenticate(ctx context.Context) (*AuthToken, error) {
 		body, _ := io.ReadAll(resp.Body)
 		am.logger.Debug("Authentication failed response body: %s", string(body))
 
-		return nil, fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, resp.Status)
+		return fmt.Errorf("%w with status %d: %s", ErrAuthFailed, resp.StatusCode, resp.Status)
 	}
 
-	// Parse response
-	var authResponse struct {
-		Data struct {
-			Ticket              string `json:"ticket"`
-			CSRFPreventionToken string `json:"CSRFPreventionToken"`
-			Username            string `json:"username"`
-		} `json:"data"`
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse authentication response: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&authResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse authentication response: %w", err)
+	return nil
+}
+
+// completeTFA answers the two-factor challenge of a partial ticket with a
+// TOTP code from the configured prompt, telling the requests waiting for
+// attempt that the code is asked for.
+func (am *AuthManager) completeTFA(ctx context.Context, partialTicket string, attempt *loginAttempt) (*ticketData, error) {
+	challenge, err := ParseTFAChallenge(partialTicket)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthFailed, err)
 	}
 
-	// Validate response
-	if authResponse.Data.Ticket == "" {
-		return nil, fmt.Errorf("authentication failed: no ticket received")
+	if !challenge.TOTP {
+		return nil, tfaUnsupportedError(challenge)
 	}
 
-	// Create token with 2-hour expiration (Proxmox default)
-	token := &AuthToken{
-		Ticket:    authResponse.Data.Ticket,
-		CSRFToken: authResponse.Data.CSRFPreventionToken,
-		Username:  authResponse.Data.Username,
-		ExpiresAt: time.Now().Add(2 * time.Hour),
+	am.mu.RLock()
+	prompt := am.tfaPrompt
+	am.mu.RUnlock()
+
+	if prompt == nil {
+		return nil, fmt.Errorf("%w: no way to ask for the TOTP code; use an API token instead", ErrTFARequired)
+	}
+
+	am.logger.Debug("Two-factor authentication required for user: %s", am.username)
+
+	close(attempt.prompting)
+
+	code, err := prompt(challenge)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTFARequired, err)
+	}
+
+	formData := url.Values{}
+	formData.Set("username", am.username)
+	formData.Set("password", "totp:"+strings.TrimSpace(code))
+	formData.Set("tfa-challenge", partialTicket)
+
+	data, err := am.requestTicket(ctx, formData)
+	if err != nil {
+		return nil, fmt.Errorf("two-factor authentication failed: %w", err)
 	}
 
-	am.authToken = token
-	am.logger.Debug("Authentication successful for user: %s", token.Username)
+	if IsTFATicket(data.Ticket) {
+		return nil, fmt.Errorf("two-factor %w: no full ticket received", ErrAuthFailed)
+	}
 
-	return token, nil
+	return data, nil
+}
+
+// SetTFAPrompt sets how the TOTP code of two-factor logins is asked for.
+// Without a prompt, such logins fail with ErrTFARequired.
+func (am *AuthManager) SetTFAPrompt(prompt TFAPrompt) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.tfaPrompt = prompt
 }
 
 // ClearToken clears the cached authentication token, forcing re-authentication on next use.
@@ -391,3 +596,13 @@ func (am *AuthManager) ClearToken() {
 func (am *AuthManager) IsTokenAuth() bool {
 	return am.token != ""
 }
+
+// SetAPIToken replaces the API token used for authentication, in the
+// format PVEAPIToken=USER@REALM!TOKENID=SECRET.
+func (am *AuthManager) SetAPIToken(token string) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.token = token
+	am.httpClient.SetAPIToken(token)
+}
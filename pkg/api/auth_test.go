@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -250,7 +252,7 @@ func TestAuthManager_authenticate_Success(t *testing.T) {
 
 	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", logger)
 
-	token, err := authManager.authenticate(context.Background())
+	token, err := authManager.authenticate(context.Background(), false)
 	require.NoError(t, err)
 	assert.NotNil(t, token)
 	assert.Equal(t, "auth-ticket", token.Ticket)
@@ -284,10 +286,11 @@ func TestAuthManager_authenticate_HTTPError(t *testing.T) {
 
 	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "wrongpass", logger)
 
-	token, err := authManager.authenticate(context.Background())
+	token, err := authManager.authenticate(context.Background(), false)
 	assert.Error(t, err)
 	assert.Nil(t, token)
 	assert.Contains(t, err.Error(), "authentication failed with status 401")
+	assert.ErrorIs(t, err, ErrAuthFailed)
 }
 
 func TestAuthManager_authenticate_InvalidJSON(t *testing.T) {
@@ -312,7 +315,7 @@ func TestAuthManager_authenticate_InvalidJSON(t *testing.T) {
 
 	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", logger)
 
-	token, err := authManager.authenticate(context.Background())
+	token, err := authManager.authenticate(context.Background(), false)
 	assert.Error(t, err)
 	assert.Nil(t, token)
 	assert.Contains(t, err.Error(), "failed to parse authentication response")
@@ -347,7 +350,7 @@ func TestAuthManager_authenticate_NoTicket(t *testing.T) {
 
 	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", logger)
 
-	token, err := authManager.authenticate(context.Background())
+	token, err := authManager.authenticate(context.Background(), false)
 	assert.Error(t, err)
 	assert.Nil(t, token)
 	assert.Contains(t, err.Error(), "authentication failed: no ticket received")
@@ -363,7 +366,7 @@ func TestAuthManager_authenticate_NetworkError(t *testing.T) {
 
 	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", logger)
 
-	token, err := authManager.authenticate(context.Background())
+	token, err := authManager.authenticate(context.Background(), false)
 	assert.Error(t, err)
 	assert.Nil(t, token)
 	// Depending on network environment, the exact error may vary
@@ -556,3 +559,215 @@ func BenchmarkAuthManager_GetValidToken_Cached(b *testing.B) {
 		_, _ = authManager.GetValidToken(ctx)
 	}
 }
+
+func TestAuthManager_authenticate_TOTP(t *testing.T) {
+	challenge := url.QueryEscape(`{"totp":true,"recovery":[0,1],"webauthn":{"challenge":"abc"}}`)
+	partialTicket := "PVE:!tfa!" + challenge + ":65A0B1C2::signature"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "testuser", r.Form.Get("username"))
+
+		data := map[string]interface{}{"ticket": partialTicket, "username": "testuser", "NeedTFA": 1}
+
+		if r.Form.Get("tfa-challenge") != "" {
+			assert.Equal(t, partialTicket, r.Form.Get("tfa-challenge"))
+
+			if r.Form.Get("password") != "totp:123456" {
+				http.Error(w, "invalid code", http.StatusUnauthorized)
+
+				return
+			}
+
+			data = map[string]interface{}{"ticket": "full-ticket", "CSRFPreventionToken": "csrf", "username": "testuser"}
+		} else {
+			assert.Equal(t, "testpass", r.Form.Get("password"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	httpClient := &HTTPClient{baseURL: server.URL, client: server.Client()}
+	logger := testutils.NewTestLogger()
+
+	// Without a prompt the login cannot complete
+	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", logger)
+	_, err := authManager.authenticate(context.Background(), false)
+	assert.ErrorIs(t, err, ErrTFARequired)
+
+	var asked TFAChallenge
+
+	authManager.SetTFAPrompt(func(challenge TFAChallenge) (string, error) {
+		asked = challenge
+
+		return " 123456 ", nil
+	})
+
+	token, err := authManager.authenticate(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "full-ticket", token.Ticket)
+	assert.Equal(t, "csrf", token.CSRFToken)
+	assert.Equal(t, TFAChallenge{TOTP: true, Recovery: true, WebAuthn: true}, asked)
+
+	authManager.ClearToken()
+	authManager.SetTFAPrompt(func(TFAChallenge) (string, error) { return "000000", nil })
+	_, err = authManager.authenticate(context.Background(), true)
+	assert.ErrorContains(t, err, "two-factor authentication failed")
+}
+
+func TestAuthManager_authenticate_TOTPPromptDoesNotBlock(t *testing.T) {
+	partialTicket := "PVE:!tfa!" + url.QueryEscape(`{"totp":true}`) + ":65A0B1C2::signature"
+
+	var logins atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		data := map[string]interface{}{"ticket": partialTicket, "username": "testuser"}
+		if r.Form.Get("tfa-challenge") != "" {
+			data = map[string]interface{}{"ticket": "full-ticket", "CSRFPreventionToken": "csrf", "username": "testuser"}
+		} else {
+			logins.Add(1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	httpClient := &HTTPClient{baseURL: server.URL, client: server.Client()}
+	authManager := NewAuthManagerWithPassword(httpClient, "testuser", "testpass", testutils.NewTestLogger())
+
+	asked := make(chan struct{})
+	code := make(chan string)
+
+	authManager.SetTFAPrompt(func(TFAChallenge) (string, error) {
+		close(asked)
+
+		return <-code, nil
+	})
+
+	// Requests fail instead of waiting for the code, and the manager stays
+	// usable while it is asked for
+	_, err := authManager.GetValidToken(context.Background())
+	assert.ErrorIs(t, err, ErrTFARequired)
+
+	<-asked
+
+	_, err = authManager.GetValidToken(context.Background())
+	assert.ErrorIs(t, err, ErrTFARequired)
+	authManager.ClearToken()
+
+	// The login completes in the background, shared by the waiting callers
+	waited := make(chan *AuthToken)
+
+	go func() {
+		token, _ := authManager.authenticate(context.Background(), true)
+		waited <- token
+	}()
+
+	code <- "123456"
+
+	token := <-waited
+	require.NotNil(t, token)
+	assert.Equal(t, "full-ticket", token.Ticket)
+
+	token, err = authManager.GetValidToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "full-ticket", token.Ticket)
+	assert.Equal(t, int32(1), logins.Load())
+}
+
+func TestParseTFAChallenge(t *testing.T) {
+	challenge, err := ParseTFAChallenge("PVE:!tfa!" + url.QueryEscape(`{"webauthn":{"challenge":"abc"},"totp":false}`) + ":65A0B1C2::sig")
+	require.NoError(t, err)
+	assert.Equal(t, TFAChallenge{WebAuthn: true}, challenge)
+
+	err = tfaUnsupportedError(challenge)
+	assert.ErrorIs(t, err, ErrTFARequired)
+	assert.ErrorContains(t, err, "WebAuthn")
+	assert.ErrorContains(t, err, "API token")
+
+	_, err = ParseTFAChallenge("PVE:root@pam:65A0B1C2::sig")
+	assert.Error(t, err)
+	assert.False(t, IsTFATicket("PVE:root@pam:65A0B1C2::sig"))
+}
+
+func TestAuthManager_authenticate_OpenID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "http://localhost:8765/", r.Form.Get("redirect-url"))
+
+		var data interface{}
+
+		switch r.URL.Path {
+		case EndpointOpenIDAuthURL:
+			assert.Equal(t, "company-sso", r.Form.Get("realm"))
+			data = "https://idp.example.com/auth?state=xyz"
+		case EndpointOpenIDLogin:
+			assert.Equal(t, "abc", r.Form.Get("code"))
+			assert.Equal(t, "xyz", r.Form.Get("state"))
+			data = map[string]interface{}{"ticket": "oidc-ticket", "CSRFPreventionToken": "csrf", "username": "jdoe@company-sso"}
+		default:
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	httpClient := &HTTPClient{baseURL: server.URL, client: server.Client()}
+
+	var opened string
+
+	authManager := NewAuthManagerWithOpenID(httpClient, "company-sso", "http://localhost:8765/",
+		func(_ context.Context, authURL string) (url.Values, error) {
+			opened = authURL
+
+			return url.Values{"code": {"abc"}, "state": {"xyz"}}, nil
+		}, testutils.NewTestLogger())
+
+	token, err := authManager.authenticate(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/auth?state=xyz", opened)
+	assert.Equal(t, "oidc-ticket", token.Ticket)
+	assert.Equal(t, "jdoe@company-sso", token.Username)
+	assert.False(t, authManager.IsTokenAuth())
+
+	// Errors of the identity provider are passed on
+	authManager.ClearToken()
+	authManager.SetOpenIDAuthorizer(func(context.Context, string) (url.Values, error) {
+		return url.Values{"error": {"access_denied"}}, nil
+	})
+	_, err = authManager.authenticate(context.Background(), false)
+	assert.ErrorContains(t, err, "access_denied")
+}
+
+func TestListRealms(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api2/json"+EndpointAccessDomains, r.URL.Path)
+		assert.Empty(t, r.Header.Get("Cookie"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]interface{}{
+			{"realm": "pve", "type": "pve", "comment": "Proxmox VE authentication server"},
+			{"realm": "company-sso", "type": "openid", "comment": "Company SSO"},
+			{"realm": "pam", "type": "pam", "default": 1},
+		}})
+	}))
+	defer server.Close()
+
+	realms, err := ListRealms(&Config{Addr: server.URL, Insecure: true})
+	require.NoError(t, err)
+	require.Len(t, realms, 3)
+	assert.Equal(t, "pam", realms[0].Realm)
+	assert.True(t, realms[0].Default)
+	assert.Equal(t, "company-sso", realms[1].Realm)
+	assert.True(t, realms[1].IsOpenID())
+	assert.False(t, realms[2].IsOpenID())
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Backup represents a guest backup archive stored on a Proxmox storage.
+type Backup struct {
+	VolID     string    `json:"volid"`     // Volume ID like "local:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst"
+	VMID      int       `json:"vmid"`      // Guest the archive belongs to
+	Type      string    `json:"subtype"`   // Guest type (qemu or lxc), empty if unknown
+	Storage   string    `json:"storage"`   // Storage holding the archive
+	Format    string    `json:"format"`    // Archive format (vma.zst, tar.zst, pbs-vm, ...)
+	Size      int64     `json:"size"`      // Archive size in bytes
+	CTime     time.Time `json:"ctime"`     // Creation time
+	Notes     string    `json:"notes"`     // Backup notes (if any)
+	Protected bool      `json:"protected"` // Whether the backup is protected from pruning
+}
+
+// GetGuestBackups retrieves the backups of a VM or container from all backup
+// storages available on the guest's node, newest first.
+//
+// Storages that cannot be listed (e.g. offline or permission denied) are skipped.
+func (c *Client) GetGuestBackups(vm *VM) ([]Backup, error) {
+	var storagesResp map[string]interface{}
+	if err := c.Get(fmt.Sprintf("/nodes/%s/storage?content=backup&enabled=1", vm.Node), &storagesResp); err != nil {
+		return nil, fmt.Errorf("failed to get backup storages: %w", err)
+	}
+
+	storages, ok := storagesResp["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid storage response format")
+	}
+
+	var backups []Backup
+
+	for _, item := range storages {
+		storageData, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		storage := getString(storageData, "storage")
+		if storage == "" {
+			continue
+		}
+
+		path := fmt.Sprintf("/nodes/%s/storage/%s/content?content=backup&vmid=%d", vm.Node, url.PathEscape(storage), vm.ID)
+
+		var contentResp map[string]interface{}
+		if err := c.GetNoRetry(path, &contentResp); err != nil {
+			c.logger.Debug("Skipping backup storage %s for guest %d: %v", storage, vm.ID, err)
+
+			continue
+		}
+
+		contents, ok := contentResp["data"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, content := range contents {
+			backupData, ok := content.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if !isGuestBackup(backupData, vm) {
+				continue
+			}
+
+			backup := parseBackup(backupData, storage)
+			if backup.VMID == 0 {
+				backup.VMID = vm.ID
+			}
+
+			if backup.Type == "" {
+				backup.Type = vm.Type
+			}
+
+			backups = append(backups, backup)
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CTime.After(backups[j].CTime)
+	})
+
+	return backups, nil
+}
+
+// GetLatestBackups returns the newest backup archive of every guest found on
+// the backup storages of the given nodes, keyed by VMID. Shared storages are
+// listed once. Archives only exist for backups that succeeded, so this is
+// the last successful backup of each guest.
+//
+// Nodes and storages that cannot be listed are skipped; an error is only
+// returned if no node could be queried at all.
+func (c *Client) GetLatestBackups(nodes []string) (map[int]Backup, error) {
+	latest := make(map[int]Backup)
+	listed := make(map[string]bool)
+
+	var lastErr error
+
+	queried := 0
+
+	for _, node := range nodes {
+		var storagesResp map[string]interface{}
+		if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/storage?content=backup&enabled=1", node), &storagesResp); err != nil {
+			c.logger.Debug("Skipping backup storages of node %s: %v", node, err)
+			lastErr = err
+
+			continue
+		}
+
+		queried++
+
+		storages, ok := storagesResp["data"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, item := range storages {
+			storageData, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			storage := getString(storageData, "storage")
+			if storage == "" {
+				continue
+			}
+
+			// Shared storages hold the same archives on every node
+			key := node + "/" + storage
+			if getBool(storageData, "shared") {
+				key = storage
+			}
+
+			if listed[key] {
+				continue
+			}
+
+			listed[key] = true
+
+			var contentResp map[string]interface{}
+			if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/storage/%s/content?content=backup", node, url.PathEscape(storage)), &contentResp); err != nil {
+				c.logger.Debug("Skipping backup storage %s on node %s: %v", storage, node, err)
+
+				continue
+			}
+
+			contents, ok := contentResp["data"].([]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, content := range contents {
+				backupData, ok := content.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				backup := parseBackup(backupData, storage)
+				if backup.VMID == 0 {
+					continue
+				}
+
+				if current, ok := latest[backup.VMID]; !ok || backup.CTime.After(current.CTime) {
+					latest[backup.VMID] = backup
+				}
+			}
+		}
+	}
+
+	if queried == 0 && lastErr != nil {
+		return nil, fmt.Errorf("failed to get backup storages: %w", lastErr)
+	}
+
+	return latest, nil
+}
+
+// parseBackup converts a storage content entry to a Backup.
+func parseBackup(data map[string]interface{}, storage string) Backup {
+	volID := getString(data, "volid")
+
+	return Backup{
+		VolID:     volID,
+		VMID:      int(getFloat(data, "vmid")),
+		Type:      backupGuestType(data),
+		Storage:   storageFromVolID(volID, storage),
+		Format:    getString(data, "format"),
+		Size:      int64(getFloat(data, "size")),
+		CTime:     time.Unix(int64(getFloat(data, "ctime")), 0),
+		Notes:     getString(data, "notes"),
+		Protected: getBool(data, "protected"),
+	}
+}
+
+// isGuestBackup reports whether a storage content entry is a backup of the
+// given guest. Storages may ignore the vmid filter, and a VM and a container
+// on different clusters can share a VMID, so both ID and guest type are checked.
+func isGuestBackup(data map[string]interface{}, vm *VM) bool {
+	if _, ok := data["vmid"]; ok && int(getFloat(data, "vmid")) != vm.ID {
+		return false
+	}
+
+	guestType := backupGuestType(data)
+
+	return guestType == "" || guestType == vm.Type
+}
+
+// backupGuestType returns the guest type of a storage content entry, or ""
+// if it can't be told.
+func backupGuestType(data map[string]interface{}) string {
+	if guestType := getString(data, "subtype"); guestType != "" {
+		return guestType
+	}
+
+	// Older storages only encode the type in the format or archive name
+	format, volID := getString(data, "format"), getString(data, "volid")
+
+	switch {
+	case format == "pbs-vm", strings.Contains(volID, "vzdump-qemu-"):
+		return VMTypeQemu
+	case format == "pbs-ct", strings.Contains(volID, "vzdump-lxc-"), strings.Contains(volID, "vzdump-openvz-"):
+		return VMTypeLXC
+	default:
+		return ""
+	}
+}
+
+// storageFromVolID returns the storage part of a volume ID, falling back to the given default.
+func storageFromVolID(volID, fallback string) string {
+	if idx := strings.Index(volID, ":"); idx > 0 {
+		return volID[:idx]
+	}
+
+	return fallback
+}
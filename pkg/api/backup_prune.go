@@ -0,0 +1,235 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marks of backups in a prune preview.
+const (
+	PruneMarkKeep      = "keep"
+	PruneMarkRemove    = "remove"
+	PruneMarkProtected = "protected"
+	// PruneMarkRenamed is set on archives that don't follow the standard
+	// naming scheme; they are never pruned.
+	PruneMarkRenamed = "renamed"
+)
+
+// pruneKeepOptions are the retention options of Proxmox in the order they
+// are applied.
+var pruneKeepOptions = []string{"keep-last", "keep-hourly", "keep-daily", "keep-weekly", "keep-monthly", "keep-yearly"}
+
+// PruneOptions is a backup retention policy. Each field is the number of
+// backups kept for its interval; 0 leaves the interval unused.
+type PruneOptions struct {
+	KeepLast    int
+	KeepHourly  int
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+	KeepYearly  int
+}
+
+// counts returns the fields in the order of pruneKeepOptions.
+func (o *PruneOptions) counts() []*int {
+	return []*int{&o.KeepLast, &o.KeepHourly, &o.KeepDaily, &o.KeepWeekly, &o.KeepMonthly, &o.KeepYearly}
+}
+
+// IsEmpty reports whether no interval is set. Proxmox keeps all backups
+// then.
+func (o PruneOptions) IsEmpty() bool {
+	for _, count := range o.counts() {
+		if *count > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String formats the policy as a Proxmox property string like
+// "keep-last=3,keep-daily=7".
+func (o PruneOptions) String() string {
+	var parts []string
+
+	for i, count := range o.counts() {
+		if *count > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", pruneKeepOptions[i], *count))
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// ParsePruneOptions parses a Proxmox prune-backups property string.
+// keep-all and unknown options are ignored.
+func ParsePruneOptions(value string) (PruneOptions, error) {
+	var options PruneOptions
+
+	counts := options.counts()
+
+	for _, part := range strings.Split(value, ",") {
+		name, number, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		for i, option := range pruneKeepOptions {
+			if name != option {
+				continue
+			}
+
+			count, err := strconv.Atoi(number)
+			if err != nil || count < 0 {
+				return PruneOptions{}, fmt.Errorf("invalid %s value %q", name, number)
+			}
+
+			*counts[i] = count
+		}
+	}
+
+	return options, nil
+}
+
+// PruneEntry is a backup in a prune preview.
+type PruneEntry struct {
+	VolID string
+	VMID  int
+	Type  string
+	CTime time.Time
+	// Mark is one of the PruneMark constants.
+	Mark string
+}
+
+// GetBackupStorages returns the names of the enabled storages of node that
+// hold backups.
+func (c *Client) GetBackupStorages(node string) ([]string, error) {
+	var result map[string]interface{}
+	if err := c.Get(fmt.Sprintf("/nodes/%s/storage?content=backup&enabled=1", node), &result); err != nil {
+		return nil, fmt.Errorf("failed to get backup storages: %w", err)
+	}
+
+	items, ok := result["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid storage response format")
+	}
+
+	var storages []string
+
+	for _, item := range items {
+		if data, ok := item.(map[string]interface{}); ok {
+			if storage := getString(data, "storage"); storage != "" {
+				storages = append(storages, storage)
+			}
+		}
+	}
+
+	sort.Strings(storages)
+
+	return storages, nil
+}
+
+// GetStoragePruneOptions returns the retention policy configured on a
+// storage, which is empty if it has none.
+func (c *Client) GetStoragePruneOptions(storage string) (PruneOptions, error) {
+	var result map[string]interface{}
+	if err := c.Get("/storage/"+url.PathEscape(storage), &result); err != nil {
+		return PruneOptions{}, fmt.Errorf("failed to get storage %s: %w", storage, err)
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return PruneOptions{}, fmt.Errorf("invalid storage response format")
+	}
+
+	return ParsePruneOptions(getString(data, "prune-backups"))
+}
+
+// pruneBackupsPath returns the prune endpoint of a storage with the policy
+// and an optional guest filter as query parameters.
+func pruneBackupsPath(node, storage string, options PruneOptions, vmid int) string {
+	query := url.Values{}
+	query.Set("prune-backups", options.String())
+
+	if vmid > 0 {
+		query.Set("vmid", strconv.Itoa(vmid))
+	}
+
+	return fmt.Sprintf("/nodes/%s/storage/%s/prunebackups?%s", node, url.PathEscape(storage), query.Encode())
+}
+
+// PreviewPruneBackups simulates pruning the backups on a storage with the
+// given policy, limited to one guest if vmid is set. The entries are sorted
+// by guest, newest first.
+func (c *Client) PreviewPruneBackups(node, storage string, options PruneOptions, vmid int) ([]PruneEntry, error) {
+	if options.IsEmpty() {
+		return nil, fmt.Errorf("the retention policy keeps all backups")
+	}
+
+	var result map[string]interface{}
+	if err := c.GetNoRetry(pruneBackupsPath(node, storage, options, vmid), &result); err != nil {
+		return nil, fmt.Errorf("failed to preview prune: %w", err)
+	}
+
+	items, ok := result["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid prune preview response format")
+	}
+
+	entries := make([]PruneEntry, 0, len(items))
+
+	for _, item := range items {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, PruneEntry{
+			VolID: getString(data, "volid"),
+			VMID:  int(getFloat(data, "vmid")),
+			Type:  getString(data, "type"),
+			CTime: time.Unix(int64(getFloat(data, "ctime")), 0),
+			Mark:  getString(data, "mark"),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].VMID != entries[j].VMID {
+			return entries[i].VMID < entries[j].VMID
+		}
+
+		return entries[i].CTime.After(entries[j].CTime)
+	})
+
+	return entries, nil
+}
+
+// PruneBackups removes the backups on storage of node that options, the
+// retention policy, doesn't keep, limited to the backups of one guest if
+// vmid is set (0 prunes those of all guests). Protected archives and
+// archives without the standard naming scheme are never removed; use
+// PreviewPruneBackups to see which backups would go. A policy that keeps
+// everything is refused. It returns the UPID of the prune task.
+func (c *Client) PruneBackups(node, storage string, options PruneOptions, vmid int) (string, error) {
+	if options.IsEmpty() {
+		return "", fmt.Errorf("the retention policy keeps all backups")
+	}
+
+	c.logger.Info("Pruning backups on storage %s of node %s with %s", storage, node, options)
+
+	var result map[string]interface{}
+	if err := c.httpClient.Delete(c.requestContext(), pruneBackupsPath(node, storage, options, vmid), &result); err != nil {
+		return "", fmt.Errorf("failed to prune backups: %w", err)
+	}
+
+	upid, ok := result["data"].(string)
+	if !ok || !strings.HasPrefix(upid, "UPID:") {
+		return "", fmt.Errorf("unexpected response when pruning backups")
+	}
+
+	return upid, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneOptions(t *testing.T) {
+	options, err := ParsePruneOptions("keep-all=0,keep-daily=7,keep-last=3, keep-monthly=6")
+	require.NoError(t, err)
+	assert.Equal(t, PruneOptions{KeepLast: 3, KeepDaily: 7, KeepMonthly: 6}, options)
+	assert.Equal(t, "keep-last=3,keep-daily=7,keep-monthly=6", options.String())
+	assert.False(t, options.IsEmpty())
+
+	empty, err := ParsePruneOptions("")
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, "", empty.String())
+
+	_, err = ParsePruneOptions("keep-last=many")
+	assert.Error(t, err)
+}
+
+func TestClient_PruneBackups(t *testing.T) {
+	var deleted string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/backups":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"storage": "backups", "prune-backups": "keep-last=2,keep-weekly=4"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/nodes/pve1/storage/backups/prunebackups":
+			assert.Equal(t, "keep-last=2", r.URL.Query().Get("prune-backups"))
+			assert.Equal(t, "100", r.URL.Query().Get("vmid"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"volid": "backups:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst", "vmid": 100, "type": "qemu", "ctime": 1704067200, "mark": "remove"},
+				map[string]interface{}{"volid": "backups:backup/vzdump-qemu-100-2024_01_03-00_00_00.vma.zst", "vmid": 100, "type": "qemu", "ctime": 1704240000, "mark": "keep"},
+				map[string]interface{}{"volid": "backups:backup/vzdump-qemu-100-2024_01_02-00_00_00.vma.zst", "vmid": 100, "type": "qemu", "ctime": 1704153600, "mark": "protected"},
+			}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/nodes/pve1/storage/backups/prunebackups":
+			deleted = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": "UPID:pve1:0001:0002:0003:prunebackups:backups:root@pam:"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	options, err := client.GetStoragePruneOptions("backups")
+	require.NoError(t, err)
+	assert.Equal(t, PruneOptions{KeepLast: 2, KeepWeekly: 4}, options)
+
+	policy := PruneOptions{KeepLast: 2}
+
+	entries, err := client.PreviewPruneBackups("pve1", "backups", policy, 100)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	// Newest first
+	assert.Equal(t, PruneMarkKeep, entries[0].Mark)
+	assert.Equal(t, PruneMarkProtected, entries[1].Mark)
+	assert.Equal(t, PruneMarkRemove, entries[2].Mark)
+	assert.Equal(t, 100, entries[2].VMID)
+
+	upid, err := client.PruneBackups("pve1", "backups", policy, 100)
+	require.NoError(t, err)
+	assert.Contains(t, upid, "prunebackups")
+	assert.Contains(t, deleted, "prune-backups=keep-last%3D2")
+
+	// An empty policy would keep everything; it's refused before any request
+	_, err = client.PruneBackups("pve1", "backups", PruneOptions{}, 0)
+	assert.Error(t, err)
+}
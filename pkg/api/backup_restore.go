@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RestoreOptions contains options for restoring a backup archive as a new guest.
+type RestoreOptions struct {
+	// VMID is the ID of the new guest. It must not be in use; existing guests
+	// are never overwritten.
+	VMID int `json:"vmid"`
+
+	// Node is the node the guest is restored on. The archive must be readable
+	// from it, i.e. on a shared storage or a storage of that node.
+	Node string `json:"node"`
+
+	// Name optionally replaces the VM name or container hostname of the backup.
+	Name string `json:"name,omitempty"`
+
+	// Storage is the target storage for the guest disks/volumes. When empty,
+	// the storages recorded in the backup are used.
+	Storage string `json:"storage,omitempty"`
+
+	// BandwidthLimit limits the restore I/O bandwidth in KiB/s. A value of 0
+	// uses the datacenter default.
+	BandwidthLimit int `json:"bwlimit,omitempty"`
+
+	// UniqueMAC assigns new MAC addresses to the network interfaces, so the
+	// restored guest can run alongside the original.
+	UniqueMAC bool `json:"unique,omitempty"`
+
+	// Start starts the guest once the restore finished.
+	Start bool `json:"start,omitempty"`
+}
+
+// GetNextVMID returns the next free VMID of the cluster.
+func (c *Client) GetNextVMID() (int, error) {
+	var result map[string]interface{}
+	if err := c.Get("/cluster/nextid", &result); err != nil {
+		return 0, fmt.Errorf("failed to get next VMID: %w", err)
+	}
+
+	// The ID is returned as a string
+	switch id := result["data"].(type) {
+	case string:
+		vmid, err := strconv.Atoi(id)
+		if err != nil {
+			return 0, fmt.Errorf("invalid next VMID %q", id)
+		}
+
+		return vmid, nil
+	case float64:
+		return int(id), nil
+	default:
+		return 0, fmt.Errorf("invalid next VMID response")
+	}
+}
+
+// RestoreBackup restores backup as a new VM or container, depending on the
+// type of the archive, with the ID, node and settings of options. The VMID
+// and node are required, and the VMID must be free: existing guests are
+// never overwritten. It returns the UPID of the restore task.
+func (c *Client) RestoreBackup(backup Backup, options *RestoreOptions) (string, error) {
+	if options == nil || options.VMID <= 0 {
+		return "", fmt.Errorf("VMID is required")
+	}
+
+	if options.Node == "" {
+		return "", fmt.Errorf("target node is required")
+	}
+
+	data := map[string]interface{}{
+		"vmid": options.VMID,
+	}
+
+	// Without force, Proxmox refuses to restore over an existing guest
+	switch backup.Type {
+	case VMTypeQemu:
+		data["archive"] = backup.VolID
+
+		if options.Name != "" {
+			data["name"] = options.Name
+		}
+	case VMTypeLXC:
+		data["ostemplate"] = backup.VolID
+		data["restore"] = "1"
+
+		if options.Name != "" {
+			data["hostname"] = options.Name
+		}
+	default:
+		return "", fmt.Errorf("unknown guest type of backup %s", backup.VolID)
+	}
+
+	if options.Storage != "" {
+		data["storage"] = options.Storage
+	}
+
+	if options.BandwidthLimit > 0 {
+		data["bwlimit"] = options.BandwidthLimit
+	}
+
+	if options.UniqueMAC {
+		data["unique"] = "1"
+	}
+
+	if options.Start {
+		data["start"] = "1"
+	}
+
+	path := fmt.Sprintf("/nodes/%s/%s", options.Node, backup.Type)
+
+	c.logger.Info("Restoring %s as %s %d on node %s", backup.VolID, backup.Type, options.VMID, options.Node)
+
+	var result map[string]interface{}
+	if err := c.PostWithResponse(path, data, &result); err != nil {
+		return "", fmt.Errorf("failed to restore %s: %w", backup.VolID, err)
+	}
+
+	upid, ok := result["data"].(string)
+	if !ok || !strings.HasPrefix(upid, "UPID:") {
+		return "", fmt.Errorf("unexpected response when restoring %s", backup.VolID)
+	}
+
+	return upid, nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RestoreBackup(t *testing.T) {
+	requests := make(map[string]map[string]interface{})
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/cluster/nextid":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": "107"})
+		case r.Method == http.MethodPost:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			requests[r.URL.Path] = body
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": "UPID:pve2:0001:0002:0003:qmrestore:107:root@pam:"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	vmid, err := client.GetNextVMID()
+	require.NoError(t, err)
+	assert.Equal(t, 107, vmid)
+
+	vmBackup := Backup{VolID: "pbs:backup/vm/100/2024-01-04T00:00:00Z", VMID: 100, Type: VMTypeQemu}
+	upid, err := client.RestoreBackup(vmBackup, &RestoreOptions{VMID: vmid, Node: "pve2", Name: "web-restored", Storage: "local-lvm", BandwidthLimit: 10240, UniqueMAC: true})
+	require.NoError(t, err)
+	assert.Contains(t, upid, "qmrestore")
+
+	body := requests["/nodes/pve2/qemu"]
+	assert.Equal(t, vmBackup.VolID, body["archive"])
+	assert.Equal(t, "web-restored", body["name"])
+	assert.Equal(t, "local-lvm", body["storage"])
+	assert.EqualValues(t, 10240, body["bwlimit"])
+	assert.Equal(t, "1", body["unique"])
+	assert.NotContains(t, body, "force")
+
+	ctBackup := Backup{VolID: "local:backup/vzdump-lxc-105-2024_01_03-00_00_00.tar.zst", VMID: 105, Type: VMTypeLXC}
+	_, err = client.RestoreBackup(ctBackup, &RestoreOptions{VMID: 108, Node: "pve1", Name: "dns2"})
+	require.NoError(t, err)
+
+	body = requests["/nodes/pve1/lxc"]
+	assert.Equal(t, ctBackup.VolID, body["ostemplate"])
+	assert.Equal(t, "1", body["restore"])
+	assert.Equal(t, "dns2", body["hostname"])
+	assert.NotContains(t, body, "unique")
+
+	_, err = client.RestoreBackup(ctBackup, &RestoreOptions{Node: "pve1"})
+	assert.ErrorContains(t, err, "VMID is required")
+
+	_, err = client.RestoreBackup(Backup{VolID: "nfs:backup/unknown.tar"}, &RestoreOptions{VMID: 109, Node: "pve1"})
+	assert.ErrorContains(t, err, "unknown guest type")
+}
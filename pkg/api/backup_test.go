@@ -0,0 +1,164 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetGuestBackups(t *testing.T) {
+	contents := map[string][]map[string]interface{}{
+		"local": {
+			{"volid": "local:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst", "vmid": 100, "subtype": "qemu", "format": "vma.zst", "size": 1024, "ctime": 1704067200},
+			{"volid": "local:backup/vzdump-lxc-100-2024_01_03-00_00_00.tar.zst", "vmid": 100, "subtype": "lxc", "format": "tar.zst", "size": 512, "ctime": 1704240000},
+			{"volid": "local:backup/vzdump-qemu-101-2024_01_02-00_00_00.vma.zst", "vmid": 101, "subtype": "qemu", "format": "vma.zst", "size": 2048, "ctime": 1704153600},
+		},
+		"pbs": {
+			{"volid": "pbs:backup/vm/100/2024-01-04T00:00:00Z", "vmid": 100, "format": "pbs-vm", "size": 4096, "ctime": 1704326400, "protected": 1},
+			{"volid": "pbs:backup/vzdump-lxc-100-2024_01_05-00_00_00.tar.zst", "format": "tar.zst", "size": 256, "ctime": 1704412800},
+		},
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var response map[string]interface{}
+
+		switch r.URL.Path {
+		case "/nodes/pve1/storage":
+			response = map[string]interface{}{"data": []map[string]interface{}{{"storage": "local"}, {"storage": "pbs"}, {"storage": "offline"}}}
+		case "/nodes/pve1/storage/local/content":
+			response = map[string]interface{}{"data": contents["local"]}
+		case "/nodes/pve1/storage/pbs/content":
+			response = map[string]interface{}{"data": contents["pbs"]}
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	tests := []struct {
+		name     string
+		vm       *VM
+		expected []string
+	}{
+		{
+			name: "VM backups newest first",
+			vm:   &VM{ID: 100, Node: "pve1", Type: VMTypeQemu},
+			expected: []string{
+				"pbs:backup/vm/100/2024-01-04T00:00:00Z",
+				"local:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst",
+			},
+		},
+		{
+			name: "container with the same VMID",
+			vm:   &VM{ID: 100, Node: "pve1", Type: VMTypeLXC},
+			expected: []string{
+				"pbs:backup/vzdump-lxc-100-2024_01_05-00_00_00.tar.zst",
+				"local:backup/vzdump-lxc-100-2024_01_03-00_00_00.tar.zst",
+			},
+		},
+		{
+			name:     "other VMID",
+			vm:       &VM{ID: 101, Node: "pve1", Type: VMTypeQemu},
+			expected: []string{"local:backup/vzdump-qemu-101-2024_01_02-00_00_00.vma.zst"},
+		},
+		{
+			name: "no backups",
+			vm:   &VM{ID: 200, Node: "pve1", Type: VMTypeQemu},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backups, err := client.GetGuestBackups(tt.vm)
+			require.NoError(t, err)
+
+			var volIDs []string
+			for _, backup := range backups {
+				volIDs = append(volIDs, backup.VolID)
+			}
+
+			assert.Equal(t, tt.expected, volIDs)
+		})
+	}
+}
+
+func TestClient_GetGuestBackups_Fields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var response map[string]interface{}
+
+		if r.URL.Path == "/nodes/pve1/storage" {
+			response = map[string]interface{}{"data": []map[string]interface{}{{"storage": "pbs"}}}
+		} else {
+			response = map[string]interface{}{"data": []map[string]interface{}{
+				{"volid": "pbs:backup/ct/101/2024-01-04T00:00:00Z", "vmid": 101, "subtype": "lxc", "format": "pbs-ct", "size": 4096, "ctime": 1704326400, "notes": "before upgrade", "protected": 1},
+			}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	backups, err := client.GetGuestBackups(&VM{ID: 101, Node: "pve1", Type: VMTypeLXC})
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	assert.Equal(t, "pbs", backups[0].Storage)
+	assert.Equal(t, "pbs-ct", backups[0].Format)
+	assert.Equal(t, int64(4096), backups[0].Size)
+	assert.Equal(t, int64(1704326400), backups[0].CTime.Unix())
+	assert.Equal(t, "before upgrade", backups[0].Notes)
+	assert.True(t, backups[0].Protected)
+	assert.Equal(t, VMTypeLXC, backups[0].Type)
+}
+
+func TestClient_GetLatestBackups(t *testing.T) {
+	listed := make(map[string]int)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var response map[string]interface{}
+
+		listed[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/nodes/pve1/storage", "/nodes/pve2/storage":
+			response = map[string]interface{}{"data": []map[string]interface{}{
+				{"storage": "local"},
+				{"storage": "pbs", "shared": 1},
+			}}
+		case "/nodes/pve1/storage/local/content":
+			response = map[string]interface{}{"data": []map[string]interface{}{
+				{"volid": "local:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst", "vmid": 100, "ctime": 1704067200},
+			}}
+		case "/nodes/pve2/storage/local/content":
+			response = map[string]interface{}{"data": []map[string]interface{}{}}
+		case "/nodes/pve1/storage/pbs/content":
+			response = map[string]interface{}{"data": []map[string]interface{}{
+				{"volid": "pbs:backup/vm/100/2024-01-03T00:00:00Z", "vmid": 100, "ctime": 1704240000},
+				{"volid": "pbs:backup/ct/101/2024-01-02T00:00:00Z", "vmid": 101, "ctime": 1704153600},
+			}}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	latest, err := client.GetLatestBackups([]string{"pve1", "pve2"})
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+
+	assert.Equal(t, "pbs", latest[100].Storage)
+	assert.Equal(t, int64(1704240000), latest[100].CTime.Unix())
+	assert.Equal(t, 101, latest[101].VMID)
+
+	// The shared storage is only listed once
+	assert.Zero(t, listed["/nodes/pve2/storage/pbs/content"])
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/devnullvoid/pvetui/pkg/api/interfaces"
 )
 
@@ -19,6 +21,40 @@ const (
 	ResourceDataTTL = 1 * time.Hour
 )
 
+// CacheTTLs are how long the client caches the responses of each kind of
+// guest and node data. Zero durations take the DefaultCacheTTLs.
+type CacheTTLs struct {
+	// Status is for fast-changing data: the status, CPU and memory usage
+	// of guests and nodes.
+	Status time.Duration
+	// Config is for slow-changing data: configurations and versions.
+	Config time.Duration
+	// Agent is for data the guests report: guest agent filesystems and
+	// container interfaces. Some agents are slow to answer, e.g. get-fsinfo
+	// on Windows guests.
+	Agent time.Duration
+}
+
+// DefaultCacheTTLs are the TTLs of clients created without WithCacheTTLs.
+var DefaultCacheTTLs = CacheTTLs{Status: VMDataTTL, Config: VMDataTTL, Agent: VMDataTTL}
+
+// withDefaults replaces the zero TTLs with the DefaultCacheTTLs.
+func (t CacheTTLs) withDefaults() CacheTTLs {
+	if t.Status <= 0 {
+		t.Status = DefaultCacheTTLs.Status
+	}
+
+	if t.Config <= 0 {
+		t.Config = DefaultCacheTTLs.Config
+	}
+
+	if t.Agent <= 0 {
+		t.Agent = DefaultCacheTTLs.Agent
+	}
+
+	return t
+}
+
 // Client is a Proxmox API client with dependency injection for logging and caching.
 type Client struct {
 	httpClient  *HTTPClient
@@ -32,20 +68,73 @@ type Client struct {
 	// API settings
 	baseURL string
 	user    string
+
+	// inflight coalesces concurrent identical GET requests
+	inflight *singleflight.Group
+	// ttls are the cache TTLs by kind of data (see WithCacheTTLs)
+	ttls CacheTTLs
+	// lazyEnrichment skips cluster-wide guest enrichment (see WithLazyEnrichment)
+	lazyEnrichment bool
+	// phaseObserver receives initial cluster load phases (see WithPhaseObserver)
+	phaseObserver PhaseObserver
+	// pveVersion is the Proxmox VE release detected at connect time (see PVEVersion)
+	pveVersion PVEVersion
+	// ctx is the context of the requests (see WithContext)
+	ctx context.Context
+	// schemas checks responses for schema drift (see WithSchemaDriftHandler)
+	schemas *schemaChecker
+}
+
+// WithContext returns a copy of the client whose requests use ctx, so they
+// are canceled with it and follow its deadline. The copy shares the
+// connection, authentication and cache of c; cluster data it loads is not
+// stored in c.Cluster.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+// requestContext returns the context of the client's requests.
+func (c *Client) requestContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
 }
 
 // Get makes a GET request to the Proxmox API with retry logic.
+// Concurrent identical requests are sent once.
 func (c *Client) Get(path string, result *map[string]interface{}) error {
-	c.logger.Debug("API GET: %s", path)
+	return c.coalesce("GET "+path, result, func(response *map[string]interface{}) error {
+		c.logger.Debug("API GET: %s", path)
+
+		if err := c.httpClient.GetWithRetry(c.requestContext(), path, response, 3); err != nil {
+			return err
+		}
+
+		c.checkResponse(path, *response)
 
-	return c.httpClient.GetWithRetry(context.Background(), path, result, 3)
+		return nil
+	})
 }
 
 // GetNoRetry makes a GET request to the Proxmox API without retry logic.
+// Concurrent identical requests are sent once.
 func (c *Client) GetNoRetry(path string, result *map[string]interface{}) error {
-	c.logger.Debug("API GET (no retry): %s", path)
+	return c.coalesce("GET (no retry) "+path, result, func(response *map[string]interface{}) error {
+		c.logger.Debug("API GET (no retry): %s", path)
+
+		if err := c.httpClient.Get(c.requestContext(), path, response); err != nil {
+			return err
+		}
+
+		c.checkResponse(path, *response)
 
-	return c.httpClient.Get(context.Background(), path, result)
+		return nil
+	})
 }
 
 // Post makes a POST request to the Proxmox API.
@@ -63,7 +152,7 @@ func (c *Client) Post(path string, data interface{}) error {
 		}
 	}
 
-	return c.httpClient.Post(context.Background(), path, postData, nil)
+	return c.httpClient.Post(c.requestContext(), path, postData, nil)
 }
 
 // PostWithResponse makes a POST request to the Proxmox API and returns the response.
@@ -81,14 +170,14 @@ func (c *Client) PostWithResponse(path string, data interface{}, result *map[str
 		}
 	}
 
-	return c.httpClient.Post(context.Background(), path, postData, result)
+	return c.httpClient.Post(c.requestContext(), path, postData, result)
 }
 
 // Delete makes a DELETE request to the Proxmox API.
 func (c *Client) Delete(path string) error {
 	c.logger.Debug("API DELETE: %s", path)
 
-	return c.httpClient.Delete(context.Background(), path, nil)
+	return c.httpClient.Delete(c.requestContext(), path, nil)
 }
 
 // IsUsingTokenAuth returns true if the client is using API token authentication.
@@ -98,6 +187,29 @@ func (c *Client) IsUsingTokenAuth() bool {
 	return c.authManager != nil && c.authManager.IsTokenAuth()
 }
 
+// SetTFAPrompt replaces how the TOTP code of two-factor logins is asked for,
+// e.g. once an interface takes over the terminal. It has no effect with API
+// token authentication.
+func (c *Client) SetTFAPrompt(prompt TFAPrompt) {
+	if c.authManager != nil && !c.authManager.IsTokenAuth() {
+		c.authManager.SetTFAPrompt(prompt)
+	}
+}
+
+// SetOpenIDAuthorizer replaces how the identity provider login page is
+// opened when an OpenID login has to be repeated, e.g. once an interface
+// takes over the terminal. It has no effect on other logins.
+func (c *Client) SetOpenIDAuthorizer(authorize OpenIDAuthorizer) {
+	if c.authManager != nil {
+		c.authManager.SetOpenIDAuthorizer(authorize)
+	}
+}
+
+// CacheTTLs returns the cache TTLs of the client.
+func (c *Client) CacheTTLs() CacheTTLs {
+	return c.ttls.withDefaults()
+}
+
 // GetBaseURL returns the base URL of the Proxmox API.
 func (c *Client) GetBaseURL() string {
 	return c.baseURL
@@ -111,9 +223,7 @@ func (c *Client) GetAuthToken() string {
 
 	// For ticket-based authentication, get the current ticket
 	if c.authManager != nil {
-		ctx := context.Background()
-
-		token, err := c.authManager.GetValidToken(ctx)
+		token, err := c.authManager.GetValidToken(c.requestContext())
 		if err == nil && token != nil {
 			return fmt.Sprintf("PVEAuthCookie=%s", token.Ticket)
 		}
@@ -168,11 +278,21 @@ func (c *Client) GetWithCache(path string, result *map[string]interface{}, ttl t
 	return nil
 }
 
+// getWithTTL makes a GET request cached for ttl, or bypassing the cache if
+// ttl is zero.
+func (c *Client) getWithTTL(path string, result *map[string]interface{}, ttl time.Duration) error {
+	if ttl == 0 {
+		return c.Get(path, result)
+	}
+
+	return c.GetWithCache(path, result, ttl)
+}
+
 // GetWithRetry makes a GET request with retry logic.
 func (c *Client) GetWithRetry(path string, result *map[string]interface{}, maxRetries int) error {
 	c.logger.Debug("API GET with retry: %s", path)
 
-	return c.httpClient.GetWithRetry(context.Background(), path, result, maxRetries)
+	return c.httpClient.GetWithRetry(c.requestContext(), path, result, maxRetries)
 }
 
 // Version gets the Proxmox API version.
@@ -253,7 +373,7 @@ func (c *Client) GetFreshClusterStatus() (*Cluster, error) {
 	}
 
 	// 1. Get basic cluster status
-	if err := c.getClusterBasicStatus(cluster); err != nil {
+	if err := c.getClusterBasicStatus(cluster, ClusterDataTTL); err != nil {
 		return nil, err
 	}
 
@@ -263,9 +383,11 @@ func (c *Client) GetFreshClusterStatus() (*Cluster, error) {
 	}
 
 	// 3. Enrich VMs with detailed status information
-	if err := c.EnrichVMs(cluster); err != nil {
-		// Log error but continue
-		c.logger.Debug("[CLUSTER] Error enriching VM data: %v", err)
+	if !c.lazyEnrichment {
+		if err := c.EnrichVMs(cluster); err != nil {
+			// Log error but continue
+			c.logger.Debug("[CLUSTER] Error enriching VM data: %v", err)
+		}
 	}
 
 	// 4. Calculate cluster-wide totals
@@ -276,6 +398,19 @@ func (c *Client) GetFreshClusterStatus() (*Cluster, error) {
 	return cluster, nil
 }
 
+// observePhase reports a finished phase that began at start to the phase
+// observer, if any.
+func (c *Client) observePhase(phase string, start time.Time) {
+	if c.phaseObserver != nil {
+		c.phaseObserver(phase, start)
+	}
+}
+
+// LazyEnrichment reports whether cluster loads skip guest enrichment.
+func (c *Client) LazyEnrichment() bool {
+	return c.lazyEnrichment
+}
+
 // RefreshNodeData refreshes data for a specific node by clearing its cache entries and fetching fresh data.
 func (c *Client) RefreshNodeData(nodeName string) (*Node, error) {
 	// Clear cache entries for this specific node
@@ -315,6 +450,13 @@ func (c *Client) RefreshNodeData(nodeName string) (*Node, error) {
 
 	// Fetch fresh node data
 	freshNode, err := c.GetNodeStatus(nodeName)
+	if IsForbidden(err) && originalNode != nil {
+		// Keep the cluster resource data; only the status is off limits
+		originalNode.DetailsForbidden = true
+
+		return originalNode, nil
+	}
+
 	if err != nil {
 		// If we can't reach the node, it's likely offline
 		if originalNode != nil {
@@ -431,37 +573,40 @@ func (c *Client) RefreshVMData(vm *VM, onEnrichmentComplete func(*VM)) (*VM, err
 	return freshVM, nil
 }
 
-// NewClient creates a new Proxmox API client with dependency injection.
-func NewClient(config interfaces.Config, options ...ClientOption) (*Client, error) {
-	// Apply options
-	opts := defaultOptions()
-	for _, option := range options {
-		option(opts)
-	}
+// RefreshVMStatus fetches the current status of a VM, clearing only its
+// cached status. Unlike RefreshVMData, it keeps the cached configuration and
+// guest agent data, which expire with their own TTLs, so polling the status
+// every few seconds doesn't query slow guest agents each time.
+func (c *Client) RefreshVMStatus(vm *VM) (*VM, error) {
+	statusPath := fmt.Sprintf("/nodes/%s/%s/%d/status/current", vm.Node, vm.Type, vm.ID)
+	statusCacheKey := strings.ReplaceAll(fmt.Sprintf("proxmox_api_%s_%s", c.baseURL, statusPath), "/", "_")
 
-	// Validate input parameters
-	if config.GetAddr() == "" {
-		return nil, fmt.Errorf("proxmox address cannot be empty")
-	}
+	_ = c.cache.Delete(statusCacheKey)
 
-	// Construct base URL - remove any API path suffix
-	baseURL := strings.TrimRight(config.GetAddr(), "/")
-	if !strings.HasPrefix(baseURL, "https://") {
-		baseURL = "https://" + baseURL
+	freshVM, err := c.GetDetailedVmInfo(vm.Node, vm.Type, vm.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM details: %w", err)
 	}
 
-	// Remove /api2/json suffix if present to get the server base URL
-	serverBaseURL := strings.TrimSuffix(baseURL, "/api2/json")
+	return freshVM, nil
+}
 
-	opts.Logger.Debug("Proxmox server URL: %s", serverBaseURL)
-	opts.Logger.Debug("Proxmox API base URL: %s", serverBaseURL+"/api2/json")
+// newServerHTTPClient creates the HTTP client for the configured server and
+// returns it with the server base URL (without the API path).
+func newServerHTTPClient(config interfaces.Config) (*http.Client, string, error) {
+	// The server base URL keeps the port and any path prefix of a reverse
+	// proxy, and drops the API path
+	serverBaseURL, err := ServerURL(config.GetAddr())
+	if err != nil {
+		return nil, "", err
+	}
 
 	// Configure TLS
 	tlsConfig := &tls.Config{InsecureSkipVerify: config.GetInsecure()}
 
 	transport, ok := http.DefaultTransport.(*http.Transport)
 	if !ok {
-		return nil, fmt.Errorf("failed to get default transport")
+		return nil, "", fmt.Errorf("failed to get default transport")
 	}
 
 	transport = transport.Clone()
@@ -473,11 +618,25 @@ func NewClient(config interfaces.Config, options ...ClientOption) (*Client, erro
 		Timeout:   30 * time.Second,
 	}
 
-	// Validate port presence
-	if !strings.Contains(serverBaseURL, ":") {
-		return nil, fmt.Errorf("missing port in address %s", serverBaseURL)
+	return httpClient, serverBaseURL, nil
+}
+
+// NewClient creates a new Proxmox API client with dependency injection.
+func NewClient(config interfaces.Config, options ...ClientOption) (*Client, error) {
+	// Apply options
+	opts := defaultOptions()
+	for _, option := range options {
+		option(opts)
+	}
+
+	httpClient, serverBaseURL, err := newServerHTTPClient(config)
+	if err != nil {
+		return nil, err
 	}
 
+	opts.Logger.Debug("Proxmox server URL: %s", serverBaseURL)
+	opts.Logger.Debug("Proxmox API base URL: %s", serverBaseURL+"/api2/json")
+
 	// Format credentials with realm
 	userWithRealm := fmt.Sprintf("%s@%s", config.GetUser(), config.GetRealm())
 
@@ -488,18 +647,26 @@ func NewClient(config interfaces.Config, options ...ClientOption) (*Client, erro
 	var authManager *AuthManager
 	if config.IsUsingTokenAuth() {
 		authManager = NewAuthManagerWithToken(httpClientWrapper, config.GetAPIToken(), opts.Logger)
+	} else if opts.OpenIDAuthorizer != nil {
+		authManager = NewAuthManagerWithOpenID(httpClientWrapper, config.GetRealm(), opts.OpenIDRedirectURL, opts.OpenIDAuthorizer, opts.Logger)
 	} else {
 		authManager = NewAuthManagerWithPassword(httpClientWrapper, userWithRealm, config.GetPassword(), opts.Logger)
+		authManager.SetTFAPrompt(opts.TFAPrompt)
 	}
 
 	// Create client
 	client := &Client{
-		httpClient:  httpClientWrapper,
-		authManager: authManager,
-		logger:      opts.Logger,
-		cache:       opts.Cache,
-		baseURL:     serverBaseURL,
-		user:        config.GetUser(),
+		httpClient:     httpClientWrapper,
+		authManager:    authManager,
+		logger:         opts.Logger,
+		cache:          opts.Cache,
+		inflight:       new(singleflight.Group),
+		ttls:           opts.CacheTTLs.withDefaults(),
+		lazyEnrichment: opts.LazyEnrichment,
+		phaseObserver:  opts.PhaseObserver,
+		schemas:        newSchemaChecker(opts.SchemaDriftHandler),
+		baseURL:        serverBaseURL,
+		user:           config.GetUser(),
 	}
 
 	// Set auth manager in HTTP client
@@ -507,9 +674,11 @@ func NewClient(config interfaces.Config, options ...ClientOption) (*Client, erro
 
 	// Test authentication
 	if err := authManager.EnsureAuthenticated(); err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
 	}
 
+	client.detectPVEVersion(context.Background())
+
 	opts.Logger.Debug("Proxmox API client initialized successfully")
 
 	return client, nil
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+func TestConfig_APIToken(t *testing.T) {
+	config := &Config{User: "monitor", Realm: "pve", TokenID: "readonly"}
+	assert.False(t, config.IsUsingTokenAuth())
+	assert.Empty(t, config.GetAPIToken())
+
+	config.TokenSecret = "secret"
+	assert.True(t, config.IsUsingTokenAuth())
+	assert.Equal(t, "PVEAPIToken=monitor@pve!readonly=secret", config.GetAPIToken())
+}
+
+func TestNewClient_Config(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PVEAPIToken=monitor@pve!readonly=secret", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": "8.2.4"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		Addr:        server.URL,
+		User:        "monitor",
+		Realm:       "pve",
+		TokenID:     "readonly",
+		TokenSecret: "secret",
+		Insecure:    true,
+	}, WithLogger(testutils.NewTestLogger()))
+	require.NoError(t, err)
+
+	var result map[string]interface{}
+	require.NoError(t, client.GetNoRetry("/version", &result))
+}
+
+func TestNewClient_PathPrefix(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": "8.2.4"}})
+	}))
+	defer server.Close()
+
+	// A plain HTTP server on a random port behind a path prefix, as with a
+	// reverse proxy
+	client, err := NewClient(&Config{
+		Addr:        server.URL + "/proxmox/",
+		User:        "monitor",
+		Realm:       "pve",
+		TokenID:     "readonly",
+		TokenSecret: "secret",
+	}, WithLogger(testutils.NewTestLogger()))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/proxmox", client.GetBaseURL())
+
+	var result map[string]interface{}
+	require.NoError(t, client.GetNoRetry("/cluster/status", &result))
+	assert.Equal(t, "/proxmox/api2/json/cluster/status", paths[len(paths)-1])
+
+	assert.Equal(t, server.URL+"/proxmox/#v1:0:=node%2Fpve1", client.GenerateNodeWebUIURL("pve1"))
+}
+
+func TestClient_WithContext(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{}})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result map[string]interface{}
+
+	err := client.WithContext(ctx).GetNoRetry("/version", &result)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The original client keeps using its own context
+	require.NoError(t, client.GetNoRetry("/version", &result))
+}
+
+// ttlCache records the TTLs items are cached with.
+type ttlCache struct {
+	testutils.InMemoryCache
+	ttls map[string]time.Duration
+}
+
+func (c *ttlCache) Set(key string, value interface{}, ttl time.Duration) error {
+	c.ttls[key] = ttl
+
+	return c.InMemoryCache.Set(key, value, ttl)
+}
+
+func TestClient_CacheTTLs(t *testing.T) {
+	requests := map[string]int{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/qemu/100/status/current":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"name": "web", "status": "running"}})
+		case "/nodes/pve1/qemu/100/config":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"name": "web"}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	cache := &ttlCache{InMemoryCache: *testutils.NewInMemoryCache(), ttls: map[string]time.Duration{}}
+	client.cache = cache
+	client.ttls = CacheTTLs{Status: 5 * time.Second, Agent: 10 * time.Minute}
+
+	assert.Equal(t, CacheTTLs{Status: 5 * time.Second, Config: VMDataTTL, Agent: 10 * time.Minute}, client.CacheTTLs())
+
+	_, err := client.GetDetailedVmInfo("pve1", VMTypeQemu, 100)
+	require.NoError(t, err)
+
+	key := func(path string) string {
+		return strings.ReplaceAll("proxmox_api_"+client.baseURL+"_"+path, "/", "_")
+	}
+
+	assert.Equal(t, 5*time.Second, cache.ttls[key("/nodes/pve1/qemu/100/status/current")])
+	assert.Equal(t, VMDataTTL, cache.ttls[key("/nodes/pve1/qemu/100/config")])
+
+	// Polling the status keeps the cached configuration and agent data
+	fsinfo := key("/nodes/pve1/qemu/100/agent/get-fsinfo")
+	require.NoError(t, cache.Set(fsinfo, map[string]interface{}{"data": nil}, time.Minute))
+
+	vm, err := client.RefreshVMStatus(&VM{ID: 100, Node: "pve1", Type: VMTypeQemu})
+	require.NoError(t, err)
+	assert.Equal(t, VMStatusRunning, vm.Status)
+	assert.Equal(t, 2, requests["/nodes/pve1/qemu/100/status/current"])
+	assert.Equal(t, 1, requests["/nodes/pve1/qemu/100/config"])
+
+	found, err := cache.Get(fsinfo, new(map[string]interface{}))
+	require.NoError(t, err)
+	assert.True(t, found)
+}
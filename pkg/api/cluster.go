@@ -3,7 +3,6 @@ package api
 import (
 	"fmt"
 	"sort"
-	"strings"
 	"sync"
 	"time"
 )
@@ -23,11 +22,33 @@ type Cluster struct {
 	StorageUsed    int64           `json:"storage_used"`
 	Nodes          []*Node         `json:"nodes"`
 	StorageManager *StorageManager `json:"-"` // Storage manager for handling deduplication
+	// StatusForbidden is set when /cluster/status could not be read (it
+	// needs Sys.Audit on /) and the nodes were taken from cluster resources
+	// instead; the quorum state is then unknown.
+	StatusForbidden bool `json:"-"`
 
 	// For metrics tracking
 	lastUpdate time.Time
 }
 
+// Resource types accepted by the type filter of /cluster/resources.
+const (
+	ClusterResourceNode    = "node"
+	ClusterResourceStorage = "storage"
+	ClusterResourceVM      = "vm"
+)
+
+// Phases of the initial cluster load reported to a PhaseObserver.
+const (
+	PhaseClusterStatus   = "cluster status"
+	PhaseResources       = "cluster resources"
+	PhaseNodeResources   = "node and storage resources"
+	PhaseNodeDetails     = "node details"
+	PhaseGuestResources  = "guest resources"
+	PhaseGuestEnrichment = "guest enrichment"
+	PhaseGuestAgentRetry = "guest agent retry"
+)
+
 // ClusterTask represents a cluster task from the Proxmox API.
 type ClusterTask struct {
 	ID        string `json:"id"`
@@ -51,7 +72,7 @@ func (c *Client) GetClusterStatus() (*Cluster, error) {
 	}
 
 	// 1. Get basic cluster status
-	if err := c.getClusterBasicStatus(cluster); err != nil {
+	if err := c.getClusterBasicStatus(cluster, ClusterDataTTL); err != nil {
 		return nil, err
 	}
 
@@ -77,6 +98,7 @@ func (c *Client) GetClusterStatus() (*Cluster, error) {
 // FastGetClusterStatus retrieves only essential cluster status without VM enrichment
 // for fast application startup. VM details will be loaded in the background.
 // The onEnrichmentComplete callback is called when background VM enrichment finishes.
+// With lazy enrichment no guests are enriched and the callback is not called.
 func (c *Client) FastGetClusterStatus(onEnrichmentComplete func()) (*Cluster, error) {
 	cluster := &Cluster{
 		Nodes:          make([]*Node, 0),
@@ -85,20 +107,29 @@ func (c *Client) FastGetClusterStatus(onEnrichmentComplete func()) (*Cluster, er
 	}
 
 	// 1. Get basic cluster status and node list
-	if err := c.getClusterBasicStatus(cluster); err != nil {
+	start := time.Now()
+	if err := c.getClusterBasicStatus(cluster, ClusterDataTTL); err != nil {
 		return nil, err
 	}
 
+	c.observePhase(PhaseClusterStatus, start)
+
 	// 2. Get cluster resources and populate all nodes, VMs, and storage
+	start = time.Now()
 	if err := c.processClusterResources(cluster); err != nil {
 		return nil, err
 	}
 
+	c.observePhase(PhaseResources, start)
+
 	// 3. Selectively enrich nodes with missing details (Version, KernelVersion, CPUInfo, LoadAvg)
+	start = time.Now()
 	if err := c.enrichMissingNodeDetails(cluster); err != nil {
 		return nil, err
 	}
 
+	c.observePhase(PhaseNodeDetails, start)
+
 	// 4. Calculate cluster-wide totals
 	c.calculateClusterTotals(cluster)
 
@@ -106,92 +137,249 @@ func (c *Client) FastGetClusterStatus(onEnrichmentComplete func()) (*Cluster, er
 	c.Cluster = cluster
 
 	// 6. Start background VM enrichment
+	if !c.lazyEnrichment {
+		go c.enrichVMsInBackground(cluster, onEnrichmentComplete)
+	}
+
+	return cluster, nil
+}
+
+// ProgressiveGetClusterStatus is like FastGetClusterStatus, but returns as
+// soon as nodes and storage are loaded so the first paint does not wait for
+// the guests of large clusters. Guests are fetched in the background and
+// attached to their nodes, then onGuestsLoaded is called with the fetch
+// error, if any. After a successful fetch the guests are enriched as with
+// FastGetClusterStatus and onEnrichmentComplete is called, unless enrichment
+// is lazy.
+func (c *Client) ProgressiveGetClusterStatus(onGuestsLoaded func(error), onEnrichmentComplete func()) (*Cluster, error) {
+	cluster := &Cluster{
+		Nodes:          make([]*Node, 0),
+		StorageManager: NewStorageManager(),
+		lastUpdate:     time.Now(),
+	}
+
+	start := time.Now()
+	if err := c.getClusterBasicStatus(cluster, ClusterDataTTL); err != nil {
+		return nil, err
+	}
+
+	c.observePhase(PhaseClusterStatus, start)
+
+	// Nodes and storage only, guests follow in the background
+	start = time.Now()
+	if err := c.processClusterResourceTypes(cluster, ResourceDataTTL, ClusterResourceNode, ClusterResourceStorage); err != nil {
+		return nil, err
+	}
+
+	c.observePhase(PhaseNodeResources, start)
+
+	start = time.Now()
+	if err := c.enrichMissingNodeDetails(cluster); err != nil {
+		return nil, err
+	}
+
+	c.observePhase(PhaseNodeDetails, start)
+
+	c.calculateClusterTotals(cluster)
+
+	c.Cluster = cluster
+
 	go func() {
-		c.logger.Debug("[BACKGROUND] Starting VM enrichment for %d nodes", len(cluster.Nodes))
+		start := time.Now()
 
-		// Count VMs that will be enriched
-		var runningVMCount int
+		err := c.loadClusterGuests(cluster, ResourceDataTTL)
+		if err != nil {
+			c.logger.Debug("[BACKGROUND] Failed to load guests: %v", err)
+		}
 
-		for _, node := range cluster.Nodes {
-			if node.Online && node.VMs != nil {
-				for _, vm := range node.VMs {
-					if vm.Status == VMStatusRunning {
-						runningVMCount++
-					}
-				}
+		c.observePhase(PhaseGuestResources, start)
+
+		if onGuestsLoaded != nil {
+			onGuestsLoaded(err)
+		}
+
+		if err == nil && !c.lazyEnrichment {
+			c.enrichVMsInBackground(cluster, onEnrichmentComplete)
+		}
+	}()
+
+	return cluster, nil
+}
+
+// RefreshClusterResources reloads the cluster for routine refreshes with one
+// /cluster/status and one /cluster/resources request, both bypassing the
+// cache. The status metrics of all guests come from cluster resources; the
+// configuration and guest agent data of guests that kept running since the
+// previous load are carried over, so no per-guest requests are made for
+// them. Running guests without such data, e.g. started since, are enriched
+// unless enrichment is lazy. Node details beyond the metrics of cluster
+// resources are left to the caller.
+func (c *Client) RefreshClusterResources() (*Cluster, error) {
+	cluster := &Cluster{
+		Nodes:          make([]*Node, 0),
+		StorageManager: NewStorageManager(),
+		lastUpdate:     time.Now(),
+	}
+
+	if err := c.getClusterBasicStatus(cluster, 0); err != nil {
+		return nil, err
+	}
+
+	if err := c.processClusterResourcesWithCache(cluster, 0); err != nil {
+		return nil, err
+	}
+
+	previous := make(map[int]*VM)
+
+	if c.Cluster != nil {
+		for _, node := range c.Cluster.Nodes {
+			for _, vm := range node.VMs {
+				previous[vm.ID] = vm
 			}
 		}
+	}
 
-		c.logger.Debug("[BACKGROUND] Found %d running VMs to enrich", runningVMCount)
+	var pending []*VM
 
-		// Reset guestAgentChecked for all VMs before enrichment
-		for _, node := range cluster.Nodes {
-			if node.Online && node.VMs != nil {
-				for _, vm := range node.VMs {
-					vm.guestAgentChecked = false
-				}
+	for _, node := range cluster.Nodes {
+		if !node.Online {
+			continue
+		}
+
+		for _, vm := range node.VMs {
+			if vm.Status != VMStatusRunning {
+				continue
 			}
+
+			if old, ok := previous[vm.ID]; ok && vm.carryOverDetails(old) {
+				continue
+			}
+
+			pending = append(pending, vm)
 		}
+	}
+
+	c.logger.Debug("[CLUSTER] Refreshed cluster resources, %d running guests to enrich", len(pending))
 
-		if err := c.EnrichVMs(cluster); err != nil {
-			c.logger.Debug("[BACKGROUND] Error enriching VM data: %v", err)
-		} else {
-			c.logger.Debug("[BACKGROUND] Successfully enriched VM data for %d running VMs", runningVMCount)
+	if !c.lazyEnrichment {
+		if err := c.enrichVMList(pending); err != nil {
+			c.logger.Debug("[CLUSTER] Error enriching VM data: %v", err)
 		}
+	}
 
-		// Wait a bit and try to enrich VMs that might not have had guest agent ready
-		time.Sleep(3 * time.Second)
-		c.logger.Debug("[BACKGROUND] Starting delayed enrichment retry for QEMU VMs with missing guest agent data")
+	c.calculateClusterTotals(cluster)
 
-		// Second pass: try to enrich QEMU VMs that still don't have guest agent data
-		// LXC containers don't have guest agents, so we skip them
-		// Only retry VMs that have guest agent enabled in their config
-		var retryCount int
+	c.Cluster = cluster
 
-		for _, node := range cluster.Nodes {
-			if !node.Online || node.VMs == nil {
-				continue
+	return cluster, nil
+}
+
+// enrichVMsInBackground enriches the guests of cluster with detailed status
+// and guest agent data, retrying QEMU guests whose agent was not ready yet,
+// then calls onEnrichmentComplete. It blocks and is meant to run in its own
+// goroutine.
+func (c *Client) enrichVMsInBackground(cluster *Cluster, onEnrichmentComplete func()) {
+	start := time.Now()
+
+	c.logger.Debug("[BACKGROUND] Starting VM enrichment for %d nodes", len(cluster.Nodes))
+
+	// Count VMs that will be enriched
+	var runningVMCount int
+
+	for _, node := range cluster.Nodes {
+		if node.Online && node.VMs != nil {
+			for _, vm := range node.VMs {
+				if vm.Status == VMStatusRunning {
+					runningVMCount++
+				}
 			}
+		}
+	}
+
+	c.logger.Debug("[BACKGROUND] Found %d running VMs to enrich", runningVMCount)
 
+	// Reset guestAgentChecked for all VMs before enrichment
+	for _, node := range cluster.Nodes {
+		if node.Online && node.VMs != nil {
 			for _, vm := range node.VMs {
-				// Only retry QEMU VMs that are running, have guest agent enabled, and don't have guest agent data
-				if vm.Status == VMStatusRunning && vm.Type == VMTypeQemu && vm.AgentEnabled && (!vm.AgentRunning || len(vm.NetInterfaces) == 0) {
-					retryCount++
+				vm.guestAgentChecked = false
+			}
+		}
+	}
+
+	if err := c.EnrichVMs(cluster); err != nil {
+		c.logger.Debug("[BACKGROUND] Error enriching VM data: %v", err)
+	} else {
+		c.logger.Debug("[BACKGROUND] Successfully enriched VM data for %d running VMs", runningVMCount)
+	}
+
+	c.observePhase(PhaseGuestEnrichment, start)
 
-					c.logger.Debug("[BACKGROUND] Retrying enrichment for QEMU VM %s (%d) - agent running: %v, interfaces: %d",
-						vm.Name, vm.ID, vm.AgentRunning, len(vm.NetInterfaces))
+	// Wait a bit and try to enrich VMs that might not have had guest agent ready
+	time.Sleep(3 * time.Second)
 
-					// Try to enrich this specific VM again, but only if the last error was not 'guest agent is not running'
-					err := c.GetVmStatus(vm)
-					if err != nil && strings.Contains(err.Error(), "guest agent is not running") {
-						c.logger.Debug("[BACKGROUND] Skipping further retries for VM %s: guest agent is not running", vm.Name)
+	retryStart := time.Now()
 
-						continue
-					}
+	c.logger.Debug("[BACKGROUND] Starting delayed enrichment retry for QEMU VMs with missing guest agent data")
 
-					if err != nil {
-						c.logger.Debug("[BACKGROUND] Retry failed for VM %s: %v", vm.Name, err)
-					}
+	// Second pass: try to enrich QEMU VMs that still don't have guest agent data
+	// LXC containers don't have guest agents, so we skip them
+	// Only retry VMs that have guest agent enabled in their config
+	var retryCount int
+
+	for _, node := range cluster.Nodes {
+		if !node.Online || node.VMs == nil {
+			continue
+		}
+
+		for _, vm := range node.VMs {
+			// Only retry QEMU VMs that are running, have guest agent enabled, and don't have guest agent data
+			if vm.Status == VMStatusRunning && vm.Type == VMTypeQemu && vm.AgentEnabled && (!vm.AgentRunning || len(vm.NetInterfaces) == 0) {
+				retryCount++
+
+				c.logger.Debug("[BACKGROUND] Retrying enrichment for QEMU VM %s (%d) - agent running: %v, interfaces: %d",
+					vm.Name, vm.ID, vm.AgentRunning, len(vm.NetInterfaces))
+
+				// Try to enrich this specific VM again
+				if err := c.EnrichVM(vm); err != nil {
+					c.logger.Debug("[BACKGROUND] Retry failed for VM %s: %v", vm.Name, err)
 				}
 			}
 		}
+	}
 
-		c.logger.Debug("[BACKGROUND] Completed enrichment process. Initial: %d VMs, QEMU Retry: %d VMs", runningVMCount, retryCount)
+	c.logger.Debug("[BACKGROUND] Completed enrichment process. Initial: %d VMs, QEMU Retry: %d VMs", runningVMCount, retryCount)
+	c.observePhase(PhaseGuestAgentRetry, retryStart)
 
-		// Call the callback only once after both initial enrichment and retry are complete
-		if onEnrichmentComplete != nil {
-			c.logger.Debug("[BACKGROUND] Calling enrichment complete callback")
-			onEnrichmentComplete()
-		}
-	}()
+	// Call the callback only once after both initial enrichment and retry are complete
+	if onEnrichmentComplete != nil {
+		c.logger.Debug("[BACKGROUND] Calling enrichment complete callback")
+		onEnrichmentComplete()
+	}
+}
+
+// GetClusterNodes retrieves the name and quorum of the cluster and its node
+// list from the API, without the resources and guests of the nodes.
+func (c *Client) GetClusterNodes() (*Cluster, error) {
+	cluster := &Cluster{}
+	if err := c.getClusterBasicStatus(cluster, 0); err != nil {
+		return nil, err
+	}
 
 	return cluster, nil
 }
 
-// getClusterBasicStatus retrieves basic cluster info and node list.
-func (c *Client) getClusterBasicStatus(cluster *Cluster) error {
+// getClusterBasicStatus retrieves basic cluster info and node list. A zero
+// ttl bypasses the cache.
+func (c *Client) getClusterBasicStatus(cluster *Cluster, ttl time.Duration) error {
 	var statusResp map[string]interface{}
-	if err := c.GetWithCache("/cluster/status", &statusResp, ClusterDataTTL); err != nil {
+	if err := c.getWithTTL("/cluster/status", &statusResp, ttl); err != nil {
+		if IsForbidden(err) {
+			c.logger.Debug("[CLUSTER] Cluster status forbidden, listing nodes from cluster resources: %v", err)
+
+			return c.getClusterNodesFromResources(cluster, ttl)
+		}
+
 		return fmt.Errorf("failed to get cluster status: %w", err)
 	}
 
@@ -225,6 +413,34 @@ func (c *Client) getClusterBasicStatus(cluster *Cluster) error {
 	return nil
 }
 
+// getClusterNodesFromResources fills the node list of cluster from the node
+// resources the user can see, for users who may not read /cluster/status.
+func (c *Client) getClusterNodesFromResources(cluster *Cluster, ttl time.Duration) error {
+	resourcesData, err := c.getClusterResources(ClusterResourceNode, ttl)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range resourcesData {
+		resource, ok := item.(map[string]interface{})
+		if !ok || getString(resource, "type") != ClusterResourceNode {
+			continue
+		}
+
+		nodeName := getString(resource, "node")
+		cluster.Nodes = append(cluster.Nodes, &Node{
+			ID:     nodeName,
+			Name:   nodeName,
+			Online: getString(resource, "status") == "online",
+		})
+	}
+
+	cluster.TotalNodes = len(cluster.Nodes)
+	cluster.StatusForbidden = true
+
+	return nil
+}
+
 // enrichMissingNodeDetails selectively enriches nodes with data not available in cluster resources.
 func (c *Client) enrichMissingNodeDetails(cluster *Cluster) error {
 	var wg sync.WaitGroup
@@ -291,6 +507,14 @@ func (c *Client) enrichNodeMissingDetails(node *Node) error {
 	}
 
 	fullStatus, err := c.GetNodeStatus(node.Name)
+	if IsForbidden(err) {
+		// The node is up, the user just may not read its status (Sys.Audit)
+		node.DetailsForbidden = true
+		c.logger.Debug("[CLUSTER] Node status of %s forbidden, keeping cluster resource data: %v", node.Name, err)
+
+		return nil
+	}
+
 	if err != nil {
 		// Mark node as offline if we can't reach it
 		node.Online = false
@@ -305,6 +529,8 @@ func (c *Client) enrichNodeMissingDetails(node *Node) error {
 	node.KernelVersion = fullStatus.KernelVersion
 	node.CPUInfo = fullStatus.CPUInfo
 	node.LoadAvg = fullStatus.LoadAvg
+	node.Temperatures = fullStatus.Temperatures
+	node.Description = fullStatus.Description
 	node.lastMetricsUpdate = time.Now()
 
 	c.logger.Debug("[CLUSTER] Successfully enriched missing details for node: %s", node.Name)
@@ -319,24 +545,13 @@ func (c *Client) processClusterResources(cluster *Cluster) error {
 
 // processClusterResourcesWithCache processes cluster resources with specified cache TTL
 func (c *Client) processClusterResourcesWithCache(cluster *Cluster, ttl time.Duration) error {
-	var resourcesResp map[string]interface{}
-	if ttl == 0 {
-		// Use non-cached call for fresh data
-		if err := c.Get("/cluster/resources", &resourcesResp); err != nil {
-			return fmt.Errorf("failed to get cluster resources: %w", err)
-		}
-	} else {
-		// Use cached call with specified TTL
-		if err := c.GetWithCache("/cluster/resources", &resourcesResp, ttl); err != nil {
-			return fmt.Errorf("failed to get cluster resources: %w", err)
-		}
-	}
-
-	resourcesData, ok := resourcesResp["data"].([]interface{})
-	if !ok {
-		return fmt.Errorf("invalid cluster resources response format")
-	}
+	return c.processClusterResourceTypes(cluster, ttl, "")
+}
 
+// processClusterResourceTypes fetches and processes the given cluster
+// resource types with one request per type. An empty type fetches all
+// resources in a single request.
+func (c *Client) processClusterResourceTypes(cluster *Cluster, ttl time.Duration, resourceTypes ...string) error {
 	// Create a map for quick node lookup
 	nodeMap := make(map[string]*Node, len(cluster.Nodes))
 	for i := range cluster.Nodes {
@@ -347,6 +562,67 @@ func (c *Client) processClusterResourcesWithCache(cluster *Cluster, ttl time.Dur
 		}
 	}
 
+	for _, resourceType := range resourceTypes {
+		resourcesData, err := c.getClusterResources(resourceType, ttl)
+		if err != nil {
+			return err
+		}
+
+		c.applyClusterResources(cluster, nodeMap, resourcesData)
+	}
+
+	return nil
+}
+
+// loadClusterGuests fetches the guests of the cluster and replaces the guest
+// lists of its nodes. The lists are built aside and swapped in per node, so
+// readers never see a partially filled list.
+func (c *Client) loadClusterGuests(cluster *Cluster, ttl time.Duration) error {
+	resourcesData, err := c.getClusterResources(ClusterResourceVM, ttl)
+	if err != nil {
+		return err
+	}
+
+	guests := make(map[string]*Node, len(cluster.Nodes))
+	for _, node := range cluster.Nodes {
+		guests[node.Name] = &Node{Name: node.Name, VMs: make([]*VM, 0)}
+	}
+
+	c.applyClusterResources(cluster, guests, resourcesData)
+
+	for _, node := range cluster.Nodes {
+		node.VMs = guests[node.Name].VMs
+	}
+
+	c.logger.Debug("[CLUSTER] Loaded %d guest resources for %d nodes", len(resourcesData), len(cluster.Nodes))
+
+	return nil
+}
+
+// getClusterResources fetches /cluster/resources, filtered to resourceType
+// unless it is empty. A zero ttl bypasses the cache.
+func (c *Client) getClusterResources(resourceType string, ttl time.Duration) ([]interface{}, error) {
+	path := "/cluster/resources"
+	if resourceType != "" {
+		path += "?type=" + resourceType
+	}
+
+	var resourcesResp map[string]interface{}
+	if err := c.getWithTTL(path, &resourcesResp, ttl); err != nil {
+		return nil, fmt.Errorf("failed to get cluster resources: %w", err)
+	}
+
+	resourcesData, ok := resourcesResp["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid cluster resources response format")
+	}
+
+	return resourcesData, nil
+}
+
+// applyClusterResources adds node metrics, storage, and guests from cluster
+// resources to the nodes in nodeMap, keyed by node name.
+func (c *Client) applyClusterResources(cluster *Cluster, nodeMap map[string]*Node, resourcesData []interface{}) {
 	// Process resources in a single pass
 	for _, item := range resourcesData {
 		resource, ok := item.(map[string]interface{})
@@ -449,6 +725,7 @@ func (c *Client) processClusterResourcesWithCache(cluster *Cluster, ttl time.Dur
 				Status:    getString(resource, "status"),
 				IP:        getString(resource, "ip"),
 				CPU:       getFloat(resource, "cpu"),
+				MaxCPU:    getInt(resource, "maxcpu"),
 				Mem:       int64(getFloat(resource, "mem")),
 				MaxMem:    int64(getFloat(resource, "maxmem")),
 				Disk:      int64(getFloat(resource, "disk")),
@@ -467,7 +744,6 @@ func (c *Client) processClusterResourcesWithCache(cluster *Cluster, ttl time.Dur
 		}
 	}
 
-	return nil
 }
 
 // calculateClusterTotals aggregates node metrics for cluster summary.
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default migration type used by Proxmox when none is configured.
+const DefaultMigrationType = "secure"
+
+// ClusterOptions holds the datacenter-wide options from /cluster/options.
+//
+// Options are kept as strings: property values (like migration or bwlimit)
+// are flattened to the "key=value,..." form used in datacenter.cfg.
+type ClusterOptions struct {
+	Values map[string]string
+}
+
+// MigrationSettings are the editable migration options of the datacenter.
+type MigrationSettings struct {
+	Type           string // "secure" or "insecure", empty for the default
+	Network        string // CIDR of the migration network, empty for the default
+	BandwidthLimit int    // Migration bandwidth limit in KiB/s, 0 for no limit
+}
+
+// GetClusterOptions retrieves the datacenter options.
+func (c *Client) GetClusterOptions() (*ClusterOptions, error) {
+	var result map[string]interface{}
+	if err := c.Get("/cluster/options", &result); err != nil {
+		return nil, fmt.Errorf("failed to get cluster options: %w", err)
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid cluster options response format")
+	}
+
+	options := &ClusterOptions{Values: make(map[string]string, len(data))}
+
+	for key, value := range data {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			props := make(map[string]string, len(v))
+			for propKey, propValue := range v {
+				props[propKey] = SafeStringValue(propValue)
+			}
+
+			options.Values[key] = FormatPropertyString(props)
+		default:
+			options.Values[key] = SafeStringValue(value)
+		}
+	}
+
+	return options, nil
+}
+
+// Get returns the value of an option, empty if it is not set.
+func (o *ClusterOptions) Get(key string) string {
+	if o == nil {
+		return ""
+	}
+
+	return o.Values[key]
+}
+
+// Description returns the datacenter notes, empty if none are set.
+func (o *ClusterOptions) Description() string {
+	return o.Get("description")
+}
+
+// Migration returns the configured migration settings.
+func (o *ClusterOptions) Migration() MigrationSettings {
+	migration := ParsePropertyString(o.Get("migration"))
+	bwlimit := ParsePropertyString(o.Get("bwlimit"))
+
+	settings := MigrationSettings{
+		Type:    migration["type"],
+		Network: migration["network"],
+	}
+
+	if limit, err := strconv.Atoi(bwlimit["migration"]); err == nil {
+		settings.BandwidthLimit = limit
+	}
+
+	return settings
+}
+
+// UpdateMigrationSettings changes the migration network, type, and bandwidth
+// limit of the datacenter. Bandwidth limits of other operations configured in
+// current are kept.
+func (c *Client) UpdateMigrationSettings(current *ClusterOptions, settings MigrationSettings) error {
+	return c.httpClient.Put(c.requestContext(), "/cluster/options", buildMigrationPayload(current, settings), nil)
+}
+
+// buildMigrationPayload builds the /cluster/options update for settings,
+// deleting options that end up empty.
+func buildMigrationPayload(current *ClusterOptions, settings MigrationSettings) map[string]interface{} {
+	data := map[string]interface{}{}
+
+	var deletes []string
+
+	if settings.Type == "" && settings.Network == "" {
+		deletes = append(deletes, "migration")
+	} else {
+		migrationType := settings.Type
+		if migrationType == "" {
+			migrationType = DefaultMigrationType
+		}
+
+		migration := "type=" + migrationType
+		if settings.Network != "" {
+			migration += ",network=" + settings.Network
+		}
+
+		data["migration"] = migration
+	}
+
+	bwlimit := ParsePropertyString(current.Get("bwlimit"))
+	if settings.BandwidthLimit > 0 {
+		bwlimit["migration"] = strconv.Itoa(settings.BandwidthLimit)
+	} else {
+		delete(bwlimit, "migration")
+	}
+
+	if len(bwlimit) == 0 {
+		deletes = append(deletes, "bwlimit")
+	} else {
+		data["bwlimit"] = FormatPropertyString(bwlimit)
+	}
+
+	if len(deletes) > 0 {
+		data["delete"] = strings.Join(deletes, ",")
+	}
+
+	return data
+}
+
+// ParsePropertyString parses a Proxmox property string like
+// "type=secure,network=10.0.0.0/24" into its key/value pairs.
+func ParsePropertyString(value string) map[string]string {
+	props := make(map[string]string)
+
+	for _, part := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found || key == "" {
+			continue
+		}
+
+		props[key] = val
+	}
+
+	return props
+}
+
+// FormatPropertyString formats key/value pairs as a Proxmox property string,
+// sorted by key.
+func FormatPropertyString(props map[string]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+props[key])
+	}
+
+	return strings.Join(parts, ",")
+}
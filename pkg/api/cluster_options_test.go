@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetClusterOptions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cluster/options", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"keyboard":    "de",
+			"console":     "xtermjs",
+			"max_workers": 4,
+			"migration":   map[string]interface{}{"type": "secure", "network": "10.0.0.0/24"},
+			"bwlimit":     map[string]interface{}{"migration": 102400, "restore": 51200},
+			"description": "# Maintenance\nSaturday 22:00",
+		}})
+	})
+
+	options, err := client.GetClusterOptions()
+	require.NoError(t, err)
+
+	assert.Equal(t, "de", options.Get("keyboard"))
+	assert.Equal(t, "xtermjs", options.Get("console"))
+	assert.Equal(t, "4", options.Get("max_workers"))
+	assert.Equal(t, "network=10.0.0.0/24,type=secure", options.Get("migration"))
+	assert.Equal(t, "migration=102400,restore=51200", options.Get("bwlimit"))
+	assert.Empty(t, options.Get("email_from"))
+	assert.Equal(t, "# Maintenance\nSaturday 22:00", options.Description())
+
+	assert.Equal(t, MigrationSettings{Type: "secure", Network: "10.0.0.0/24", BandwidthLimit: 102400}, options.Migration())
+}
+
+func TestBuildMigrationPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		settings MigrationSettings
+		expected map[string]interface{}
+	}{
+		{
+			name:     "set network and limit",
+			current:  map[string]string{},
+			settings: MigrationSettings{Network: "10.0.0.0/24", BandwidthLimit: 1024},
+			expected: map[string]interface{}{
+				"migration": "type=secure,network=10.0.0.0/24",
+				"bwlimit":   "migration=1024",
+			},
+		},
+		{
+			name:     "keep other bandwidth limits",
+			current:  map[string]string{"bwlimit": "migration=1024,restore=2048"},
+			settings: MigrationSettings{Type: "insecure"},
+			expected: map[string]interface{}{
+				"migration": "type=insecure",
+				"bwlimit":   "restore=2048",
+			},
+		},
+		{
+			name:     "reset to defaults",
+			current:  map[string]string{"migration": "type=secure,network=10.0.0.0/24", "bwlimit": "migration=1024"},
+			settings: MigrationSettings{},
+			expected: map[string]interface{}{"delete": "migration,bwlimit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := buildMigrationPayload(&ClusterOptions{Values: tt.current}, tt.settings)
+			assert.Equal(t, tt.expected, payload)
+		})
+	}
+}
+
+func TestPropertyString(t *testing.T) {
+	props := ParsePropertyString("type=secure, network=10.0.0.0/24,invalid,=x")
+	assert.Equal(t, map[string]string{"type": "secure", "network": "10.0.0.0/24"}, props)
+	assert.Equal(t, "network=10.0.0.0/24,type=secure", FormatPropertyString(props))
+	assert.Empty(t, ParsePropertyString(""))
+}
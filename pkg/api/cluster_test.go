@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+func TestClient_ProcessClusterResourceTypes(t *testing.T) {
+	resources := map[string][]map[string]interface{}{
+		ClusterResourceNode: {
+			{"type": "node", "node": "pve1", "maxcpu": 8, "uptime": 1000},
+		},
+		ClusterResourceStorage: {
+			{"type": "storage", "node": "pve1", "storage": "local", "disk": 10, "maxdisk": 100},
+		},
+		ClusterResourceVM: {
+			{"type": "qemu", "node": "pve1", "vmid": 100, "name": "web", "status": "running"},
+			{"type": "lxc", "node": "pve2", "vmid": 101, "name": "dns", "status": "stopped"},
+			{"type": "qemu", "node": "gone", "vmid": 102, "name": "orphan"},
+		},
+	}
+
+	var requested []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/cluster/resources", r.URL.Path)
+
+		resourceType := r.URL.Query().Get("type")
+		requested = append(requested, resourceType)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": resources[resourceType]})
+	})
+
+	cluster := &Cluster{
+		Nodes:          []*Node{{Name: "pve1", Online: true}, {Name: "pve2", Online: true}},
+		StorageManager: NewStorageManager(),
+	}
+
+	// Nodes and storage first, without guests
+	require.NoError(t, client.processClusterResourceTypes(cluster, 0, ClusterResourceNode, ClusterResourceStorage))
+	assert.Equal(t, []string{ClusterResourceNode, ClusterResourceStorage}, requested)
+	assert.Equal(t, 8.0, cluster.Nodes[0].CPUCount)
+	require.Len(t, cluster.Nodes[0].Storage, 1)
+	assert.Equal(t, "local", cluster.Nodes[0].Storage[0].Name)
+	assert.Empty(t, cluster.Nodes[0].VMs)
+	assert.NotNil(t, cluster.Nodes[1].VMs)
+
+	// Guests are attached to their nodes; guests of unknown nodes are dropped
+	require.NoError(t, client.loadClusterGuests(cluster, 0))
+	require.Len(t, cluster.Nodes[0].VMs, 1)
+	assert.Equal(t, "web", cluster.Nodes[0].VMs[0].Name)
+	require.Len(t, cluster.Nodes[1].VMs, 1)
+	assert.Equal(t, VMTypeLXC, cluster.Nodes[1].VMs[0].Type)
+	assert.Len(t, cluster.Nodes[0].Storage, 1)
+
+	// Reloading replaces the guest lists instead of appending
+	require.NoError(t, client.loadClusterGuests(cluster, 0))
+	assert.Len(t, cluster.Nodes[0].VMs, 1)
+}
+
+func TestClient_ClusterStatusForbidden(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cluster/status", "/nodes/pve2/status":
+			http.Error(w, "", http.StatusForbidden)
+		case "/nodes/pve1/status":
+			_, _ = w.Write([]byte(`{"data":{"pveversion":"pve-manager/8.2.4","kversion":"Linux 6.8.12"}}`))
+		case "/nodes/pve1/config":
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case "/cluster/resources":
+			assert.Equal(t, ClusterResourceNode, r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`{"data":[{"type":"node","node":"pve1","status":"online"},{"type":"node","node":"pve2","status":"online"},{"type":"node","node":"pve3","status":"offline"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	client.cache = testutils.NewInMemoryCache()
+
+	cluster := &Cluster{StorageManager: NewStorageManager()}
+
+	// Without Sys.Audit on / the nodes come from cluster resources
+	require.NoError(t, client.getClusterBasicStatus(cluster, ClusterDataTTL))
+	assert.True(t, cluster.StatusForbidden)
+	assert.Equal(t, 3, cluster.TotalNodes)
+	require.Len(t, cluster.Nodes, 3)
+	assert.True(t, cluster.Nodes[1].Online)
+	assert.False(t, cluster.Nodes[2].Online)
+
+	// A forbidden node status keeps the node online
+	require.NoError(t, client.enrichMissingNodeDetails(cluster))
+	assert.Equal(t, "pve-manager/8.2.4", cluster.Nodes[0].Version)
+	assert.False(t, cluster.Nodes[0].DetailsForbidden)
+	assert.True(t, cluster.Nodes[1].Online)
+	assert.True(t, cluster.Nodes[1].DetailsForbidden)
+}
+
+func TestClient_RefreshClusterResources(t *testing.T) {
+	requests := map[string]int{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/cluster/status":
+			_, _ = w.Write([]byte(`{"data":[{"type":"node","name":"pve1","online":1}]}`))
+		case "/cluster/resources":
+			assert.Empty(t, r.URL.Query().Get("type"), "one request for all resources")
+			_, _ = w.Write([]byte(`{"data":[
+				{"type":"node","node":"pve1","maxcpu":8},
+				{"type":"qemu","node":"pve1","vmid":100,"name":"web","status":"running","cpu":0.5,"mem":1024,"uptime":600,"maxdisk":4096},
+				{"type":"qemu","node":"pve1","vmid":101,"name":"db","status":"running","cpu":0.1,"uptime":30},
+				{"type":"lxc","node":"pve1","vmid":102,"name":"dns","status":"stopped"}]}`))
+		case "/nodes/pve1/qemu/101/config":
+			_, _ = w.Write([]byte(`{"data":{"cores":2}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	client.cache = testutils.NewInMemoryCache()
+
+	// web kept running since it was enriched, db restarted
+	client.Cluster = &Cluster{Nodes: []*Node{{Name: "pve1", VMs: []*VM{
+		{ID: 100, Node: "pve1", Status: VMStatusRunning, Uptime: 300, IP: "10.0.0.5", Enriched: true, CPUCores: 4,
+			Disk: 1024, MaxDisk: 2048, Filesystems: []Filesystem{{Mountpoint: "/", TotalBytes: 2048, UsedBytes: 1024}}},
+		{ID: 101, Node: "pve1", Status: VMStatusRunning, Uptime: 900, Enriched: true, CPUCores: 1},
+	}}}}
+
+	cluster, err := client.RefreshClusterResources()
+	require.NoError(t, err)
+	require.Len(t, cluster.Nodes, 1)
+	require.Len(t, cluster.Nodes[0].VMs, 3)
+	assert.Same(t, cluster, client.Cluster)
+
+	web, db := cluster.Nodes[0].VMs[0], cluster.Nodes[0].VMs[1]
+	assert.Equal(t, 0.5, web.CPU, "metrics come from cluster resources")
+	assert.Equal(t, int64(600), web.Uptime)
+	assert.Equal(t, "10.0.0.5", web.IP, "details are carried over")
+	assert.Equal(t, 4, web.CPUCores)
+	assert.Equal(t, int64(2048), web.MaxDisk, "agent disk usage is carried over")
+	assert.True(t, web.Enriched)
+	assert.Equal(t, 2, db.CPUCores, "restarted guests are enriched again")
+
+	assert.Equal(t, map[string]int{"/cluster/status": 1, "/cluster/resources": 1, "/nodes/pve1/qemu/101/config": 1}, requests,
+		"no per-guest status requests")
+}
@@ -0,0 +1,62 @@
+package api
+
+// coalesce runs fetch once for concurrent calls with the same key, e.g.
+// when a manual refresh, the auto-refresh and a selection change request the
+// same endpoint at once, and gives every caller its own deep copy of the
+// response. Requests with their own context (see WithContext) are not
+// coalesced, so cancelling one doesn't fail the others.
+func (c *Client) coalesce(key string, result *map[string]interface{}, fetch func(*map[string]interface{}) error) error {
+	if c.inflight == nil || c.ctx != nil {
+		return fetch(result)
+	}
+
+	value, err, shared := c.inflight.Do(key, func() (interface{}, error) {
+		var response map[string]interface{}
+		err := fetch(&response)
+
+		return response, err
+	})
+	if shared {
+		c.logger.Debug("API %s shared with concurrent identical requests", key)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	response, _ := value.(map[string]interface{})
+	*result, _ = copyJSONValue(response).(map[string]interface{})
+
+	return nil
+}
+
+// copyJSONValue returns a deep copy of a decoded JSON value: the maps and
+// slices of objects and arrays are copied, the scalars are immutable.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyJSONValue(item)
+		}
+
+		return copied
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyJSONValue(item)
+		}
+
+		return copied
+	}
+
+	return value
+}
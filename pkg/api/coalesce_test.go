@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/singleflight"
+)
+
+func TestClient_CoalescesConcurrentGets(t *testing.T) {
+	var requests atomic.Int32
+
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+
+		_, _ = w.Write([]byte(`{"data":{"version":"8.2.4","repoid":["a1b2"]}}`))
+	})
+	client.inflight = new(singleflight.Group)
+
+	const callers = 5
+
+	results := make([]map[string]interface{}, callers)
+
+	var wg sync.WaitGroup
+
+	for i := range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Get("/version", &results[i]))
+		}()
+	}
+
+	// Let the callers join the request in flight
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), requests.Load())
+
+	for _, result := range results {
+		assert.Equal(t, map[string]interface{}{"version": "8.2.4", "repoid": []interface{}{"a1b2"}}, result["data"])
+	}
+
+	// Every caller gets its own copy of the response, down to nested values
+	results[0]["extra"] = true
+	data := results[0]["data"].(map[string]interface{})
+	data["version"] = "changed"
+	data["repoid"].([]interface{})[0] = "changed"
+
+	assert.NotContains(t, results[1], "extra")
+	assert.Equal(t, map[string]interface{}{"version": "8.2.4", "repoid": []interface{}{"a1b2"}}, results[1]["data"])
+
+	// Later requests are sent again
+	require.NoError(t, client.Get("/version", &results[0]))
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Requests with their own context are not coalesced
+	var result map[string]interface{}
+	require.NoError(t, client.WithContext(context.Background()).Get("/version", &result))
+	assert.Equal(t, int32(3), requests.Load())
+}
@@ -0,0 +1,64 @@
+package api
+
+import "fmt"
+
+// Config holds the connection settings of a client. It implements
+// interfaces.Config, so programs using this package don't need their own
+// configuration type:
+//
+//	client, err := api.NewClient(&api.Config{
+//		Addr:        "https://pve.example.com:8006",
+//		User:        "monitor",
+//		Realm:       "pve",
+//		TokenID:     "readonly",
+//		TokenSecret: os.Getenv("PVE_TOKEN_SECRET"),
+//	})
+type Config struct {
+	// Addr is the server URL, e.g. "https://pve.example.com:8006".
+	Addr string
+	// User is the user name without the realm.
+	User     string
+	Password string
+	// Realm is the authentication realm, e.g. "pam" or "pve".
+	Realm string
+	// TokenID and TokenSecret select API token authentication instead of
+	// the password.
+	TokenID     string
+	TokenSecret string
+	// Insecure skips the verification of the server certificate.
+	Insecure bool
+}
+
+// GetAddr returns the server URL.
+func (c *Config) GetAddr() string { return c.Addr }
+
+// GetUser returns the user name without the realm.
+func (c *Config) GetUser() string { return c.User }
+
+// GetPassword returns the password.
+func (c *Config) GetPassword() string { return c.Password }
+
+// GetRealm returns the authentication realm.
+func (c *Config) GetRealm() string { return c.Realm }
+
+// GetTokenID returns the API token ID.
+func (c *Config) GetTokenID() string { return c.TokenID }
+
+// GetTokenSecret returns the API token secret.
+func (c *Config) GetTokenSecret() string { return c.TokenSecret }
+
+// GetInsecure reports whether the server certificate is not verified.
+func (c *Config) GetInsecure() bool { return c.Insecure }
+
+// IsUsingTokenAuth reports whether an API token is set.
+func (c *Config) IsUsingTokenAuth() bool { return c.TokenID != "" && c.TokenSecret != "" }
+
+// GetAPIToken returns the API token in the Authorization header format,
+// "PVEAPIToken=USER@REALM!TOKENID=SECRET", or "" without a token.
+func (c *Config) GetAPIToken() string {
+	if !c.IsUsingTokenAuth() {
+		return ""
+	}
+
+	return fmt.Sprintf("PVEAPIToken=%s@%s!%s=%s", c.User, c.Realm, c.TokenID, c.TokenSecret)
+}
@@ -33,7 +33,10 @@ const (
 
 // API Endpoints.
 const (
-	EndpointAccessTicket = "/access/ticket"
+	EndpointAccessTicket  = "/access/ticket"
+	EndpointAccessDomains = "/access/domains"
+	EndpointOpenIDAuthURL = "/access/openid/auth-url"
+	EndpointOpenIDLogin   = "/access/openid/login"
 )
 
 // Network interface names.
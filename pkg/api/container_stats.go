@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContainerStats are resource stats of a running container read over SSH,
+// which the API doesn't report since containers have no guest agent.
+type ContainerStats struct {
+	// Load is the 1, 5 and 15 minute load average seen inside the container.
+	// Without load virtualization in LXCFS it is the load of the node.
+	Load    [3]float64
+	HasLoad bool
+	// Processes is the number of processes inside the container, 0 if
+	// unknown.
+	Processes int
+	// Tasks is the number of tasks (processes and threads) in the cgroup of
+	// the container, 0 if unknown.
+	Tasks int
+	// CPUPressure, MemoryPressure and IOPressure are the share of the last
+	// 10 seconds in which some tasks of the container were stalled waiting
+	// for the resource, in percent.
+	CPUPressure    float64
+	MemoryPressure float64
+	IOPressure     float64
+	HasPressure    bool
+}
+
+// ParseContainerStats parses the sections printed by the container stats
+// command of the ssh package. Missing sections leave their stats unset; an
+// error is returned if no stats were found at all.
+func ParseContainerStats(output []byte) (ContainerStats, error) {
+	var (
+		stats   ContainerStats
+		section string
+		found   bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if name, ok := strings.CutPrefix(line, "=="); ok {
+			section = name
+
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+
+		switch section {
+		case "tasks":
+			if tasks, err := strconv.Atoi(line); err == nil {
+				stats.Tasks, found = tasks, true
+			}
+		case "processes":
+			if processes, err := strconv.Atoi(line); err == nil {
+				stats.Processes, found = processes, true
+			}
+		case "load":
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+
+			for i := range stats.Load {
+				value, err := strconv.ParseFloat(fields[i], 64)
+				if err != nil {
+					return ContainerStats{}, fmt.Errorf("invalid load average %q", line)
+				}
+
+				stats.Load[i] = value
+			}
+
+			stats.HasLoad, found = true, true
+		case "cpu", "memory", "io":
+			pressure, ok := parseSomePressure(line)
+			if !ok {
+				continue
+			}
+
+			switch section {
+			case "cpu":
+				stats.CPUPressure = pressure
+			case "memory":
+				stats.MemoryPressure = pressure
+			default:
+				stats.IOPressure = pressure
+			}
+
+			stats.HasPressure, found = true, true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ContainerStats{}, err
+	}
+
+	if !found {
+		return ContainerStats{}, fmt.Errorf("no container stats found")
+	}
+
+	return stats, nil
+}
+
+// parseSomePressure returns the avg10 value of a "some" line of a pressure
+// stall information file, e.g. "some avg10=1.50 avg60=0.80 avg300=0.20 total=1234".
+func parseSomePressure(line string) (float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "some" {
+		return 0, false
+	}
+
+	value, ok := strings.CutPrefix(fields[1], "avg10=")
+	if !ok {
+		return 0, false
+	}
+
+	pressure, err := strconv.ParseFloat(value, 64)
+
+	return pressure, err == nil
+}
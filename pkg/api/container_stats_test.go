@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerStats(t *testing.T) {
+	output := []byte(`==tasks
+57
+==cpu
+some avg10=2.50 avg60=1.10 avg300=0.30 total=123456
+full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+==memory
+some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+==io
+some avg10=12.00 avg60=4.00 avg300=1.00 total=9999
+full avg10=8.00 avg60=2.00 avg300=0.50 total=8888
+==load
+0.52 0.41 0.30 2/61 4242
+==processes
+23
+`)
+
+	stats, err := ParseContainerStats(output)
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStats{
+		Load:        [3]float64{0.52, 0.41, 0.30},
+		HasLoad:     true,
+		Processes:   23,
+		Tasks:       57,
+		CPUPressure: 2.5,
+		IOPressure:  12,
+		HasPressure: true,
+	}, stats)
+
+	// Without pct exec (no sudo) only the cgroup stats are read
+	stats, err = ParseContainerStats([]byte("==tasks\n12\n==cpu\n==memory\n==io\n==load\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 12, stats.Tasks)
+	assert.False(t, stats.HasLoad)
+	assert.False(t, stats.HasPressure)
+
+	_, err = ParseContainerStats([]byte("==tasks\n==cpu\n==memory\n==io\n==load\n"))
+	assert.ErrorContains(t, err, "no container stats found")
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CorosyncNode is a node of the corosync configuration with its votes and
+// the addresses of its links.
+type CorosyncNode struct {
+	Name   string
+	NodeID int
+	Votes  int
+	Online bool
+	// Local is the node answering the API requests.
+	Local bool
+	// Links maps link numbers to the node's address on them (ringX_addr).
+	Links map[int]string
+}
+
+// QuorumStatus describes the corosync configuration and quorum of a cluster.
+type QuorumStatus struct {
+	ClusterName   string
+	ConfigVersion int
+	// Transport is the corosync transport, "knet" unless configured.
+	Transport string
+	LinkMode  string
+	SecAuth   string
+	// Links are the link numbers of the totem configuration.
+	Links   []int
+	Quorate bool
+	Nodes   []CorosyncNode
+}
+
+// ExpectedVotes returns the votes of all configured nodes.
+func (q *QuorumStatus) ExpectedVotes() int {
+	votes := 0
+
+	for _, node := range q.Nodes {
+		votes += node.Votes
+	}
+
+	return votes
+}
+
+// TotalVotes returns the votes of the online nodes.
+func (q *QuorumStatus) TotalVotes() int {
+	votes := 0
+
+	for _, node := range q.Nodes {
+		if node.Online {
+			votes += node.Votes
+		}
+	}
+
+	return votes
+}
+
+// Quorum returns the votes needed for quorum, a majority of the expected
+// votes.
+func (q *QuorumStatus) Quorum() int {
+	return q.ExpectedVotes()/2 + 1
+}
+
+// ringAddrPattern matches the link address keys of the corosync nodelist.
+var ringAddrPattern = regexp.MustCompile(`^ring(\d+)_addr$`)
+
+// GetQuorumStatus reads the corosync totem and node configuration and the
+// cluster status. Standalone nodes have no corosync configuration and return
+// an error.
+func (c *Client) GetQuorumStatus() (*QuorumStatus, error) {
+	var totemResp map[string]interface{}
+	if err := c.GetNoRetry("/cluster/config/totem", &totemResp); err != nil {
+		return nil, fmt.Errorf("failed to get corosync totem config: %w", err)
+	}
+
+	totem, ok := totemResp["data"].(map[string]interface{})
+	if !ok || len(totem) == 0 {
+		return nil, fmt.Errorf("no corosync configuration: the node is not part of a cluster")
+	}
+
+	status := &QuorumStatus{
+		ClusterName:   getString(totem, "cluster_name"),
+		ConfigVersion: getInt(totem, "config_version"),
+		Transport:     getString(totem, "transport"),
+		LinkMode:      getString(totem, "link_mode"),
+		SecAuth:       getString(totem, "secauth"),
+	}
+
+	if status.Transport == "" {
+		status.Transport = "knet"
+	}
+
+	if interfaces, ok := totem["interface"].(map[string]interface{}); ok {
+		for key, value := range interfaces {
+			// Interfaces are keyed by link number; linknumber repeats it
+			if settings, ok := value.(map[string]interface{}); ok && settings["linknumber"] != nil {
+				status.Links = append(status.Links, getInt(settings, "linknumber"))
+			} else if link, err := strconv.Atoi(key); err == nil {
+				status.Links = append(status.Links, link)
+			}
+		}
+
+		sort.Ints(status.Links)
+	}
+
+	var nodesResp map[string]interface{}
+	if err := c.GetNoRetry("/cluster/config/nodes", &nodesResp); err != nil {
+		return nil, fmt.Errorf("failed to get corosync nodes: %w", err)
+	}
+
+	nodes, _ := nodesResp["data"].([]interface{})
+	for _, item := range nodes {
+		nodeMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		node := CorosyncNode{
+			Name:   getString(nodeMap, "node"),
+			NodeID: getInt(nodeMap, "nodeid"),
+			Votes:  getInt(nodeMap, "quorum_votes"),
+			Links:  make(map[int]string),
+		}
+
+		if node.Votes == 0 {
+			// corosync counts one vote for nodes without quorum_votes
+			node.Votes = 1
+		}
+
+		for key := range nodeMap {
+			if match := ringAddrPattern.FindStringSubmatch(key); match != nil {
+				link, _ := strconv.Atoi(match[1])
+				node.Links[link] = getString(nodeMap, key)
+			}
+		}
+
+		status.Nodes = append(status.Nodes, node)
+	}
+
+	var statusResp map[string]interface{}
+	if err := c.GetNoRetry("/cluster/status", &statusResp); err != nil {
+		return nil, fmt.Errorf("failed to get cluster status: %w", err)
+	}
+
+	items, _ := statusResp["data"].([]interface{})
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch getString(itemMap, "type") {
+		case "cluster":
+			status.Quorate = getBool(itemMap, "quorate")
+		case "node":
+			for i := range status.Nodes {
+				if status.Nodes[i].Name == getString(itemMap, "name") {
+					status.Nodes[i].Online = getBool(itemMap, "online")
+					status.Nodes[i].Local = getBool(itemMap, "local")
+				}
+			}
+		}
+	}
+
+	sort.Slice(status.Nodes, func(i, j int) bool { return status.Nodes[i].NodeID < status.Nodes[j].NodeID })
+
+	return status, nil
+}
+
+// CorosyncLink is the state of a link to another node as seen by the node
+// that ran `corosync-cfgtool -n`.
+type CorosyncLink struct {
+	NodeID    int
+	Link      int
+	Addresses string
+	Enabled   bool
+	Connected bool
+}
+
+var (
+	cfgtoolNodePattern = regexp.MustCompile(`^nodeid:\s*(\d+)`)
+	cfgtoolLinkPattern = regexp.MustCompile(`^LINK:\s*(\d+)\s+\S+\s+\(([^)]*)\)\s+(.*)$`)
+)
+
+// ParseCorosyncLinks parses the output of `corosync-cfgtool -n`, which lists
+// the links to every other node:
+//
+//	nodeid: 2 reachable
+//	   LINK: 0 udp (10.0.0.1->10.0.0.2) enabled connected mtu: 1397
+func ParseCorosyncLinks(output []byte) []CorosyncLink {
+	var (
+		links  []CorosyncLink
+		nodeID int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := cfgtoolNodePattern.FindStringSubmatch(line); match != nil {
+			nodeID, _ = strconv.Atoi(match[1])
+
+			continue
+		}
+
+		match := cfgtoolLinkPattern.FindStringSubmatch(line)
+		if match == nil || nodeID == 0 {
+			continue
+		}
+
+		link, _ := strconv.Atoi(match[1])
+		flags := strings.Fields(match[3])
+
+		links = append(links, CorosyncLink{
+			NodeID:    nodeID,
+			Link:      link,
+			Addresses: match[2],
+			Enabled:   slices.Contains(flags, "enabled"),
+			Connected: slices.Contains(flags, "connected"),
+		})
+	}
+
+	return links
+}
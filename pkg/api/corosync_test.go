@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetQuorumStatus(t *testing.T) {
+	responses := map[string]interface{}{
+		"/cluster/config/totem": map[string]interface{}{
+			"cluster_name":   "lab",
+			"config_version": "5",
+			"link_mode":      "passive",
+			"secauth":        "on",
+			"interface": map[string]interface{}{
+				"1": map[string]interface{}{"linknumber": "1"},
+				"0": map[string]interface{}{"linknumber": "0"},
+			},
+		},
+		"/cluster/config/nodes": []interface{}{
+			map[string]interface{}{"node": "pve2", "nodeid": "2", "quorum_votes": "1", "ring0_addr": "10.0.0.2"},
+			map[string]interface{}{"node": "pve1", "nodeid": "1", "quorum_votes": "1", "ring0_addr": "10.0.0.1", "ring1_addr": "10.1.0.1"},
+			map[string]interface{}{"node": "pve3", "nodeid": "3", "ring0_addr": "10.0.0.3"},
+		},
+		"/cluster/status": []interface{}{
+			map[string]interface{}{"type": "cluster", "name": "lab", "quorate": 1},
+			map[string]interface{}{"type": "node", "name": "pve1", "online": 1, "local": 1},
+			map[string]interface{}{"type": "node", "name": "pve2", "online": 1},
+			map[string]interface{}{"type": "node", "name": "pve3", "online": 0},
+		},
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, ok := responses[r.URL.Path]
+		require.True(t, ok, "unexpected request %s", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	})
+
+	status, err := client.GetQuorumStatus()
+	require.NoError(t, err)
+
+	assert.Equal(t, "lab", status.ClusterName)
+	assert.Equal(t, 5, status.ConfigVersion)
+	assert.Equal(t, "knet", status.Transport)
+	assert.Equal(t, []int{0, 1}, status.Links)
+	assert.True(t, status.Quorate)
+
+	require.Len(t, status.Nodes, 3)
+	assert.Equal(t, "pve1", status.Nodes[0].Name)
+	assert.True(t, status.Nodes[0].Local)
+	assert.Equal(t, map[int]string{0: "10.0.0.1", 1: "10.1.0.1"}, status.Nodes[0].Links)
+	assert.False(t, status.Nodes[2].Online)
+
+	assert.Equal(t, 3, status.ExpectedVotes())
+	assert.Equal(t, 2, status.TotalVotes())
+	assert.Equal(t, 2, status.Quorum())
+}
+
+func TestParseCorosyncLinks(t *testing.T) {
+	output := []byte(`Local node ID 1, transport knet
+nodeid: 2 reachable
+   LINK: 0 udp (10.0.0.1->10.0.0.2) enabled connected mtu: 1397
+   LINK: 1 udp (10.1.0.1->10.1.0.2) enabled disconnected mtu: 1397
+
+nodeid: 3 unreachable
+   LINK: 0 udp (10.0.0.1->10.0.0.3) enabled disconnected mtu: 1397
+`)
+
+	links := ParseCorosyncLinks(output)
+	require.Len(t, links, 3)
+	assert.Equal(t, CorosyncLink{NodeID: 2, Link: 0, Addresses: "10.0.0.1->10.0.0.2", Enabled: true, Connected: true}, links[0])
+	assert.False(t, links[1].Connected)
+	assert.Equal(t, 3, links[2].NodeID)
+	assert.Empty(t, ParseCorosyncLinks([]byte("Could not initialize corosync configuration API")))
+}
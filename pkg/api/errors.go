@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The errors the client's errors wrap, to tell failures apart with errors.Is
+// regardless of the request that failed.
+var (
+	// ErrAuthFailed is returned when the server rejects the credentials or
+	// API token, at login or for a request.
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrConnection is returned when the server can't be reached: the name
+	// doesn't resolve, the connection is refused or drops, or the request
+	// times out. TLS certificate errors are not connection errors.
+	ErrConnection = errors.New("connection failed")
+	// ErrPermission is returned for 403 responses, when the user or API token
+	// lacks a privilege the endpoint requires.
+	ErrPermission = errors.New("permission denied")
+	// ErrNotFound is returned when the requested node, guest or other object
+	// doesn't exist.
+	ErrNotFound = errors.New("not found")
+)
+
+// isConnectionFailure reports whether err, the error of sending a request
+// with ctx or reading its response, means the server couldn't be reached: a
+// name that doesn't resolve, a refused, reset or closed connection, or a
+// timeout, including ctx's deadline. A request the caller cancelled is not a
+// connection failure. Neither are TLS errors, like a certificate that can't
+// be verified: retrying doesn't help, and the fix is trusting the
+// certificate or the insecure setting.
+func isConnectionFailure(ctx context.Context, err error) bool {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return false
+	}
+
+	var (
+		certErr   *tls.CertificateVerificationError
+		recordErr tls.RecordHeaderError
+		dnsErr    *net.DNSError
+		opErr     *net.OpError
+		netErr    net.Error
+	)
+
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &recordErr):
+		return false
+	case errors.As(err, &dnsErr), errors.As(err, &opErr):
+		return true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// APIError is an unsuccessful response of the Proxmox API.
+type APIError struct {
+	StatusCode int
+	// Message is the reason Proxmox gives in the status line, e.g.
+	// "Permission check failed (/vms/100, VM.PowerMgmt)". It is empty when
+	// the status line holds only the standard status text.
+	Message string
+	Body    string
+}
+
+// newAPIError creates an APIError from a response status line and body.
+func newAPIError(statusCode int, status, body string) *APIError {
+	message := strings.TrimSpace(strings.TrimPrefix(status, strconv.Itoa(statusCode)))
+	if message == http.StatusText(statusCode) {
+		message = ""
+	}
+
+	return &APIError{StatusCode: statusCode, Message: message, Body: strings.TrimSpace(body)}
+}
+
+// Error returns the status code followed by the reason and response body.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API request failed with status %d", e.StatusCode)
+
+	for _, detail := range []string{e.Message, e.Body} {
+		if detail != "" {
+			msg += ": " + detail
+		}
+	}
+
+	return msg
+}
+
+// Is matches the error against ErrAuthFailed for 401, ErrPermission for 403
+// and ErrNotFound for 404 responses. Proxmox answers requests for some
+// missing objects, like the configuration of a guest that doesn't exist, with
+// a 500 saying so, which matches ErrNotFound too.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAuthFailed:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPermission:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound ||
+			(e.StatusCode == http.StatusInternalServerError && strings.Contains(e.Message, "does not exist"))
+	}
+
+	return false
+}
+
+// IsForbidden reports whether err is a 403 response, returned when the user
+// or API token lacks a privilege the endpoint requires. It is the same as
+// errors.Is(err, ErrPermission).
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrPermission)
+}
@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/devnullvoid/pvetui/pkg/api"
+)
+
+// Connect with an API token and list the guests of every node.
+func ExampleNewClient() {
+	client, err := api.NewClient(&api.Config{
+		Addr:        "https://pve.example.com:8006",
+		User:        "monitor",
+		Realm:       "pve",
+		TokenID:     "readonly",
+		TokenSecret: os.Getenv("PVE_TOKEN_SECRET"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cluster, err := client.GetClusterStatus()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, node := range cluster.Nodes {
+		for _, vm := range node.VMs {
+			fmt.Printf("%s: %d %s (%s)\n", node.Name, vm.ID, vm.Name, vm.Status)
+		}
+	}
+}
+
+// Bound the requests of a batch job with a deadline.
+func ExampleClient_WithContext() {
+	client, err := api.NewClient(&api.Config{
+		Addr:     "https://pve.example.com:8006",
+		User:     "root",
+		Realm:    "pam",
+		Password: os.Getenv("PVE_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cluster, err := client.WithContext(ctx).GetClusterStatus()
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Fatal("the cluster did not answer within 10 seconds")
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(cluster.Name, cluster.OnlineNodes, "of", cluster.TotalNodes, "nodes online")
+}
+
+// Tell missing privileges apart from other failures.
+func ExampleIsForbidden() {
+	client, err := api.NewClient(&api.Config{
+		Addr:        "https://pve.example.com:8006",
+		User:        "monitor",
+		Realm:       "pve",
+		TokenID:     "readonly",
+		TokenSecret: os.Getenv("PVE_TOKEN_SECRET"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = client.StartVM(&api.VM{ID: 100, Node: "pve1", Type: api.VMTypeQemu})
+
+	var apiErr *api.APIError
+
+	switch {
+	case api.IsForbidden(err):
+		fmt.Println("the token lacks VM.PowerMgmt")
+	case errors.As(err, &apiErr):
+		fmt.Println("the API refused:", apiErr.Message)
+	case err != nil:
+		log.Fatal(err)
+	}
+}
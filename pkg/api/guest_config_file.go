@@ -0,0 +1,341 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GuestConfig is the full configuration of a guest as key/value pairs, like
+// in /etc/pve/qemu-server/<vmid>.conf.
+type GuestConfig map[string]string
+
+// guestConfigDiskKey matches keys of QEMU disks that are allocated when a
+// guest is created from a config.
+var guestConfigDiskKey = regexp.MustCompile(`^((ide|sata|scsi|virtio)\d+|efidisk0|tpmstate0)$`)
+
+// guestConfigSkippedKey matches keys that belong to the original guest and
+// are not carried over to a new one.
+var guestConfigSkippedKey = regexp.MustCompile(`^(digest|lock|parent|vmgenid|meta|unused\d+)$`)
+
+// GetGuestConfig fetches the full configuration of a VM or container.
+func (c *Client) GetGuestConfig(vm *VM) (GuestConfig, error) {
+	var result map[string]interface{}
+
+	endpoint := fmt.Sprintf("/nodes/%s/%s/%d/config", vm.Node, vm.Type, vm.ID)
+	if err := c.Get(endpoint, &result); err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected config response format")
+	}
+
+	config := make(GuestConfig, len(data))
+
+	for key, value := range data {
+		if key == "digest" {
+			continue
+		}
+
+		config[key] = formatConfigValue(value)
+	}
+
+	return config, nil
+}
+
+// formatConfigValue formats a config value of the API as it appears in
+// config files.
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sortedKeys returns the keys of the config in order.
+func (g GuestConfig) sortedKeys() []string {
+	keys := make([]string, 0, len(g))
+
+	for key := range g {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// FormatConf formats the config like a Proxmox config file: the
+// description as leading comment lines, then one "key: value" per line.
+func (g GuestConfig) FormatConf() []byte {
+	var buf bytes.Buffer
+
+	if description := g["description"]; description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			buf.WriteString("#" + line + "\n")
+		}
+	}
+
+	for _, key := range g.sortedKeys() {
+		if key != "description" {
+			fmt.Fprintf(&buf, "%s: %s\n", key, g[key])
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// FormatYAML formats the config as a YAML mapping.
+func (g GuestConfig) FormatYAML() ([]byte, error) {
+	return yaml.Marshal(map[string]string(g))
+}
+
+// ParseGuestConfig parses a config saved with FormatConf or FormatYAML, or
+// copied from /etc/pve. Snapshot sections of config files are ignored.
+func ParseGuestConfig(data []byte, isYAML bool) (GuestConfig, error) {
+	config := GuestConfig{}
+
+	if isYAML {
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+
+		for key, value := range values {
+			config[key] = formatConfigValue(value)
+		}
+	} else {
+		var description []string
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			line := strings.TrimRight(scanner.Text(), "\r")
+
+			// Snapshots and pending changes follow the current config
+			if strings.HasPrefix(line, "[") {
+				break
+			}
+
+			if strings.HasPrefix(line, "#") {
+				description = append(description, strings.TrimPrefix(line, "#"))
+
+				continue
+			}
+
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNumber)
+			}
+
+			config[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(description) > 0 {
+			config["description"] = strings.Join(description, "\n")
+		}
+	}
+
+	if len(config) == 0 {
+		return nil, fmt.Errorf("the file holds no config")
+	}
+
+	return config, nil
+}
+
+// IsContainer reports whether the config is of a container.
+func (g GuestConfig) IsContainer() bool {
+	_, ok := g["rootfs"]
+
+	return ok
+}
+
+// ForNewVM returns the config adapted for creating another VM from it: the
+// disks are allocated anew with their size instead of referencing the
+// volumes of the original, on storage if it is set, network interfaces and
+// SMBIOS get new MAC addresses and UUIDs, and state of the original guest
+// like locks and snapshots is dropped.
+func (g GuestConfig) ForNewVM(storage string) (GuestConfig, error) {
+	if g.IsContainer() {
+		return nil, fmt.Errorf("containers can't be created from a config; they need an OS template or a backup")
+	}
+
+	config := make(GuestConfig, len(g))
+
+	for key, value := range g {
+		switch {
+		case guestConfigSkippedKey.MatchString(key):
+			continue
+		case guestConfigDiskKey.MatchString(key):
+			disk, err := newDiskAllocation(value, storage)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+
+			config[key] = disk
+		case strings.HasPrefix(key, "net"):
+			config[key] = withoutMAC(value)
+		case key == "smbios1":
+			if smbios := withoutOption(value, "uuid"); smbios != "" {
+				config[key] = smbios
+			}
+		default:
+			config[key] = value
+		}
+	}
+
+	return config, nil
+}
+
+// newDiskAllocation turns a disk that references a volume, e.g.
+// "local-lvm:vm-100-disk-0,iothread=1,size=32G", into the allocation of a
+// new volume of the same size: "local-lvm:32,iothread=1". CD-ROMs and disks
+// without a storage, like passed-through devices, are kept.
+func newDiskAllocation(value, storage string) (string, error) {
+	options := strings.Split(value, ",")
+	volume := options[0]
+
+	if strings.Contains(value, "media=cdrom") || !strings.Contains(volume, ":") {
+		return value, nil
+	}
+
+	if storage == "" {
+		storage, _, _ = strings.Cut(volume, ":")
+	}
+
+	// EFI disks and TPM states have a fixed size; 1 GiB is what Proxmox asks for
+	sizeGiB := 1
+	kept := []string{}
+
+	for _, option := range options[1:] {
+		if size, ok := strings.CutPrefix(option, "size="); ok {
+			gib, err := parseSizeGiB(size)
+			if err != nil {
+				return "", err
+			}
+
+			sizeGiB = gib
+
+			continue
+		}
+
+		kept = append(kept, option)
+	}
+
+	return strings.Join(append([]string{fmt.Sprintf("%s:%d", storage, sizeGiB)}, kept...), ","), nil
+}
+
+// parseSizeGiB parses a disk size like "32G" or "512M", rounded up to GiB.
+func parseSizeGiB(size string) (int, error) {
+	units := map[byte]float64{'K': 1.0 / (1 << 20), 'M': 1.0 / (1 << 10), 'G': 1, 'T': 1 << 10}
+
+	if size == "" {
+		return 0, fmt.Errorf("empty disk size")
+	}
+
+	factor, ok := units[size[len(size)-1]]
+	number := size[:len(size)-1]
+
+	if !ok {
+		// Plain numbers are bytes
+		factor, number = 1.0/(1<<30), size
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid disk size %q", size)
+	}
+
+	return int(math.Max(1, math.Ceil(value*factor))), nil
+}
+
+// withoutMAC removes the MAC address from a network interface like
+// "virtio=BC:24:11:2E:61:0A,bridge=vmbr0", so that a new one is generated.
+func withoutMAC(value string) string {
+	options := strings.Split(value, ",")
+	if model, mac, ok := strings.Cut(options[0], "="); ok && strings.Count(mac, ":") == 5 {
+		options[0] = model
+	}
+
+	return strings.Join(withoutEmpty(withoutOptionList(options, "macaddr")), ",")
+}
+
+// withoutOption removes the option name from a comma-separated option list.
+func withoutOption(value, name string) string {
+	return strings.Join(withoutEmpty(withoutOptionList(strings.Split(value, ","), name)), ",")
+}
+
+// withoutOptionList removes the options with the given name.
+func withoutOptionList(options []string, name string) []string {
+	kept := options[:0]
+
+	for _, option := range options {
+		if !strings.HasPrefix(option, name+"=") {
+			kept = append(kept, option)
+		}
+	}
+
+	return kept
+}
+
+// withoutEmpty removes empty strings.
+func withoutEmpty(items []string) []string {
+	kept := items[:0]
+
+	for _, item := range items {
+		if item != "" {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+// CreateVMFromConfig creates VM vmid on node with the settings of config,
+// typically one prepared with ForNewVM so that its disks are allocated
+// rather than taken over from another VM. vmid must be a valid, unused
+// VMID. It returns the UPID of the create task, which allocates the disks.
+func (c *Client) CreateVMFromConfig(node string, vmid int, config GuestConfig) (string, error) {
+	if vmid < 100 {
+		return "", fmt.Errorf("VMID is required")
+	}
+
+	data := map[string]interface{}{"vmid": vmid}
+	for key, value := range config {
+		data[key] = value
+	}
+
+	c.logger.Info("Creating VM %d on node %s from a config", vmid, node)
+
+	var result map[string]interface{}
+	if err := c.PostWithResponse(fmt.Sprintf("/nodes/%s/qemu", node), data, &result); err != nil {
+		return "", fmt.Errorf("failed to create VM %d: %w", vmid, err)
+	}
+
+	upid, ok := result["data"].(string)
+	if !ok || !strings.HasPrefix(upid, "UPID:") {
+		return "", fmt.Errorf("unexpected response when creating VM %d", vmid)
+	}
+
+	return upid, nil
+}
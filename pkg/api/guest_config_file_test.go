@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestConfig_FormatAndParse(t *testing.T) {
+	config := GuestConfig{
+		"cores":       "2",
+		"description": "web server\nowner: alice",
+		"net0":        "virtio=BC:24:11:2E:61:0A,bridge=vmbr0",
+	}
+
+	conf := config.FormatConf()
+	assert.Equal(t, "#web server\n#owner: alice\ncores: 2\nnet0: virtio=BC:24:11:2E:61:0A,bridge=vmbr0\n", string(conf))
+
+	parsed, err := ParseGuestConfig(append(conf, []byte("\n[snap1]\ncores: 1\n")...), false)
+	require.NoError(t, err)
+	assert.Equal(t, config, parsed)
+
+	data, err := config.FormatYAML()
+	require.NoError(t, err)
+
+	parsed, err = ParseGuestConfig(data, true)
+	require.NoError(t, err)
+	assert.Equal(t, config, parsed)
+
+	parsed, err = ParseGuestConfig([]byte("cores: 4\nonboot: 1\n"), true)
+	require.NoError(t, err)
+	assert.Equal(t, GuestConfig{"cores": "4", "onboot": "1"}, parsed)
+
+	_, err = ParseGuestConfig([]byte("not a config line\n"), false)
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = ParseGuestConfig([]byte("\n"), false)
+	assert.ErrorContains(t, err, "holds no config")
+}
+
+func TestGuestConfig_ForNewVM(t *testing.T) {
+	config := GuestConfig{
+		"name":     "web",
+		"scsi0":    "local-lvm:vm-100-disk-0,iothread=1,size=32G",
+		"efidisk0": "local-lvm:vm-100-disk-1,efitype=4m,size=4M",
+		"ide2":     "local:iso/debian.iso,media=cdrom,size=600M",
+		"net0":     "virtio=BC:24:11:2E:61:0A,bridge=vmbr0,firewall=1",
+		"smbios1":  "uuid=0b6f1a7e-5d3c-4a8e-9f0e-6d2c1b0a9e8f",
+		"vmgenid":  "2a1e6c0d-7b9f-4e3a-8d5c-1f0b9a8e7d6c",
+		"unused0":  "local-lvm:vm-100-disk-2",
+		"lock":     "backup",
+	}
+
+	prepared, err := config.ForNewVM("")
+	require.NoError(t, err)
+	assert.Equal(t, GuestConfig{
+		"name":     "web",
+		"scsi0":    "local-lvm:32,iothread=1",
+		"efidisk0": "local-lvm:1,efitype=4m",
+		"ide2":     "local:iso/debian.iso,media=cdrom,size=600M",
+		"net0":     "virtio,bridge=vmbr0,firewall=1",
+	}, prepared)
+
+	prepared, err = config.ForNewVM("ceph")
+	require.NoError(t, err)
+	assert.Equal(t, "ceph:32,iothread=1", prepared["scsi0"])
+
+	_, err = GuestConfig{"rootfs": "local-lvm:vm-101-disk-0,size=8G"}.ForNewVM("")
+	assert.ErrorContains(t, err, "containers can't be created")
+
+	_, err = GuestConfig{"scsi0": "local-lvm:vm-100-disk-0,size=big"}.ForNewVM("")
+	assert.ErrorContains(t, err, "scsi0: invalid disk size")
+}
+
+func TestClient_CreateVMFromConfig(t *testing.T) {
+	var body map[string]interface{}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/nodes/pve2/qemu", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": "UPID:pve2:0001:0002:0003:qmcreate:120:root@pam:"})
+	})
+
+	upid, err := client.CreateVMFromConfig("pve2", 120, GuestConfig{"name": "web", "cores": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, "UPID:pve2:0001:0002:0003:qmcreate:120:root@pam:", upid)
+	assert.Equal(t, map[string]interface{}{"vmid": float64(120), "name": "web", "cores": "2"}, body)
+}
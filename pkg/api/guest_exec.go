@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// guestExecPollInterval is how often the status of a command run with the
+// guest agent is checked.
+const guestExecPollInterval = 250 * time.Millisecond
+
+// GuestExecResult is the outcome of a command run with the guest agent.
+type GuestExecResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// Failure describes a failed command by its exit code and error output.
+func (r *GuestExecResult) Failure() string {
+	if stderr := strings.TrimSpace(r.Stderr); stderr != "" {
+		return fmt.Sprintf("exit code %d: %s", r.ExitCode, stderr)
+	}
+
+	return fmt.Sprintf("exit code %d", r.ExitCode)
+}
+
+// GuestExec runs a command inside a running QEMU guest with the guest agent
+// and waits up to timeout for it to exit. Errors are returned for failures
+// to run the command; its own exit code is part of the result.
+func (c *Client) GuestExec(vm *VM, command []string, timeout time.Duration) (*GuestExecResult, error) {
+	if vm.Type != VMTypeQemu || vm.Status != VMStatusRunning {
+		return nil, fmt.Errorf("commands can only run in running QEMU VMs")
+	}
+
+	if !vm.AgentEnabled {
+		return nil, fmt.Errorf("guest agent is not enabled for this VM")
+	}
+
+	base := fmt.Sprintf("/nodes/%s/qemu/%d/agent", vm.Node, vm.ID)
+
+	var res map[string]interface{}
+	if err := c.httpClient.Post(c.requestContext(), base+"/exec", map[string]interface{}{"command": command}, &res); err != nil {
+		if strings.Contains(err.Error(), "QEMU guest agent is not running") {
+			return nil, fmt.Errorf("QEMU guest agent is not running")
+		}
+
+		return nil, fmt.Errorf("failed to run command in guest: %w", err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format from guest agent")
+	}
+
+	pid := int(getFloat(data, "pid"))
+	deadline := time.Now().Add(timeout)
+
+	for {
+		var status map[string]interface{}
+		if err := c.GetNoRetry(fmt.Sprintf("%s/exec-status?pid=%d", base, pid), &status); err != nil {
+			return nil, fmt.Errorf("failed to get command status: %w", err)
+		}
+
+		data, ok := status["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format from guest agent")
+		}
+
+		if getBool(data, "exited") {
+			return &GuestExecResult{
+				ExitCode: int(getFloat(data, "exitcode")),
+				Stdout:   getString(data, "out-data"),
+				Stderr:   getString(data, "err-data"),
+			}, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("command did not finish within %s", timeout)
+		}
+
+		time.Sleep(guestExecPollInterval)
+	}
+}
+
+// PingGuestAgent checks that the guest agent of a running QEMU VM answers.
+func (c *Client) PingGuestAgent(vm *VM) error {
+	if vm.Type != VMTypeQemu {
+		return fmt.Errorf("only QEMU VMs have a guest agent")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/agent/ping", vm.Node, vm.ID)
+
+	return c.httpClient.Post(c.requestContext(), path, nil, nil)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GuestExec(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/nodes/pve1/qemu/100/agent/exec" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"pid": 7}})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"exited": 1, "exitcode": 5, "err-data": "Unit nginx.service not found.\n",
+		}})
+	})
+
+	vm := &VM{ID: 100, Node: "pve1", Type: VMTypeQemu, Status: VMStatusRunning, AgentEnabled: true}
+
+	result, err := client.GuestExec(vm, []string{"sh", "-c", "systemctl restart nginx"}, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.ExitCode)
+	assert.Equal(t, "exit code 5: Unit nginx.service not found.", result.Failure())
+
+	_, err = client.GuestExec(&VM{ID: 105, Node: "pve1", Type: VMTypeLXC, Status: VMStatusRunning}, []string{"true"}, time.Second)
+	assert.Error(t, err)
+}
+
+func TestClient_PingGuestAgent(t *testing.T) {
+	var pinged string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pinged = r.Method + " " + r.URL.Path
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{}})
+	})
+
+	require.NoError(t, client.PingGuestAgent(&VM{ID: 100, Node: "pve1", Type: VMTypeQemu}))
+	assert.Equal(t, "POST /nodes/pve1/qemu/100/agent/ping", pinged)
+
+	assert.Error(t, client.PingGuestAgent(&VM{ID: 105, Node: "pve1", Type: VMTypeLXC}))
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// GuestFile is a file read from a QEMU guest through its guest agent.
+type GuestFile struct {
+	Path    string
+	Content string
+	// BytesRead is the number of bytes the agent read.
+	BytesRead int64
+	// Truncated is set when the file is larger than the agent reads at once
+	// (16 MiB), so Content holds only its beginning.
+	Truncated bool
+}
+
+// IsBinary reports whether the file looks binary and shouldn't be shown as
+// text.
+func (f *GuestFile) IsBinary() bool {
+	return strings.ContainsRune(f.Content, 0) || !utf8.ValidString(f.Content)
+}
+
+// IsWindowsGuest reports whether the OS type of a QEMU guest is Windows.
+func IsWindowsGuest(vm *VM) bool {
+	return strings.HasPrefix(vm.OSType, "win") || strings.HasPrefix(vm.OSType, "w2k") ||
+		vm.OSType == "wxp" || vm.OSType == "wvista"
+}
+
+// ReadGuestFile reads a file inside a running QEMU guest with the guest
+// agent. The agent can only read files; Proxmox offers no way to list
+// directories or stat files through it.
+func (c *Client) ReadGuestFile(vm *VM, path string) (*GuestFile, error) {
+	if vm.Type != VMTypeQemu || vm.Status != VMStatusRunning {
+		return nil, fmt.Errorf("files can only be read from running QEMU VMs")
+	}
+
+	if !vm.AgentEnabled {
+		return nil, fmt.Errorf("guest agent is not enabled for this VM")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+
+	endpoint := fmt.Sprintf("/nodes/%s/qemu/%d/agent/file-read?file=%s", vm.Node, vm.ID, url.QueryEscape(path))
+
+	var res map[string]interface{}
+
+	// Use GetNoRetry: a missing file or stopped agent won't succeed on retry
+	if err := c.GetNoRetry(endpoint, &res); err != nil {
+		if strings.Contains(err.Error(), "QEMU guest agent is not running") {
+			return nil, fmt.Errorf("QEMU guest agent is not running")
+		}
+
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format from guest agent")
+	}
+
+	return &GuestFile{
+		Path:      path,
+		Content:   getString(data, "content"),
+		BytesRead: int64(getFloat(data, "bytes-read")),
+		Truncated: getBool(data, "truncated"),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReadGuestFile(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/nodes/pve1/qemu/100/agent/file-read", r.URL.Path)
+		assert.Equal(t, "/var/log/my app.log", r.URL.Query().Get("file"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"content":    "started\n",
+				"bytes-read": 8,
+				"truncated":  true,
+			},
+		})
+	})
+
+	vm := &VM{ID: 100, Node: "pve1", Type: VMTypeQemu, Status: VMStatusRunning, AgentEnabled: true}
+
+	file, err := client.ReadGuestFile(vm, "/var/log/my app.log")
+	require.NoError(t, err)
+	assert.Equal(t, &GuestFile{Path: "/var/log/my app.log", Content: "started\n", BytesRead: 8, Truncated: true}, file)
+	assert.False(t, file.IsBinary())
+
+	_, err = client.ReadGuestFile(&VM{ID: 101, Node: "pve1", Type: VMTypeLXC, Status: VMStatusRunning}, "/etc/hosts")
+	assert.Error(t, err)
+
+	_, err = client.ReadGuestFile(&VM{ID: 102, Node: "pve1", Type: VMTypeQemu, Status: VMStatusRunning}, "/etc/hosts")
+	assert.EqualError(t, err, "guest agent is not enabled for this VM")
+}
+
+func TestGuestFile_IsBinary(t *testing.T) {
+	assert.True(t, (&GuestFile{Content: "ELF\x00\x01"}).IsBinary())
+	assert.True(t, (&GuestFile{Content: "\xff\xfe"}).IsBinary())
+	assert.False(t, (&GuestFile{Content: "héllo\n"}).IsBinary())
+}
+
+func TestIsWindowsGuest(t *testing.T) {
+	assert.True(t, IsWindowsGuest(&VM{OSType: "win11"}))
+	assert.True(t, IsWindowsGuest(&VM{OSType: "w2k8"}))
+	assert.False(t, IsWindowsGuest(&VM{OSType: "l26"}))
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GuestProcessScript is the shell script that samples the processes of a
+// Linux guest: it prints the clock tick rate, page size and total memory,
+// then /proc/uptime and the stat line of every process twice, one second
+// apart, so CPU usage is measured over that second rather than averaged
+// over the lifetime of a process. It contains no single quotes, so it can be
+// wrapped in them.
+const GuestProcessScript = "echo ==tick; getconf CLK_TCK 2>/dev/null || echo 100; " +
+	"echo ==page; getconf PAGESIZE 2>/dev/null || echo 4096; " +
+	"echo ==mem; grep MemTotal /proc/meminfo; " +
+	"echo ==sample; cat /proc/uptime; cat /proc/[0-9]*/stat 2>/dev/null; " +
+	"sleep 1; " +
+	"echo ==sample; cat /proc/uptime; cat /proc/[0-9]*/stat 2>/dev/null"
+
+// guestProcessesTimeout bounds sampling the processes of a guest, which
+// takes a second itself.
+const guestProcessesTimeout = 15 * time.Second
+
+// GuestProcess is a process running inside a guest.
+type GuestProcess struct {
+	PID   int
+	Name  string
+	State string
+	// CPU is the usage over the sampled second in percent of one core, like
+	// top.
+	CPU float64
+	// RSS is the resident memory in bytes and Memory its share of the
+	// guest's memory in percent.
+	RSS     int64
+	Memory  float64
+	Threads int
+}
+
+// processStat is the part of a /proc/<pid>/stat line used for the list.
+type processStat struct {
+	pid     int
+	name    string
+	state   string
+	ticks   int64
+	threads int
+	start   string
+	rss     int64
+}
+
+// parseProcessStat parses a /proc/<pid>/stat line. The name is in
+// parentheses and may contain spaces and parentheses itself.
+func parseProcessStat(line string) (processStat, bool) {
+	open := strings.IndexByte(line, '(')
+	closing := strings.LastIndexByte(line, ')')
+
+	if open < 1 || closing < open {
+		return processStat{}, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(line[:open]))
+	if err != nil {
+		return processStat{}, false
+	}
+
+	// Fields after the name, starting with the state (field 3)
+	fields := strings.Fields(line[closing+1:])
+	if len(fields) < 22 {
+		return processStat{}, false
+	}
+
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	threads, _ := strconv.Atoi(fields[17])
+	rss, _ := strconv.ParseInt(fields[21], 10, 64)
+
+	return processStat{
+		pid:     pid,
+		name:    line[open+1 : closing],
+		state:   fields[0],
+		ticks:   utime + stime,
+		threads: threads,
+		start:   fields[19],
+		rss:     rss,
+	}, true
+}
+
+// ParseGuestProcesses parses the output of GuestProcessScript into the
+// processes of the second sample, sorted by CPU usage.
+func ParseGuestProcesses(output []byte) ([]GuestProcess, error) {
+	var (
+		section  string
+		tick     = 100.0
+		pageSize = int64(4096)
+		memTotal int64
+		uptimes  []float64
+		samples  []map[string]processStat
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if name, ok := strings.CutPrefix(line, "=="); ok {
+			section = name
+
+			if section == "sample" {
+				samples = append(samples, make(map[string]processStat))
+			}
+
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+
+		switch section {
+		case "tick":
+			if value, err := strconv.ParseFloat(line, 64); err == nil && value > 0 {
+				tick = value
+			}
+		case "page":
+			if value, err := strconv.ParseInt(line, 10, 64); err == nil && value > 0 {
+				pageSize = value
+			}
+		case "mem":
+			// MemTotal:       16384000 kB
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				if kb, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+					memTotal = kb * 1024
+				}
+			}
+		case "sample":
+			// The first line of a sample is /proc/uptime
+			if len(uptimes) < len(samples) {
+				fields := strings.Fields(line)
+
+				uptime, err := strconv.ParseFloat(fields[0], 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid uptime %q", line)
+				}
+
+				uptimes = append(uptimes, uptime)
+
+				continue
+			}
+
+			if stat, ok := parseProcessStat(line); ok {
+				// The start time tells apart processes reusing a PID
+				samples[len(samples)-1][fmt.Sprintf("%d:%s", stat.pid, stat.start)] = stat
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(samples) != 2 || len(uptimes) != 2 || len(samples[1]) == 0 {
+		return nil, fmt.Errorf("no process samples found")
+	}
+
+	elapsed := uptimes[1] - uptimes[0]
+	if elapsed <= 0 {
+		elapsed = 1
+	}
+
+	processes := make([]GuestProcess, 0, len(samples[1]))
+
+	for key, stat := range samples[1] {
+		// Processes started between the samples used all their ticks then
+		delta := stat.ticks - samples[0][key].ticks
+		if delta < 0 {
+			delta = 0
+		}
+
+		process := GuestProcess{
+			PID:     stat.pid,
+			Name:    stat.name,
+			State:   stat.state,
+			CPU:     float64(delta) / tick / elapsed * 100,
+			RSS:     stat.rss * pageSize,
+			Threads: stat.threads,
+		}
+
+		if memTotal > 0 {
+			process.Memory = float64(process.RSS) / float64(memTotal) * 100
+		}
+
+		processes = append(processes, process)
+	}
+
+	SortGuestProcesses(processes, false)
+
+	return processes, nil
+}
+
+// SortGuestProcesses sorts processes by CPU usage, or by memory if byMemory
+// is set, highest first.
+func SortGuestProcesses(processes []GuestProcess, byMemory bool) {
+	sort.SliceStable(processes, func(i, j int) bool {
+		first, second := processes[i], processes[j]
+
+		if byMemory && first.RSS != second.RSS {
+			return first.RSS > second.RSS
+		}
+
+		if first.CPU != second.CPU {
+			return first.CPU > second.CPU
+		}
+
+		if first.RSS != second.RSS {
+			return first.RSS > second.RSS
+		}
+
+		return first.PID < second.PID
+	})
+}
+
+// GetGuestProcesses samples the processes of a running Linux QEMU guest
+// with the guest agent.
+func (c *Client) GetGuestProcesses(vm *VM) ([]GuestProcess, error) {
+	result, err := c.GuestExec(vm, []string{"sh", "-c", GuestProcessScript}, guestProcessesTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list processes: %s", result.Failure())
+	}
+
+	return ParseGuestProcesses([]byte(result.Stdout))
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statLine returns a /proc/<pid>/stat line with the given CPU ticks, thread
+// count, start time and resident pages.
+func statLine(pid, name, ticks, threads, start, rss string) string {
+	return pid + " (" + name + ") S 1 1 1 0 -1 4194560 100 0 0 0 " + ticks + " 0 0 0 20 0 " + threads + " 0 " + start + " 1000000 " + rss + " 18446744073709551615"
+}
+
+func TestParseGuestProcesses(t *testing.T) {
+	output := "==tick\n100\n==page\n4096\n==mem\nMemTotal:        1024000 kB\n" +
+		"==sample\n1000.00 3000.00\n" +
+		statLine("1", "systemd", "500", "1", "1", "2000") + "\n" +
+		statLine("42", "my (app)", "1000", "8", "300", "51200") + "\n" +
+		statLine("77", "old", "10", "1", "400", "10") + "\n" +
+		"==sample\n1001.00 3001.00\n" +
+		statLine("1", "systemd", "501", "1", "1", "2000") + "\n" +
+		statLine("42", "my (app)", "1150", "8", "300", "51200") + "\n" +
+		// PID 77 was reused by a new process between the samples
+		statLine("77", "new", "20", "1", "99000", "100") + "\n"
+
+	processes, err := ParseGuestProcesses([]byte(output))
+	require.NoError(t, err)
+	require.Len(t, processes, 3)
+
+	assert.Equal(t, 42, processes[0].PID)
+	assert.Equal(t, "my (app)", processes[0].Name)
+	assert.InDelta(t, 150, processes[0].CPU, 0.01)
+	assert.Equal(t, int64(51200*4096), processes[0].RSS)
+	assert.InDelta(t, 20, processes[0].Memory, 0.01)
+	assert.Equal(t, 8, processes[0].Threads)
+
+	assert.Equal(t, "new", processes[1].Name)
+	assert.InDelta(t, 20, processes[1].CPU, 0.01)
+	assert.InDelta(t, 1, processes[2].CPU, 0.01)
+
+	SortGuestProcesses(processes, true)
+	assert.Equal(t, []int{42, 1, 77}, []int{processes[0].PID, processes[1].PID, processes[2].PID})
+
+	_, err = ParseGuestProcesses([]byte("sh: getconf: not found\n"))
+	assert.Error(t, err)
+}
+
+func TestClient_GetGuestProcesses(t *testing.T) {
+	output := "==sample\n10.0 0\n" + statLine("1", "init", "0", "1", "1", "100") + "\n" +
+		"==sample\n11.0 0\n" + statLine("1", "init", "5", "1", "1", "100") + "\n"
+
+	polls := 0
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/qemu/100/agent/exec":
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []interface{}{"sh", "-c", GuestProcessScript}, body["command"])
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"pid": 4242}})
+		case "/nodes/pve1/qemu/100/agent/exec-status":
+			assert.Equal(t, "4242", r.URL.Query().Get("pid"))
+
+			polls++
+			if polls == 1 {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"exited": 0}})
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"exited": 1, "exitcode": 0, "out-data": output,
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	vm := &VM{ID: 100, Node: "pve1", Type: VMTypeQemu, Status: VMStatusRunning, AgentEnabled: true}
+
+	processes, err := client.GetGuestProcesses(vm)
+	require.NoError(t, err)
+	require.Len(t, processes, 1)
+	assert.Equal(t, "init", processes[0].Name)
+	assert.InDelta(t, 5, processes[0].CPU, 0.01)
+	assert.Equal(t, 2, polls)
+}
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PCIDevice is a PCI device of a node.
+type PCIDevice struct {
+	// ID is the PCI address, e.g. "0000:01:00.0".
+	ID         string `json:"id"`
+	Class      string `json:"class"` // e.g. "0x030000" for VGA controllers
+	VendorID   string `json:"vendor"`
+	DeviceID   string `json:"device"`
+	VendorName string `json:"vendor_name,omitempty"`
+	DeviceName string `json:"device_name,omitempty"`
+	// IOMMUGroup is -1 when IOMMU is disabled. Devices of a group can only
+	// be passed through together.
+	IOMMUGroup int `json:"iommugroup"`
+	// MDev reports support for mediated devices (e.g. vGPU).
+	MDev bool `json:"mdev,omitempty"`
+}
+
+// USBDevice is a USB device of a node.
+type USBDevice struct {
+	// Path is the bus-port path, e.g. "1-2" or "1-2.3".
+	Path         string `json:"usbpath"`
+	VendorID     string `json:"vendid"`
+	ProductID    string `json:"prodid"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Product      string `json:"product,omitempty"`
+	Speed        string `json:"speed,omitempty"`
+}
+
+// Passthrough is a host device assigned to a VM with a hostpciN or usbN
+// config entry.
+type Passthrough struct {
+	Key string // Config key, e.g. "hostpci0" or "usb1"
+	// Host selects the device: PCI addresses separated by ";" for hostpci,
+	// "vendor:product" or a bus-port path for usb.
+	Host string
+	// Mapping is the cluster resource mapping used instead of Host.
+	Mapping string
+	// MDev is the mediated device type created for the guest, e.g.
+	// "nvidia-63"; empty when the whole device is passed through.
+	MDev string
+}
+
+// MDevType is a mediated device type a PCI device can provide, e.g. a vGPU
+// profile.
+type MDevType struct {
+	Type        string `json:"type"` // e.g. "nvidia-63"
+	Name        string `json:"name"` // e.g. "GRID P4-1Q"
+	Description string `json:"description"`
+	// Available is the number of instances that can still be created.
+	Available int `json:"available"`
+}
+
+// passthroughKeyPattern matches the config keys of passed-through devices.
+var passthroughKeyPattern = regexp.MustCompile(`^(hostpci|usb)\d+$`)
+
+// GetNodePCIDevices lists the PCI devices of a node, sorted by address.
+// Memory controllers, bridges and processors are left out, like in the web UI.
+func (c *Client) GetNodePCIDevices(nodeName string) ([]PCIDevice, error) {
+	var res map[string]interface{}
+	if err := c.Get(fmt.Sprintf("/nodes/%s/hardware/pci", nodeName), &res); err != nil {
+		return nil, fmt.Errorf("failed to get PCI devices: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for PCI devices")
+	}
+
+	devices := make([]PCIDevice, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		group := -1
+		if _, ok := entry["iommugroup"]; ok {
+			group = getInt(entry, "iommugroup")
+		}
+
+		devices = append(devices, PCIDevice{
+			ID:         getString(entry, "id"),
+			Class:      getString(entry, "class"),
+			VendorID:   getString(entry, "vendor"),
+			DeviceID:   getString(entry, "device"),
+			VendorName: getString(entry, "vendor_name"),
+			DeviceName: getString(entry, "device_name"),
+			IOMMUGroup: group,
+			MDev:       getBool(entry, "mdev"),
+		})
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].ID < devices[j].ID
+	})
+
+	return devices, nil
+}
+
+// GetNodeUSBDevices lists the USB devices of a node, sorted by path.
+func (c *Client) GetNodeUSBDevices(nodeName string) ([]USBDevice, error) {
+	var res map[string]interface{}
+	if err := c.Get(fmt.Sprintf("/nodes/%s/hardware/usb", nodeName), &res); err != nil {
+		return nil, fmt.Errorf("failed to get USB devices: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for USB devices")
+	}
+
+	devices := make([]USBDevice, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// Devices directly on a root hub have no path
+		path := getString(entry, "usbpath")
+		if path == "" {
+			path = fmt.Sprintf("%d-%d", getInt(entry, "busnum"), getInt(entry, "port"))
+		}
+
+		devices = append(devices, USBDevice{
+			Path:         path,
+			VendorID:     getString(entry, "vendid"),
+			ProductID:    getString(entry, "prodid"),
+			Manufacturer: getString(entry, "manufacturer"),
+			Product:      getString(entry, "product"),
+			Speed:        getString(entry, "speed"),
+		})
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Path < devices[j].Path
+	})
+
+	return devices, nil
+}
+
+// GetPCIMDevTypes lists the mediated device types of a PCI device with the
+// number of instances still available.
+func (c *Client) GetPCIMDevTypes(nodeName, pciID string) ([]MDevType, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/hardware/pci/%s/mdev", nodeName, pciID), &res); err != nil {
+		return nil, fmt.Errorf("failed to get mediated device types: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for mediated device types")
+	}
+
+	types := make([]MDevType, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		types = append(types, MDevType{
+			Type:        getString(entry, "type"),
+			Name:        getString(entry, "name"),
+			Description: getString(entry, "description"),
+			Available:   getInt(entry, "available"),
+		})
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Type < types[j].Type
+	})
+
+	return types, nil
+}
+
+// IsGPU reports whether the device is a display controller or supports
+// mediated devices, i.e. can serve as a (v)GPU.
+func (d PCIDevice) IsGPU() bool {
+	return d.MDev || strings.HasPrefix(d.Class, "0x03")
+}
+
+// GetGuestPassthrough returns the host devices passed through to a VM.
+// Containers have none.
+func (c *Client) GetGuestPassthrough(vm *VM) ([]Passthrough, error) {
+	if vm.Type != VMTypeQemu {
+		return nil, nil
+	}
+
+	var res map[string]interface{}
+	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/qemu/%d/config", vm.Node, vm.ID), &res, c.CacheTTLs().Config); err != nil {
+		return nil, fmt.Errorf("failed to get config of VM %d: %w", vm.ID, err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected config response format")
+	}
+
+	return ParsePassthrough(data), nil
+}
+
+// ParsePassthrough extracts the hostpciN and usbN entries of a VM config,
+// sorted by key. USB entries for SPICE redirection are left out.
+func ParsePassthrough(configData map[string]interface{}) []Passthrough {
+	var result []Passthrough
+
+	for key, value := range configData {
+		text, ok := value.(string)
+		if !ok || !passthroughKeyPattern.MatchString(key) {
+			continue
+		}
+
+		passthrough := Passthrough{Key: key}
+
+		for i, option := range strings.Split(text, ",") {
+			name, val, found := strings.Cut(option, "=")
+
+			switch {
+			case i == 0 && !found:
+				passthrough.Host = name
+			case name == "host":
+				passthrough.Host = val
+			case name == "mapping":
+				passthrough.Mapping = val
+			case name == "mdev":
+				passthrough.MDev = val
+			}
+		}
+
+		if passthrough.Host == "spice" || (passthrough.Host == "" && passthrough.Mapping == "") {
+			continue
+		}
+
+		result = append(result, passthrough)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// MatchesPCI reports whether the entry passes through the PCI device.
+// Addresses without function select all functions of the device.
+func (p Passthrough) MatchesPCI(device PCIDevice) bool {
+	if !strings.HasPrefix(p.Key, "hostpci") {
+		return false
+	}
+
+	for _, address := range strings.Split(p.Host, ";") {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address == "" {
+			continue
+		}
+
+		// The PCI domain is optional
+		if strings.Count(address, ":") == 1 {
+			address = "0000:" + address
+		}
+
+		id := strings.ToLower(device.ID)
+		if id == address || (!strings.Contains(address, ".") && strings.HasPrefix(id, address+".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesUSB reports whether the entry passes through the USB device, by
+// vendor and product ID or by port.
+func (p Passthrough) MatchesUSB(device USBDevice) bool {
+	if !strings.HasPrefix(p.Key, "usb") || p.Host == "" {
+		return false
+	}
+
+	if vendor, product, found := strings.Cut(p.Host, ":"); found {
+		return strings.EqualFold(trimHexPrefix(vendor), trimHexPrefix(device.VendorID)) &&
+			strings.EqualFold(trimHexPrefix(product), trimHexPrefix(device.ProductID))
+	}
+
+	return p.Host == device.Path
+}
+
+// trimHexPrefix strips the "0x" of IDs like "0x046d".
+func trimHexPrefix(id string) string {
+	return strings.TrimPrefix(strings.ToLower(id), "0x")
+}
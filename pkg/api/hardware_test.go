@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetNodeHardware(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/hardware/pci":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "0000:01:00.1", "class": "0x040300", "vendor": "0x10de", "device": "0x10f0", "device_name": "GP104 HD Audio", "iommugroup": 14},
+				map[string]interface{}{"id": "0000:01:00.0", "class": "0x030000", "vendor": "0x10de", "device": "0x1b80", "vendor_name": "NVIDIA Corporation", "device_name": "GP104 [GeForce GTX 1080]", "iommugroup": 14, "mdev": 1},
+			}})
+		case "/nodes/pve1/hardware/pci/0000:01:00.0/mdev":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"type": "nvidia-65", "name": "GRID P4-4Q", "available": 2, "description": "max_instance=2"},
+				map[string]interface{}{"type": "nvidia-63", "name": "GRID P4-1Q", "available": 6, "description": "max_instance=8"},
+			}})
+		case "/nodes/pve1/hardware/usb":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"busnum": 1, "port": 2, "usbpath": "1-2.3", "vendid": "0x046d", "prodid": "0xc52b", "product": "USB Receiver"},
+				map[string]interface{}{"busnum": 1, "port": 1, "vendid": "0x0bda", "prodid": "0x0129"},
+			}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	pci, err := client.GetNodePCIDevices("pve1")
+	require.NoError(t, err)
+	require.Len(t, pci, 2)
+	assert.Equal(t, PCIDevice{
+		ID: "0000:01:00.0", Class: "0x030000", VendorID: "0x10de", DeviceID: "0x1b80",
+		VendorName: "NVIDIA Corporation", DeviceName: "GP104 [GeForce GTX 1080]", IOMMUGroup: 14, MDev: true,
+	}, pci[0])
+	assert.Equal(t, 14, pci[1].IOMMUGroup)
+
+	assert.True(t, pci[0].IsGPU())
+	assert.False(t, pci[1].IsGPU())
+
+	types, err := client.GetPCIMDevTypes("pve1", "0000:01:00.0")
+	require.NoError(t, err)
+	assert.Equal(t, []MDevType{
+		{Type: "nvidia-63", Name: "GRID P4-1Q", Description: "max_instance=8", Available: 6},
+		{Type: "nvidia-65", Name: "GRID P4-4Q", Description: "max_instance=2", Available: 2},
+	}, types)
+
+	usb, err := client.GetNodeUSBDevices("pve1")
+	require.NoError(t, err)
+	require.Len(t, usb, 2)
+	assert.Equal(t, "1-1", usb[0].Path, "path built from bus and port")
+	assert.Equal(t, "1-2.3", usb[1].Path)
+}
+
+func TestParsePassthrough(t *testing.T) {
+	passthrough := ParsePassthrough(map[string]interface{}{
+		"hostpci0": "0000:01:00,pcie=1,x-vga=1",
+		"hostpci1": "mapping=nic1",
+		"hostpci2": "0000:03:00.0,mdev=nvidia-63",
+		"usb0":     "host=046d:c52b",
+		"usb1":     "spice",
+		"usb2":     "host=1-1,usb3=1",
+		"scsi0":    "local-lvm:vm-100-disk-0",
+	})
+
+	assert.Equal(t, []Passthrough{
+		{Key: "hostpci0", Host: "0000:01:00"},
+		{Key: "hostpci1", Mapping: "nic1"},
+		{Key: "hostpci2", Host: "0000:03:00.0", MDev: "nvidia-63"},
+		{Key: "usb0", Host: "046d:c52b"},
+		{Key: "usb2", Host: "1-1"},
+	}, passthrough)
+
+	gpu := PCIDevice{ID: "0000:01:00.0"}
+	audio := PCIDevice{ID: "0000:01:00.1"}
+	nic := PCIDevice{ID: "0000:02:00.0"}
+
+	assert.True(t, passthrough[0].MatchesPCI(gpu), "all functions of the device")
+	assert.True(t, passthrough[0].MatchesPCI(audio))
+	assert.False(t, passthrough[0].MatchesPCI(nic))
+	assert.True(t, Passthrough{Key: "hostpci0", Host: "02:00.0;01:00.1"}.MatchesPCI(nic), "domain is optional")
+	assert.False(t, Passthrough{Key: "hostpci0", Host: "01:00.1"}.MatchesPCI(gpu))
+	assert.False(t, passthrough[1].MatchesPCI(gpu), "mappings are not resolved")
+
+	receiver := USBDevice{Path: "1-2.3", VendorID: "0x046d", ProductID: "0xc52b"}
+	reader := USBDevice{Path: "1-1", VendorID: "0x0bda", ProductID: "0x0129"}
+
+	assert.True(t, passthrough[3].MatchesUSB(receiver))
+	assert.False(t, passthrough[3].MatchesUSB(reader))
+	assert.True(t, passthrough[4].MatchesUSB(reader))
+	assert.False(t, passthrough[0].MatchesUSB(reader))
+}
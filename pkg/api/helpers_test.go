@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+// newTestClient returns a client of a test server answering with handler.
+// The server is closed when the test ends.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	logger := testutils.NewTestLogger()
+
+	return &Client{httpClient: NewHTTPClient(server.Client(), server.URL, logger), logger: logger}
+}
+
+// requestRecorder records the JSON bodies of the write requests a test
+// server gets, with the request path under "path", and answers them with
+// empty data.
+type requestRecorder struct {
+	requests []map[string]interface{}
+}
+
+// handler returns the handler of the test server, expecting method.
+func (rec *requestRecorder) handler(t *testing.T, method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, method, r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		body["path"] = r.URL.Path
+
+		rec.requests = append(rec.requests, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": nil})
+	}
+}
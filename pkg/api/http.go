@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -96,7 +98,7 @@ func (hc *HTTPClient) doRequestWithRetry(ctx context.Context, method, path strin
 		lastErr = err
 
 		// Check if we should retry
-		if !hc.shouldRetry(err, attempt, maxRetries) {
+		if ctx.Err() != nil || !hc.shouldRetry(err, attempt, maxRetries) {
 			break
 		}
 
@@ -145,7 +147,7 @@ func (hc *HTTPClient) executeRequest(ctx context.Context, method, path string, d
 		// Use ticket-based authentication
 		token, authErr := hc.authManager.GetValidToken(ctx)
 		if authErr != nil {
-			return fmt.Errorf("authentication failed: %w", authErr)
+			return fmt.Errorf("failed to authenticate: %w", authErr)
 		}
 
 		// Set authentication cookie
@@ -169,7 +171,11 @@ func (hc *HTTPClient) executeRequest(ctx context.Context, method, path string, d
 	// Execute request
 	resp, err := hc.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		if !isConnectionFailure(ctx, err) {
+			return fmt.Errorf("request failed: %w", err)
+		}
+
+		return fmt.Errorf("%w: %w", ErrConnection, err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -178,24 +184,28 @@ func (hc *HTTPClient) executeRequest(ctx context.Context, method, path string, d
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		if !isConnectionFailure(ctx, err) {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		return fmt.Errorf("failed to read response body: %w: %w", ErrConnection, err)
 	}
 
 	// Check for authentication errors
 	if resp.StatusCode == http.StatusUnauthorized {
 		if hc.apiToken != "" {
-			return fmt.Errorf("API token authentication failed: %s", resp.Status)
+			return fmt.Errorf("API token %w: %s", ErrAuthFailed, resp.Status)
 		} else if hc.authManager != nil {
 			hc.logger.Debug("Authentication token expired, clearing cache")
 			hc.authManager.ClearToken()
 
-			return fmt.Errorf("authentication failed: %s", resp.Status)
+			return fmt.Errorf("%w: %s", ErrAuthFailed, resp.Status)
 		}
 	}
 
 	// Check for other HTTP errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return newAPIError(resp.StatusCode, resp.Status, string(respBody))
 	}
 
 	// Parse JSON response if result is provided
@@ -215,11 +225,16 @@ func (hc *HTTPClient) shouldRetry(err error, attempt, maxRetries int) bool {
 	}
 
 	// Retry on network errors, timeouts, and 5xx server errors
-	if strings.Contains(err.Error(), "connection") ||
-		strings.Contains(err.Error(), "timeout") ||
-		strings.Contains(err.Error(), "status 5") {
+	if errors.Is(err, ErrConnection) {
 		return true
 	}
 
-	return false
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
 }
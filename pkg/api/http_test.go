@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
@@ -303,6 +306,41 @@ func TestHTTPClient_HTTPErrors(t *testing.T) {
 	}
 }
 
+func TestNewAPIError(t *testing.T) {
+	err := newAPIError(http.StatusForbidden, "403 Permission check failed (/vms/100, VM.PowerMgmt)", "{\"data\":null}\n")
+	assert.Equal(t, "Permission check failed (/vms/100, VM.PowerMgmt)", err.Message)
+	assert.Equal(t, "API request failed with status 403: Permission check failed (/vms/100, VM.PowerMgmt): {\"data\":null}", err.Error())
+
+	err = newAPIError(http.StatusNotFound, "404 Not Found", "")
+	assert.Empty(t, err.Message)
+	assert.Equal(t, "API request failed with status 404", err.Error())
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"401 is an authentication failure", newAPIError(http.StatusUnauthorized, "401 Unauthorized", ""), ErrAuthFailed, true},
+		{"403 is a permission error", newAPIError(http.StatusForbidden, "403 Permission check failed (/vms/100, VM.Audit)", ""), ErrPermission, true},
+		{"404 is not found", newAPIError(http.StatusNotFound, "404 Not Found", ""), ErrNotFound, true},
+		{"missing guest is not found", newAPIError(http.StatusInternalServerError, "500 Configuration file 'nodes/pve/qemu-server/999.conf' does not exist", ""), ErrNotFound, true},
+		{"other 500 is not found", newAPIError(http.StatusInternalServerError, "500 Internal Server Error", ""), ErrNotFound, false},
+		{"403 is no authentication failure", newAPIError(http.StatusForbidden, "403 Forbidden", ""), ErrAuthFailed, false},
+		{"wrapped 403 is a permission error", fmt.Errorf("failed to get VM details: %w", newAPIError(http.StatusForbidden, "403 Forbidden", "")), ErrPermission, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+
+	assert.True(t, IsForbidden(newAPIError(http.StatusForbidden, "403 Forbidden", "")))
+}
+
 func TestHTTPClient_UnauthorizedWithAPIToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -318,6 +356,7 @@ func TestHTTPClient_UnauthorizedWithAPIToken(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "API token authentication failed")
+	assert.ErrorIs(t, err, ErrAuthFailed)
 }
 
 func TestHTTPClient_UnauthorizedWithTicketAuth(t *testing.T) {
@@ -342,7 +381,7 @@ func TestHTTPClient_UnauthorizedWithTicketAuth(t *testing.T) {
 	err := client.Get(context.Background(), "/test", &result)
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "authentication failed")
+	assert.ErrorIs(t, err, ErrAuthFailed)
 
 	// Verify that the token was cleared
 	assert.Nil(t, authManager.authToken)
@@ -373,6 +412,98 @@ func TestHTTPClient_NetworkError(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "request failed")
+	assert.ErrorIs(t, err, ErrConnection)
+}
+
+func TestHTTPClient_CertificateError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The self-signed certificate of the server is not trusted
+	logger := testutils.NewTestLogger()
+	client := NewHTTPClient(&http.Client{}, server.URL, logger)
+
+	var result map[string]interface{}
+	err := client.GetWithRetry(context.Background(), "/test", &result, 3)
+
+	var certErr *tls.CertificateVerificationError
+
+	require.ErrorAs(t, err, &certErr)
+	assert.NotErrorIs(t, err, ErrConnection)
+
+	for _, message := range logger.DebugMessages {
+		assert.NotContains(t, message, "will retry")
+	}
+
+	authManager := NewAuthManagerWithPassword(client, "user", "pass", logger)
+	_, err = authManager.authenticate(context.Background(), false)
+	require.ErrorAs(t, err, &certErr)
+	assert.NotErrorIs(t, err, ErrConnection)
+	assert.NotErrorIs(t, err, ErrAuthFailed)
+}
+
+func TestHTTPClient_ConnectionLost(t *testing.T) {
+	// truncated sends half of the promised body and drops the connection
+	truncated := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":`))
+		w.(http.Flusher).Flush()
+
+		panic(http.ErrAbortHandler)
+	}
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		timeout    time.Duration
+		ctxTimeout time.Duration
+		cancel     bool
+		connection bool
+	}{
+		{name: "mid-body EOF", handler: truncated, connection: true},
+		{name: "client timeout", handler: slow, timeout: 50 * time.Millisecond, connection: true},
+		{name: "context deadline", handler: slow, ctxTimeout: 50 * time.Millisecond, connection: true},
+		{name: "cancelled by the caller", handler: slow, cancel: true, connection: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := NewHTTPClient(&http.Client{Timeout: tt.timeout}, server.URL, testutils.NewTestLogger())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tt.ctxTimeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			if tt.cancel {
+				time.AfterFunc(50*time.Millisecond, cancel)
+			}
+
+			var result map[string]interface{}
+			err := client.Get(ctx, "/test", &result)
+
+			require.Error(t, err)
+
+			if tt.connection {
+				assert.ErrorIs(t, err, ErrConnection)
+			} else {
+				assert.NotErrorIs(t, err, ErrConnection)
+			}
+		})
+	}
 }
 
 func TestHTTPClient_ContextCancellation(t *testing.T) {
@@ -460,35 +591,42 @@ func TestHTTPClient_shouldRetry(t *testing.T) {
 	}{
 		{
 			name:        "connection error should retry",
-			err:         fmt.Errorf("connection refused"),
+			err:         fmt.Errorf("%w: dial tcp: connection refused", ErrConnection),
 			attempt:     1,
 			maxRetries:  3,
 			shouldRetry: true,
 		},
 		{
 			name:        "timeout error should retry",
-			err:         fmt.Errorf("timeout exceeded"),
+			err:         fmt.Errorf("failed to read response body: %w", os.ErrDeadlineExceeded),
 			attempt:     1,
 			maxRetries:  3,
 			shouldRetry: true,
 		},
 		{
 			name:        "5xx error should retry",
-			err:         fmt.Errorf("API request failed with status 500"),
+			err:         newAPIError(http.StatusInternalServerError, "500 Internal Server Error", ""),
 			attempt:     1,
 			maxRetries:  3,
 			shouldRetry: true,
 		},
 		{
 			name:        "4xx error should not retry",
-			err:         fmt.Errorf("API request failed with status 400"),
+			err:         newAPIError(http.StatusBadRequest, "400 Bad Request", ""),
+			attempt:     1,
+			maxRetries:  3,
+			shouldRetry: false,
+		},
+		{
+			name:        "authentication error should not retry",
+			err:         fmt.Errorf("%w: 401 Unauthorized", ErrAuthFailed),
 			attempt:     1,
 			maxRetries:  3,
 			shouldRetry: false,
 		},
 		{
 			name:        "max retries reached should not retry",
-			err:         fmt.Errorf("connection refused"),
+			err:         fmt.Errorf("%w: dial tcp: connection refused", ErrConnection),
 			attempt:     3,
 			maxRetries:  3,
 			shouldRetry: false,
@@ -523,7 +661,8 @@ func TestHTTPClient_AuthenticationError(t *testing.T) {
 	err := client.Get(context.Background(), "/test", &result)
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "authentication failed")
+	assert.ErrorIs(t, err, ErrConnection)
+	assert.NotErrorIs(t, err, ErrAuthFailed)
 }
 
 func TestHTTPClient_MarshalError(t *testing.T) {
@@ -601,7 +740,7 @@ func BenchmarkHTTPClient_Get(b *testing.B) {
 
 func BenchmarkHTTPClient_shouldRetry(b *testing.B) {
 	client := NewHTTPClient(&http.Client{}, "https://test.example.com", testutils.NewTestLogger())
-	err := fmt.Errorf("connection refused")
+	err := fmt.Errorf("%w: dial tcp: connection refused", ErrConnection)
 
 	b.ResetTimer()
 
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"strings"
+)
+
+// ParseNeighbors parses the output of `ip neigh show` on a node into the
+// IP address last seen for every MAC address, keyed by the upper-case MAC.
+// IPv4 addresses are preferred, and link-local IPv6 addresses and entries
+// without a MAC (FAILED, INCOMPLETE) are skipped.
+//
+// Example line: "192.0.2.50 dev vmbr0 lladdr bc:24:11:aa:bb:cc REACHABLE".
+func ParseNeighbors(output []byte) map[string]string {
+	neighbors := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		ip := net.ParseIP(fields[0])
+		if ip == nil || ip.IsLinkLocalUnicast() || ip.IsLoopback() {
+			continue
+		}
+
+		var mac string
+
+		for i := 1; i < len(fields)-1; i++ {
+			if fields[i] == "lladdr" {
+				mac = strings.ToUpper(fields[i+1])
+
+				break
+			}
+		}
+
+		if mac == "" || fields[len(fields)-1] == "FAILED" {
+			continue
+		}
+
+		// Keep an IPv4 address over an IPv6 one
+		if existing, ok := neighbors[mac]; ok && net.ParseIP(existing).To4() != nil && ip.To4() == nil {
+			continue
+		}
+
+		neighbors[mac] = ip.String()
+	}
+
+	return neighbors
+}
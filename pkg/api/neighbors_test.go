@@ -0,0 +1,24 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNeighbors(t *testing.T) {
+	output := `192.0.2.1 dev vmbr0 lladdr 00:11:22:33:44:55 REACHABLE
+2001:db8::50 dev vmbr0 lladdr bc:24:11:aa:bb:cc STALE
+192.0.2.50 dev vmbr0 lladdr bc:24:11:aa:bb:cc STALE
+fe80::be24:11ff:fedd:eeff dev vmbr0 lladdr bc:24:11:dd:ee:ff router STALE
+2001:db8::60 dev vmbr0 lladdr bc:24:11:dd:ee:ff DELAY
+192.0.2.99 dev vmbr0  FAILED
+192.0.2.98 dev vmbr0 lladdr bc:24:11:00:00:01 FAILED
+`
+
+	assert.Equal(t, map[string]string{
+		"00:11:22:33:44:55": "192.0.2.1",
+		"BC:24:11:AA:BB:CC": "192.0.2.50",
+		"BC:24:11:DD:EE:FF": "2001:db8::60",
+	}, ParseNeighbors([]byte(output)))
+}
@@ -161,11 +161,16 @@ func (c *Client) GetLxcInterfaces(vm *VM) ([]NetworkInterface, error) {
 		return nil, fmt.Errorf("network interface endpoint not applicable for this guest type or status")
 	}
 
+	// Older releases answer 501 for every container on every refresh
+	if !c.Supports(FeatureContainerInterfaces) {
+		return nil, nil
+	}
+
 	var apiResponse map[string]interface{}
 
 	endpoint := fmt.Sprintf("/nodes/%s/lxc/%d/interfaces", vm.Node, vm.ID)
 
-	if err := c.GetWithCache(endpoint, &apiResponse, VMDataTTL); err != nil {
+	if err := c.GetWithCache(endpoint, &apiResponse, c.CacheTTLs().Agent); err != nil {
 		// Based on previous handling, API might return 500 if feature not available or container stopped.
 		// Treat this as "no interfaces found" rather than a hard error for GetVmStatus.
 		c.logger.Debug("Failed to get LXC interfaces for VM %d on node %s (may be expected): %v", vm.ID, vm.Node, err)
@@ -39,6 +39,12 @@ type Node struct {
 	VMs           []*VM      `json:"vms,omitempty"`
 	CPUInfo       *CPUInfo   `json:"cpuinfo,omitempty"`
 	LoadAvg       []string   `json:"loadavg,omitempty"`
+	// Temperatures are only reported by nodes with a sensors API extension.
+	Temperatures []Temperature `json:"temperatures,omitempty"`
+	Description  string        `json:"description,omitempty"` // Node notes
+	// DetailsForbidden is set when the node status (version, kernel, CPU
+	// model, load) could not be read for lack of Sys.Audit on the node.
+	DetailsForbidden bool `json:"-"`
 
 	// For metrics tracking and concurrency
 	// mu                sync.RWMutex `json:"-"`
@@ -72,7 +78,7 @@ func (c *Client) ListNodes() ([]Node, error) {
 func (c *Client) GetNodeStatus(nodeName string) (*Node, error) {
 	var res map[string]interface{}
 
-	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/status", nodeName), &res, NodeDataTTL); err != nil {
+	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/status", nodeName), &res, c.CacheTTLs().Status); err != nil {
 		return nil, fmt.Errorf("failed to get status for node %s: %w", nodeName, err)
 	}
 
@@ -152,24 +158,36 @@ func (c *Client) GetNodeStatus(nodeName string) (*Node, error) {
 		}
 	}
 
+	// Temperatures added by sensor extensions as `sensors -j` output
+	if output := getString(data, "sensorsOutput"); output != "" {
+		if temps, err := ParseSensorsJSON([]byte(output)); err == nil {
+			node.Temperatures = temps
+		}
+	}
+
 	// Fallback to version endpoint if pveversion not in status
 	if node.Version == "" {
 		var versionRes map[string]interface{}
 
-		if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/version", nodeName), &versionRes, NodeDataTTL); err == nil {
+		if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/version", nodeName), &versionRes, c.CacheTTLs().Config); err == nil {
 			if versionData, ok := versionRes["data"].(map[string]interface{}); ok {
 				node.Version = getString(versionData, "version")
 			}
 		}
 	}
 
+	// Notes are part of the node config
+	if config, err := c.GetNodeConfig(nodeName); err == nil {
+		node.Description = getString(config, "description")
+	}
+
 	return node, nil
 }
 
 // GetNodeConfig retrieves configuration for a given node with caching.
 func (c *Client) GetNodeConfig(nodeName string) (map[string]interface{}, error) {
 	var res map[string]interface{}
-	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/config", nodeName), &res, NodeDataTTL); err != nil {
+	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/config", nodeName), &res, c.CacheTTLs().Config); err != nil {
 		return nil, fmt.Errorf("failed to get node config: %w", err)
 	}
 
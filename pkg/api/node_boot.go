@@ -0,0 +1,147 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Boot modes reported in a node's boot-info.
+const (
+	BootModeEFI    = "efi"
+	BootModeLegacy = "legacy-bios"
+)
+
+// kernelPackage matches the packages of installed kernel images, e.g.
+// "proxmox-kernel-6.8.12-4-pve-signed" or "pve-kernel-5.15.143-1-pve".
+var kernelPackage = regexp.MustCompile(`^(?:proxmox|pve)-kernel-(\d+\.\d+\.\d+-\d+-pve)(?:-signed)?$`)
+
+// NodeBootInfo describes the boot environment and kernel of a node.
+type NodeBootInfo struct {
+	// Mode is BootModeEFI or BootModeLegacy, empty when unknown.
+	Mode       string
+	SecureBoot bool
+	// RunningKernel is the release of the booted kernel, e.g. "6.8.12-4-pve".
+	RunningKernel string
+	// InstalledKernel is the newest installed kernel release.
+	InstalledKernel string
+	// ZFSVersion and CephVersion are the installed package versions, empty
+	// when the package is not installed.
+	ZFSVersion  string
+	CephVersion string
+}
+
+// RebootRequired reports whether a newer kernel is installed than the one
+// running.
+func (b *NodeBootInfo) RebootRequired() bool {
+	return b.RunningKernel != "" && b.InstalledKernel != "" &&
+		CompareKernelReleases(b.InstalledKernel, b.RunningKernel) > 0
+}
+
+// CompareKernelReleases compares two kernel releases such as "6.8.12-4-pve"
+// number by number and returns -1, 0 or 1.
+func CompareKernelReleases(a, b string) int {
+	split := func(release string) []int {
+		var numbers []int
+
+		for _, field := range strings.FieldsFunc(release, func(r rune) bool { return r < '0' || r > '9' }) {
+			n, _ := strconv.Atoi(field)
+			numbers = append(numbers, n)
+		}
+
+		return numbers
+	}
+
+	x, y := split(a), split(b)
+
+	for i := 0; i < len(x) || i < len(y); i++ {
+		var m, n int
+		if i < len(x) {
+			m = x[i]
+		}
+
+		if i < len(y) {
+			n = y[i]
+		}
+
+		switch {
+		case m < n:
+			return -1
+		case m > n:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// GetNodeBootInfo reads the boot mode and running kernel from the node
+// status and the installed kernel, ZFS and Ceph versions from the node's
+// package list.
+func (c *Client) GetNodeBootInfo(nodeName string) (*NodeBootInfo, error) {
+	var status map[string]interface{}
+	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/status", nodeName), &status, c.CacheTTLs().Status); err != nil {
+		return nil, fmt.Errorf("failed to get status for node %s: %w", nodeName, err)
+	}
+
+	data, ok := status["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid status response format for node %s", nodeName)
+	}
+
+	info := &NodeBootInfo{}
+
+	if boot, ok := data["boot-info"].(map[string]interface{}); ok {
+		info.Mode = getString(boot, "mode")
+		info.SecureBoot = getFloat(boot, "secureboot") == 1
+	}
+
+	if kernel, ok := data["current-kernel"].(map[string]interface{}); ok {
+		info.RunningKernel = getString(kernel, "release")
+	}
+
+	// Older releases only report "Linux 6.5.11-7-pve #1 SMP ..."
+	if info.RunningKernel == "" {
+		if fields := strings.Fields(getString(data, "kversion")); len(fields) > 1 {
+			info.RunningKernel = fields[1]
+		}
+	}
+
+	var versions map[string]interface{}
+	if err := c.GetWithCache(fmt.Sprintf("/nodes/%s/apt/versions", nodeName), &versions, c.CacheTTLs().Config); err != nil {
+		return nil, fmt.Errorf("failed to get package versions for node %s: %w", nodeName, err)
+	}
+
+	packages, ok := versions["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for package versions")
+	}
+
+	for _, item := range packages {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if state := getString(entry, "CurrentState"); state != "" && state != "Installed" {
+			continue
+		}
+
+		name := getString(entry, "Package")
+
+		switch {
+		case name == "zfsutils-linux":
+			info.ZFSVersion = getString(entry, "Version")
+		case name == "ceph":
+			info.CephVersion = getString(entry, "Version")
+		default:
+			if match := kernelPackage.FindStringSubmatch(name); match != nil &&
+				(info.InstalledKernel == "" || CompareKernelReleases(match[1], info.InstalledKernel) > 0) {
+				info.InstalledKernel = match[1]
+			}
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+func TestCompareKernelReleases(t *testing.T) {
+	assert.Equal(t, 1, CompareKernelReleases("6.8.12-5-pve", "6.8.12-4-pve"))
+	assert.Equal(t, 1, CompareKernelReleases("6.11.0-1-pve", "6.8.12-4-pve"))
+	assert.Equal(t, -1, CompareKernelReleases("6.5.13-6-pve", "6.8.4-2-pve"))
+	assert.Equal(t, 0, CompareKernelReleases("6.8.12-4-pve", "6.8.12-4-pve"))
+}
+
+func TestClient_GetNodeBootInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"boot-info":      map[string]interface{}{"mode": "efi", "secureboot": 1},
+				"current-kernel": map[string]interface{}{"release": "6.8.12-4-pve"},
+				"kversion":       "Linux 6.8.12-4-pve #1 SMP PREEMPT_DYNAMIC PMX 6.8.12-4",
+			}})
+		case "/nodes/pve1/apt/versions":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"Package": "proxmox-kernel-6.8", "Version": "6.8.12-5", "CurrentState": "Installed"},
+				map[string]interface{}{"Package": "proxmox-kernel-6.8.12-4-pve-signed", "Version": "6.8.12-4", "CurrentState": "Installed"},
+				map[string]interface{}{"Package": "proxmox-kernel-6.8.12-5-pve-signed", "Version": "6.8.12-5", "CurrentState": "Installed"},
+				map[string]interface{}{"Package": "pve-kernel-6.2.16-20-pve", "Version": "6.2.16-20", "CurrentState": "ConfigFiles"},
+				map[string]interface{}{"Package": "zfsutils-linux", "Version": "2.2.6-pve1", "CurrentState": "Installed"},
+				map[string]interface{}{"Package": "ceph-fuse", "Version": "17.2.7-pve3", "CurrentState": "Installed"},
+			}})
+		case "/nodes/pve2/status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"kversion": "Linux 6.5.11-7-pve #1 SMP PREEMPT_DYNAMIC PMX 6.5.11-7",
+			}})
+		case "/nodes/pve2/apt/versions":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"Package": "proxmox-kernel-6.5.11-7-pve-signed", "Version": "6.5.11-7", "CurrentState": "Installed"},
+				map[string]interface{}{"Package": "ceph", "Version": "18.2.4-pve3", "CurrentState": "Installed"},
+			}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	client.cache = testutils.NewInMemoryCache()
+
+	info, err := client.GetNodeBootInfo("pve1")
+	require.NoError(t, err)
+	assert.Equal(t, &NodeBootInfo{
+		Mode:            BootModeEFI,
+		SecureBoot:      true,
+		RunningKernel:   "6.8.12-4-pve",
+		InstalledKernel: "6.8.12-5-pve",
+		ZFSVersion:      "2.2.6-pve1",
+	}, info)
+	assert.True(t, info.RebootRequired())
+
+	info, err = client.GetNodeBootInfo("pve2")
+	require.NoError(t, err)
+	assert.Equal(t, "6.5.11-7-pve", info.RunningKernel, "falls back to kversion")
+	assert.Empty(t, info.Mode)
+	assert.Equal(t, "18.2.4-pve3", info.CephVersion)
+	assert.False(t, info.RebootRequired())
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// NodeTime is the clock of a node as read through the API.
+type NodeTime struct {
+	Node     string
+	Time     time.Time
+	Timezone string
+	// Offset is how far the node's clock is ahead of the local clock,
+	// estimated against the middle of the request.
+	Offset time.Duration
+	// RoundTrip is the duration of the request, which bounds the accuracy
+	// of Offset.
+	RoundTrip time.Duration
+}
+
+// GetNodeTime reads the clock of a node.
+func (c *Client) GetNodeTime(node string) (*NodeTime, error) {
+	var res map[string]interface{}
+
+	start := time.Now()
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/time", node), &res); err != nil {
+		return nil, fmt.Errorf("failed to get time of node %s: %w", node, err)
+	}
+
+	roundTrip := time.Since(start)
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok || getInt(data, "time") == 0 {
+		return nil, fmt.Errorf("no time reported by node %s", node)
+	}
+
+	// The node reports whole seconds, so its clock is half a second ahead
+	// of the reported time on average
+	nodeTime := time.Unix(int64(getInt(data, "time")), 0)
+	reference := start.Add(roundTrip / 2)
+
+	return &NodeTime{
+		Node:      node,
+		Time:      nodeTime,
+		Timezone:  getString(data, "timezone"),
+		Offset:    nodeTime.Add(500 * time.Millisecond).Sub(reference),
+		RoundTrip: roundTrip,
+	}, nil
+}
+
+// GetNodeTimes reads the clocks of the given nodes, skipping nodes that
+// fail. An error is only returned if no node could be read.
+func (c *Client) GetNodeTimes(nodes []string) (map[string]NodeTime, error) {
+	times := make(map[string]NodeTime, len(nodes))
+
+	var lastErr error
+
+	for _, node := range nodes {
+		nodeTime, err := c.GetNodeTime(node)
+		if err != nil {
+			c.logger.Debug("Skipping clock of node %s: %v", node, err)
+			lastErr = err
+
+			continue
+		}
+
+		times[node] = *nodeTime
+	}
+
+	if len(times) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return times, nil
+}
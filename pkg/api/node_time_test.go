@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetNodeTimes(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+
+		var data map[string]interface{}
+
+		switch r.URL.Path {
+		case "/nodes/pve1/time":
+			data = map[string]interface{}{"time": now.Unix(), "localtime": now.Unix() + 7200, "timezone": "Europe/Berlin"}
+		case "/nodes/pve2/time":
+			data = map[string]interface{}{"time": now.Add(30 * time.Second).Unix(), "timezone": "UTC"}
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	})
+
+	times, err := client.GetNodeTimes([]string{"pve1", "pve2", "pve3"})
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+
+	assert.Equal(t, "Europe/Berlin", times["pve1"].Timezone)
+	assert.InDelta(t, 0, times["pve1"].Offset.Seconds(), 1)
+	assert.InDelta(t, 30, times["pve2"].Offset.Seconds(), 1)
+
+	_, err = client.GetNodeTimes([]string{"pve3"})
+	assert.Error(t, err)
+}
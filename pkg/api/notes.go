@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpdateNodeDescription sets the notes of a node. An empty description
+// removes the notes.
+func (c *Client) UpdateNodeDescription(nodeName, description string) error {
+	path := fmt.Sprintf("/nodes/%s/config", nodeName)
+
+	if err := c.httpClient.Put(c.requestContext(), path, descriptionPayload(description), nil); err != nil {
+		return fmt.Errorf("failed to update node notes: %w", err)
+	}
+
+	c.deleteCachedPath(path)
+
+	return nil
+}
+
+// UpdateVMDescription sets the notes of a VM or container. An empty
+// description removes the notes.
+func (c *Client) UpdateVMDescription(vm *VM, description string) error {
+	path := fmt.Sprintf("/nodes/%s/%s/%d/config", vm.Node, vm.Type, vm.ID)
+
+	if err := c.httpClient.Put(c.requestContext(), path, descriptionPayload(description), nil); err != nil {
+		return fmt.Errorf("failed to update guest notes: %w", err)
+	}
+
+	c.deleteCachedPath(path)
+
+	return nil
+}
+
+// descriptionPayload builds the config update setting or deleting the description.
+func descriptionPayload(description string) map[string]interface{} {
+	if strings.TrimSpace(description) == "" {
+		return map[string]interface{}{"delete": "description"}
+	}
+
+	return map[string]interface{}{"description": description}
+}
+
+// deleteCachedPath removes the cached response of a GET request for path.
+func (c *Client) deleteCachedPath(path string) {
+	if c.cache == nil {
+		return
+	}
+
+	cacheKey := strings.ReplaceAll(fmt.Sprintf("proxmox_api_%s_%s", c.baseURL, path), "/", "_")
+	_ = c.cache.Delete(cacheKey)
+}
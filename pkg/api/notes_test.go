@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateDescription(t *testing.T) {
+	var recorder requestRecorder
+
+	client := newTestClient(t, recorder.handler(t, http.MethodPut))
+
+	require.NoError(t, client.UpdateVMDescription(&VM{ID: 100, Node: "pve1", Type: VMTypeQemu}, "owner: alice"))
+	require.NoError(t, client.UpdateNodeDescription("pve1", "  \n"))
+
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "/nodes/pve1/qemu/100/config", "description": "owner: alice"},
+		{"path": "/nodes/pve1/config", "delete": "description"},
+	}, recorder.requests)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/devnullvoid/pvetui/pkg/api/interfaces"
+)
+
+// RealmTypeOpenID is the type of OpenID Connect realms.
+const RealmTypeOpenID = "openid"
+
+// Realm is an authentication realm (domain) users can log in with, e.g.
+// "pam" or an OpenID Connect provider.
+type Realm struct {
+	Realm   string `json:"realm"`
+	Type    string `json:"type"` // pam, pve, ldap, ad or openid
+	Comment string `json:"comment,omitempty"`
+	Default bool   `json:"default,omitempty"`
+	// TFA is the two-factor method the realm enforces, if any.
+	TFA string `json:"tfa,omitempty"`
+}
+
+// IsOpenID reports whether users of the realm log in through an OpenID
+// Connect provider.
+func (r Realm) IsOpenID() bool {
+	return r.Type == RealmTypeOpenID
+}
+
+// OpenIDAuthorizer lets the user log in to the identity provider at authURL,
+// usually in a browser, and returns the query of the redirect back to the
+// redirect URL, which holds the code and state of the login.
+type OpenIDAuthorizer func(ctx context.Context, authURL string) (url.Values, error)
+
+// openIDSettings configures the OpenID Connect login of an AuthManager.
+type openIDSettings struct {
+	realm       string
+	redirectURL string
+	authorize   OpenIDAuthorizer
+}
+
+// loginOpenID logs in through the OpenID realm: it asks the cluster for the
+// login URL of the identity provider, lets the user log in there, and trades
+// the returned code for a ticket.
+func (am *AuthManager) loginOpenID(ctx context.Context) (*ticketData, error) {
+	am.logger.Debug("Authenticating with OpenID realm: %s", am.openID.realm)
+
+	formData := url.Values{}
+	formData.Set("realm", am.openID.realm)
+	formData.Set("redirect-url", am.openID.redirectURL)
+
+	var authURLResponse struct {
+		Data string `json:"data"`
+	}
+
+	if err := am.postForm(ctx, EndpointOpenIDAuthURL, formData, &authURLResponse); err != nil {
+		return nil, fmt.Errorf("failed to start OpenID login: %w", err)
+	}
+
+	if authURLResponse.Data == "" {
+		return nil, fmt.Errorf("failed to start OpenID login: no login URL received")
+	}
+
+	if am.openID.authorize == nil {
+		return nil, fmt.Errorf("OpenID login failed: no way to open the login page")
+	}
+
+	callback, err := am.openID.authorize(ctx, authURLResponse.Data)
+	if err != nil {
+		return nil, fmt.Errorf("OpenID login failed: %w", err)
+	}
+
+	if providerErr := callback.Get("error"); providerErr != "" {
+		return nil, fmt.Errorf("OpenID login failed: %s %s", providerErr, callback.Get("error_description"))
+	}
+
+	formData = url.Values{}
+	formData.Set("code", callback.Get("code"))
+	formData.Set("state", callback.Get("state"))
+	formData.Set("redirect-url", am.openID.redirectURL)
+
+	var loginResponse struct {
+		Data ticketData `json:"data"`
+	}
+
+	if err := am.postForm(ctx, EndpointOpenIDLogin, formData, &loginResponse); err != nil {
+		return nil, fmt.Errorf("OpenID login failed: %w", err)
+	}
+
+	if loginResponse.Data.Ticket == "" {
+		return nil, fmt.Errorf("OpenID login failed: no ticket received")
+	}
+
+	// The user name is assigned by the identity provider
+	am.username = loginResponse.Data.Username
+
+	return &loginResponse.Data, nil
+}
+
+// SetOpenIDAuthorizer replaces how the identity provider login page of
+// OpenID logins is opened. It has no effect on other logins.
+func (am *AuthManager) SetOpenIDAuthorizer(authorize OpenIDAuthorizer) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if am.openID != nil {
+		am.openID.authorize = authorize
+	}
+}
+
+// ListRealms lists the realms users can log in with, the default realm
+// first. It needs no credentials, so it works before a profile is complete.
+func ListRealms(config interfaces.Config, options ...ClientOption) ([]Realm, error) {
+	opts := defaultOptions()
+	for _, option := range options {
+		option(opts)
+	}
+
+	httpClient, serverBaseURL, err := newServerHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an auth manager the request is sent unauthenticated
+	client := NewHTTPClient(httpClient, serverBaseURL+"/api2/json", opts.Logger)
+
+	var res map[string]interface{}
+	if err := client.Get(context.Background(), EndpointAccessDomains, &res); err != nil {
+		return nil, fmt.Errorf("failed to get realms: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for realms")
+	}
+
+	realms := make([]Realm, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		realms = append(realms, Realm{
+			Realm:   getString(entry, "realm"),
+			Type:    getString(entry, "type"),
+			Comment: getString(entry, "comment"),
+			Default: getBool(entry, "default"),
+			TFA:     getString(entry, "tfa"),
+		})
+	}
+
+	sort.Slice(realms, func(i, j int) bool {
+		if realms[i].Default != realms[j].Default {
+			return realms[i].Default
+		}
+
+		return realms[i].Realm < realms[j].Realm
+	})
+
+	return realms, nil
+}
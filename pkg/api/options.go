@@ -1,13 +1,36 @@
 package api
 
 import (
+	"time"
+
 	"github.com/devnullvoid/pvetui/pkg/api/interfaces"
 )
 
+// PhaseObserver is told about each finished phase of an initial cluster
+// load (see FastGetClusterStatus) with the time the phase began.
+type PhaseObserver func(phase string, start time.Time)
+
 // ClientOptions holds optional dependencies for the API client.
 type ClientOptions struct {
 	Logger interfaces.Logger
 	Cache  interfaces.Cache
+	// LazyEnrichment skips enriching all guests when loading the cluster;
+	// callers enrich the guests they show with EnrichVM.
+	LazyEnrichment bool
+	// PhaseObserver receives the durations of the initial cluster load phases.
+	PhaseObserver PhaseObserver
+	// TFAPrompt asks for the TOTP code when a password login requires
+	// two-factor authentication.
+	TFAPrompt TFAPrompt
+	// OpenIDRedirectURL and OpenIDAuthorizer log in through the OpenID
+	// Connect realm of the config instead of with a password.
+	OpenIDRedirectURL string
+	OpenIDAuthorizer  OpenIDAuthorizer
+	// CacheTTLs are how long each kind of data is cached.
+	CacheTTLs CacheTTLs
+	// SchemaDriftHandler is told about responses that don't match their
+	// schema, in addition to logging them.
+	SchemaDriftHandler SchemaDriftHandler
 }
 
 // ClientOption is a function that configures ClientOptions.
@@ -27,6 +50,57 @@ func WithCache(cache interfaces.Cache) ClientOption {
 	}
 }
 
+// WithCacheTTLs sets how long status, configuration and guest agent data
+// are cached, e.g. a status TTL of seconds with agent data kept for minutes.
+// Zero durations keep their DefaultCacheTTLs.
+func WithCacheTTLs(ttls CacheTTLs) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.CacheTTLs = ttls
+	}
+}
+
+// WithLazyEnrichment makes cluster loads skip guest enrichment, leaving it
+// to EnrichVM for the guests that are actually shown.
+func WithLazyEnrichment(lazy bool) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.LazyEnrichment = lazy
+	}
+}
+
+// WithPhaseObserver reports how long the phases of the initial cluster load
+// take, e.g. to profile a slow startup.
+func WithPhaseObserver(observer PhaseObserver) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.PhaseObserver = observer
+	}
+}
+
+// WithTFAPrompt sets how the TOTP code of two-factor logins is asked for.
+func WithTFAPrompt(prompt TFAPrompt) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.TFAPrompt = prompt
+	}
+}
+
+// WithOpenID logs in through the OpenID Connect realm of the config. The
+// identity provider redirects to redirectURL after the login, where
+// authorize has to pick up the code.
+func WithOpenID(redirectURL string, authorize OpenIDAuthorizer) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.OpenIDRedirectURL = redirectURL
+		opts.OpenIDAuthorizer = authorize
+	}
+}
+
+// WithSchemaDriftHandler passes the fields of responses that are missing or
+// have an unexpected type (see ResponseSchemas) to handler, e.g. to alert
+// about changes of a new Proxmox release. Drift is logged without it too.
+func WithSchemaDriftHandler(handler SchemaDriftHandler) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.SchemaDriftHandler = handler
+	}
+}
+
 // defaultOptions returns ClientOptions with sensible defaults.
 func defaultOptions() *ClientOptions {
 	return &ClientOptions{
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// queryStepKind is what a step of a query does to the value it is applied to.
+type queryStepKind int
+
+const (
+	queryField   queryStepKind = iota // Member of an object
+	queryIndex                        // Element of a list
+	queryProject                      // Every element of a list
+	queryFilter                       // Elements of a list matching a comparison
+)
+
+// queryStep is a parsed step of a query.
+type queryStep struct {
+	kind  queryStepKind
+	field string
+	index int
+	// Filters compare the value at path with value using op.
+	path  []queryStep
+	op    string
+	value interface{}
+}
+
+var (
+	queryIdentPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]+`)
+	queryFilterPattern = regexp.MustCompile(`^\?\s*([A-Za-z0-9_.-]+)\s*(==|!=|<=|>=|<|>)\s*(.+?)\s*$`)
+)
+
+// QueryJSON selects parts of a decoded JSON value with a subset of JMESPath:
+//
+//	data.version        member of an object
+//	data[0], data[-1]   element of a list, negative from the end
+//	data[*].name        member of every element of a list
+//	data[?status=='running'].vmid
+//	                    elements whose member compares with a literal
+//
+// Filters support ==, !=, <, <=, > and >= with quoted strings, numbers,
+// true, false and null. Unlike JMESPath, equality also matches a number
+// given as a string, since Proxmox returns some numbers as strings. Steps
+// after [*] or a filter apply to every element, dropping those they select
+// nothing from. An empty query or @ returns value unchanged; a query that
+// selects nothing returns nil.
+func QueryJSON(value interface{}, query string) (interface{}, error) {
+	steps, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return evalQuery(value, steps), nil
+}
+
+// parseQuery parses a query into its steps.
+func parseQuery(query string) ([]queryStep, error) {
+	rest := strings.TrimSpace(query)
+	if rest == "@" {
+		return nil, nil
+	}
+
+	var steps []queryStep
+
+	for rest != "" {
+		switch {
+		case rest[0] == '.' && len(steps) > 0:
+			ident := queryIdentPattern.FindString(rest[1:])
+			if ident == "" {
+				return nil, fmt.Errorf("expected a member name after . in %q", query)
+			}
+
+			steps = append(steps, queryStep{kind: queryField, field: ident})
+			rest = rest[1+len(ident):]
+		case rest[0] == '[':
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] in %q", query)
+			}
+
+			step, err := parseBracket(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, step)
+			rest = rest[end+1:]
+		case len(steps) == 0 && queryIdentPattern.MatchString(rest):
+			ident := queryIdentPattern.FindString(rest)
+			steps = append(steps, queryStep{kind: queryField, field: ident})
+			rest = rest[len(ident):]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest, query)
+		}
+	}
+
+	return steps, nil
+}
+
+// closingBracket returns the index of the ] closing the [ that s starts
+// with, skipping quoted strings, or -1 if there is none.
+func closingBracket(s string) int {
+	var quote byte
+
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseBracket parses the content of a bracket step: *, an index or a
+// filter.
+func parseBracket(content string) (queryStep, error) {
+	if content == "*" {
+		return queryStep{kind: queryProject}, nil
+	}
+
+	if index, err := strconv.Atoi(content); err == nil {
+		return queryStep{kind: queryIndex, index: index}, nil
+	}
+
+	match := queryFilterPattern.FindStringSubmatch(content)
+	if match == nil {
+		return queryStep{}, fmt.Errorf("invalid [%s]: expected *, an index or a filter like [?name=='value']", content)
+	}
+
+	path, err := parseQuery(match[1])
+	if err != nil {
+		return queryStep{}, err
+	}
+
+	value, err := parseQueryLiteral(match[3])
+	if err != nil {
+		return queryStep{}, err
+	}
+
+	return queryStep{kind: queryFilter, path: path, op: match[2], value: value}, nil
+}
+
+// parseQueryLiteral parses the literal of a filter.
+func parseQueryLiteral(literal string) (interface{}, error) {
+	if len(literal) >= 2 && (literal[0] == '\'' || literal[0] == '"') && literal[len(literal)-1] == literal[0] {
+		return literal[1 : len(literal)-1], nil
+	}
+
+	switch literal {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	number, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid literal %s: quote strings, e.g. '%s'", literal, literal)
+	}
+
+	return number, nil
+}
+
+// evalQuery applies steps to value.
+func evalQuery(value interface{}, steps []queryStep) interface{} {
+	if len(steps) == 0 {
+		return value
+	}
+
+	step, rest := steps[0], steps[1:]
+
+	switch step.kind {
+	case queryField:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		return evalQuery(object[step.field], rest)
+	case queryIndex:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		index := step.index
+		if index < 0 {
+			index += len(list)
+		}
+
+		if index < 0 || index >= len(list) {
+			return nil
+		}
+
+		return evalQuery(list[index], rest)
+	default:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		results := []interface{}{}
+
+		for _, element := range list {
+			if step.kind == queryFilter && !compareQueryValues(evalQuery(element, step.path), step.op, step.value) {
+				continue
+			}
+
+			if result := evalQuery(element, rest); result != nil {
+				results = append(results, result)
+			}
+		}
+
+		return results
+	}
+}
+
+// compareQueryValues compares a selected value with the literal of a
+// filter. Numbers given as strings compare as numbers; values that can't be
+// ordered match no ordering comparison.
+func compareQueryValues(value interface{}, op string, literal interface{}) bool {
+	if number, ok := literal.(float64); ok {
+		if text, ok := value.(string); ok {
+			if parsed, err := strconv.ParseFloat(text, 64); err == nil {
+				value = parsed
+			}
+		}
+
+		if actual, ok := value.(float64); ok {
+			switch op {
+			case "==":
+				return actual == number
+			case "!=":
+				return actual != number
+			case "<":
+				return actual < number
+			case "<=":
+				return actual <= number
+			case ">":
+				return actual > number
+			case ">=":
+				return actual >= number
+			}
+		}
+	}
+
+	switch op {
+	case "==":
+		return queryEqual(value, literal)
+	case "!=":
+		return !queryEqual(value, literal)
+	}
+
+	actual, ok := value.(string)
+	text, isText := literal.(string)
+
+	if !ok || !isText {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return actual < text
+	case "<=":
+		return actual <= text
+	case ">":
+		return actual > text
+	default:
+		return actual >= text
+	}
+}
+
+// queryEqual compares values of the same type directly and numbers with
+// their string form.
+func queryEqual(value, literal interface{}) bool {
+	if value == nil || literal == nil {
+		return value == nil && literal == nil
+	}
+
+	switch value.(type) {
+	case string, float64, bool:
+		if value == literal {
+			return true
+		}
+	default:
+		return false
+	}
+
+	if number, ok := value.(float64); ok {
+		if text, ok := literal.(string); ok {
+			return strconv.FormatFloat(number, 'f', -1, 64) == text
+		}
+	}
+
+	return false
+}
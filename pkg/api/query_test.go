@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryJSON(t *testing.T) {
+	var response interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"data": [
+		{"vmid": 100, "name": "db", "status": "running", "tags": "prod", "maxmem": "4096"},
+		{"vmid": 101, "name": "web", "status": "stopped", "maxmem": "2048"},
+		{"vmid": 102, "name": "cache", "status": "running", "tags": "prod;cache", "maxmem": "1024"}
+	]}`), &response))
+
+	tests := []struct {
+		query string
+		want  interface{}
+	}{
+		{"", response},
+		{"@", response},
+		{"data[0].name", "db"},
+		{"data[-1].vmid", float64(102)},
+		{"data[5]", nil},
+		{"data.name", nil},
+		{"data[*].name", []interface{}{"db", "web", "cache"}},
+		{"data[*].tags", []interface{}{"prod", "prod;cache"}},
+		{"data[?status=='running'].vmid", []interface{}{float64(100), float64(102)}},
+		{`data[?status != "running"].name`, []interface{}{"web"}},
+		{"data[?vmid>=101].name", []interface{}{"web", "cache"}},
+		{"data[?maxmem<2048].name", []interface{}{"cache"}},
+		{"data[?vmid=='100'].name", []interface{}{"db"}},
+		{"data[?tags==null].name", []interface{}{"web"}},
+		{"data[?name>'d'].name", []interface{}{"db", "web"}},
+		{"data[?status=='paused']", []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := QueryJSON(response, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, query := range []string{"data[", "data.", "data[?status=running]", "data[x]", ".data", "data name"} {
+		_, err := QueryJSON(response, query)
+		assert.Error(t, err, query)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RequestMethods are the methods accepted by Client.Request.
+var RequestMethods = []string{"GET", HTTPMethodPOST, HTTPMethodPUT, HTTPMethodDELETE}
+
+// Request sends a request with any of the RequestMethods to an API path, for
+// endpoints the client has no method for, and returns the decoded response
+// with its "data" member. GET and DELETE send params as the query string,
+// POST and PUT as the request body. Requests are not retried, so writes are
+// sent once.
+func (c *Client) Request(method, path string, params map[string]interface{}) (map[string]interface{}, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	path = "/" + strings.TrimLeft(strings.TrimSpace(path), "/")
+
+	c.logger.Debug("API %s (raw): %s", method, path)
+
+	var (
+		result map[string]interface{}
+		err    error
+	)
+
+	switch method {
+	case "GET":
+		err = c.httpClient.Get(c.requestContext(), withQuery(path, params), &result)
+	case HTTPMethodDELETE:
+		err = c.httpClient.Delete(c.requestContext(), withQuery(path, params), &result)
+	case HTTPMethodPOST:
+		err = c.httpClient.Post(c.requestContext(), path, bodyParams(params), &result)
+	case HTTPMethodPUT:
+		err = c.httpClient.Put(c.requestContext(), path, bodyParams(params), &result)
+	default:
+		return nil, fmt.Errorf("unsupported method %q: use one of %s", method, strings.Join(RequestMethods, ", "))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// withQuery appends params to path as a query string, sorted by name.
+func withQuery(path string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return path
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = url.QueryEscape(name) + "=" + url.QueryEscape(fmt.Sprint(params[name]))
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	return path + separator + strings.Join(values, "&")
+}
+
+// bodyParams returns params as a request body, or nil to send none.
+func bodyParams(params map[string]interface{}) interface{} {
+	if len(params) == 0 {
+		return nil
+	}
+
+	return params
+}
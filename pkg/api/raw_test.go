@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Request(t *testing.T) {
+	var requests []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		if r.URL.Path == "/missing" {
+			http.Error(w, "no such path", http.StatusNotImplemented)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"ok": 1}})
+	})
+
+	result, err := client.Request("get", "nodes/pve1/apt/versions", map[string]interface{}{"type": "vm", "full": 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"data": map[string]interface{}{"ok": float64(1)}}, result)
+
+	_, err = client.Request("POST", "/nodes/pve1/qemu/100/status/start", map[string]interface{}{"timeout": 30})
+	require.NoError(t, err)
+
+	_, err = client.Request("DELETE", "/pools/web", nil)
+	require.NoError(t, err)
+
+	_, err = client.Request("GET", "/missing", nil)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotImplemented, apiErr.StatusCode)
+
+	_, err = client.Request("PATCH", "/nodes", nil)
+	assert.ErrorContains(t, err, "unsupported method")
+
+	assert.Equal(t, []string{
+		"GET /nodes/pve1/apt/versions?full=1&type=vm ",
+		`POST /nodes/pve1/qemu/100/status/start {"timeout":30}`,
+		"DELETE /pools/web ",
+		"GET /missing ",
+	}, requests)
+}
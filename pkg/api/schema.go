@@ -0,0 +1,328 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaType is the JSON type expected for a field of an API response.
+type SchemaType string
+
+const (
+	// SchemaString is a JSON string.
+	SchemaString SchemaType = "string"
+	// SchemaNumber is a JSON number or a string holding one, which Proxmox
+	// returns for some numbers and the client reads alike.
+	SchemaNumber SchemaType = "number"
+	// SchemaObject is a JSON object.
+	SchemaObject SchemaType = "object"
+	// SchemaList is a JSON array.
+	SchemaList SchemaType = "list"
+)
+
+// ResponseSchema lists the fields the client reads from the data of the
+// responses of an endpoint. For list responses every element is checked.
+type ResponseSchema struct {
+	// Path is the endpoint, with {name} matching any one path segment, e.g.
+	// "/nodes/{node}/status".
+	Path string
+	// Match limits the check to data, or list elements, whose fields have
+	// these string values, e.g. {"type": "qemu"} for guests of
+	// /cluster/resources.
+	Match map[string]string
+	// Fields maps the field names to their expected types.
+	Fields map[string]SchemaType
+}
+
+// SchemaDrift is a field of a response that is missing or has another type
+// than its endpoint's schema expects, as happens when a Proxmox release
+// changes a response.
+type SchemaDrift struct {
+	// Endpoint is the Path of the schema, and Path the requested path.
+	Endpoint string
+	Path     string
+	Field    string
+	Expected SchemaType
+	// Actual is the JSON type found, or "missing".
+	Actual string
+	// Version is the release of the cluster when known to the client.
+	Version PVEVersion
+}
+
+// String describes the drift, e.g. "/nodes/{node}/status: field memory is
+// string, expected object".
+func (d SchemaDrift) String() string {
+	if d.Actual == "missing" {
+		return fmt.Sprintf("%s: field %s is missing, expected %s", d.Endpoint, d.Field, d.Expected)
+	}
+
+	return fmt.Sprintf("%s: field %s is %s, expected %s", d.Endpoint, d.Field, d.Actual, d.Expected)
+}
+
+// SchemaDriftHandler is told about each drift the client detects, once per
+// endpoint, field and type found.
+type SchemaDriftHandler func(drift SchemaDrift)
+
+// ResponseSchemas are the schemas the client checks its responses against:
+// the fields it reads that every supported Proxmox release returns.
+var ResponseSchemas = []ResponseSchema{
+	{Path: "/version", Fields: map[string]SchemaType{"version": SchemaString, "release": SchemaString}},
+	{Path: "/cluster/status", Match: map[string]string{"type": "node"}, Fields: map[string]SchemaType{
+		"name": SchemaString, "online": SchemaNumber, "nodeid": SchemaNumber,
+	}},
+	{Path: "/cluster/status", Match: map[string]string{"type": "cluster"}, Fields: map[string]SchemaType{
+		"name": SchemaString, "quorate": SchemaNumber,
+	}},
+	{Path: "/cluster/resources", Match: map[string]string{"type": "node"}, Fields: map[string]SchemaType{
+		"node": SchemaString, "status": SchemaString,
+	}},
+	{Path: "/cluster/resources", Match: map[string]string{"type": "qemu"}, Fields: map[string]SchemaType{
+		"vmid": SchemaNumber, "node": SchemaString, "status": SchemaString, "maxmem": SchemaNumber, "maxcpu": SchemaNumber,
+	}},
+	{Path: "/cluster/resources", Match: map[string]string{"type": "lxc"}, Fields: map[string]SchemaType{
+		"vmid": SchemaNumber, "node": SchemaString, "status": SchemaString, "maxmem": SchemaNumber, "maxcpu": SchemaNumber,
+	}},
+	{Path: "/cluster/resources", Match: map[string]string{"type": "storage"}, Fields: map[string]SchemaType{
+		"storage": SchemaString, "node": SchemaString,
+	}},
+	{Path: "/cluster/tasks", Fields: map[string]SchemaType{
+		"upid": SchemaString, "node": SchemaString, "type": SchemaString, "starttime": SchemaNumber,
+	}},
+	{Path: "/nodes/{node}/status", Fields: map[string]SchemaType{
+		"cpu": SchemaNumber, "memory": SchemaObject, "rootfs": SchemaObject, "uptime": SchemaNumber,
+		"cpuinfo": SchemaObject, "pveversion": SchemaString,
+	}},
+	{Path: "/nodes/{node}/qemu/{vmid}/status/current", Fields: map[string]SchemaType{
+		"status": SchemaString, "cpu": SchemaNumber, "mem": SchemaNumber, "maxmem": SchemaNumber,
+	}},
+	{Path: "/nodes/{node}/lxc/{vmid}/status/current", Fields: map[string]SchemaType{
+		"status": SchemaString, "cpu": SchemaNumber, "mem": SchemaNumber, "maxmem": SchemaNumber,
+	}},
+}
+
+// CheckSchema checks the data of a response to path against the
+// ResponseSchemas of its endpoint and returns the drift found.
+func CheckSchema(path string, response map[string]interface{}) []SchemaDrift {
+	path, _, _ = strings.Cut(path, "?")
+
+	var drifts []SchemaDrift
+
+	for _, schema := range ResponseSchemas {
+		if !matchSchemaPath(schema.Path, path) {
+			continue
+		}
+
+		var items []interface{}
+
+		switch data := response["data"].(type) {
+		case []interface{}:
+			items = data
+		case nil:
+			// Empty results decode as null
+		default:
+			items = []interface{}{data}
+		}
+
+		for _, item := range items {
+			drifts = append(drifts, schema.check(path, item)...)
+		}
+	}
+
+	return drifts
+}
+
+// check returns the drift of a data object or list element.
+func (s ResponseSchema) check(path string, item interface{}) []SchemaDrift {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		if len(s.Match) > 0 {
+			return nil
+		}
+
+		return []SchemaDrift{{Endpoint: s.Path, Path: path, Field: "data", Expected: SchemaObject, Actual: jsonTypeName(item)}}
+	}
+
+	for field, value := range s.Match {
+		if getString(object, field) != value {
+			return nil
+		}
+	}
+
+	var drifts []SchemaDrift
+
+	for _, field := range slices.Sorted(maps.Keys(s.Fields)) {
+		expected := s.Fields[field]
+		value, present := object[field]
+
+		actual := "missing"
+		if present {
+			if schemaTypeMatches(expected, value) {
+				continue
+			}
+
+			actual = jsonTypeName(value)
+		}
+
+		drifts = append(drifts, SchemaDrift{Endpoint: s.Path, Path: path, Field: field, Expected: expected, Actual: actual})
+	}
+
+	return drifts
+}
+
+// matchSchemaPath reports whether path is an instance of the schema path
+// pattern.
+func matchSchemaPath(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return false
+			}
+
+			continue
+		}
+
+		if part != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// schemaTypeMatches reports whether a decoded JSON value has the expected
+// type.
+func schemaTypeMatches(expected SchemaType, value interface{}) bool {
+	switch expected {
+	case SchemaString:
+		_, ok := value.(string)
+
+		return ok
+	case SchemaNumber:
+		switch v := value.(type) {
+		case float64:
+			return true
+		case string:
+			_, err := strconv.ParseFloat(v, 64)
+
+			return err == nil
+		}
+
+		return false
+	case SchemaObject:
+		_, ok := value.(map[string]interface{})
+
+		return ok
+	case SchemaList:
+		_, ok := value.([]interface{})
+
+		return ok
+	}
+
+	return false
+}
+
+// jsonTypeName names the JSON type of a decoded value.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "list"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaChecker checks the responses of a client and reports each drift
+// once. Drift found before a handler is set is kept for it.
+type schemaChecker struct {
+	mu       sync.Mutex
+	reported map[string]bool
+	pending  []SchemaDrift
+	handler  SchemaDriftHandler
+}
+
+// newSchemaChecker creates a checker that logs drift and passes it to
+// handler, if set.
+func newSchemaChecker(handler SchemaDriftHandler) *schemaChecker {
+	return &schemaChecker{reported: make(map[string]bool), handler: handler}
+}
+
+// SetSchemaDriftHandler replaces the handler of schema drift (see
+// WithSchemaDriftHandler), e.g. once an interface can show it. Drift found
+// before any handler was set is passed to it right away.
+func (c *Client) SetSchemaDriftHandler(handler SchemaDriftHandler) {
+	if c.schemas == nil {
+		c.schemas = newSchemaChecker(nil)
+	}
+
+	c.schemas.mu.Lock()
+	c.schemas.handler = handler
+	pending := c.schemas.pending
+	c.schemas.pending = nil
+	c.schemas.mu.Unlock()
+
+	if handler == nil {
+		return
+	}
+
+	for _, drift := range pending {
+		handler(drift)
+	}
+}
+
+// checkResponse checks a response of the client to path against the
+// ResponseSchemas.
+func (c *Client) checkResponse(path string, response map[string]interface{}) {
+	if c.schemas == nil || response == nil {
+		return
+	}
+
+	for _, drift := range CheckSchema(path, response) {
+		drift.Version = c.pveVersion
+		key := drift.Endpoint + " " + drift.Field + " " + drift.Actual
+
+		c.schemas.mu.Lock()
+		reported := c.schemas.reported[key]
+		c.schemas.reported[key] = true
+		handler := c.schemas.handler
+
+		if !reported && handler == nil {
+			c.schemas.pending = append(c.schemas.pending, drift)
+		}
+		c.schemas.mu.Unlock()
+
+		if reported {
+			continue
+		}
+
+		if drift.Version.Major > 0 {
+			c.logger.Error("API schema drift on Proxmox VE %s: %s", drift.Version, drift)
+		} else {
+			c.logger.Error("API schema drift: %s", drift)
+		}
+
+		if handler != nil {
+			handler(drift)
+		}
+	}
+}
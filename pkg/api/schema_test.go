@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	resources := map[string]interface{}{"data": []interface{}{
+		map[string]interface{}{"type": "node", "node": "pve1", "status": "online"},
+		map[string]interface{}{"type": "qemu", "vmid": "100", "node": "pve1", "status": "running", "maxmem": 1024.0, "maxcpu": 2.0},
+		map[string]interface{}{"type": "lxc", "vmid": 101.0, "node": "pve1", "status": 1.0, "maxmem": "a lot"},
+		map[string]interface{}{"type": "sdn", "sdn": "localnetwork"},
+	}}
+
+	assert.Equal(t, []SchemaDrift{
+		{Endpoint: "/cluster/resources", Path: "/cluster/resources", Field: "maxcpu", Expected: SchemaNumber, Actual: "missing"},
+		{Endpoint: "/cluster/resources", Path: "/cluster/resources", Field: "maxmem", Expected: SchemaNumber, Actual: "string"},
+		{Endpoint: "/cluster/resources", Path: "/cluster/resources", Field: "status", Expected: SchemaString, Actual: "number"},
+	}, CheckSchema("/cluster/resources?type=vm", resources))
+
+	status := map[string]interface{}{"data": map[string]interface{}{
+		"cpu": 0.1, "memory": "8G", "rootfs": map[string]interface{}{}, "uptime": 100.0,
+		"cpuinfo": map[string]interface{}{}, "pveversion": "pve-manager/9.0.3",
+	}}
+
+	drifts := CheckSchema("/nodes/pve1/status", status)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "/nodes/{node}/status: field memory is string, expected object", drifts[0].String())
+
+	assert.Empty(t, CheckSchema("/nodes/pve1/status", map[string]interface{}{"data": nil}))
+	assert.Empty(t, CheckSchema("/nodes/pve1/network", status))
+	assert.Equal(t, "/version: field data is missing, expected object",
+		SchemaDrift{Endpoint: "/version", Field: "data", Expected: SchemaObject, Actual: "missing"}.String())
+	assert.Equal(t, []SchemaDrift{{Endpoint: "/version", Path: "/version", Field: "data", Expected: SchemaObject, Actual: "string"}},
+		CheckSchema("/version", map[string]interface{}{"data": "9.0"}))
+}
+
+func TestClient_SchemaDrift(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"upid": "UPID:pve1", "node": "pve1", "type": "vzdump"},
+		}})
+	})
+	client.schemas = newSchemaChecker(nil)
+
+	var result map[string]interface{}
+	require.NoError(t, client.Get("/cluster/tasks", &result))
+
+	// Drift found before the handler is set is passed to it, and each drift
+	// only once
+	var drifts []SchemaDrift
+
+	client.SetSchemaDriftHandler(func(drift SchemaDrift) { drifts = append(drifts, drift) })
+	require.NoError(t, client.GetNoRetry("/cluster/tasks", &result))
+
+	assert.Equal(t, []SchemaDrift{
+		{Endpoint: "/cluster/tasks", Path: "/cluster/tasks", Field: "starttime", Expected: SchemaNumber, Actual: "missing"},
+	}, drifts)
+}
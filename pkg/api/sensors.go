@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Temperature is a single temperature reading of a node sensor.
+type Temperature struct {
+	// Sensor is the chip reporting the reading, e.g. "coretemp-isa-0000".
+	Sensor string `json:"sensor"`
+	// Label is the feature name, e.g. "Package id 0" or "Composite".
+	Label   string  `json:"label"`
+	Celsius float64 `json:"celsius"`
+}
+
+// sensorInputPattern matches the current value keys of lm-sensors temperature features.
+var sensorInputPattern = regexp.MustCompile(`^temp\d+_input$`)
+
+// ParseSensorsJSON parses the output of `sensors -j` into temperature
+// readings, sorted by sensor and label. Fans, voltages, and other
+// non-temperature values are ignored.
+func ParseSensorsJSON(data []byte) ([]Temperature, error) {
+	var chips map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &chips); err != nil {
+		return nil, fmt.Errorf("invalid sensors output: %w", err)
+	}
+
+	var temps []Temperature
+
+	for chip, features := range chips {
+		for label, raw := range features {
+			var values map[string]float64
+			if err := json.Unmarshal(raw, &values); err != nil {
+				continue // "Adapter" and other non-feature fields
+			}
+
+			for key, value := range values {
+				if sensorInputPattern.MatchString(key) {
+					temps = append(temps, Temperature{Sensor: chip, Label: label, Celsius: value})
+
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(temps, func(i, j int) bool {
+		if temps[i].Sensor != temps[j].Sensor {
+			return temps[i].Sensor < temps[j].Sensor
+		}
+
+		return temps[i].Label < temps[j].Label
+	})
+
+	return temps, nil
+}
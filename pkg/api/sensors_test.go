@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSensorsJSON(t *testing.T) {
+	output := `{
+  "coretemp-isa-0000": {
+    "Adapter": "ISA adapter",
+    "Package id 0": {"temp1_input": 54.0, "temp1_max": 80.0, "temp1_crit": 100.0},
+    "Core 0": {"temp2_input": 51.0, "temp2_max": 80.0}
+  },
+  "nvme-pci-0100": {
+    "Adapter": "PCI adapter",
+    "Composite": {"temp1_input": 38.85, "temp1_alarm": 0.0}
+  },
+  "nct6775-isa-0290": {
+    "Adapter": "ISA adapter",
+    "fan1": {"fan1_input": 1200.0}
+  }
+}`
+
+	temps, err := ParseSensorsJSON([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, []Temperature{
+		{Sensor: "coretemp-isa-0000", Label: "Core 0", Celsius: 51.0},
+		{Sensor: "coretemp-isa-0000", Label: "Package id 0", Celsius: 54.0},
+		{Sensor: "nvme-pci-0100", Label: "Composite", Celsius: 38.85},
+	}, temps)
+
+	_, err = ParseSensorsJSON([]byte("sensors: command not found"))
+	assert.Error(t, err)
+}
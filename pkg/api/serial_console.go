@@ -0,0 +1,211 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// serialPortKey matches the config keys of QEMU serial ports.
+var serialPortKey = regexp.MustCompile(`^serial[0-3]$`)
+
+// termPingInterval keeps idle serial console connections open; termproxy
+// closes connections that stay silent for too long.
+const termPingInterval = 30 * time.Second
+
+// SerialPorts returns the serial ports of a QEMU guest's config that are
+// backed by a socket, such as "serial0", in order. Only those can be
+// attached to with termproxy.
+func (g GuestConfig) SerialPorts() []string {
+	var ports []string
+
+	for key, value := range g {
+		if serialPortKey.MatchString(key) && value == "socket" {
+			ports = append(ports, key)
+		}
+	}
+
+	sort.Strings(ports)
+
+	return ports
+}
+
+// SerialConsole is an open connection to the serial port of a QEMU guest
+// through termproxy. Reads return the output of the guest, writes send
+// input to it.
+type SerialConsole struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	pending []byte
+	done    chan struct{}
+	once    sync.Once
+}
+
+// OpenSerialConsole starts termproxy for a serial port of a running QEMU
+// guest, e.g. "serial0", and connects to it.
+func (c *Client) OpenSerialConsole(vm *VM, port string) (*SerialConsole, error) {
+	if vm.Type != VMTypeQemu {
+		return nil, fmt.Errorf("serial consoles are only available for QEMU VMs")
+	}
+
+	var res map[string]interface{}
+
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/termproxy", vm.Node, vm.ID)
+	if err := c.PostWithResponse(path, map[string]interface{}{"serial": port}, &res); err != nil {
+		return nil, fmt.Errorf("failed to start termproxy: %w", err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected termproxy response format")
+	}
+
+	ticket := getString(data, "ticket")
+	user := getString(data, "user")
+	proxyPort := getString(data, "port")
+
+	if proxyPort == "" {
+		if number, ok := data["port"].(float64); ok {
+			proxyPort = fmt.Sprintf("%.0f", number)
+		}
+	}
+
+	wsURL := WebSocketURL(c.baseURL) +
+		fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/vncwebsocket?port=%s&vncticket=%s",
+			url.PathEscape(vm.Node), vm.ID, url.QueryEscape(proxyPort), url.QueryEscape(ticket))
+
+	dialer := websocket.Dialer{
+		TLSClientConfig:  c.tlsConfig(),
+		HandshakeTimeout: 10 * time.Second,
+		Proxy:            http.ProxyFromEnvironment,
+	}
+
+	headers := make(http.Header)
+
+	if token := c.GetAuthToken(); strings.HasPrefix(token, "PVEAuthCookie=") {
+		headers.Set("Cookie", token)
+	} else if token != "" {
+		headers.Set("Authorization", token)
+	}
+
+	conn, resp, err := dialer.Dial(wsURL, headers)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the serial console: %w", err)
+	}
+
+	// termproxy expects the user and ticket first and answers OK
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(user+":"+ticket+"\n")); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to authenticate to the serial console: %w", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	_, reply, err := conn.ReadMessage()
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("serial console authentication failed: %w", err)
+	}
+
+	if !strings.HasPrefix(string(reply), "OK") {
+		conn.Close()
+
+		return nil, fmt.Errorf("serial console authentication failed: %s", strings.TrimSpace(string(reply)))
+	}
+
+	_ = conn.SetReadDeadline(time.Time{})
+
+	console := &SerialConsole{conn: conn, done: make(chan struct{})}
+	// Output that arrived together with the OK
+	console.pending = append(console.pending, reply[2:]...)
+
+	go console.keepAlive()
+
+	return console, nil
+}
+
+// tlsConfig returns the TLS settings of the API connection.
+func (c *Client) tlsConfig() *tls.Config {
+	if transport, ok := c.httpClient.client.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		return transport.TLSClientConfig.Clone()
+	}
+
+	return nil
+}
+
+// keepAlive pings termproxy until the console is closed.
+func (s *SerialConsole) keepAlive() {
+	ticker := time.NewTicker(termPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.send("2"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// send writes a termproxy message.
+func (s *SerialConsole) send(message string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	return s.conn.WriteMessage(websocket.BinaryMessage, []byte(message))
+}
+
+// Read reads output of the guest.
+func (s *SerialConsole) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		_, message, err := s.conn.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+
+		s.pending = message
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+
+	return n, nil
+}
+
+// Write sends input to the guest.
+func (s *SerialConsole) Write(p []byte) (int, error) {
+	if err := s.send(fmt.Sprintf("0:%d:%s", len(p), p)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Resize tells termproxy the size of the local terminal.
+func (s *SerialConsole) Resize(cols, rows int) error {
+	return s.send(fmt.Sprintf("1:%d:%d:", cols, rows))
+}
+
+// Close closes the connection.
+func (s *SerialConsole) Close() error {
+	s.once.Do(func() { close(s.done) })
+
+	return s.conn.Close()
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devnullvoid/pvetui/pkg/api/testutils"
+)
+
+func TestGuestConfig_SerialPorts(t *testing.T) {
+	config := GuestConfig{"serial1": "socket", "serial0": "socket", "serial2": "/dev/ttyS0", "vga": "serial0"}
+	assert.Equal(t, []string{"serial0", "serial1"}, config.SerialPorts())
+	assert.Empty(t, GuestConfig{"vga": "std"}.SerialPorts())
+}
+
+func TestClient_OpenSerialConsole(t *testing.T) {
+	received := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/nodes/pve1/qemu/100/termproxy":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "serial0", body["serial"])
+			_, _ = w.Write([]byte(`{"data":{"port":5900,"ticket":"PVEVNC:abc","user":"root@pam"}}`))
+		case r.URL.Path == "/api2/json/nodes/pve1/qemu/100/vncwebsocket":
+			assert.Equal(t, "5900", r.URL.Query().Get("port"))
+			assert.Equal(t, "PVEVNC:abc", r.URL.Query().Get("vncticket"))
+
+			conn, err := upgrader.Upgrade(w, r, nil)
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			_, auth, err := conn.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, "root@pam:PVEVNC:abc\n", string(auth))
+			require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte("OKlogin: ")))
+
+			for {
+				_, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+
+				received <- string(message)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	logger := testutils.NewTestLogger()
+	client := &Client{
+		httpClient: NewHTTPClient(server.Client(), server.URL, logger),
+		logger:     logger,
+		baseURL:    server.URL,
+	}
+
+	console, err := client.OpenSerialConsole(&VM{ID: 100, Node: "pve1", Type: VMTypeQemu}, "serial0")
+	require.NoError(t, err)
+
+	defer console.Close()
+
+	output := make([]byte, 64)
+	n, err := console.Read(output)
+	require.NoError(t, err)
+	assert.Equal(t, "login: ", string(output[:n]))
+
+	_, err = io.WriteString(console, "root\r")
+	require.NoError(t, err)
+	assert.Equal(t, "0:5:root\r", <-received)
+
+	require.NoError(t, console.Resize(120, 40))
+	assert.Equal(t, "1:120:40:", <-received)
+
+	_, err = client.OpenSerialConsole(&VM{ID: 200, Node: "pve1", Type: VMTypeLXC}, "serial0")
+	assert.True(t, err != nil && strings.Contains(err.Error(), "only available for QEMU"))
+}
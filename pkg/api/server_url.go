@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ServerURL returns the base URL of a Proxmox VE server from its configured
+// address: the scheme, host, port and any path prefix the server is served
+// under, without the API path or a trailing slash. For example
+// "pve:8006/api2/json" becomes "https://pve:8006", and
+// "http://proxy.example.com/proxmox/" becomes
+// "http://proxy.example.com/proxmox" for a server behind a reverse proxy.
+// Addresses without a scheme use https; without a port, the default port of
+// the scheme is used.
+func ServerURL(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return "", fmt.Errorf("proxmox address cannot be empty")
+	}
+
+	if !strings.Contains(addr, "://") {
+		addr = "https://" + addr
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "https" && scheme != "http" {
+		return "", fmt.Errorf("invalid address %s: the scheme must be https or http", addr)
+	}
+
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid address %s: missing host", addr)
+	}
+
+	prefix := strings.TrimSuffix(strings.TrimRight(u.Path, "/"), "/api2/json")
+
+	return scheme + "://" + u.Host + strings.TrimRight(prefix, "/"), nil
+}
+
+// WebSocketURL returns the websocket URL of a server base URL, e.g.
+// "wss://pve:8006" for "https://pve:8006", keeping the path prefix.
+func WebSocketURL(serverURL string) string {
+	if rest, ok := strings.CutPrefix(serverURL, "http://"); ok {
+		return "ws://" + rest
+	}
+
+	return "wss://" + strings.TrimPrefix(serverURL, "https://")
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerURL(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"https://pve.example.com:8006", "https://pve.example.com:8006"},
+		{"https://pve.example.com:8006/api2/json/", "https://pve.example.com:8006"},
+		{"pve.example.com:8006", "https://pve.example.com:8006"},
+		{"https://pve.example.com", "https://pve.example.com"},
+		{"https://proxy.example.com/proxmox", "https://proxy.example.com/proxmox"},
+		{"https://proxy.example.com:8443/proxmox/api2/json", "https://proxy.example.com:8443/proxmox"},
+		{"http://10.0.0.5:8080/pve/", "http://10.0.0.5:8080/pve"},
+		{"HTTPS://[fd00::5]:8006", "https://[fd00::5]:8006"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got, err := ServerURL(tt.addr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, addr := range []string{"", "ftp://pve:8006", "https://", "https://:8006"} {
+		_, err := ServerURL(addr)
+		assert.Error(t, err, addr)
+	}
+}
+
+func TestWebSocketURL(t *testing.T) {
+	assert.Equal(t, "wss://pve:8006", WebSocketURL("https://pve:8006"))
+	assert.Equal(t, "ws://proxy.example.com/proxmox", WebSocketURL("http://proxy.example.com/proxmox"))
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Task status values reported by the Proxmox task status endpoint.
+const (
+	TaskStatusRunning = "running"
+	TaskStatusStopped = "stopped"
+	TaskExitStatusOK  = "OK"
+)
+
+// TaskLogTailLines is the number of log lines attached to a TaskError.
+const TaskLogTailLines = 10
+
+// taskLogPageSize is the number of log lines requested at once.
+const taskLogPageSize = 500
+
+// TaskError is returned for tasks that finished unsuccessfully. LogTail holds
+// the last lines of the task log, which usually explain the failure.
+type TaskError struct {
+	UPID       string
+	Node       string
+	ExitStatus string
+	LogTail    []string
+}
+
+// Error returns the exit status of the task.
+func (e *TaskError) Error() string {
+	return "task failed: " + e.ExitStatus
+}
+
+// TaskStatus represents the current state of a single Proxmox task (UPID).
+type TaskStatus struct {
+	UPID       string `json:"upid"`
+	Node       string `json:"node"`
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	User       string `json:"user"`
+	Status     string `json:"status"`     // "running" or "stopped"
+	ExitStatus string `json:"exitstatus"` // "OK" or an error message once stopped
+	StartTime  int64  `json:"starttime"`
+}
+
+// IsRunning returns true if the task has not finished yet.
+func (t *TaskStatus) IsRunning() bool {
+	return t.Status == TaskStatusRunning
+}
+
+// IsSuccessful returns true if the task finished with an OK exit status.
+func (t *TaskStatus) IsSuccessful() bool {
+	return t.Status == TaskStatusStopped && t.ExitStatus == TaskExitStatusOK
+}
+
+// ParseUPIDNode extracts the node name from a Proxmox UPID string.
+//
+// UPIDs have the format "UPID:node:pid:pstart:starttime:type:id:user:".
+// An empty string is returned if the UPID is malformed.
+func ParseUPIDNode(upid string) string {
+	parts := strings.Split(upid, ":")
+	if len(parts) < 3 || parts[0] != "UPID" {
+		return ""
+	}
+
+	return parts[1]
+}
+
+// GetTaskStatus retrieves the status of a task on the given node.
+func (c *Client) GetTaskStatus(node, upid string) (*TaskStatus, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/status", node, url.PathEscape(upid))
+
+	var result map[string]interface{}
+	if err := c.GetNoRetry(path, &result); err != nil {
+		return nil, fmt.Errorf("failed to get task status: %w", err)
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for task status")
+	}
+
+	status := &TaskStatus{
+		UPID:       getString(data, "upid"),
+		Node:       getString(data, "node"),
+		Type:       getString(data, "type"),
+		ID:         getString(data, "id"),
+		User:       getString(data, "user"),
+		Status:     getString(data, "status"),
+		ExitStatus: getString(data, "exitstatus"),
+		StartTime:  int64(getFloat(data, "starttime")),
+	}
+
+	if status.UPID == "" {
+		status.UPID = upid
+	}
+
+	return status, nil
+}
+
+// GetTaskLog retrieves task log lines starting at the given line number.
+func (c *Client) GetTaskLog(node, upid string, start int) ([]string, error) {
+	lines, _, err := c.getTaskLogPage(node, upid, start, taskLogPageSize)
+
+	return lines, err
+}
+
+// GetFullTaskLog retrieves all lines of a task log.
+func (c *Client) GetFullTaskLog(node, upid string) ([]string, error) {
+	var lines []string
+
+	for {
+		page, total, err := c.getTaskLogPage(node, upid, len(lines), taskLogPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, page...)
+
+		if len(page) < taskLogPageSize || len(lines) >= total {
+			return lines, nil
+		}
+	}
+}
+
+// GetTaskLogTail retrieves the last count lines of a task log.
+func (c *Client) GetTaskLogTail(node, upid string, count int) ([]string, error) {
+	lines, total, err := c.getTaskLogPage(node, upid, 0, count)
+	if err != nil || total <= count {
+		return lines, err
+	}
+
+	lines, _, err = c.getTaskLogPage(node, upid, total-count, count)
+
+	return lines, err
+}
+
+// getTaskLogPage retrieves up to limit task log lines starting at the given
+// line number, and the number of lines of the whole log.
+func (c *Client) getTaskLogPage(node, upid string, start, limit int) ([]string, int, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/log?start=%d&limit=%d", node, url.PathEscape(upid), start, limit)
+
+	var result map[string]interface{}
+	if err := c.GetNoRetry(path, &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to get task log: %w", err)
+	}
+
+	data, ok := result["data"].([]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected format for task log")
+	}
+
+	lines := make([]string, 0, len(data))
+
+	for _, item := range data {
+		if entry, ok := item.(map[string]interface{}); ok {
+			lines = append(lines, getString(entry, "t"))
+		}
+	}
+
+	total := getInt(result, "total")
+	if total < start+len(lines) {
+		total = start + len(lines)
+	}
+
+	return lines, total, nil
+}
+
+// newTaskError creates the error of a failed task with the end of its log.
+// The log is left out if it can't be read.
+func (c *Client) newTaskError(node, upid, exitStatus string) *TaskError {
+	taskErr := &TaskError{UPID: upid, Node: node, ExitStatus: exitStatus}
+
+	lines, err := c.GetTaskLogTail(node, upid, TaskLogTailLines)
+	if err != nil {
+		c.logger.Debug("Failed to get log of failed task %s: %v", upid, err)
+
+		return taskErr
+	}
+
+	taskErr.LogTail = lines
+
+	return taskErr
+}
+
+// GetFailedGuestTask returns the newest task of a guest that failed since
+// the given time, or nil if there is none. Actions that don't wait for their
+// task use it to notice failures, e.g. a start that fails after the request
+// was accepted.
+func (c *Client) GetFailedGuestTask(vm *VM, since time.Time) (*TaskError, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks?vmid=%d&errors=1&since=%d", vm.Node, vm.ID, since.Unix())
+
+	var result map[string]interface{}
+	if err := c.GetNoRetry(path, &result); err != nil {
+		return nil, fmt.Errorf("failed to get tasks of %s: %w", vm.Name, err)
+	}
+
+	items, _ := result["data"].([]interface{})
+
+	var (
+		newest  map[string]interface{}
+		started int64
+	)
+
+	for _, item := range items {
+		task, ok := item.(map[string]interface{})
+		if !ok || getFloat(task, "endtime") == 0 {
+			continue
+		}
+
+		status := getString(task, "status")
+		if status == "" || status == TaskExitStatusOK {
+			continue
+		}
+
+		if start := int64(getFloat(task, "starttime")); newest == nil || start > started {
+			newest, started = task, start
+		}
+	}
+
+	if newest == nil {
+		return nil, nil
+	}
+
+	return c.newTaskError(vm.Node, getString(newest, "upid"), getString(newest, "status")), nil
+}
+
+// WaitForTask polls a task until it finishes, reporting the most recent
+// log line through onProgress (if non-nil) while the task is running.
+//
+// A *TaskError is returned if the task fails, another error if it does not
+// finish within maxWait.
+func (c *Client) WaitForTask(upid string, maxWait time.Duration, onProgress func(line string)) error {
+	node := ParseUPIDNode(upid)
+	if node == "" {
+		return fmt.Errorf("invalid task ID: %s", upid)
+	}
+
+	const pollInterval = 2 * time.Second
+
+	logOffset := 0
+	start := time.Now()
+
+	for time.Since(start) < maxWait {
+		status, err := c.GetTaskStatus(node, upid)
+		if err != nil {
+			c.logger.Debug("Failed to get status for task %s: %v", upid, err)
+			time.Sleep(pollInterval)
+
+			continue
+		}
+
+		if onProgress != nil {
+			if lines, err := c.GetTaskLog(node, upid, logOffset); err == nil && len(lines) > 0 {
+				logOffset += len(lines)
+
+				for i := len(lines) - 1; i >= 0; i-- {
+					if line := strings.TrimSpace(lines[i]); line != "" {
+						onProgress(line)
+
+						break
+					}
+				}
+			}
+		}
+
+		if !status.IsRunning() {
+			if status.IsSuccessful() {
+				return nil
+			}
+
+			return c.newTaskError(node, upid, status.ExitStatus)
+		}
+
+		time.Sleep(pollInterval)
+	}
+
+	return fmt.Errorf("timed out waiting for task %s", upid)
+}
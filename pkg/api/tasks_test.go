@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUPIDNode(t *testing.T) {
+	tests := []struct {
+		name     string
+		upid     string
+		expected string
+	}{
+		{
+			name:     "valid UPID",
+			upid:     "UPID:pve1:0000A1B2:0012C3D4:64F0A1B2:qmmove:100:root@pam:",
+			expected: "pve1",
+		},
+		{
+			name:     "empty string",
+			upid:     "",
+			expected: "",
+		},
+		{
+			name:     "missing prefix",
+			upid:     "TASK:pve1:0000A1B2",
+			expected: "",
+		},
+		{
+			name:     "too short",
+			upid:     "UPID:pve1",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseUPIDNode(tt.upid))
+		})
+	}
+}
+
+func TestTaskStatus_States(t *testing.T) {
+	running := &TaskStatus{Status: TaskStatusRunning}
+	assert.True(t, running.IsRunning())
+	assert.False(t, running.IsSuccessful())
+
+	ok := &TaskStatus{Status: TaskStatusStopped, ExitStatus: TaskExitStatusOK}
+	assert.False(t, ok.IsRunning())
+	assert.True(t, ok.IsSuccessful())
+
+	failed := &TaskStatus{Status: TaskStatusStopped, ExitStatus: "storage 'nfs' is not online"}
+	assert.False(t, failed.IsRunning())
+	assert.False(t, failed.IsSuccessful())
+}
+
+func TestClient_TaskErrors(t *testing.T) {
+	const upid = "UPID:pve1:0000A1B2:0012C3D4:64F0A1B2:qmstart:100:root@pam:"
+
+	log := make([]interface{}, 25)
+	for i := range log {
+		log[i] = map[string]interface{}{"n": i + 1, "t": fmt.Sprintf("line %d", i+1)}
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{}
+
+		switch r.URL.Path {
+		case "/nodes/pve1/tasks/" + upid + "/log":
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := min(start+limit, len(log))
+
+			response["data"] = log[start:end]
+			response["total"] = len(log)
+		case "/nodes/pve1/tasks":
+			assert.Equal(t, "100", r.URL.Query().Get("vmid"))
+
+			if r.URL.Query().Get("since") != "1700000000" {
+				response["data"] = []interface{}{}
+
+				break
+			}
+
+			response["data"] = []interface{}{
+				map[string]interface{}{"upid": "UPID:old", "status": "earlier failure", "starttime": 1700000001, "endtime": 1700000002},
+				map[string]interface{}{"upid": upid, "status": "start failed: QEMU exited with code 1", "starttime": 1700000010, "endtime": 1700000011},
+				map[string]interface{}{"upid": "UPID:running", "status": "", "starttime": 1700000020},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	tail, err := client.GetTaskLogTail("pve1", upid, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 23", "line 24", "line 25"}, tail)
+
+	full, err := client.GetFullTaskLog("pve1", upid)
+	require.NoError(t, err)
+	assert.Len(t, full, 25)
+
+	vm := &VM{ID: 100, Name: "web", Node: "pve1"}
+
+	taskErr, err := client.GetFailedGuestTask(vm, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.NotNil(t, taskErr)
+	assert.Equal(t, upid, taskErr.UPID)
+	assert.Equal(t, "task failed: start failed: QEMU exited with code 1", taskErr.Error())
+	assert.Len(t, taskErr.LogTail, TaskLogTailLines)
+	assert.Equal(t, "line 25", taskErr.LogTail[TaskLogTailLines-1])
+
+	taskErr, err = client.GetFailedGuestTask(vm, time.Unix(1700000100, 0))
+	require.NoError(t, err)
+	assert.Nil(t, taskErr)
+}
+
+func TestMoveDisk_Validation(t *testing.T) {
+	client := &Client{}
+
+	qemu := &VM{ID: 100, Node: "pve1", Type: VMTypeQemu}
+	lxc := &VM{ID: 101, Node: "pve1", Type: VMTypeLXC, Status: VMStatusStopped}
+
+	_, err := client.MoveDisk(lxc, &MoveDiskOptions{Disk: "rootfs", Storage: "local-lvm"})
+	assert.Error(t, err)
+
+	_, err = client.MoveVolume(qemu, &MoveDiskOptions{Disk: "scsi0", Storage: "local-lvm"})
+	assert.Error(t, err)
+
+	_, err = client.MoveDisk(qemu, nil)
+	assert.Error(t, err)
+
+	_, err = client.MoveDisk(qemu, &MoveDiskOptions{Disk: "scsi0"})
+	assert.Error(t, err)
+
+	_, err = client.MoveVolume(lxc, &MoveDiskOptions{Storage: "local-lvm"})
+	assert.Error(t, err)
+
+	running := &VM{ID: 102, Name: "db", Node: "pve1", Type: VMTypeLXC, Status: VMStatusRunning}
+	_, err = client.MoveVolume(running, &MoveDiskOptions{Disk: "rootfs", Storage: "local-lvm"})
+	assert.EqualError(t, err, "db must be stopped before moving its volumes")
+}
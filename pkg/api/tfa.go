@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrTFARequired is returned when a login needs a second factor that cannot
+// be provided, either because no TFAPrompt is configured or because the
+// account only accepts factors pvetui does not support (WebAuthn, U2F,
+// Yubico OTP).
+var ErrTFARequired = errors.New("two-factor authentication required")
+
+// tfaTicketMarker marks the partial ticket of a login awaiting a second factor.
+const tfaTicketMarker = "!tfa!"
+
+// TFAChallenge describes the second factors a login accepts.
+type TFAChallenge struct {
+	TOTP     bool
+	Recovery bool
+	WebAuthn bool
+	U2F      bool
+	Yubico   bool
+}
+
+// TFAPrompt asks the user for the TOTP code of a login. It is called on a
+// goroutine of its own while authenticating, which may happen in the
+// background when a ticket expires; requests made meanwhile fail with
+// ErrTFARequired instead of waiting for the code.
+type TFAPrompt func(challenge TFAChallenge) (string, error)
+
+// IsTFATicket reports whether a ticket is the partial ticket of a login
+// awaiting a second factor.
+func IsTFATicket(ticket string) bool {
+	return strings.Contains(ticket, tfaTicketMarker)
+}
+
+// ParseTFAChallenge decodes the challenge embedded in a partial ticket like
+// "PVE:!tfa!%7B%22totp%22%3Atrue%7D:65A0B1C2::signature".
+func ParseTFAChallenge(ticket string) (TFAChallenge, error) {
+	_, encoded, found := strings.Cut(ticket, tfaTicketMarker)
+	if !found {
+		return TFAChallenge{}, fmt.Errorf("not a two-factor ticket")
+	}
+
+	encoded, _, _ = strings.Cut(encoded, ":")
+
+	decoded, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return TFAChallenge{}, fmt.Errorf("invalid two-factor challenge: %w", err)
+	}
+
+	// Factors are listed with their parameters; only presence matters here
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(decoded), &raw); err != nil {
+		return TFAChallenge{}, fmt.Errorf("invalid two-factor challenge: %w", err)
+	}
+
+	present := func(key string) bool {
+		value, ok := raw[key]
+
+		return ok && string(value) != "false" && string(value) != "null"
+	}
+
+	return TFAChallenge{
+		TOTP:     present("totp"),
+		Recovery: present("recovery"),
+		WebAuthn: present("webauthn"),
+		U2F:      present("u2f"),
+		Yubico:   present("yubico"),
+	}, nil
+}
+
+// tfaUnsupportedError explains a challenge without TOTP.
+func tfaUnsupportedError(challenge TFAChallenge) error {
+	var factors []string
+
+	if challenge.WebAuthn {
+		factors = append(factors, "WebAuthn")
+	}
+
+	if challenge.U2F {
+		factors = append(factors, "U2F")
+	}
+
+	if challenge.Yubico {
+		factors = append(factors, "Yubico OTP")
+	}
+
+	if len(factors) == 0 {
+		factors = append(factors, "an unsupported second factor")
+	}
+
+	return fmt.Errorf("%w: the account uses %s, which pvetui does not support; use an API token instead",
+		ErrTFARequired, strings.Join(factors, "/"))
+}
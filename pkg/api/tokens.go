@@ -0,0 +1,222 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// APIToken is an API token of a user.
+type APIToken struct {
+	UserID  string // user@realm
+	TokenID string
+	Comment string
+	// PrivilegeSeparation limits the token to its own ACL entries instead
+	// of the permissions of its user.
+	PrivilegeSeparation bool
+	// Expire is the expiry as a Unix timestamp, 0 when the token never expires.
+	Expire int64
+}
+
+// FullID returns the token ID in the form user@realm!tokenid.
+func (t *APIToken) FullID() string {
+	return t.UserID + "!" + t.TokenID
+}
+
+// ExpiresAt returns when the token expires, and false if it never does.
+func (t *APIToken) ExpiresAt() (time.Time, bool) {
+	if t.Expire <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(t.Expire, 0), true
+}
+
+// ACLEntry grants a role on a path to a user, group or token.
+type ACLEntry struct {
+	Path      string
+	Type      string // user, group or token
+	UGID      string // The user, group or full token ID
+	Role      string
+	Propagate bool
+}
+
+// tokenPath returns the API path of a user's token.
+func tokenPath(userID, tokenID string) string {
+	return fmt.Sprintf("/access/users/%s/token/%s", url.PathEscape(userID), url.PathEscape(tokenID))
+}
+
+// GetAPIToken retrieves the privilege separation and expiry of a user's token.
+func (c *Client) GetAPIToken(userID, tokenID string) (*APIToken, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry(tokenPath(userID, tokenID), &res); err != nil {
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for API token")
+	}
+
+	return &APIToken{
+		UserID:              userID,
+		TokenID:             tokenID,
+		Comment:             getString(data, "comment"),
+		PrivilegeSeparation: getBool(data, "privsep"),
+		Expire:              int64(getFloat(data, "expire")),
+	}, nil
+}
+
+// CreateAPIToken creates a token and returns its secret, which can't be
+// retrieved again later.
+func (c *Client) CreateAPIToken(token APIToken) (string, error) {
+	privsep := 0
+	if token.PrivilegeSeparation {
+		privsep = 1
+	}
+
+	data := map[string]interface{}{
+		"privsep": privsep,
+		"expire":  token.Expire,
+	}
+
+	if token.Comment != "" {
+		data["comment"] = token.Comment
+	}
+
+	var res map[string]interface{}
+	if err := c.PostWithResponse(tokenPath(token.UserID, token.TokenID), data, &res); err != nil {
+		return "", fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	created, ok := res["data"].(map[string]interface{})
+	if !ok || getString(created, "value") == "" {
+		return "", fmt.Errorf("failed to create API token: no secret received")
+	}
+
+	return getString(created, "value"), nil
+}
+
+// DeleteAPIToken deletes a user's token.
+func (c *Client) DeleteAPIToken(userID, tokenID string) error {
+	if err := c.Delete(tokenPath(userID, tokenID)); err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+
+	return nil
+}
+
+// GetTokenACL lists the ACL entries granted to a token.
+func (c *Client) GetTokenACL(fullTokenID string) ([]ACLEntry, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry("/access/acl", &res); err != nil {
+		return nil, fmt.Errorf("failed to get ACL: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for ACL")
+	}
+
+	var entries []ACLEntry
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok || getString(entry, "type") != "token" || getString(entry, "ugid") != fullTokenID {
+			continue
+		}
+
+		entries = append(entries, ACLEntry{
+			Path:      getString(entry, "path"),
+			Type:      "token",
+			UGID:      fullTokenID,
+			Role:      getString(entry, "roleid"),
+			Propagate: getBool(entry, "propagate"),
+		})
+	}
+
+	return entries, nil
+}
+
+// GrantTokenACL grants the role of entry on its path to a token.
+func (c *Client) GrantTokenACL(fullTokenID string, entry ACLEntry) error {
+	propagate := 0
+	if entry.Propagate {
+		propagate = 1
+	}
+
+	data := map[string]interface{}{
+		"path":      entry.Path,
+		"roles":     entry.Role,
+		"tokens":    fullTokenID,
+		"propagate": propagate,
+	}
+
+	if err := c.httpClient.Put(c.requestContext(), "/access/acl", data, nil); err != nil {
+		return fmt.Errorf("failed to grant %s on %s: %w", entry.Role, entry.Path, err)
+	}
+
+	return nil
+}
+
+// RotateAPIToken creates newTokenID as a replacement of current with the
+// same privilege separation and comment and, with privilege separation,
+// the same ACL entries. It returns the secret of the new token. The current
+// token is kept; delete it once the new one is in use. If granting the ACL
+// entries fails, the new token is deleted again.
+func (c *Client) RotateAPIToken(current *APIToken, newTokenID string, expire int64) (string, error) {
+	var acl []ACLEntry
+
+	if current.PrivilegeSeparation {
+		var err error
+		if acl, err = c.GetTokenACL(current.FullID()); err != nil {
+			return "", err
+		}
+	}
+
+	replacement := APIToken{
+		UserID:              current.UserID,
+		TokenID:             newTokenID,
+		Comment:             current.Comment,
+		PrivilegeSeparation: current.PrivilegeSeparation,
+		Expire:              expire,
+	}
+
+	secret, err := c.CreateAPIToken(replacement)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range acl {
+		if err := c.GrantTokenACL(replacement.FullID(), entry); err != nil {
+			_ = c.DeleteAPIToken(replacement.UserID, replacement.TokenID)
+
+			return "", err
+		}
+	}
+
+	return secret, nil
+}
+
+// UseAPIToken switches the client to another token of the same user, e.g.
+// after a rotation. It has no effect with other authentication.
+func (c *Client) UseAPIToken(fullTokenID, secret string) {
+	if c.authManager == nil || !c.authManager.IsTokenAuth() {
+		return
+	}
+
+	c.authManager.SetAPIToken(fmt.Sprintf("PVEAPIToken=%s=%s", fullTokenID, secret))
+}
+
+// rotationSuffix matches the date suffix added by RotatedTokenID.
+var rotationSuffix = regexp.MustCompile(`-\d{8}-\d{4}$`)
+
+// RotatedTokenID returns the ID for the replacement of a token: its ID with
+// a date suffix, replacing the suffix of an earlier rotation.
+func RotatedTokenID(tokenID string, now time.Time) string {
+	base := strings.TrimSuffix(rotationSuffix.ReplaceAllString(tokenID, ""), "-")
+
+	return base + "-" + now.Format("20060102-1504")
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetAPIToken(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/access/users/pvetui@pve/token/tui", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"comment": "terminal UI",
+			"privsep": 1,
+			"expire":  1767225600,
+		}})
+	})
+
+	token, err := client.GetAPIToken("pvetui@pve", "tui")
+	require.NoError(t, err)
+
+	assert.Equal(t, "pvetui@pve!tui", token.FullID())
+	assert.Equal(t, "terminal UI", token.Comment)
+	assert.True(t, token.PrivilegeSeparation)
+
+	expires, ok := token.ExpiresAt()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1767225600), expires.Unix())
+
+	_, ok = (&APIToken{}).ExpiresAt()
+	assert.False(t, ok)
+}
+
+func TestClient_RotateAPIToken(t *testing.T) {
+	var (
+		created map[string]interface{}
+		granted []map[string]interface{}
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/access/acl":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"path": "/vms", "type": "token", "ugid": "pvetui@pve!tui", "roleid": "PVEVMAdmin", "propagate": 1},
+				map[string]interface{}{"path": "/", "type": "user", "ugid": "pvetui@pve", "roleid": "PVEAuditor", "propagate": 1},
+				map[string]interface{}{"path": "/", "type": "token", "ugid": "root@pam!other", "roleid": "Administrator", "propagate": 1},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/access/users/pvetui@pve/token/tui-20261016-1200":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"full-tokenid": "pvetui@pve!tui-20261016-1200",
+				"value":        "new-secret",
+			}})
+		case r.Method == http.MethodPut && r.URL.Path == "/access/acl":
+			var entry map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&entry))
+			granted = append(granted, entry)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": nil})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	current := &APIToken{UserID: "pvetui@pve", TokenID: "tui", Comment: "terminal UI", PrivilegeSeparation: true}
+	newID := RotatedTokenID(current.TokenID, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+
+	secret, err := client.RotateAPIToken(current, newID, 1790000000)
+	require.NoError(t, err)
+	assert.Equal(t, "new-secret", secret)
+
+	assert.Equal(t, float64(1), created["privsep"])
+	assert.Equal(t, float64(1790000000), created["expire"])
+	assert.Equal(t, "terminal UI", created["comment"])
+
+	require.Len(t, granted, 1)
+	assert.Equal(t, "/vms", granted[0]["path"])
+	assert.Equal(t, "PVEVMAdmin", granted[0]["roles"])
+	assert.Equal(t, "pvetui@pve!tui-20261016-1200", granted[0]["tokens"])
+}
+
+func TestRotatedTokenID(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, "pvetui-20261016-0905", RotatedTokenID("pvetui", now))
+	assert.Equal(t, "pvetui-20261016-0905", RotatedTokenID("pvetui-20260101-1200", now))
+}
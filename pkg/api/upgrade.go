@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PackageUpdate is a pending apt package update of a node.
+type PackageUpdate struct {
+	Package    string `json:"Package"`
+	OldVersion string `json:"OldVersion"`
+	Version    string `json:"Version"`
+	Priority   string `json:"Priority"`
+}
+
+// ZFSPool is a ZFS pool of a node.
+type ZFSPool struct {
+	Name string `json:"name"`
+	// Health is the pool state, e.g. "ONLINE" or "DEGRADED".
+	Health string `json:"health"`
+	Size   int64  `json:"size"`
+	Alloc  int64  `json:"alloc"`
+	Free   int64  `json:"free"`
+}
+
+// CephHealth is the overall health of the Ceph cluster.
+type CephHealth struct {
+	// Status is "HEALTH_OK", "HEALTH_WARN" or "HEALTH_ERR".
+	Status string `json:"status"`
+	// Checks are the summaries of the failing health checks.
+	Checks []string `json:"checks"`
+}
+
+// GetPendingUpdates lists the package updates available on a node, sorted by
+// package name. It reads the package index of the last `apt update` and does
+// not refresh it.
+func (c *Client) GetPendingUpdates(nodeName string) ([]PackageUpdate, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/apt/update", nodeName), &res); err != nil {
+		return nil, fmt.Errorf("failed to get pending updates: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for pending updates")
+	}
+
+	updates := make([]PackageUpdate, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		updates = append(updates, PackageUpdate{
+			Package:    getString(entry, "Package"),
+			OldVersion: getString(entry, "OldVersion"),
+			Version:    getString(entry, "Version"),
+			Priority:   getString(entry, "Priority"),
+		})
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Package < updates[j].Package
+	})
+
+	return updates, nil
+}
+
+// GetZFSPools lists the ZFS pools of a node.
+func (c *Client) GetZFSPools(nodeName string) ([]ZFSPool, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/disks/zfs", nodeName), &res); err != nil {
+		return nil, fmt.Errorf("failed to get ZFS pools: %w", err)
+	}
+
+	data, ok := res["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for ZFS pools")
+	}
+
+	pools := make([]ZFSPool, 0, len(data))
+
+	for _, item := range data {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pools = append(pools, ZFSPool{
+			Name:   getString(entry, "name"),
+			Health: getString(entry, "health"),
+			Size:   int64(getFloat(entry, "size")),
+			Alloc:  int64(getFloat(entry, "alloc")),
+			Free:   int64(getFloat(entry, "free")),
+		})
+	}
+
+	return pools, nil
+}
+
+// GetCephHealth returns the health of the Ceph cluster. It fails on clusters
+// without Ceph.
+func (c *Client) GetCephHealth() (*CephHealth, error) {
+	var res map[string]interface{}
+	if err := c.GetNoRetry("/cluster/ceph/status", &res); err != nil {
+		return nil, fmt.Errorf("failed to get Ceph status: %w", err)
+	}
+
+	data, ok := res["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected format for Ceph status")
+	}
+
+	health, ok := data["health"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no health in Ceph status")
+	}
+
+	result := &CephHealth{Status: getString(health, "status")}
+
+	if checks, ok := health["checks"].(map[string]interface{}); ok {
+		for name, check := range checks {
+			message := name
+
+			if details, ok := check.(map[string]interface{}); ok {
+				if summary, ok := details["summary"].(map[string]interface{}); ok {
+					if text := getString(summary, "message"); text != "" {
+						message = text
+					}
+				}
+			}
+
+			result.Checks = append(result.Checks, message)
+		}
+
+		sort.Strings(result.Checks)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpgradeReadinessData(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/apt/update":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"Package": "pve-manager", "OldVersion": "8.1.4", "Version": "8.2.4", "Priority": "important"},
+				map[string]interface{}{"Package": "curl", "OldVersion": "7.88.1-10", "Version": "7.88.1-10+deb12u5", "Priority": "optional"},
+			}})
+		case "/nodes/pve1/disks/zfs":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"name": "rpool", "health": "DEGRADED", "size": 1000, "alloc": 400, "free": 600},
+			}})
+		case "/cluster/ceph/status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"health": map[string]interface{}{
+					"status": "HEALTH_WARN",
+					"checks": map[string]interface{}{
+						"OSD_DOWN": map[string]interface{}{"summary": map[string]interface{}{"message": "1 osds down"}},
+					},
+				},
+			}})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	updates, err := client.GetPendingUpdates("pve1")
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	assert.Equal(t, "curl", updates[0].Package, "sorted by package name")
+	assert.Equal(t, PackageUpdate{Package: "pve-manager", OldVersion: "8.1.4", Version: "8.2.4", Priority: "important"}, updates[1])
+
+	pools, err := client.GetZFSPools("pve1")
+	require.NoError(t, err)
+	assert.Equal(t, []ZFSPool{{Name: "rpool", Health: "DEGRADED", Size: 1000, Alloc: 400, Free: 600}}, pools)
+
+	health, err := client.GetCephHealth()
+	require.NoError(t, err)
+	assert.Equal(t, "HEALTH_WARN", health.Status)
+	assert.Equal(t, []string{"1 osds down"}, health.Checks)
+
+	_, err = client.GetPendingUpdates("pve2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PVEVersion is the release of Proxmox VE a cluster runs, e.g. 8.2.4.
+type PVEVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParsePVEVersion parses a Proxmox VE release such as "8.2.4", "7.4" or
+// "pve-manager/8.2.4/faa83925c9641325".
+func ParsePVEVersion(version string) (PVEVersion, error) {
+	version = strings.TrimSpace(version)
+	if rest, ok := strings.CutPrefix(version, "pve-manager/"); ok {
+		version, _, _ = strings.Cut(rest, "/")
+	}
+
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return PVEVersion{}, fmt.Errorf("invalid Proxmox VE version %q", version)
+	}
+
+	numbers := make([]int, 3)
+
+	for i, part := range parts {
+		// Drop suffixes like "-1" or "~rc1"
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end >= 0 {
+			part = part[:end]
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return PVEVersion{}, fmt.Errorf("invalid Proxmox VE version %q", version)
+		}
+
+		numbers[i] = n
+	}
+
+	return PVEVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// IsZero reports whether the version is unknown.
+func (v PVEVersion) IsZero() bool {
+	return v == PVEVersion{}
+}
+
+// AtLeast reports whether the version is major.minor or later.
+func (v PVEVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// String returns the version as "major.minor.patch".
+func (v PVEVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Feature is a part of the Proxmox API that only newer releases provide.
+type Feature struct {
+	Name  string
+	Major int
+	Minor int
+}
+
+// FeatureContainerInterfaces lists the addresses of running containers
+// (GET /nodes/{node}/lxc/{vmid}/interfaces).
+var FeatureContainerInterfaces = Feature{Name: "Container interface addresses", Major: 8, Minor: 1}
+
+// UnsupportedFeatureError is returned for features the cluster's Proxmox VE
+// release does not provide.
+type UnsupportedFeatureError struct {
+	Feature Feature
+	Version PVEVersion
+}
+
+// Error names the feature, the release it needs and the release the cluster
+// runs.
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires Proxmox VE %d.%d or later (the cluster runs %s)",
+		e.Feature.Name, e.Feature.Major, e.Feature.Minor, e.Version)
+}
+
+// detectPVEVersion reads the Proxmox VE release of the cluster. Features are
+// not gated when it can't be read.
+func (c *Client) detectPVEVersion(ctx context.Context) {
+	var res map[string]interface{}
+	if err := c.httpClient.Get(ctx, "/version", &res); err != nil {
+		c.logger.Debug("Failed to get the Proxmox VE version: %v", err)
+
+		return
+	}
+
+	c.checkResponse("/version", res)
+
+	data, _ := res["data"].(map[string]interface{})
+
+	version, err := ParsePVEVersion(getString(data, "version"))
+	if err != nil {
+		c.logger.Debug("Failed to parse the Proxmox VE version: %v", err)
+
+		return
+	}
+
+	c.pveVersion = version
+	c.logger.Debug("Connected to Proxmox VE %s", version)
+}
+
+// PVEVersion returns the Proxmox VE release of the cluster, which is zero
+// when it could not be detected.
+func (c *Client) PVEVersion() PVEVersion {
+	return c.pveVersion
+}
+
+// Supports reports whether the cluster provides a feature. Features are
+// assumed to be available when the release is unknown.
+func (c *Client) Supports(feature Feature) bool {
+	return c.pveVersion.IsZero() || c.pveVersion.AtLeast(feature.Major, feature.Minor)
+}
+
+// RequireFeature returns an UnsupportedFeatureError if the cluster does not
+// provide a feature.
+func (c *Client) RequireFeature(feature Feature) error {
+	if c.Supports(feature) {
+		return nil
+	}
+
+	return &UnsupportedFeatureError{Feature: feature, Version: c.pveVersion}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePVEVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  PVEVersion
+	}{
+		{"8.2.4", PVEVersion{Major: 8, Minor: 2, Patch: 4}},
+		{"7.4", PVEVersion{Major: 7, Minor: 4}},
+		{"pve-manager/8.1.3/b46aac3b42da5d15", PVEVersion{Major: 8, Minor: 1, Patch: 3}},
+		{"9.0.0~11", PVEVersion{Major: 9}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePVEVersion(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	_, err := ParsePVEVersion("eight")
+	assert.Error(t, err)
+}
+
+func TestClient_RequireFeature(t *testing.T) {
+	client := &Client{}
+	assert.True(t, client.Supports(FeatureContainerInterfaces), "features are not gated while the version is unknown")
+
+	client.pveVersion = PVEVersion{Major: 8, Minor: 1}
+	assert.NoError(t, client.RequireFeature(FeatureContainerInterfaces))
+
+	client.pveVersion = PVEVersion{Major: 7, Minor: 4, Patch: 3}
+
+	err := client.RequireFeature(FeatureContainerInterfaces)
+
+	var unsupported *UnsupportedFeatureError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "Container interface addresses requires Proxmox VE 8.1 or later (the cluster runs 7.4.3)", err.Error())
+}
+
+func TestClient_DetectPVEVersion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/version", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{"version":"7.4-3","release":"7.4","repoid":"9002ab8a"}}`))
+	})
+
+	client.detectPVEVersion(t.Context())
+	assert.Equal(t, PVEVersion{Major: 7, Minor: 4}, client.PVEVersion())
+	assert.False(t, client.Supports(FeatureContainerInterfaces))
+}
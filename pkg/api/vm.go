@@ -5,7 +5,10 @@ import (
 	"strings"
 )
 
-// GetVmStatus retrieves current status metrics for a VM or LXC.
+// GetVmStatus retrieves the current status metrics of a VM or LXC from its
+// status endpoint and enriches it like EnrichVM. Cluster loads and refreshes
+// take the metrics of all guests from cluster resources instead; this is
+// for the detail view of one guest.
 func (c *Client) GetVmStatus(vm *VM) error {
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
@@ -17,7 +20,7 @@ func (c *Client) GetVmStatus(vm *VM) error {
 	var res map[string]interface{}
 
 	endpoint := fmt.Sprintf("/nodes/%s/%s/%d/status/current", vm.Node, vm.Type, vm.ID)
-	if err := c.GetWithCache(endpoint, &res, VMDataTTL); err != nil {
+	if err := c.GetWithCache(endpoint, &res, c.CacheTTLs().Status); err != nil {
 		return err
 	}
 
@@ -33,6 +36,12 @@ func (c *Client) GetVmStatus(vm *VM) error {
 		}
 	}
 
+	if cpusVal, ok := data["cpus"]; ok {
+		if cpusFloat, ok := cpusVal.(float64); ok {
+			vm.MaxCPU = int(cpusFloat)
+		}
+	}
+
 	if memVal, ok := data["mem"]; ok {
 		if memFloat, ok := memVal.(float64); ok {
 			vm.Mem = int64(memFloat)
@@ -103,13 +112,21 @@ func (c *Client) GetVmStatus(vm *VM) error {
 		}
 	}
 
+	c.enrichVMDetails(vm)
+
+	return nil
+}
+
+// enrichVMDetails loads the configuration and guest agent data of a running
+// guest and marks it enriched. The caller holds vm.mu.
+func (c *Client) enrichVMDetails(vm *VM) {
 	// For QEMU VMs, check guest agent and get network interfaces
 	if vm.Type == VMTypeQemu && vm.Status == VMStatusRunning {
 		// Get VM config to identify configured MAC addresses
 		var configRes map[string]interface{}
 
 		configEndpoint := fmt.Sprintf("/nodes/%s/qemu/%d/config", vm.Node, vm.ID)
-		if err := c.GetWithCache(configEndpoint, &configRes, VMDataTTL); err == nil {
+		if err := c.GetWithCache(configEndpoint, &configRes, c.CacheTTLs().Config); err == nil {
 			if configData, ok := configRes["data"].(map[string]interface{}); ok {
 				populateConfiguredMACs(vm, configData)
 				populateConfigDetails(vm, configData)
@@ -247,7 +264,7 @@ func (c *Client) GetVmStatus(vm *VM) error {
 		var configRes map[string]interface{}
 
 		configEndpoint := fmt.Sprintf("/nodes/%s/lxc/%d/config", vm.Node, vm.ID)
-		if err := c.GetWithCache(configEndpoint, &configRes, VMDataTTL); err == nil {
+		if err := c.GetWithCache(configEndpoint, &configRes, c.CacheTTLs().Config); err == nil {
 			if configData, ok := configRes["data"].(map[string]interface{}); ok {
 				populateConfiguredMACs(vm, configData)
 				populateConfigDetails(vm, configData)
@@ -291,8 +308,6 @@ func (c *Client) GetVmStatus(vm *VM) error {
 	}
 
 	vm.Enriched = true
-
-	return nil
 }
 
 // GetDetailedVmInfo retrieves complete information about a VM by combining status and config data (cached).
@@ -307,7 +322,7 @@ func (c *Client) GetDetailedVmInfo(node, vmType string, vmid int) (*VM, error) {
 	var statusRes map[string]interface{}
 
 	statusEndpoint := fmt.Sprintf("/nodes/%s/%s/%d/status/current", node, vmType, vmid)
-	if err := c.GetWithCache(statusEndpoint, &statusRes, VMDataTTL); err != nil {
+	if err := c.GetWithCache(statusEndpoint, &statusRes, c.CacheTTLs().Status); err != nil {
 		return nil, fmt.Errorf("failed to get VM status: %w", err)
 	}
 
@@ -331,6 +346,10 @@ func (c *Client) GetDetailedVmInfo(node, vmType string, vmid int) (*VM, error) {
 		vm.CPU = cpu
 	}
 
+	if cpus, okCPUs := statusData["cpus"].(float64); okCPUs {
+		vm.MaxCPU = int(cpus)
+	}
+
 	if mem, okMem := statusData["mem"].(float64); okMem {
 		vm.Mem = int64(mem)
 	}
@@ -371,9 +390,8 @@ func (c *Client) GetDetailedVmInfo(node, vmType string, vmid int) (*VM, error) {
 		vm.HAState = hastate
 	}
 
-	if lock, okLock := statusData["lock"].(string); okLock {
-		vm.Lock = lock
-	}
+	// The lock is omitted once it's released
+	vm.Lock, _ = statusData["lock"].(string)
 
 	if tags, okTags := statusData["tags"].(string); okTags {
 		vm.Tags = tags
@@ -391,7 +409,7 @@ func (c *Client) GetDetailedVmInfo(node, vmType string, vmid int) (*VM, error) {
 	var configRes map[string]interface{}
 
 	configEndpoint := fmt.Sprintf("/nodes/%s/%s/%d/config", node, vmType, vmid)
-	if err := c.GetWithCache(configEndpoint, &configRes, VMDataTTL); err != nil {
+	if err := c.GetWithCache(configEndpoint, &configRes, c.CacheTTLs().Config); err != nil {
 		return nil, fmt.Errorf("failed to get VM config: %w", err)
 	}
 
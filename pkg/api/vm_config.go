@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -30,6 +29,10 @@ type VMConfig struct {
 
 	// Storage (for resizing, etc.)
 	Disks map[string]int64 `json:"disks,omitempty"` // disk name -> size in bytes
+
+	// Digest identifies the config as it was read. Updates carrying it are
+	// rejected by Proxmox if the config was changed in the meantime.
+	Digest string `json:"digest,omitempty"`
 }
 
 // GetVMConfig fetches the configuration for a VM or container.
@@ -57,9 +60,9 @@ func (c *Client) UpdateVMConfig(vm *VM, config *VMConfig) error {
 	data := buildConfigPayload(vm.Type, config)
 
 	if vm.Type == VMTypeLXC {
-		return c.httpClient.Put(context.Background(), endpoint, data, nil)
+		return c.httpClient.Put(c.requestContext(), endpoint, data, nil)
 	} else if vm.Type == VMTypeQemu {
-		return c.httpClient.Post(context.Background(), endpoint, data, nil)
+		return c.httpClient.Post(c.requestContext(), endpoint, data, nil)
 	}
 
 	return fmt.Errorf("unsupported VM type: %s", vm.Type)
@@ -73,7 +76,7 @@ func (c *Client) ResizeVMStorage(vm *VM, disk string, size string) error {
 		"size": size, // Proxmox expects size as string (e.g., "+10G")
 	}
 
-	return c.httpClient.Put(context.Background(), endpoint, data, nil)
+	return c.httpClient.Put(c.requestContext(), endpoint, data, nil)
 }
 
 // UpdateVMResources updates CPU and memory for a VM or container.
@@ -85,9 +88,9 @@ func (c *Client) UpdateVMResources(vm *VM, cores int, memory int64) error {
 	}
 
 	if vm.Type == VMTypeLXC {
-		return c.httpClient.Put(context.Background(), endpoint, data, nil)
+		return c.httpClient.Put(c.requestContext(), endpoint, data, nil)
 	} else if vm.Type == VMTypeQemu {
-		return c.httpClient.Post(context.Background(), endpoint, data, nil)
+		return c.httpClient.Post(c.requestContext(), endpoint, data, nil)
 	}
 
 	return fmt.Errorf("unsupported VM type: %s", vm.Type)
@@ -129,6 +132,10 @@ func parseVMConfig(vmType string, data map[string]interface{}) *VMConfig {
 		cfg.Description = v
 	}
 
+	if v, ok := data["digest"].(string); ok {
+		cfg.Digest = v
+	}
+
 	if v, ok := data["onboot"].(float64); ok {
 		b := v != 0
 		cfg.OnBoot = &b
@@ -187,6 +194,10 @@ func buildConfigPayload(vmType string, config *VMConfig) map[string]interface{}
 		data["description"] = config.Description
 	}
 
+	if config.Digest != "" {
+		data["digest"] = config.Digest
+	}
+
 	if config.OnBoot != nil {
 		if *config.OnBoot {
 			data["onboot"] = 1
@@ -217,3 +228,55 @@ func buildConfigPayload(vmType string, config *VMConfig) map[string]interface{}
 
 	return data
 }
+
+// ConfigChange is a setting that differs between two configs of a guest.
+type ConfigChange struct {
+	Setting string
+	Old     string
+	New     string
+}
+
+// DiffVMConfig lists the editable settings that differ between two configs
+// of a guest, e.g. the config an editor loaded and the current one.
+func DiffVMConfig(before, after *VMConfig) []ConfigChange {
+	var changes []ConfigChange
+
+	add := func(setting, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, ConfigChange{Setting: setting, Old: oldValue, New: newValue})
+		}
+	}
+
+	count := func(n int64) string {
+		if n == 0 {
+			return ""
+		}
+
+		return strconv.FormatInt(n, 10)
+	}
+
+	boolean := func(b *bool) string {
+		switch {
+		case b == nil:
+			return ""
+		case *b:
+			return "yes"
+		default:
+			return "no"
+		}
+	}
+
+	add("name", before.Name, after.Name)
+	add("hostname", before.Hostname, after.Hostname)
+	add("cores", count(int64(before.Cores)), count(int64(after.Cores)))
+	add("sockets", count(int64(before.Sockets)), count(int64(after.Sockets)))
+	add("memory (MB)", count(before.Memory/1024/1024), count(after.Memory/1024/1024))
+	add("description", before.Description, after.Description)
+	add("start at boot", boolean(before.OnBoot), boolean(after.OnBoot))
+	add("cpu", before.CPUType, after.CPUType)
+	add("maxmem", count(before.MaxMem), count(after.MaxMem))
+	add("boot", before.BootOrder, after.BootOrder)
+	add("swap (MB)", count(before.Swap/1024/1024), count(after.Swap/1024/1024))
+
+	return changes
+}
@@ -26,6 +26,7 @@ func TestVMConfig_ParseAndBuild(t *testing.T) {
 				"cpu":         "host",
 				"maxmem":      16384.0,
 				"boot":        "order=scsi0;net0",
+				"digest":      "4f2e8a1c",
 			},
 			expected: &VMConfig{
 				Name:        "test-vm",
@@ -37,6 +38,7 @@ func TestVMConfig_ParseAndBuild(t *testing.T) {
 				CPUType:     "host",
 				MaxMem:      16384,
 				BootOrder:   "order=scsi0;net0",
+				Digest:      "4f2e8a1c",
 			},
 		},
 		{
@@ -100,6 +102,7 @@ func TestVMConfig_ParseAndBuild(t *testing.T) {
 			assert.Equal(t, tt.expected.Memory, result.Memory)
 			assert.Equal(t, tt.expected.Description, result.Description)
 			assert.Equal(t, tt.expected.OnBoot, result.OnBoot)
+			assert.Equal(t, tt.expected.Digest, result.Digest)
 
 			if tt.vmType == VMTypeQemu {
 				assert.Equal(t, tt.expected.CPUType, result.CPUType)
@@ -132,6 +135,11 @@ func TestVMConfig_ParseAndBuild(t *testing.T) {
 			if tt.expected.Description != "" {
 				assert.Equal(t, tt.expected.Description, payload["description"])
 			}
+			if tt.expected.Digest != "" {
+				assert.Equal(t, tt.expected.Digest, payload["digest"])
+			} else {
+				assert.NotContains(t, payload, "digest")
+			}
 			if tt.expected.OnBoot != nil {
 				if *tt.expected.OnBoot {
 					assert.Equal(t, 1, payload["onboot"])
@@ -160,3 +168,18 @@ func TestVMConfig_ParseAndBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffVMConfig(t *testing.T) {
+	onboot := true
+	before := &VMConfig{Name: "web", Cores: 2, Memory: 2048 * 1024 * 1024, Description: "old", Digest: "a"}
+	after := &VMConfig{Name: "web", Cores: 4, Memory: 2048 * 1024 * 1024, OnBoot: &onboot, Digest: "b"}
+
+	assert.Equal(t, []ConfigChange{
+		{Setting: "cores", Old: "2", New: "4"},
+		{Setting: "description", Old: "old", New: ""},
+		{Setting: "start at boot", Old: "", New: "yes"},
+	}, DiffVMConfig(before, after))
+
+	// Only the digest differs, e.g. after a disk resize
+	assert.Empty(t, DiffVMConfig(before, &VMConfig{Name: "web", Cores: 2, Memory: 2048 * 1024 * 1024, Description: "old", Digest: "c"}))
+}
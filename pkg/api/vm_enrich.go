@@ -6,42 +6,38 @@ import (
 	"sync"
 )
 
-// EnrichVMs enriches all VMs in the cluster with detailed status information.
+// EnrichVMs enriches the running guests of the cluster with their
+// configuration and guest agent data (see EnrichVM).
 func (c *Client) EnrichVMs(cluster *Cluster) error {
-	const maxConcurrentRequests = 5 // Limit concurrent API requests
-
-	var wg sync.WaitGroup
-
-	errChan := make(chan error, 100) // Buffer for potential errors
-	vmChan := make(chan *VM, 100)    // Channel for VM tasks
-
-	// Count total VMs for error channel sizing
-	totalVMs := 0
+	var vms []*VM
 
 	for _, node := range cluster.Nodes {
-		if node.Online && node.VMs != nil {
-			totalVMs += len(node.VMs)
+		if !node.Online || node.VMs == nil {
+			continue
 		}
-	}
 
-	if totalVMs == 0 {
-		return nil // No VMs to enrich
+		for _, vm := range node.VMs {
+			if vm.Status == VMStatusRunning { // Only enrich running VMs to avoid API overhead
+				vms = append(vms, vm)
+			}
+		}
 	}
 
-	// Start error collector
-	var errors []error
+	return c.enrichVMList(vms)
+}
 
-	done := make(chan struct{})
+// enrichVMList enriches guests with a limited number of concurrent requests.
+func (c *Client) enrichVMList(vms []*VM) error {
+	const maxConcurrentRequests = 5 // Limit concurrent API requests
 
-	go func() {
-		for err := range errChan {
-			if err != nil {
-				errors = append(errors, err)
-			}
-		}
+	if len(vms) == 0 {
+		return nil // No VMs to enrich
+	}
 
-		close(done)
-	}()
+	var wg sync.WaitGroup
+
+	errChan := make(chan error, len(vms))
+	vmChan := make(chan *VM, len(vms))
 
 	// Start workers with limited concurrency
 	for i := 0; i < maxConcurrentRequests; i++ {
@@ -51,39 +47,13 @@ func (c *Client) EnrichVMs(cluster *Cluster) error {
 			defer wg.Done()
 
 			for vm := range vmChan {
-				// Store the current disk usage values from /cluster/resources
-				diskUsage := vm.Disk
-				maxDiskUsage := vm.MaxDisk
-
-				// Get regular VM status info including guest agent data
-				err := c.GetVmStatus(vm)
-
-				// Restore disk usage values from cluster resources if they got overwritten or are zero
-				if vm.Disk == 0 && diskUsage > 0 {
-					vm.Disk = diskUsage
-				}
-
-				if vm.MaxDisk == 0 && maxDiskUsage > 0 {
-					vm.MaxDisk = maxDiskUsage
-				}
-
-				errChan <- err
+				errChan <- c.EnrichVM(vm)
 			}
 		}()
 	}
 
-	// Queue VMs for processing
-	for _, node := range cluster.Nodes {
-		if !node.Online || node.VMs == nil {
-			continue
-		}
-
-		for i := range node.VMs {
-			if node.VMs[i].Status != VMStatusRunning {
-				continue // Only enrich running VMs to avoid API overhead
-			}
-			vmChan <- node.VMs[i]
-		}
+	for _, vm := range vms {
+		vmChan <- vm
 	}
 
 	// Close VM channel to signal workers that all tasks are queued
@@ -92,7 +62,14 @@ func (c *Client) EnrichVMs(cluster *Cluster) error {
 	// Wait for all goroutines to complete
 	wg.Wait()
 	close(errChan)
-	<-done // Wait for error collection to finish
+
+	var errors []error
+
+	for err := range errChan {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("errors updating VM statuses: %v", errors)
@@ -101,6 +78,18 @@ func (c *Client) EnrichVMs(cluster *Cluster) error {
 	return nil
 }
 
+// EnrichVM enriches a single guest with its configuration and guest agent
+// data. Its status metrics come from cluster resources, so unlike
+// GetVmStatus no request is made for its status.
+func (c *Client) EnrichVM(vm *VM) error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	c.enrichVMDetails(vm)
+
+	return nil
+}
+
 // populateConfiguredMACs extracts MAC addresses from the VM configuration (net0, net1, etc.)
 func populateConfiguredMACs(vm *VM, configData map[string]interface{}) {
 	vm.ConfiguredMACs = make(map[string]bool)
@@ -147,6 +136,7 @@ func populateConfiguredMACs(vm *VM, configData map[string]interface{}) {
 // and populates the VM struct with structured configuration information including:
 //   - CPU configuration (cores, sockets)
 //   - System settings (architecture, OS type, boot order)
+//   - Virtual hardware of QEMU VMs (machine type, CPU model, firmware, display)
 //   - Administrative settings (description, auto-start)
 //   - Network interface configuration
 //   - Storage device configuration
@@ -180,6 +170,11 @@ func populateConfigDetails(vm *VM, configData map[string]interface{}) {
 		vm.OSType = ostype
 	}
 
+	// Parse virtual hardware
+	if vm.Type == VMTypeQemu {
+		populateHardwareDetails(vm, configData)
+	}
+
 	// Parse description
 	if desc, ok := configData["description"].(string); ok {
 		vm.Description = desc
@@ -371,6 +366,8 @@ func parseStorageConfig(configData map[string]interface{}, vmType string) []Stor
 		if len(parts) > 0 {
 			// First part is usually storage:size or path
 			mainPart := strings.TrimSpace(parts[0])
+			device.VolID = mainPart
+
 			if strings.Contains(mainPart, ":") {
 				storageParts := strings.SplitN(mainPart, ":", 2)
 				device.Storage = storageParts[0]
@@ -459,7 +456,7 @@ func (c *Client) GetGuestAgentFilesystems(vm *VM) ([]Filesystem, error) {
 
 	endpoint := fmt.Sprintf("/nodes/%s/qemu/%d/agent/get-fsinfo", vm.Node, vm.ID)
 
-	if err := c.GetWithCache(endpoint, &res, VMDataTTL); err != nil {
+	if err := c.GetWithCache(endpoint, &res, c.CacheTTLs().Agent); err != nil {
 		return nil, fmt.Errorf("failed to get filesystem info from guest agent: %w", err)
 	}
 
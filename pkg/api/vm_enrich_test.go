@@ -0,0 +1,29 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStorageConfig_VolID(t *testing.T) {
+	devices := parseStorageConfig(map[string]interface{}{
+		"scsi0": "local-lvm:vm-100-disk-0,size=32G,format=raw",
+	}, VMTypeQemu)
+
+	if assert.Len(t, devices, 1) {
+		assert.Equal(t, "local-lvm", devices[0].Storage)
+		assert.Equal(t, "local-lvm:vm-100-disk-0", devices[0].VolID)
+		assert.Equal(t, "32G", devices[0].Size)
+	}
+
+	devices = parseStorageConfig(map[string]interface{}{
+		"scsi1": "/dev/disk/by-id/ata-disk1,backup=0",
+	}, VMTypeQemu)
+
+	if assert.Len(t, devices, 1) {
+		assert.Equal(t, "/dev/disk/by-id/ata-disk1", devices[0].Storage)
+		assert.Equal(t, "/dev/disk/by-id/ata-disk1", devices[0].VolID)
+		assert.False(t, devices[0].Backup)
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Virtual hardware QEMU uses when the VM config does not set it.
+const (
+	DefaultMachineType = "i440fx"
+	DefaultCPUModel    = "kvm64"
+	DefaultBIOS        = "seabios"
+	DefaultDisplay     = "std"
+)
+
+// deprecatedMachineVersion is the first pinned machine version that is not
+// deprecated. QEMU deprecates versioned machine types six years after their
+// release and removes them later; 4.0 was released in 2019.
+var deprecatedMachineVersion = [2]int{4, 0}
+
+// populateHardwareDetails parses the machine type, CPU model, firmware and
+// display settings of a QEMU VM config.
+func populateHardwareDetails(vm *VM, configData map[string]interface{}) {
+	if machine, ok := configData["machine"].(string); ok {
+		vm.MachineType = firstConfigValue(machine, "type")
+	}
+
+	if cpu, ok := configData["cpu"].(string); ok {
+		vm.CPUModel = firstConfigValue(cpu, "cputype")
+	}
+
+	if bios, ok := configData["bios"].(string); ok {
+		vm.BIOS = bios
+	}
+
+	if vga, ok := configData["vga"].(string); ok {
+		vm.Display = vga
+	}
+}
+
+// firstConfigValue returns the main value of a property string like
+// "host,flags=+aes" or "cputype=host,flags=+aes", where key is the name of
+// the main value.
+func firstConfigValue(value, key string) string {
+	first, _, _ := strings.Cut(value, ",")
+
+	return strings.TrimPrefix(strings.TrimSpace(first), key+"=")
+}
+
+// MachineVersion returns the version a machine type is pinned to, e.g. "6.2"
+// for "pc-q35-6.2+pve0". Unpinned types like "q35" follow the installed QEMU
+// and have no version.
+func MachineVersion(machine string) (version string, pinned bool) {
+	for _, prefix := range []string{"pc-i440fx-", "pc-q35-", "pc-"} {
+		if rest, found := strings.CutPrefix(machine, prefix); found {
+			version, _, _ = strings.Cut(rest, "+")
+			if _, _, ok := parseMachineVersion(version); ok {
+				return version, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// MachineTypeDeprecated reports whether a VM is pinned to a machine version
+// that QEMU has deprecated or removed. Such VMs fail to start once the
+// version is gone; switching to a newer version changes the virtual
+// hardware, which some guests (e.g. Windows) notice.
+func MachineTypeDeprecated(machine string) bool {
+	version, pinned := MachineVersion(machine)
+	if !pinned {
+		return false
+	}
+
+	major, minor, _ := parseMachineVersion(version)
+
+	if major != deprecatedMachineVersion[0] {
+		return major < deprecatedMachineVersion[0]
+	}
+
+	return minor < deprecatedMachineVersion[1]
+}
+
+// parseMachineVersion parses a "major.minor" machine version.
+func parseMachineVersion(version string) (major, minor int, ok bool) {
+	majorText, minorText, found := strings.Cut(version, ".")
+	if !found {
+		return 0, 0, false
+	}
+
+	major, errMajor := strconv.Atoi(majorText)
+	minor, errMinor := strconv.Atoi(minorText)
+
+	return major, minor, errMajor == nil && errMinor == nil
+}
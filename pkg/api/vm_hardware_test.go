@@ -0,0 +1,51 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateConfigDetails_Hardware(t *testing.T) {
+	vm := &VM{Type: VMTypeQemu}
+	populateConfigDetails(vm, map[string]interface{}{
+		"machine": "pc-q35-6.2+pve0,viommu=intel",
+		"cpu":     "cputype=host,flags=+aes",
+		"bios":    "ovmf",
+		"vga":     "qxl,memory=32",
+	})
+
+	assert.Equal(t, "pc-q35-6.2+pve0", vm.MachineType)
+	assert.Equal(t, "host", vm.CPUModel)
+	assert.Equal(t, "ovmf", vm.BIOS)
+	assert.Equal(t, "qxl,memory=32", vm.Display)
+
+	ct := &VM{Type: VMTypeLXC}
+	populateConfigDetails(ct, map[string]interface{}{"cpu": "host"})
+	assert.Empty(t, ct.CPUModel, "containers have no virtual hardware")
+}
+
+func TestMachineTypeDeprecated(t *testing.T) {
+	tests := []struct {
+		machine    string
+		version    string
+		deprecated bool
+	}{
+		{"", "", false},
+		{"q35", "", false},
+		{"pc-q35-8.1", "8.1", false},
+		{"pc-i440fx-4.0+pve1", "4.0", false},
+		{"pc-i440fx-3.1", "3.1", true},
+		{"pc-q35-2.12+pve0", "2.12", true},
+		{"pc-1.3", "1.3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.machine, func(t *testing.T) {
+			version, pinned := MachineVersion(tt.machine)
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.version != "", pinned)
+			assert.Equal(t, tt.deprecated, MachineTypeDeprecated(tt.machine))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+)
+
+// LockSuspended is the lock of a hibernated VM, which starting it resumes.
+const LockSuspended = "suspended"
+
+// lockReasons describe the locks Proxmox sets on guests.
+var lockReasons = map[string]string{
+	"backup":          "a backup is running",
+	"clone":           "the guest is being cloned",
+	"copy":            "the guest is being copied",
+	"create":          "the guest is being created or restored",
+	"destroyed":       "the guest is being destroyed",
+	"disk":            "a disk is being moved",
+	"fstrim":          "a filesystem trim is running",
+	"migrate":         "a migration is running",
+	"mounted":         "the container's volumes are mounted on the node",
+	"rollback":        "a snapshot is being rolled back",
+	"snapshot":        "a snapshot is being taken",
+	"snapshot-delete": "a snapshot is being deleted",
+	"suspended":       "the VM is hibernated; starting it resumes it",
+	"suspending":      "the VM is being hibernated",
+}
+
+// LockReason describes why a guest holds lock.
+func LockReason(lock string) string {
+	if reason, ok := lockReasons[lock]; ok {
+		return reason
+	}
+
+	return "an operation holds the lock"
+}
+
+// CheckGuestLock returns why actions that change a guest are blocked, or
+// nil if the guest isn't locked.
+func CheckGuestLock(vm *VM) error {
+	if vm == nil || vm.Lock == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%s is locked (%s): %s", vm.Name, vm.Lock, LockReason(vm.Lock))
+}
+
+// UnlockVM removes the lock of a guest, like `qm unlock`. Only use it for
+// stale locks left by failed tasks. Proxmox only lets root@pam skip the lock
+// check of QEMU VMs; containers are unlocked on the node with `pct unlock`.
+func (c *Client) UnlockVM(vm *VM) error {
+	path := fmt.Sprintf("/nodes/%s/%s/%d/config", vm.Node, vm.Type, vm.ID)
+	data := map[string]interface{}{"delete": "lock"}
+
+	if vm.Type == VMTypeQemu {
+		data["skiplock"] = 1
+	}
+
+	c.logger.Info("Unlocking %s %s (ID: %d), lock was %q", vm.Type, vm.Name, vm.ID, vm.Lock)
+
+	if err := c.httpClient.Put(c.requestContext(), path, data, nil); err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", vm.Name, err)
+	}
+
+	return nil
+}
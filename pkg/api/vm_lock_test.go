@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckGuestLock(t *testing.T) {
+	assert.NoError(t, CheckGuestLock(&VM{Name: "web"}))
+	assert.EqualError(t, CheckGuestLock(&VM{Name: "web", Lock: "backup"}), "web is locked (backup): a backup is running")
+	assert.Equal(t, "an operation holds the lock", LockReason("unknown"))
+}
+
+func TestClient_UnlockVM(t *testing.T) {
+	var recorder requestRecorder
+
+	client := newTestClient(t, recorder.handler(t, http.MethodPut))
+
+	require.NoError(t, client.UnlockVM(&VM{ID: 100, Node: "pve1", Type: VMTypeQemu, Lock: "backup"}))
+	require.NoError(t, client.UnlockVM(&VM{ID: 105, Node: "pve1", Type: VMTypeLXC, Lock: "mounted"}))
+
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "/nodes/pve1/qemu/100/config", "delete": "lock", "skiplock": float64(1)},
+		{"path": "/nodes/pve1/lxc/105/config", "delete": "lock"},
+	}, recorder.requests)
+}
@@ -1,13 +1,16 @@
 package api
 
 import (
-	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
 
+// snapshotTaskTimeout is how long snapshot operations wait for their task.
+const snapshotTaskTimeout = 2 * time.Minute
+
 // Snapshot represents a Proxmox VM or container snapshot.
 type Snapshot struct {
 	Name        string    `json:"name"`        // Snapshot name
@@ -63,6 +66,47 @@ func (c *Client) GetSnapshots(vm *VM) ([]Snapshot, error) {
 	return snapshots, nil
 }
 
+// GetSnapshotStateSize returns the size in bytes of the RAM state saved with
+// a QEMU snapshot, 0 if it has none. The API doesn't report the disk space
+// held by snapshots themselves.
+func (c *Client) GetSnapshotStateSize(vm *VM, snapshotName string) (int64, error) {
+	if vm.Type != VMTypeQemu {
+		return 0, nil
+	}
+
+	var configResp map[string]interface{}
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/qemu/%d/snapshot/%s/config", vm.Node, vm.ID, url.PathEscape(snapshotName)), &configResp); err != nil {
+		return 0, fmt.Errorf("failed to get snapshot config: %w", err)
+	}
+
+	config, ok := configResp["data"].(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("invalid snapshot config response format")
+	}
+
+	volID := getString(config, "vmstate")
+	if volID == "" {
+		return 0, nil
+	}
+
+	storage := storageFromVolID(volID, "")
+	if storage == "" {
+		return 0, fmt.Errorf("invalid RAM state volume %q", volID)
+	}
+
+	var volumeResp map[string]interface{}
+	if err := c.GetNoRetry(fmt.Sprintf("/nodes/%s/storage/%s/content/%s", vm.Node, url.PathEscape(storage), url.PathEscape(volID)), &volumeResp); err != nil {
+		return 0, fmt.Errorf("failed to get RAM state volume: %w", err)
+	}
+
+	volume, ok := volumeResp["data"].(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("invalid volume response format")
+	}
+
+	return int64(getFloat(volume, "size")), nil
+}
+
 // CreateSnapshot creates a new snapshot for a VM or container.
 func (c *Client) CreateSnapshot(vm *VM, name string, options *SnapshotOptions) error {
 	path := fmt.Sprintf("/nodes/%s/%s/%d/snapshot", vm.Node, vm.Type, vm.ID)
@@ -84,7 +128,7 @@ func (c *Client) CreateSnapshot(vm *VM, name string, options *SnapshotOptions) e
 
 	// Use direct HTTP client to get raw response
 	var result map[string]interface{}
-	err := c.httpClient.Post(context.Background(), path, data, &result)
+	err := c.httpClient.Post(c.requestContext(), path, data, &result)
 	if err != nil {
 		c.logger.Debug("CreateSnapshot HTTP error: %v", err)
 		// Check if the error contains the response body
@@ -121,7 +165,11 @@ func (c *Client) CreateSnapshot(vm *VM, name string, options *SnapshotOptions) e
 	if upid, ok := result["data"].(string); ok && strings.HasPrefix(upid, "UPID:") {
 		c.logger.Debug("CreateSnapshot task queued with UPID: %s", upid)
 		// Poll for task completion
-		return c.waitForTaskCompletion(upid, "snapshot creation")
+		if err := c.WaitForTask(upid, snapshotTaskTimeout, nil); err != nil {
+			return fmt.Errorf("snapshot creation failed: %w", err)
+		}
+
+		return nil
 	}
 
 	// Check if the response contains error messages in the data field
@@ -140,47 +188,6 @@ func (c *Client) CreateSnapshot(vm *VM, name string, options *SnapshotOptions) e
 	return nil
 }
 
-// waitForTaskCompletion polls for task completion and returns an error if the task failed.
-func (c *Client) waitForTaskCompletion(upid string, operationName string) error {
-	c.logger.Debug("Waiting for task completion: %s", upid)
-
-	// Poll for up to 2 minutes
-	maxWait := 2 * time.Minute
-	pollInterval := 2 * time.Second
-	start := time.Now()
-
-	for time.Since(start) < maxWait {
-		tasks, err := c.GetClusterTasks()
-		if err != nil {
-			c.logger.Debug("Failed to get cluster tasks: %v", err)
-			time.Sleep(pollInterval)
-			continue
-		}
-
-		// Find our task
-		for _, task := range tasks {
-			if task.UPID == upid {
-				c.logger.Debug("Found task %s, status: %s", upid, task.Status)
-
-				// Check if task is complete
-				if task.Status == "OK" {
-					c.logger.Debug("Task %s completed successfully", upid)
-					return nil
-				} else if task.Status == "ERROR" || strings.Contains(task.Status, "error") || strings.Contains(task.Status, "not available") {
-					c.logger.Debug("Task %s failed with status: %s", upid, task.Status)
-					return fmt.Errorf("%s failed: %s", operationName, task.Status)
-				}
-				// Task is still running, continue polling
-				break
-			}
-		}
-
-		time.Sleep(pollInterval)
-	}
-
-	return fmt.Errorf("%s timed out waiting for task %s", operationName, upid)
-}
-
 // DeleteSnapshot deletes a snapshot from a VM or container.
 func (c *Client) DeleteSnapshot(vm *VM, snapshotName string) error {
 	path := fmt.Sprintf("/nodes/%s/%s/%d/snapshot/%s", vm.Node, vm.Type, vm.ID, snapshotName)
@@ -188,7 +195,7 @@ func (c *Client) DeleteSnapshot(vm *VM, snapshotName string) error {
 	c.logger.Info("Deleting snapshot '%s' from %s %s (ID: %d)", snapshotName, vm.Type, vm.Name, vm.ID)
 
 	var result map[string]interface{}
-	err := c.httpClient.Delete(context.Background(), path, &result)
+	err := c.httpClient.Delete(c.requestContext(), path, &result)
 	if err != nil {
 		// Check if the error contains the response body
 		if strings.Contains(err.Error(), "failed to parse response JSON") {
@@ -215,7 +222,11 @@ func (c *Client) DeleteSnapshot(vm *VM, snapshotName string) error {
 	if upid, ok := result["data"].(string); ok && strings.HasPrefix(upid, "UPID:") {
 		c.logger.Debug("DeleteSnapshot task queued with UPID: %s", upid)
 		// Poll for task completion
-		return c.waitForTaskCompletion(upid, "snapshot deletion")
+		if err := c.WaitForTask(upid, snapshotTaskTimeout, nil); err != nil {
+			return fmt.Errorf("snapshot deletion failed: %w", err)
+		}
+
+		return nil
 	}
 
 	// Check if the response contains error messages in the data field
@@ -240,7 +251,7 @@ func (c *Client) RollbackToSnapshot(vm *VM, snapshotName string) error {
 	c.logger.Info("Rolling back %s %s (ID: %d) to snapshot '%s'", vm.Type, vm.Name, vm.ID, snapshotName)
 
 	var result map[string]interface{}
-	err := c.httpClient.Post(context.Background(), path, nil, &result)
+	err := c.httpClient.Post(c.requestContext(), path, nil, &result)
 	if err != nil {
 		// Check if the error contains the response body
 		if strings.Contains(err.Error(), "failed to parse response JSON") {
@@ -267,7 +278,11 @@ func (c *Client) RollbackToSnapshot(vm *VM, snapshotName string) error {
 	if upid, ok := result["data"].(string); ok && strings.HasPrefix(upid, "UPID:") {
 		c.logger.Debug("RollbackToSnapshot task queued with UPID: %s", upid)
 		// Poll for task completion
-		return c.waitForTaskCompletion(upid, "snapshot rollback")
+		if err := c.WaitForTask(upid, snapshotTaskTimeout, nil); err != nil {
+			return fmt.Errorf("snapshot rollback failed: %w", err)
+		}
+
+		return nil
 	}
 
 	// Check if the response contains error messages in the data field
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetSnapshotStateSize(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/nodes/pve1/qemu/100/snapshot/with-ram/config":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"vmstate": "local-lvm:vm-100-state-with-ram", "memory": 4096}})
+		case "/nodes/pve1/qemu/100/snapshot/disk-only/config":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"memory": 4096}})
+		case "/nodes/pve1/storage/local-lvm/content/local-lvm:vm-100-state-with-ram":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"size": 8592031744, "format": "raw"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	vm := &VM{ID: 100, Node: "pve1", Type: VMTypeQemu}
+
+	size, err := client.GetSnapshotStateSize(vm, "with-ram")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8592031744), size)
+
+	size, err = client.GetSnapshotStateSize(vm, "disk-only")
+	require.NoError(t, err)
+	assert.Zero(t, size)
+
+	// Containers have no RAM state; no request is made
+	size, err = client.GetSnapshotStateSize(&VM{ID: 105, Node: "pve1", Type: VMTypeLXC}, "any")
+	require.NoError(t, err)
+	assert.Zero(t, size)
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MoveDiskOptions contains options for moving a guest disk or volume to another storage.
+type MoveDiskOptions struct {
+	// Disk is the disk (QEMU, e.g. "scsi0") or volume (LXC, e.g. "rootfs", "mp0") to move.
+	Disk string `json:"disk"`
+
+	// Storage is the target storage ID.
+	Storage string `json:"storage"`
+
+	// Format optionally sets the target image format for QEMU disks (raw, qcow2, vmdk).
+	// Ignored for LXC volumes.
+	Format string `json:"format,omitempty"`
+
+	// Delete removes the source disk after a successful copy. When false the
+	// source is kept as an unused disk.
+	Delete bool `json:"delete,omitempty"`
+
+	// BandwidthLimit limits the I/O bandwidth in KiB/s. A value of 0 means no limit.
+	BandwidthLimit int `json:"bwlimit,omitempty"`
+}
+
+// MoveDisk moves a QEMU VM disk to a different storage. It returns the UPID
+// of the move task.
+func (c *Client) MoveDisk(vm *VM, options *MoveDiskOptions) (string, error) {
+	if vm.Type != VMTypeQemu {
+		return "", fmt.Errorf("move disk is only supported for QEMU VMs")
+	}
+
+	if err := validateMoveDiskOptions(options); err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{
+		"disk":    options.Disk,
+		"storage": options.Storage,
+	}
+
+	if options.Format != "" {
+		data["format"] = options.Format
+	}
+
+	return c.postMoveRequest(vm, "move_disk", data, options)
+}
+
+// MoveVolume moves an LXC container volume (rootfs or mount point) to a
+// different storage. Proxmox only moves the volumes of a stopped container.
+// It returns the UPID of the move task.
+func (c *Client) MoveVolume(vm *VM, options *MoveDiskOptions) (string, error) {
+	if vm.Type != VMTypeLXC {
+		return "", fmt.Errorf("move volume is only supported for LXC containers")
+	}
+
+	if vm.Status != VMStatusStopped {
+		return "", fmt.Errorf("%s must be stopped before moving its volumes", vm.Name)
+	}
+
+	if err := validateMoveDiskOptions(options); err != nil {
+		return "", err
+	}
+
+	data := map[string]interface{}{
+		"volume":  options.Disk,
+		"storage": options.Storage,
+	}
+
+	return c.postMoveRequest(vm, "move_volume", data, options)
+}
+
+// validateMoveDiskOptions checks that the required move parameters are present.
+func validateMoveDiskOptions(options *MoveDiskOptions) error {
+	if options == nil || options.Disk == "" {
+		return fmt.Errorf("disk is required")
+	}
+
+	if options.Storage == "" {
+		return fmt.Errorf("target storage is required")
+	}
+
+	return nil
+}
+
+// postMoveRequest adds the shared move parameters and issues the request, returning the task UPID.
+func (c *Client) postMoveRequest(vm *VM, endpoint string, data map[string]interface{}, options *MoveDiskOptions) (string, error) {
+	if options.Delete {
+		data["delete"] = "1"
+	}
+
+	if options.BandwidthLimit > 0 {
+		data["bwlimit"] = options.BandwidthLimit
+	}
+
+	path := fmt.Sprintf("/nodes/%s/%s/%d/%s", vm.Node, vm.Type, vm.ID, endpoint)
+
+	c.logger.Info("Moving %s of %s %s (ID: %d) to storage %s", options.Disk, vm.Type, vm.Name, vm.ID, options.Storage)
+
+	var result map[string]interface{}
+	if err := c.PostWithResponse(path, data, &result); err != nil {
+		return "", fmt.Errorf("failed to move %s: %w", options.Disk, err)
+	}
+
+	upid, ok := result["data"].(string)
+	if !ok || !strings.HasPrefix(upid, "UPID:") {
+		return "", fmt.Errorf("unexpected response when moving %s", options.Disk)
+	}
+
+	return upid, nil
+}
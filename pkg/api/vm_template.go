@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// templateTaskTimeout is how long a template conversion waits for its task.
+const templateTaskTimeout = 5 * time.Minute
+
+// CheckTemplateConversion returns why a guest can't be converted to a
+// template, or nil if it can. The guest must be stopped, not already a
+// template, and not managed by HA.
+func CheckTemplateConversion(vm *VM) error {
+	if vm == nil {
+		return fmt.Errorf("no guest selected")
+	}
+
+	if vm.Template {
+		return fmt.Errorf("%s is already a template", vm.Name)
+	}
+
+	if vm.Status != VMStatusStopped {
+		return fmt.Errorf("%s must be stopped before converting it to a template", vm.Name)
+	}
+
+	if vm.HAState != "" {
+		return fmt.Errorf("%s is managed by HA (%s); remove it from HA first", vm.Name, vm.HAState)
+	}
+
+	return nil
+}
+
+// ConvertToTemplate converts a stopped VM or container to a template and
+// waits for the conversion to finish. Containers with snapshots are
+// rejected, as their volumes can't be converted to base volumes.
+func (c *Client) ConvertToTemplate(vm *VM) error {
+	if err := CheckTemplateConversion(vm); err != nil {
+		return err
+	}
+
+	if vm.Type == VMTypeLXC {
+		snapshots, err := c.GetSnapshots(vm)
+		if err != nil {
+			return err
+		}
+
+		for _, snapshot := range snapshots {
+			// "current" is the live state, not a snapshot
+			if snapshot.Name != "current" {
+				return fmt.Errorf("%s has snapshots; delete them before converting it to a template", vm.Name)
+			}
+		}
+	}
+
+	path := fmt.Sprintf("/nodes/%s/%s/%d/template", vm.Node, vm.Type, vm.ID)
+
+	c.logger.Info("Converting %s %s (ID: %d) to a template", vm.Type, vm.Name, vm.ID)
+
+	var result map[string]interface{}
+	if err := c.PostWithResponse(path, nil, &result); err != nil {
+		return fmt.Errorf("failed to convert to template: %w", err)
+	}
+
+	// QEMU converts the disks in a task, containers finish right away
+	if upid, ok := result["data"].(string); ok && strings.HasPrefix(upid, "UPID:") {
+		if err := c.WaitForTask(upid, templateTaskTimeout, nil); err != nil {
+			return fmt.Errorf("failed to convert to template: %w", err)
+		}
+	}
+
+	return nil
+}
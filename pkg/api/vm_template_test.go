@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTemplateConversion(t *testing.T) {
+	tests := []struct {
+		name    string
+		vm      *VM
+		wantErr string
+	}{
+		{"stopped guest", &VM{Name: "web", Status: VMStatusStopped}, ""},
+		{"running guest", &VM{Name: "web", Status: VMStatusRunning}, "must be stopped"},
+		{"template", &VM{Name: "web", Status: VMStatusStopped, Template: true}, "already a template"},
+		{"HA managed", &VM{Name: "web", Status: VMStatusStopped, HAState: "stopped"}, "managed by HA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTemplateConversion(tt.vm)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_ConvertToTemplate(t *testing.T) {
+	var (
+		snapshots []map[string]interface{}
+		converted []string
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/nodes/pve1/lxc/105/snapshot":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": snapshots})
+		case r.Method == http.MethodPost && r.URL.Path == "/nodes/pve1/lxc/105/template":
+			converted = append(converted, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": nil})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ct := &VM{ID: 105, Name: "dns", Node: "pve1", Type: VMTypeLXC, Status: VMStatusStopped}
+
+	snapshots = []map[string]interface{}{{"name": "before-upgrade"}, {"name": "current"}}
+	assert.ErrorContains(t, client.ConvertToTemplate(ct), "has snapshots")
+	assert.Empty(t, converted)
+
+	snapshots = []map[string]interface{}{{"name": "current"}}
+	require.NoError(t, client.ConvertToTemplate(ct))
+	assert.Equal(t, []string{"/nodes/pve1/lxc/105/template"}, converted)
+}
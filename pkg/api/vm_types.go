@@ -43,6 +43,7 @@ type VM struct {
 
 	// Runtime resource usage metrics
 	CPU       float64 `json:"cpu,omitempty"`       // CPU usage as percentage (0.0-1.0)
+	MaxCPU    int     `json:"maxcpu,omitempty"`    // Number of virtual CPUs
 	Mem       int64   `json:"mem,omitempty"`       // Current memory usage in bytes
 	MaxMem    int64   `json:"maxmem,omitempty"`    // Maximum memory allocation in bytes
 	Disk      int64   `json:"disk,omitempty"`      // Current disk usage in bytes
@@ -78,12 +79,64 @@ type VM struct {
 	Description        string              `json:"description,omitempty"`         // VM description
 	OnBoot             bool                `json:"onboot,omitempty"`              // Whether VM starts automatically
 
+	// Virtual hardware from config endpoint (QEMU VMs only)
+	MachineType string `json:"machine,omitempty"`   // Machine type, e.g. "q35" or "pc-i440fx-8.1"; empty for the default i440fx
+	CPUModel    string `json:"cpu_model,omitempty"` // Emulated CPU type, e.g. "host" or "x86-64-v2-AES"
+	BIOS        string `json:"bios,omitempty"`      // Firmware: "seabios" or "ovmf" (UEFI); empty for the default SeaBIOS
+	Display     string `json:"vga,omitempty"`       // Display settings, e.g. "std" or "qxl,memory=32"
+
 	// Internal fields for concurrency and state management
 	mu                sync.RWMutex // Protects concurrent access to VM data
 	Enriched          bool         `json:"-"` // Whether VM has been enriched with detailed information
 	guestAgentChecked bool         // internal: true if guest agent API was already called this cycle
 }
 
+// carryOverDetails copies the configuration and guest agent data of a
+// previous load of the same guest to vm, freshly loaded from cluster
+// resources, and reports whether it did: previous has to be enriched and the
+// guest running in both loads without restarting in between.
+func (vm *VM) carryOverDetails(previous *VM) bool {
+	previous.mu.RLock()
+	defer previous.mu.RUnlock()
+
+	if !previous.Enriched || previous.Status != VMStatusRunning || vm.Status != VMStatusRunning || vm.Uptime < previous.Uptime {
+		return false
+	}
+
+	if vm.IP == "" {
+		vm.IP = previous.IP
+	}
+
+	// Disk usage from the guest agent is more accurate than that of cluster
+	// resources, which is zero for QEMU guests
+	if len(previous.Filesystems) > 0 {
+		vm.Disk = previous.Disk
+		vm.MaxDisk = previous.MaxDisk
+	}
+
+	vm.AgentEnabled = previous.AgentEnabled
+	vm.AgentRunning = previous.AgentRunning
+	vm.NetInterfaces = previous.NetInterfaces
+	vm.Filesystems = previous.Filesystems
+	vm.ConfiguredMACs = previous.ConfiguredMACs
+	vm.ConfiguredNetworks = previous.ConfiguredNetworks
+	vm.StorageDevices = previous.StorageDevices
+	vm.BootOrder = previous.BootOrder
+	vm.CPUCores = previous.CPUCores
+	vm.CPUSockets = previous.CPUSockets
+	vm.Architecture = previous.Architecture
+	vm.OSType = previous.OSType
+	vm.Description = previous.Description
+	vm.OnBoot = previous.OnBoot
+	vm.MachineType = previous.MachineType
+	vm.CPUModel = previous.CPUModel
+	vm.BIOS = previous.BIOS
+	vm.Display = previous.Display
+	vm.Enriched = true
+
+	return true
+}
+
 // ConfiguredNetwork represents a network interface configuration from VM config endpoint.
 //
 // This struct contains the network configuration as defined in the VM's configuration,
@@ -122,6 +175,7 @@ type ConfiguredNetwork struct {
 type StorageDevice struct {
 	Device    string `json:"device"`              // Device identifier (scsi0, ide0, virtio0, rootfs, mp0, etc.)
 	Storage   string `json:"storage"`             // Storage pool name or device path
+	VolID     string `json:"volid,omitempty"`     // Volume ID or path as configured (e.g., "local-lvm:vm-100-disk-0")
 	Size      string `json:"size,omitempty"`      // Size specification (e.g., "32G", "500G")
 	Format    string `json:"format,omitempty"`    // Storage format (raw, qcow2, vmdk, etc.)
 	Cache     string `json:"cache,omitempty"`     // Cache mode (none, writethrough, writeback, etc.)
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateWebUIURL returns the Proxmox web UI URL deep-linked to the given
+// guest, e.g. "https://pve:8006/#v1:0:=qemu%2F104".
+func (c *Client) GenerateWebUIURL(vm *VM) string {
+	resourceType := "qemu"
+	if vm.Type == VMTypeLXC {
+		resourceType = "lxc"
+	}
+
+	return webUIURL(c.baseURL, fmt.Sprintf("%s/%d", resourceType, vm.ID))
+}
+
+// GenerateNodeWebUIURL returns the Proxmox web UI URL deep-linked to the given node.
+func (c *Client) GenerateNodeWebUIURL(nodeName string) string {
+	return webUIURL(c.baseURL, "node/"+nodeName)
+}
+
+// webUIURL builds a web UI URL that selects the resource with the given ID
+// ("qemu/100", "lxc/101", "node/pve1") in the resource tree.
+func webUIURL(baseURL, resourceID string) string {
+	serverURL := strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api2/json")
+
+	return fmt.Sprintf("%s/#v1:0:=%s", serverURL, strings.ReplaceAll(resourceID, "/", "%2F"))
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateWebUIURL(t *testing.T) {
+	client := &Client{baseURL: "https://pve.example.com:8006"}
+
+	assert.Equal(t, "https://pve.example.com:8006/#v1:0:=qemu%2F104",
+		client.GenerateWebUIURL(&VM{ID: 104, Type: VMTypeQemu}))
+	assert.Equal(t, "https://pve.example.com:8006/#v1:0:=lxc%2F200",
+		client.GenerateWebUIURL(&VM{ID: 200, Type: VMTypeLXC}))
+	assert.Equal(t, "https://pve.example.com:8006/#v1:0:=node%2Fpve1",
+		client.GenerateNodeWebUIURL("pve1"))
+}
+
+func TestWebUIURL_TrimsAPIPath(t *testing.T) {
+	assert.Equal(t, "https://pve:8006/#v1:0:=qemu%2F100", webUIURL("https://pve:8006/api2/json", "qemu/100"))
+}
+
+func TestWebUIURL_KeepsPathPrefix(t *testing.T) {
+	assert.Equal(t, "https://proxy.example.com/proxmox/#v1:0:=node%2Fpve1",
+		webUIURL("https://proxy.example.com/proxmox", "node/pve1"))
+}
@@ -0,0 +1,139 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a single plugin request.
+const DefaultTimeout = 10 * time.Second
+
+// Plugin is a loaded plugin executable.
+type Plugin struct {
+	Path     string
+	Args     []string
+	Timeout  time.Duration
+	Manifest Manifest
+}
+
+// Load runs the describe request of the plugin at path and returns the
+// plugin with its manifest.
+func Load(ctx context.Context, path string, args []string, timeout time.Duration) (*Plugin, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	p := &Plugin{Path: path, Args: args, Timeout: timeout}
+
+	resp, err := p.call(ctx, Request{Method: MethodDescribe})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Manifest == nil {
+		return nil, fmt.Errorf("plugin %s: describe returned no manifest", path)
+	}
+
+	if resp.Manifest.Version != ProtocolVersion {
+		return nil, fmt.Errorf("plugin %s: unsupported protocol version %d (expected %d)", path, resp.Manifest.Version, ProtocolVersion)
+	}
+
+	if resp.Manifest.Name == "" {
+		return nil, fmt.Errorf("plugin %s: manifest has no name", path)
+	}
+
+	p.Manifest = *resp.Manifest
+
+	return p, nil
+}
+
+// Name returns the name of the plugin from its manifest.
+func (p *Plugin) Name() string {
+	return p.Manifest.Name
+}
+
+// Action runs an action for a guest and returns its message.
+func (p *Plugin) Action(ctx context.Context, id string, guest Guest) (string, error) {
+	resp, err := p.call(ctx, Request{Method: MethodAction, ID: id, Guest: &guest})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Message, nil
+}
+
+// Section returns the rows of a section for a guest.
+func (p *Plugin) Section(ctx context.Context, id string, guest Guest) ([]Row, error) {
+	resp, err := p.call(ctx, Request{Method: MethodSection, ID: id, Guest: &guest})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Rows, nil
+}
+
+// Columns returns the column values of the guests, keyed by Guest.Key.
+func (p *Plugin) Columns(ctx context.Context, guests []Guest) (map[string]map[string]string, error) {
+	resp, err := p.call(ctx, Request{Method: MethodColumns, Guests: guests})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Columns, nil
+}
+
+// call runs the plugin with a request and decodes its response.
+func (p *Plugin) call(ctx context.Context, req Request) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, p.Path, p.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("plugin %s: %s timed out after %s", p.label(), req.Method, p.Timeout)
+		}
+
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", p.label(), err, detail)
+		}
+
+		return nil, fmt.Errorf("plugin %s: %w", p.label(), err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s: invalid response: %w", p.label(), err)
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", p.label(), resp.Error)
+	}
+
+	return &resp, nil
+}
+
+// label names the plugin in errors.
+func (p *Plugin) label() string {
+	if p.Manifest.Name != "" {
+		return p.Manifest.Name
+	}
+
+	return p.Path
+}
@@ -0,0 +1,138 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHandler is the plugin run by the helper process.
+type testHandler struct{}
+
+func (testHandler) Describe() Manifest {
+	return Manifest{
+		Name:     "test",
+		Actions:  []Action{{ID: "greet", Label: "Greet"}},
+		Sections: []Section{{ID: "info", Title: "Info"}},
+		Columns:  []Column{{ID: "owner", Title: "owner"}},
+	}
+}
+
+func (testHandler) Action(id string, guest Guest) (string, error) {
+	if id == "fail" {
+		return "", errors.New("action failed")
+	}
+
+	return "hello " + guest.Name, nil
+}
+
+func (testHandler) Section(_ string, guest Guest) ([]Row, error) {
+	return []Row{{Label: "Node", Value: guest.Node}}, nil
+}
+
+func (testHandler) Columns(guests []Guest) (map[string]map[string]string, error) {
+	columns := make(map[string]map[string]string)
+	for _, guest := range guests {
+		columns[guest.Key()] = map[string]string{"owner": "ops"}
+	}
+
+	return columns, nil
+}
+
+// TestHelperPlugin is run as the plugin executable by the host tests.
+func TestHelperPlugin(t *testing.T) {
+	switch os.Getenv("PVETUI_TEST_PLUGIN") {
+	case "":
+		t.Skip("helper process")
+	case "serve":
+		Serve(testHandler{})
+	case "sleep":
+		time.Sleep(5 * time.Second)
+	case "garbage":
+		_, _ = os.Stdout.WriteString("not json")
+	}
+
+	os.Exit(0)
+}
+
+// helperPlugin loads the test binary as a plugin in the given mode.
+func helperPlugin(t *testing.T, mode string) (*Plugin, error) {
+	t.Setenv("PVETUI_TEST_PLUGIN", mode)
+
+	return Load(context.Background(), os.Args[0], []string{"-test.run=^TestHelperPlugin$"}, 2*time.Second)
+}
+
+func TestServeIO(t *testing.T) {
+	var out bytes.Buffer
+
+	err := ServeIO(testHandler{}, strings.NewReader(`{"method":"describe"}`), &out)
+	require.NoError(t, err)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+	require.NotNil(t, resp.Manifest)
+	assert.Equal(t, "test", resp.Manifest.Name)
+	assert.Equal(t, ProtocolVersion, resp.Manifest.Version)
+
+	t.Run("handler error", func(t *testing.T) {
+		out.Reset()
+		require.NoError(t, ServeIO(testHandler{}, strings.NewReader(`{"method":"action","id":"fail","guest":{"vmid":100}}`), &out))
+		assert.Contains(t, out.String(), `"error":"action failed"`)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		out.Reset()
+		require.NoError(t, ServeIO(testHandler{}, strings.NewReader(`{"method":"explode"}`), &out))
+		assert.Contains(t, out.String(), "unsupported method")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		assert.Error(t, ServeIO(testHandler{}, strings.NewReader(`{`), &out))
+	})
+}
+
+func TestPlugin(t *testing.T) {
+	p, err := helperPlugin(t, "serve")
+	require.NoError(t, err)
+	assert.Equal(t, "test", p.Name())
+	assert.Len(t, p.Manifest.Actions, 1)
+
+	guest := Guest{ID: 101, Name: "web", Node: "pve1"}
+
+	message, err := p.Action(context.Background(), "greet", guest)
+	require.NoError(t, err)
+	assert.Equal(t, "hello web", message)
+
+	_, err = p.Action(context.Background(), "fail", guest)
+	assert.ErrorContains(t, err, "plugin test: action failed")
+
+	rows, err := p.Section(context.Background(), "info", guest)
+	require.NoError(t, err)
+	assert.Equal(t, []Row{{Label: "Node", Value: "pve1"}}, rows)
+
+	columns, err := p.Columns(context.Background(), []Guest{guest})
+	require.NoError(t, err)
+	assert.Equal(t, "ops", columns["pve1/101"]["owner"])
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := helperPlugin(t, "garbage")
+	assert.ErrorContains(t, err, "invalid response")
+
+	_, err = Load(context.Background(), "/nonexistent/pvetui-plugin", nil, time.Second)
+	assert.Error(t, err)
+
+	p := &Plugin{Path: os.Args[0], Args: []string{"-test.run=^TestHelperPlugin$"}, Timeout: 100 * time.Millisecond}
+	t.Setenv("PVETUI_TEST_PLUGIN", "sleep")
+
+	_, err = p.Columns(context.Background(), nil)
+	assert.ErrorContains(t, err, "timed out")
+}
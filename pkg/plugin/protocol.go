@@ -0,0 +1,112 @@
+// Package plugin implements the pvetui plugin protocol.
+//
+// A plugin is an executable that pvetui runs once per request. The request
+// is written to its standard input as a single JSON object and the plugin
+// answers with a single JSON object on standard output; anything written to
+// standard error is included in error messages. Keeping plugins in separate
+// processes means they can be written in any language and pvetui stays free
+// of cgo and dynamic loading.
+//
+// On startup pvetui sends a "describe" request and the plugin returns a
+// Manifest listing what it contributes:
+//
+//   - actions, shown in the guest context menu and run for the selected guest
+//   - sections, shown in the guest details panel and loaded when expanded
+//   - columns, short values shown next to every guest in the guest list
+//
+// Go plugins can use Serve to implement the protocol.
+package plugin
+
+import "strconv"
+
+// ProtocolVersion is the version of the protocol spoken by pvetui. Plugins
+// return the version they implement in their manifest.
+const ProtocolVersion = 1
+
+// Request methods.
+const (
+	MethodDescribe = "describe" // Return the manifest
+	MethodAction   = "action"   // Run an action for Guest
+	MethodSection  = "section"  // Return the rows of a section for Guest
+	MethodColumns  = "columns"  // Return the column values of Guests
+)
+
+// Request is sent to the plugin on standard input.
+type Request struct {
+	Method string `json:"method"`
+	// ID is the action or section ID for the action and section methods.
+	ID string `json:"id,omitempty"`
+	// Guest is the selected guest for the action and section methods.
+	Guest *Guest `json:"guest,omitempty"`
+	// Guests are all listed guests for the columns method.
+	Guests []Guest `json:"guests,omitempty"`
+}
+
+// Response is returned by the plugin on standard output. Only the field of
+// the requested method is used; Error reports a failure of any request.
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	Manifest *Manifest `json:"manifest,omitempty"`
+	// Message summarizes the result of an action.
+	Message string `json:"message,omitempty"`
+	// Rows are the rows of a section.
+	Rows []Row `json:"rows,omitempty"`
+	// Columns maps guest keys (see Guest.Key) to column IDs to values.
+	Columns map[string]map[string]string `json:"columns,omitempty"`
+}
+
+// Manifest describes what a plugin contributes.
+type Manifest struct {
+	Name     string    `json:"name"`
+	Version  int       `json:"protocol_version"`
+	Actions  []Action  `json:"actions,omitempty"`
+	Sections []Section `json:"sections,omitempty"`
+	Columns  []Column  `json:"columns,omitempty"`
+}
+
+// Action is a guest context menu entry.
+type Action struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Section is a collapsible section of the guest details panel.
+type Section struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Column is a value shown next to guests in the guest list.
+type Column struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Row is a label and value pair of a section.
+type Row struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Guest describes a guest to the plugin.
+type Guest struct {
+	ID     int    `json:"vmid"`
+	Name   string `json:"name"`
+	Node   string `json:"node"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	IP     string `json:"ip,omitempty"`
+	Tags   string `json:"tags,omitempty"`
+	Uptime int64  `json:"uptime,omitempty"`
+}
+
+// Key identifies the guest in column responses.
+func (g Guest) Key() string {
+	return GuestKey(g.Node, g.ID)
+}
+
+// GuestKey returns the key of the guest with the given node and VMID.
+func GuestKey(node string, vmid int) string {
+	return node + "/" + strconv.Itoa(vmid)
+}
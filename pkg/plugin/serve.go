@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Handler implements a plugin for Serve.
+type Handler interface {
+	// Describe returns the manifest of the plugin.
+	Describe() Manifest
+	// Action runs the action with the given ID and returns a short message.
+	Action(id string, guest Guest) (string, error)
+	// Section returns the rows of the section with the given ID.
+	Section(id string, guest Guest) ([]Row, error)
+	// Columns returns the column values of the guests, keyed by Guest.Key.
+	Columns(guests []Guest) (map[string]map[string]string, error)
+}
+
+// Serve answers the request on standard input and exits the process with a
+// non-zero status if it can't be answered. Plugins call it from main:
+//
+//	func main() {
+//		plugin.Serve(myHandler{})
+//	}
+func Serve(h Handler) {
+	if err := ServeIO(h, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// ServeIO answers a single request read from r by writing the response to w.
+// Handler errors are returned to pvetui in the response; only protocol
+// errors are returned.
+func ServeIO(h Handler, r io.Reader, w io.Writer) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	var (
+		resp Response
+		err  error
+	)
+
+	switch req.Method {
+	case MethodDescribe:
+		manifest := h.Describe()
+		if manifest.Version == 0 {
+			manifest.Version = ProtocolVersion
+		}
+
+		resp.Manifest = &manifest
+	case MethodAction:
+		if req.Guest == nil {
+			return fmt.Errorf("action request without guest")
+		}
+
+		resp.Message, err = h.Action(req.ID, *req.Guest)
+	case MethodSection:
+		if req.Guest == nil {
+			return fmt.Errorf("section request without guest")
+		}
+
+		resp.Rows, err = h.Section(req.ID, *req.Guest)
+	case MethodColumns:
+		resp.Columns, err = h.Columns(req.Guests)
+	default:
+		err = fmt.Errorf("unsupported method %q", req.Method)
+	}
+
+	if err != nil {
+		resp = Response{Error: err.Error()}
+	}
+
+	return json.NewEncoder(w).Encode(resp)
+}
@@ -271,8 +271,8 @@ func TestAPIClientIntegration_ErrorScenarios(t *testing.T) {
 			api.WithCache(testCache))
 
 		// Should fail with some kind of network/DNS error
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "authentication failed")
+		assert.ErrorIs(t, err, api.ErrConnection)
+		assert.NotErrorIs(t, err, api.ErrAuthFailed)
 	})
 
 	t.Run("invalid_credentials", func(t *testing.T) {